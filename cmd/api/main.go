@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -17,6 +20,7 @@ import (
 	"whisko-petcare/internal/domain/event"
 	"whisko-petcare/internal/infrastructure/bus"
 	"whisko-petcare/internal/infrastructure/cloudinary"
+	"whisko-petcare/internal/infrastructure/gateway"
 	httpHandler "whisko-petcare/internal/infrastructure/http"
 	"whisko-petcare/internal/infrastructure/mongo"
 	"whisko-petcare/internal/infrastructure/payos"
@@ -33,6 +37,9 @@ func main() {
 		log.Println("Warning: .env file not found or could not be loaded")
 	}
 
+	// APP_ENV=development relaxes checks meant for deployed servers, such as required signing secrets
+	appEnv := getEnv("APP_ENV", "production")
+
 	log.Println("Starting Whisko Pet Care API (Event Sourcing)...")
 
 	mongoConfig := &mongo.MongoConfig{
@@ -90,6 +97,12 @@ func main() {
 		log.Fatal("Failed to initialize PayOS service:", err)
 	}
 
+	// Payment gateways available to customers; vendors choose which of these they accept
+	paymentGateways := gateway.NewRegistry(
+		gateway.NewPayOSGateway(payOSService),
+		gateway.NewCashGateway(),
+	)
+
 	// Initialize PayOS Payout Service for real bank transfers to vendors
 	payoutConfig := &payos.PayoutConfig{
 		ClientID:    getEnv("PAYOS_PAYOUT_CLIENT_ID", ""),
@@ -126,6 +139,13 @@ func main() {
 	// Initialize projections
 	paymentProjection := projection.NewMongoPaymentProjection(database)
 	petProjection := projection.NewMongoPetProjection(database)
+
+	// Pets created before birth dates and weight history existed get them from their stored age and weight
+	if migrated, err := petProjection.MigrateLegacyProfiles(context.Background()); err != nil {
+		log.Printf("⚠️  Warning: failed to migrate legacy pet profiles: %v", err)
+	} else if migrated > 0 {
+		log.Printf("Migrated %d legacy pet profile(s)", migrated)
+	}
 	vendorProjection := projection.NewMongoVendorProjection(database)
 	serviceProjection := projection.NewMongoServiceProjection(database)
 	scheduleProjection := projection.NewMongoScheduleProjection(database)
@@ -191,6 +211,31 @@ func main() {
 			return paymentProjection.HandlePaymentStatusChanged(ctx, e.(*event.PaymentStatusChanged))
 		}))
 
+	eventBus.Subscribe("PaymentCollected", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return paymentProjection.HandlePaymentCollected(ctx, e.(*event.PaymentCollected))
+		}))
+
+	eventBus.Subscribe("PaymentRefundRequested", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return paymentProjection.HandlePaymentRefundRequested(ctx, e.(*event.PaymentRefundRequested))
+		}))
+
+	eventBus.Subscribe("PaymentRefunded", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return paymentProjection.HandlePaymentRefunded(ctx, e.(*event.PaymentRefunded))
+		}))
+
+	eventBus.Subscribe("PaymentDiscountApplied", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return paymentProjection.HandlePaymentDiscountApplied(ctx, e.(*event.PaymentDiscountApplied))
+		}))
+
+	eventBus.Subscribe("PaymentAmountAdjusted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return paymentProjection.HandlePaymentAmountAdjusted(ctx, e.(*event.PaymentAmountAdjusted))
+		}))
+
 	// Subscribe pet projection to events
 	eventBus.Subscribe("PetCreated", bus.EventHandlerFunc(
 		func(ctx context.Context, e event.DomainEvent) error {
@@ -227,6 +272,116 @@ func main() {
 			return petProjection.HandlePetAllergyRemoved(ctx, e.(*event.PetAllergyRemoved))
 		}))
 
+	eventBus.Subscribe("PetVaccinationUpdated", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetVaccinationUpdated(ctx, e.(*event.PetVaccinationUpdated))
+		}))
+
+	eventBus.Subscribe("PetVaccinationVoided", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetVaccinationVoided(ctx, e.(*event.PetVaccinationVoided))
+		}))
+
+	eventBus.Subscribe("PetMedicalRecordUpdated", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetMedicalRecordUpdated(ctx, e.(*event.PetMedicalRecordUpdated))
+		}))
+
+	eventBus.Subscribe("PetMedicalRecordVoided", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetMedicalRecordVoided(ctx, e.(*event.PetMedicalRecordVoided))
+		}))
+
+	eventBus.Subscribe("PetAllergyUpdated", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetAllergyUpdated(ctx, e.(*event.PetAllergyUpdated))
+		}))
+
+	eventBus.Subscribe("PetBirthDateSet", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetBirthDateSet(ctx, e.(*event.PetBirthDateSet))
+		}))
+
+	eventBus.Subscribe("PetWeightRecorded", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetWeightRecorded(ctx, e.(*event.PetWeightRecorded))
+		}))
+
+	eventBus.Subscribe("PetGuardianInvited", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetGuardianInvited(ctx, e.(*event.PetGuardianInvited))
+		}))
+
+	eventBus.Subscribe("PetGuardianInvitationAccepted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetGuardianInvitationAccepted(ctx, e.(*event.PetGuardianInvitationAccepted))
+		}))
+
+	eventBus.Subscribe("PetGuardianInvitationDeclined", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetGuardianInvitationDeclined(ctx, e.(*event.PetGuardianInvitationDeclined))
+		}))
+
+	eventBus.Subscribe("PetGuardianInvitationCancelled", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetGuardianInvitationCancelled(ctx, e.(*event.PetGuardianInvitationCancelled))
+		}))
+
+	eventBus.Subscribe("PetGuardianRemoved", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetGuardianRemoved(ctx, e.(*event.PetGuardianRemoved))
+		}))
+
+	eventBus.Subscribe("PetOwnershipTransferRequested", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetOwnershipTransferRequested(ctx, e.(*event.PetOwnershipTransferRequested))
+		}))
+
+	eventBus.Subscribe("PetOwnershipTransferred", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetOwnershipTransferred(ctx, e.(*event.PetOwnershipTransferred))
+		}))
+
+	eventBus.Subscribe("PetOwnershipTransferDeclined", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetOwnershipTransferDeclined(ctx, e.(*event.PetOwnershipTransferDeclined))
+		}))
+
+	eventBus.Subscribe("PetOwnershipTransferCancelled", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetOwnershipTransferCancelled(ctx, e.(*event.PetOwnershipTransferCancelled))
+		}))
+
+	eventBus.Subscribe("PetMedicationPlanAdded", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetMedicationPlanAdded(ctx, e.(*event.PetMedicationPlanAdded))
+		}))
+
+	eventBus.Subscribe("PetMedicationPlanStopped", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetMedicationPlanStopped(ctx, e.(*event.PetMedicationPlanStopped))
+		}))
+
+	eventBus.Subscribe("PetMedicationDoseLogged", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetMedicationDoseLogged(ctx, e.(*event.PetMedicationDoseLogged))
+		}))
+
+	eventBus.Subscribe("PetAttachmentAdded", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetAttachmentAdded(ctx, e.(*event.PetAttachmentAdded))
+		}))
+
+	eventBus.Subscribe("PetAttachmentRemoved", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetAttachmentRemoved(ctx, e.(*event.PetAttachmentRemoved))
+		}))
+
+	eventBus.Subscribe("PetCareNotesUpdated", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petProjection.HandlePetCareNotesUpdated(ctx, e.(*event.PetCareNotesUpdated))
+		}))
+
 	eventBus.Subscribe("PetImageUpdated", bus.EventHandlerFunc(
 		func(ctx context.Context, e event.DomainEvent) error {
 			return petProjection.HandlePetImageUpdated(ctx, e.(*event.PetImageUpdated))
@@ -274,6 +429,11 @@ func main() {
 			return serviceProjection.HandleServiceImageUpdated(ctx, e.(*event.ServiceImageUpdated))
 		}))
 
+	eventBus.Subscribe("ServiceEligibilityUpdated", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return serviceProjection.HandleServiceEligibilityUpdated(ctx, e.(*event.ServiceEligibilityUpdated))
+		}))
+
 	// Subscribe schedule projection to events
 	eventBus.Subscribe("ScheduleCreated", bus.EventHandlerFunc(
 		func(ctx context.Context, e event.DomainEvent) error {
@@ -295,6 +455,61 @@ func main() {
 			return scheduleProjection.HandleScheduleCancelled(ctx, *e.(*event.ScheduleCancelled))
 		}))
 
+	eventBus.Subscribe("ScheduleCareBriefShared", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleCareBriefShared(ctx, *e.(*event.ScheduleCareBriefShared))
+		}))
+
+	eventBus.Subscribe("ScheduleCareBriefRevoked", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleCareBriefRevoked(ctx, *e.(*event.ScheduleCareBriefRevoked))
+		}))
+
+	eventBus.Subscribe("ScheduleVisitReportSubmitted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleVisitReportSubmitted(ctx, *e.(*event.ScheduleVisitReportSubmitted))
+		}))
+
+	eventBus.Subscribe("ScheduleVisitReportAccepted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleVisitReportAccepted(ctx, *e.(*event.ScheduleVisitReportAccepted))
+		}))
+
+	eventBus.Subscribe("ScheduleRescheduled", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleRescheduled(ctx, *e.(*event.ScheduleRescheduled))
+		}))
+
+	eventBus.Subscribe("ScheduleRescheduleProposed", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleRescheduleProposed(ctx, *e.(*event.ScheduleRescheduleProposed))
+		}))
+
+	eventBus.Subscribe("ScheduleRescheduleDeclined", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleRescheduleDeclined(ctx, *e.(*event.ScheduleRescheduleDeclined))
+		}))
+
+	eventBus.Subscribe("ScheduleRescheduleReverted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleRescheduleReverted(ctx, *e.(*event.ScheduleRescheduleReverted))
+		}))
+
+	eventBus.Subscribe("SchedulePriceAdjusted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleSchedulePriceAdjusted(ctx, *e.(*event.SchedulePriceAdjusted))
+		}))
+
+	eventBus.Subscribe("ScheduleStaffAssigned", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleScheduleStaffAssigned(ctx, *e.(*event.ScheduleStaffAssigned))
+		}))
+
+	eventBus.Subscribe("SchedulePetCancelled", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return scheduleProjection.HandleSchedulePetCancelled(ctx, *e.(*event.SchedulePetCancelled))
+		}))
+
 	// Subscribe vendor staff projection to events
 	eventBus.Subscribe("VendorStaffCreated", bus.EventHandlerFunc(
 		func(ctx context.Context, e event.DomainEvent) error {
@@ -311,6 +526,53 @@ func main() {
 			return vendorStaffProjection.HandleVendorStaffDeleted(ctx, *e.(*event.VendorStaffDeleted))
 		}))
 
+	// Subscribe ledger to money movement events
+	if err := mongo.EnsureLedgerIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	if err := mongo.EnsureEventStreamIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	ledgerService := services.NewLedgerService(uowFactory)
+
+	eventBus.Subscribe("PaymentStatusChanged", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return ledgerService.HandlePaymentStatusChanged(ctx, e.(*event.PaymentStatusChanged))
+		}))
+
+	eventBus.Subscribe("PaymentRefunded", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return ledgerService.HandlePaymentRefunded(ctx, e.(*event.PaymentRefunded))
+		}))
+
+	eventBus.Subscribe("SettlementPaidOut", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return ledgerService.HandleSettlementPaidOut(ctx, e.(*event.SettlementPaidOut))
+		}))
+
+	eventBus.Subscribe("PayoutCompleted", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return ledgerService.HandlePayoutCompleted(ctx, e.(*event.PayoutCompleted))
+		}))
+
+	// Coupon redemptions are given back with the cancelled or expired payment itself
+	if err := mongo.EnsurePromotionIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+
+	// Issue invoices for paid bookings (booking prices include VAT at INVOICE_VAT_PERCENT)
+	invoiceVATPercent, err := strconv.Atoi(getEnv("INVOICE_VAT_PERCENT", "0"))
+	if err != nil || invoiceVATPercent < 0 || invoiceVATPercent > 100 {
+		log.Printf("Invalid INVOICE_VAT_PERCENT, using default 0: %v", err)
+		invoiceVATPercent = 0
+	}
+	invoiceService := services.NewInvoiceService(uowFactory, paymentProjection, mongo.NewMongoSequence(database), invoiceVATPercent)
+
+	eventBus.Subscribe("PaymentStatusChanged", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return invoiceService.HandlePaymentStatusChanged(ctx, e.(*event.PaymentStatusChanged))
+		}))
+
 	// Initialize Unit of Work command handlers
 	createUserHandler := command.NewCreateUserWithUoWHandler(uowFactory, eventBus)
 	updateUserProfileHandler := command.NewUpdateUserProfileWithUoWHandler(uowFactory, eventBus)
@@ -328,10 +590,31 @@ func main() {
 	// Initialize schedule command handlers FIRST (needed by payment confirmation)
 	createScheduleHandler := command.NewCreateScheduleWithUoWHandler(uowFactory, eventBus)
 
+	// Recurring booking occurrences are booked when their upfront payment is confirmed and ahead of time afterwards
+	generateSeriesHandler := command.NewGenerateSeriesOccurrencesWithUoWHandler(uowFactory, eventBus, paymentGateways)
+	createBookingSeriesHandler := command.NewCreateBookingSeriesWithUoWHandler(uowFactory, eventBus, paymentGateways, generateSeriesHandler)
+	skipSeriesOccurrenceHandler := command.NewSkipSeriesOccurrenceWithUoWHandler(uowFactory, eventBus, paymentGateways)
+	modifySeriesOccurrenceHandler := command.NewModifySeriesOccurrenceWithUoWHandler(uowFactory, eventBus, generateSeriesHandler)
+	cancelBookingSeriesHandler := command.NewCancelBookingSeriesWithUoWHandler(uowFactory, eventBus, paymentGateways)
+
 	// Initialize payment command handlers with UoW
-	createPaymentHandler := command.NewCreatePaymentWithUoWHandler(uowFactory, eventBus, payOSService)
-	cancelPaymentHandler := command.NewCancelPaymentWithUoWHandler(uowFactory, eventBus, payOSService)
-	confirmPaymentHandler := command.NewConfirmPaymentWithUoWHandler(uowFactory, eventBus, payOSService, payoutService, createScheduleHandler)
+	createPaymentHandler := command.NewCreatePaymentWithUoWHandler(uowFactory, eventBus, paymentGateways, createScheduleHandler)
+	cancelPaymentHandler := command.NewCancelPaymentWithUoWHandler(uowFactory, eventBus, paymentGateways)
+	confirmPaymentHandler := command.NewConfirmPaymentWithUoWHandler(uowFactory, eventBus, paymentGateways, createScheduleHandler, generateSeriesHandler)
+	collectPaymentHandler := command.NewMarkPaymentCollectedWithUoWHandler(uowFactory, eventBus)
+	refundPaymentHandler := command.NewRefundPaymentWithUoWHandler(uowFactory, eventBus, paymentGateways)
+
+	// Initialize promotion command handlers
+	createPromotionHandler := command.NewCreatePromotionWithUoWHandler(uowFactory, eventBus)
+	deactivatePromotionHandler := command.NewDeactivatePromotionWithUoWHandler(uowFactory, eventBus)
+
+	// Initialize species catalogue command handlers
+	createSpeciesHandler := command.NewCreateSpeciesWithUoWHandler(uowFactory, eventBus)
+	updateSpeciesHandler := command.NewUpdateSpeciesWithUoWHandler(uowFactory, eventBus)
+	deactivateSpeciesHandler := command.NewDeactivateSpeciesWithUoWHandler(uowFactory, eventBus)
+	addSpeciesBreedHandler := command.NewAddSpeciesBreedWithUoWHandler(uowFactory, eventBus)
+	updateSpeciesBreedHandler := command.NewUpdateSpeciesBreedWithUoWHandler(uowFactory, eventBus)
+	removeSpeciesBreedHandler := command.NewRemoveSpeciesBreedWithUoWHandler(uowFactory, eventBus)
 	
 	// Initialize payment query handlers
 	getPaymentHandler := query.NewGetPaymentHandler(paymentProjection)
@@ -347,11 +630,35 @@ func main() {
 	addPetMedicalRecordHandler := command.NewAddPetMedicalRecordWithUoWHandler(uowFactory, eventBus)
 	addPetAllergyHandler := command.NewAddPetAllergyWithUoWHandler(uowFactory, eventBus)
 	removePetAllergyHandler := command.NewRemovePetAllergyWithUoWHandler(uowFactory, eventBus)
+	updatePetVaccinationHandler := command.NewUpdatePetVaccinationWithUoWHandler(uowFactory, eventBus)
+	voidPetVaccinationHandler := command.NewVoidPetVaccinationWithUoWHandler(uowFactory, eventBus)
+	updatePetMedicalRecordHandler := command.NewUpdatePetMedicalRecordWithUoWHandler(uowFactory, eventBus)
+	voidPetMedicalRecordHandler := command.NewVoidPetMedicalRecordWithUoWHandler(uowFactory, eventBus)
+	updatePetAllergyHandler := command.NewUpdatePetAllergyWithUoWHandler(uowFactory, eventBus)
+	recordPetWeightHandler := command.NewRecordPetWeightWithUoWHandler(uowFactory, eventBus)
+
+	// Initialize pet guardian command handlers
+	invitePetGuardianHandler := command.NewInvitePetGuardianWithUoWHandler(uowFactory, eventBus)
+	acceptPetGuardianInvitationHandler := command.NewAcceptPetGuardianInvitationWithUoWHandler(uowFactory, eventBus)
+	declinePetGuardianInvitationHandler := command.NewDeclinePetGuardianInvitationWithUoWHandler(uowFactory, eventBus)
+	removePetGuardianHandler := command.NewRemovePetGuardianWithUoWHandler(uowFactory, eventBus)
+	requestPetOwnershipTransferHandler := command.NewRequestPetOwnershipTransferWithUoWHandler(uowFactory, eventBus)
+	acceptPetOwnershipTransferHandler := command.NewAcceptPetOwnershipTransferWithUoWHandler(uowFactory, eventBus)
+	declinePetOwnershipTransferHandler := command.NewDeclinePetOwnershipTransferWithUoWHandler(uowFactory, eventBus)
+	addPetMedicationPlanHandler := command.NewAddPetMedicationPlanWithUoWHandler(uowFactory, eventBus)
+	stopPetMedicationPlanHandler := command.NewStopPetMedicationPlanWithUoWHandler(uowFactory, eventBus)
+	logPetMedicationDoseHandler := command.NewLogPetMedicationDoseWithUoWHandler(uowFactory, eventBus)
+	addPetAttachmentHandler := command.NewAddPetAttachmentWithUoWHandler(uowFactory, eventBus)
+	removePetAttachmentHandler := command.NewRemovePetAttachmentWithUoWHandler(uowFactory, eventBus)
+	updatePetCareNotesHandler := command.NewUpdatePetCareNotesWithUoWHandler(uowFactory, eventBus)
+	shareScheduleCareBriefHandler := command.NewShareScheduleCareBriefWithUoWHandler(uowFactory, eventBus)
+	revokeScheduleCareBriefHandler := command.NewRevokeScheduleCareBriefWithUoWHandler(uowFactory, eventBus)
 
 	// Initialize pet query handlers
 	getPetHandler := query.NewGetPetHandler(petProjection)
 	listUserPetsHandler := query.NewListUserPetsHandler(petProjection)
 	listPetsHandler := query.NewListPetsHandler(petProjection)
+	getPetWeightsHandler := query.NewGetPetWeightHistoryHandler(petProjection)
 
 	// Initialize vendor command handlers
 	createVendorHandler := command.NewCreateVendorWithUoWHandler(uowFactory, eventBus)
@@ -359,6 +666,9 @@ func main() {
 	deleteVendorHandler := command.NewDeleteVendorWithUoWHandler(uowFactory, eventBus)
 	updateVendorImageHandler := command.NewUpdateVendorImageWithUoWHandler(uowFactory, eventBus)
 	updateVendorBankHandler := command.NewUpdateVendorBankAccountWithUoWHandler(uowFactory, eventBus)
+	updateVendorSettlementHandler := command.NewUpdateVendorSettlementSettingsWithUoWHandler(uowFactory, eventBus)
+	updateVendorPaymentMethodsHandler := command.NewUpdateVendorPaymentMethodsWithUoWHandler(uowFactory, eventBus)
+	updateVendorReschedulePolicyHandler := command.NewUpdateVendorReschedulePolicyWithUoWHandler(uowFactory, eventBus)
 
 	// Initialize vendor query handlers
 	getVendorHandler := query.NewGetVendorHandler(vendorProjection)
@@ -369,6 +679,7 @@ func main() {
 	updateServiceHandler := command.NewUpdateServiceWithUoWHandler(uowFactory, eventBus)
 	deleteServiceHandler := command.NewDeleteServiceWithUoWHandler(uowFactory, eventBus)
 	updateServiceImageHandler := command.NewUpdateServiceImageWithUoWHandler(uowFactory, eventBus)
+	updateServiceEligibilityHandler := command.NewUpdateServiceEligibilityWithUoWHandler(uowFactory, eventBus)
 
 	// Initialize service query handlers
 	getServiceHandler := query.NewGetServiceHandler(serviceProjection)
@@ -379,11 +690,19 @@ func main() {
 	changeScheduleStatusHandler := command.NewChangeScheduleStatusWithUoWHandler(uowFactory, eventBus)
 	completeScheduleHandler := command.NewCompleteScheduleWithUoWHandler(uowFactory, eventBus)
 	cancelScheduleHandler := command.NewCancelScheduleWithUoWHandler(uowFactory, eventBus)
+	cancelSchedulePetHandler := command.NewCancelSchedulePetWithUoWHandler(uowFactory, eventBus, paymentGateways)
+	submitVisitReportHandler := command.NewSubmitVisitReportWithUoWHandler(uowFactory, eventBus)
+	acceptVisitReportHandler := command.NewAcceptVisitReportWithUoWHandler(uowFactory, eventBus)
+	rescheduleScheduleHandler := command.NewRescheduleScheduleWithUoWHandler(uowFactory, eventBus, paymentGateways)
+	proposeScheduleRescheduleHandler := command.NewProposeScheduleRescheduleWithUoWHandler(uowFactory, eventBus)
+	respondRescheduleProposalHandler := command.NewRespondRescheduleProposalWithUoWHandler(uowFactory, eventBus, paymentGateways)
+	assignScheduleStaffHandler := command.NewAssignScheduleStaffWithUoWHandler(uowFactory, eventBus)
 
 	// Initialize schedule query handlers
 	getScheduleHandler := query.NewGetScheduleHandler(scheduleProjection)
 	listUserSchedulesHandler := query.NewListUserSchedulesHandler(scheduleProjection)
 	listShopSchedulesHandler := query.NewListShopSchedulesHandler(scheduleProjection)
+	listStaffSchedulesHandler := query.NewListStaffSchedulesHandler(scheduleProjection, vendorStaffProjection)
 	listSchedulesHandler := query.NewListSchedulesHandler(scheduleProjection)
 
 	// Initialize vendor staff command handlers
@@ -418,9 +737,16 @@ func main() {
 		addPetMedicalRecordHandler,
 		addPetAllergyHandler,
 		removePetAllergyHandler,
+		updatePetVaccinationHandler,
+		voidPetVaccinationHandler,
+		updatePetMedicalRecordHandler,
+		voidPetMedicalRecordHandler,
+		updatePetAllergyHandler,
+		recordPetWeightHandler,
 		getPetHandler,
 		listUserPetsHandler,
 		listPetsHandler,
+		getPetWeightsHandler,
 	)
 
 	vendorService := services.NewVendorService(
@@ -429,6 +755,9 @@ func main() {
 		deleteVendorHandler,
 		updateVendorImageHandler,
 		updateVendorBankHandler,
+		updateVendorSettlementHandler,
+		updateVendorPaymentMethodsHandler,
+		updateVendorReschedulePolicyHandler,
 		getVendorHandler,
 		listVendorsHandler,
 	)
@@ -438,6 +767,7 @@ func main() {
 		updateServiceHandler,
 		deleteServiceHandler,
 		updateServiceImageHandler,
+		updateServiceEligibilityHandler,
 		getServiceHandler,
 		listVendorServicesHandler,
 		listServicesHandler,
@@ -448,9 +778,17 @@ func main() {
 		changeScheduleStatusHandler,
 		completeScheduleHandler,
 		cancelScheduleHandler,
+		cancelSchedulePetHandler,
+		submitVisitReportHandler,
+		acceptVisitReportHandler,
+		rescheduleScheduleHandler,
+		proposeScheduleRescheduleHandler,
+		respondRescheduleProposalHandler,
+		assignScheduleStaffHandler,
 		getScheduleHandler,
 		listUserSchedulesHandler,
 		listShopSchedulesHandler,
+		listStaffSchedulesHandler,
 		listSchedulesHandler,
 	)
 
@@ -489,10 +827,12 @@ func main() {
 		createPaymentHandler,
 		cancelPaymentHandler,
 		confirmPaymentHandler,
+		collectPaymentHandler,
+		refundPaymentHandler,
 		getPaymentHandler,
 		getPaymentByOrderCodeHandler,
 		listUserPaymentsHandler,
-		payOSService,
+		paymentGateways,
 	)
 	petController := httpHandler.NewHTTPPetController(petService, cloudinaryService)
 	vendorController := httpHandler.NewVendorController(vendorService, cloudinaryService)
@@ -501,6 +841,225 @@ func main() {
 	vendorStaffController := httpHandler.NewVendorStaffController(vendorStaffService)
 	payoutController := httpHandler.NewHTTPPayoutController(uowFactory, payoutService)
 
+	// Vendor settlements are paid out in periodic batches instead of one transfer per booking
+	commissionPercent, err := strconv.Atoi(getEnv("PLATFORM_COMMISSION_PERCENT", "0"))
+	if err != nil || commissionPercent < 0 || commissionPercent > 100 {
+		log.Printf("Invalid PLATFORM_COMMISSION_PERCENT, using default 0: %v", err)
+		commissionPercent = 0
+	}
+	settlementService := services.NewSettlementService(uowFactory, eventBus, payoutService, commissionPercent)
+	settlementController := httpHandler.NewHTTPSettlementController(uowFactory, settlementService)
+	ledgerController := httpHandler.NewHTTPLedgerController(ledgerService)
+	promotionController := httpHandler.NewHTTPPromotionController(uowFactory, createPromotionHandler, deactivatePromotionHandler)
+	invoiceController := httpHandler.NewHTTPInvoiceController(uowFactory, paymentProjection, invoiceService)
+
+	// Species and breed catalogue that pets are validated against; seeded with dogs and cats when empty
+	if err := mongo.EnsureSpeciesIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	speciesCatalogService := services.NewSpeciesCatalogService(
+		uowFactory,
+		createSpeciesHandler,
+		updateSpeciesHandler,
+		deactivateSpeciesHandler,
+		addSpeciesBreedHandler,
+		updateSpeciesBreedHandler,
+		removeSpeciesBreedHandler,
+	)
+	if err := speciesCatalogService.SeedDefaults(context.Background()); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	speciesController := httpHandler.NewHTTPSpeciesController(speciesCatalogService)
+
+	// Time held for customers while they pay for a booking
+	if err := mongo.EnsureSlotHoldIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+
+	// Recurring bookings
+	if err := mongo.EnsureBookingSeriesIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	bookingSeriesService := services.NewBookingSeriesService(
+		uowFactory,
+		createBookingSeriesHandler,
+		generateSeriesHandler,
+		skipSeriesOccurrenceHandler,
+		modifySeriesOccurrenceHandler,
+		cancelBookingSeriesHandler,
+	)
+	bookingSeriesController := httpHandler.NewHTTPBookingSeriesController(bookingSeriesService)
+
+	// Waitlist for fully booked slots, offered slots are checked out like regular bookings
+	if err := mongo.EnsureWaitlistIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	offerWaitlistSlotHandler := command.NewOfferWaitlistSlotWithUoWHandler(uowFactory, eventBus)
+	waitlistService := services.NewWaitlistService(
+		uowFactory,
+		command.NewJoinWaitlistWithUoWHandler(uowFactory, eventBus),
+		offerWaitlistSlotHandler,
+		command.NewDeclineWaitlistOfferWithUoWHandler(uowFactory, eventBus, offerWaitlistSlotHandler),
+		command.NewLeaveWaitlistWithUoWHandler(uowFactory, eventBus, offerWaitlistSlotHandler),
+		command.NewClaimWaitlistOfferWithUoWHandler(uowFactory, createPaymentHandler),
+	)
+	waitlistController := httpHandler.NewHTTPWaitlistController(waitlistService)
+
+	eventBus.Subscribe("ScheduleCancelled", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return waitlistService.HandleScheduleCancelled(ctx, e.(*event.ScheduleCancelled))
+		}))
+
+	eventBus.Subscribe("ScheduleRescheduled", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return waitlistService.HandleScheduleRescheduled(ctx, e.(*event.ScheduleRescheduled))
+		}))
+
+	// Booking lifecycle: customers are reminded SCHEDULE_REMINDER_LEADS before a confirmed booking, bookings
+	// complete SCHEDULE_AUTO_COMPLETE_AFTER they end unless the shop records a no-show, shops are asked to
+	// confirm bookings pending for SCHEDULE_PENDING_ESCALATE_AFTER, and bookings still pending
+	// SCHEDULE_PENDING_CANCEL_BEFORE they start are cancelled and refunded (SCHEDULE_CANCEL_UNCONFIRMED=false
+	// keeps them). One replica at a time runs the jobs.
+	if err := mongo.EnsureScheduleIndexes(context.Background(), database); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+	scheduleLifecycleService := services.NewScheduleLifecycleService(
+		uowFactory,
+		eventBus,
+		mongo.NewMongoReminderLog(database),
+		mongo.NewMongoJobLease(database),
+		services.ScheduleLifecycleConfig{
+			ReminderLeads:        parseDurations(getEnv("SCHEDULE_REMINDER_LEADS", "24h,2h")),
+			AutoCompleteAfter:    getEnvDuration("SCHEDULE_AUTO_COMPLETE_AFTER", 2*time.Hour),
+			EscalatePendingAfter: getEnvDuration("SCHEDULE_PENDING_ESCALATE_AFTER", 2*time.Hour),
+			CancelUnconfirmed:    getEnv("SCHEDULE_CANCEL_UNCONFIRMED", "true") != "false",
+			CancelPendingBefore:  getEnvDuration("SCHEDULE_PENDING_CANCEL_BEFORE", 1*time.Hour),
+		},
+		command.NewAutoCompleteScheduleWithUoWHandler(uowFactory, eventBus),
+		command.NewCancelUnconfirmedScheduleWithUoWHandler(uowFactory, eventBus, paymentGateways),
+	)
+
+	// Vaccination reminders fire VACCINATION_REMINDER_DAYS days before a vaccination is due and once it is overdue
+	reminderOffsets := parseDayOffsets(getEnv("VACCINATION_REMINDER_DAYS", "14,3"))
+	vaccinationReminderService := services.NewVaccinationReminderService(petProjection, eventBus, mongo.NewMongoReminderLog(database), reminderOffsets)
+
+	// Reminders land in the notification feed of the customer or shop they are for
+	notificationService := services.NewNotificationService(uowFactory, projection.NewMongoNotificationProjection(database))
+	notificationController := httpHandler.NewHTTPNotificationController(notificationService)
+
+	eventBus.Subscribe("VaccinationDueSoon", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return notificationService.HandleVaccinationDueSoon(ctx, e.(*event.VaccinationDueSoon))
+		}))
+
+	eventBus.Subscribe("VaccinationOverdue", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return notificationService.HandleVaccinationOverdue(ctx, e.(*event.VaccinationOverdue))
+		}))
+
+	eventBus.Subscribe("WaitlistSlotOffered", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return notificationService.HandleWaitlistSlotOffered(ctx, e.(*event.WaitlistSlotOffered))
+		}))
+
+	eventBus.Subscribe("ScheduleReminderDue", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return notificationService.HandleScheduleReminderDue(ctx, e.(*event.ScheduleReminderDue))
+		}))
+
+	eventBus.Subscribe("ScheduleConfirmationOverdue", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return notificationService.HandleScheduleConfirmationOverdue(ctx, e.(*event.ScheduleConfirmationOverdue))
+		}))
+
+	// Health record share links are signed with HEALTH_SHARE_LINK_SECRET
+	healthShareService := services.NewPetHealthShareService(
+		petProjection,
+		projection.NewMongoHealthShareLinkProjection(database),
+		requireSecret("HEALTH_SHARE_LINK_SECRET", appEnv),
+		getEnv("PUBLIC_BASE_URL", "http://localhost:8080"),
+	)
+
+	// Links shared by the previous household stop working once a pet is rehomed
+	eventBus.Subscribe("PetOwnershipTransferred", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return healthShareService.HandlePetOwnershipTransferred(ctx, e.(*event.PetOwnershipTransferred))
+		}))
+	petHealthController := httpHandler.NewHTTPPetHealthController(vaccinationReminderService, healthShareService)
+
+	// Calendar feed URLs are signed with CALENDAR_FEED_SECRET; calendars are shown in CALENDAR_TIME_ZONE by default
+	calendarService := services.NewCalendarService(
+		uowFactory,
+		scheduleProjection,
+		projection.NewMongoCalendarFeedProjection(database),
+		requireSecret("CALENDAR_FEED_SECRET", appEnv),
+		getEnv("PUBLIC_BASE_URL", "http://localhost:8080"),
+		getEnv("CALENDAR_TIME_ZONE", "Asia/Ho_Chi_Minh"),
+	)
+	calendarController := httpHandler.NewHTTPCalendarController(calendarService)
+
+	// Pets can be shared with co-owners and caretakers, and handed over to a new owner
+	petGuardianService := services.NewPetGuardianService(
+		petProjection,
+		invitePetGuardianHandler,
+		acceptPetGuardianInvitationHandler,
+		declinePetGuardianInvitationHandler,
+		removePetGuardianHandler,
+		requestPetOwnershipTransferHandler,
+		acceptPetOwnershipTransferHandler,
+		declinePetOwnershipTransferHandler,
+	)
+	petGuardianController := httpHandler.NewHTTPPetGuardianController(petGuardianService)
+
+	// Medication plans; owners are reminded MEDICATION_REMINDER_LEAD before each dose
+	medicationReminderLead, err := time.ParseDuration(getEnv("MEDICATION_REMINDER_LEAD", "30m"))
+	if err != nil || medicationReminderLead <= 0 {
+		log.Printf("Invalid MEDICATION_REMINDER_LEAD, using default 30m: %v", err)
+		medicationReminderLead = 30 * time.Minute
+	}
+	petMedicationService := services.NewPetMedicationService(
+		uowFactory,
+		petProjection,
+		eventBus,
+		mongo.NewMongoReminderLog(database),
+		medicationReminderLead,
+		addPetMedicationPlanHandler,
+		stopPetMedicationPlanHandler,
+		logPetMedicationDoseHandler,
+	)
+	petMedicationController := httpHandler.NewHTTPPetMedicationController(petMedicationService)
+
+	eventBus.Subscribe("MedicationDoseDue", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return notificationService.HandleMedicationDoseDue(ctx, e.(*event.MedicationDoseDue))
+		}))
+
+	// Pet documents are stored through the media service; uploads fail when Cloudinary is not configured
+	var attachmentStorage services.AttachmentStorage
+	if cloudinaryService != nil {
+		attachmentStorage = services.NewCloudinaryAttachmentStorage(cloudinaryService)
+	}
+	petAttachmentService := services.NewPetAttachmentService(petProjection, attachmentStorage, addPetAttachmentHandler, removePetAttachmentHandler)
+
+	// Stored files are deleted once their attachment is removed
+	eventBus.Subscribe("PetAttachmentRemoved", bus.EventHandlerFunc(
+		func(ctx context.Context, e event.DomainEvent) error {
+			return petAttachmentService.HandlePetAttachmentRemoved(ctx, e.(*event.PetAttachmentRemoved))
+		}))
+	petAttachmentController := httpHandler.NewHTTPPetAttachmentController(petAttachmentService)
+
+	// Care briefs: owners share allergies, medication and handling notes with the shop of a booking,
+	// readable by its staff only during the booking and with every access recorded
+	petCareBriefService := services.NewPetCareBriefService(
+		uowFactory,
+		petProjection,
+		projection.NewMongoCareBriefAccessProjection(database),
+		updatePetCareNotesHandler,
+		shareScheduleCareBriefHandler,
+		revokeScheduleCareBriefHandler,
+	)
+	petCareBriefController := httpHandler.NewHTTPPetCareBriefController(petCareBriefService)
+
 	// Setup HTTP routes
 	mux := http.NewServeMux()
 
@@ -518,6 +1077,14 @@ func main() {
 
 	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
 		// Check for nested routes under /users/{userID}/...
+		if strings.HasSuffix(r.URL.Path, "/health/upcoming") && r.Method == http.MethodGet {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petHealthController.GetUserCareDigest)).ServeHTTP(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/pet-invitations") && r.Method == http.MethodGet {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petGuardianController.ListPendingForUser)).ServeHTTP(w, r)
+			return
+		}
 		if strings.Contains(r.URL.Path, "/pets") && r.Method == http.MethodGet {
 			petController.ListUserPets(w, r)
 			return
@@ -624,13 +1191,27 @@ func main() {
 
 		switch r.Method {
 		case http.MethodGet:
-			paymentController.GetPayment(w, r)
+			if strings.HasSuffix(path, "/invoice") {
+				// Customer, vendor staff or admin download the invoice of a paid payment
+				middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(invoiceController.DownloadInvoice)).ServeHTTP(w, r)
+			} else {
+				paymentController.GetPayment(w, r)
+			}
 		case http.MethodPut:
 			if strings.HasSuffix(path, "/cancel") {
 				paymentController.CancelPayment(w, r)
 			} else {
 				w.WriteHeader(http.StatusMethodNotAllowed)
 			}
+		case http.MethodPost:
+			if strings.HasSuffix(path, "/collect") {
+				// Vendor staff confirm a pay at shop payment was collected
+				middleware.JWTAuthMiddleware(jwtManager)(middleware.RoleAuthMiddleware("Vendor")(http.HandlerFunc(paymentController.CollectPayment))).ServeHTTP(w, r)
+			} else if strings.HasSuffix(path, "/refund") {
+				middleware.JWTAuthMiddleware(jwtManager)(middleware.RoleAuthMiddleware("Admin")(http.HandlerFunc(paymentController.RefundPayment))).ServeHTTP(w, r)
+			} else {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
 		default:
 			w.WriteHeader(http.StatusMethodNotAllowed)
 		}
@@ -670,6 +1251,16 @@ func main() {
 			return
 		}
 
+		// Check for /payouts/{id}/statement
+		if strings.HasSuffix(path, "/statement") {
+			if r.Method == http.MethodGet {
+				middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(invoiceController.DownloadPayoutStatement)).ServeHTTP(w, r)
+			} else {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		// Check for /payouts/{id}/process
 		if strings.HasSuffix(path, "/process") {
 			if r.Method == http.MethodPost {
@@ -688,6 +1279,23 @@ func main() {
 		}
 	})
 
+	// Settlement routes (vendor staff and admins only)
+	mux.HandleFunc("/settlements/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		// Check for /settlements/vendor/{vendorId}
+		if strings.Contains(r.URL.Path, "/vendor/") {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(settlementController.ListSettlementsByVendor)).ServeHTTP(w, r)
+			return
+		}
+
+		// Default: GET /settlements/{id}
+		middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(settlementController.GetSettlementByID)).ServeHTTP(w, r)
+	})
+
 	// Pet routes
 	mux.HandleFunc("/pets", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -715,16 +1323,152 @@ func main() {
 					return
 				}
 			case "vaccinations":
+				// Corrections record who made them: PUT /pets/{id}/vaccinations/{record_id}, POST .../{record_id}/void
+				if r.Method == http.MethodPut && len(parts) >= 3 {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.UpdatePetVaccination)).ServeHTTP(w, r)
+					return
+				}
+				if r.Method == http.MethodPost && len(parts) >= 4 && parts[3] == "void" {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.VoidPetVaccination)).ServeHTTP(w, r)
+					return
+				}
 				if r.Method == http.MethodPost {
 					petController.AddPetVaccination(w, r)
 					return
 				}
 			case "medical-records":
+				// Corrections record who made them: PUT /pets/{id}/medical-records/{record_id}, POST .../{record_id}/void
+				if r.Method == http.MethodPut && len(parts) >= 3 {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.UpdatePetMedicalRecord)).ServeHTTP(w, r)
+					return
+				}
+				if r.Method == http.MethodPost && len(parts) >= 4 && parts[3] == "void" {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.VoidPetMedicalRecord)).ServeHTTP(w, r)
+					return
+				}
 				if r.Method == http.MethodPost {
 					petController.AddPetMedicalRecord(w, r)
 					return
 				}
+			case "weights":
+				// Handle GET /pets/{id}/weights (guardians only) and POST /pets/{id}/weights
+				if r.Method == http.MethodGet {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.GetPetWeights)).ServeHTTP(w, r)
+					return
+				}
+				if r.Method == http.MethodPost {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.RecordPetWeight)).ServeHTTP(w, r)
+					return
+				}
+			case "health":
+				// Guardians only: GET /pets/{id}/health/upcoming and GET /pets/{id}/health/history
+				if r.Method == http.MethodGet && len(parts) >= 3 && parts[2] == "upcoming" {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petHealthController.GetUpcomingCare)).ServeHTTP(w, r)
+					return
+				}
+				if r.Method == http.MethodGet && len(parts) >= 3 && parts[2] == "history" {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.GetPetHealthHistory)).ServeHTTP(w, r)
+					return
+				}
+				// Owner-only: GET /pets/{id}/health/export, GET|POST /pets/{id}/health/share-links, DELETE .../share-links/{link_id}
+				if r.Method == http.MethodGet && len(parts) >= 3 && parts[2] == "export" {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petHealthController.ExportHealthRecord)).ServeHTTP(w, r)
+					return
+				}
+				if len(parts) >= 3 && parts[2] == "share-links" {
+					switch {
+					case r.Method == http.MethodPost && len(parts) == 3:
+						middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petHealthController.CreateShareLink)).ServeHTTP(w, r)
+						return
+					case r.Method == http.MethodGet && len(parts) == 3:
+						middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petHealthController.ListShareLinks)).ServeHTTP(w, r)
+						return
+					case r.Method == http.MethodDelete && len(parts) >= 4:
+						middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petHealthController.RevokeShareLink)).ServeHTTP(w, r)
+						return
+					}
+				}
+			case "guardians":
+				// Guardians: GET /pets/{id}/guardians, POST .../guardians/invitations, POST .../invitations/{invitation_id}/accept|decline,
+				// DELETE .../invitations/{invitation_id} (owner cancels) and DELETE .../guardians/{user_id}
+				var handler http.HandlerFunc
+				switch {
+				case r.Method == http.MethodGet && len(parts) == 2:
+					handler = petGuardianController.GetGuardians
+				case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "invitations":
+					handler = petGuardianController.InviteGuardian
+				case r.Method == http.MethodPost && len(parts) == 5 && parts[2] == "invitations" && parts[4] == "accept":
+					handler = petGuardianController.AcceptInvitation
+				case r.Method == http.MethodPost && len(parts) == 5 && parts[2] == "invitations" && parts[4] == "decline",
+					r.Method == http.MethodDelete && len(parts) == 4 && parts[2] == "invitations":
+					handler = petGuardianController.DeclineInvitation
+				case r.Method == http.MethodDelete && len(parts) == 3:
+					handler = petGuardianController.RemoveGuardian
+				}
+				if handler != nil {
+					middleware.JWTAuthMiddleware(jwtManager)(handler).ServeHTTP(w, r)
+					return
+				}
+			case "transfer":
+				// Ownership transfer: POST /pets/{id}/transfer, POST .../transfer/accept|decline, DELETE /pets/{id}/transfer (owner cancels)
+				var handler http.HandlerFunc
+				switch {
+				case r.Method == http.MethodPost && len(parts) == 2:
+					handler = petGuardianController.RequestTransfer
+				case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "accept":
+					handler = petGuardianController.AcceptTransfer
+				case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "decline",
+					r.Method == http.MethodDelete && len(parts) == 2:
+					handler = petGuardianController.DeclineTransfer
+				}
+				if handler != nil {
+					middleware.JWTAuthMiddleware(jwtManager)(handler).ServeHTTP(w, r)
+					return
+				}
+			case "attachments":
+				// Documents: GET|POST /pets/{id}/attachments, DELETE /pets/{id}/attachments/{attachment_id}
+				var handler http.HandlerFunc
+				switch {
+				case r.Method == http.MethodGet && len(parts) == 2:
+					handler = petAttachmentController.ListAttachments
+				case r.Method == http.MethodPost && len(parts) == 2:
+					handler = petAttachmentController.UploadAttachment
+				case r.Method == http.MethodDelete && len(parts) == 3:
+					handler = petAttachmentController.RemoveAttachment
+				}
+				if handler != nil {
+					middleware.JWTAuthMiddleware(jwtManager)(handler).ServeHTTP(w, r)
+					return
+				}
+			case "medications":
+				// Medication plans: GET|POST /pets/{id}/medications, POST .../medications/{plan_id}/stop, POST .../medications/{plan_id}/doses
+				var handler http.HandlerFunc
+				switch {
+				case r.Method == http.MethodGet && len(parts) == 2:
+					handler = petMedicationController.ListPlans
+				case r.Method == http.MethodPost && len(parts) == 2:
+					handler = petMedicationController.AddPlan
+				case r.Method == http.MethodPost && len(parts) == 4 && parts[3] == "stop":
+					handler = petMedicationController.StopPlan
+				case r.Method == http.MethodPost && len(parts) == 4 && parts[3] == "doses":
+					handler = petMedicationController.LogDose
+				}
+				if handler != nil {
+					middleware.JWTAuthMiddleware(jwtManager)(handler).ServeHTTP(w, r)
+					return
+				}
+			case "care-notes":
+				// Temperament and special instructions shared in care briefs: PUT /pets/{id}/care-notes
+				if r.Method == http.MethodPut && len(parts) == 2 {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petCareBriefController.UpdateCareNotes)).ServeHTTP(w, r)
+					return
+				}
 			case "allergies":
+				// Handle PUT /pets/{id}/allergies/{allergy_id}
+				if r.Method == http.MethodPut && len(parts) >= 3 {
+					middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petController.UpdatePetAllergy)).ServeHTTP(w, r)
+					return
+				}
 				if r.Method == http.MethodPost {
 					petController.AddPetAllergy(w, r)
 					return
@@ -750,6 +1494,15 @@ func main() {
 		}
 	})
 
+	// Shared health records: GET /shared/health/{token} is public, the signed token is the credential
+	mux.HandleFunc("/shared/health/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		petHealthController.GetSharedHealthRecord(w, r)
+	})
+
 	// Vendor routes
 	mux.HandleFunc("/vendors", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -772,6 +1525,21 @@ func main() {
 			vendorController.UpdateVendorImage(w, r)
 			return
 		}
+		// Check for /vendors/{vendorID}/payment-methods
+		if strings.Contains(r.URL.Path, "/payment-methods") && r.Method == http.MethodPut {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(vendorController.UpdatePaymentMethods)).ServeHTTP(w, r)
+			return
+		}
+		// Check for /vendors/{vendorID}/reschedule-policy
+		if strings.Contains(r.URL.Path, "/reschedule-policy") && r.Method == http.MethodPut {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(vendorController.UpdateReschedulePolicy)).ServeHTTP(w, r)
+			return
+		}
+		// Check for /vendors/{vendorID}/settlement-settings
+		if strings.Contains(r.URL.Path, "/settlement-settings") && r.Method == http.MethodPut {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(vendorController.UpdateSettlementSettings)).ServeHTTP(w, r)
+			return
+		}
 		// Check for /vendors/{vendorID}/bank-account
 		if strings.Contains(r.URL.Path, "/bank-account") && r.Method == http.MethodPut {
 			fmt.Printf("✅ ROUTER: Matched /bank-account route\n")
@@ -819,6 +1587,11 @@ func main() {
 	})
 
 	mux.HandleFunc("/services/", func(w http.ResponseWriter, r *http.Request) {
+		// Check for /services/{id}/eligibility (vendor staff or admin)
+		if strings.HasSuffix(r.URL.Path, "/eligibility") && r.Method == http.MethodPut {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(serviceController.UpdateServiceEligibility)).ServeHTTP(w, r)
+			return
+		}
 		// Check for /services/{id}/image
 		if strings.Contains(r.URL.Path, "/image") && r.Method == http.MethodPut {
 			serviceController.UpdateServiceImage(w, r)
@@ -850,21 +1623,88 @@ func main() {
 	})
 
 	mux.HandleFunc("/schedules/", func(w http.ResponseWriter, r *http.Request) {
+		// Calendar download: GET /schedules/{id}/calendar.ics (customer, shop staff or admin)
+		if strings.HasSuffix(r.URL.Path, "/calendar.ics") && r.Method == http.MethodGet {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(calendarController.GetScheduleCalendar)).ServeHTTP(w, r)
+			return
+		}
 		// Check for /schedules/{id}/status
 		if strings.HasSuffix(r.URL.Path, "/status") && r.Method == http.MethodPut {
-			scheduleController.ChangeScheduleStatus(w, r)
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.ChangeScheduleStatus)).ServeHTTP(w, r)
 			return
 		}
 		// Check for /schedules/{id}/complete
 		if strings.HasSuffix(r.URL.Path, "/complete") && r.Method == http.MethodPost {
-			scheduleController.CompleteSchedule(w, r)
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.CompleteSchedule)).ServeHTTP(w, r)
+			return
+		}
+		// Check for /schedules/{id}/pets/{petID}/cancel before the cancellation of the whole booking
+		if strings.Contains(r.URL.Path, "/pets/") && strings.HasSuffix(r.URL.Path, "/cancel") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.CancelSchedulePet)).ServeHTTP(w, r)
 			return
 		}
 		// Check for /schedules/{id}/cancel
 		if strings.HasSuffix(r.URL.Path, "/cancel") && r.Method == http.MethodPost {
-			scheduleController.CancelSchedule(w, r)
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.CancelSchedule)).ServeHTTP(w, r)
+			return
+		}
+		// Visit report: POST /schedules/{id}/visit-report (shop staff), POST /schedules/{id}/visit-report/accept (owner)
+		if strings.HasSuffix(r.URL.Path, "/visit-report") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.SubmitVisitReport)).ServeHTTP(w, r)
 			return
 		}
+		if strings.HasSuffix(r.URL.Path, "/visit-report/accept") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.AcceptVisitReport)).ServeHTTP(w, r)
+			return
+		}
+		// Staff assignment: POST /schedules/{id}/assign-staff (shop owner, manager or admin)
+		if strings.HasSuffix(r.URL.Path, "/assign-staff") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.AssignStaff)).ServeHTTP(w, r)
+			return
+		}
+		// Reschedule: POST /schedules/{id}/reschedule (customer or admin), POST /schedules/{id}/reschedule-proposal (shop staff),
+		// POST /schedules/{id}/reschedule-proposal/respond (customer)
+		if strings.HasSuffix(r.URL.Path, "/reschedule") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.RescheduleSchedule)).ServeHTTP(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/reschedule-proposal") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.ProposeReschedule)).ServeHTTP(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/reschedule-proposal/respond") && r.Method == http.MethodPost {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.RespondRescheduleProposal)).ServeHTTP(w, r)
+			return
+		}
+		// Medication of the booked pet: GET /schedules/{id}/pet-medications, POST .../pet-medications/{plan_id}/doses
+		if strings.Contains(r.URL.Path, "/pet-medications") {
+			if strings.HasSuffix(r.URL.Path, "/pet-medications") && r.Method == http.MethodGet {
+				middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petMedicationController.GetScheduleMedications)).ServeHTTP(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/doses") && r.Method == http.MethodPost {
+				middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(petMedicationController.LogScheduleDose)).ServeHTTP(w, r)
+				return
+			}
+		}
+		// Care brief of the booked pet: GET /schedules/{id}/care-brief, POST|DELETE .../care-brief/consent, GET .../care-brief/accesses
+		if strings.Contains(r.URL.Path, "/care-brief") {
+			var handler http.HandlerFunc
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/care-brief") && r.Method == http.MethodGet:
+				handler = petCareBriefController.GetCareBrief
+			case strings.HasSuffix(r.URL.Path, "/care-brief/consent") && r.Method == http.MethodPost:
+				handler = petCareBriefController.ShareCareBrief
+			case strings.HasSuffix(r.URL.Path, "/care-brief/consent") && r.Method == http.MethodDelete:
+				handler = petCareBriefController.RevokeCareBrief
+			case strings.HasSuffix(r.URL.Path, "/care-brief/accesses") && r.Method == http.MethodGet:
+				handler = petCareBriefController.ListAccesses
+			}
+			if handler != nil {
+				middleware.JWTAuthMiddleware(jwtManager)(handler).ServeHTTP(w, r)
+				return
+			}
+		}
 		
 		switch r.Method {
 		case http.MethodGet:
@@ -887,6 +1727,12 @@ func main() {
 	})
 
 	mux.HandleFunc("/vendor-staffs/", func(w http.ResponseWriter, r *http.Request) {
+		// Check for /vendor-staffs/{userID}/schedules pattern (staff calendar, requires authentication)
+		if strings.HasSuffix(r.URL.Path, "/schedules") && r.Method == http.MethodGet {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.ListStaffSchedules)).ServeHTTP(w, r)
+			return
+		}
+
 		// Check for /vendor-staffs/user/{userID} pattern
 		if strings.Contains(r.URL.Path, "/vendor-staffs/user/") && r.Method == http.MethodGet {
 			vendorStaffController.ListVendorStaffByUser(w, r)
@@ -919,6 +1765,15 @@ func main() {
 		}
 	})
 
+	// Vendor Staff assignments route: bookings assigned to the current user (requires authentication)
+	mux.HandleFunc("/vendor-staff/schedules", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			middleware.JWTAuthMiddleware(jwtManager)(http.HandlerFunc(scheduleController.ListMySchedules)).ServeHTTP(w, r)
+		} else {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
 	// Auth routes
 	mux.HandleFunc("/auth/register", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
@@ -1018,6 +1873,216 @@ func main() {
 		)).ServeHTTP)
 	log.Println("   GET    /admin/vendors/{vendorID}/dashboard?from_date=YYYY-MM-DD&to_date=YYYY-MM-DD")
 
+	// Admin Settlement route (settle all due vendor periods immediately)
+	mux.HandleFunc("POST /admin/settlements/run", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(settlementController.RunSettlements),
+		)).ServeHTTP)
+	log.Println("   POST   /admin/settlements/run")
+
+	// Admin Ledger routes
+	mux.HandleFunc("GET /admin/ledger/balances", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(ledgerController.GetBalances),
+		)).ServeHTTP)
+	mux.HandleFunc("GET /admin/ledger/vendors/{vendorID}/balance", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(ledgerController.GetVendorBalance),
+		)).ServeHTTP)
+	mux.HandleFunc("GET /admin/ledger/check", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(ledgerController.CheckBalance),
+		)).ServeHTTP)
+	mux.HandleFunc("GET /admin/ledger/entries", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(ledgerController.ListEntries),
+		)).ServeHTTP)
+	mux.HandleFunc("GET /admin/ledger/export", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(ledgerController.ExportEntries),
+		)).ServeHTTP)
+	log.Println("   GET    /admin/ledger/balances")
+	log.Println("   GET    /admin/ledger/vendors/{vendorID}/balance")
+	log.Println("   GET    /admin/ledger/check")
+	log.Println("   GET    /admin/ledger/entries?from_date=YYYY-MM-DD&to_date=YYYY-MM-DD")
+	log.Println("   GET    /admin/ledger/export?from_date=YYYY-MM-DD&to_date=YYYY-MM-DD")
+
+	// Admin Promotion routes
+	mux.HandleFunc("POST /admin/promotions", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(promotionController.CreatePromotion),
+		)).ServeHTTP)
+	mux.HandleFunc("GET /admin/promotions", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(promotionController.ListPromotions),
+		)).ServeHTTP)
+	mux.HandleFunc("GET /admin/promotions/{promotionID}", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(promotionController.GetPromotion),
+		)).ServeHTTP)
+	mux.HandleFunc("PUT /admin/promotions/{promotionID}/deactivate", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(promotionController.DeactivatePromotion),
+		)).ServeHTTP)
+	log.Println("   POST   /admin/promotions")
+	log.Println("   GET    /admin/promotions?offset=0&limit=20")
+	log.Println("   GET    /admin/promotions/{promotionID}")
+	log.Println("   PUT    /admin/promotions/{promotionID}/deactivate")
+
+	// Vendor-funded promotions (vendor staff only)
+	mux.HandleFunc("POST /vendors/{vendorID}/promotions", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Vendor")(
+			http.HandlerFunc(promotionController.CreateVendorPromotion),
+		)).ServeHTTP)
+	log.Println("   POST   /vendors/{vendorID}/promotions")
+
+	// Coupon preview for customers before checkout
+	mux.HandleFunc("POST /promotions/validate", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(promotionController.ValidatePromotion),
+	).ServeHTTP)
+	log.Println("   POST   /promotions/validate")
+
+	// Species and breed catalogue (public reads, admin changes)
+	mux.HandleFunc("GET /species", speciesController.ListSpecies)
+	mux.HandleFunc("GET /species/{speciesID}", speciesController.GetSpecies)
+	mux.HandleFunc("GET /admin/species", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.ListSpecies),
+		)).ServeHTTP)
+	mux.HandleFunc("POST /admin/species", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.CreateSpecies),
+		)).ServeHTTP)
+	mux.HandleFunc("PUT /admin/species/{speciesID}", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.UpdateSpecies),
+		)).ServeHTTP)
+	mux.HandleFunc("DELETE /admin/species/{speciesID}", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.DeactivateSpecies),
+		)).ServeHTTP)
+	mux.HandleFunc("POST /admin/species/{speciesID}/breeds", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.AddBreed),
+		)).ServeHTTP)
+	mux.HandleFunc("PUT /admin/species/{speciesID}/breeds/{breedCode}", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.UpdateBreed),
+		)).ServeHTTP)
+	mux.HandleFunc("DELETE /admin/species/{speciesID}/breeds/{breedCode}", middleware.JWTAuthMiddleware(jwtManager)(
+		middleware.RoleAuthMiddleware("Admin")(
+			http.HandlerFunc(speciesController.RemoveBreed),
+		)).ServeHTTP)
+	log.Println("   GET    /species?lang=en|vi")
+	log.Println("   GET    /species/{speciesID}?lang=en|vi")
+	log.Println("   GET    /admin/species")
+	log.Println("   POST   /admin/species")
+	log.Println("   PUT    /admin/species/{speciesID}")
+	log.Println("   DELETE /admin/species/{speciesID}")
+	log.Println("   POST   /admin/species/{speciesID}/breeds")
+	log.Println("   PUT    /admin/species/{speciesID}/breeds/{breedCode}")
+	log.Println("   DELETE /admin/species/{speciesID}/breeds/{breedCode}")
+
+	// Recurring booking routes (customer who set up the series or admin)
+	mux.HandleFunc("POST /booking-series", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(bookingSeriesController.CreateSeries),
+	).ServeHTTP)
+	mux.HandleFunc("GET /booking-series", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(bookingSeriesController.ListMySeries),
+	).ServeHTTP)
+	mux.HandleFunc("GET /booking-series/{seriesID}", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(bookingSeriesController.GetSeries),
+	).ServeHTTP)
+	mux.HandleFunc("POST /booking-series/{seriesID}/skip", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(bookingSeriesController.SkipOccurrence),
+	).ServeHTTP)
+	mux.HandleFunc("POST /booking-series/{seriesID}/modify", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(bookingSeriesController.ModifyOccurrence),
+	).ServeHTTP)
+	mux.HandleFunc("POST /booking-series/{seriesID}/cancel", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(bookingSeriesController.CancelSeries),
+	).ServeHTTP)
+	log.Println("   POST   /booking-series")
+	log.Println("   GET    /booking-series?offset=0&limit=10")
+	log.Println("   GET    /booking-series/{seriesID}")
+	log.Println("   POST   /booking-series/{seriesID}/skip")
+	log.Println("   POST   /booking-series/{seriesID}/modify")
+	log.Println("   POST   /booking-series/{seriesID}/cancel")
+
+	// Waitlist routes (customer on the waitlist or admin)
+	mux.HandleFunc("POST /waitlist", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(waitlistController.JoinWaitlist),
+	).ServeHTTP)
+	mux.HandleFunc("GET /waitlist", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(waitlistController.ListMyEntries),
+	).ServeHTTP)
+	mux.HandleFunc("GET /waitlist/{entryID}", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(waitlistController.GetEntry),
+	).ServeHTTP)
+	mux.HandleFunc("DELETE /waitlist/{entryID}", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(waitlistController.LeaveWaitlist),
+	).ServeHTTP)
+	mux.HandleFunc("POST /waitlist/{entryID}/decline", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(waitlistController.DeclineOffer),
+	).ServeHTTP)
+	mux.HandleFunc("POST /waitlist/{entryID}/claim", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(waitlistController.ClaimOffer),
+	).ServeHTTP)
+	log.Println("   POST   /waitlist")
+	log.Println("   GET    /waitlist?offset=0&limit=10")
+	log.Println("   GET    /waitlist/{entryID}")
+	log.Println("   DELETE /waitlist/{entryID}")
+	log.Println("   POST   /waitlist/{entryID}/decline")
+	log.Println("   POST   /waitlist/{entryID}/claim")
+
+	// Calendar feed routes: the caller's own bookings, a shop's bookings (shop owner, manager or admin), and the
+	// public feed where the signed token is the credential
+	mux.HandleFunc("POST /calendar-feed", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(calendarController.CreateMyFeed),
+	).ServeHTTP)
+	mux.HandleFunc("GET /calendar-feed", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(calendarController.GetMyFeed),
+	).ServeHTTP)
+	mux.HandleFunc("DELETE /calendar-feed", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(calendarController.RevokeMyFeed),
+	).ServeHTTP)
+	mux.HandleFunc("POST /vendors/{vendorID}/calendar-feed", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(calendarController.CreateVendorFeed),
+	).ServeHTTP)
+	mux.HandleFunc("GET /vendors/{vendorID}/calendar-feed", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(calendarController.GetVendorFeed),
+	).ServeHTTP)
+	mux.HandleFunc("DELETE /vendors/{vendorID}/calendar-feed", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(calendarController.RevokeVendorFeed),
+	).ServeHTTP)
+	mux.HandleFunc("GET /calendar-feeds/{token}", calendarController.GetFeed)
+	log.Println("   POST   /calendar-feed")
+	log.Println("   GET    /calendar-feed")
+	log.Println("   DELETE /calendar-feed")
+	log.Println("   POST   /vendors/{vendorID}/calendar-feed")
+	log.Println("   GET    /vendors/{vendorID}/calendar-feed")
+	log.Println("   DELETE /vendors/{vendorID}/calendar-feed")
+	log.Println("   GET    /calendar-feeds/{token}.ics")
+	log.Println("   GET    /schedules/{id}/calendar.ics")
+
+	// Notification feed routes: the caller's own reminders, and a shop's (shop staff or admin)
+	mux.HandleFunc("GET /notifications", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(notificationController.ListMyNotifications),
+	).ServeHTTP)
+	mux.HandleFunc("POST /notifications/{notificationID}/read", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(notificationController.MarkMyNotificationRead),
+	).ServeHTTP)
+	mux.HandleFunc("GET /vendors/{vendorID}/notifications", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(notificationController.ListVendorNotifications),
+	).ServeHTTP)
+	mux.HandleFunc("POST /vendors/{vendorID}/notifications/{notificationID}/read", middleware.JWTAuthMiddleware(jwtManager)(
+		http.HandlerFunc(notificationController.MarkVendorNotificationRead),
+	).ServeHTTP)
+	log.Println("   GET    /notifications?unread=true&offset=0&limit=20")
+	log.Println("   POST   /notifications/{notificationID}/read")
+	log.Println("   GET    /vendors/{vendorID}/notifications?unread=true&offset=0&limit=20")
+	log.Println("   POST   /vendors/{vendorID}/notifications/{notificationID}/read")
+
 	// Vendor Dashboard route (vendor sees their own data)
 	mux.HandleFunc("GET /vendors/dashboard", middleware.JWTAuthMiddleware(jwtManager)(
 		http.HandlerFunc(vendorDashboardController.GetVendorDashboard),
@@ -1032,9 +2097,33 @@ func main() {
 	})
 
 	// Start payment expiry background service
-	paymentExpiryService := services.NewPaymentExpiryService(uowFactory, eventBus, payOSService)
+	paymentExpiryService := services.NewPaymentExpiryService(uowFactory, eventBus, paymentGateways)
 	go paymentExpiryService.Start(context.Background())
 
+	// Start vendor settlement background service
+	go settlementService.Start(context.Background())
+
+	// Start ledger catch-up background service
+	go ledgerService.Start(context.Background())
+
+	// Start invoice catch-up background service
+	go invoiceService.Start(context.Background())
+
+	// Start vaccination reminder background service
+	go vaccinationReminderService.Start(context.Background())
+
+	// Start medication reminder background service
+	go petMedicationService.Start(context.Background())
+
+	// Start recurring booking background service
+	go bookingSeriesService.Start(context.Background())
+
+	// Start waitlist background service
+	go waitlistService.Start(context.Background())
+
+	// Start booking lifecycle background service
+	go scheduleLifecycleService.Start(context.Background())
+
 	// Start HTTP server
 	go func() {
 		port := getEnv("PORT", "8080")
@@ -1051,6 +2140,14 @@ func main() {
 
 	log.Println("Shutting down server...")
 	paymentExpiryService.Stop()
+	settlementService.Stop()
+	ledgerService.Stop()
+	invoiceService.Stop()
+	vaccinationReminderService.Stop()
+	petMedicationService.Stop()
+	bookingSeriesService.Stop()
+	waitlistService.Stop()
+	scheduleLifecycleService.Stop()
 	eventBus.Stop()
 	log.Println("Server stopped")
 }
@@ -1061,3 +2158,65 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// requireSecret reads a signing secret that is not shared with any other purpose. Outside development the
+// server refuses to start without it; in development a random secret is used, so links signed with it stop
+// working when the server restarts.
+func requireSecret(key, appEnv string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	if appEnv != "development" {
+		log.Fatalf("%s must be set (APP_ENV=%s)", key, appEnv)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate %s: %v", key, err)
+	}
+	log.Printf("Warning: %s is not set, using a random secret until the server restarts", key)
+	return hex.EncodeToString(secret)
+}
+
+// getEnvDuration reads a duration such as "2h" from the environment, using the default when it is unset
+// or not a valid non-negative duration
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("Invalid %s, using default %s: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return duration
+}
+
+// parseDurations parses a comma separated list of positive durations, e.g. "24h,2h"
+func parseDurations(value string) []time.Duration {
+	var durations []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		duration, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || duration <= 0 {
+			log.Printf("Ignoring invalid duration %q", part)
+			continue
+		}
+		durations = append(durations, duration)
+	}
+	return durations
+}
+
+// parseDayOffsets parses a comma separated list of positive day counts, e.g. "14,3"
+func parseDayOffsets(value string) []int {
+	var offsets []int
+	for _, part := range strings.Split(value, ",") {
+		days, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || days <= 0 {
+			log.Printf("Ignoring invalid day offset %q", part)
+			continue
+		}
+		offsets = append(offsets, days)
+	}
+	return offsets
+}
@@ -0,0 +1,899 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/gateway"
+	"whisko-petcare/pkg/errors"
+)
+
+// CreateBookingSeriesWithUoWHandler handles create booking series commands with Unit of Work
+type CreateBookingSeriesWithUoWHandler struct {
+	uowFactory      repository.UnitOfWorkFactory
+	eventBus        bus.EventBus
+	gateways        *gateway.Registry
+	generateHandler *GenerateSeriesOccurrencesWithUoWHandler
+}
+
+// NewCreateBookingSeriesWithUoWHandler creates a new create booking series handler with UoW
+func NewCreateBookingSeriesWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+	generateHandler *GenerateSeriesOccurrencesWithUoWHandler,
+) *CreateBookingSeriesWithUoWHandler {
+	return &CreateBookingSeriesWithUoWHandler{
+		uowFactory:      uowFactory,
+		eventBus:        eventBus,
+		gateways:        gateways,
+		generateHandler: generateHandler,
+	}
+}
+
+// Handle processes the create booking series command. Each occurrence costs what the services cost for
+// the first one. Series paid upfront return the payment to complete; series paid per occurrence book
+// their first occurrences straight away.
+func (h *CreateBookingSeriesWithUoWHandler) Handle(ctx context.Context, cmd *CreateBookingSeries) (*CreateBookingSeriesResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.UserID == "" {
+		return nil, errors.NewUnauthorizedError("user not authenticated")
+	}
+	if cmd.VendorID == "" {
+		return nil, errors.NewValidationError("vendor_id is required")
+	}
+	if cmd.PetID == "" {
+		return nil, errors.NewValidationError("pet_id is required")
+	}
+	if len(cmd.ServiceIDs) == 0 {
+		return nil, errors.NewValidationError("service_ids are required")
+	}
+	if cmd.Recurrence == "" {
+		return nil, errors.NewValidationError("recurrence is required")
+	}
+	startTime, endTime, err := parseRescheduleTimes(cmd.StartTime, cmd.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	recurrence, err := aggregate.ParseRecurrenceRule(cmd.Recurrence)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid recurrence: %v", err))
+	}
+
+	paymentMode := aggregate.SeriesPaymentPerOccurrence
+	if cmd.PaymentMode != "" {
+		paymentMode = aggregate.SeriesPaymentMode(strings.ToUpper(cmd.PaymentMode))
+	}
+	method := aggregate.PaymentMethodPayOS
+	if cmd.Method != "" {
+		method = aggregate.PaymentMethod(strings.ToUpper(cmd.Method))
+	}
+	paymentGateway, err := h.gateways.Get(method)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported payment method: %s", cmd.Method))
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	parties, err := loadBookingParties(ctx, uow, cmd.UserID, cmd.VendorID, cmd.PetID, cmd.ServiceIDs, true)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+	if !parties.vendor.AcceptsPaymentMethod(method) {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("vendor does not accept payment method %s", method))
+	}
+
+	price, err := quoteServices(ctx, uow, cmd.ServiceIDs, startTime, endTime)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	series, err := aggregate.NewBookingSeries(cmd.UserID, cmd.VendorID, cmd.PetID, cmd.ServiceIDs, startTime, endTime,
+		recurrence, paymentMode, method, price)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to create booking series: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := series.GetUncommittedEvents()
+	if err := uow.BookingSeriesRepository().Save(ctx, series); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save booking series: %v", err))
+	}
+
+	response := &CreateBookingSeriesResponse{
+		SeriesID:        series.ID(),
+		Status:          string(series.Status()),
+		OccurrencePrice: series.OccurrencePrice(),
+		OccurrenceCount: series.OccurrenceCount(),
+	}
+
+	// Series paid upfront are booked once the customer has paid for every occurrence
+	if paymentMode == aggregate.SeriesPaymentUpfront {
+		payment, err := aggregate.NewSeriesPayment(
+			cmd.UserID, series.UpfrontAmount(), "Recurring booking",
+			[]aggregate.PaymentItem{{Name: "Recurring booking visit", Quantity: series.OccurrenceCount(), Price: price}},
+			cmd.VendorID, cmd.PetID, cmd.ServiceIDs, startTime, endTime, method, series.ID(),
+		)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewValidationError(fmt.Sprintf("failed to create payment: %v", err))
+		}
+
+		checkout, err := paymentGateway.CreatePayment(ctx, &gateway.CreatePaymentRequest{
+			OrderCode:   payment.OrderCode(),
+			Amount:      payment.Amount(),
+			Description: payment.Description(),
+			Items:       payment.Items(),
+		})
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to create %s payment: %v", method, err))
+		}
+		if checkout.TransactionID != "" || checkout.CheckoutURL != "" {
+			if err := payment.SetPayOSDetails(checkout.TransactionID, checkout.CheckoutURL, checkout.QRCode); err != nil {
+				uow.Rollback(ctx)
+				return nil, errors.NewInternalError(fmt.Sprintf("failed to set payment details: %v", err))
+			}
+		}
+
+		// Get events BEFORE saving (Save will clear them)
+		events = append(events, payment.GetUncommittedEvents()...)
+		if err := uow.PaymentRepository().Save(ctx, payment); err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+		}
+
+		response.UpfrontPayment = &CreatePaymentResponse{
+			PaymentID:   payment.ID(),
+			OrderCode:   payment.OrderCode(),
+			CheckoutURL: checkout.CheckoutURL,
+			QRCode:      checkout.QRCode,
+			Amount:      payment.Amount(),
+			Status:      string(payment.Status()),
+			Method:      string(payment.Method()),
+			ExpiredAt:   payment.ExpiredAt().Format(time.RFC3339),
+		}
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish booking series events: %v\n", err)
+	}
+
+	if paymentMode == aggregate.SeriesPaymentPerOccurrence && h.generateHandler != nil {
+		booked, err := h.generateHandler.Handle(ctx, series.ID())
+		if err != nil {
+			// The background generator books them on its next run
+			fmt.Printf("❌ Failed to book occurrences of series %s: %v\n", series.ID(), err)
+		}
+		response.BookedSchedules = booked
+	}
+
+	return response, nil
+}
+
+// GenerateSeriesOccurrencesWithUoWHandler books the upcoming occurrences of booking series ahead of time
+type GenerateSeriesOccurrencesWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewGenerateSeriesOccurrencesWithUoWHandler creates a new generate series occurrences handler with UoW
+func NewGenerateSeriesOccurrencesWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *GenerateSeriesOccurrencesWithUoWHandler {
+	return &GenerateSeriesOccurrencesWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle books the occurrences of a series starting within the booking horizon as schedules and returns
+// their IDs. Occurrences of a series paid upfront are booked against the upfront payment; occurrences
+// paid one by one get a payment of their own. Occurrences whose time has passed are not booked.
+func (h *GenerateSeriesOccurrencesWithUoWHandler) Handle(ctx context.Context, seriesID string) ([]string, error) {
+	if seriesID == "" {
+		return nil, errors.NewValidationError("series_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	seriesRepo := uow.BookingSeriesRepository()
+	series, err := seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("booking series")
+	}
+	if series.Status() != aggregate.BookingSeriesStatusActive {
+		uow.Rollback(ctx)
+		return nil, nil
+	}
+
+	now := time.Now()
+	until := now.Add(aggregate.SeriesBookingHorizon)
+	plans := series.DueOccurrences(until)
+
+	// Eligibility was checked when the series was set up; the vendor may have changed the rules since
+	var parties *bookingParties
+	if len(plans) > 0 {
+		parties, err = loadBookingParties(ctx, uow, series.UserID(), series.VendorID(), series.PetID(), series.ServiceIDs(), false)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	var events []event.DomainEvent
+	var booked []event.SeriesOccurrence
+	var scheduleIDs []string
+	for _, plan := range plans {
+		if !plan.StartTime.After(now) {
+			continue
+		}
+
+		schedule, err := aggregate.NewSeriesSchedule(parties.bookingUser, parties.bookedVendor, parties.assignedPet,
+			plan.StartTime, plan.EndTime, series.PaymentID(), series.OccurrencePrice(), series.ID())
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewValidationError(fmt.Sprintf("failed to create schedule: %v", err))
+		}
+
+		// Get events BEFORE saving (Save will clear them)
+		events = append(events, schedule.GetUncommittedEvents()...)
+		if err := uow.ScheduleRepository().Save(ctx, schedule); err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+		}
+
+		occurrence := event.SeriesOccurrence{OccurrenceStart: plan.OccurrenceStart, ScheduleID: schedule.ID()}
+		if series.PaymentMode() == aggregate.SeriesPaymentPerOccurrence {
+			payment, paymentEvents, err := h.createOccurrencePayment(ctx, uow, series, schedule)
+			if err != nil {
+				uow.Rollback(ctx)
+				return nil, err
+			}
+			events = append(events, paymentEvents...)
+			occurrence.PaymentID = payment.ID()
+		}
+
+		booked = append(booked, occurrence)
+		scheduleIDs = append(scheduleIDs, schedule.ID())
+	}
+
+	if err := series.RecordBookedOccurrences(booked, until); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to record booked occurrences: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events = append(events, series.GetUncommittedEvents()...)
+	if err := seriesRepo.Save(ctx, series); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save booking series: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish booking series events: %v\n", err)
+	}
+
+	return scheduleIDs, nil
+}
+
+// Activate starts a series paid upfront once its payment is paid and books its first occurrences
+func (h *GenerateSeriesOccurrencesWithUoWHandler) Activate(ctx context.Context, seriesID, paymentID string) ([]string, error) {
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	seriesRepo := uow.BookingSeriesRepository()
+	series, err := seriesRepo.GetByID(ctx, seriesID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("booking series")
+	}
+
+	if err := series.Activate(paymentID); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to activate booking series: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := series.GetUncommittedEvents()
+	if err := seriesRepo.Save(ctx, series); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save booking series: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish booking series events: %v\n", err)
+	}
+
+	return h.Handle(ctx, seriesID)
+}
+
+// createOccurrencePayment creates the payment for one booked occurrence with the series' payment method
+func (h *GenerateSeriesOccurrencesWithUoWHandler) createOccurrencePayment(ctx context.Context, uow repository.UnitOfWork,
+	series *aggregate.BookingSeries, schedule *aggregate.Schedule) (*aggregate.Payment, []event.DomainEvent, error) {
+	payment, err := aggregate.NewOccurrencePayment(
+		series.UserID(), series.OccurrencePrice(), "Recurring booking visit",
+		[]aggregate.PaymentItem{{Name: "Recurring booking visit", Quantity: 1, Price: series.OccurrencePrice()}},
+		series.VendorID(), series.PetID(), series.ServiceIDs(), schedule.StartTime(), schedule.EndTime(),
+		series.Method(), schedule.ID(), series.ID(),
+	)
+	if err != nil {
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("failed to create payment: %v", err))
+	}
+
+	paymentGateway, err := h.gateways.Get(payment.Method())
+	if err != nil {
+		return nil, nil, errors.NewInternalError(err.Error())
+	}
+	checkout, err := paymentGateway.CreatePayment(ctx, &gateway.CreatePaymentRequest{
+		OrderCode:   payment.OrderCode(),
+		Amount:      payment.Amount(),
+		Description: payment.Description(),
+		Items:       payment.Items(),
+	})
+	if err != nil {
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to create %s payment: %v", payment.Method(), err))
+	}
+	if checkout.TransactionID != "" || checkout.CheckoutURL != "" {
+		if err := payment.SetPayOSDetails(checkout.TransactionID, checkout.CheckoutURL, checkout.QRCode); err != nil {
+			return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to set payment details: %v", err))
+		}
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+	if err := uow.PaymentRepository().Save(ctx, payment); err != nil {
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	return payment, events, nil
+}
+
+// SkipSeriesOccurrenceWithUoWHandler handles skip series occurrence commands with Unit of Work
+type SkipSeriesOccurrenceWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewSkipSeriesOccurrenceWithUoWHandler creates a new skip series occurrence handler with UoW
+func NewSkipSeriesOccurrenceWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *SkipSeriesOccurrenceWithUoWHandler {
+	return &SkipSeriesOccurrenceWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle processes the skip series occurrence command. A booked occurrence has its booking cancelled, and
+// its share of an upfront payment is refunded.
+func (h *SkipSeriesOccurrenceWithUoWHandler) Handle(ctx context.Context, cmd *SkipSeriesOccurrence) (*BookingSeriesChangeResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.SeriesID == "" {
+		return nil, errors.NewValidationError("series_id is required")
+	}
+	occurrenceStart, err := parseOccurrenceStart(cmd.OccurrenceStart)
+	if err != nil {
+		return nil, err
+	}
+	reason := cmd.Reason
+	if reason == "" {
+		reason = "Occurrence skipped"
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	seriesRepo := uow.BookingSeriesRepository()
+	series, err := seriesRepo.GetByID(ctx, cmd.SeriesID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("booking series")
+	}
+
+	actor, err := bookingSeriesActor(series, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := series.SkipOccurrence(occurrenceStart, cmd.UserID, reason); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to skip occurrence: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := series.GetUncommittedEvents()
+	if err := seriesRepo.Save(ctx, series); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save booking series: %v", err))
+	}
+
+	response := &BookingSeriesChangeResponse{SeriesID: series.ID(), Status: string(series.Status())}
+	if occurrence, ok := series.BookedOccurrence(occurrenceStart); ok {
+		cancelled, cancelEvents, err := cancelSeriesOccurrence(ctx, uow, h.gateways, occurrence, actor, reason)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+		if !cancelled {
+			uow.Rollback(ctx)
+			return nil, errors.NewConflictError("the booking of this occurrence is already over")
+		}
+		events = append(events, cancelEvents...)
+		response.CancelledSchedules = append(response.CancelledSchedules, occurrence.ScheduleID)
+	}
+
+	if series.PaymentMode() == aggregate.SeriesPaymentUpfront {
+		refunded, refundEvents, err := refundSeriesPayment(ctx, uow, series, series.OccurrencePrice(), reason)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+		events = append(events, refundEvents...)
+		response.RefundedAmount = refunded
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish booking series events: %v\n", err)
+	}
+
+	// The change stands when the gateway fails; the refund stays in progress until it is retried
+	if response.RefundedAmount > 0 {
+		if _, _, err := completePaymentRefund(ctx, h.uowFactory, h.eventBus, h.gateways, series.PaymentID()); err != nil {
+			fmt.Printf("Warning: failed to refund booking series %s: %v\n", series.ID(), err)
+		}
+	}
+
+	return response, nil
+}
+
+// ModifySeriesOccurrenceWithUoWHandler handles modify series occurrence commands with Unit of Work
+type ModifySeriesOccurrenceWithUoWHandler struct {
+	uowFactory      repository.UnitOfWorkFactory
+	eventBus        bus.EventBus
+	generateHandler *GenerateSeriesOccurrencesWithUoWHandler
+}
+
+// NewModifySeriesOccurrenceWithUoWHandler creates a new modify series occurrence handler with UoW
+func NewModifySeriesOccurrenceWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	generateHandler *GenerateSeriesOccurrencesWithUoWHandler,
+) *ModifySeriesOccurrenceWithUoWHandler {
+	return &ModifySeriesOccurrenceWithUoWHandler{
+		uowFactory:      uowFactory,
+		eventBus:        eventBus,
+		generateHandler: generateHandler,
+	}
+}
+
+// Handle processes the modify series occurrence command. The occurrence keeps the series price at its
+// new time; if it is within the booking horizon it is booked straight away.
+func (h *ModifySeriesOccurrenceWithUoWHandler) Handle(ctx context.Context, cmd *ModifySeriesOccurrence) (*BookingSeriesChangeResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.SeriesID == "" {
+		return nil, errors.NewValidationError("series_id is required")
+	}
+	occurrenceStart, err := parseOccurrenceStart(cmd.OccurrenceStart)
+	if err != nil {
+		return nil, err
+	}
+	startTime, endTime, err := parseRescheduleTimes(cmd.StartTime, cmd.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	seriesRepo := uow.BookingSeriesRepository()
+	series, err := seriesRepo.GetByID(ctx, cmd.SeriesID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("booking series")
+	}
+
+	if _, err := bookingSeriesActor(series, cmd.UserID, cmd.IsAdmin); err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := series.ModifyOccurrence(occurrenceStart, startTime, endTime, cmd.UserID, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to modify occurrence: %v", err))
+	}
+
+	if err := checkShopSlot(ctx, uow, series.VendorID(), startTime, endTime, "", ""); err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := series.GetUncommittedEvents()
+	if err := seriesRepo.Save(ctx, series); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save booking series: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish booking series events: %v\n", err)
+	}
+
+	if h.generateHandler != nil {
+		if _, err := h.generateHandler.Handle(ctx, series.ID()); err != nil {
+			fmt.Printf("❌ Failed to book occurrences of series %s: %v\n", series.ID(), err)
+		}
+	}
+
+	return &BookingSeriesChangeResponse{SeriesID: series.ID(), Status: string(series.Status())}, nil
+}
+
+// CancelBookingSeriesWithUoWHandler handles cancel booking series commands with Unit of Work
+type CancelBookingSeriesWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewCancelBookingSeriesWithUoWHandler creates a new cancel booking series handler with UoW
+func NewCancelBookingSeriesWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *CancelBookingSeriesWithUoWHandler {
+	return &CancelBookingSeriesWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle processes the cancel booking series command. Bookings of cancelled occurrences are cancelled, and
+// the part of an upfront payment that covered them is refunded.
+func (h *CancelBookingSeriesWithUoWHandler) Handle(ctx context.Context, cmd *CancelBookingSeries) (*BookingSeriesChangeResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.SeriesID == "" {
+		return nil, errors.NewValidationError("series_id is required")
+	}
+
+	now := time.Now()
+	from := now
+	if cmd.From != "" {
+		parsed, err := time.Parse(time.RFC3339, cmd.From)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid from format: %v", err))
+		}
+		if parsed.After(now) {
+			from = parsed
+		}
+	}
+	reason := cmd.Reason
+	if reason == "" {
+		reason = "Recurring booking cancelled"
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	seriesRepo := uow.BookingSeriesRepository()
+	series, err := seriesRepo.GetByID(ctx, cmd.SeriesID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("booking series")
+	}
+
+	actor, err := bookingSeriesActor(series, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+	if series.Status() == aggregate.BookingSeriesStatusPendingPayment {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError("series is waiting for its upfront payment; cancel the payment instead")
+	}
+
+	// Count what is left to refund before the cancellation cuts the series short
+	refund := 0
+	if series.PaymentMode() == aggregate.SeriesPaymentUpfront {
+		refund = series.OccurrencePrice() * series.RemainingOccurrences(from)
+	}
+
+	if err := series.CancelRemaining(from, cmd.UserID, reason); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to cancel booking series: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := series.GetUncommittedEvents()
+	if err := seriesRepo.Save(ctx, series); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save booking series: %v", err))
+	}
+
+	response := &BookingSeriesChangeResponse{SeriesID: series.ID(), Status: string(series.Status())}
+	for _, occurrence := range series.BookedOccurrences() {
+		if occurrence.OccurrenceStart.Before(from) {
+			continue
+		}
+		cancelled, cancelEvents, err := cancelSeriesOccurrence(ctx, uow, h.gateways, occurrence, actor, reason)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+		events = append(events, cancelEvents...)
+		if cancelled {
+			response.CancelledSchedules = append(response.CancelledSchedules, occurrence.ScheduleID)
+		}
+	}
+
+	if refund > 0 {
+		refunded, refundEvents, err := refundSeriesPayment(ctx, uow, series, refund, reason)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+		events = append(events, refundEvents...)
+		response.RefundedAmount = refunded
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish booking series events: %v\n", err)
+	}
+
+	// The change stands when the gateway fails; the refund stays in progress until it is retried
+	if response.RefundedAmount > 0 {
+		if _, _, err := completePaymentRefund(ctx, h.uowFactory, h.eventBus, h.gateways, series.PaymentID()); err != nil {
+			fmt.Printf("Warning: failed to refund booking series %s: %v\n", series.ID(), err)
+		}
+	}
+
+	return response, nil
+}
+
+// ReleaseUnpaidSeriesPayment undoes what a payment of a recurring booking held once the payment is cancelled
+// or expires, within the caller's unit of work: a series waiting for its upfront payment is cancelled, and
+// the booking of an occurrence waiting for its payment is cancelled. Other payments are left alone.
+func ReleaseUnpaidSeriesPayment(ctx context.Context, uow repository.UnitOfWork, payment *aggregate.Payment, reason string) ([]event.DomainEvent, error) {
+	if payment.SeriesID() == "" {
+		return nil, nil
+	}
+
+	if payment.IsSeriesUpfront() {
+		seriesRepo := uow.BookingSeriesRepository()
+		series, err := seriesRepo.GetByID(ctx, payment.SeriesID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get booking series: %w", err)
+		}
+		if series.Status() != aggregate.BookingSeriesStatusPendingPayment {
+			return nil, nil
+		}
+		if err := series.CancelRemaining(time.Now(), string(aggregate.ScheduleActorSystem), reason); err != nil {
+			return nil, fmt.Errorf("failed to cancel booking series: %w", err)
+		}
+
+		// Get events BEFORE saving (Save will clear them)
+		events := series.GetUncommittedEvents()
+		if err := seriesRepo.Save(ctx, series); err != nil {
+			return nil, fmt.Errorf("failed to save booking series: %w", err)
+		}
+		return events, nil
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, payment.ScheduleID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	if schedule.Status().IsFinal() {
+		return nil, nil
+	}
+	if err := schedule.Cancel(reason, aggregate.ScheduleActorSystem); err != nil {
+		return nil, fmt.Errorf("failed to cancel schedule: %w", err)
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := schedule.GetUncommittedEvents()
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %w", err)
+	}
+	return events, nil
+}
+
+// bookingSeriesActor resolves the role under which a user changes a booking series: admin, or the customer
+// who set it up
+func bookingSeriesActor(series *aggregate.BookingSeries, userID string, isAdmin bool) (aggregate.ScheduleActor, error) {
+	if isAdmin {
+		return aggregate.ScheduleActorAdmin, nil
+	}
+	if userID == "" {
+		return "", errors.NewUnauthorizedError("user not authenticated")
+	}
+	if userID != series.UserID() {
+		return "", errors.NewForbiddenError("you cannot change this booking series")
+	}
+	return aggregate.ScheduleActorCustomer, nil
+}
+
+// cancelSeriesOccurrence cancels the booking of an occurrence and the payment it is still waiting for,
+// within the caller's unit of work. Bookings that are already over or cancelled are left alone.
+func cancelSeriesOccurrence(ctx context.Context, uow repository.UnitOfWork, gateways *gateway.Registry,
+	occurrence event.SeriesOccurrence, actor aggregate.ScheduleActor, reason string) (bool, []event.DomainEvent, error) {
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, occurrence.ScheduleID)
+	if err != nil {
+		return false, nil, errors.NewNotFoundError("schedule")
+	}
+	if schedule.Status().IsFinal() {
+		return false, nil, nil
+	}
+
+	if err := schedule.Cancel(reason, actor); err != nil {
+		return false, nil, scheduleChangeError("cancel schedule", err)
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := schedule.GetUncommittedEvents()
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		return false, nil, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if occurrence.PaymentID == "" {
+		return true, events, nil
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, occurrence.PaymentID)
+	if err != nil {
+		return false, nil, errors.NewNotFoundError("payment")
+	}
+	if payment.Status() != aggregate.PaymentStatusPending {
+		return true, events, nil
+	}
+
+	paymentGateway, err := gateways.Get(payment.Method())
+	if err != nil {
+		return false, nil, errors.NewInternalError(err.Error())
+	}
+	if err := paymentGateway.CancelPayment(ctx, payment.OrderCode(), reason); err != nil {
+		return false, nil, errors.NewInternalError(fmt.Sprintf("failed to cancel %s payment: %v", payment.Method(), err))
+	}
+	if err := payment.MarkAsCancelled(); err != nil {
+		return false, nil, errors.NewValidationError(fmt.Sprintf("failed to mark payment as cancelled: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events = append(events, payment.GetUncommittedEvents()...)
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		return false, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	return true, events, nil
+}
+
+// refundSeriesPayment reserves a refund of part of the upfront payment of a series, up to what is left of
+// it, within the caller's unit of work. It returns the amount reserved; once the unit of work is committed
+// the caller completes the refund with completePaymentRefund, which deducts it from the vendor's settlement.
+func refundSeriesPayment(ctx context.Context, uow repository.UnitOfWork,
+	series *aggregate.BookingSeries, amount int, reason string) (int, []event.DomainEvent, error) {
+	if series.PaymentID() == "" {
+		return 0, nil, nil
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, series.PaymentID())
+	if err != nil {
+		return 0, nil, errors.NewNotFoundError("payment")
+	}
+	if payment.Status() != aggregate.PaymentStatusPaid {
+		return 0, nil, nil
+	}
+	if amount > payment.RefundableAmount() {
+		amount = payment.RefundableAmount()
+	}
+	if amount <= 0 {
+		return 0, nil, nil
+	}
+
+	if _, err := requestPaymentRefund(payment, amount, reason); err != nil {
+		return 0, nil, err
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		return 0, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	return amount, events, nil
+}
+
+// parseOccurrenceStart parses the start the recurrence gives an occurrence
+func parseOccurrenceStart(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.NewValidationError("occurrence_start is required")
+	}
+	occurrenceStart, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError(fmt.Sprintf("invalid occurrence_start format: %v", err))
+	}
+	return occurrenceStart, nil
+}
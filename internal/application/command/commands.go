@@ -75,6 +75,13 @@ type CreatePaymentCommand struct {
 	ServiceIDs  []string                `json:"service_ids"`
 	StartTime   string                  `json:"start_time"` // RFC3339 format
 	EndTime     string                  `json:"end_time"`   // RFC3339 format
+	Method      string                  `json:"method,omitempty"` // PAYOS (default) or PAY_AT_SHOP; must be accepted by the vendor
+	CouponCode  string                  `json:"coupon_code,omitempty"`
+	// Pets of a booking covering several pets, each with its own services; replaces pet_id and service_ids.
+	// Items and amount are priced from the services, and end_time defaults to their combined duration.
+	Pets []aggregate.PaymentPetLine `json:"pets,omitempty"`
+	// Waitlist entry whose offered slot this checkout claims; set by the waitlist claim, never by clients
+	WaitlistEntryID string `json:"-"`
 }
 
 // CreatePaymentResponse represents a payment creation response
@@ -85,7 +92,11 @@ type CreatePaymentResponse struct {
 	QRCode      string `json:"qr_code"`
 	Amount      int    `json:"amount"`
 	Status      string `json:"status"`
+	Method      string `json:"method"`
 	ExpiredAt   string `json:"expired_at"`
+	// Promotion applied to the payment, Amount is already discounted
+	PromotionCode  string `json:"promotion_code,omitempty"`
+	DiscountAmount int    `json:"discount_amount,omitempty"`
 }
 
 // CancelPaymentCommand represents a command to cancel a payment
@@ -99,29 +110,188 @@ type ConfirmPaymentCommand struct {
 	OrderCode int64 `json:"order_code"`
 }
 
+// MarkPaymentCollectedCommand represents a vendor confirming a pay at shop payment was collected
+type MarkPaymentCollectedCommand struct {
+	PaymentID   string `json:"payment_id"`
+	CollectedBy string `json:"collected_by"` // Vendor staff user that collected the payment
+}
+
+// RefundPaymentCommand represents a command to refund part or all of a paid payment
+type RefundPaymentCommand struct {
+	PaymentID string `json:"payment_id"`
+	Amount    int    `json:"amount"` // Amount in VND; 0 refunds the remaining amount
+	Reason    string `json:"reason"`
+}
+
+// RefundPaymentResponse represents a refund result
+type RefundPaymentResponse struct {
+	RefundID       string `json:"refund_id"`
+	PaymentID      string `json:"payment_id"`
+	Amount         int    `json:"amount"`
+	RefundedAmount int    `json:"refunded_amount"`
+	Status         string `json:"status"`
+	Manual         bool   `json:"manual"` // Money must be returned to the customer outside the gateway
+}
+
 // ============================================
 // Pet Commands
 // ============================================
 
 // CreatePet represents a command to create a new pet
 type CreatePet struct {
-	UserID   string  `json:"user_id"`
-	Name     string  `json:"name"`
-	Species  string  `json:"species"`
-	Breed    string  `json:"breed"`
-	Age      int     `json:"age"`
-	Weight   float64 `json:"weight"`
-	ImageUrl string  `json:"image_url,omitempty"`
+	UserID             string    `json:"user_id"`
+	Name               string    `json:"name"`
+	Species            string    `json:"species"`
+	Breed              string    `json:"breed"`
+	Age                int       `json:"age"` // Used to estimate the birth date when none is given
+	BirthDate          time.Time `json:"birth_date,omitempty"`
+	BirthDateEstimated bool      `json:"birth_date_estimated,omitempty"`
+	Weight             float64   `json:"weight"`
+	ImageUrl           string    `json:"image_url,omitempty"`
 }
 
 // UpdatePet represents a command to update pet information
 type UpdatePet struct {
-	PetID   string  `json:"pet_id"`
-	Name    string  `json:"name"`
-	Species string  `json:"species"`
-	Breed   string  `json:"breed"`
-	Age     int     `json:"age"`
-	Weight  float64 `json:"weight"`
+	PetID              string    `json:"pet_id"`
+	Name               string    `json:"name"`
+	Species            string    `json:"species"`
+	Breed              string    `json:"breed"`
+	Age                int       `json:"age"` // Used to estimate the birth date when none is given
+	BirthDate          time.Time `json:"birth_date,omitempty"`
+	BirthDateEstimated bool      `json:"birth_date_estimated,omitempty"`
+	Weight             float64   `json:"weight"`
+}
+
+// UpdatePetCareNotes represents a command to set the temperament and special instructions shared
+// with vendors in the pet's care brief
+type UpdatePetCareNotes struct {
+	PetID               string `json:"-"`
+	Temperament         string `json:"temperament"`          // e.g. "nervous around dryers, may nip when handled at the paws"
+	SpecialInstructions string `json:"special_instructions"` // e.g. "muzzle for nail trims, no scented shampoo"
+	UpdatedBy           string `json:"-"`                    // Set from the authenticated user
+}
+
+// RecordPetWeight represents a command to add a weight measurement to a pet's weight history
+type RecordPetWeight struct {
+	PetID      string    `json:"pet_id"`
+	Weight     float64   `json:"weight"`
+	MeasuredAt time.Time `json:"measured_at,omitempty"`
+	Source     string    `json:"source"` // OWNER, VENDOR or VET
+	Notes      string    `json:"notes,omitempty"`
+	RecordedBy string    `json:"-"` // Set from the authenticated user
+}
+
+// Pet Guardianship Commands
+// ============================================
+
+// InvitePetGuardian represents a command to invite a user to share a pet as a co-owner or caretaker
+type InvitePetGuardian struct {
+	PetID     string `json:"pet_id"`
+	UserID    string `json:"user_id"` // The invited user
+	Role      string `json:"role"`    // OWNER or CARETAKER
+	InvitedBy string `json:"-"`       // Set from the authenticated user
+}
+
+// AcceptPetGuardianInvitation represents a command to accept a guardian invitation
+type AcceptPetGuardianInvitation struct {
+	PetID        string `json:"pet_id"`
+	InvitationID string `json:"invitation_id"`
+	UserID       string `json:"-"` // Set from the authenticated user
+}
+
+// DeclinePetGuardianInvitation represents a command to decline a guardian invitation, or to cancel it when sent by an owner
+type DeclinePetGuardianInvitation struct {
+	PetID        string `json:"pet_id"`
+	InvitationID string `json:"invitation_id"`
+	UserID       string `json:"-"` // Set from the authenticated user
+}
+
+// RemovePetGuardian represents a command to remove a guardian from a pet
+type RemovePetGuardian struct {
+	PetID          string `json:"pet_id"`
+	GuardianUserID string `json:"guardian_user_id"`
+	RemovedBy      string `json:"-"` // Set from the authenticated user
+}
+
+// RequestPetOwnershipTransfer represents a command to hand a pet over to a new primary owner
+type RequestPetOwnershipTransfer struct {
+	PetID       string `json:"pet_id"`
+	ToUserID    string `json:"to_user_id"`
+	Note        string `json:"note,omitempty"`
+	RequestedBy string `json:"-"` // Set from the authenticated user
+}
+
+// AcceptPetOwnershipTransfer represents a command to accept a pending ownership transfer
+type AcceptPetOwnershipTransfer struct {
+	PetID  string `json:"pet_id"`
+	UserID string `json:"-"` // Set from the authenticated user
+}
+
+// DeclinePetOwnershipTransfer represents a command to decline a pending ownership transfer, or to cancel it when sent by the owner
+type DeclinePetOwnershipTransfer struct {
+	PetID  string `json:"pet_id"`
+	UserID string `json:"-"` // Set from the authenticated user
+}
+
+// Pet Medication Commands
+// ============================================
+
+// AddPetMedicationPlan represents a command to start a medication plan for a pet
+type AddPetMedicationPlan struct {
+	PetID           string    `json:"pet_id"`
+	DrugName        string    `json:"drug_name"`
+	Dose            string    `json:"dose"`
+	IntervalHours   int       `json:"interval_hours"`
+	StartDate       time.Time `json:"start_date,omitempty"`
+	EndDate         time.Time `json:"end_date,omitempty"`
+	PrescribedBy    string    `json:"prescribed_by,omitempty"`
+	Instructions    string    `json:"instructions,omitempty"`
+	MedicalRecordID string    `json:"medical_record_id,omitempty"`
+	CreatedBy       string    `json:"-"` // Set from the authenticated user
+}
+
+// StopPetMedicationPlan represents a command to stop a medication plan
+type StopPetMedicationPlan struct {
+	PetID     string `json:"pet_id"`
+	PlanID    string `json:"plan_id"`
+	Reason    string `json:"reason,omitempty"`
+	StoppedBy string `json:"-"` // Set from the authenticated user
+}
+
+// LogPetMedicationDose represents a command to record a scheduled dose as given or missed
+type LogPetMedicationDose struct {
+	PetID        string    `json:"pet_id"`
+	PlanID       string    `json:"plan_id"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	Status       string    `json:"status"` // GIVEN or MISSED
+	Notes        string    `json:"notes,omitempty"`
+	RecordedBy   string    `json:"-"` // Set from the authenticated user
+	ScheduleID   string    `json:"-"` // Set when vendor staff log a dose during a booking
+}
+
+// Pet Attachment Commands
+// ============================================
+
+// AddPetAttachment represents a command to record a document uploaded for a pet
+type AddPetAttachment struct {
+	PetID       string `json:"pet_id"`
+	Category    string `json:"category"` // LAB_RESULT, CERTIFICATE, XRAY, PRESCRIPTION or OTHER
+	Title       string `json:"title,omitempty"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+	StorageKey  string `json:"storage_key"`
+	RecordType  string `json:"record_type,omitempty"` // vaccination or medical_record
+	RecordID    string `json:"record_id,omitempty"`
+	UploadedBy  string `json:"-"` // Set from the authenticated user
+}
+
+// RemovePetAttachment represents a command to remove a document from a pet
+type RemovePetAttachment struct {
+	PetID        string `json:"pet_id"`
+	AttachmentID string `json:"attachment_id"`
+	RemovedBy    string `json:"-"` // Set from the authenticated user
 }
 
 // DeletePet represents a command to delete a pet
@@ -169,6 +339,62 @@ type RemovePetAllergy struct {
 	AllergyID string `json:"allergy_id"`
 }
 
+// UpdatePetVaccination represents a command to correct a vaccination record
+type UpdatePetVaccination struct {
+	PetID        string    `json:"pet_id"`
+	RecordID     string    `json:"record_id"`
+	VaccineName  string    `json:"vaccine_name"`
+	Date         time.Time `json:"date"`
+	NextDueDate  time.Time `json:"next_due_date,omitempty"`
+	Veterinarian string    `json:"veterinarian,omitempty"`
+	Notes        string    `json:"notes,omitempty"`
+	Reason       string    `json:"reason"`
+	ChangedBy    string    `json:"-"` // Set from the authenticated user
+}
+
+// VoidPetVaccination represents a command to void a vaccination record
+type VoidPetVaccination struct {
+	PetID    string `json:"pet_id"`
+	RecordID string `json:"record_id"`
+	Reason   string `json:"reason"`
+	VoidedBy string `json:"-"` // Set from the authenticated user
+}
+
+// UpdatePetMedicalRecord represents a command to correct a medical record
+type UpdatePetMedicalRecord struct {
+	PetID        string    `json:"pet_id"`
+	RecordID     string    `json:"record_id"`
+	Date         time.Time `json:"date"`
+	Description  string    `json:"description"`
+	Treatment    string    `json:"treatment,omitempty"`
+	Veterinarian string    `json:"veterinarian,omitempty"`
+	Diagnosis    string    `json:"diagnosis,omitempty"`
+	Notes        string    `json:"notes,omitempty"`
+	Reason       string    `json:"reason"`
+	ChangedBy    string    `json:"-"` // Set from the authenticated user
+}
+
+// VoidPetMedicalRecord represents a command to void a medical record
+type VoidPetMedicalRecord struct {
+	PetID    string `json:"pet_id"`
+	RecordID string `json:"record_id"`
+	Reason   string `json:"reason"`
+	VoidedBy string `json:"-"` // Set from the authenticated user
+}
+
+// UpdatePetAllergy represents a command to correct an allergy
+type UpdatePetAllergy struct {
+	PetID         string    `json:"pet_id"`
+	AllergyID     string    `json:"allergy_id"`
+	Allergen      string    `json:"allergen"`
+	Severity      string    `json:"severity"`
+	Symptoms      string    `json:"symptoms,omitempty"`
+	DiagnosedDate time.Time `json:"diagnosed_date,omitempty"`
+	Notes         string    `json:"notes,omitempty"`
+	Reason        string    `json:"reason"`
+	ChangedBy     string    `json:"-"` // Set from the authenticated user
+}
+
 // ============================================
 // Vendor Commands
 // ============================================
@@ -206,6 +432,32 @@ type UpdateVendorBankAccount struct {
 	BankBranch    string `json:"bank_branch"`
 }
 
+// UpdateVendorSettlementSettings represents a command to update how vendor earnings are settled
+type UpdateVendorSettlementSettings struct {
+	VendorID        string `json:"vendor_id"`
+	Period          string `json:"period"`            // DAILY or WEEKLY
+	MinPayoutAmount int    `json:"min_payout_amount"` // Balances below this are carried over
+	UpdatedBy       string `json:"-"`
+	IsAdmin         bool   `json:"-"` // Admins can update any vendor; otherwise only its owners and managers
+}
+
+// UpdateVendorPaymentMethods represents a command to update which payment methods a vendor accepts
+type UpdateVendorPaymentMethods struct {
+	VendorID       string   `json:"vendor_id"`
+	PaymentMethods []string `json:"payment_methods"` // PAYOS and/or PAY_AT_SHOP
+	UpdatedBy      string   `json:"-"`
+	IsAdmin        bool     `json:"-"` // Admins can update any vendor; otherwise only its owners and managers
+}
+
+// UpdateVendorReschedulePolicy represents a command to update how customers may reschedule bookings with a vendor
+type UpdateVendorReschedulePolicy struct {
+	VendorID       string `json:"vendor_id"`
+	CutoffHours    int    `json:"cutoff_hours"`    // Hours before the start after which customers cannot reschedule
+	MaxReschedules int    `json:"max_reschedules"` // 0 disables customer reschedules
+	UpdatedBy      string `json:"-"`
+	IsAdmin        bool   `json:"-"` // Admins can update any vendor; otherwise only its owners and managers
+}
+
 // ============================================
 // Service Commands (Vendor Services)
 // ============================================
@@ -236,21 +488,36 @@ type DeleteService struct {
 	ServiceID string `json:"service_id"`
 }
 
+// UpdateServiceEligibility represents a command to set which pets a service accepts
+type UpdateServiceEligibility struct {
+	ServiceID string   `json:"-"`
+	Species   []string `json:"species"`    // Catalogue species codes, names or aliases; empty = all species
+	Sizes     []string `json:"sizes"`      // SMALL, MEDIUM, LARGE or GIANT; empty = all sizes
+	MinWeight float64  `json:"min_weight"` // Kilograms, 0 = no minimum
+	MaxWeight float64  `json:"max_weight"` // Kilograms, 0 = no maximum
+	UpdatedBy string   `json:"-"`
+	IsAdmin   bool     `json:"-"` // Admins can update any service; otherwise only the vendor's staff
+}
+
 // ==================== Schedule Commands ====================
 
 // CreateSchedule represents a command to create a new schedule
 type CreateSchedule struct {
-	UserID        string              `json:"user_id"`
-	VendorID      string              `json:"vendor_id"`
-	PetID         string              `json:"pet_id"`
-	ServiceIDs    []string            `json:"service_ids"`
-	StartTime     string              `json:"start_time"` // RFC3339 format
-	EndTime       string              `json:"end_time"`   // RFC3339 format
-	BookingUser   BookingUserData     `json:"booking_user"`
-	BookedVendor  BookedVendorData    `json:"booked_vendor"`
-	AssignedPet   AssignedPetData     `json:"assigned_pet"`
-	PaymentID     string              `json:"payment_id,omitempty"`      // For auto-creating payout
-	TotalPrice    int                 `json:"total_price,omitempty"`     // For auto-creating payout
+	UserID         string           `json:"user_id"`
+	VendorID       string           `json:"vendor_id"`
+	PetID          string           `json:"pet_id"`
+	ServiceIDs     []string         `json:"service_ids"`
+	StartTime      string           `json:"start_time"` // RFC3339 format
+	EndTime        string           `json:"end_time"`   // RFC3339 format
+	BookingUser    BookingUserData  `json:"booking_user"`
+	BookedVendor   BookedVendorData `json:"booked_vendor"`
+	AssignedPet    AssignedPetData  `json:"assigned_pet"`
+	PaymentID      string           `json:"payment_id,omitempty"`       // Paid booking to add to the vendor's settlement
+	TotalPrice     int              `json:"total_price,omitempty"`      // Informational; settlement uses the payment amount
+	ShareCareBrief bool             `json:"share_care_brief,omitempty"` // Owner consents to share the care briefs of their booked pets with the shop
+
+	// Pets of a booking covering several pets, each with its own services; replaces pet_id and service_ids
+	Pets []aggregate.PaymentPetLine `json:"pets,omitempty"`
 }
 
 type BookingUserData struct {
@@ -283,18 +550,248 @@ type AssignedPetData struct {
 // ChangeScheduleStatus represents a command to change schedule status
 type ChangeScheduleStatus struct {
 	ScheduleID string `json:"schedule_id"`
-	Status     string `json:"status"` // pending, confirmed, completed, cancelled
+	Status     string `json:"status"`           // pending, confirmed, in_progress, completed, cancelled, no_show
+	Reason     string `json:"reason,omitempty"` // Used when cancelling
+	UserID     string `json:"-"`                // Set from the authenticated user
+	IsAdmin    bool   `json:"-"`
 }
 
 // CompleteSchedule represents a command to complete a schedule
 type CompleteSchedule struct {
 	ScheduleID string `json:"schedule_id"`
+	UserID     string `json:"-"` // Set from the authenticated user
+	IsAdmin    bool   `json:"-"`
 }
 
 // CancelSchedule represents a command to cancel a schedule
 type CancelSchedule struct {
 	ScheduleID string `json:"schedule_id"`
 	Reason     string `json:"reason"`
+	UserID     string `json:"-"` // Set from the authenticated user
+	IsAdmin    bool   `json:"-"`
+}
+
+// CancelSchedulePet represents a command to cancel one pet of a booking covering several pets
+type CancelSchedulePet struct {
+	ScheduleID string `json:"-"`
+	PetID      string `json:"-"`
+	Reason     string `json:"reason"`
+	UserID     string `json:"-"` // Set from the authenticated user
+	IsAdmin    bool   `json:"-"`
+}
+
+// CancelSchedulePetResponse represents the booking after one of its pets was cancelled
+type CancelSchedulePetResponse struct {
+	ScheduleID string                `json:"schedule_id"`
+	PetID      string                `json:"pet_id"`
+	TotalPrice int                   `json:"total_price"`
+	Adjustment *RescheduleAdjustment `json:"adjustment,omitempty"` // Partial refund, or the amount settled at the shop when unpaid
+}
+
+// ShareScheduleCareBrief represents an owner's consent to share a booked pet's care brief with the booked shop
+type ShareScheduleCareBrief struct {
+	ScheduleID string `json:"-"`
+	PetID      string `json:"-"` // Booked pet; empty means the booking's primary pet
+	UserID     string `json:"-"` // Set from the authenticated user
+}
+
+// RevokeScheduleCareBrief represents an owner withdrawing consent to share a booked pet's care brief
+type RevokeScheduleCareBrief struct {
+	ScheduleID string `json:"-"`
+	PetID      string `json:"-"` // Booked pet; empty means the booking's primary pet
+	UserID     string `json:"-"` // Set from the authenticated user
+}
+
+// VisitReportServiceInput is a booked service performed during the visit
+type VisitReportServiceInput struct {
+	ServiceID string `json:"service_id"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// SubmitVisitReport represents a command for shop staff to report on a visit and complete the booking
+type SubmitVisitReport struct {
+	ScheduleID        string                    `json:"-"`
+	ServicesPerformed []VisitReportServiceInput `json:"services_performed"`
+	Observations      string                    `json:"observations"`
+	Weight            float64                   `json:"weight,omitempty"` // Kilograms, omitted when the pet was not weighed
+	PhotoURLs         []string                  `json:"photo_urls,omitempty"`
+	Recommendations   string                    `json:"recommendations,omitempty"`
+	SubmittedBy       string                    `json:"-"` // Set from the authenticated user
+}
+
+// AcceptVisitReport represents a command for an owner to accept a visit report into the pet's medical history
+type AcceptVisitReport struct {
+	ScheduleID string `json:"-"`
+	AcceptedBy string `json:"-"` // Set from the authenticated user
+}
+
+// RescheduleSchedule represents a command by the customer or an admin to move a booking to a new time
+type RescheduleSchedule struct {
+	ScheduleID string `json:"-"`
+	StartTime  string `json:"start_time"` // RFC3339 format
+	EndTime    string `json:"end_time"`   // RFC3339 format
+	Reason     string `json:"reason,omitempty"`
+	UserID     string `json:"-"` // Set from the authenticated user
+	IsAdmin    bool   `json:"-"`
+}
+
+// ProposeScheduleReschedule represents a command by vendor staff to propose a new time for a booking
+type ProposeScheduleReschedule struct {
+	ScheduleID string `json:"-"`
+	StartTime  string `json:"start_time"` // RFC3339 format
+	EndTime    string `json:"end_time"`   // RFC3339 format
+	Reason     string `json:"reason,omitempty"`
+	ProposedBy string `json:"-"` // Set from the authenticated user
+}
+
+// RespondRescheduleProposal represents the customer accepting or declining a vendor's reschedule proposal
+type RespondRescheduleProposal struct {
+	ScheduleID string `json:"-"`
+	Accept     bool   `json:"accept"`
+	Reason     string `json:"reason,omitempty"` // Why the proposal is declined
+	UserID     string `json:"-"`                // Set from the authenticated user
+}
+
+// RescheduleAdjustment describes how a change in the price of a rescheduled booking is settled
+type RescheduleAdjustment struct {
+	Kind         string `json:"kind"` // TOP_UP, REFUND or AT_SHOP
+	Amount       int    `json:"amount"`
+	PaymentID    string `json:"payment_id,omitempty"`
+	CheckoutURL  string `json:"checkout_url,omitempty"` // Where the customer pays a top-up
+	QRCode       string `json:"qr_code,omitempty"`
+	RefundID     string `json:"refund_id,omitempty"`
+	ManualRefund bool   `json:"manual_refund,omitempty"` // Money must be returned to the customer outside the gateway
+}
+
+// RescheduleScheduleResponse represents the booking after a reschedule or a response to a proposal
+type RescheduleScheduleResponse struct {
+	ScheduleID      string                `json:"schedule_id"`
+	StartTime       string                `json:"start_time"`
+	EndTime         string                `json:"end_time"`
+	TotalPrice      int                   `json:"total_price"`
+	RescheduleCount int                   `json:"reschedule_count"`
+	Adjustment      *RescheduleAdjustment `json:"adjustment,omitempty"`
+}
+
+// AssignScheduleStaff represents assigning a booking to a staff member of the booked shop
+type AssignScheduleStaff struct {
+	ScheduleID string `json:"-"`
+	StaffID    string `json:"staff_id,omitempty"` // User ID of the staff member; empty picks the free staff member with the fewest bookings that day
+	AssignedBy string `json:"-"`                  // Set from the authenticated user
+	IsAdmin    bool   `json:"-"`
+}
+
+// AssignScheduleStaffResponse represents the staff member a booking was assigned to
+type AssignScheduleStaffResponse struct {
+	ScheduleID   string `json:"schedule_id"`
+	StaffID      string `json:"staff_id"`
+	AutoAssigned bool   `json:"auto_assigned"`
+}
+
+// ==================== Booking Series Commands ====================
+
+// CreateBookingSeries represents a command to book the same services for a pet on a recurring basis
+type CreateBookingSeries struct {
+	UserID      string   `json:"-"` // Set from the authenticated user
+	VendorID    string   `json:"vendor_id"`
+	PetID       string   `json:"pet_id"`
+	ServiceIDs  []string `json:"service_ids"`
+	StartTime   string   `json:"start_time"`       // First occurrence, RFC3339 format
+	EndTime     string   `json:"end_time"`         // RFC3339 format
+	Recurrence  string   `json:"recurrence"`       // RRULE, e.g. FREQ=WEEKLY;INTERVAL=1;COUNT=10
+	PaymentMode string   `json:"payment_mode"`     // UPFRONT or PER_OCCURRENCE (default)
+	Method      string   `json:"method,omitempty"` // PAYOS (default) or PAY_AT_SHOP; must be accepted by the vendor
+}
+
+// CreateBookingSeriesResponse represents a booking series creation response. Series paid upfront
+// return the payment to complete before any occurrence is booked.
+type CreateBookingSeriesResponse struct {
+	SeriesID        string                 `json:"series_id"`
+	Status          string                 `json:"status"`
+	OccurrencePrice int                    `json:"occurrence_price"`
+	OccurrenceCount int                    `json:"occurrence_count"` // -1 when the series runs until cancelled
+	UpfrontPayment  *CreatePaymentResponse `json:"upfront_payment,omitempty"`
+	BookedSchedules []string               `json:"booked_schedules,omitempty"`
+}
+
+// SkipSeriesOccurrence represents a command to skip one occurrence of a booking series
+type SkipSeriesOccurrence struct {
+	SeriesID        string `json:"-"`
+	OccurrenceStart string `json:"occurrence_start"` // Start the recurrence gives the occurrence, RFC3339 format
+	Reason          string `json:"reason,omitempty"`
+	UserID          string `json:"-"`
+	IsAdmin         bool   `json:"-"`
+}
+
+// ModifySeriesOccurrence represents a command to move one occurrence of a booking series that is not booked yet
+type ModifySeriesOccurrence struct {
+	SeriesID        string `json:"-"`
+	OccurrenceStart string `json:"occurrence_start"` // Start the recurrence gives the occurrence, RFC3339 format
+	StartTime       string `json:"start_time"`       // RFC3339 format
+	EndTime         string `json:"end_time"`         // RFC3339 format
+	Reason          string `json:"reason,omitempty"`
+	UserID          string `json:"-"`
+	IsAdmin         bool   `json:"-"`
+}
+
+// CancelBookingSeries represents a command to cancel the rest of a booking series
+type CancelBookingSeries struct {
+	SeriesID string `json:"-"`
+	From     string `json:"from,omitempty"` // RFC3339 format; empty cancels every occurrence from now
+	Reason   string `json:"reason,omitempty"`
+	UserID   string `json:"-"`
+	IsAdmin  bool   `json:"-"`
+}
+
+// BookingSeriesChangeResponse represents the result of a change to a booking series
+type BookingSeriesChangeResponse struct {
+	SeriesID           string   `json:"series_id"`
+	Status             string   `json:"status"`
+	CancelledSchedules []string `json:"cancelled_schedules,omitempty"`
+	RefundedAmount     int      `json:"refunded_amount,omitempty"` // Refunded from the upfront payment
+}
+
+// ==================== Waitlist Commands ====================
+
+// JoinWaitlist represents a command to wait for a slot with a vendor's service within a time window
+type JoinWaitlist struct {
+	UserID      string `json:"-"` // Set from the authenticated user
+	VendorID    string `json:"vendor_id"`
+	ServiceID   string `json:"service_id"`
+	PetID       string `json:"pet_id"`
+	WindowStart string `json:"window_start"` // RFC3339 format
+	WindowEnd   string `json:"window_end"`   // RFC3339 format
+}
+
+// OfferWaitlistSlot represents a command to offer a freed slot to the next customer on the waitlist
+type OfferWaitlistSlot struct {
+	VendorID        string
+	ServiceIDs      []string // Services the freed booking was for
+	StartTime       time.Time
+	EndTime         time.Time
+	FreedScheduleID string    // Booking whose cancellation or move freed the slot
+	JoinedAfter     time.Time // Only customers who joined after this time are offered the slot
+}
+
+// DeclineWaitlistOffer represents a command to turn down an offered slot and stay on the waitlist
+type DeclineWaitlistOffer struct {
+	EntryID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+// LeaveWaitlist represents a command to leave the waitlist
+type LeaveWaitlist struct {
+	EntryID string `json:"-"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// ClaimWaitlistOffer represents a command to check out an offered slot
+type ClaimWaitlistOffer struct {
+	EntryID    string `json:"-"`
+	UserID     string `json:"-"`
+	Method     string `json:"method,omitempty"` // PAYOS (default) or PAY_AT_SHOP; must be accepted by the vendor
+	CouponCode string `json:"coupon_code,omitempty"`
 }
 
 // ==================== VendorStaff Commands ====================
@@ -355,3 +852,94 @@ type RequestPayout struct {
 	Amount     int    `json:"amount"`
 	Notes      string `json:"notes,omitempty"`
 }
+
+// ============================================
+// Promotion Commands
+// ============================================
+
+// CreatePromotion represents a command to create a coupon code or offer
+type CreatePromotion struct {
+	Code             string   `json:"code"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	DiscountType     string   `json:"discount_type"`  // PERCENTAGE or FIXED
+	DiscountValue    int      `json:"discount_value"` // Percentage (1-100) or amount in VND
+	MaxDiscount      int      `json:"max_discount"`
+	MinOrderAmount   int      `json:"min_order_amount"`
+	FundedBy         string   `json:"funded_by"` // PLATFORM or VENDOR
+	VendorID         string   `json:"vendor_id"`
+	ServiceIDs       []string `json:"service_ids"`
+	FirstBookingOnly bool     `json:"first_booking_only"`
+	StartsAt         string   `json:"starts_at"` // RFC3339 format
+	EndsAt           string   `json:"ends_at"`   // RFC3339 format
+	UsageLimit       int      `json:"usage_limit"`
+	PerUserLimit     int      `json:"per_user_limit"`
+	CreatedBy        string   `json:"-"`
+	CreatedByVendor  bool     `json:"-"` // Vendor staff can only create promotions they fund themselves
+}
+
+// CreatePromotionResponse represents a promotion creation response
+type CreatePromotionResponse struct {
+	PromotionID string `json:"promotion_id"`
+	Code        string `json:"code"`
+}
+
+// DeactivatePromotion represents a command to stop a promotion from being redeemed
+type DeactivatePromotion struct {
+	PromotionID string `json:"promotion_id"`
+	Reason      string `json:"reason"`
+}
+
+// ============================================
+// Species Catalogue Commands
+// ============================================
+
+// SpeciesBreedData describes a breed of the species catalogue
+type SpeciesBreedData struct {
+	Code    string   `json:"code"`
+	NameEn  string   `json:"name_en"`
+	NameVi  string   `json:"name_vi"`
+	Aliases []string `json:"aliases"`
+	Size    string   `json:"size"` // Typical adult size: SMALL, MEDIUM, LARGE or GIANT
+}
+
+// CreateSpecies represents a command to add a species to the catalogue
+type CreateSpecies struct {
+	Code      string             `json:"code"`
+	NameEn    string             `json:"name_en"`
+	NameVi    string             `json:"name_vi"`
+	Aliases   []string           `json:"aliases"`
+	Breeds    []SpeciesBreedData `json:"breeds"`
+	CreatedBy string             `json:"-"`
+}
+
+// UpdateSpecies represents a command to change the names and aliases of a species
+type UpdateSpecies struct {
+	SpeciesID string   `json:"-"`
+	NameEn    string   `json:"name_en"`
+	NameVi    string   `json:"name_vi"`
+	Aliases   []string `json:"aliases"`
+}
+
+// DeactivateSpecies represents a command to stop a species from being chosen for new pets
+type DeactivateSpecies struct {
+	SpeciesID string `json:"species_id"`
+}
+
+// AddSpeciesBreed represents a command to add a breed to a species
+type AddSpeciesBreed struct {
+	SpeciesID string `json:"-"`
+	SpeciesBreedData
+}
+
+// UpdateSpeciesBreed represents a command to change a breed of a species
+type UpdateSpeciesBreed struct {
+	SpeciesID string `json:"-"`
+	SpeciesBreedData
+}
+
+// RemoveSpeciesBreed represents a command to remove a breed from a species
+type RemoveSpeciesBreed struct {
+	SpeciesID string `json:"species_id"`
+	BreedCode string `json:"breed_code"`
+}
@@ -3,33 +3,39 @@ package command
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/event"
 	"whisko-petcare/internal/domain/repository"
 	"whisko-petcare/internal/infrastructure/bus"
-	"whisko-petcare/internal/infrastructure/payos"
+	"whisko-petcare/internal/infrastructure/gateway"
 	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
 )
 
 // CreatePaymentWithUoWHandler handles create payment commands with Unit of Work
 type CreatePaymentWithUoWHandler struct {
-	uowFactory   repository.UnitOfWorkFactory
-	eventBus     bus.EventBus
-	payOSService *payos.Service
+	uowFactory            repository.UnitOfWorkFactory
+	eventBus              bus.EventBus
+	gateways              *gateway.Registry
+	createScheduleHandler *CreateScheduleWithUoWHandler
 }
 
 // NewCreatePaymentWithUoWHandler creates a new create payment handler with UoW
 func NewCreatePaymentWithUoWHandler(
 	uowFactory repository.UnitOfWorkFactory,
 	eventBus bus.EventBus,
-	payOSService *payos.Service,
+	gateways *gateway.Registry,
+	createScheduleHandler *CreateScheduleWithUoWHandler,
 ) *CreatePaymentWithUoWHandler {
 	return &CreatePaymentWithUoWHandler{
-		uowFactory:   uowFactory,
-		eventBus:     eventBus,
-		payOSService: payOSService,
+		uowFactory:            uowFactory,
+		eventBus:              eventBus,
+		gateways:              gateways,
+		createScheduleHandler: createScheduleHandler,
 	}
 }
 
@@ -39,52 +45,78 @@ func (h *CreatePaymentWithUoWHandler) Handle(ctx context.Context, cmd *CreatePay
 		return nil, errors.NewValidationError("command cannot be nil")
 	}
 
+	// Bookings covering several pets are priced from their services once the services are loaded
+	multiPet := len(cmd.Pets) > 0
+
 	// Validate request
 	if cmd.UserID == "" {
 		return nil, errors.NewValidationError("user_id is required")
 	}
-	if cmd.Amount <= 0 {
-		return nil, errors.NewValidationError("amount must be greater than 0")
-	}
-	if cmd.Description == "" {
-		return nil, errors.NewValidationError("description is required")
-	}
-	if len(cmd.Items) == 0 {
-		return nil, errors.NewValidationError("items are required")
-	}
 	if cmd.VendorID == "" {
 		return nil, errors.NewValidationError("vendor_id is required")
 	}
-	if cmd.PetID == "" {
-		return nil, errors.NewValidationError("pet_id is required")
-	}
-	if len(cmd.ServiceIDs) == 0 {
-		return nil, errors.NewValidationError("service_ids are required")
+	if multiPet {
+		if cmd.PetID != "" || len(cmd.ServiceIDs) > 0 {
+			return nil, errors.NewValidationError("pets replaces pet_id and service_ids")
+		}
+		if cmd.Amount < 0 {
+			return nil, errors.NewValidationError("amount cannot be negative")
+		}
+	} else {
+		if cmd.Amount <= 0 {
+			return nil, errors.NewValidationError("amount must be greater than 0")
+		}
+		if cmd.Description == "" {
+			return nil, errors.NewValidationError("description is required")
+		}
+		if len(cmd.Items) == 0 {
+			return nil, errors.NewValidationError("items are required")
+		}
+		if cmd.PetID == "" {
+			return nil, errors.NewValidationError("pet_id is required")
+		}
+		if len(cmd.ServiceIDs) == 0 {
+			return nil, errors.NewValidationError("service_ids are required")
+		}
+		if cmd.EndTime == "" {
+			return nil, errors.NewValidationError("end_time is required")
+		}
 	}
 	if cmd.StartTime == "" {
 		return nil, errors.NewValidationError("start_time is required")
 	}
-	if cmd.EndTime == "" {
-		return nil, errors.NewValidationError("end_time is required")
-	}
 
 	// Parse times
 	startTime, err := time.Parse(time.RFC3339, cmd.StartTime)
 	if err != nil {
 		return nil, errors.NewValidationError(fmt.Sprintf("invalid start_time format: %v", err))
 	}
-	endTime, err := time.Parse(time.RFC3339, cmd.EndTime)
-	if err != nil {
-		return nil, errors.NewValidationError(fmt.Sprintf("invalid end_time format: %v", err))
+	var endTime time.Time
+	if cmd.EndTime != "" {
+		endTime, err = time.Parse(time.RFC3339, cmd.EndTime)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid end_time format: %v", err))
+		}
 	}
 
 	// Validate that item total matches amount
-	totalAmount := 0
-	for _, item := range cmd.Items {
-		totalAmount += item.Price * item.Quantity
+	if !multiPet {
+		totalAmount := 0
+		for _, item := range cmd.Items {
+			totalAmount += item.Price * item.Quantity
+		}
+		if totalAmount != cmd.Amount {
+			return nil, errors.NewValidationError(fmt.Sprintf("total item amount (%d) does not match payment amount (%d)", totalAmount, cmd.Amount))
+		}
+	}
+
+	method := aggregate.PaymentMethodPayOS
+	if cmd.Method != "" {
+		method = aggregate.PaymentMethod(strings.ToUpper(cmd.Method))
 	}
-	if totalAmount != cmd.Amount {
-		return nil, errors.NewValidationError(fmt.Sprintf("total item amount (%d) does not match payment amount (%d)", totalAmount, cmd.Amount))
+	paymentGateway, err := h.gateways.Get(method)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported payment method: %s", cmd.Method))
 	}
 
 	// Create unit of work
@@ -96,60 +128,101 @@ func (h *CreatePaymentWithUoWHandler) Handle(ctx context.Context, cmd *CreatePay
 		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
 	}
 
-	// Create payment aggregate with schedule information
-	payment, err := aggregate.NewPayment(cmd.UserID, cmd.Amount, cmd.Description, cmd.Items, cmd.VendorID, cmd.PetID, cmd.ServiceIDs, startTime, endTime)
+	// The vendor decides which payment methods customers can book with
+	vendor, err := uow.VendorRepository().GetByID(ctx, cmd.VendorID)
 	if err != nil {
 		uow.Rollback(ctx)
-		return nil, errors.NewValidationError(fmt.Sprintf("failed to create payment: %v", err))
+		return nil, errors.NewNotFoundError("vendor")
+	}
+	if !vendor.AcceptsPaymentMethod(method) {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("vendor does not accept payment method %s", method))
 	}
 
-	// Convert items for PayOS API
-	payOSItems := make([]payos.PaymentItem, len(cmd.Items))
-	for i, item := range cmd.Items {
-		payOSItems[i] = payos.PaymentItem{
-			Name:     item.Name,
-			Quantity: item.Quantity,
-			Price:    item.Price,
+	var payment *aggregate.Payment
+	if multiPet {
+		payment, err = h.newMultiPetPayment(ctx, uow, cmd, startTime, endTime, method)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+	} else {
+		// Check that the services take this pet before the customer pays
+		pet, err := uow.PetRepository().GetByID(ctx, cmd.PetID)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewNotFoundError("pet")
+		}
+		for _, serviceID := range cmd.ServiceIDs {
+			service, err := uow.ServiceRepository().GetByID(ctx, serviceID)
+			if err != nil {
+				uow.Rollback(ctx)
+				return nil, errors.NewNotFoundError("service")
+			}
+			if err := checkServiceAcceptsPet(ctx, uow, service, pet); err != nil {
+				uow.Rollback(ctx)
+				return nil, err
+			}
 		}
-	}
 
-	// Create payment request for PayOS
-	// PayOS requires description to be max 25 characters
-	description := cmd.Description
-	if len(description) > 25 {
-		description = description[:25]
+		// Create payment aggregate with schedule information
+		payment, err = aggregate.NewPayment(cmd.UserID, cmd.Amount, cmd.Description, cmd.Items, cmd.VendorID, cmd.PetID, cmd.ServiceIDs, startTime, endTime, method)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewValidationError(fmt.Sprintf("failed to create payment: %v", err))
+		}
 	}
-	
-	payOSReq := &payos.CreatePaymentRequest{
-		OrderCode:   payment.OrderCode(),
-		Amount:      cmd.Amount,
-		Description: description,
-		Items:       payOSItems,
-		ReturnURL:   h.payOSService.GetReturnURL(),
-		CancelURL:   h.payOSService.GetCancelURL(),
+
+	// A slot offered from the waitlist is claimed first, so its own offer does not block the hold
+	var waitlistEvents []event.DomainEvent
+	if cmd.WaitlistEntryID != "" {
+		waitlistEvents, err = claimWaitlistOffer(ctx, uow, cmd.WaitlistEntryID, payment)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
 	}
 
-	// Create payment in PayOS using the service
-	payOSResp, err := h.payOSService.CreatePaymentLink(ctx, payOSReq)
+	// Hold the time while the customer pays, so no one else can pay for it meanwhile
+	hold, err := placeSlotHold(ctx, uow, payment)
 	if err != nil {
 		uow.Rollback(ctx)
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to create PayOS payment: %v", err))
+		return nil, err
 	}
 
-	if !payOSResp.Success {
-		uow.Rollback(ctx)
-		return nil, errors.NewInternalError(fmt.Sprintf("PayOS payment creation failed: %s", payOSResp.Desc))
+	// Redeem the coupon in the same transaction so usage limits hold under concurrent bookings
+	var promotion *aggregate.Promotion
+	if cmd.CouponCode != "" {
+		promotion, err = h.redeemCoupon(ctx, uow, payment, cmd.CouponCode)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
 	}
 
-	// Update payment with PayOS details
-	err = payment.SetPayOSDetails(
-		payOSResp.Data.PaymentLinkId,
-		payOSResp.Data.CheckoutUrl,
-		payOSResp.Data.QrCode,
-	)
+	// Create payment with the gateway
+	gatewayResult, err := paymentGateway.CreatePayment(ctx, &gateway.CreatePaymentRequest{
+		OrderCode:   payment.OrderCode(),
+		Amount:      payment.Amount(),
+		Description: payment.Description(),
+		Items:       payment.Items(),
+	})
 	if err != nil {
 		uow.Rollback(ctx)
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to set PayOS details: %v", err))
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to create %s payment: %v", method, err))
+	}
+
+	// Update payment with the gateway checkout details (offline payments have none)
+	if gatewayResult.TransactionID != "" || gatewayResult.CheckoutURL != "" {
+		err = payment.SetPayOSDetails(
+			gatewayResult.TransactionID,
+			gatewayResult.CheckoutURL,
+			gatewayResult.QRCode,
+		)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to set payment details: %v", err))
+		}
 	}
 
 	// CRITICAL: Get events BEFORE calling Save (Save will mark them as committed and clear them!)
@@ -170,7 +243,27 @@ func (h *CreatePaymentWithUoWHandler) Handle(ctx context.Context, cmd *CreatePay
 		uow.Rollback(ctx)
 		return nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
 	}
+
+	events = append(events, hold.GetUncommittedEvents()...)
+	if err := uow.SlotHoldRepository().Save(ctx, hold); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save slot hold: %v", err))
+	}
+	events = append(events, waitlistEvents...)
+
+	if promotion != nil {
+		events = append(events, promotion.GetUncommittedEvents()...)
+		if err := uow.PromotionRepository().Save(ctx, promotion); err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to save promotion: %v", err))
+		}
+	}
 	
+	// Commit transaction
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
 	if len(events) == 0 {
 		fmt.Printf("⚠️  WARNING: No events to publish!\n")
 	} else {
@@ -185,39 +278,185 @@ func (h *CreatePaymentWithUoWHandler) Handle(ctx context.Context, cmd *CreatePay
 		}
 	}
 
-	// Commit transaction
-	if err := uow.Commit(ctx); err != nil {
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	// Pay at shop bookings are confirmed immediately; the vendor marks the payment collected later
+	if method.IsCollectedByVendor() && h.createScheduleHandler != nil {
+		scheduleCmd := &CreateSchedule{
+			UserID:     payment.UserID(),
+			VendorID:   payment.VendorID(),
+			PetID:      payment.PetID(),
+			ServiceIDs: payment.ServiceIDs(),
+			StartTime:  payment.StartTime().Format(time.RFC3339),
+			EndTime:    payment.EndTime().Format(time.RFC3339),
+			PaymentID:  payment.ID(),
+			TotalPrice: payment.Amount(),
+			Pets:       payment.PetLines(),
+		}
+		if err := h.createScheduleHandler.Handle(ctx, scheduleCmd); err != nil {
+			fmt.Printf("❌ Failed to create schedule for pay at shop payment %s: %v\n", payment.ID(), err)
+			// Nothing was paid yet, so the payment is cancelled and its time given back rather than left to expire
+			h.cancelUnbookedPayment(ctx, payment.ID(), fmt.Sprintf("Booking could not be created: %v", err))
+			return nil, err
+		}
 	}
 
 	return &CreatePaymentResponse{
 		PaymentID:   payment.ID(),
 		OrderCode:   payment.OrderCode(),
-		CheckoutURL: payOSResp.Data.CheckoutUrl,
-		QRCode:      payOSResp.Data.QrCode,
+		CheckoutURL: gatewayResult.CheckoutURL,
+		QRCode:      gatewayResult.QRCode,
 		Amount:      payment.Amount(),
 		Status:      string(payment.Status()),
+		Method:      string(payment.Method()),
 		ExpiredAt:   payment.ExpiredAt().Format("2006-01-02T15:04:05Z07:00"),
+
+		PromotionCode:  payment.PromotionCode(),
+		DiscountAmount: payment.DiscountAmount(),
 	}, nil
 }
 
+// cancelUnbookedPayment cancels a pay at shop payment whose booking could not be created and releases what
+// it held: the time held during checkout and the coupon redeemed with it
+func (h *CreatePaymentWithUoWHandler) cancelUnbookedPayment(ctx context.Context, paymentID, reason string) {
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		fmt.Printf("❌ Failed to begin cancellation transaction: %v\n", err)
+		return
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		fmt.Printf("❌ Failed to get payment for cancellation: %v\n", err)
+		uow.Rollback(ctx)
+		return
+	}
+	if payment.Status() != aggregate.PaymentStatusPending {
+		uow.Rollback(ctx)
+		return
+	}
+
+	if err := payment.MarkAsCancelled(); err != nil {
+		fmt.Printf("❌ Failed to cancel unbooked payment %s: %v\n", paymentID, err)
+		uow.Rollback(ctx)
+		return
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		fmt.Printf("❌ Failed to save cancellation of unbooked payment %s: %v\n", paymentID, err)
+		uow.Rollback(ctx)
+		return
+	}
+
+	releaseEvents, err := ReleaseUnpaidPayment(ctx, uow, payment, reason)
+	if err != nil {
+		fmt.Printf("❌ Failed to release unbooked payment %s: %v\n", paymentID, err)
+		uow.Rollback(ctx)
+		return
+	}
+	events = append(events, releaseEvents...)
+
+	if err := uow.Commit(ctx); err != nil {
+		fmt.Printf("❌ Failed to commit cancellation of unbooked payment %s: %v\n", paymentID, err)
+		return
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish payment events: %v\n", err)
+	}
+
+	fmt.Printf("🚫 Cancelled unbooked pay at shop payment %s\n", paymentID)
+}
+
+// newMultiPetPayment creates the payment of a booking covering several pets. Every pet is one item priced
+// at its services, and the booking lasts the combined duration of the services unless an end time is given.
+func (h *CreatePaymentWithUoWHandler) newMultiPetPayment(ctx context.Context, uow repository.UnitOfWork, cmd *CreatePaymentCommand,
+	startTime, endTime time.Time, method aggregate.PaymentMethod) (*aggregate.Payment, error) {
+	lines, err := quotePetLines(ctx, uow, cmd.UserID, cmd.VendorID, cmd.Pets, true)
+	if err != nil {
+		return nil, err
+	}
+
+	items, amount, duration := petLineItems(lines)
+	if cmd.Amount != 0 && cmd.Amount != amount {
+		return nil, errors.NewValidationError(fmt.Sprintf("payment amount (%d) does not match the price of the pets' services (%d)", cmd.Amount, amount))
+	}
+	if endTime.IsZero() {
+		endTime = startTime.Add(duration)
+	}
+	if endTime.Sub(startTime) < duration {
+		return nil, errors.NewValidationError(fmt.Sprintf("booked time is shorter than the %d minutes the pets' services take", int(duration/time.Minute)))
+	}
+
+	description := cmd.Description
+	if description == "" {
+		description = fmt.Sprintf("Booking for %d pets", len(lines))
+	}
+
+	payment, err := aggregate.NewMultiPetPayment(cmd.UserID, amount, description, items, cmd.VendorID, cmd.Pets, startTime, endTime, method)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to create payment: %v", err))
+	}
+	return payment, nil
+}
+
+// redeemCoupon looks up a coupon code, records the redemption on the promotion and discounts the payment
+func (h *CreatePaymentWithUoWHandler) redeemCoupon(ctx context.Context, uow repository.UnitOfWork, payment *aggregate.Payment, code string) (*aggregate.Promotion, error) {
+	promotion, err := uow.PromotionRepository().GetByCode(ctx, code)
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to get promotion: %v", err))
+	}
+	if promotion == nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid coupon code: %s", code))
+	}
+
+	// The platform can only fund a discount on money it collects
+	if promotion.FundedBy() == aggregate.PromotionFundedByPlatform && payment.Method().IsCollectedByVendor() {
+		return nil, errors.NewValidationError(fmt.Sprintf("coupon %s cannot be used with payment method %s", promotion.Code(), payment.Method()))
+	}
+
+	firstBooking := true
+	if promotion.FirstBookingOnly() {
+		hasPaid, err := uow.PaymentRepository().HasPaidPayment(ctx, payment.UserID())
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to check booking history: %v", err))
+		}
+		firstBooking = !hasPaid
+	}
+
+	discount, err := promotion.Redeem(payment.ID(), payment.UserID(), payment.VendorID(), payment.ServiceIDs(), payment.Amount(), time.Now(), firstBooking)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("coupon %s cannot be applied: %v", promotion.Code(), err))
+	}
+
+	if err := payment.ApplyDiscount(promotion.ID(), promotion.Code(), discount, promotion.FundedBy()); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to apply coupon: %v", err))
+	}
+
+	return promotion, nil
+}
+
 // CancelPaymentWithUoWHandler handles cancel payment commands with Unit of Work
 type CancelPaymentWithUoWHandler struct {
-	uowFactory   repository.UnitOfWorkFactory
-	eventBus     bus.EventBus
-	payOSService *payos.Service
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
 }
 
 // NewCancelPaymentWithUoWHandler creates a new cancel payment handler with UoW
 func NewCancelPaymentWithUoWHandler(
 	uowFactory repository.UnitOfWorkFactory,
 	eventBus bus.EventBus,
-	payOSService *payos.Service,
+	gateways *gateway.Registry,
 ) *CancelPaymentWithUoWHandler {
 	return &CancelPaymentWithUoWHandler{
-		uowFactory:   uowFactory,
-		eventBus:     eventBus,
-		payOSService: payOSService,
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
 	}
 }
 
@@ -253,12 +492,16 @@ func (h *CancelPaymentWithUoWHandler) Handle(ctx context.Context, cmd *CancelPay
 		reason = "Cancelled by user"
 	}
 
-	// Cancel in PayOS if still pending
+	// Cancel with the gateway if still pending
 	if payment.Status() == aggregate.PaymentStatusPending {
-		err = h.payOSService.CancelPaymentLink(ctx, payment.OrderCode(), reason)
+		paymentGateway, err := h.gateways.Get(payment.Method())
 		if err != nil {
 			uow.Rollback(ctx)
-			return errors.NewInternalError(fmt.Sprintf("failed to cancel PayOS payment: %v", err))
+			return errors.NewInternalError(err.Error())
+		}
+		if err := paymentGateway.CancelPayment(ctx, payment.OrderCode(), reason); err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(fmt.Sprintf("failed to cancel %s payment: %v", payment.Method(), err))
 		}
 	}
 
@@ -278,16 +521,24 @@ func (h *CancelPaymentWithUoWHandler) Handle(ctx context.Context, cmd *CancelPay
 		return errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
 	}
 
-	// Publish events asynchronously
-	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
-		fmt.Printf("Warning: failed to publish payment events: %v\n", err)
+	// Give back the time held during checkout; a recurring booking cannot wait for a payment that will never come
+	releaseEvents, err := ReleaseUnpaidPayment(ctx, uow, payment, reason)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(err.Error())
 	}
+	events = append(events, releaseEvents...)
 
 	// Commit transaction
 	if err := uow.Commit(ctx); err != nil {
 		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
 	}
 
+	// Publish only once committed; the ledger and invoices act on these events
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish payment events: %v\n", err)
+	}
+
 	return nil
 }
 
@@ -295,25 +546,25 @@ func (h *CancelPaymentWithUoWHandler) Handle(ctx context.Context, cmd *CancelPay
 type ConfirmPaymentWithUoWHandler struct {
 	uowFactory              repository.UnitOfWorkFactory
 	eventBus                bus.EventBus
-	payOSService            *payos.Service
-	payoutService           *payos.PayoutService
+	gateways                *gateway.Registry
 	createScheduleHandler   *CreateScheduleWithUoWHandler
+	generateSeriesHandler   *GenerateSeriesOccurrencesWithUoWHandler
 }
 
 // NewConfirmPaymentWithUoWHandler creates a new confirm payment handler with UoW
 func NewConfirmPaymentWithUoWHandler(
 	uowFactory repository.UnitOfWorkFactory,
 	eventBus bus.EventBus,
-	payOSService *payos.Service,
-	payoutService *payos.PayoutService,
+	gateways *gateway.Registry,
 	createScheduleHandler *CreateScheduleWithUoWHandler,
+	generateSeriesHandler *GenerateSeriesOccurrencesWithUoWHandler,
 ) *ConfirmPaymentWithUoWHandler {
 	return &ConfirmPaymentWithUoWHandler{
 		uowFactory:              uowFactory,
 		eventBus:                eventBus,
-		payOSService:            payOSService,
-		payoutService:           payoutService,
+		gateways:                gateways,
 		createScheduleHandler:   createScheduleHandler,
+		generateSeriesHandler:   generateSeriesHandler,
 	}
 }
 
@@ -351,39 +602,37 @@ func (h *ConfirmPaymentWithUoWHandler) Handle(ctx context.Context, cmd *ConfirmP
 	
 	fmt.Printf("✅ Found payment: ID=%s, Current Status=%s\n", payment.ID(), payment.Status())
 
-	// Verify payment status with PayOS
-	fmt.Printf("🔍 Checking payment status with PayOS...\n")
-	payOSInfo, err := h.payOSService.GetPaymentLinkInformation(ctx, cmd.OrderCode)
+	// Verify payment status with the payment's gateway
+	paymentGateway, err := h.gateways.Get(payment.Method())
 	if err != nil {
 		uow.Rollback(ctx)
-		fmt.Printf("❌ Failed to get PayOS info: %v\n", err)
-		return errors.NewInternalError(fmt.Sprintf("failed to get PayOS payment info: %v", err))
+		return errors.NewInternalError(err.Error())
 	}
 
-	if !payOSInfo.Success {
+	fmt.Printf("🔍 Checking payment status with %s...\n", payment.Method())
+	gatewayStatus, err := paymentGateway.VerifyPayment(ctx, cmd.OrderCode)
+	if err != nil {
 		uow.Rollback(ctx)
-		fmt.Printf("❌ PayOS request failed: %s\n", payOSInfo.Desc)
-		return errors.NewInternalError(fmt.Sprintf("PayOS payment info request failed: %s", payOSInfo.Desc))
+		fmt.Printf("❌ Failed to verify payment: %v\n", err)
+		return errors.NewInternalError(fmt.Sprintf("failed to verify %s payment: %v", payment.Method(), err))
 	}
 
-	fmt.Printf("💰 PayOS Status: %s\n", payOSInfo.Data.Status)
-
-	// Update payment status based on PayOS response
+	// Update payment status based on the gateway response
 	var paymentWasPaid bool
-	switch payOSInfo.Data.Status {
-	case "PAID":
+	switch gatewayStatus {
+	case aggregate.PaymentStatusPaid:
 		fmt.Printf("✅ Payment is PAID - marking as paid\n")
 		err = payment.MarkAsPaid()
 		paymentWasPaid = true
-	case "CANCELLED":
+	case aggregate.PaymentStatusCancelled:
 		fmt.Printf("❌ Payment is CANCELLED - marking as cancelled\n")
 		err = payment.MarkAsCancelled()
-	case "EXPIRED":
+	case aggregate.PaymentStatusExpired:
 		fmt.Printf("⏰ Payment is EXPIRED - marking as expired\n")
 		err = payment.MarkAsExpired()
 	default:
 		// Payment is still pending or in unknown state
-		fmt.Printf("⚠️  Payment status unknown or still pending: %s\n", payOSInfo.Data.Status)
+		fmt.Printf("⚠️  Payment status unknown or still pending: %s\n", gatewayStatus)
 		uow.Rollback(ctx)
 		return nil
 	}
@@ -402,9 +651,41 @@ func (h *ConfirmPaymentWithUoWHandler) Handle(ctx context.Context, cmd *ConfirmP
 		return errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
 	}
 
-	// Publish events asynchronously
-	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
-		fmt.Printf("Warning: failed to publish payment events: %v\n", err)
+	// A paid reschedule top-up keeps the booking at its new time, so its earlier time is given back
+	if paymentWasPaid && payment.IsForExistingBooking() {
+		_, releaseEvents, err := releaseSlotHold(ctx, uow, payment.ID(), "Top-up paid")
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(err.Error())
+		}
+		events = append(events, releaseEvents...)
+	}
+
+	// A paid top-up, occurrence or upfront series payment, or a paid booking without a schedule handler, does
+	// not create a booking, so it is added to the vendor's settlement together with the payment status. A
+	// failure leaves the payment unconfirmed for the next webhook or status check to retry.
+	if paymentWasPaid && (payment.IsSeriesUpfront() || payment.IsForExistingBooking() || h.createScheduleHandler == nil) {
+		vendor, err := uow.VendorRepository().GetByID(ctx, payment.VendorID())
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(fmt.Sprintf("failed to get vendor for settlement: %v", err))
+		}
+
+		settlementEvents, err := RecordSettlementEarning(ctx, uow, vendor, payment.ID(), payment.ScheduleID(), payment.VendorEarning(), time.Now())
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(fmt.Sprintf("failed to record settlement earning: %v", err))
+		}
+		events = append(events, settlementEvents...)
+	}
+
+	if !paymentWasPaid {
+		releaseEvents, err := ReleaseUnpaidPayment(ctx, uow, payment, fmt.Sprintf("Payment %s", strings.ToLower(string(payment.Status()))))
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(err.Error())
+		}
+		events = append(events, releaseEvents...)
 	}
 
 	// Commit transaction
@@ -412,134 +693,26 @@ func (h *ConfirmPaymentWithUoWHandler) Handle(ctx context.Context, cmd *ConfirmP
 		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
 	}
 
-	// CREATE PAYOUT BEFORE SCHEDULE: If payment was successful, create and process payout to vendor
-	if paymentWasPaid && h.payoutService != nil {
-		fmt.Printf("========================================\n")
-		fmt.Printf("💰 Auto-creating and processing payout for payment ID: %s\n", payment.ID())
-		
-		// Get vendor information to check bank account
-		uow2 := h.uowFactory.CreateUnitOfWork()
-		defer uow2.Close()
-		
-		if err := uow2.Begin(ctx); err != nil {
-			fmt.Printf("❌ Failed to begin payout transaction: %v\n", err)
-		} else {
-			vendorRepo := uow2.VendorRepository()
-			vendor, err := vendorRepo.GetByID(ctx, payment.VendorID())
-			if err != nil {
-				fmt.Printf("❌ Failed to get vendor for payout: %v\n", err)
-				_ = uow2.Rollback(ctx)
-			} else if !vendor.HasBankAccount() {
-				fmt.Printf("⚠️ Vendor does not have bank account - payout creation skipped\n")
-				_ = uow2.Rollback(ctx)
-			} else {
-				// Vendor has bank account - create payout
-				vendorBankAccount := vendor.GetBankAccount()
-				payoutAmount := payment.Amount()
-				
-				// Generate temporary schedule ID for payout (will be updated later)
-				tempScheduleID := "PENDING"
-				payoutID := fmt.Sprintf("PAYOUT-%d", time.Now().UnixNano())
-				
-				fmt.Printf("💰 Creating payout: ID=%s, Amount=%d, VendorID=%s, PaymentID=%s\n",
-					payoutID, payoutAmount, payment.VendorID(), payment.ID())
-				
-				// Create payout aggregate
-				bankAccount := aggregate.BankAccount{
-					BankName:      vendorBankAccount.BankName,
-					AccountNumber: vendorBankAccount.AccountNumber,
-					AccountName:   vendorBankAccount.AccountName,
-					BankBranch:    vendorBankAccount.BankBranch,
-				}
-				
-				payout, err := aggregate.NewPayout(
-					payoutID,
-					payment.VendorID(),
-					payment.ID(),
-					tempScheduleID,
-					payoutAmount,
-					bankAccount,
-					"Pet care service", // Max 25 chars for PayOS
-				)
-				
-				if err != nil {
-					fmt.Printf("❌ Failed to create payout: %v\n", err)
-					_ = uow2.Rollback(ctx)
-				} else {
-					// Save payout in PENDING status first
-					payoutRepo := uow2.PayoutRepository()
-					if err := payoutRepo.Save(ctx, payout); err != nil {
-						fmt.Printf("❌ Failed to save payout: %v\n", err)
-						_ = uow2.Rollback(ctx)
-					} else {
-						// Commit payout creation
-						if err := uow2.Commit(ctx); err != nil {
-							fmt.Printf("❌ Failed to commit payout: %v\n", err)
-						} else {
-							fmt.Printf("✅ Payout saved with status: %s\n", payout.Status())
-							
-							// Now process the actual bank transfer
-							fmt.Printf("🏦 Processing bank transfer to %s - %s\n", 
-								vendorBankAccount.BankName, vendorBankAccount.AccountNumber)
-							
-							transferInfo, transferErr := h.payoutService.ProcessPayout(
-								ctx,
-								payout.ID(),
-								vendorBankAccount.BankName,
-								vendorBankAccount.AccountNumber,
-								vendorBankAccount.AccountName,
-								payoutAmount,
-								"Pet care service", // Max 25 chars for PayOS
-							)
-							
-							// Update payout status based on transfer result
-							uow3 := h.uowFactory.CreateUnitOfWork()
-							defer uow3.Close()
-							
-							if err := uow3.Begin(ctx); err == nil {
-								payoutRepo2 := uow3.PayoutRepository()
-								payout2, err := payoutRepo2.GetByID(ctx, payoutID)
-								
-								if err == nil {
-									if transferErr != nil {
-										fmt.Printf("❌ Bank transfer failed: %v\n", transferErr)
-										_ = payout2.MarkAsFailed(transferErr.Error())
-									} else if transferInfo.Status == "SUCCEEDED" {
-										fmt.Printf("✅ Bank transfer SUCCEEDED! Transfer ID: %s\n", transferInfo.TransferID)
-										_ = payout2.MarkAsProcessing(transferInfo.TransferID)
-										_ = payout2.MarkAsCompleted()
-									} else if transferInfo.Status == "FAILED" {
-										errorMsg := "Transfer failed"
-										if transferInfo.ErrorMessage != "" {
-											errorMsg = transferInfo.ErrorMessage
-										}
-										fmt.Printf("❌ Transfer failed: %s\n", errorMsg)
-										_ = payout2.MarkAsFailed(errorMsg)
-									} else {
-										fmt.Printf("⏳ Transfer is PROCESSING (status: %s) - marking as processing\n", transferInfo.Status)
-										if transferInfo.TransferID != "" {
-											_ = payout2.MarkAsProcessing(transferInfo.TransferID)
-										} else {
-											_ = payout2.MarkAsProcessing("PROCESSING")
-										}
-									}
-									
-									_ = payoutRepo2.Save(ctx, payout2)
-									_ = uow3.Commit(ctx)
-									
-									fmt.Printf("✅ Payout final status: %s\n", payout2.Status())
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-		fmt.Printf("========================================\n")
+	// Publish only once committed; the ledger and invoices act on these events
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish payment events: %v\n", err)
 	}
 
-	// AUTO-CREATE SCHEDULE: If payment was successful, automatically create a schedule
-	if paymentWasPaid && h.createScheduleHandler != nil {
+	// AUTO-CREATE SCHEDULE: If payment was successful, automatically create a schedule.
+	// Schedule creation also adds the booking to the vendor's settlement, which is paid out
+	// in periodic batches by the settlement service instead of one transfer per booking.
+	// Top-ups and occurrence payments pay for an existing booking; their settlement was recorded above.
+	// An upfront payment of a recurring booking is settled as a whole and starts booking its occurrences.
+	if paymentWasPaid && payment.IsSeriesUpfront() {
+		fmt.Printf("🔁 Upfront payment %s paid for booking series %s\n", payment.ID(), payment.SeriesID())
+		if h.generateSeriesHandler != nil {
+			if _, err := h.generateSeriesHandler.Activate(ctx, payment.SeriesID(), payment.ID()); err != nil {
+				fmt.Printf("❌ Failed to activate booking series %s: %v\n", payment.SeriesID(), err)
+			}
+		}
+	} else if paymentWasPaid && payment.IsForExistingBooking() {
+		fmt.Printf("💰 Payment %s paid for existing schedule %s\n", payment.ID(), payment.ScheduleID())
+	} else if paymentWasPaid && h.createScheduleHandler != nil {
 		fmt.Printf("========================================\n")
 		fmt.Printf("📅 Auto-creating schedule for payment ID: %s\n", payment.ID())
 		fmt.Printf("   UserID: %s\n", payment.UserID())
@@ -557,12 +730,16 @@ func (h *ConfirmPaymentWithUoWHandler) Handle(ctx context.Context, cmd *ConfirmP
 			EndTime:    payment.EndTime().Format(time.RFC3339),
 			PaymentID:  payment.ID(),
 			TotalPrice: payment.Amount(),
+			Pets:       payment.PetLines(),
 		}
 		
 		if err := h.createScheduleHandler.Handle(ctx, scheduleCmd); err != nil {
 			// Log error but don't fail the payment confirmation
 			fmt.Printf("❌ Failed to auto-create schedule: %v\n", err)
 			fmt.Printf("========================================\n")
+
+			// The customer has paid for a booking that could not be made, so the payment is refunded
+			h.refundUnbookedPayment(ctx, payment, fmt.Sprintf("Booking could not be created: %v", err))
 		} else {
 			fmt.Printf("✅ Successfully auto-created schedule!\n")
 			fmt.Printf("========================================\n")
@@ -576,3 +753,370 @@ func (h *ConfirmPaymentWithUoWHandler) Handle(ctx context.Context, cmd *ConfirmP
 	fmt.Printf("========================================\n")
 	return nil
 }
+
+// refundUnbookedPayment reserves a full refund of a paid payment whose booking could not be created, then
+// asks the payment gateway to execute it. When the gateway fails the refund stays in progress for an
+// admin to retry. The vendor was never credited with the payment, so nothing is deducted from them.
+func (h *ConfirmPaymentWithUoWHandler) refundUnbookedPayment(ctx context.Context, payment *aggregate.Payment, reason string) {
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		fmt.Printf("❌ Failed to begin refund transaction: %v\n", err)
+		return
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, payment.ID())
+	if err != nil {
+		fmt.Printf("❌ Failed to get payment for refund: %v\n", err)
+		uow.Rollback(ctx)
+		return
+	}
+	if payment.PendingRefund() != nil || payment.Status() != aggregate.PaymentStatusPaid {
+		uow.Rollback(ctx)
+		return
+	}
+
+	refundID, err := requestPaymentRefund(payment, payment.RefundableAmount(), reason)
+	if err != nil {
+		fmt.Printf("❌ Failed to reserve refund of unbooked payment %s: %v\n", payment.ID(), err)
+		uow.Rollback(ctx)
+		return
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		fmt.Printf("❌ Failed to save refund of unbooked payment %s: %v\n", payment.ID(), err)
+		uow.Rollback(ctx)
+		return
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		fmt.Printf("❌ Failed to commit refund of unbooked payment %s: %v\n", payment.ID(), err)
+		return
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish refund events: %v\n", err)
+	}
+
+	fmt.Printf("💸 Refund %s reserved for unbooked payment %s\n", refundID, payment.ID())
+	if _, _, err := completePaymentRefund(ctx, h.uowFactory, h.eventBus, h.gateways, payment.ID()); err != nil {
+		fmt.Printf("Warning: failed to complete refund %s: %v\n", refundID, err)
+	}
+}
+
+// MarkPaymentCollectedWithUoWHandler handles vendors confirming pay at shop payments with Unit of Work
+type MarkPaymentCollectedWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewMarkPaymentCollectedWithUoWHandler creates a new mark payment collected handler with UoW
+func NewMarkPaymentCollectedWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *MarkPaymentCollectedWithUoWHandler {
+	return &MarkPaymentCollectedWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the mark payment collected command
+func (h *MarkPaymentCollectedWithUoWHandler) Handle(ctx context.Context, cmd *MarkPaymentCollectedCommand) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.PaymentID == "" {
+		return errors.NewValidationError("payment_id is required")
+	}
+	if cmd.CollectedBy == "" {
+		return errors.NewValidationError("collected_by is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, cmd.PaymentID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError(fmt.Sprintf("payment not found: %v", err))
+	}
+
+	// Only staff of the vendor the booking was made with can collect the payment
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, cmd.CollectedBy+"-"+payment.VendorID())
+	if err != nil || staff == nil || !staff.IsActive() {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only staff of the payment's vendor can mark it as collected")
+	}
+
+	if err := payment.MarkAsCollected(cmd.CollectedBy); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to mark payment as collected: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish payment events: %v\n", err)
+	}
+
+	return nil
+}
+
+// RefundPaymentWithUoWHandler handles refund payment commands with Unit of Work
+type RefundPaymentWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewRefundPaymentWithUoWHandler creates a new refund payment handler with UoW
+func NewRefundPaymentWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *RefundPaymentWithUoWHandler {
+	return &RefundPaymentWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle processes the refund payment command. The refund is reserved on the payment and committed
+// before the payment gateway is called, then recorded in a second step; when the gateway fails the refund
+// stays in progress and refunding the payment again retries it with the same idempotency key. Refunds of
+// online payments are deducted from the vendor's next settlement; pay at shop refunds are handed back by
+// the vendor directly.
+func (h *RefundPaymentWithUoWHandler) Handle(ctx context.Context, cmd *RefundPaymentCommand) (*RefundPaymentResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.PaymentID == "" {
+		return nil, errors.NewValidationError("payment_id is required")
+	}
+	if cmd.Amount < 0 {
+		return nil, errors.NewValidationError("amount cannot be negative")
+	}
+
+	if err := h.requestRefund(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	payment, refund, err := completePaymentRefund(ctx, h.uowFactory, h.eventBus, h.gateways, cmd.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefundPaymentResponse{
+		RefundID:       refund.RefundID,
+		PaymentID:      payment.ID(),
+		Amount:         refund.Amount,
+		RefundedAmount: payment.RefundedAmount(),
+		Status:         string(payment.Status()),
+		Manual:         refund.Manual,
+	}, nil
+}
+
+// requestRefund reserves the refund on the payment, unless a refund of the same amount is already in
+// progress and only needs to be retried
+func (h *RefundPaymentWithUoWHandler) requestRefund(ctx context.Context, cmd *RefundPaymentCommand) error {
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, cmd.PaymentID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError(fmt.Sprintf("payment not found: %v", err))
+	}
+
+	if pending := payment.PendingRefund(); pending != nil {
+		uow.Rollback(ctx)
+		if cmd.Amount != 0 && cmd.Amount != pending.Amount {
+			return errors.NewConflictError(fmt.Sprintf("refund %s of %d is still in progress", pending.RefundID, pending.Amount))
+		}
+		return nil
+	}
+
+	if payment.Status() != aggregate.PaymentStatusPaid {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("cannot refund payment with status %s", payment.Status()))
+	}
+
+	amount := cmd.Amount
+	if amount == 0 {
+		amount = payment.RefundableAmount()
+	}
+
+	if _, err := requestPaymentRefund(payment, amount, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish refund events: %v\n", err)
+	}
+
+	return nil
+}
+
+// PaymentRefundResult describes a refund executed by completePaymentRefund
+type PaymentRefundResult struct {
+	RefundID string
+	Amount   int
+	Manual   bool // Money must be returned to the customer outside the gateway
+}
+
+// requestPaymentRefund reserves a refund on a paid payment within the caller's unit of work and returns
+// its ID. Once the unit of work is committed the caller executes it with completePaymentRefund, so the
+// payment gateway is never called inside an open transaction.
+func requestPaymentRefund(payment *aggregate.Payment, amount int, reason string) (string, error) {
+	refundID := uuid.New().String()
+	if err := payment.RequestRefund(refundID, amount, reason); err != nil {
+		return "", errors.NewValidationError(fmt.Sprintf("failed to refund payment: %v", err))
+	}
+	return refundID, nil
+}
+
+// completePaymentRefund asks the payment gateway to execute the refund in progress on a payment, with
+// the refund ID as idempotency key, then records it in a unit of work of its own. Refunds of online
+// payments are deducted from the vendor's settlement; a full refund also claws back the platform-funded
+// discount the vendor was credited with. When the gateway fails the refund stays in progress.
+func completePaymentRefund(ctx context.Context, uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus,
+	gateways *gateway.Registry, paymentID string) (*aggregate.Payment, *PaymentRefundResult, error) {
+	readUow := uowFactory.CreateUnitOfWork()
+	defer readUow.Close()
+
+	payment, err := readUow.PaymentRepository().GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, nil, errors.NewNotFoundError(fmt.Sprintf("payment not found: %v", err))
+	}
+	pending := payment.PendingRefund()
+	if pending == nil {
+		return nil, nil, errors.NewConflictError("payment has no refund in progress")
+	}
+
+	paymentGateway, err := gateways.Get(payment.Method())
+	if err != nil {
+		return nil, nil, errors.NewInternalError(err.Error())
+	}
+
+	refund, err := paymentGateway.RefundPayment(ctx, &gateway.RefundRequest{
+		PaymentID:      payment.ID(),
+		OrderCode:      payment.OrderCode(),
+		Amount:         pending.Amount,
+		Reason:         pending.Reason,
+		IdempotencyKey: pending.RefundID,
+	})
+	if err != nil {
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to refund %s payment, refund %s stays in progress: %v", payment.Method(), pending.RefundID, err))
+	}
+	result := &PaymentRefundResult{RefundID: pending.RefundID, Amount: pending.Amount, Manual: refund.Manual}
+
+	uow := uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err = paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, nil, errors.NewNotFoundError(fmt.Sprintf("payment not found: %v", err))
+	}
+
+	// A concurrent retry already recorded the refund
+	if current := payment.PendingRefund(); current == nil || current.RefundID != pending.RefundID {
+		uow.Rollback(ctx)
+		return payment, result, nil
+	}
+
+	if err := payment.Refund(pending.RefundID, pending.Amount, pending.Reason); err != nil {
+		uow.Rollback(ctx)
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("failed to refund payment: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		uow.Rollback(ctx)
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	// Only payments the vendor was credited with are deducted from their settlement
+	earned, err := uow.SettlementRepository().GetByPaymentID(ctx, payment.ID())
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to get settlement of payment: %v", err))
+	}
+
+	if earned != nil && !payment.Method().IsCollectedByVendor() && payment.VendorID() != "" {
+		vendor, err := uow.VendorRepository().GetByID(ctx, payment.VendorID())
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, nil, errors.NewNotFoundError("vendor")
+		}
+
+		deduction := pending.Amount
+		if payment.Status() == aggregate.PaymentStatusRefunded {
+			deduction += payment.PlatformFundedDiscount()
+		}
+
+		settlementEvents, err := RecordSettlementRefund(ctx, uow, vendor, payment.ID(), pending.RefundID, deduction, time.Now())
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to record settlement refund: %v", err))
+		}
+		events = append(events, settlementEvents...)
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish refund events: %v\n", err)
+	}
+
+	return payment, result, nil
+}
@@ -0,0 +1,189 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+)
+
+// AddPetAttachmentWithUoWHandler handles add attachment commands with Unit of Work
+type AddPetAttachmentWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAddPetAttachmentWithUoWHandler creates a new add attachment handler with UoW
+func NewAddPetAttachmentWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *AddPetAttachmentWithUoWHandler {
+	return &AddPetAttachmentWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the add attachment command
+func (h *AddPetAttachmentWithUoWHandler) Handle(ctx context.Context, cmd *AddPetAttachment) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.URL == "" || cmd.StorageKey == "" {
+		return errors.NewValidationError("an uploaded file is required")
+	}
+	cmd.Category = strings.ToUpper(strings.TrimSpace(cmd.Category))
+	if cmd.Category == "" {
+		cmd.Category = event.AttachmentCategoryOther
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.IsOwner(cmd.UploadedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can add attachments")
+	}
+
+	// Add attachment
+	if err := petAggregate.AddAttachment(
+		cmd.Category,
+		strings.TrimSpace(cmd.Title),
+		cmd.FileName,
+		cmd.ContentType,
+		cmd.Size,
+		cmd.URL,
+		cmd.StorageKey,
+		cmd.RecordType,
+		cmd.RecordID,
+		cmd.UploadedBy,
+	); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to add attachment: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet attachment events: %v\n", err)
+	}
+
+	return nil
+}
+
+// RemovePetAttachmentWithUoWHandler handles remove attachment commands with Unit of Work
+type RemovePetAttachmentWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewRemovePetAttachmentWithUoWHandler creates a new remove attachment handler with UoW
+func NewRemovePetAttachmentWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *RemovePetAttachmentWithUoWHandler {
+	return &RemovePetAttachmentWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the remove attachment command
+func (h *RemovePetAttachmentWithUoWHandler) Handle(ctx context.Context, cmd *RemovePetAttachment) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.AttachmentID == "" {
+		return errors.NewValidationError("attachment_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.IsOwner(cmd.RemovedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can remove attachments")
+	}
+
+	// Remove attachment
+	if err := petAggregate.RemoveAttachment(cmd.AttachmentID, cmd.RemovedBy); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to remove attachment: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet attachment events: %v\n", err)
+	}
+
+	return nil
+}
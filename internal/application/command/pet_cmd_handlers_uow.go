@@ -3,8 +3,11 @@ package command
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
 	"whisko-petcare/internal/domain/repository"
 	"whisko-petcare/internal/infrastructure/bus"
 	"whisko-petcare/pkg/errors"
@@ -59,13 +62,39 @@ func (h *CreatePetWithUoWHandler) Handle(ctx context.Context, cmd *CreatePet) er
 		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
 	}
 
+	// Pets store catalogue codes, so "Dog", "dog" and "chó" are the same species
+	species, breed, err := resolvePetSpecies(ctx, uow, cmd.Species, cmd.Breed, "")
+	if err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
 	// Create pet aggregate (with optional imageUrl)
-	pet, err := aggregate.NewPet(cmd.UserID, cmd.Name, cmd.Species, cmd.Breed, cmd.Age, cmd.Weight, cmd.ImageUrl)
+	pet, err := aggregate.NewPet(cmd.UserID, cmd.Name, species, breed, cmd.Age, cmd.Weight, cmd.ImageUrl)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to create pet: %v", err))
+	}
+
+	// The age is derived from the birth date; an age alone gives an estimated birth date
+	if !cmd.BirthDate.IsZero() {
+		err = pet.UpdateBirthDate(cmd.BirthDate, cmd.BirthDateEstimated)
+	} else if cmd.Age > 0 {
+		err = pet.EstimateBirthDateFromAge(cmd.Age)
+	}
 	if err != nil {
 		uow.Rollback(ctx)
 		return errors.NewValidationError(fmt.Sprintf("failed to create pet: %v", err))
 	}
 
+	// The initial weight starts the weight history
+	if cmd.Weight > 0 {
+		if err := pet.RecordWeight(cmd.Weight, time.Now(), event.WeightSourceOwner, cmd.UserID, ""); err != nil {
+			uow.Rollback(ctx)
+			return errors.NewValidationError(fmt.Sprintf("failed to create pet: %v", err))
+		}
+	}
+
 	// Get events BEFORE saving (Save() will clear them)
 	events := pet.GetUncommittedEvents()
 
@@ -143,12 +172,46 @@ func (h *UpdatePetWithUoWHandler) Handle(ctx context.Context, cmd *UpdatePet) er
 		return errors.NewNotFoundError("pet")
 	}
 
+	// A new breed alone is checked against the pet's current species
+	species, breed := cmd.Species, cmd.Breed
+	if species != "" || breed != "" {
+		speciesTerm := species
+		if speciesTerm == "" {
+			speciesTerm = petAggregate.Species()
+		}
+		species, breed, err = resolvePetSpecies(ctx, uow, speciesTerm, breed, petAggregate.Species())
+		if err != nil {
+			uow.Rollback(ctx)
+			return err
+		}
+	}
+
 	// Update pet
-	if err := petAggregate.UpdateProfile(cmd.Name, cmd.Species, cmd.Breed, cmd.Age, cmd.Weight); err != nil {
+	previousWeight := petAggregate.Weight()
+	if err := petAggregate.UpdateProfile(cmd.Name, species, breed, cmd.Age, cmd.Weight); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update pet: %v", err))
+	}
+
+	// The age is derived from the birth date; a changed age gives an estimated birth date
+	if !cmd.BirthDate.IsZero() {
+		err = petAggregate.UpdateBirthDate(cmd.BirthDate, cmd.BirthDateEstimated)
+	} else if cmd.Age > 0 {
+		err = petAggregate.EstimateBirthDateFromAge(cmd.Age)
+	}
+	if err != nil {
 		uow.Rollback(ctx)
 		return errors.NewValidationError(fmt.Sprintf("failed to update pet: %v", err))
 	}
 
+	// Keep the previous weight in the history instead of overwriting it
+	if cmd.Weight > 0 && cmd.Weight != previousWeight {
+		if err := petAggregate.RecordWeight(cmd.Weight, time.Now(), event.WeightSourceOwner, "", ""); err != nil {
+			uow.Rollback(ctx)
+			return errors.NewValidationError(fmt.Sprintf("failed to update pet: %v", err))
+		}
+	}
+
 	// Get events BEFORE saving (Save() will clear them)
 	events := petAggregate.GetUncommittedEvents()
 
@@ -171,6 +234,85 @@ func (h *UpdatePetWithUoWHandler) Handle(ctx context.Context, cmd *UpdatePet) er
 	return nil
 }
 
+// UpdatePetCareNotesWithUoWHandler handles update care notes commands with Unit of Work
+type UpdatePetCareNotesWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdatePetCareNotesWithUoWHandler creates a new update care notes handler with UoW
+func NewUpdatePetCareNotesWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *UpdatePetCareNotesWithUoWHandler {
+	return &UpdatePetCareNotesWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update care notes command
+func (h *UpdatePetCareNotesWithUoWHandler) Handle(ctx context.Context, cmd *UpdatePetCareNotes) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	cmd.Temperament = strings.TrimSpace(cmd.Temperament)
+	cmd.SpecialInstructions = strings.TrimSpace(cmd.SpecialInstructions)
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.IsOwner(cmd.UpdatedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can change a pet's care notes")
+	}
+
+	if err := petAggregate.UpdateCareNotes(cmd.Temperament, cmd.SpecialInstructions, cmd.UpdatedBy); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update care notes: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet care notes events: %v\n", err)
+	}
+
+	return nil
+}
+
 // DeletePetWithUoWHandler handles delete pet commands with Unit of Work
 type DeletePetWithUoWHandler struct {
 	uowFactory repository.UnitOfWorkFactory
@@ -579,3 +721,554 @@ func (h *RemovePetAllergyWithUoWHandler) Handle(ctx context.Context, cmd *Remove
 
 	return nil
 }
+
+// UpdatePetVaccinationWithUoWHandler handles update vaccination commands with Unit of Work
+type UpdatePetVaccinationWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdatePetVaccinationWithUoWHandler creates a new update vaccination handler with UoW
+func NewUpdatePetVaccinationWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *UpdatePetVaccinationWithUoWHandler {
+	return &UpdatePetVaccinationWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update vaccination command
+func (h *UpdatePetVaccinationWithUoWHandler) Handle(ctx context.Context, cmd *UpdatePetVaccination) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.RecordID == "" {
+		return errors.NewValidationError("record_id is required")
+	}
+	if cmd.Reason == "" {
+		return errors.NewValidationError("reason is required")
+	}
+	if cmd.VaccineName == "" {
+		return errors.NewValidationError("vaccine_name is required")
+	}
+	if cmd.Date.IsZero() {
+		return errors.NewValidationError("date is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Only owners correct the pet's health history
+	if !petAggregate.IsOwner(cmd.ChangedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can correct a pet's health records")
+	}
+
+	// Correct vaccination record
+	if err := petAggregate.UpdateVaccinationRecord(
+		cmd.RecordID,
+		cmd.VaccineName,
+		cmd.Date,
+		cmd.NextDueDate,
+		cmd.Veterinarian,
+		cmd.Notes,
+		cmd.ChangedBy,
+		cmd.Reason,
+	); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update vaccination: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet vaccination update events: %v\n", err)
+	}
+
+	return nil
+}
+
+// VoidPetVaccinationWithUoWHandler handles void vaccination commands with Unit of Work
+type VoidPetVaccinationWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewVoidPetVaccinationWithUoWHandler creates a new void vaccination handler with UoW
+func NewVoidPetVaccinationWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *VoidPetVaccinationWithUoWHandler {
+	return &VoidPetVaccinationWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the void vaccination command
+func (h *VoidPetVaccinationWithUoWHandler) Handle(ctx context.Context, cmd *VoidPetVaccination) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.RecordID == "" {
+		return errors.NewValidationError("record_id is required")
+	}
+	if cmd.Reason == "" {
+		return errors.NewValidationError("reason is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Only owners void records in the pet's health history
+	if !petAggregate.IsOwner(cmd.VoidedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can void a pet's health records")
+	}
+
+	// Void vaccination record
+	if err := petAggregate.VoidVaccinationRecord(cmd.RecordID, cmd.VoidedBy, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to void vaccination: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet vaccination void events: %v\n", err)
+	}
+
+	return nil
+}
+
+// UpdatePetMedicalRecordWithUoWHandler handles update medical record commands with Unit of Work
+type UpdatePetMedicalRecordWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdatePetMedicalRecordWithUoWHandler creates a new update medical record handler with UoW
+func NewUpdatePetMedicalRecordWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *UpdatePetMedicalRecordWithUoWHandler {
+	return &UpdatePetMedicalRecordWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update medical record command
+func (h *UpdatePetMedicalRecordWithUoWHandler) Handle(ctx context.Context, cmd *UpdatePetMedicalRecord) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.RecordID == "" {
+		return errors.NewValidationError("record_id is required")
+	}
+	if cmd.Reason == "" {
+		return errors.NewValidationError("reason is required")
+	}
+	if cmd.Date.IsZero() {
+		return errors.NewValidationError("date is required")
+	}
+	if cmd.Description == "" {
+		return errors.NewValidationError("description is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Only owners correct the pet's health history
+	if !petAggregate.IsOwner(cmd.ChangedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can correct a pet's health records")
+	}
+
+	// Correct medical record
+	if err := petAggregate.UpdateMedicalRecord(
+		cmd.RecordID,
+		cmd.Date,
+		cmd.Description,
+		cmd.Treatment,
+		cmd.Veterinarian,
+		cmd.Diagnosis,
+		cmd.Notes,
+		cmd.ChangedBy,
+		cmd.Reason,
+	); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update medical record: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet medical record update events: %v\n", err)
+	}
+
+	return nil
+}
+
+// VoidPetMedicalRecordWithUoWHandler handles void medical record commands with Unit of Work
+type VoidPetMedicalRecordWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewVoidPetMedicalRecordWithUoWHandler creates a new void medical record handler with UoW
+func NewVoidPetMedicalRecordWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *VoidPetMedicalRecordWithUoWHandler {
+	return &VoidPetMedicalRecordWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the void medical record command
+func (h *VoidPetMedicalRecordWithUoWHandler) Handle(ctx context.Context, cmd *VoidPetMedicalRecord) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.RecordID == "" {
+		return errors.NewValidationError("record_id is required")
+	}
+	if cmd.Reason == "" {
+		return errors.NewValidationError("reason is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Only owners void records in the pet's health history
+	if !petAggregate.IsOwner(cmd.VoidedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can void a pet's health records")
+	}
+
+	// Void medical record
+	if err := petAggregate.VoidMedicalRecord(cmd.RecordID, cmd.VoidedBy, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to void medical record: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet medical record void events: %v\n", err)
+	}
+
+	return nil
+}
+
+// UpdatePetAllergyWithUoWHandler handles update allergy commands with Unit of Work
+type UpdatePetAllergyWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdatePetAllergyWithUoWHandler creates a new update allergy handler with UoW
+func NewUpdatePetAllergyWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *UpdatePetAllergyWithUoWHandler {
+	return &UpdatePetAllergyWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update allergy command
+func (h *UpdatePetAllergyWithUoWHandler) Handle(ctx context.Context, cmd *UpdatePetAllergy) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.AllergyID == "" {
+		return errors.NewValidationError("allergy_id is required")
+	}
+	if cmd.Reason == "" {
+		return errors.NewValidationError("reason is required")
+	}
+	if cmd.Allergen == "" {
+		return errors.NewValidationError("allergen is required")
+	}
+	// Validate severity value
+	if cmd.Severity != "mild" && cmd.Severity != "moderate" && cmd.Severity != "severe" {
+		return errors.NewValidationError("severity must be one of: mild, moderate, severe")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Only owners correct the pet's health history
+	if !petAggregate.IsOwner(cmd.ChangedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can correct a pet's health records")
+	}
+
+	// Correct allergy
+	if err := petAggregate.UpdateAllergy(
+		cmd.AllergyID,
+		cmd.Allergen,
+		cmd.Severity,
+		cmd.Symptoms,
+		cmd.DiagnosedDate,
+		cmd.Notes,
+		cmd.ChangedBy,
+		cmd.Reason,
+	); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update allergy: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet allergy update events: %v\n", err)
+	}
+
+	return nil
+}
+
+// RecordPetWeightWithUoWHandler handles record weight commands with Unit of Work
+type RecordPetWeightWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewRecordPetWeightWithUoWHandler creates a new record weight handler with UoW
+func NewRecordPetWeightWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *RecordPetWeightWithUoWHandler {
+	return &RecordPetWeightWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the record weight command
+func (h *RecordPetWeightWithUoWHandler) Handle(ctx context.Context, cmd *RecordPetWeight) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.Weight <= 0 {
+		return errors.NewValidationError("weight must be greater than 0")
+	}
+	if cmd.Source == "" {
+		cmd.Source = event.WeightSourceOwner
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Record weight measurement
+	if err := petAggregate.RecordWeight(cmd.Weight, cmd.MeasuredAt, cmd.Source, cmd.RecordedBy, cmd.Notes); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to record weight: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet weight events: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,572 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+)
+
+// InvitePetGuardianWithUoWHandler handles invite pet guardian commands with Unit of Work
+type InvitePetGuardianWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewInvitePetGuardianWithUoWHandler creates a new invite guardian handler with UoW
+func NewInvitePetGuardianWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *InvitePetGuardianWithUoWHandler {
+	return &InvitePetGuardianWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the invite guardian command
+func (h *InvitePetGuardianWithUoWHandler) Handle(ctx context.Context, cmd *InvitePetGuardian) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.UserID == "" {
+		return errors.NewValidationError("user_id is required")
+	}
+	if cmd.Role == "" {
+		cmd.Role = event.GuardianRoleCaretaker
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.IsOwner(cmd.InvitedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can invite guardians")
+	}
+
+	// Validate that the invited user exists
+	if _, err := uow.UserRepository().GetByID(ctx, cmd.UserID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("user")
+	}
+
+	// Invite guardian
+	if err := petAggregate.InviteGuardian(cmd.UserID, cmd.Role, cmd.InvitedBy); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to invite guardian: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet guardian events: %v\n", err)
+	}
+
+	return nil
+}
+
+// AcceptPetGuardianInvitationWithUoWHandler handles accept guardian invitation commands with Unit of Work
+type AcceptPetGuardianInvitationWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAcceptPetGuardianInvitationWithUoWHandler creates a new accept invitation handler with UoW
+func NewAcceptPetGuardianInvitationWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *AcceptPetGuardianInvitationWithUoWHandler {
+	return &AcceptPetGuardianInvitationWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the accept invitation command
+func (h *AcceptPetGuardianInvitationWithUoWHandler) Handle(ctx context.Context, cmd *AcceptPetGuardianInvitation) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.InvitationID == "" {
+		return errors.NewValidationError("invitation_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Accept invitation
+	if err := petAggregate.AcceptGuardianInvitation(cmd.InvitationID, cmd.UserID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to accept invitation: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet guardian events: %v\n", err)
+	}
+
+	return nil
+}
+
+// DeclinePetGuardianInvitationWithUoWHandler handles decline guardian invitation commands with Unit of Work
+type DeclinePetGuardianInvitationWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewDeclinePetGuardianInvitationWithUoWHandler creates a new decline invitation handler with UoW
+func NewDeclinePetGuardianInvitationWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *DeclinePetGuardianInvitationWithUoWHandler {
+	return &DeclinePetGuardianInvitationWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the decline invitation command
+func (h *DeclinePetGuardianInvitationWithUoWHandler) Handle(ctx context.Context, cmd *DeclinePetGuardianInvitation) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.InvitationID == "" {
+		return errors.NewValidationError("invitation_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Decline (or, for owners, cancel) invitation
+	if err := petAggregate.DeclineGuardianInvitation(cmd.InvitationID, cmd.UserID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to close invitation: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet guardian events: %v\n", err)
+	}
+
+	return nil
+}
+
+// RemovePetGuardianWithUoWHandler handles remove pet guardian commands with Unit of Work
+type RemovePetGuardianWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewRemovePetGuardianWithUoWHandler creates a new remove guardian handler with UoW
+func NewRemovePetGuardianWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *RemovePetGuardianWithUoWHandler {
+	return &RemovePetGuardianWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the remove guardian command
+func (h *RemovePetGuardianWithUoWHandler) Handle(ctx context.Context, cmd *RemovePetGuardian) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.GuardianUserID == "" {
+		return errors.NewValidationError("guardian_user_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if cmd.RemovedBy != cmd.GuardianUserID && !petAggregate.IsOwner(cmd.RemovedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can remove other guardians")
+	}
+
+	// Remove guardian
+	if err := petAggregate.RemoveGuardian(cmd.GuardianUserID, cmd.RemovedBy); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to remove guardian: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet guardian events: %v\n", err)
+	}
+
+	return nil
+}
+
+// RequestPetOwnershipTransferWithUoWHandler handles request ownership transfer commands with Unit of Work
+type RequestPetOwnershipTransferWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewRequestPetOwnershipTransferWithUoWHandler creates a new request transfer handler with UoW
+func NewRequestPetOwnershipTransferWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *RequestPetOwnershipTransferWithUoWHandler {
+	return &RequestPetOwnershipTransferWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the request transfer command
+func (h *RequestPetOwnershipTransferWithUoWHandler) Handle(ctx context.Context, cmd *RequestPetOwnershipTransfer) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.ToUserID == "" {
+		return errors.NewValidationError("to_user_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if petAggregate.UserID() != cmd.RequestedBy {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only the primary owner can transfer ownership")
+	}
+
+	// Validate that the new owner exists
+	if _, err := uow.UserRepository().GetByID(ctx, cmd.ToUserID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("user")
+	}
+
+	// Request ownership transfer
+	if err := petAggregate.RequestOwnershipTransfer(cmd.ToUserID, cmd.RequestedBy, cmd.Note); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to request ownership transfer: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet ownership events: %v\n", err)
+	}
+
+	return nil
+}
+
+// AcceptPetOwnershipTransferWithUoWHandler handles accept ownership transfer commands with Unit of Work
+type AcceptPetOwnershipTransferWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAcceptPetOwnershipTransferWithUoWHandler creates a new accept transfer handler with UoW
+func NewAcceptPetOwnershipTransferWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *AcceptPetOwnershipTransferWithUoWHandler {
+	return &AcceptPetOwnershipTransferWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the accept transfer command
+func (h *AcceptPetOwnershipTransferWithUoWHandler) Handle(ctx context.Context, cmd *AcceptPetOwnershipTransfer) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if transfer := petAggregate.PendingTransfer(); transfer != nil && transfer.ToUserID != cmd.UserID {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("ownership transfer was offered to another user")
+	}
+
+	// Accept ownership transfer
+	if err := petAggregate.AcceptOwnershipTransfer(cmd.UserID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to accept ownership transfer: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet ownership events: %v\n", err)
+	}
+
+	return nil
+}
+
+// DeclinePetOwnershipTransferWithUoWHandler handles decline ownership transfer commands with Unit of Work
+type DeclinePetOwnershipTransferWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewDeclinePetOwnershipTransferWithUoWHandler creates a new decline transfer handler with UoW
+func NewDeclinePetOwnershipTransferWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *DeclinePetOwnershipTransferWithUoWHandler {
+	return &DeclinePetOwnershipTransferWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the decline transfer command
+func (h *DeclinePetOwnershipTransferWithUoWHandler) Handle(ctx context.Context, cmd *DeclinePetOwnershipTransfer) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	// Decline (or, for the owner, cancel) ownership transfer
+	if err := petAggregate.DeclineOwnershipTransfer(cmd.UserID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to close ownership transfer: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet ownership events: %v\n", err)
+	}
+
+	return nil
+}
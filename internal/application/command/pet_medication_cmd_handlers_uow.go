@@ -0,0 +1,296 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+)
+
+// AddPetMedicationPlanWithUoWHandler handles add medication plan commands with Unit of Work
+type AddPetMedicationPlanWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAddPetMedicationPlanWithUoWHandler creates a new add medication plan handler with UoW
+func NewAddPetMedicationPlanWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *AddPetMedicationPlanWithUoWHandler {
+	return &AddPetMedicationPlanWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the add medication plan command
+func (h *AddPetMedicationPlanWithUoWHandler) Handle(ctx context.Context, cmd *AddPetMedicationPlan) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	cmd.DrugName = strings.TrimSpace(cmd.DrugName)
+	if cmd.DrugName == "" {
+		return errors.NewValidationError("drug_name is required")
+	}
+	cmd.Dose = strings.TrimSpace(cmd.Dose)
+	if cmd.Dose == "" {
+		return errors.NewValidationError("dose is required")
+	}
+	if cmd.IntervalHours <= 0 {
+		return errors.NewValidationError("interval_hours must be positive")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.IsOwner(cmd.CreatedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can add medication plans")
+	}
+
+	// Add medication plan
+	if err := petAggregate.AddMedicationPlan(
+		cmd.DrugName,
+		cmd.Dose,
+		cmd.IntervalHours,
+		cmd.StartDate,
+		cmd.EndDate,
+		cmd.PrescribedBy,
+		cmd.Instructions,
+		cmd.MedicalRecordID,
+		cmd.CreatedBy,
+	); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to add medication plan: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet medication events: %v\n", err)
+	}
+
+	return nil
+}
+
+// StopPetMedicationPlanWithUoWHandler handles stop medication plan commands with Unit of Work
+type StopPetMedicationPlanWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewStopPetMedicationPlanWithUoWHandler creates a new stop medication plan handler with UoW
+func NewStopPetMedicationPlanWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *StopPetMedicationPlanWithUoWHandler {
+	return &StopPetMedicationPlanWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the stop medication plan command
+func (h *StopPetMedicationPlanWithUoWHandler) Handle(ctx context.Context, cmd *StopPetMedicationPlan) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.PlanID == "" {
+		return errors.NewValidationError("plan_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.IsOwner(cmd.StoppedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can stop medication plans")
+	}
+
+	// Stop medication plan
+	if err := petAggregate.StopMedicationPlan(cmd.PlanID, cmd.StoppedBy, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to stop medication plan: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet medication events: %v\n", err)
+	}
+
+	return nil
+}
+
+// LogPetMedicationDoseWithUoWHandler handles log medication dose commands with Unit of Work
+type LogPetMedicationDoseWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewLogPetMedicationDoseWithUoWHandler creates a new log medication dose handler with UoW
+func NewLogPetMedicationDoseWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *LogPetMedicationDoseWithUoWHandler {
+	return &LogPetMedicationDoseWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the log medication dose command. Guardians of the pet can log doses, and so can
+// staff of the vendor caring for the pet when the dose is logged through one of its bookings.
+func (h *LogPetMedicationDoseWithUoWHandler) Handle(ctx context.Context, cmd *LogPetMedicationDose) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.PetID == "" {
+		return errors.NewValidationError("pet_id is required")
+	}
+	if cmd.PlanID == "" {
+		return errors.NewValidationError("plan_id is required")
+	}
+	if cmd.ScheduledFor.IsZero() {
+		return errors.NewValidationError("scheduled_for is required")
+	}
+	cmd.Status = strings.ToUpper(strings.TrimSpace(cmd.Status))
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get pet from repository
+	petRepo := uow.PetRepository()
+	petAggregate, err := petRepo.GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+
+	if !petAggregate.CanBeBookedBy(cmd.RecordedBy) && !isCaringVendorStaff(ctx, uow, cmd.ScheduleID, cmd.PetID, cmd.RecordedBy) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only guardians of the pet or staff caring for it can log doses")
+	}
+
+	// Log medication dose
+	if err := petAggregate.LogMedicationDose(cmd.PlanID, cmd.ScheduledFor, cmd.Status, cmd.RecordedBy, cmd.Notes); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to log medication dose: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := petAggregate.GetUncommittedEvents()
+
+	// Save updated pet
+	if err := petRepo.Save(ctx, petAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+	}
+
+	// Commit transaction FIRST
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish pet medication events: %v\n", err)
+	}
+
+	return nil
+}
+
+// isCaringVendorStaff reports whether the user is active staff of the vendor booked for the pet, alone or
+// with other pets, by a schedule that is still open
+func isCaringVendorStaff(ctx context.Context, uow repository.UnitOfWork, scheduleID, petID, userID string) bool {
+	if scheduleID == "" || userID == "" {
+		return false
+	}
+
+	schedule, err := uow.ScheduleRepository().GetByID(ctx, scheduleID)
+	if err != nil || !schedule.HasBookedPet(petID) {
+		return false
+	}
+	if schedule.Status().IsFinal() {
+		return false
+	}
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, userID+"-"+schedule.BookedShop().ShopID)
+	return err == nil && staff != nil && staff.IsActive()
+}
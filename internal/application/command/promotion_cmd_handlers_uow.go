@@ -0,0 +1,232 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// ============================================
+// Create Promotion Handler (UoW)
+// ============================================
+
+// CreatePromotionWithUoWHandler handles create promotion commands with Unit of Work
+type CreatePromotionWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewCreatePromotionWithUoWHandler creates a new create promotion handler
+func NewCreatePromotionWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *CreatePromotionWithUoWHandler {
+	return &CreatePromotionWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the create promotion command
+func (h *CreatePromotionWithUoWHandler) Handle(ctx context.Context, cmd *CreatePromotion) (*CreatePromotionResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.StartsAt == "" {
+		return nil, errors.NewValidationError("starts_at is required")
+	}
+	if cmd.EndsAt == "" {
+		return nil, errors.NewValidationError("ends_at is required")
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, cmd.StartsAt)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid starts_at format: %v", err))
+	}
+	endsAt, err := time.Parse(time.RFC3339, cmd.EndsAt)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid ends_at format: %v", err))
+	}
+
+	fundedBy := aggregate.PromotionFunding(strings.ToUpper(cmd.FundedBy))
+	if cmd.CreatedByVendor {
+		fundedBy = aggregate.PromotionFundedByVendor
+	}
+
+	terms := aggregate.PromotionTerms{
+		Code:             cmd.Code,
+		Name:             cmd.Name,
+		Description:      cmd.Description,
+		DiscountType:     aggregate.PromotionDiscountType(strings.ToUpper(cmd.DiscountType)),
+		DiscountValue:    cmd.DiscountValue,
+		MaxDiscount:      cmd.MaxDiscount,
+		MinOrderAmount:   cmd.MinOrderAmount,
+		FundedBy:         fundedBy,
+		VendorID:         cmd.VendorID,
+		ServiceIDs:       cmd.ServiceIDs,
+		FirstBookingOnly: cmd.FirstBookingOnly,
+		StartsAt:         startsAt,
+		EndsAt:           endsAt,
+		UsageLimit:       cmd.UsageLimit,
+		PerUserLimit:     cmd.PerUserLimit,
+	}
+
+	promotion, err := aggregate.NewPromotion(uuid.New().String(), terms, cmd.CreatedBy)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to create promotion: %v", err))
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	if promotion.VendorID() != "" {
+		if _, err := uow.VendorRepository().GetByID(ctx, promotion.VendorID()); err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewNotFoundError("vendor")
+		}
+	}
+
+	// Vendors fund their own discounts, so only their staff may create them
+	if cmd.CreatedByVendor {
+		staff, err := uow.VendorStaffRepository().GetByID(ctx, cmd.CreatedBy+"-"+promotion.VendorID())
+		if err != nil || staff == nil || !staff.IsActive() {
+			uow.Rollback(ctx)
+			return nil, errors.NewForbiddenError("only staff of the vendor can create its promotions")
+		}
+	}
+
+	promotionRepo := uow.PromotionRepository()
+	existing, err := promotionRepo.GetByCode(ctx, promotion.Code())
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to check promotion code: %v", err))
+	}
+	if existing != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewConflictError(fmt.Sprintf("promotion code %s already exists", promotion.Code()))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := promotion.GetUncommittedEvents()
+
+	if err := promotionRepo.Save(ctx, promotion); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save promotion: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish promotion events: %v\n", err)
+	}
+
+	return &CreatePromotionResponse{
+		PromotionID: promotion.ID(),
+		Code:        promotion.Code(),
+	}, nil
+}
+
+// ============================================
+// Deactivate Promotion Handler (UoW)
+// ============================================
+
+// DeactivatePromotionWithUoWHandler handles deactivate promotion commands with Unit of Work
+type DeactivatePromotionWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewDeactivatePromotionWithUoWHandler creates a new deactivate promotion handler
+func NewDeactivatePromotionWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *DeactivatePromotionWithUoWHandler {
+	return &DeactivatePromotionWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the deactivate promotion command
+func (h *DeactivatePromotionWithUoWHandler) Handle(ctx context.Context, cmd *DeactivatePromotion) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.PromotionID == "" {
+		return errors.NewValidationError("promotion_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	promotionRepo := uow.PromotionRepository()
+	promotion, err := promotionRepo.GetByID(ctx, cmd.PromotionID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("promotion")
+	}
+
+	if err := promotion.Deactivate(cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to deactivate promotion: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := promotion.GetUncommittedEvents()
+
+	if err := promotionRepo.Save(ctx, promotion); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save promotion: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish promotion events: %v\n", err)
+	}
+
+	return nil
+}
+
+// releaseCouponRedemption gives back the coupon redemption of a payment that was cancelled or expired,
+// within the caller's unit of work, so the per-user and global limits count it no longer. Payments made
+// without a coupon, or whose redemption was already released, are left alone.
+func releaseCouponRedemption(ctx context.Context, uow repository.UnitOfWork, payment *aggregate.Payment, reason string) ([]event.DomainEvent, error) {
+	if payment.PromotionID() == "" {
+		return nil, nil
+	}
+
+	promotionRepo := uow.PromotionRepository()
+	promotion, err := promotionRepo.GetByID(ctx, payment.PromotionID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	if !promotion.Release(payment.ID(), reason) {
+		return nil, nil // Already released
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := promotion.GetUncommittedEvents()
+	if err := promotionRepo.Save(ctx, promotion); err != nil {
+		return nil, fmt.Errorf("failed to save promotion: %w", err)
+	}
+
+	fmt.Printf("🎟️  Released promotion %s redemption for payment %s\n", promotion.Code(), payment.ID())
+	return events, nil
+}
@@ -3,9 +3,11 @@ package command
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
 	"whisko-petcare/internal/domain/repository"
 	"whisko-petcare/internal/infrastructure/bus"
 	"whisko-petcare/pkg/errors"
@@ -28,8 +30,27 @@ func NewCreateScheduleWithUoWHandler(
 	}
 }
 
-// Handle processes the create schedule command
+// createScheduleAttempts is how often a schedule is created before giving up on a shop kept busy by
+// concurrent checkouts
+const createScheduleAttempts = 3
+
+// Handle processes the create schedule command. Losing the shop's slot lock to a concurrent checkout
+// is retried rather than returned, since the booking may already be paid for.
 func (h *CreateScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CreateSchedule) error {
+	var err error
+	for attempt := 1; attempt <= createScheduleAttempts; attempt++ {
+		err = h.handle(ctx, cmd)
+		if err != errShopSlotsBusy {
+			return err
+		}
+		fmt.Printf("⚠️  Shop %s is busy, retrying schedule creation (attempt %d)\n", cmd.VendorID, attempt)
+		time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+	}
+	return err
+}
+
+// handle creates the schedule in a single unit of work
+func (h *CreateScheduleWithUoWHandler) handle(ctx context.Context, cmd *CreateSchedule) error {
 	if cmd == nil {
 		return errors.NewValidationError("command cannot be nil")
 	}
@@ -41,7 +62,7 @@ func (h *CreateScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CreateSc
 	if cmd.VendorID == "" {
 		return errors.NewValidationError("shop_id is required")
 	}
-	if cmd.PetID == "" {
+	if cmd.PetID == "" && len(cmd.Pets) == 0 {
 		return errors.NewValidationError("pet_id is required")
 	}
 	if cmd.StartTime == "" {
@@ -70,86 +91,80 @@ func (h *CreateScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CreateSc
 		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
 	}
 
-	// Validate that User exists
-	userRepo := uow.UserRepository()
-	user, err := userRepo.GetByID(ctx, cmd.UserID)
-	if err != nil {
-		uow.Rollback(ctx)
-		return errors.NewValidationError(fmt.Sprintf("user not found: %v", err))
-	}
-	// Create booking user with real data from User aggregate
-	bookingUser := aggregate.BookingUser{
-		UserID:  cmd.UserID,
-		Name:    user.Name(),
-		Email:   user.Email(),
-		Phone:   user.Phone(),
-		Address: user.Address(),
-	}
-
-	// Validate that Vendor/Shop exists
-	vendorRepo := uow.VendorRepository()
-	vendor, err := vendorRepo.GetByID(ctx, cmd.VendorID)
-	if err != nil {
-		uow.Rollback(ctx)
-		return errors.NewValidationError(fmt.Sprintf("vendor/shop not found: %v", err))
+	// A booking covering several pets takes its primary pet and services from the pet lines
+	petID, serviceIDs := cmd.PetID, cmd.ServiceIDs
+	if len(cmd.Pets) > 0 {
+		petID, serviceIDs = cmd.Pets[0].PetID, nil
 	}
-	// Validate that Pet exists and belongs to the user
-	petRepo := uow.PetRepository()
-	pet, err := petRepo.GetByID(ctx, cmd.PetID)
+	parties, err := loadBookingParties(ctx, uow, cmd.UserID, cmd.VendorID, petID, serviceIDs, cmd.PaymentID == "")
 	if err != nil {
 		uow.Rollback(ctx)
-		return errors.NewValidationError(fmt.Sprintf("pet not found: %v", err))
-	}
-	if pet.UserID() != cmd.UserID {
-		uow.Rollback(ctx)
-		return errors.NewValidationError("pet does not belong to this user")
-	}
-	// Create assigned pet with real data
-	assignedPet := aggregate.PetAssigned{
-		PetID:   cmd.PetID,
-		Name:    pet.Name(),
-		Species: pet.Species(),
-		Breed:   pet.Breed(),
-		Age:     pet.Age(),
-		Weight:  pet.Weight(),
+		return err
 	}
+	vendor := parties.vendor
 
-	// Validate that all Services exist and belong to the vendor
-	serviceRepo := uow.ServiceRepository()
-	var bookedServices []aggregate.BookedServices
-	for _, serviceID := range cmd.ServiceIDs {
-		service, err := serviceRepo.GetByID(ctx, serviceID)
+	var petLines []event.SchedulePetLine
+	if len(cmd.Pets) > 0 {
+		petLines, err = quotePetLines(ctx, uow, cmd.UserID, cmd.VendorID, cmd.Pets, cmd.PaymentID == "")
 		if err != nil {
 			uow.Rollback(ctx)
-			return errors.NewValidationError(fmt.Sprintf("service %s not found: %v", serviceID, err))
+			return err
 		}
-		if service.VendorID() != cmd.VendorID {
+	}
+
+	// The time must still be free, unless the booking's payment holds it: a paid checkout keeps the time
+	// it held even when the hold ran out while the customer was paying
+	heldForPayment := false
+	if cmd.PaymentID != "" {
+		hold, err := uow.SlotHoldRepository().GetByPaymentID(ctx, cmd.PaymentID)
+		if err != nil {
 			uow.Rollback(ctx)
-			return errors.NewValidationError(fmt.Sprintf("service %s does not belong to vendor %s", serviceID, cmd.VendorID))
+			return errors.NewInternalError(fmt.Sprintf("failed to get slot hold: %v", err))
 		}
-		// Add service with real data
-		bookedServices = append(bookedServices, aggregate.BookedServices{
-			ServiceID: serviceID,
-			Name:      service.Name(),
-		})
+		heldForPayment = hold != nil && hold.Status() == aggregate.SlotHoldStatusHeld
 	}
-
-	// Create booked shop with real data
-	bookedVendor := aggregate.BookedVendor{
-		ShopID:         cmd.VendorID,
-		Name:           vendor.Name(),
-		Location:       vendor.Address(),
-		Phone:          vendor.Phone(),
-		BookedServices: bookedServices,
+	if !heldForPayment {
+		if err := checkShopSlot(ctx, uow, cmd.VendorID, startTime, endTime, "", cmd.PaymentID); err != nil {
+			uow.Rollback(ctx)
+			return err
+		}
 	}
 
 	// Create schedule aggregate with validated data
-	schedule, err := aggregate.NewSchedule(bookingUser, bookedVendor, assignedPet, startTime, endTime)
+	var schedule *aggregate.Schedule
+	if len(petLines) > 0 {
+		schedule, err = aggregate.NewMultiPetSchedule(parties.bookingUser, parties.bookedVendor, petLines, startTime, endTime, cmd.PaymentID, cmd.TotalPrice)
+	} else {
+		schedule, err = aggregate.NewSchedule(parties.bookingUser, parties.bookedVendor, parties.assignedPet, startTime, endTime, cmd.PaymentID, cmd.TotalPrice)
+	}
 	if err != nil {
 		uow.Rollback(ctx)
 		return errors.NewValidationError(fmt.Sprintf("failed to create schedule: %v", err))
 	}
 
+	// Share the care brief of every booked pet the owner owns when they consent while booking
+	if cmd.ShareCareBrief {
+		shared := 0
+		for _, petID := range schedule.BookedPetIDs() {
+			bookedPet, err := uow.PetRepository().GetByID(ctx, petID)
+			if err != nil || !bookedPet.IsOwner(cmd.UserID) {
+				continue
+			}
+			if err := schedule.ShareCareBrief(petID, cmd.UserID); err != nil {
+				uow.Rollback(ctx)
+				return errors.NewValidationError(fmt.Sprintf("failed to share care brief: %v", err))
+			}
+			shared++
+		}
+		if shared == 0 {
+			uow.Rollback(ctx)
+			return errors.NewForbiddenError("only owners can share a pet's care brief")
+		}
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := schedule.GetUncommittedEvents()
+
 	// Save schedule using repository from unit of work
 	scheduleRepo := uow.ScheduleRepository()
 	if err := scheduleRepo.Save(ctx, schedule); err != nil {
@@ -157,10 +172,40 @@ func (h *CreateScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CreateSc
 		return errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
 	}
 
-	// Publish events asynchronously
-	events := schedule.GetUncommittedEvents()
-	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
-		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	// Add the paid booking to the vendor's settlement; payouts are made per settlement period.
+	// Pay at shop bookings are confirmed before payment and the vendor keeps the money, so they
+	// are not settled.
+	var settlementEvents, holdEvents []event.DomainEvent
+	if cmd.PaymentID != "" {
+		payment, err := uow.PaymentRepository().GetByID(ctx, cmd.PaymentID)
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewValidationError(fmt.Sprintf("payment not found: %v", err))
+		}
+		collectedByVendor := payment.Method().IsCollectedByVendor()
+		if payment.Status() != aggregate.PaymentStatusPaid && !(collectedByVendor && payment.Status() == aggregate.PaymentStatusPending) {
+			uow.Rollback(ctx)
+			return errors.NewValidationError("payment has not been paid")
+		}
+		if payment.VendorID() != cmd.VendorID {
+			uow.Rollback(ctx)
+			return errors.NewValidationError("payment does not belong to this vendor")
+		}
+
+		if !collectedByVendor {
+			settlementEvents, err = RecordSettlementEarning(ctx, uow, vendor, payment.ID(), schedule.ID(), payment.VendorEarning(), time.Now())
+			if err != nil {
+				uow.Rollback(ctx)
+				return errors.NewInternalError(fmt.Sprintf("failed to record settlement earning: %v", err))
+			}
+		}
+
+		// The time held while the customer paid becomes this schedule
+		holdEvents, err = convertSlotHold(ctx, uow, payment.ID(), schedule.ID())
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(err.Error())
+		}
 	}
 
 	// Commit transaction
@@ -168,14 +213,104 @@ func (h *CreateScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CreateSc
 		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
 	}
 
-	fmt.Printf("✅ Schedule created successfully: %s\n", schedule.ID())
+	// Publish only once committed, so subscribers never see a booking or converted hold that was not stored
+	events = append(events, holdEvents...)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
 
-	// Note: Payout is now created and processed in payment confirmation handler BEFORE schedule creation
-	// This ensures vendors receive payment immediately after user pays
+	if err := h.eventBus.PublishBatch(ctx, settlementEvents); err != nil {
+		fmt.Printf("Warning: failed to publish settlement events: %v\n", err)
+	}
+
+	fmt.Printf("✅ Schedule created successfully: %s\n", schedule.ID())
 
 	return nil
 }
 
+// bookingParties is who and what a booking is made for, loaded with their current details
+type bookingParties struct {
+	vendor       *aggregate.Vendor
+	pet          *aggregate.Pet
+	bookingUser  aggregate.BookingUser
+	bookedVendor aggregate.BookedVendor
+	assignedPet  aggregate.PetAssigned
+}
+
+// loadBookingParties loads the customer, shop, pet and services of a booking and checks that they belong
+// together. Whether the services take the pet is only checked when checkEligibility is set.
+func loadBookingParties(ctx context.Context, uow repository.UnitOfWork, userID, vendorID, petID string,
+	serviceIDs []string, checkEligibility bool) (*bookingParties, error) {
+	// Validate that User exists
+	user, err := uow.UserRepository().GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("user not found: %v", err))
+	}
+
+	// Validate that Vendor/Shop exists
+	vendor, err := uow.VendorRepository().GetByID(ctx, vendorID)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("vendor/shop not found: %v", err))
+	}
+
+	// Validate that Pet exists and the user is one of its owners or caretakers
+	pet, err := uow.PetRepository().GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("pet not found: %v", err))
+	}
+	if !pet.CanBeBookedBy(userID) {
+		return nil, errors.NewValidationError("pet does not belong to this user or a household they care for")
+	}
+
+	// Validate that all Services exist and belong to the vendor
+	var bookedServices []aggregate.BookedServices
+	for _, serviceID := range serviceIDs {
+		service, err := uow.ServiceRepository().GetByID(ctx, serviceID)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("service %s not found: %v", serviceID, err))
+		}
+		if service.VendorID() != vendorID {
+			return nil, errors.NewValidationError(fmt.Sprintf("service %s does not belong to vendor %s", serviceID, vendorID))
+		}
+		if checkEligibility {
+			if err := checkServiceAcceptsPet(ctx, uow, service, pet); err != nil {
+				return nil, err
+			}
+		}
+		bookedServices = append(bookedServices, aggregate.BookedServices{
+			ServiceID: serviceID,
+			Name:      service.Name(),
+		})
+	}
+
+	return &bookingParties{
+		vendor: vendor,
+		pet:    pet,
+		bookingUser: aggregate.BookingUser{
+			UserID:  userID,
+			Name:    user.Name(),
+			Email:   user.Email(),
+			Phone:   user.Phone(),
+			Address: user.Address(),
+		},
+		bookedVendor: aggregate.BookedVendor{
+			ShopID:         vendorID,
+			Name:           vendor.Name(),
+			Location:       vendor.Address(),
+			Phone:          vendor.Phone(),
+			BookedServices: bookedServices,
+		},
+		assignedPet: aggregate.PetAssigned{
+			PetID:   petID,
+			Name:    pet.Name(),
+			Species: pet.Species(),
+			Breed:   pet.Breed(),
+			Age:     pet.Age(),
+			Weight:  pet.Weight(),
+		},
+	}, nil
+}
+
 // ChangeScheduleStatusWithUoWHandler handles change schedule status commands with Unit of Work
 type ChangeScheduleStatusWithUoWHandler struct {
 	uowFactory repository.UnitOfWorkFactory
@@ -209,10 +344,7 @@ func (h *ChangeScheduleStatusWithUoWHandler) Handle(ctx context.Context, cmd *Ch
 
 	// Validate status
 	status := aggregate.ScheduleStatus(cmd.Status)
-	if status != aggregate.ScheduleStatusPending &&
-		status != aggregate.ScheduleStatusConfirmed &&
-		status != aggregate.ScheduleStatusCompleted &&
-		status != aggregate.ScheduleStatusCancelled {
+	if !status.IsValid() {
 		return errors.NewValidationError("invalid status value")
 	}
 
@@ -233,10 +365,16 @@ func (h *ChangeScheduleStatusWithUoWHandler) Handle(ctx context.Context, cmd *Ch
 		return errors.NewNotFoundError("schedule")
 	}
 
+	actor, err := scheduleActor(ctx, uow, scheduleAggregate, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
 	// Change status
-	if err := scheduleAggregate.ChangeStatus(status); err != nil {
+	if err := scheduleAggregate.ChangeStatus(status, actor, cmd.Reason); err != nil {
 		uow.Rollback(ctx)
-		return errors.NewValidationError(fmt.Sprintf("failed to change status: %v", err))
+		return scheduleChangeError("change status", err)
 	}
 
 	// Save updated schedule
@@ -304,10 +442,16 @@ func (h *CompleteScheduleWithUoWHandler) Handle(ctx context.Context, cmd *Comple
 		return errors.NewNotFoundError("schedule")
 	}
 
+	actor, err := scheduleActor(ctx, uow, scheduleAggregate, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
 	// Complete schedule
-	if err := scheduleAggregate.Complete(); err != nil {
+	if err := scheduleAggregate.Complete(actor); err != nil {
 		uow.Rollback(ctx)
-		return errors.NewValidationError(fmt.Sprintf("failed to complete schedule: %v", err))
+		return scheduleChangeError("complete schedule", err)
 	}
 
 	// Save updated schedule
@@ -378,10 +522,16 @@ func (h *CancelScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CancelSc
 		return errors.NewNotFoundError("schedule")
 	}
 
+	actor, err := scheduleActor(ctx, uow, scheduleAggregate, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
 	// Cancel schedule
-	if err := scheduleAggregate.Cancel(cmd.Reason); err != nil {
+	if err := scheduleAggregate.Cancel(cmd.Reason, actor); err != nil {
 		uow.Rollback(ctx)
-		return errors.NewValidationError(fmt.Sprintf("failed to cancel schedule: %v", err))
+		return scheduleChangeError("cancel schedule", err)
 	}
 
 	// Save updated schedule
@@ -403,3 +553,412 @@ func (h *CancelScheduleWithUoWHandler) Handle(ctx context.Context, cmd *CancelSc
 
 	return nil
 }
+
+// ShareScheduleCareBriefWithUoWHandler handles share care brief commands with Unit of Work
+type ShareScheduleCareBriefWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewShareScheduleCareBriefWithUoWHandler creates a new share care brief handler with UoW
+func NewShareScheduleCareBriefWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *ShareScheduleCareBriefWithUoWHandler {
+	return &ShareScheduleCareBriefWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the share care brief command
+func (h *ShareScheduleCareBriefWithUoWHandler) Handle(ctx context.Context, cmd *ShareScheduleCareBrief) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return errors.NewValidationError("schedule_id is required")
+	}
+
+	return changeScheduleCareBrief(ctx, h.uowFactory, h.eventBus, cmd.ScheduleID, cmd.PetID, cmd.UserID, "share care brief",
+		func(schedule *aggregate.Schedule, petID string) error {
+			return schedule.ShareCareBrief(petID, cmd.UserID)
+		})
+}
+
+// RevokeScheduleCareBriefWithUoWHandler handles revoke care brief commands with Unit of Work
+type RevokeScheduleCareBriefWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewRevokeScheduleCareBriefWithUoWHandler creates a new revoke care brief handler with UoW
+func NewRevokeScheduleCareBriefWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *RevokeScheduleCareBriefWithUoWHandler {
+	return &RevokeScheduleCareBriefWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the revoke care brief command
+func (h *RevokeScheduleCareBriefWithUoWHandler) Handle(ctx context.Context, cmd *RevokeScheduleCareBrief) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return errors.NewValidationError("schedule_id is required")
+	}
+
+	return changeScheduleCareBrief(ctx, h.uowFactory, h.eventBus, cmd.ScheduleID, cmd.PetID, cmd.UserID, "revoke care brief",
+		func(schedule *aggregate.Schedule, petID string) error {
+			return schedule.RevokeCareBrief(petID, cmd.UserID)
+		})
+}
+
+// scheduleActor resolves the role under which a user changes a schedule: admin, active staff of the
+// booked shop, or the customer who booked it or owns the pet
+func scheduleActor(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule,
+	userID string, isAdmin bool) (aggregate.ScheduleActor, error) {
+	if isAdmin {
+		return aggregate.ScheduleActorAdmin, nil
+	}
+	if userID == "" {
+		return "", errors.NewUnauthorizedError("user not authenticated")
+	}
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, userID+"-"+schedule.BookedShop().ShopID)
+	if err == nil && staff != nil && staff.IsActive() {
+		return aggregate.ScheduleActorVendorStaff, nil
+	}
+	if userID == schedule.BookingUser().UserID {
+		return aggregate.ScheduleActorCustomer, nil
+	}
+	pet, err := uow.PetRepository().GetByID(ctx, schedule.AssignedPet().PetID)
+	if err == nil && pet.IsOwner(userID) {
+		return aggregate.ScheduleActorCustomer, nil
+	}
+
+	return "", errors.NewForbiddenError("you cannot change this schedule")
+}
+
+// scheduleChangeError maps a refused schedule change to an application error. Transitions the actor may
+// not make are forbidden; other refused transitions conflict with the current state of the booking.
+func scheduleChangeError(action string, err error) error {
+	if transitionErr, ok := err.(*aggregate.ScheduleTransitionError); ok {
+		message := fmt.Sprintf("failed to %s: %v", action, transitionErr)
+		if transitionErr.Kind == aggregate.ScheduleTransitionForbidden {
+			return errors.NewForbiddenError(message)
+		}
+		return errors.NewConflictError(message)
+	}
+	return errors.NewValidationError(fmt.Sprintf("failed to %s: %v", action, err))
+}
+
+// changeScheduleCareBrief loads a schedule, checks that the user owns the booked pet, applies a change
+// to the care brief consent and saves the schedule
+func changeScheduleCareBrief(ctx context.Context, uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus,
+	scheduleID, petID, userID, action string, change func(schedule *aggregate.Schedule, petID string) error) error {
+	// Create unit of work
+	uow := uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get schedule from repository
+	scheduleRepo := uow.ScheduleRepository()
+	scheduleAggregate, err := scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("schedule")
+	}
+
+	if petID == "" {
+		petID = scheduleAggregate.AssignedPet().PetID
+	}
+	if !scheduleAggregate.HasBookedPet(petID) {
+		uow.Rollback(ctx)
+		return errors.NewValidationError("pet is not booked on this schedule")
+	}
+
+	// Only owners of the pet decide what is shared about it
+	pet, err := uow.PetRepository().GetByID(ctx, petID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("pet")
+	}
+	if !pet.IsOwner(userID) {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can change what is shared about their pet")
+	}
+
+	if err := change(scheduleAggregate, petID); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to %s: %v", action, err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := scheduleAggregate.GetUncommittedEvents()
+
+	// Save updated schedule
+	if err := scheduleRepo.Save(ctx, scheduleAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	// Commit transaction
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	return nil
+}
+
+// SubmitVisitReportWithUoWHandler handles submit visit report commands with Unit of Work
+type SubmitVisitReportWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewSubmitVisitReportWithUoWHandler creates a new submit visit report handler with UoW
+func NewSubmitVisitReportWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *SubmitVisitReportWithUoWHandler {
+	return &SubmitVisitReportWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the submit visit report command. The booking is completed along with the report.
+func (h *SubmitVisitReportWithUoWHandler) Handle(ctx context.Context, cmd *SubmitVisitReport) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	// Validate command
+	if cmd.ScheduleID == "" {
+		return errors.NewValidationError("schedule_id is required")
+	}
+	if len(cmd.ServicesPerformed) == 0 {
+		return errors.NewValidationError("services_performed is required")
+	}
+	if cmd.Observations == "" {
+		return errors.NewValidationError("observations is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get schedule from repository
+	scheduleRepo := uow.ScheduleRepository()
+	scheduleAggregate, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("schedule")
+	}
+
+	// Only active staff of the booked shop report on the visit
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, cmd.SubmittedBy+"-"+scheduleAggregate.BookedShop().ShopID)
+	if err != nil || staff == nil || !staff.IsActive() {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only staff of the booked shop can submit a visit report")
+	}
+
+	services := make([]event.VisitReportService, 0, len(cmd.ServicesPerformed))
+	for _, svc := range cmd.ServicesPerformed {
+		services = append(services, event.VisitReportService{
+			ServiceID: svc.ServiceID,
+			Notes:     svc.Notes,
+		})
+	}
+
+	if err := scheduleAggregate.SubmitVisitReport(event.VisitReport{
+		ServicesPerformed: services,
+		Observations:      cmd.Observations,
+		Weight:            cmd.Weight,
+		PhotoURLs:         cmd.PhotoURLs,
+		Recommendations:   cmd.Recommendations,
+		SubmittedBy:       cmd.SubmittedBy,
+	}); err != nil {
+		uow.Rollback(ctx)
+		return scheduleChangeError("submit visit report", err)
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := scheduleAggregate.GetUncommittedEvents()
+
+	// Save updated schedule
+	if err := scheduleRepo.Save(ctx, scheduleAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	// Commit transaction
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	return nil
+}
+
+// AcceptVisitReportWithUoWHandler handles accept visit report commands with Unit of Work
+type AcceptVisitReportWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAcceptVisitReportWithUoWHandler creates a new accept visit report handler with UoW
+func NewAcceptVisitReportWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *AcceptVisitReportWithUoWHandler {
+	return &AcceptVisitReportWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the accept visit report command. The report is added to the medical history of each
+// booked pet the owner owns, with the shop as its source, and the weight taken during a single-pet visit
+// is recorded.
+func (h *AcceptVisitReportWithUoWHandler) Handle(ctx context.Context, cmd *AcceptVisitReport) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return errors.NewValidationError("schedule_id is required")
+	}
+
+	// Create unit of work
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	// Begin transaction
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// Get schedule from repository
+	scheduleRepo := uow.ScheduleRepository()
+	scheduleAggregate, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("schedule")
+	}
+
+	report := scheduleAggregate.VisitReport()
+	if report == nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError("no visit report has been submitted for this schedule")
+	}
+
+	shop := scheduleAggregate.BookedShop()
+	serviceNames := make([]string, 0, len(report.ServicesPerformed))
+	for _, svc := range report.ServicesPerformed {
+		serviceNames = append(serviceNames, svc.Name)
+	}
+
+	// Every booked pet the accepter owns gets the visit in its medical history. The weight is taken
+	// for the booking as a whole, so it is only recorded when a single pet was seen.
+	bookedPetIDs := scheduleAggregate.BookedPetIDs()
+	petRepo := uow.PetRepository()
+	var petEvents []event.DomainEvent
+	owned := 0
+	for _, petID := range bookedPetIDs {
+		pet, err := petRepo.GetByID(ctx, petID)
+		if err != nil || !pet.IsOwner(cmd.AcceptedBy) {
+			continue
+		}
+		owned++
+		if scheduleAggregate.VisitReportAcceptedFor(petID) {
+			continue
+		}
+
+		recordID, err := pet.AddVendorMedicalRecord(
+			report.SubmittedAt,
+			fmt.Sprintf("Visit at %s: %s", shop.Name, strings.Join(serviceNames, ", ")),
+			report.Observations,
+			report.Recommendations,
+			event.RecordProvenance{
+				VendorID:   shop.ShopID,
+				VendorName: shop.Name,
+				ScheduleID: scheduleAggregate.ID(),
+				RecordedBy: report.SubmittedBy,
+			},
+		)
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewValidationError(fmt.Sprintf("failed to add medical record: %v", err))
+		}
+		if report.Weight > 0 && len(bookedPetIDs) == 1 {
+			if err := pet.RecordWeight(report.Weight, report.SubmittedAt, event.WeightSourceVendor, report.SubmittedBy, "Visit at "+shop.Name); err != nil {
+				uow.Rollback(ctx)
+				return errors.NewValidationError(fmt.Sprintf("failed to record weight: %v", err))
+			}
+		}
+
+		if err := scheduleAggregate.AcceptVisitReport(petID, cmd.AcceptedBy, recordID); err != nil {
+			uow.Rollback(ctx)
+			return errors.NewValidationError(fmt.Sprintf("failed to accept visit report: %v", err))
+		}
+
+		// Get events BEFORE saving (Save() will clear them)
+		petEvents = append(petEvents, pet.GetUncommittedEvents()...)
+		if err := petRepo.Save(ctx, pet); err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(fmt.Sprintf("failed to save pet: %v", err))
+		}
+	}
+	if owned == 0 {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only owners can accept a visit report into their pet's medical history")
+	}
+	if len(petEvents) == 0 {
+		uow.Rollback(ctx)
+		return errors.NewValidationError("visit report has already been accepted for your pets")
+	}
+
+	scheduleEvents := scheduleAggregate.GetUncommittedEvents()
+	if err := scheduleRepo.Save(ctx, scheduleAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	// Commit transaction
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	// Publish events AFTER successful commit (eventual consistency)
+	if err := h.eventBus.PublishBatch(ctx, petEvents); err != nil {
+		fmt.Printf("Warning: failed to publish pet events: %v\n", err)
+	}
+	if err := h.eventBus.PublishBatch(ctx, scheduleEvents); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/gateway"
+	"whisko-petcare/pkg/errors"
+)
+
+// AutoCompleteScheduleWithUoWHandler completes bookings on behalf of the system once they have ended
+type AutoCompleteScheduleWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAutoCompleteScheduleWithUoWHandler creates a new auto-complete schedule handler with UoW
+func NewAutoCompleteScheduleWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *AutoCompleteScheduleWithUoWHandler {
+	return &AutoCompleteScheduleWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle completes an ended booking and reports whether it did. Bookings that are already over, including
+// those the shop recorded as a no-show, are left alone.
+func (h *AutoCompleteScheduleWithUoWHandler) Handle(ctx context.Context, scheduleID string) (bool, error) {
+	if scheduleID == "" {
+		return false, errors.NewValidationError("schedule_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return false, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// The status is read again inside the transaction, so a booking completed or marked as a no-show
+	// since it was listed is not completed twice
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return false, errors.NewNotFoundError("schedule")
+	}
+	if schedule.Status().IsFinal() {
+		uow.Rollback(ctx)
+		return false, nil
+	}
+
+	if err := schedule.Complete(aggregate.ScheduleActorSystem); err != nil {
+		uow.Rollback(ctx)
+		return false, scheduleChangeError("complete schedule", err)
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := schedule.GetUncommittedEvents()
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return false, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return false, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	return true, nil
+}
+
+// CancelUnconfirmedScheduleWithUoWHandler cancels bookings the shop has not confirmed in time
+type CancelUnconfirmedScheduleWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewCancelUnconfirmedScheduleWithUoWHandler creates a new cancel unconfirmed schedule handler with UoW
+func NewCancelUnconfirmedScheduleWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *CancelUnconfirmedScheduleWithUoWHandler {
+	return &CancelUnconfirmedScheduleWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle cancels a booking that is still pending and refunds what the customer paid for it. It reports
+// whether the booking was cancelled; bookings confirmed in the meantime are left alone.
+func (h *CancelUnconfirmedScheduleWithUoWHandler) Handle(ctx context.Context, scheduleID, reason string) (bool, error) {
+	if scheduleID == "" {
+		return false, errors.NewValidationError("schedule_id is required")
+	}
+	if reason == "" {
+		return false, errors.NewValidationError("reason is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return false, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return false, errors.NewNotFoundError("schedule")
+	}
+	if schedule.Status() != aggregate.ScheduleStatusPending {
+		uow.Rollback(ctx)
+		return false, nil
+	}
+
+	if err := schedule.Cancel(reason, aggregate.ScheduleActorSystem); err != nil {
+		uow.Rollback(ctx)
+		return false, scheduleChangeError("cancel schedule", err)
+	}
+
+	refundPaymentID, paymentEvents, err := refundUnconfirmedSchedule(ctx, uow, schedule, reason)
+	if err != nil {
+		uow.Rollback(ctx)
+		return false, err
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := append(schedule.GetUncommittedEvents(), paymentEvents...)
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return false, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return false, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	// The booking stays cancelled when the gateway fails; the refund stays in progress until it is retried
+	if refundPaymentID != "" {
+		if _, _, err := completePaymentRefund(ctx, h.uowFactory, h.eventBus, h.gateways, refundPaymentID); err != nil {
+			fmt.Printf("Warning: failed to refund cancelled schedule %s: %v\n", scheduleID, err)
+		}
+	}
+
+	return true, nil
+}
+
+// refundUnconfirmedSchedule reserves a refund of the price of a booking the shop never confirmed, up to
+// what is left of its payment, within the caller's unit of work. It returns the ID of the payment to
+// complete the refund on with completePaymentRefund once the unit of work is committed, or an empty ID
+// when nothing is refunded. Payments that have not been paid are left alone; nothing was collected for them.
+func refundUnconfirmedSchedule(ctx context.Context, uow repository.UnitOfWork,
+	schedule *aggregate.Schedule, reason string) (string, []event.DomainEvent, error) {
+	if schedule.PaymentID() == "" || schedule.TotalPrice() <= 0 {
+		return "", nil, nil
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, schedule.PaymentID())
+	if err != nil {
+		return "", nil, errors.NewNotFoundError("payment")
+	}
+	if payment.Status() != aggregate.PaymentStatusPaid {
+		return "", nil, nil
+	}
+
+	// A series paid upfront shares its payment between occurrences, so only this booking's price is refunded
+	amount := schedule.TotalPrice()
+	if amount > payment.RefundableAmount() {
+		amount = payment.RefundableAmount()
+	}
+	if amount <= 0 {
+		return "", nil, nil
+	}
+
+	if _, err := requestPaymentRefund(payment, amount, reason); err != nil {
+		return "", nil, err
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := payment.GetUncommittedEvents()
+	if err := paymentRepo.Save(ctx, payment); err != nil {
+		return "", nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+	}
+
+	return payment.ID(), events, nil
+}
@@ -0,0 +1,212 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/gateway"
+	"whisko-petcare/pkg/errors"
+)
+
+// quotePetLines loads the pets and services of a booking covering several pets and prices every pet on
+// its own: the pet's services are charged once and take their combined duration. Every pet must be
+// bookable by the user and every service must belong to the vendor. Whether the services take the pet is
+// only checked when checkEligibility is set.
+func quotePetLines(ctx context.Context, uow repository.UnitOfWork, userID, vendorID string,
+	pets []aggregate.PaymentPetLine, checkEligibility bool) ([]event.SchedulePetLine, error) {
+	if len(pets) == 0 {
+		return nil, errors.NewValidationError("pets are required")
+	}
+
+	seenPets := map[string]bool{}
+	lines := make([]event.SchedulePetLine, 0, len(pets))
+	for _, petLine := range pets {
+		if petLine.PetID == "" {
+			return nil, errors.NewValidationError("pet_id is required for every pet")
+		}
+		if seenPets[petLine.PetID] {
+			return nil, errors.NewValidationError(fmt.Sprintf("pet %s is listed twice", petLine.PetID))
+		}
+		seenPets[petLine.PetID] = true
+		if len(petLine.ServiceIDs) == 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("service_ids are required for pet %s", petLine.PetID))
+		}
+
+		pet, err := uow.PetRepository().GetByID(ctx, petLine.PetID)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("pet %s not found: %v", petLine.PetID, err))
+		}
+		if !pet.CanBeBookedBy(userID) {
+			return nil, errors.NewValidationError(fmt.Sprintf("pet %s does not belong to this user or a household they care for", petLine.PetID))
+		}
+
+		line := event.SchedulePetLine{
+			PetID:   pet.ID(),
+			PetName: pet.Name(),
+			Species: pet.Species(),
+			Breed:   pet.Breed(),
+			Age:     pet.Age(),
+			Weight:  pet.Weight(),
+		}
+		var duration time.Duration
+		seenServices := map[string]bool{}
+		for _, serviceID := range petLine.ServiceIDs {
+			if seenServices[serviceID] {
+				return nil, errors.NewValidationError(fmt.Sprintf("service %s is listed twice for pet %s", serviceID, petLine.PetID))
+			}
+			seenServices[serviceID] = true
+
+			service, err := uow.ServiceRepository().GetByID(ctx, serviceID)
+			if err != nil {
+				return nil, errors.NewValidationError(fmt.Sprintf("service %s not found: %v", serviceID, err))
+			}
+			if service.VendorID() != vendorID {
+				return nil, errors.NewValidationError(fmt.Sprintf("service %s does not belong to vendor %s", serviceID, vendorID))
+			}
+			if checkEligibility {
+				if err := checkServiceAcceptsPet(ctx, uow, service, pet); err != nil {
+					return nil, err
+				}
+			}
+
+			line.Services = append(line.Services, event.ScheduleLineService{
+				ServiceID: serviceID,
+				Name:      service.Name(),
+			})
+			line.Price += service.Price()
+			duration += service.Duration()
+		}
+		line.DurationMinutes = int(duration / time.Minute)
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// petLineItems lists every pet of a booking as one payment item priced at its services
+func petLineItems(lines []event.SchedulePetLine) ([]aggregate.PaymentItem, int, time.Duration) {
+	items := make([]aggregate.PaymentItem, 0, len(lines))
+	total := 0
+	var duration time.Duration
+	for _, line := range lines {
+		names := make([]string, 0, len(line.Services))
+		for _, svc := range line.Services {
+			names = append(names, svc.Name)
+		}
+		items = append(items, aggregate.PaymentItem{
+			Name:     fmt.Sprintf("%s: %s", line.PetName, strings.Join(names, ", ")),
+			Quantity: 1,
+			Price:    line.Price,
+		})
+		total += line.Price
+		duration += time.Duration(line.DurationMinutes) * time.Minute
+	}
+	return items, total, duration
+}
+
+// CancelSchedulePetWithUoWHandler handles cancelling one pet of a booking with Unit of Work
+type CancelSchedulePetWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewCancelSchedulePetWithUoWHandler creates a new cancel schedule pet handler with UoW
+func NewCancelSchedulePetWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *CancelSchedulePetWithUoWHandler {
+	return &CancelSchedulePetWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle cancels one pet of a booking covering several pets. The pet's share of the price is refunded
+// when the booking is paid, or taken off the amount due at the shop when it is not.
+func (h *CancelSchedulePetWithUoWHandler) Handle(ctx context.Context, cmd *CancelSchedulePet) (*CancelSchedulePetResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return nil, errors.NewValidationError("schedule_id is required")
+	}
+	if cmd.PetID == "" {
+		return nil, errors.NewValidationError("pet_id is required")
+	}
+	if cmd.Reason == "" {
+		return nil, errors.NewValidationError("reason is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	actor, err := scheduleActor(ctx, uow, schedule, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	// The pay at shop payment gets a line taking the pet's services off the amount due
+	description := ""
+	for _, line := range schedule.PetLines() {
+		if line.PetID == cmd.PetID {
+			description = fmt.Sprintf("Cancelled %s", line.PetName)
+		}
+	}
+
+	oldPrice := schedule.TotalPrice()
+	if err := schedule.CancelPet(cmd.PetID, cmd.Reason, actor); err != nil {
+		uow.Rollback(ctx)
+		return nil, scheduleChangeError("cancel pet", err)
+	}
+
+	adjustment, paymentEvents, err := settleSchedulePriceChange(ctx, uow, h.gateways, schedule, oldPrice, cmd.Reason, description)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := append(schedule.GetUncommittedEvents(), paymentEvents...)
+
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule pet events: %v\n", err)
+	}
+
+	completeScheduleRefund(ctx, h.uowFactory, h.eventBus, h.gateways, adjustment)
+
+	return &CancelSchedulePetResponse{
+		ScheduleID: schedule.ID(),
+		PetID:      cmd.PetID,
+		TotalPrice: schedule.TotalPrice(),
+		Adjustment: adjustment,
+	}, nil
+}
@@ -0,0 +1,638 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/gateway"
+	"whisko-petcare/pkg/errors"
+)
+
+// RescheduleScheduleWithUoWHandler handles reschedule commands with Unit of Work
+type RescheduleScheduleWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewRescheduleScheduleWithUoWHandler creates a new reschedule handler with UoW
+func NewRescheduleScheduleWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *RescheduleScheduleWithUoWHandler {
+	return &RescheduleScheduleWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle moves a booking to a new time. Customers are bound by the vendor's reschedule policy; the new
+// time must be free at the shop. A change in price is paid with a top-up or refunded.
+func (h *RescheduleScheduleWithUoWHandler) Handle(ctx context.Context, cmd *RescheduleSchedule) (*RescheduleScheduleResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return nil, errors.NewValidationError("schedule_id is required")
+	}
+	startTime, endTime, err := parseRescheduleTimes(cmd.StartTime, cmd.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	actor, err := scheduleActor(ctx, uow, schedule, cmd.UserID, cmd.IsAdmin)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+	if actor == aggregate.ScheduleActorVendorStaff {
+		uow.Rollback(ctx)
+		return nil, errors.NewForbiddenError("vendor staff propose a new time for the customer to accept instead")
+	}
+
+	vendor, err := uow.VendorRepository().GetByID(ctx, schedule.BookedShop().ShopID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("vendor")
+	}
+
+	if err := checkRescheduleSlot(ctx, uow, schedule, startTime, endTime); err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	price, err := rescheduledPrice(ctx, uow, schedule, startTime, endTime)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	oldPrice, oldStart, oldEnd := schedule.TotalPrice(), schedule.StartTime(), schedule.EndTime()
+	if err := schedule.Reschedule(startTime, endTime, price, cmd.UserID, actor, cmd.Reason, vendor.ReschedulePolicy()); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to reschedule: %v", err))
+	}
+
+	adjustment, paymentEvents, err := settleSchedulePriceChange(ctx, uow, h.gateways, schedule, oldPrice, cmd.Reason, "")
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+	if err := holdTimeForTopUp(ctx, uow, schedule, adjustment, oldStart, oldEnd); err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := append(schedule.GetUncommittedEvents(), paymentEvents...)
+
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish reschedule events: %v\n", err)
+	}
+
+	completeScheduleRefund(ctx, h.uowFactory, h.eventBus, h.gateways, adjustment)
+
+	return newRescheduleScheduleResponse(schedule, adjustment), nil
+}
+
+// ProposeScheduleRescheduleWithUoWHandler handles vendor reschedule proposals with Unit of Work
+type ProposeScheduleRescheduleWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewProposeScheduleRescheduleWithUoWHandler creates a new propose reschedule handler with UoW
+func NewProposeScheduleRescheduleWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *ProposeScheduleRescheduleWithUoWHandler {
+	return &ProposeScheduleRescheduleWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle lets active staff of the booked shop propose a new time for a booking. The time must be free at
+// the shop; the booking moves only when the customer accepts.
+func (h *ProposeScheduleRescheduleWithUoWHandler) Handle(ctx context.Context, cmd *ProposeScheduleReschedule) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return errors.NewValidationError("schedule_id is required")
+	}
+	if cmd.ProposedBy == "" {
+		return errors.NewUnauthorizedError("user not authenticated")
+	}
+	startTime, endTime, err := parseRescheduleTimes(cmd.StartTime, cmd.EndTime)
+	if err != nil {
+		return err
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("schedule")
+	}
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, cmd.ProposedBy+"-"+schedule.BookedShop().ShopID)
+	if err != nil || staff == nil || !staff.IsActive() {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("only staff of the booked shop can propose a new time")
+	}
+
+	if err := checkRescheduleSlot(ctx, uow, schedule, startTime, endTime); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	if err := schedule.ProposeReschedule(startTime, endTime, cmd.ProposedBy, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to propose reschedule: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := schedule.GetUncommittedEvents()
+
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	return nil
+}
+
+// RespondRescheduleProposalWithUoWHandler handles customer responses to reschedule proposals with Unit of Work
+type RespondRescheduleProposalWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+	gateways   *gateway.Registry
+}
+
+// NewRespondRescheduleProposalWithUoWHandler creates a new respond reschedule proposal handler with UoW
+func NewRespondRescheduleProposalWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	gateways *gateway.Registry,
+) *RespondRescheduleProposalWithUoWHandler {
+	return &RespondRescheduleProposalWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+		gateways:   gateways,
+	}
+}
+
+// Handle lets the customer accept or decline the time the vendor proposed. On accepting, the slot is
+// checked again and a change in price is paid with a top-up or refunded.
+func (h *RespondRescheduleProposalWithUoWHandler) Handle(ctx context.Context, cmd *RespondRescheduleProposal) (*RescheduleScheduleResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return nil, errors.NewValidationError("schedule_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	actor, err := scheduleActor(ctx, uow, schedule, cmd.UserID, false)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, err
+	}
+	if actor != aggregate.ScheduleActorCustomer {
+		uow.Rollback(ctx)
+		return nil, errors.NewForbiddenError("only the customer can respond to a reschedule proposal")
+	}
+
+	proposal := schedule.RescheduleProposal()
+	if proposal == nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewConflictError("no reschedule proposal is waiting")
+	}
+
+	var adjustment *RescheduleAdjustment
+	var paymentEvents []event.DomainEvent
+	if cmd.Accept {
+		vendor, err := uow.VendorRepository().GetByID(ctx, schedule.BookedShop().ShopID)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewNotFoundError("vendor")
+		}
+
+		if err := checkRescheduleSlot(ctx, uow, schedule, proposal.StartTime, proposal.EndTime); err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+
+		price, err := rescheduledPrice(ctx, uow, schedule, proposal.StartTime, proposal.EndTime)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+
+		oldPrice, oldStart, oldEnd := schedule.TotalPrice(), schedule.StartTime(), schedule.EndTime()
+		reason := proposal.Reason
+		if err := schedule.AcceptRescheduleProposal(cmd.UserID, price, vendor.ReschedulePolicy()); err != nil {
+			uow.Rollback(ctx)
+			return nil, errors.NewValidationError(fmt.Sprintf("failed to accept reschedule proposal: %v", err))
+		}
+
+		adjustment, paymentEvents, err = settleSchedulePriceChange(ctx, uow, h.gateways, schedule, oldPrice, reason, "")
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+		if err := holdTimeForTopUp(ctx, uow, schedule, adjustment, oldStart, oldEnd); err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+	} else if err := schedule.DeclineRescheduleProposal(cmd.UserID, cmd.Reason); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to decline reschedule proposal: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := append(schedule.GetUncommittedEvents(), paymentEvents...)
+
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish reschedule events: %v\n", err)
+	}
+
+	completeScheduleRefund(ctx, h.uowFactory, h.eventBus, h.gateways, adjustment)
+
+	return newRescheduleScheduleResponse(schedule, adjustment), nil
+}
+
+// parseRescheduleTimes parses the new start and end time of a booking
+func parseRescheduleTimes(start, end string) (time.Time, time.Time, error) {
+	if start == "" {
+		return time.Time{}, time.Time{}, errors.NewValidationError("start_time is required")
+	}
+	if end == "" {
+		return time.Time{}, time.Time{}, errors.NewValidationError("end_time is required")
+	}
+
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewValidationError(fmt.Sprintf("invalid start_time format: %v", err))
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewValidationError(fmt.Sprintf("invalid end_time format: %v", err))
+	}
+	return startTime, endTime, nil
+}
+
+// checkRescheduleSlot checks that the new time is free: for the assigned staff member if the booking has
+// one, otherwise for the shop, including time held for other customers' checkouts
+func checkRescheduleSlot(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule, startTime, endTime time.Time) error {
+	if staff := schedule.AssignedStaff(); staff != nil {
+		return checkStaffSlot(ctx, uow, staff.UserID, schedule.ID(), startTime, endTime)
+	}
+
+	return checkShopSlot(ctx, uow, schedule.BookedShop().ShopID, startTime, endTime, schedule.ID(), "")
+}
+
+// rescheduledPrice returns the price of the booking at its new time. The booked services are quoted at
+// their current prices for both the old and the new time, and the difference is applied to the price the
+// customer agreed to, so discounts and earlier price changes carry over. Bookings without a known price
+// stay unpriced.
+func rescheduledPrice(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule, startTime, endTime time.Time) (int, error) {
+	if schedule.TotalPrice() == 0 {
+		return 0, nil
+	}
+
+	oldQuote, err := quoteScheduleServices(ctx, uow, schedule, schedule.StartTime(), schedule.EndTime())
+	if err != nil {
+		return 0, err
+	}
+	newQuote, err := quoteScheduleServices(ctx, uow, schedule, startTime, endTime)
+	if err != nil {
+		return 0, err
+	}
+
+	price := schedule.TotalPrice() + newQuote - oldQuote
+	if price < 0 {
+		price = 0
+	}
+	return price, nil
+}
+
+// quoteScheduleServices prices the booked services for a time range
+func quoteScheduleServices(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule, startTime, endTime time.Time) (int, error) {
+	return quoteServices(ctx, uow, schedule.BookedServiceIDs(), startTime, endTime)
+}
+
+// quoteServices prices services for a time range. The services are charged once for every started run
+// of their combined duration.
+func quoteServices(ctx context.Context, uow repository.UnitOfWork, serviceIDs []string, startTime, endTime time.Time) (int, error) {
+	total := 0
+	var duration time.Duration
+	for _, serviceID := range serviceIDs {
+		service, err := uow.ServiceRepository().GetByID(ctx, serviceID)
+		if err != nil {
+			return 0, errors.NewNotFoundError(fmt.Sprintf("service %s", serviceID))
+		}
+		total += service.Price()
+		duration += service.Duration()
+	}
+	if duration <= 0 {
+		return total, nil
+	}
+
+	runs := int((endTime.Sub(startTime) + duration - 1) / duration)
+	if runs < 1 {
+		runs = 1
+	}
+	return total * runs, nil
+}
+
+// settleSchedulePriceChange pays or refunds the difference after a booking changed price, within the
+// caller's unit of work. A higher price creates a top-up payment with the method of the original payment;
+// a lower price reserves a refund of part of the original payment, which the caller completes with
+// completeScheduleRefund once the unit of work is committed. When the original payment has not been paid
+// yet, the difference is settled at the shop and a pay at shop payment is adjusted to the new price, with
+// the description as the payment line of the difference (a generic one when empty). Bookings without a
+// payment or a known price are not adjusted.
+func settleSchedulePriceChange(ctx context.Context, uow repository.UnitOfWork, gateways *gateway.Registry,
+	schedule *aggregate.Schedule, oldPrice int, reason, description string) (*RescheduleAdjustment, []event.DomainEvent, error) {
+	difference := schedule.TotalPrice() - oldPrice
+	if difference == 0 || oldPrice == 0 || schedule.PaymentID() == "" {
+		return nil, nil, nil
+	}
+
+	paymentRepo := uow.PaymentRepository()
+	payment, err := paymentRepo.GetByID(ctx, schedule.PaymentID())
+	if err != nil {
+		return nil, nil, errors.NewNotFoundError("payment")
+	}
+
+	adjustment := event.SchedulePriceAdjustment{
+		OldPrice: oldPrice,
+		NewPrice: schedule.TotalPrice(),
+		Reason:   reason,
+	}
+	result := &RescheduleAdjustment{}
+	var events []event.DomainEvent
+
+	switch {
+	case payment.Status() != aggregate.PaymentStatusPaid:
+		adjustment.Kind = event.PriceAdjustmentAtShop
+		adjustment.Amount = absInt(difference)
+		adjustment.PaymentID = payment.ID()
+
+		// The vendor collects the new price; a discount larger than the new price leaves nothing to collect
+		if payment.Status() == aggregate.PaymentStatusPending && payment.Method().IsCollectedByVendor() {
+			change := difference
+			if change < -payment.Amount() {
+				change = -payment.Amount()
+			}
+			if change != 0 {
+				if description == "" && change > 0 {
+					description = "Booking price increase"
+				} else if description == "" {
+					description = "Booking price reduction"
+				}
+				if err := payment.AdjustPendingAmount(change, description); err != nil {
+					return nil, nil, errors.NewValidationError(fmt.Sprintf("failed to adjust payment: %v", err))
+				}
+
+				// Get events BEFORE saving (Save will clear them)
+				events = append(events, payment.GetUncommittedEvents()...)
+				if err := paymentRepo.Save(ctx, payment); err != nil {
+					return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+				}
+			}
+		}
+
+	case difference > 0:
+		topUp, err := aggregate.NewTopUpPayment(
+			payment.UserID(), difference, "Reschedule top-up",
+			[]aggregate.PaymentItem{{Name: "Reschedule top-up", Quantity: 1, Price: difference}},
+			payment.VendorID(), payment.PetID(), payment.ServiceIDs(),
+			schedule.StartTime(), schedule.EndTime(), payment.Method(), schedule.ID(),
+		)
+		if err != nil {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("failed to create top-up payment: %v", err))
+		}
+
+		paymentGateway, err := gateways.Get(topUp.Method())
+		if err != nil {
+			return nil, nil, errors.NewInternalError(err.Error())
+		}
+		checkout, err := paymentGateway.CreatePayment(ctx, &gateway.CreatePaymentRequest{
+			OrderCode:   topUp.OrderCode(),
+			Amount:      topUp.Amount(),
+			Description: topUp.Description(),
+			Items:       topUp.Items(),
+		})
+		if err != nil {
+			return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to create %s payment: %v", topUp.Method(), err))
+		}
+		if checkout.TransactionID != "" || checkout.CheckoutURL != "" {
+			if err := topUp.SetPayOSDetails(checkout.TransactionID, checkout.CheckoutURL, checkout.QRCode); err != nil {
+				return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to set payment details: %v", err))
+			}
+		}
+
+		// Get events BEFORE saving (Save will clear them)
+		events = append(events, topUp.GetUncommittedEvents()...)
+		if err := paymentRepo.Save(ctx, topUp); err != nil {
+			return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+		}
+
+		adjustment.Kind = event.PriceAdjustmentTopUp
+		adjustment.Amount = difference
+		adjustment.PaymentID = topUp.ID()
+		result.CheckoutURL = checkout.CheckoutURL
+		result.QRCode = checkout.QRCode
+
+	default:
+		amount := -difference
+		if amount > payment.RefundableAmount() {
+			amount = payment.RefundableAmount()
+		}
+		if amount == 0 {
+			return nil, nil, nil
+		}
+
+		refundID, err := requestPaymentRefund(payment, amount, "Booking changed to a lower price")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Get events BEFORE saving (Save will clear them)
+		events = append(events, payment.GetUncommittedEvents()...)
+		if err := paymentRepo.Save(ctx, payment); err != nil {
+			return nil, nil, errors.NewInternalError(fmt.Sprintf("failed to save payment: %v", err))
+		}
+
+		adjustment.Kind = event.PriceAdjustmentRefund
+		adjustment.Amount = amount
+		adjustment.PaymentID = payment.ID()
+		adjustment.RefundID = refundID
+		result.RefundID = refundID
+	}
+
+	if err := schedule.RecordPriceAdjustment(adjustment); err != nil {
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("failed to record price adjustment: %v", err))
+	}
+
+	result.Kind = adjustment.Kind
+	result.Amount = adjustment.Amount
+	result.PaymentID = adjustment.PaymentID
+	return result, events, nil
+}
+
+// newRescheduleScheduleResponse describes the booking after a reschedule
+func newRescheduleScheduleResponse(schedule *aggregate.Schedule, adjustment *RescheduleAdjustment) *RescheduleScheduleResponse {
+	return &RescheduleScheduleResponse{
+		ScheduleID:      schedule.ID(),
+		StartTime:       schedule.StartTime().Format(time.RFC3339),
+		EndTime:         schedule.EndTime().Format(time.RFC3339),
+		TotalPrice:      schedule.TotalPrice(),
+		RescheduleCount: schedule.RescheduleCount(),
+		Adjustment:      adjustment,
+	}
+}
+
+// holdTimeForTopUp holds the time a booking moved away from for as long as the top-up paying for the move
+// is open, within the caller's unit of work. If the top-up lapses the booking moves back to that time (see
+// revertUnpaidReschedule); once it is paid the hold is released.
+func holdTimeForTopUp(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule,
+	adjustment *RescheduleAdjustment, oldStart, oldEnd time.Time) error {
+	if adjustment == nil || adjustment.Kind != event.PriceAdjustmentTopUp {
+		return nil
+	}
+
+	topUp, err := uow.PaymentRepository().GetByID(ctx, adjustment.PaymentID)
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to get top-up payment: %v", err))
+	}
+
+	hold, err := aggregate.NewSlotHold(topUp.ID(), topUp.UserID(), schedule.BookedShop().ShopID, oldStart, oldEnd, topUp.ExpiredAt())
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to hold previous time: %v", err))
+	}
+	if err := uow.SlotHoldRepository().Save(ctx, hold); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to save slot hold: %v", err))
+	}
+	return nil
+}
+
+// revertUnpaidReschedule moves a booking back to the time held for its lapsed top-up payment, within the
+// caller's unit of work. Payments that are not a reschedule top-up are left alone.
+func revertUnpaidReschedule(ctx context.Context, uow repository.UnitOfWork, payment *aggregate.Payment, hold *aggregate.SlotHold, reason string) ([]event.DomainEvent, error) {
+	if !payment.IsForExistingBooking() || hold == nil {
+		return nil, nil
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, payment.ScheduleID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	if err := schedule.RevertReschedule(payment.ID(), hold.StartTime(), hold.EndTime(), reason); err != nil {
+		fmt.Printf("⚠️  Schedule %s keeps its time after top-up %s lapsed: %v\n", schedule.ID(), payment.ID(), err)
+		return nil, nil
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := schedule.GetUncommittedEvents()
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	fmt.Printf("↩️  Schedule %s moved back to %s after top-up %s lapsed\n", schedule.ID(), hold.StartTime().Format(time.RFC3339), payment.ID())
+	return events, nil
+}
+
+// completeScheduleRefund completes the refund a price change reserved, after the booking change was
+// committed. The change stands when the gateway fails; the refund stays in progress until it is retried.
+func completeScheduleRefund(ctx context.Context, uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus,
+	gateways *gateway.Registry, adjustment *RescheduleAdjustment) {
+	if adjustment == nil || adjustment.Kind != event.PriceAdjustmentRefund {
+		return
+	}
+	_, refund, err := completePaymentRefund(ctx, uowFactory, eventBus, gateways, adjustment.PaymentID)
+	if err != nil {
+		fmt.Printf("Warning: failed to complete refund %s: %v\n", adjustment.RefundID, err)
+		return
+	}
+	adjustment.ManualRefund = refund.Manual
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
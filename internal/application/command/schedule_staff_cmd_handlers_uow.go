@@ -0,0 +1,173 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+)
+
+// AssignScheduleStaffWithUoWHandler handles staff assignment commands with Unit of Work
+type AssignScheduleStaffWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAssignScheduleStaffWithUoWHandler creates a new assign schedule staff handler with UoW
+func NewAssignScheduleStaffWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *AssignScheduleStaffWithUoWHandler {
+	return &AssignScheduleStaffWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle assigns the booking to a staff member of the booked shop. Owners and managers of the shop
+// assign their staff; without a staff member in the command the free staff member with the fewest
+// bookings that day is picked.
+func (h *AssignScheduleStaffWithUoWHandler) Handle(ctx context.Context, cmd *AssignScheduleStaff) (*AssignScheduleStaffResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ScheduleID == "" {
+		return nil, errors.NewValidationError("schedule_id is required")
+	}
+	if cmd.AssignedBy == "" {
+		return nil, errors.NewUnauthorizedError("user not authenticated")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	scheduleRepo := uow.ScheduleRepository()
+	schedule, err := scheduleRepo.GetByID(ctx, cmd.ScheduleID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewNotFoundError("schedule")
+	}
+	shopID := schedule.BookedShop().ShopID
+
+	if !cmd.IsAdmin {
+		assigner, err := uow.VendorStaffRepository().GetByID(ctx, cmd.AssignedBy+"-"+shopID)
+		if err != nil || assigner == nil || !assigner.IsActive() ||
+			(assigner.Role() != aggregate.VendorStaffRoleOwner && assigner.Role() != aggregate.VendorStaffRoleManager) {
+			uow.Rollback(ctx)
+			return nil, errors.NewForbiddenError("only owners and managers of the booked shop can assign staff")
+		}
+	}
+
+	staffID := cmd.StaffID
+	autoAssigned := staffID == ""
+	if autoAssigned {
+		staffID, err = pickLeastLoadedStaff(ctx, uow, schedule)
+		if err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+	} else {
+		staff, err := uow.VendorStaffRepository().GetByID(ctx, staffID+"-"+shopID)
+		if err != nil || staff == nil || !staff.IsActive() {
+			uow.Rollback(ctx)
+			return nil, errors.NewValidationError("staff member does not work for the booked shop")
+		}
+		if err := checkStaffSlot(ctx, uow, staffID, schedule.ID(), schedule.StartTime(), schedule.EndTime()); err != nil {
+			uow.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if err := schedule.AssignStaff(staffID, cmd.AssignedBy, autoAssigned); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to assign staff: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := schedule.GetUncommittedEvents()
+
+	if err := scheduleRepo.Save(ctx, schedule); err != nil {
+		uow.Rollback(ctx)
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save schedule: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish schedule events: %v\n", err)
+	}
+
+	return &AssignScheduleStaffResponse{
+		ScheduleID:   schedule.ID(),
+		StaffID:      staffID,
+		AutoAssigned: autoAssigned,
+	}, nil
+}
+
+// pickLeastLoadedStaff picks the active staff member of the booked shop who is free at the time of the
+// booking and has the fewest open bookings that day. Ties go to the lowest user ID so the choice is
+// stable. The staff member already assigned is not picked again.
+func pickLeastLoadedStaff(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule) (string, error) {
+	staffs, err := uow.VendorStaffRepository().GetActiveByVendorID(ctx, schedule.BookedShop().ShopID)
+	if err != nil {
+		return "", errors.NewInternalError(fmt.Sprintf("failed to list shop staff: %v", err))
+	}
+
+	currentStaffID := ""
+	if assigned := schedule.AssignedStaff(); assigned != nil {
+		currentStaffID = assigned.UserID
+	}
+
+	start := schedule.StartTime()
+	dayStart := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	scheduleRepo := uow.ScheduleRepository()
+	bestStaffID, bestLoad := "", -1
+	for _, staff := range staffs {
+		staffID := staff.UserID()
+		if staffID == currentStaffID {
+			continue
+		}
+
+		taken, err := scheduleRepo.HasOverlappingStaffBooking(ctx, staffID, schedule.StartTime(), schedule.EndTime(), schedule.ID())
+		if err != nil {
+			return "", errors.NewInternalError(fmt.Sprintf("failed to check staff availability: %v", err))
+		}
+		if taken {
+			continue
+		}
+
+		load, err := scheduleRepo.CountStaffBookings(ctx, staffID, dayStart, dayEnd)
+		if err != nil {
+			return "", errors.NewInternalError(fmt.Sprintf("failed to count staff bookings: %v", err))
+		}
+		if bestLoad == -1 || load < bestLoad || (load == bestLoad && staffID < bestStaffID) {
+			bestStaffID, bestLoad = staffID, load
+		}
+	}
+
+	if bestStaffID == "" {
+		return "", errors.NewConflictError("no staff member of the shop is free at this time")
+	}
+	return bestStaffID, nil
+}
+
+// checkStaffSlot checks that the staff member has no other open booking overlapping the time
+func checkStaffSlot(ctx context.Context, uow repository.UnitOfWork, staffID, scheduleID string, startTime, endTime time.Time) error {
+	taken, err := uow.ScheduleRepository().HasOverlappingStaffBooking(ctx, staffID, startTime, endTime, scheduleID)
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to check staff availability: %v", err))
+	}
+	if taken {
+		return errors.NewConflictError("the staff member already has a booking at this time")
+	}
+	return nil
+}
@@ -254,3 +254,96 @@ func (h *DeleteServiceWithUoWHandler) Handle(ctx context.Context, cmd *DeleteSer
 
 	return nil
 }
+
+// UpdateServiceEligibilityWithUoWHandler handles service eligibility commands with Unit of Work
+type UpdateServiceEligibilityWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdateServiceEligibilityWithUoWHandler creates a new service eligibility handler with UoW
+func NewUpdateServiceEligibilityWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *UpdateServiceEligibilityWithUoWHandler {
+	return &UpdateServiceEligibilityWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the service eligibility command
+func (h *UpdateServiceEligibilityWithUoWHandler) Handle(ctx context.Context, cmd *UpdateServiceEligibility) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.ServiceID == "" {
+		return errors.NewValidationError("service_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	serviceRepo := uow.ServiceRepository()
+	serviceAggregate, err := serviceRepo.GetByID(ctx, cmd.ServiceID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("service")
+	}
+
+	if !cmd.IsAdmin {
+		staff, err := uow.VendorStaffRepository().GetByID(ctx, cmd.UpdatedBy+"-"+serviceAggregate.VendorID())
+		if err != nil || staff == nil || !staff.IsActive() {
+			uow.Rollback(ctx)
+			return errors.NewForbiddenError("only staff of the vendor can change which pets its services accept")
+		}
+	}
+
+	// Species may be given by name or alias; the service keeps catalogue codes
+	speciesCodes := make([]string, 0, len(cmd.Species))
+	for _, term := range cmd.Species {
+		species, err := uow.SpeciesRepository().GetByTerm(ctx, term)
+		if err != nil {
+			uow.Rollback(ctx)
+			return errors.NewInternalError(fmt.Sprintf("failed to look up species: %v", err))
+		}
+		if species == nil {
+			uow.Rollback(ctx)
+			return errors.NewValidationError(fmt.Sprintf("unknown species: %s", term))
+		}
+		speciesCodes = append(speciesCodes, species.ID())
+	}
+
+	eligibility := aggregate.ServiceEligibility{
+		Species:   speciesCodes,
+		Sizes:     cmd.Sizes,
+		MinWeight: cmd.MinWeight,
+		MaxWeight: cmd.MaxWeight,
+	}
+	if err := serviceAggregate.UpdateEligibility(eligibility, cmd.UpdatedBy); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update service eligibility: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := serviceAggregate.GetUncommittedEvents()
+
+	if err := serviceRepo.Save(ctx, serviceAggregate); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save service: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish service events: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// ============================================
+// Update Vendor Settlement Settings Handler (UoW)
+// ============================================
+
+// UpdateVendorSettlementSettingsWithUoWHandler handles vendor settlement settings updates with Unit of Work
+type UpdateVendorSettlementSettingsWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdateVendorSettlementSettingsWithUoWHandler creates a new update vendor settlement settings handler
+func NewUpdateVendorSettlementSettingsWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *UpdateVendorSettlementSettingsWithUoWHandler {
+	return &UpdateVendorSettlementSettingsWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update vendor settlement settings command
+func (h *UpdateVendorSettlementSettingsWithUoWHandler) Handle(ctx context.Context, cmd *UpdateVendorSettlementSettings) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.VendorID == "" {
+		return errors.NewValidationError("vendor_id is required")
+	}
+
+	period := aggregate.SettlementPeriod(strings.ToUpper(cmd.Period))
+	if !period.IsValid() {
+		return errors.NewValidationError("period must be DAILY or WEEKLY")
+	}
+	if cmd.MinPayoutAmount < 0 {
+		return errors.NewValidationError("min_payout_amount cannot be negative")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	vendorRepo := uow.VendorRepository()
+	vendor, err := vendorRepo.GetByID(ctx, cmd.VendorID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("vendor")
+	}
+
+	if err := requireVendorManager(ctx, uow, cmd.UpdatedBy, cmd.VendorID, cmd.IsAdmin,
+		"only an owner or manager of the vendor can change its settlement settings"); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	if err := vendor.UpdateSettlementSettings(period, cmd.MinPayoutAmount); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update settlement settings: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := vendor.GetUncommittedEvents()
+
+	if err := vendorRepo.Save(ctx, vendor); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save vendor: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish vendor events: %v\n", err)
+	}
+
+	return nil
+}
+
+// ============================================
+// Settlement earnings
+// ============================================
+
+// RecordSettlementEarning adds a paid booking to the vendor's open settlement for the current period,
+// opening a new settlement if needed. It runs inside the caller's unit of work and returns the
+// settlement events to publish after commit. Payments already included in a settlement are skipped.
+func RecordSettlementEarning(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	vendor *aggregate.Vendor,
+	paymentID, scheduleID string,
+	amount int,
+	earnedAt time.Time,
+) ([]event.DomainEvent, error) {
+	settlementRepo := uow.SettlementRepository()
+
+	existing, err := settlementRepo.GetByPaymentID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		fmt.Printf("⚠️  Payment %s is already included in settlement %s\n", paymentID, existing.ID())
+		return nil, nil
+	}
+
+	settlement, err := settlementRepo.GetOpenForVendorAt(ctx, vendor.ID(), earnedAt)
+	if err != nil {
+		return nil, err
+	}
+	if settlement == nil {
+		periodStart, periodEnd := aggregate.SettlementPeriodBounds(vendor.SettlementPeriod(), earnedAt)
+		settlement, err = aggregate.NewSettlement(uuid.New().String(), vendor.ID(), vendor.SettlementPeriod(), periodStart, periodEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := settlement.AddLineItem(paymentID, scheduleID, amount, earnedAt); err != nil {
+		return nil, err
+	}
+
+	events := settlement.GetUncommittedEvents()
+	if err := settlementRepo.Save(ctx, settlement); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("💰 Added payment %s (%d VND) to settlement %s for vendor %s\n", paymentID, amount, settlement.ID(), vendor.ID())
+	return events, nil
+}
+
+// RecordSettlementRefund deducts a refund from the vendor's open settlement for the current period,
+// so the refunded amount is withheld from the next payout. Like RecordSettlementEarning it runs
+// inside the caller's unit of work and returns the settlement events to publish after commit.
+func RecordSettlementRefund(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	vendor *aggregate.Vendor,
+	paymentID, refundID string,
+	amount int,
+	refundedAt time.Time,
+) ([]event.DomainEvent, error) {
+	settlementRepo := uow.SettlementRepository()
+
+	settlement, err := settlementRepo.GetOpenForVendorAt(ctx, vendor.ID(), refundedAt)
+	if err != nil {
+		return nil, err
+	}
+	if settlement == nil {
+		periodStart, periodEnd := aggregate.SettlementPeriodBounds(vendor.SettlementPeriod(), refundedAt)
+		settlement, err = aggregate.NewSettlement(uuid.New().String(), vendor.ID(), vendor.SettlementPeriod(), periodStart, periodEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := settlement.AddRefundAdjustment(paymentID, refundID, amount, refundedAt); err != nil {
+		return nil, err
+	}
+
+	events := settlement.GetUncommittedEvents()
+	if err := settlementRepo.Save(ctx, settlement); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("💸 Deducted refund %s (%d VND) of payment %s from settlement %s for vendor %s\n", refundID, amount, paymentID, settlement.ID(), vendor.ID())
+	return events, nil
+}
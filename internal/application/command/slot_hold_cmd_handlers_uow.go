@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/pkg/errors"
+)
+
+// errShopSlotsBusy is returned by checkShopSlot when a concurrent checkout holds the shop's lock
+var errShopSlotsBusy = errors.NewConflictError("another booking at this shop is in progress, please try again")
+
+// checkShopSlot checks that the shop's time is free: not booked by a schedule other than excludeScheduleID,
+// not held for the checkout of a payment other than excludePaymentID and not offered to a waitlisted
+// customer. The shop is locked for the rest of the caller's transaction, so a concurrent checkout of the
+// same time fails instead of double booking.
+func checkShopSlot(ctx context.Context, uow repository.UnitOfWork, shopID string, startTime, endTime time.Time, excludeScheduleID, excludePaymentID string) error {
+	holdRepo := uow.SlotHoldRepository()
+	if err := holdRepo.LockShopSlots(ctx, shopID); err != nil {
+		if err == repository.ErrShopSlotsBusy {
+			return errShopSlotsBusy
+		}
+		return errors.NewInternalError(fmt.Sprintf("failed to check availability: %v", err))
+	}
+
+	taken, err := uow.ScheduleRepository().HasOverlappingBooking(ctx, shopID, startTime, endTime, excludeScheduleID)
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to check availability: %v", err))
+	}
+	if taken {
+		return errors.NewConflictError("the shop is already booked at this time")
+	}
+
+	held, err := holdRepo.HasOverlappingHold(ctx, shopID, startTime, endTime, excludePaymentID, time.Now())
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to check availability: %v", err))
+	}
+	if held {
+		return errors.NewConflictError("this time is being held for another customer's checkout")
+	}
+
+	offered, err := uow.WaitlistRepository().HasOverlappingOffer(ctx, shopID, startTime, endTime, time.Now())
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to check availability: %v", err))
+	}
+	if offered {
+		return errors.NewConflictError("this time has been offered to a customer on the waitlist")
+	}
+	return nil
+}
+
+// placeSlotHold holds the time of a new booking payment for the customer until the payment expires.
+// The hold is not saved; the caller saves it with the payment.
+func placeSlotHold(ctx context.Context, uow repository.UnitOfWork, payment *aggregate.Payment) (*aggregate.SlotHold, error) {
+	if err := checkShopSlot(ctx, uow, payment.VendorID(), payment.StartTime(), payment.EndTime(), "", ""); err != nil {
+		return nil, err
+	}
+
+	hold, err := aggregate.NewSlotHold(payment.ID(), payment.UserID(), payment.VendorID(), payment.StartTime(), payment.EndTime(), payment.ExpiredAt())
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to hold time: %v", err))
+	}
+	return hold, nil
+}
+
+// convertSlotHold turns the time held for a payment into the schedule created for it, within the caller's
+// unit of work. Payments that hold no time are left alone.
+func convertSlotHold(ctx context.Context, uow repository.UnitOfWork, paymentID, scheduleID string) ([]event.DomainEvent, error) {
+	holdRepo := uow.SlotHoldRepository()
+	hold, err := holdRepo.GetByPaymentID(ctx, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slot hold: %w", err)
+	}
+	if hold == nil || hold.Status() != aggregate.SlotHoldStatusHeld {
+		return nil, nil
+	}
+
+	if err := hold.Convert(scheduleID); err != nil {
+		return nil, fmt.Errorf("failed to convert slot hold: %w", err)
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := hold.GetUncommittedEvents()
+	if err := holdRepo.Save(ctx, hold); err != nil {
+		return nil, fmt.Errorf("failed to save slot hold: %w", err)
+	}
+	return events, nil
+}
+
+// releaseSlotHold gives back the time held for a payment within the caller's unit of work and returns the
+// released hold, or nil when the payment holds no time
+func releaseSlotHold(ctx context.Context, uow repository.UnitOfWork, paymentID, reason string) (*aggregate.SlotHold, []event.DomainEvent, error) {
+	holdRepo := uow.SlotHoldRepository()
+	hold, err := holdRepo.GetByPaymentID(ctx, paymentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get slot hold: %w", err)
+	}
+	if hold == nil || hold.Status() != aggregate.SlotHoldStatusHeld {
+		return nil, nil, nil
+	}
+
+	if err := hold.Release(reason); err != nil {
+		return nil, nil, fmt.Errorf("failed to release slot hold: %w", err)
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := hold.GetUncommittedEvents()
+	if err := holdRepo.Save(ctx, hold); err != nil {
+		return nil, nil, fmt.Errorf("failed to save slot hold: %w", err)
+	}
+	return hold, events, nil
+}
+
+// ReleaseUnpaidPayment undoes what a payment held once it is cancelled or expires, within the caller's unit
+// of work: the time held during checkout and the coupon redeemed with the payment are given back, a
+// booking rescheduled with the payment as its top-up moves back to its earlier time, and recurring bookings
+// waiting for the payment are released (see ReleaseUnpaidSeriesPayment).
+func ReleaseUnpaidPayment(ctx context.Context, uow repository.UnitOfWork, payment *aggregate.Payment, reason string) ([]event.DomainEvent, error) {
+	hold, events, err := releaseSlotHold(ctx, uow, payment.ID(), reason)
+	if err != nil {
+		return nil, err
+	}
+
+	revertEvents, err := revertUnpaidReschedule(ctx, uow, payment, hold, reason)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, revertEvents...)
+
+	couponEvents, err := releaseCouponRedemption(ctx, uow, payment, reason)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, couponEvents...)
+
+	seriesEvents, err := ReleaseUnpaidSeriesPayment(ctx, uow, payment, reason)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, seriesEvents...), nil
+}
@@ -0,0 +1,359 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+)
+
+// ============================================
+// Create Species Handler (UoW)
+// ============================================
+
+// CreateSpeciesWithUoWHandler handles create species commands with Unit of Work
+type CreateSpeciesWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewCreateSpeciesWithUoWHandler creates a new create species handler
+func NewCreateSpeciesWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *CreateSpeciesWithUoWHandler {
+	return &CreateSpeciesWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the create species command
+func (h *CreateSpeciesWithUoWHandler) Handle(ctx context.Context, cmd *CreateSpecies) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.Code == "" {
+		return errors.NewValidationError("code is required")
+	}
+
+	breeds := make([]event.SpeciesBreed, 0, len(cmd.Breeds))
+	for _, breed := range cmd.Breeds {
+		breeds = append(breeds, breed.toEvent())
+	}
+
+	species, err := aggregate.NewSpecies(cmd.Code, cmd.NameEn, cmd.NameVi, cmd.Aliases, breeds, cmd.CreatedBy)
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("failed to create species: %v", err))
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	speciesRepo := uow.SpeciesRepository()
+	if _, err := speciesRepo.GetByID(ctx, species.ID()); err == nil {
+		uow.Rollback(ctx)
+		return errors.NewConflictError(fmt.Sprintf("species %s already exists", species.ID()))
+	}
+	if err := checkSpeciesTermsUnique(ctx, speciesRepo, species); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := species.GetUncommittedEvents()
+
+	if err := speciesRepo.Save(ctx, species); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save species: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish species events: %v\n", err)
+	}
+
+	return nil
+}
+
+// ============================================
+// Update Species Handler (UoW)
+// ============================================
+
+// UpdateSpeciesWithUoWHandler handles update species commands with Unit of Work
+type UpdateSpeciesWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdateSpeciesWithUoWHandler creates a new update species handler
+func NewUpdateSpeciesWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *UpdateSpeciesWithUoWHandler {
+	return &UpdateSpeciesWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update species command
+func (h *UpdateSpeciesWithUoWHandler) Handle(ctx context.Context, cmd *UpdateSpecies) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	return changeSpecies(ctx, h.uowFactory, h.eventBus, cmd.SpeciesID, "update species", func(species *aggregate.Species) error {
+		return species.Update(cmd.NameEn, cmd.NameVi, cmd.Aliases)
+	})
+}
+
+// ============================================
+// Deactivate Species Handler (UoW)
+// ============================================
+
+// DeactivateSpeciesWithUoWHandler handles deactivate species commands with Unit of Work
+type DeactivateSpeciesWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewDeactivateSpeciesWithUoWHandler creates a new deactivate species handler
+func NewDeactivateSpeciesWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *DeactivateSpeciesWithUoWHandler {
+	return &DeactivateSpeciesWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the deactivate species command
+func (h *DeactivateSpeciesWithUoWHandler) Handle(ctx context.Context, cmd *DeactivateSpecies) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	return changeSpecies(ctx, h.uowFactory, h.eventBus, cmd.SpeciesID, "deactivate species", func(species *aggregate.Species) error {
+		return species.Deactivate()
+	})
+}
+
+// ============================================
+// Species Breed Handlers (UoW)
+// ============================================
+
+// AddSpeciesBreedWithUoWHandler handles add breed commands with Unit of Work
+type AddSpeciesBreedWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewAddSpeciesBreedWithUoWHandler creates a new add breed handler
+func NewAddSpeciesBreedWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *AddSpeciesBreedWithUoWHandler {
+	return &AddSpeciesBreedWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the add breed command
+func (h *AddSpeciesBreedWithUoWHandler) Handle(ctx context.Context, cmd *AddSpeciesBreed) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	return changeSpecies(ctx, h.uowFactory, h.eventBus, cmd.SpeciesID, "add breed", func(species *aggregate.Species) error {
+		return species.AddBreed(cmd.SpeciesBreedData.toEvent())
+	})
+}
+
+// UpdateSpeciesBreedWithUoWHandler handles update breed commands with Unit of Work
+type UpdateSpeciesBreedWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdateSpeciesBreedWithUoWHandler creates a new update breed handler
+func NewUpdateSpeciesBreedWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *UpdateSpeciesBreedWithUoWHandler {
+	return &UpdateSpeciesBreedWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update breed command
+func (h *UpdateSpeciesBreedWithUoWHandler) Handle(ctx context.Context, cmd *UpdateSpeciesBreed) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+
+	return changeSpecies(ctx, h.uowFactory, h.eventBus, cmd.SpeciesID, "update breed", func(species *aggregate.Species) error {
+		return species.UpdateBreed(cmd.SpeciesBreedData.toEvent())
+	})
+}
+
+// RemoveSpeciesBreedWithUoWHandler handles remove breed commands with Unit of Work
+type RemoveSpeciesBreedWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewRemoveSpeciesBreedWithUoWHandler creates a new remove breed handler
+func NewRemoveSpeciesBreedWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *RemoveSpeciesBreedWithUoWHandler {
+	return &RemoveSpeciesBreedWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the remove breed command
+func (h *RemoveSpeciesBreedWithUoWHandler) Handle(ctx context.Context, cmd *RemoveSpeciesBreed) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.BreedCode == "" {
+		return errors.NewValidationError("breed_code is required")
+	}
+
+	return changeSpecies(ctx, h.uowFactory, h.eventBus, cmd.SpeciesID, "remove breed", func(species *aggregate.Species) error {
+		return species.RemoveBreed(cmd.BreedCode)
+	})
+}
+
+// ============================================
+// Catalogue helpers
+// ============================================
+
+// toEvent converts breed command data to the catalogue breed
+func (b SpeciesBreedData) toEvent() event.SpeciesBreed {
+	return event.SpeciesBreed{
+		Code:    b.Code,
+		NameEn:  b.NameEn,
+		NameVi:  b.NameVi,
+		Aliases: b.Aliases,
+		Size:    b.Size,
+	}
+}
+
+// changeSpecies loads a species, applies a change to it and saves it in one transaction
+func changeSpecies(ctx context.Context, uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus, speciesID, action string, change func(*aggregate.Species) error) error {
+	if speciesID == "" {
+		return errors.NewValidationError("species_id is required")
+	}
+
+	uow := uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	speciesRepo := uow.SpeciesRepository()
+	species, err := speciesRepo.GetByID(ctx, aggregate.NormalizeCatalogTerm(speciesID))
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("species")
+	}
+
+	if err := change(species); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to %s: %v", action, err))
+	}
+	if err := checkSpeciesTermsUnique(ctx, speciesRepo, species); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := species.GetUncommittedEvents()
+
+	if err := speciesRepo.Save(ctx, species); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save species: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish species events: %v\n", err)
+	}
+
+	return nil
+}
+
+// checkSpeciesTermsUnique makes sure no other species already answers to the code, names or aliases of a species
+func checkSpeciesTermsUnique(ctx context.Context, speciesRepo repository.SpeciesRepository, species *aggregate.Species) error {
+	for _, term := range species.Terms() {
+		other, err := speciesRepo.GetByTerm(ctx, term)
+		if err != nil {
+			return errors.NewInternalError(fmt.Sprintf("failed to check species names: %v", err))
+		}
+		if other != nil && other.ID() != species.ID() {
+			return errors.NewConflictError(fmt.Sprintf("%q already refers to species %s", term, other.ID()))
+		}
+	}
+	return nil
+}
+
+// resolvePetSpecies maps the species and breed given for a pet to catalogue codes. The species may be
+// given by code, English or Vietnamese name, or alias. Breeds must come from the catalogue when the
+// species lists its breeds. A deactivated species is only accepted if it is the pet's current species.
+func resolvePetSpecies(ctx context.Context, uow repository.UnitOfWork, speciesTerm, breedTerm, currentSpecies string) (string, string, error) {
+	species, err := uow.SpeciesRepository().GetByTerm(ctx, speciesTerm)
+	if err != nil {
+		return "", "", errors.NewInternalError(fmt.Sprintf("failed to look up species: %v", err))
+	}
+	if species == nil {
+		return "", "", errors.NewValidationError(fmt.Sprintf("unknown species: %s", speciesTerm))
+	}
+	if !species.IsActive() && !species.Matches(currentSpecies) {
+		return "", "", errors.NewValidationError(fmt.Sprintf("species %s can no longer be chosen", species.ID()))
+	}
+
+	breedTerm = strings.TrimSpace(breedTerm)
+	if breedTerm == "" {
+		return species.ID(), "", nil
+	}
+	if !species.HasBreeds() {
+		return species.ID(), breedTerm, nil
+	}
+	breed, found := species.FindBreed(breedTerm)
+	if !found {
+		return "", "", errors.NewValidationError(fmt.Sprintf("unknown breed for %s: %s", species.ID(), breedTerm))
+	}
+	return species.ID(), breed.Code, nil
+}
+
+// checkServiceAcceptsPet checks a pet against the eligibility rules of a service. Pets recorded before the
+// catalogue existed are matched through the catalogue by their free-text species and breed.
+func checkServiceAcceptsPet(ctx context.Context, uow repository.UnitOfWork, service *aggregate.Service, pet *aggregate.Pet) error {
+	eligibility := service.Eligibility()
+	if !eligibility.IsRestricted() {
+		return nil
+	}
+
+	speciesCode := aggregate.NormalizeCatalogTerm(pet.Species())
+	size := aggregate.PetSizeForWeight(pet.Weight())
+	species, err := uow.SpeciesRepository().GetByTerm(ctx, pet.Species())
+	if err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to look up species: %v", err))
+	}
+	if species != nil {
+		speciesCode = species.ID()
+		if breed, found := species.FindBreed(pet.Breed()); found && size == "" {
+			size = breed.Size
+		}
+	}
+
+	if err := eligibility.Accepts(speciesCode, size, pet.Weight()); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("service %s %v", service.Name(), err))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/repository"
@@ -238,3 +239,171 @@ func (h *DeleteVendorWithUoWHandler) Handle(ctx context.Context, cmd *DeleteVend
 
 	return nil
 }
+
+// requireVendorManager returns a forbidden error with the given message unless the user is an admin or an
+// active owner or manager of the vendor
+func requireVendorManager(ctx context.Context, uow repository.UnitOfWork, userID, vendorID string, isAdmin bool, message string) error {
+	if isAdmin {
+		return nil
+	}
+	if userID == "" {
+		return errors.NewForbiddenError(message)
+	}
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, userID+"-"+vendorID)
+	if err != nil || staff == nil || !staff.IsActive() ||
+		(staff.Role() != aggregate.VendorStaffRoleOwner && staff.Role() != aggregate.VendorStaffRoleManager) {
+		return errors.NewForbiddenError(message)
+	}
+	return nil
+}
+
+// ============================================
+// Update Vendor Payment Methods Handler (UoW)
+// ============================================
+
+// UpdateVendorPaymentMethodsWithUoWHandler handles vendor payment method updates with Unit of Work
+type UpdateVendorPaymentMethodsWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdateVendorPaymentMethodsWithUoWHandler creates a new update vendor payment methods handler
+func NewUpdateVendorPaymentMethodsWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *UpdateVendorPaymentMethodsWithUoWHandler {
+	return &UpdateVendorPaymentMethodsWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update vendor payment methods command
+func (h *UpdateVendorPaymentMethodsWithUoWHandler) Handle(ctx context.Context, cmd *UpdateVendorPaymentMethods) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.VendorID == "" {
+		return errors.NewValidationError("vendor_id is required")
+	}
+	if len(cmd.PaymentMethods) == 0 {
+		return errors.NewValidationError("payment_methods are required")
+	}
+
+	methods := make([]aggregate.PaymentMethod, 0, len(cmd.PaymentMethods))
+	for _, name := range cmd.PaymentMethods {
+		method := aggregate.PaymentMethod(strings.ToUpper(name))
+		if !method.IsValid() {
+			return errors.NewValidationError(fmt.Sprintf("unsupported payment method: %s", name))
+		}
+		methods = append(methods, method)
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	vendorRepo := uow.VendorRepository()
+	vendor, err := vendorRepo.GetByID(ctx, cmd.VendorID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("vendor")
+	}
+
+	if err := requireVendorManager(ctx, uow, cmd.UpdatedBy, cmd.VendorID, cmd.IsAdmin,
+		"only an owner or manager of the vendor can change its payment methods"); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	if err := vendor.UpdatePaymentMethods(methods); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update payment methods: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := vendor.GetUncommittedEvents()
+
+	if err := vendorRepo.Save(ctx, vendor); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save vendor: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish vendor events: %v\n", err)
+	}
+
+	return nil
+}
+
+// UpdateVendorReschedulePolicyWithUoWHandler handles vendor reschedule policy updates with Unit of Work
+type UpdateVendorReschedulePolicyWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewUpdateVendorReschedulePolicyWithUoWHandler creates a new update vendor reschedule policy handler
+func NewUpdateVendorReschedulePolicyWithUoWHandler(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus) *UpdateVendorReschedulePolicyWithUoWHandler {
+	return &UpdateVendorReschedulePolicyWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle processes the update vendor reschedule policy command
+func (h *UpdateVendorReschedulePolicyWithUoWHandler) Handle(ctx context.Context, cmd *UpdateVendorReschedulePolicy) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.VendorID == "" {
+		return errors.NewValidationError("vendor_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	vendorRepo := uow.VendorRepository()
+	vendor, err := vendorRepo.GetByID(ctx, cmd.VendorID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("vendor")
+	}
+
+	if err := requireVendorManager(ctx, uow, cmd.UpdatedBy, cmd.VendorID, cmd.IsAdmin,
+		"only an owner or manager of the vendor can change its reschedule policy"); err != nil {
+		uow.Rollback(ctx)
+		return err
+	}
+
+	if err := vendor.UpdateReschedulePolicy(cmd.CutoffHours, cmd.MaxReschedules); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to update reschedule policy: %v", err))
+	}
+
+	// Get events BEFORE saving (Save() will clear them)
+	events := vendor.GetUncommittedEvents()
+
+	if err := vendorRepo.Save(ctx, vendor); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save vendor: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish vendor events: %v\n", err)
+	}
+
+	return nil
+}
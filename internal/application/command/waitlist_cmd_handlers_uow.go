@@ -0,0 +1,507 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/pkg/errors"
+)
+
+// JoinWaitlistWithUoWHandler handles join waitlist commands with Unit of Work
+type JoinWaitlistWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewJoinWaitlistWithUoWHandler creates a new join waitlist handler with UoW
+func NewJoinWaitlistWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *JoinWaitlistWithUoWHandler {
+	return &JoinWaitlistWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle puts the customer on the waitlist and returns the entry ID
+func (h *JoinWaitlistWithUoWHandler) Handle(ctx context.Context, cmd *JoinWaitlist) (string, error) {
+	if cmd == nil {
+		return "", errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.UserID == "" {
+		return "", errors.NewUnauthorizedError("user not authenticated")
+	}
+	if cmd.VendorID == "" {
+		return "", errors.NewValidationError("vendor_id is required")
+	}
+	if cmd.ServiceID == "" {
+		return "", errors.NewValidationError("service_id is required")
+	}
+	if cmd.PetID == "" {
+		return "", errors.NewValidationError("pet_id is required")
+	}
+
+	windowStart, err := time.Parse(time.RFC3339, cmd.WindowStart)
+	if err != nil {
+		return "", errors.NewValidationError(fmt.Sprintf("invalid window_start format: %v", err))
+	}
+	windowEnd, err := time.Parse(time.RFC3339, cmd.WindowEnd)
+	if err != nil {
+		return "", errors.NewValidationError(fmt.Sprintf("invalid window_end format: %v", err))
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return "", errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	pet, err := uow.PetRepository().GetByID(ctx, cmd.PetID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewNotFoundError("pet")
+	}
+	if !pet.CanBeBookedBy(cmd.UserID) {
+		uow.Rollback(ctx)
+		return "", errors.NewForbiddenError("pet does not belong to this user or a household they care for")
+	}
+
+	service, err := uow.ServiceRepository().GetByID(ctx, cmd.ServiceID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewNotFoundError("service")
+	}
+	if service.VendorID() != cmd.VendorID {
+		uow.Rollback(ctx)
+		return "", errors.NewValidationError(fmt.Sprintf("service %s does not belong to vendor %s", cmd.ServiceID, cmd.VendorID))
+	}
+	if err := checkServiceAcceptsPet(ctx, uow, service, pet); err != nil {
+		uow.Rollback(ctx)
+		return "", err
+	}
+
+	entry, err := aggregate.NewWaitlistEntry(cmd.UserID, cmd.PetID, cmd.VendorID, cmd.ServiceID, windowStart, windowEnd)
+	if err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewValidationError(fmt.Sprintf("failed to join waitlist: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := entry.GetUncommittedEvents()
+	if err := uow.WaitlistRepository().Save(ctx, entry); err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewInternalError(fmt.Sprintf("failed to save waitlist entry: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return "", errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish waitlist events: %v\n", err)
+	}
+
+	return entry.ID(), nil
+}
+
+// OfferWaitlistSlotWithUoWHandler offers freed slots to the next customer on the waitlist with Unit of Work
+type OfferWaitlistSlotWithUoWHandler struct {
+	uowFactory repository.UnitOfWorkFactory
+	eventBus   bus.EventBus
+}
+
+// NewOfferWaitlistSlotWithUoWHandler creates a new offer waitlist slot handler with UoW
+func NewOfferWaitlistSlotWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+) *OfferWaitlistSlotWithUoWHandler {
+	return &OfferWaitlistSlotWithUoWHandler{
+		uowFactory: uowFactory,
+		eventBus:   eventBus,
+	}
+}
+
+// Handle offers the slot to the first customer in line and returns their entry ID. Nothing is offered,
+// without an error, when nobody is waiting for the slot or it is no longer free.
+func (h *OfferWaitlistSlotWithUoWHandler) Handle(ctx context.Context, cmd *OfferWaitlistSlot) (string, error) {
+	if cmd == nil {
+		return "", errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.VendorID == "" || len(cmd.ServiceIDs) == 0 {
+		return "", errors.NewValidationError("vendor and services of the slot are required")
+	}
+
+	now := time.Now()
+	if !cmd.StartTime.After(now) {
+		return "", nil
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return "", errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	// The booking that freed the slot may not be committed yet, so it does not count
+	if err := checkShopSlot(ctx, uow, cmd.VendorID, cmd.StartTime, cmd.EndTime, cmd.FreedScheduleID, ""); err != nil {
+		uow.Rollback(ctx)
+		if appErr, ok := err.(*errors.ApplicationError); ok && appErr.Code == "CONFLICT" {
+			return "", nil
+		}
+		return "", err
+	}
+
+	waitlistRepo := uow.WaitlistRepository()
+	entry, err := waitlistRepo.FindNextForSlot(ctx, cmd.VendorID, cmd.ServiceIDs, cmd.StartTime, cmd.EndTime, cmd.JoinedAfter)
+	if err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewInternalError(fmt.Sprintf("failed to find waitlist entry: %v", err))
+	}
+	if entry == nil {
+		uow.Rollback(ctx)
+		return "", nil
+	}
+
+	if err := entry.OfferSlot(cmd.StartTime, cmd.EndTime, cmd.ServiceIDs, cmd.FreedScheduleID, now); err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewValidationError(fmt.Sprintf("failed to offer slot: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := entry.GetUncommittedEvents()
+	if err := waitlistRepo.Save(ctx, entry); err != nil {
+		uow.Rollback(ctx)
+		return "", errors.NewInternalError(fmt.Sprintf("failed to save waitlist entry: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return "", errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish waitlist events: %v\n", err)
+	}
+
+	return entry.ID(), nil
+}
+
+// offerToNextInLine passes a slot that was offered to an entry on to the customers who joined after it
+func (h *OfferWaitlistSlotWithUoWHandler) offerToNextInLine(ctx context.Context, entry *aggregate.WaitlistEntry, offer event.WaitlistOffer) {
+	nextEntryID, err := h.Handle(ctx, &OfferWaitlistSlot{
+		VendorID:        entry.VendorID(),
+		ServiceIDs:      offer.ServiceIDs,
+		StartTime:       offer.StartTime,
+		EndTime:         offer.EndTime,
+		FreedScheduleID: offer.FreedScheduleID,
+		JoinedAfter:     entry.CreatedAt(),
+	})
+	if err != nil {
+		fmt.Printf("❌ Failed to offer slot to the next customer after waitlist entry %s: %v\n", entry.ID(), err)
+		return
+	}
+	if nextEntryID != "" {
+		fmt.Printf("📨 Slot passed from waitlist entry %s to %s\n", entry.ID(), nextEntryID)
+	}
+}
+
+// DeclineWaitlistOfferWithUoWHandler handles offered slots that are turned down or not claimed in time with Unit of Work
+type DeclineWaitlistOfferWithUoWHandler struct {
+	uowFactory   repository.UnitOfWorkFactory
+	eventBus     bus.EventBus
+	offerHandler *OfferWaitlistSlotWithUoWHandler
+}
+
+// NewDeclineWaitlistOfferWithUoWHandler creates a new decline waitlist offer handler with UoW
+func NewDeclineWaitlistOfferWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	offerHandler *OfferWaitlistSlotWithUoWHandler,
+) *DeclineWaitlistOfferWithUoWHandler {
+	return &DeclineWaitlistOfferWithUoWHandler{
+		uowFactory:   uowFactory,
+		eventBus:     eventBus,
+		offerHandler: offerHandler,
+	}
+}
+
+// Handle turns down the offered slot for the customer, who stays on the waitlist
+func (h *DeclineWaitlistOfferWithUoWHandler) Handle(ctx context.Context, cmd *DeclineWaitlistOffer) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.EntryID == "" {
+		return errors.NewValidationError("entry_id is required")
+	}
+	return h.pass(ctx, cmd.EntryID, cmd.UserID, aggregate.WaitlistOfferDeclined)
+}
+
+// Lapse passes on an offered slot that was not claimed in time
+func (h *DeclineWaitlistOfferWithUoWHandler) Lapse(ctx context.Context, entryID string) error {
+	return h.pass(ctx, entryID, "", aggregate.WaitlistOfferLapsed)
+}
+
+// pass gives up the entry's offer and offers the slot to the next customer in line. userID is checked
+// against the entry when set.
+func (h *DeclineWaitlistOfferWithUoWHandler) pass(ctx context.Context, entryID, userID, reason string) error {
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	waitlistRepo := uow.WaitlistRepository()
+	entry, err := waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("waitlist entry")
+	}
+	if userID != "" && entry.UserID() != userID {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("you cannot change this waitlist entry")
+	}
+	if reason == aggregate.WaitlistOfferLapsed && !entry.HasLapsedOffer(time.Now()) {
+		uow.Rollback(ctx)
+		return nil
+	}
+
+	offer := entry.Offer()
+	if err := entry.PassOffer(reason); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to pass on offer: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := entry.GetUncommittedEvents()
+	if err := waitlistRepo.Save(ctx, entry); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save waitlist entry: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish waitlist events: %v\n", err)
+	}
+
+	h.offerHandler.offerToNextInLine(ctx, entry, *offer)
+	return nil
+}
+
+// LeaveWaitlistWithUoWHandler handles leave waitlist commands with Unit of Work
+type LeaveWaitlistWithUoWHandler struct {
+	uowFactory   repository.UnitOfWorkFactory
+	eventBus     bus.EventBus
+	offerHandler *OfferWaitlistSlotWithUoWHandler
+}
+
+// NewLeaveWaitlistWithUoWHandler creates a new leave waitlist handler with UoW
+func NewLeaveWaitlistWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	offerHandler *OfferWaitlistSlotWithUoWHandler,
+) *LeaveWaitlistWithUoWHandler {
+	return &LeaveWaitlistWithUoWHandler{
+		uowFactory:   uowFactory,
+		eventBus:     eventBus,
+		offerHandler: offerHandler,
+	}
+}
+
+// Handle takes the customer off the waitlist. A slot they were offered goes to the next customer in line.
+func (h *LeaveWaitlistWithUoWHandler) Handle(ctx context.Context, cmd *LeaveWaitlist) error {
+	if cmd == nil {
+		return errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.EntryID == "" {
+		return errors.NewValidationError("entry_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	waitlistRepo := uow.WaitlistRepository()
+	entry, err := waitlistRepo.GetByID(ctx, cmd.EntryID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("waitlist entry")
+	}
+	if !cmd.IsAdmin && entry.UserID() != cmd.UserID {
+		uow.Rollback(ctx)
+		return errors.NewForbiddenError("you cannot change this waitlist entry")
+	}
+
+	hadOffer := entry.Status() == aggregate.WaitlistStatusOffered
+	if err := entry.Leave(); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to leave waitlist: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := entry.GetUncommittedEvents()
+	if err := waitlistRepo.Save(ctx, entry); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save waitlist entry: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish waitlist events: %v\n", err)
+	}
+
+	if hadOffer {
+		h.offerHandler.offerToNextInLine(ctx, entry, *entry.Offer())
+	}
+	return nil
+}
+
+// ClaimWaitlistOfferWithUoWHandler checks out offered slots with Unit of Work. The checkout is a regular
+// booking payment, which holds the slot until it is paid.
+type ClaimWaitlistOfferWithUoWHandler struct {
+	uowFactory           repository.UnitOfWorkFactory
+	createPaymentHandler *CreatePaymentWithUoWHandler
+}
+
+// NewClaimWaitlistOfferWithUoWHandler creates a new claim waitlist offer handler with UoW
+func NewClaimWaitlistOfferWithUoWHandler(
+	uowFactory repository.UnitOfWorkFactory,
+	createPaymentHandler *CreatePaymentWithUoWHandler,
+) *ClaimWaitlistOfferWithUoWHandler {
+	return &ClaimWaitlistOfferWithUoWHandler{
+		uowFactory:           uowFactory,
+		createPaymentHandler: createPaymentHandler,
+	}
+}
+
+// Handle creates the checkout of the offered slot at the service's current price
+func (h *ClaimWaitlistOfferWithUoWHandler) Handle(ctx context.Context, cmd *ClaimWaitlistOffer) (*CreatePaymentResponse, error) {
+	if cmd == nil {
+		return nil, errors.NewValidationError("command cannot be nil")
+	}
+	if cmd.EntryID == "" {
+		return nil, errors.NewValidationError("entry_id is required")
+	}
+
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	entry, err := uow.WaitlistRepository().GetByID(ctx, cmd.EntryID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("waitlist entry")
+	}
+	if entry.UserID() != cmd.UserID {
+		return nil, errors.NewForbiddenError("this slot was offered to another customer")
+	}
+	offer := entry.Offer()
+	if entry.Status() != aggregate.WaitlistStatusOffered || entry.HasLapsedOffer(time.Now()) {
+		return nil, errors.NewValidationError("there is no open offer to claim")
+	}
+
+	service, err := uow.ServiceRepository().GetByID(ctx, entry.ServiceID())
+	if err != nil {
+		return nil, errors.NewNotFoundError("service")
+	}
+	price, err := quoteServices(ctx, uow, []string{entry.ServiceID()}, offer.StartTime, offer.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.createPaymentHandler.Handle(ctx, &CreatePaymentCommand{
+		UserID:          entry.UserID(),
+		Amount:          price,
+		Description:     "Waitlist booking",
+		Items:           []aggregate.PaymentItem{{Name: service.Name(), Quantity: 1, Price: price}},
+		VendorID:        entry.VendorID(),
+		PetID:           entry.PetID(),
+		ServiceIDs:      []string{entry.ServiceID()},
+		StartTime:       offer.StartTime.Format(time.RFC3339),
+		EndTime:         offer.EndTime.Format(time.RFC3339),
+		Method:          cmd.Method,
+		CouponCode:      cmd.CouponCode,
+		WaitlistEntryID: entry.ID(),
+	})
+}
+
+// claimWaitlistOffer claims the waitlist offer a booking payment checks out, within the caller's unit of
+// work, and returns the entry's events. The entry is saved straight away so its offer no longer blocks the
+// slot for the payment's own hold.
+func claimWaitlistOffer(ctx context.Context, uow repository.UnitOfWork, entryID string, payment *aggregate.Payment) ([]event.DomainEvent, error) {
+	waitlistRepo := uow.WaitlistRepository()
+	entry, err := waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("waitlist entry")
+	}
+	if entry.VendorID() != payment.VendorID() || entry.PetID() != payment.PetID() {
+		return nil, errors.NewValidationError("checkout does not match the waitlist entry")
+	}
+
+	if err := entry.Claim(payment.UserID(), payment.ID(), payment.StartTime(), payment.EndTime(), time.Now()); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("failed to claim offered slot: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := entry.GetUncommittedEvents()
+	if err := waitlistRepo.Save(ctx, entry); err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to save waitlist entry: %v", err))
+	}
+	return events, nil
+}
+
+// Expire closes a waiting entry whose time window has passed
+func (h *LeaveWaitlistWithUoWHandler) Expire(ctx context.Context, entryID string) error {
+	uow := h.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to begin transaction: %v", err))
+	}
+
+	waitlistRepo := uow.WaitlistRepository()
+	entry, err := waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return errors.NewNotFoundError("waitlist entry")
+	}
+	if entry.Status() != aggregate.WaitlistStatusWaiting {
+		uow.Rollback(ctx)
+		return nil
+	}
+
+	if err := entry.Expire(time.Now()); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewValidationError(fmt.Sprintf("failed to expire waitlist entry: %v", err))
+	}
+
+	// Get events BEFORE saving (Save will clear them)
+	events := entry.GetUncommittedEvents()
+	if err := waitlistRepo.Save(ctx, entry); err != nil {
+		uow.Rollback(ctx)
+		return errors.NewInternalError(fmt.Sprintf("failed to save waitlist entry: %v", err))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return errors.NewInternalError(fmt.Sprintf("failed to commit transaction: %v", err))
+	}
+
+	if err := h.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish waitlist events: %v\n", err)
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package query
 
 import (
 	"context"
+	"math"
+	"sort"
+	"time"
 	"whisko-petcare/internal/infrastructure/projection"
 	"whisko-petcare/pkg/errors"
 )
@@ -125,3 +128,139 @@ func (h *ListPetsHandler) Handle(ctx context.Context, query *ListPets) ([]*proje
 
 	return pets, nil
 }
+
+// GetPetWeightHistory represents a query to get a pet's weight history
+type GetPetWeightHistory struct {
+	PetID string    `json:"pet_id"`
+	Since time.Time `json:"since,omitempty"`
+}
+
+// PetWeightStats summarises a pet's weight history
+type PetWeightStats struct {
+	Count         int       `json:"count"`
+	Min           float64   `json:"min"`
+	Max           float64   `json:"max"`
+	Average       float64   `json:"average"`
+	First         float64   `json:"first"`
+	Latest        float64   `json:"latest"`
+	Change        float64   `json:"change"`         // Latest minus first
+	ChangePercent float64   `json:"change_percent"` // Change relative to the first measurement
+	WeeklyRate    float64   `json:"weekly_rate"`    // Average change per week between the first and latest measurement
+	Trend         string    `json:"trend"`          // GAINING, LOSING or STABLE
+	FirstAt       time.Time `json:"first_at"`
+	LatestAt      time.Time `json:"latest_at"`
+}
+
+// PetWeightHistory is a pet's weight measurements, oldest first, with trend statistics
+type PetWeightHistory struct {
+	PetID         string                             `json:"pet_id"`
+	CurrentWeight float64                            `json:"current_weight"`
+	Measurements  []projection.WeightMeasurementView `json:"measurements"`
+	Stats         *PetWeightStats                    `json:"stats,omitempty"`
+}
+
+// Weight trends
+const (
+	WeightTrendGaining = "GAINING"
+	WeightTrendLosing  = "LOSING"
+	WeightTrendStable  = "STABLE"
+)
+
+// weightStableThresholdPercent is the change below which a weight is considered stable
+const weightStableThresholdPercent = 2.0
+
+// GetPetWeightHistoryHandler handles get pet weight history queries
+type GetPetWeightHistoryHandler struct {
+	petProjection projection.PetProjection
+}
+
+// NewGetPetWeightHistoryHandler creates a new get pet weight history handler
+func NewGetPetWeightHistoryHandler(petProjection projection.PetProjection) *GetPetWeightHistoryHandler {
+	return &GetPetWeightHistoryHandler{
+		petProjection: petProjection,
+	}
+}
+
+// Handle processes the get pet weight history query
+func (h *GetPetWeightHistoryHandler) Handle(ctx context.Context, query *GetPetWeightHistory) (*PetWeightHistory, error) {
+	if query == nil {
+		return nil, errors.NewValidationError("query cannot be nil")
+	}
+
+	if query.PetID == "" {
+		return nil, errors.NewValidationError("pet_id is required")
+	}
+
+	pet, err := h.petProjection.GetByID(ctx, query.PetID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+
+	measurements := []projection.WeightMeasurementView{}
+	for _, m := range pet.Weights {
+		if !query.Since.IsZero() && m.MeasuredAt.Before(query.Since) {
+			continue
+		}
+		measurements = append(measurements, m)
+	}
+	sort.SliceStable(measurements, func(i, j int) bool {
+		return measurements[i].MeasuredAt.Before(measurements[j].MeasuredAt)
+	})
+
+	return &PetWeightHistory{
+		PetID:         pet.ID,
+		CurrentWeight: pet.Weight,
+		Measurements:  measurements,
+		Stats:         weightStats(measurements),
+	}, nil
+}
+
+// weightStats computes trend statistics of measurements sorted oldest first
+func weightStats(measurements []projection.WeightMeasurementView) *PetWeightStats {
+	if len(measurements) == 0 {
+		return nil
+	}
+
+	first := measurements[0]
+	latest := measurements[len(measurements)-1]
+	stats := &PetWeightStats{
+		Count:    len(measurements),
+		Min:      first.Weight,
+		Max:      first.Weight,
+		First:    first.Weight,
+		Latest:   latest.Weight,
+		Change:   roundWeight(latest.Weight - first.Weight),
+		Trend:    WeightTrendStable,
+		FirstAt:  first.MeasuredAt,
+		LatestAt: latest.MeasuredAt,
+	}
+
+	total := 0.0
+	for _, m := range measurements {
+		total += m.Weight
+		stats.Min = math.Min(stats.Min, m.Weight)
+		stats.Max = math.Max(stats.Max, m.Weight)
+	}
+	stats.Average = roundWeight(total / float64(len(measurements)))
+
+	if first.Weight > 0 {
+		stats.ChangePercent = math.Round((latest.Weight-first.Weight)/first.Weight*1000) / 10
+	}
+	if weeks := latest.MeasuredAt.Sub(first.MeasuredAt).Hours() / (24 * 7); weeks >= 1 {
+		stats.WeeklyRate = roundWeight((latest.Weight - first.Weight) / weeks)
+	}
+
+	switch {
+	case stats.ChangePercent >= weightStableThresholdPercent:
+		stats.Trend = WeightTrendGaining
+	case stats.ChangePercent <= -weightStableThresholdPercent:
+		stats.Trend = WeightTrendLosing
+	}
+
+	return stats
+}
+
+// roundWeight rounds a weight to two decimals
+func roundWeight(weight float64) float64 {
+	return math.Round(weight*100) / 100
+}
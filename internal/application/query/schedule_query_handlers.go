@@ -2,17 +2,181 @@ package query
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/infrastructure/projection"
 	"whisko-petcare/pkg/errors"
 )
 
+// Range of staff calendars
+const (
+	DefaultStaffCalendarDays = 7  // Days shown when no end is given
+	MaxStaffCalendarDays     = 92 // Longest range a calendar can show at once
+)
+
 // ScheduleProjection interface for schedule read model
 type ScheduleProjection interface {
 	GetByID(ctx context.Context, id string) (interface{}, error)
-	GetByUserID(ctx context.Context, userID string, offset, limit int) ([]interface{}, error)
-	GetByShopID(ctx context.Context, shopID string, offset, limit int) ([]interface{}, error)
-	ListAll(ctx context.Context, offset, limit int) ([]interface{}, error)
+	GetByStaffID(ctx context.Context, staffID string, from, to time.Time) ([]interface{}, error)
+	Search(ctx context.Context, search projection.ScheduleSearch) (*projection.SchedulePage, error)
+}
+
+// Page sizes of schedule lists
+const (
+	DefaultSchedulePageSize = 10
+	MaxSchedulePageSize     = 100
+)
+
+// maxScheduleSearchLength caps the text a schedule list can be searched for
+const maxScheduleSearchLength = 100
+
+// ScheduleListOptions are the filters, order and page shared by the schedule list queries. Zero fields do
+// not filter; without statuses only active bookings are listed.
+type ScheduleListOptions struct {
+	From      time.Time `json:"from,omitempty"` // Starting at or after
+	To        time.Time `json:"to,omitempty"`   // Starting before
+	Statuses  []string  `json:"statuses,omitempty"`
+	PetID     string    `json:"pet_id,omitempty"`
+	ServiceID string    `json:"service_id,omitempty"`
+	StaffID   string    `json:"staff_id,omitempty"`
+	Search    string    `json:"search,omitempty"` // Pet, customer, shop or service name
+	Sort      string    `json:"sort,omitempty"`   // start_time, -start_time (default), created_at or -created_at
+	Cursor    string    `json:"cursor,omitempty"` // NextCursor of the previous page; first page when empty
+	Offset    int       `json:"offset,omitempty"` // Schedules skipped instead of following a cursor
+	Limit     int       `json:"limit,omitempty"`  // DefaultSchedulePageSize when zero
+}
+
+// ListUserSchedulesQuery lists the bookings of a customer
+type ListUserSchedulesQuery struct {
+	UserID string `json:"user_id"`
+	ScheduleListOptions
+}
+
+// ListShopSchedulesQuery lists the bookings of a shop
+type ListShopSchedulesQuery struct {
+	ShopID string `json:"shop_id"`
+	ScheduleListOptions
+}
+
+// ListSchedulesQuery lists the bookings of every shop
+type ListSchedulesQuery struct {
+	ScheduleListOptions
+}
+
+// SchedulePage is one page of a schedule list
+type SchedulePage struct {
+	Schedules  []*projection.ScheduleReadModel `json:"schedules"`
+	Total      int                             `json:"total"` // Bookings matching the filters across all pages
+	Limit      int                             `json:"limit"`
+	NextCursor string                          `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// searchSchedules validates list options and runs them as a search of the given user's or shop's schedules
+func searchSchedules(ctx context.Context, p ScheduleProjection, userID, shopID string, opts ScheduleListOptions) (*SchedulePage, error) {
+	search := projection.ScheduleSearch{
+		UserID:    userID,
+		ShopID:    shopID,
+		From:      opts.From,
+		To:        opts.To,
+		PetID:     opts.PetID,
+		ServiceID: opts.ServiceID,
+		StaffID:   opts.StaffID,
+		Text:      strings.TrimSpace(opts.Search),
+		Limit:     opts.Limit,
+	}
+
+	if !search.From.IsZero() && !search.To.IsZero() && !search.To.After(search.From) {
+		return nil, errors.NewValidationError("to must be after from")
+	}
+	if len(search.Text) > maxScheduleSearchLength {
+		return nil, errors.NewValidationError(fmt.Sprintf("search must be at most %d characters", maxScheduleSearchLength))
+	}
+	for _, status := range opts.Statuses {
+		if !aggregate.ScheduleStatus(status).IsValid() {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid status: %s", status))
+		}
+		search.Statuses = append(search.Statuses, status)
+	}
+
+	sort := opts.Sort
+	if sort == "" {
+		sort = "-" + projection.ScheduleSortStartTime
+	}
+	search.SortField = strings.TrimPrefix(sort, "-")
+	search.Descending = strings.HasPrefix(sort, "-")
+	if search.SortField != projection.ScheduleSortStartTime && search.SortField != projection.ScheduleSortCreatedAt {
+		return nil, errors.NewValidationError("sort must be start_time or created_at, prefixed with - for newest first")
+	}
+
+	if opts.Offset < 0 {
+		return nil, errors.NewValidationError("offset must not be negative")
+	}
+	if opts.Offset > 0 && opts.Cursor != "" {
+		return nil, errors.NewValidationError("pass either offset or cursor, not both")
+	}
+	search.Offset = opts.Offset
+
+	if search.Limit <= 0 {
+		search.Limit = DefaultSchedulePageSize
+	}
+	if search.Limit > MaxSchedulePageSize {
+		search.Limit = MaxSchedulePageSize
+	}
+
+	if opts.Cursor != "" {
+		after, err := decodeScheduleCursor(opts.Cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+		search.After = after
+	}
+
+	page, err := p.Search(ctx, search)
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to list schedules: %v", err))
+	}
+
+	result := &SchedulePage{
+		Schedules: page.Schedules,
+		Total:     int(page.Total),
+		Limit:     search.Limit,
+	}
+	if page.Next != nil {
+		result.NextCursor = encodeScheduleCursor(page.Next, sort)
+	}
+	return result, nil
+}
+
+// encodeScheduleCursor turns the position of a schedule into an opaque cursor. The sort order is part of
+// the cursor so a cursor cannot be replayed against a list sorted differently.
+func encodeScheduleCursor(c *projection.ScheduleCursor, sort string) string {
+	raw := fmt.Sprintf("%s|%d|%s", sort, c.Value.UnixMilli(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeScheduleCursor reads a cursor made by encodeScheduleCursor for the same sort order
+func decodeScheduleCursor(cursor, sort string) (*projection.ScheduleCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return nil, errors.NewValidationError("invalid cursor")
+	}
+	if parts[0] != sort {
+		return nil, errors.NewValidationError("cursor does not match the sort order")
+	}
+	millis, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid cursor")
+	}
+	return &projection.ScheduleCursor{Value: time.UnixMilli(millis), ID: parts[2]}, nil
 }
 
 // GetScheduleHandler handles get schedule by ID queries
@@ -54,22 +218,15 @@ func NewListUserSchedulesHandler(projection ScheduleProjection) *ListUserSchedul
 }
 
 // Handle processes the list user schedules query
-func (h *ListUserSchedulesHandler) Handle(ctx context.Context, userID string, offset, limit int) ([]interface{}, error) {
-	if userID == "" {
-		return nil, errors.NewValidationError("user_id is required")
+func (h *ListUserSchedulesHandler) Handle(ctx context.Context, query *ListUserSchedulesQuery) (*SchedulePage, error) {
+	if query == nil {
+		return nil, errors.NewValidationError("query cannot be nil")
 	}
-
-	// No limit - return all schedules for the user
-	if limit == 0 {
-		limit = 0 // 0 means no limit in MongoDB
-	}
-
-	schedules, err := h.projection.GetByUserID(ctx, userID, offset, limit)
-	if err != nil {
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to list user schedules: %v", err))
+	if query.UserID == "" {
+		return nil, errors.NewValidationError("user_id is required")
 	}
 
-	return schedules, nil
+	return searchSchedules(ctx, h.projection, query.UserID, "", query.ScheduleListOptions)
 }
 
 // ListShopSchedulesHandler handles list shop schedules queries
@@ -85,24 +242,96 @@ func NewListShopSchedulesHandler(projection ScheduleProjection) *ListShopSchedul
 }
 
 // Handle processes the list shop schedules query
-func (h *ListShopSchedulesHandler) Handle(ctx context.Context, shopID string, offset, limit int) ([]interface{}, error) {
-	if shopID == "" {
+func (h *ListShopSchedulesHandler) Handle(ctx context.Context, query *ListShopSchedulesQuery) (*SchedulePage, error) {
+	if query == nil {
+		return nil, errors.NewValidationError("query cannot be nil")
+	}
+	if query.ShopID == "" {
 		return nil, errors.NewValidationError("shop_id is required")
 	}
 
-	// No limit - return all schedules for the shop
-	if limit == 0 {
-		limit = 0 // 0 means no limit in MongoDB
+	return searchSchedules(ctx, h.projection, "", query.ShopID, query.ScheduleListOptions)
+}
+
+// ListStaffSchedulesHandler handles staff calendar queries: the bookings assigned to a staff member
+type ListStaffSchedulesHandler struct {
+	projection      ScheduleProjection
+	staffProjection VendorStaffProjection
+}
+
+// NewListStaffSchedulesHandler creates a new list staff schedules handler
+func NewListStaffSchedulesHandler(projection ScheduleProjection, staffProjection VendorStaffProjection) *ListStaffSchedulesHandler {
+	return &ListStaffSchedulesHandler{
+		projection:      projection,
+		staffProjection: staffProjection,
+	}
+}
+
+// Handle processes the staff calendar query. Staff see their own calendar; owners and managers see the
+// calendars of staff of their shops. The range starts today when from is zero and spans
+// DefaultStaffCalendarDays when to is zero.
+func (h *ListStaffSchedulesHandler) Handle(ctx context.Context, staffID, requesterID string, isAdmin bool, from, to time.Time) ([]interface{}, error) {
+	if staffID == "" {
+		return nil, errors.NewValidationError("staff_id is required")
+	}
+
+	if from.IsZero() {
+		now := time.Now()
+		from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, DefaultStaffCalendarDays)
+	}
+	if !to.After(from) {
+		return nil, errors.NewValidationError("to must be after from")
+	}
+	if to.Sub(from) > MaxStaffCalendarDays*24*time.Hour {
+		return nil, errors.NewValidationError(fmt.Sprintf("a calendar can show at most %d days", MaxStaffCalendarDays))
 	}
 
-	schedules, err := h.projection.GetByShopID(ctx, shopID, offset, limit)
+	if !isAdmin && requesterID != staffID {
+		allowed, err := h.managesStaff(ctx, requesterID, staffID)
+		if err != nil {
+			return nil, errors.NewInternalError(fmt.Sprintf("failed to check staff access: %v", err))
+		}
+		if !allowed {
+			return nil, errors.NewForbiddenError("only the staff member, or an owner or manager of their shop, can see this calendar")
+		}
+	}
+
+	schedules, err := h.projection.GetByStaffID(ctx, staffID, from, to)
 	if err != nil {
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to list shop schedules: %v", err))
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to list staff schedules: %v", err))
 	}
 
 	return schedules, nil
 }
 
+// managesStaff reports whether the requester is an active owner or manager of a shop the staff member works for
+func (h *ListStaffSchedulesHandler) managesStaff(ctx context.Context, requesterID, staffID string) (bool, error) {
+	memberships, err := h.staffProjection.GetByUserID(ctx, staffID, 0, 0)
+	if err != nil {
+		return false, err
+	}
+
+	for _, m := range memberships {
+		membership, ok := m.(projection.VendorStaffReadModel)
+		if !ok {
+			continue
+		}
+		r, err := h.staffProjection.GetByID(ctx, requesterID, membership.VendorID)
+		if err != nil {
+			continue
+		}
+		requester, ok := r.(projection.VendorStaffReadModel)
+		if ok && requester.IsActive && (requester.Role == "owner" || requester.Role == "manager") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // ListSchedulesHandler handles list all schedules queries
 type ListSchedulesHandler struct {
 	projection ScheduleProjection
@@ -116,19 +345,10 @@ func NewListSchedulesHandler(projection ScheduleProjection) *ListSchedulesHandle
 }
 
 // Handle processes the list schedules query
-func (h *ListSchedulesHandler) Handle(ctx context.Context, offset, limit int) ([]interface{}, error) {
-	// Set default limit if not provided
-	if limit == 0 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100
+func (h *ListSchedulesHandler) Handle(ctx context.Context, query *ListSchedulesQuery) (*SchedulePage, error) {
+	if query == nil {
+		return nil, errors.NewValidationError("query cannot be nil")
 	}
 
-	schedules, err := h.projection.ListAll(ctx, offset, limit)
-	if err != nil {
-		return nil, errors.NewInternalError(fmt.Sprintf("failed to list schedules: %v", err))
-	}
-
-	return schedules, nil
+	return searchSchedules(ctx, h.projection, "", "", query.ScheduleListOptions)
 }
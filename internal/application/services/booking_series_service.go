@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/pkg/errors"
+)
+
+// BookingSeriesView is a recurring booking as shown to the customer
+type BookingSeriesView struct {
+	ID                string                   `json:"id"`
+	UserID            string                   `json:"user_id"`
+	VendorID          string                   `json:"vendor_id"`
+	PetID             string                   `json:"pet_id"`
+	ServiceIDs        []string                 `json:"service_ids"`
+	Recurrence        string                   `json:"recurrence"` // RRULE
+	FirstStartTime    time.Time                `json:"first_start_time"`
+	FirstEndTime      time.Time                `json:"first_end_time"`
+	PaymentMode       string                   `json:"payment_mode"`
+	Method            string                   `json:"method"`
+	OccurrencePrice   int                      `json:"occurrence_price"`
+	OccurrenceCount   int                      `json:"occurrence_count"` // -1 when the series runs until cancelled
+	PaymentID         string                   `json:"payment_id,omitempty"`
+	Status            string                   `json:"status"`
+	Exceptions        []event.SeriesException  `json:"exceptions"`
+	BookedOccurrences []event.SeriesOccurrence `json:"booked_occurrences"`
+	NextOccurrence    *time.Time               `json:"next_occurrence,omitempty"` // First occurrence not booked yet
+	CancelledFrom     *time.Time               `json:"cancelled_from,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+}
+
+// BookingSeriesService manages recurring bookings and books their occurrences ahead of time
+type BookingSeriesService struct {
+	uowFactory repository.UnitOfWorkFactory
+	stopChan   chan struct{}
+
+	createHandler   *command.CreateBookingSeriesWithUoWHandler
+	generateHandler *command.GenerateSeriesOccurrencesWithUoWHandler
+	skipHandler     *command.SkipSeriesOccurrenceWithUoWHandler
+	modifyHandler   *command.ModifySeriesOccurrenceWithUoWHandler
+	cancelHandler   *command.CancelBookingSeriesWithUoWHandler
+}
+
+// NewBookingSeriesService creates a new booking series service
+func NewBookingSeriesService(
+	uowFactory repository.UnitOfWorkFactory,
+	createHandler *command.CreateBookingSeriesWithUoWHandler,
+	generateHandler *command.GenerateSeriesOccurrencesWithUoWHandler,
+	skipHandler *command.SkipSeriesOccurrenceWithUoWHandler,
+	modifyHandler *command.ModifySeriesOccurrenceWithUoWHandler,
+	cancelHandler *command.CancelBookingSeriesWithUoWHandler,
+) *BookingSeriesService {
+	return &BookingSeriesService{
+		uowFactory:      uowFactory,
+		stopChan:        make(chan struct{}),
+		createHandler:   createHandler,
+		generateHandler: generateHandler,
+		skipHandler:     skipHandler,
+		modifyHandler:   modifyHandler,
+		cancelHandler:   cancelHandler,
+	}
+}
+
+// Start begins the background job that books upcoming occurrences of recurring bookings
+func (s *BookingSeriesService) Start(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Hour) // Check every hour
+	defer ticker.Stop()
+
+	fmt.Printf("✅ Booking series service started (checking every 1 hour, booking %s ahead)\n", aggregate.SeriesBookingHorizon)
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.bookDueOccurrences(ctx); err != nil {
+				fmt.Printf("❌ Error booking series occurrences: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Booking series service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Booking series service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *BookingSeriesService) Stop() {
+	close(s.stopChan)
+}
+
+// bookDueOccurrences books the occurrences that came within the booking horizon, one series at a time
+func (s *BookingSeriesService) bookDueOccurrences(ctx context.Context) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	due, err := uow.BookingSeriesRepository().GetDue(ctx, time.Now().Add(aggregate.SeriesBookingHorizon))
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get due booking series: %w", err)
+	}
+
+	bookedCount := 0
+	for _, series := range due {
+		booked, err := s.generateHandler.Handle(ctx, series.ID())
+		if err != nil {
+			fmt.Printf("⚠️  Failed to book occurrences of series %s: %v\n", series.ID(), err)
+			continue
+		}
+		bookedCount += len(booked)
+	}
+
+	if bookedCount > 0 {
+		fmt.Printf("✅ Booked %d recurring booking occurrence(s)\n", bookedCount)
+	}
+
+	return nil
+}
+
+// Command operations
+
+// CreateSeries sets up a recurring booking
+func (s *BookingSeriesService) CreateSeries(ctx context.Context, cmd command.CreateBookingSeries) (*command.CreateBookingSeriesResponse, error) {
+	return s.createHandler.Handle(ctx, &cmd)
+}
+
+// SkipOccurrence skips one occurrence of a recurring booking
+func (s *BookingSeriesService) SkipOccurrence(ctx context.Context, cmd command.SkipSeriesOccurrence) (*command.BookingSeriesChangeResponse, error) {
+	return s.skipHandler.Handle(ctx, &cmd)
+}
+
+// ModifyOccurrence moves one occurrence of a recurring booking
+func (s *BookingSeriesService) ModifyOccurrence(ctx context.Context, cmd command.ModifySeriesOccurrence) (*command.BookingSeriesChangeResponse, error) {
+	return s.modifyHandler.Handle(ctx, &cmd)
+}
+
+// CancelSeries cancels the rest of a recurring booking
+func (s *BookingSeriesService) CancelSeries(ctx context.Context, cmd command.CancelBookingSeries) (*command.BookingSeriesChangeResponse, error) {
+	return s.cancelHandler.Handle(ctx, &cmd)
+}
+
+// Query operations
+
+// GetSeries gets a recurring booking. Only the customer who set it up and admins can see it.
+func (s *BookingSeriesService) GetSeries(ctx context.Context, seriesID, userID string, isAdmin bool) (*BookingSeriesView, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	series, err := uow.BookingSeriesRepository().GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("booking series")
+	}
+	if !isAdmin && series.UserID() != userID {
+		return nil, errors.NewForbiddenError("you cannot view this booking series")
+	}
+
+	view := toBookingSeriesView(series)
+	return &view, nil
+}
+
+// ListUserSeries lists a customer's recurring bookings, newest first
+func (s *BookingSeriesService) ListUserSeries(ctx context.Context, userID string, offset, limit int) ([]BookingSeriesView, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	seriesList, err := uow.BookingSeriesRepository().GetByUserID(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list booking series")
+	}
+
+	result := make([]BookingSeriesView, 0, len(seriesList))
+	for _, series := range seriesList {
+		result = append(result, toBookingSeriesView(series))
+	}
+	return result, nil
+}
+
+// toBookingSeriesView converts a booking series aggregate to its view
+func toBookingSeriesView(series *aggregate.BookingSeries) BookingSeriesView {
+	exceptions := series.Exceptions()
+	if exceptions == nil {
+		exceptions = []event.SeriesException{}
+	}
+	occurrences := series.BookedOccurrences()
+	if occurrences == nil {
+		occurrences = []event.SeriesOccurrence{}
+	}
+
+	view := BookingSeriesView{
+		ID:                series.ID(),
+		UserID:            series.UserID(),
+		VendorID:          series.VendorID(),
+		PetID:             series.PetID(),
+		ServiceIDs:        series.ServiceIDs(),
+		Recurrence:        aggregate.FormatRecurrenceRule(series.Recurrence()),
+		FirstStartTime:    series.FirstStartTime(),
+		FirstEndTime:      series.FirstEndTime(),
+		PaymentMode:       string(series.PaymentMode()),
+		Method:            string(series.Method()),
+		OccurrencePrice:   series.OccurrencePrice(),
+		OccurrenceCount:   series.OccurrenceCount(),
+		PaymentID:         series.PaymentID(),
+		Status:            string(series.Status()),
+		Exceptions:        exceptions,
+		BookedOccurrences: occurrences,
+		CreatedAt:         series.CreatedAt(),
+		UpdatedAt:         series.UpdatedAt(),
+	}
+	if next := series.NextOccurrence(); !next.IsZero() {
+		view.NextOccurrence = &next
+	}
+	if cancelledFrom := series.CancelledFrom(); !cancelledFrom.IsZero() {
+		view.CancelledFrom = &cancelledFrom
+	}
+	return view
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/document"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// Calendar feed contents
+const (
+	CalendarFeedHistory         = 90 * 24 * time.Hour // How far back a feed lists bookings
+	CalendarFeedRefreshInterval = time.Hour           // How often calendar applications are asked to fetch a feed again
+)
+
+// CalendarFeed is a calendar feed together with its signed token and subscription URL
+type CalendarFeed struct {
+	*projection.CalendarFeedReadModel
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// CalendarService publishes bookings to calendar applications: secret iCalendar feed URLs listing the
+// bookings of a customer or a shop, and single bookings as .ics downloads. Feed tokens are the feed ID
+// followed by an HMAC of the feed ID and owner, so a token cannot be forged or pointed at another
+// calendar; an owner has one feed at a time and creating a new one revokes the old URL.
+type CalendarService struct {
+	uowFactory         repository.UnitOfWorkFactory
+	scheduleProjection projection.ScheduleCalendarProjection
+	feedProjection     projection.CalendarFeedProjection
+	secret             []byte
+	baseURL            string
+	timeZone           string
+}
+
+// NewCalendarService creates a new calendar service. The time zone is the IANA zone calendars are shown
+// in by default; booking times themselves are published in UTC.
+func NewCalendarService(
+	uowFactory repository.UnitOfWorkFactory,
+	scheduleProjection projection.ScheduleCalendarProjection,
+	feedProjection projection.CalendarFeedProjection,
+	secret, baseURL, timeZone string,
+) *CalendarService {
+	return &CalendarService{
+		uowFactory:         uowFactory,
+		scheduleProjection: scheduleProjection,
+		feedProjection:     feedProjection,
+		secret:             []byte(secret),
+		baseURL:            strings.TrimSuffix(baseURL, "/"),
+		timeZone:           timeZone,
+	}
+}
+
+// CreateUserFeed creates the calendar feed of the requester's bookings, replacing their previous feed
+func (s *CalendarService) CreateUserFeed(ctx context.Context, userID string) (*CalendarFeed, error) {
+	if userID == "" {
+		return nil, errors.NewUnauthorizedError("user not authenticated")
+	}
+	return s.createFeed(ctx, projection.CalendarFeedOwnerUser, userID, "My Whisko bookings", userID)
+}
+
+// GetUserFeed returns the current calendar feed of the requester's bookings
+func (s *CalendarService) GetUserFeed(ctx context.Context, userID string) (*CalendarFeed, error) {
+	if userID == "" {
+		return nil, errors.NewUnauthorizedError("user not authenticated")
+	}
+	return s.activeFeed(ctx, projection.CalendarFeedOwnerUser, userID)
+}
+
+// RevokeUserFeed revokes the calendar feed of the requester's bookings
+func (s *CalendarService) RevokeUserFeed(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.NewUnauthorizedError("user not authenticated")
+	}
+	return s.revokeFeeds(ctx, projection.CalendarFeedOwnerUser, userID, userID)
+}
+
+// CreateVendorFeed creates the calendar feed of a shop's bookings, replacing its previous feed.
+// Available to owners and managers of the shop and admins.
+func (s *CalendarService) CreateVendorFeed(ctx context.Context, vendorID, requesterID string, isAdmin bool) (*CalendarFeed, error) {
+	vendor, err := s.managedVendor(ctx, vendorID, requesterID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return s.createFeed(ctx, projection.CalendarFeedOwnerVendor, vendor.ID(), vendor.Name()+" bookings", requesterID)
+}
+
+// GetVendorFeed returns the current calendar feed of a shop's bookings
+func (s *CalendarService) GetVendorFeed(ctx context.Context, vendorID, requesterID string, isAdmin bool) (*CalendarFeed, error) {
+	if _, err := s.managedVendor(ctx, vendorID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+	return s.activeFeed(ctx, projection.CalendarFeedOwnerVendor, vendorID)
+}
+
+// RevokeVendorFeed revokes the calendar feed of a shop's bookings
+func (s *CalendarService) RevokeVendorFeed(ctx context.Context, vendorID, requesterID string, isAdmin bool) error {
+	if _, err := s.managedVendor(ctx, vendorID, requesterID, isAdmin); err != nil {
+		return err
+	}
+	return s.revokeFeeds(ctx, projection.CalendarFeedOwnerVendor, vendorID, requesterID)
+}
+
+// OpenFeed verifies a feed token and renders the feed: the owner's bookings starting within the last
+// CalendarFeedHistory or later, cancelled ones included so calendar applications remove them
+func (s *CalendarService) OpenFeed(ctx context.Context, token string) ([]byte, error) {
+	feedID, signature, ok := strings.Cut(strings.TrimSuffix(token, ".ics"), ".")
+	if !ok || feedID == "" || signature == "" {
+		return nil, errors.NewNotFoundError("calendar feed")
+	}
+
+	feed, err := s.feedProjection.GetByID(ctx, feedID)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(feed))) {
+		return nil, errors.NewNotFoundError("calendar feed")
+	}
+	if feed.IsRevoked() {
+		return nil, errors.NewNotFoundError("calendar feed")
+	}
+
+	now := time.Now()
+	since := now.Add(-CalendarFeedHistory)
+
+	var schedules []projection.ScheduleReadModel
+	audience := document.CalendarAudienceCustomer
+	if feed.OwnerType == projection.CalendarFeedOwnerVendor {
+		audience = document.CalendarAudienceShop
+		schedules, err = s.scheduleProjection.GetCalendarByShopID(ctx, feed.OwnerID, since)
+	} else {
+		schedules, err = s.scheduleProjection.GetCalendarByUserID(ctx, feed.OwnerID, since)
+	}
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to list calendar bookings: %v", err))
+	}
+
+	if err := s.feedProjection.RecordFetch(ctx, feed.ID, now); err != nil {
+		fmt.Printf("⚠️ Failed to record calendar feed fetch %s: %v\n", feed.ID, err)
+	}
+
+	calendar := &document.Calendar{
+		Name:            feed.Name,
+		TimeZone:        s.timeZone,
+		RefreshInterval: CalendarFeedRefreshInterval,
+		Events:          make([]document.CalendarEvent, 0, len(schedules)),
+	}
+	for i := range schedules {
+		calendar.Events = append(calendar.Events, document.NewBookingCalendarEvent(&schedules[i], audience))
+	}
+	return document.RenderCalendar(calendar, now), nil
+}
+
+// ScheduleCalendar renders a single booking as a calendar to download. Available to the customer who
+// booked it, staff of the booked shop and admins.
+func (s *CalendarService) ScheduleCalendar(ctx context.Context, scheduleID, requesterID string, isAdmin bool) ([]byte, error) {
+	if scheduleID == "" {
+		return nil, errors.NewValidationError("schedule ID is required")
+	}
+
+	result, err := s.scheduleProjection.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("schedule")
+	}
+	schedule, ok := result.(projection.ScheduleReadModel)
+	if !ok {
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	audience := document.CalendarAudienceCustomer
+	if !isAdmin && requesterID != schedule.UserID && requesterID != schedule.BookingUser.UserID {
+		if !s.isShopStaff(ctx, requesterID, schedule.BookedShop.ShopID) {
+			return nil, errors.NewForbiddenError("you do not have access to this booking")
+		}
+		audience = document.CalendarAudienceShop
+	}
+
+	calendar := &document.Calendar{
+		Name:     "Whisko booking",
+		TimeZone: s.timeZone,
+		Events:   []document.CalendarEvent{document.NewBookingCalendarEvent(&schedule, audience)},
+	}
+	return document.RenderCalendar(calendar, time.Now()), nil
+}
+
+// createFeed revokes the owner's feeds and creates a new one
+func (s *CalendarService) createFeed(ctx context.Context, ownerType, ownerID, name, createdBy string) (*CalendarFeed, error) {
+	now := time.Now()
+	if err := s.feedProjection.RevokeAllForOwner(ctx, ownerType, ownerID, createdBy, now); err != nil {
+		return nil, errors.NewInternalError("failed to replace calendar feed")
+	}
+
+	feed := &projection.CalendarFeedReadModel{
+		ID:        uuid.New().String(),
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		Name:      name,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if err := s.feedProjection.Create(ctx, feed); err != nil {
+		return nil, errors.NewInternalError("failed to create calendar feed")
+	}
+	return s.withToken(feed), nil
+}
+
+// activeFeed returns the owner's feed that is not revoked
+func (s *CalendarService) activeFeed(ctx context.Context, ownerType, ownerID string) (*CalendarFeed, error) {
+	feed, err := s.feedProjection.GetActiveByOwner(ctx, ownerType, ownerID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("calendar feed")
+	}
+	return s.withToken(feed), nil
+}
+
+// revokeFeeds revokes the owner's feeds
+func (s *CalendarService) revokeFeeds(ctx context.Context, ownerType, ownerID, revokedBy string) error {
+	if err := s.feedProjection.RevokeAllForOwner(ctx, ownerType, ownerID, revokedBy, time.Now()); err != nil {
+		return errors.NewInternalError("failed to revoke calendar feed")
+	}
+	return nil
+}
+
+// managedVendor loads a vendor and checks that the requester is an active owner or manager of it, or an admin
+func (s *CalendarService) managedVendor(ctx context.Context, vendorID, requesterID string, isAdmin bool) (*aggregate.Vendor, error) {
+	if vendorID == "" {
+		return nil, errors.NewValidationError("vendor ID is required")
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	vendor, err := uow.VendorRepository().GetByID(ctx, vendorID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("vendor")
+	}
+	if isAdmin {
+		return vendor, nil
+	}
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, requesterID+"-"+vendorID)
+	if requesterID == "" || err != nil || staff == nil || !staff.IsActive() ||
+		(staff.Role() != aggregate.VendorStaffRoleOwner && staff.Role() != aggregate.VendorStaffRoleManager) {
+		return nil, errors.NewForbiddenError("only an owner or manager of the shop can manage its calendar feed")
+	}
+	return vendor, nil
+}
+
+// isShopStaff reports whether the requester is active staff of a shop
+func (s *CalendarService) isShopStaff(ctx context.Context, requesterID, shopID string) bool {
+	if requesterID == "" {
+		return false
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, requesterID+"-"+shopID)
+	return err == nil && staff != nil && staff.IsActive()
+}
+
+// withToken adds the signed token and subscription URL to a feed
+func (s *CalendarService) withToken(feed *projection.CalendarFeedReadModel) *CalendarFeed {
+	token := feed.ID + "." + s.sign(feed)
+	return &CalendarFeed{
+		CalendarFeedReadModel: feed,
+		Token:                 token,
+		URL:                   s.baseURL + "/calendar-feeds/" + token + ".ics",
+	}
+}
+
+// sign computes the token signature of a feed
+func (s *CalendarService) sign(feed *projection.CalendarFeedReadModel) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s|%s|%s", feed.ID, feed.OwnerType, feed.OwnerID)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
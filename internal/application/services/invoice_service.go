@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/document"
+	"whisko-petcare/internal/infrastructure/projection"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceSequence hands out sequential numbers for a named counter
+type InvoiceSequence interface {
+	Next(ctx context.Context, name string) (int64, error)
+}
+
+// invoiceReplayWindow is how far back the catch-up job re-reads stored payment events
+const invoiceReplayWindow = 7 * 24 * time.Hour
+
+// InvoiceService issues invoices for paid bookings and builds vendor payout statements
+type InvoiceService struct {
+	uowFactory        repository.UnitOfWorkFactory
+	paymentProjection projection.PaymentProjection
+	sequence          InvoiceSequence
+	taxRate           int // VAT percentage included in booking prices
+	stopChan          chan struct{}
+}
+
+// NewInvoiceService creates a new invoice service
+func NewInvoiceService(
+	uowFactory repository.UnitOfWorkFactory,
+	paymentProjection projection.PaymentProjection,
+	sequence InvoiceSequence,
+	taxRate int,
+) *InvoiceService {
+	return &InvoiceService{
+		uowFactory:        uowFactory,
+		paymentProjection: paymentProjection,
+		sequence:          sequence,
+		taxRate:           taxRate,
+		stopChan:          make(chan struct{}),
+	}
+}
+
+// Start begins the background job that replays recently stored payment events, issuing the invoice
+// of any paid payment whose event was lost in delivery or failed to issue
+func (s *InvoiceService) Start(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Minute)
+	defer ticker.Stop()
+
+	fmt.Println("✅ Invoice catch-up service started (checking every 15 minutes)")
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.ReplayEvents(ctx, time.Now().Add(-invoiceReplayWindow)); err != nil {
+				fmt.Printf("❌ Error replaying invoice events: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Invoice catch-up service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Invoice catch-up service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *InvoiceService) Stop() {
+	close(s.stopChan)
+}
+
+// ReplayEvents issues the invoice of every payment that became PAID at or after since and has none yet.
+// Payments already invoiced are skipped, so replaying is safe at any time.
+func (s *InvoiceService) ReplayEvents(ctx context.Context, since time.Time) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	events, err := uow.PaymentRepository().GetEventsOfTypeSince(ctx, since, "PaymentStatusChanged")
+	if err != nil {
+		return fmt.Errorf("failed to read payment events: %w", err)
+	}
+
+	failed := 0
+	for _, e := range events {
+		evt, ok := e.(*event.PaymentStatusChanged)
+		if !ok {
+			continue
+		}
+		if err := s.HandlePaymentStatusChanged(ctx, evt); err != nil {
+			fmt.Printf("⚠️  Failed to issue invoice for payment %s: %v\n", evt.PaymentID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events could not be invoiced", failed, len(events))
+	}
+	return nil
+}
+
+// HandlePaymentStatusChanged issues the invoice of a payment once it is PAID. Invoice numbers
+// restart every year, e.g. INV-2026-000042. The payment's invoice is claimed before a number is
+// taken, so redelivered or concurrent events do not use up numbers and leave gaps in the sequence.
+func (s *InvoiceService) HandlePaymentStatusChanged(ctx context.Context, e *event.PaymentStatusChanged) error {
+	if e.NewStatus != string(aggregate.PaymentStatusPaid) {
+		return nil
+	}
+
+	readModel, err := s.paymentProjection.GetByID(ctx, e.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment read model: %w", err)
+	}
+	if readModel.Invoice != nil {
+		return nil // Already issued
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	payment, err := uow.PaymentRepository().GetByID(ctx, e.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment for invoice: %w", err)
+	}
+
+	seller := projection.InvoicePartyReadModel{ID: payment.VendorID()}
+	if payment.VendorID() != "" {
+		vendor, err := uow.VendorRepository().GetByID(ctx, payment.VendorID())
+		if err != nil {
+			return fmt.Errorf("failed to get vendor for invoice: %w", err)
+		}
+		seller.Name = vendor.Name()
+		seller.Email = vendor.Email()
+		seller.Phone = vendor.Phone()
+		seller.Address = vendor.Address()
+	}
+
+	buyer := projection.InvoicePartyReadModel{ID: payment.UserID()}
+	if user, err := uow.UserRepository().GetByID(ctx, payment.UserID()); err == nil {
+		buyer.Name = user.Name()
+		buyer.Email = user.Email()
+		buyer.Phone = user.Phone()
+		buyer.Address = user.Address()
+	} else {
+		fmt.Printf("⚠️  Customer %s not found for invoice of payment %s: %v\n", payment.UserID(), payment.ID(), err)
+	}
+
+	lines := make([]projection.InvoiceLineReadModel, len(payment.Items()))
+	subtotal := 0
+	for i, item := range payment.Items() {
+		lines[i] = projection.InvoiceLineReadModel{
+			Description: item.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			Amount:      item.Price * item.Quantity,
+		}
+		subtotal += lines[i].Amount
+	}
+
+	// Booking prices include VAT, so the tax is the VAT portion of the total
+	total := payment.Amount()
+	taxAmount := int(math.Round(float64(total) * float64(s.taxRate) / float64(100+s.taxRate)))
+
+	issuedAt := e.Timestamp
+	if issuedAt.IsZero() {
+		issuedAt = time.Now()
+	}
+
+	claimID := uuid.New().String()
+	claimed, err := s.paymentProjection.ClaimInvoice(ctx, payment.ID(), claimID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil // Already issued or being issued
+	}
+
+	sequence, err := s.sequence.Next(ctx, fmt.Sprintf("invoice-%d", issuedAt.Year()))
+	if err != nil {
+		if releaseErr := s.paymentProjection.ReleaseInvoiceClaim(ctx, payment.ID(), claimID); releaseErr != nil {
+			fmt.Printf("⚠️  Failed to release invoice claim of payment %s: %v\n", payment.ID(), releaseErr)
+		}
+		return err
+	}
+
+	invoice := &projection.InvoiceReadModel{
+		Number:         fmt.Sprintf("INV-%d-%06d", issuedAt.Year(), sequence),
+		IssuedAt:       issuedAt,
+		PaymentID:      payment.ID(),
+		OrderCode:      payment.OrderCode(),
+		PaymentMethod:  string(payment.Method()),
+		Seller:         seller,
+		Buyer:          buyer,
+		Lines:          lines,
+		Subtotal:       subtotal,
+		PromotionCode:  payment.PromotionCode(),
+		DiscountAmount: payment.DiscountAmount(),
+		TaxRate:        s.taxRate,
+		TaxAmount:      taxAmount,
+		NetAmount:      total - taxAmount,
+		TotalAmount:    total,
+		Currency:       "VND",
+	}
+
+	if err := s.paymentProjection.AttachInvoice(ctx, payment.ID(), claimID, invoice); err != nil {
+		return err
+	}
+
+	fmt.Printf("🧾 Issued invoice %s for payment %s\n", invoice.Number, payment.ID())
+	return nil
+}
+
+// BuildPayoutStatement assembles the statement document of a payout from its settlement
+func (s *InvoiceService) BuildPayoutStatement(ctx context.Context, payoutID string) (*document.PayoutStatement, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	payout, err := uow.PayoutRepository().GetByID(ctx, payoutID)
+	if err != nil {
+		return nil, fmt.Errorf("payout not found: %w", err)
+	}
+
+	vendor, err := uow.VendorRepository().GetByID(ctx, payout.VendorID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vendor: %w", err)
+	}
+
+	bankAccount := payout.BankAccount()
+	statement := &document.PayoutStatement{
+		PayoutID:    payout.ID(),
+		Status:      string(payout.Status()),
+		GeneratedAt: time.Now(),
+		Vendor: document.StatementVendor{
+			ID:      vendor.ID(),
+			Name:    vendor.Name(),
+			Email:   vendor.Email(),
+			Phone:   vendor.Phone(),
+			Address: vendor.Address(),
+		},
+		BankName:      bankAccount.BankName,
+		AccountNumber: maskAccountNumber(bankAccount.AccountNumber),
+		AccountName:   bankAccount.AccountName,
+		PayoutAmount:  payout.Amount(),
+		RequestedAt:   payout.RequestedAt(),
+		CompletedAt:   payout.CompletedAt(),
+		Currency:      "VND",
+	}
+
+	if payout.SettlementID() == "" {
+		// Payouts made before settlements cover a single booking
+		statement.Lines = []document.StatementLine{{
+			Date:        payout.RequestedAt(),
+			Description: "Booking",
+			Reference:   payout.PaymentID(),
+			Amount:      payout.Amount(),
+		}}
+		statement.EarningsAmount = payout.Amount()
+		return statement, nil
+	}
+
+	settlement, err := uow.SettlementRepository().GetByID(ctx, payout.SettlementID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement: %w", err)
+	}
+
+	statement.SettlementID = settlement.ID()
+	statement.PeriodStart = settlement.PeriodStart()
+	statement.PeriodEnd = settlement.PeriodEnd()
+	statement.EarningsAmount = settlement.EarningsAmount()
+	statement.CarriedInAmount = settlement.CarriedInAmount()
+	statement.Commission = settlement.Commission()
+
+	for _, item := range settlement.LineItems() {
+		line := document.StatementLine{
+			Date:        item.EarnedAt,
+			Description: "Booking",
+			Reference:   item.PaymentID,
+			Amount:      item.Amount,
+		}
+		if item.RefundID != "" {
+			line.Description = "Refund"
+			line.Reference = item.PaymentID + " / " + item.RefundID
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	return statement, nil
+}
+
+// maskAccountNumber hides all but the last four digits of a bank account number
+func maskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+	masked := make([]byte, len(accountNumber)-4)
+	for i := range masked {
+		masked[i] = '*'
+	}
+	return string(masked) + accountNumber[len(accountNumber)-4:]
+}
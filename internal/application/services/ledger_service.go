@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// LedgerCheckResult reports whether the ledger balances
+type LedgerCheckResult struct {
+	TotalDebit        int       `json:"total_debit"`
+	TotalCredit       int       `json:"total_credit"`
+	Balanced          bool      `json:"balanced"`
+	UnbalancedEntries []string  `json:"unbalanced_entries"`
+	CheckedAt         time.Time `json:"checked_at"`
+}
+
+// ledgerReplayWindow is how far back the catch-up job re-reads stored events
+const ledgerReplayWindow = 7 * 24 * time.Hour
+
+// LedgerService posts double-entry ledger entries from payment, settlement and payout events
+// and answers balance queries. Every entry is keyed by its source event, so replaying an
+// event never posts twice.
+type LedgerService struct {
+	uowFactory repository.UnitOfWorkFactory
+	stopChan   chan struct{}
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(uowFactory repository.UnitOfWorkFactory) *LedgerService {
+	return &LedgerService{
+		uowFactory: uowFactory,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start begins the background job that replays recently stored money movement events, posting
+// any entry whose event was lost in delivery or failed to post
+func (s *LedgerService) Start(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Minute)
+	defer ticker.Stop()
+
+	fmt.Println("✅ Ledger catch-up service started (checking every 15 minutes)")
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.ReplayEvents(ctx, time.Now().Add(-ledgerReplayWindow)); err != nil {
+				fmt.Printf("❌ Error replaying ledger events: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Ledger catch-up service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Ledger catch-up service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *LedgerService) Stop() {
+	close(s.stopChan)
+}
+
+// ReplayEvents posts the ledger entries of every stored money movement event that occurred at or
+// after since. Entries already posted are skipped, so replaying is safe at any time.
+func (s *LedgerService) ReplayEvents(ctx context.Context, since time.Time) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	paymentEvents, err := uow.PaymentRepository().GetEventsOfTypeSince(ctx, since, "PaymentStatusChanged", "PaymentRefunded")
+	if err != nil {
+		return fmt.Errorf("failed to read payment events: %w", err)
+	}
+	settlementEvents, err := uow.SettlementRepository().GetEventsOfTypeSince(ctx, since, "SettlementPaidOut")
+	if err != nil {
+		return fmt.Errorf("failed to read settlement events: %w", err)
+	}
+	payoutEvents, err := uow.PayoutRepository().GetEventsOfTypeSince(ctx, since, "PayoutCompleted")
+	if err != nil {
+		return fmt.Errorf("failed to read payout events: %w", err)
+	}
+
+	events := append(append(paymentEvents, settlementEvents...), payoutEvents...)
+	failed := 0
+	for _, e := range events {
+		var err error
+		switch evt := e.(type) {
+		case *event.PaymentStatusChanged:
+			err = s.HandlePaymentStatusChanged(ctx, evt)
+		case *event.PaymentRefunded:
+			err = s.HandlePaymentRefunded(ctx, evt)
+		case *event.SettlementPaidOut:
+			err = s.HandleSettlementPaidOut(ctx, evt)
+		case *event.PayoutCompleted:
+			err = s.HandlePayoutCompleted(ctx, evt)
+		}
+		if err != nil {
+			fmt.Printf("⚠️  Failed to post ledger entry for %s %s: %v\n", e.EventType(), e.AggregateID(), err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events could not be posted", failed, len(events))
+	}
+	return nil
+}
+
+// HandlePaymentStatusChanged posts the capture of a payment once it is PAID. Payments collected
+// by the vendor at the shop never pass through the platform and are not posted.
+func (s *LedgerService) HandlePaymentStatusChanged(ctx context.Context, e *event.PaymentStatusChanged) error {
+	if e.NewStatus != string(aggregate.PaymentStatusPaid) {
+		return nil
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	payment, err := uow.PaymentRepository().GetByID(ctx, e.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment for ledger: %w", err)
+	}
+	if payment.Method().IsCollectedByVendor() {
+		return nil
+	}
+	if payment.VendorID() == "" {
+		fmt.Printf("⚠️  Payment %s has no vendor - skipping ledger posting\n", payment.ID())
+		return nil
+	}
+
+	entry, err := aggregate.NewPaymentCapturedEntry(uuid.New().String(), payment.ID(), payment.VendorID(), payment.Amount(), payment.PlatformFundedDiscount(), e.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return s.append(ctx, uow, entry)
+}
+
+// HandlePaymentRefunded posts a refund of an online payment
+func (s *LedgerService) HandlePaymentRefunded(ctx context.Context, e *event.PaymentRefunded) error {
+	if aggregate.PaymentMethod(e.Method).IsCollectedByVendor() || e.VendorID == "" {
+		return nil
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	entry, err := aggregate.NewRefundEntry(uuid.New().String(), e.RefundID, e.PaymentID, e.VendorID, e.Amount, e.DiscountReversed, e.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return s.append(ctx, uow, entry)
+}
+
+// HandleSettlementPaidOut posts the platform commission withheld from a settlement
+func (s *LedgerService) HandleSettlementPaidOut(ctx context.Context, e *event.SettlementPaidOut) error {
+	if e.Commission <= 0 {
+		return nil
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	entry, err := aggregate.NewCommissionEntry(uuid.New().String(), e.SettlementID, e.VendorID, e.Commission, e.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return s.append(ctx, uow, entry)
+}
+
+// HandlePayoutCompleted posts the bank transfer that settles a vendor's payable
+func (s *LedgerService) HandlePayoutCompleted(ctx context.Context, e *event.PayoutCompleted) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	entry, err := aggregate.NewPayoutCompletedEntry(uuid.New().String(), e.PayoutID, e.VendorID, e.Amount, e.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return s.append(ctx, uow, entry)
+}
+
+// append stores an entry, ignoring duplicates of an already posted source event
+func (s *LedgerService) append(ctx context.Context, uow repository.UnitOfWork, entry *aggregate.LedgerEntry) error {
+	appended, err := uow.LedgerRepository().Append(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if appended {
+		fmt.Printf("📒 Ledger entry posted: %s (%s, %d VND)\n", entry.Reference(), entry.EntryType(), entry.TotalDebit())
+	} else {
+		fmt.Printf("📒 Ledger entry already posted: %s\n", entry.Reference())
+	}
+	return nil
+}
+
+// GetAccountBalances returns the balance of every account and vendor payable sub-account
+func (s *LedgerService) GetAccountBalances(ctx context.Context) ([]aggregate.LedgerBalance, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	return uow.LedgerRepository().GetAccountBalances(ctx)
+}
+
+// GetVendorBalance returns the amount currently owed to a vendor
+func (s *LedgerService) GetVendorBalance(ctx context.Context, vendorID string) (aggregate.LedgerBalance, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	return uow.LedgerRepository().GetVendorBalance(ctx, vendorID)
+}
+
+// ListEntries returns ledger entries that occurred within [from, to)
+func (s *LedgerService) ListEntries(ctx context.Context, from, to time.Time, offset, limit int) ([]*aggregate.LedgerEntry, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	return uow.LedgerRepository().List(ctx, from, to, offset, limit)
+}
+
+// ExportEntries returns every ledger entry that occurred within [from, to) for accounting export
+func (s *LedgerService) ExportEntries(ctx context.Context, from, to time.Time) ([]*aggregate.LedgerEntry, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	return uow.LedgerRepository().ListAll(ctx, from, to)
+}
+
+// CheckBalance verifies the ledger invariant: total debits equal total credits and every entry balances
+func (s *LedgerService) CheckBalance(ctx context.Context) (*LedgerCheckResult, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	ledgerRepo := uow.LedgerRepository()
+
+	debit, credit, err := ledgerRepo.GetTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unbalanced, err := ledgerRepo.GetUnbalancedEntryIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LedgerCheckResult{
+		TotalDebit:        debit,
+		TotalCredit:       credit,
+		Balanced:          debit == credit && len(unbalanced) == 0,
+		UnbalancedEntries: unbalanced,
+		CheckedAt:         time.Now(),
+	}
+
+	if !result.Balanced {
+		fmt.Printf("❌ Ledger is out of balance: debits %d, credits %d, %d unbalanced entries\n", debit, credit, len(unbalanced))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// NotificationFeed is a page of a notification feed with the number of notifications not read yet
+type NotificationFeed struct {
+	Notifications []*projection.NotificationReadModel `json:"notifications"`
+	Unread        int64                               `json:"unread"`
+	Offset        int                                 `json:"offset"`
+	Limit         int                                 `json:"limit"`
+}
+
+// NotificationService turns reminder events into entries of the notification feeds customers and shop
+// staff read in the app. Every notification is keyed by the reminder it was raised for, so a redelivered
+// event does not notify twice.
+type NotificationService struct {
+	uowFactory    repository.UnitOfWorkFactory
+	notifications projection.NotificationProjection
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(uowFactory repository.UnitOfWorkFactory, notifications projection.NotificationProjection) *NotificationService {
+	return &NotificationService{
+		uowFactory:    uowFactory,
+		notifications: notifications,
+	}
+}
+
+// HandleVaccinationDueSoon tells the owner that a vaccination of their pet is coming due
+func (s *NotificationService) HandleVaccinationDueSoon(ctx context.Context, e *event.VaccinationDueSoon) error {
+	return s.notify(ctx, &projection.NotificationReadModel{
+		RecipientType: projection.NotificationRecipientUser,
+		RecipientID:   e.UserID,
+		Type:          e.EventType(),
+		Title:         fmt.Sprintf("%s vaccination due soon", e.VaccineName),
+		Message: fmt.Sprintf("%s's %s vaccination is due in %d day(s), on %s.",
+			e.PetName, e.VaccineName, e.DaysUntilDue, e.DueDate.Format("2006-01-02")),
+		PetID:     e.PetID,
+		SourceKey: fmt.Sprintf("%s:%s:%s:%d", e.EventType(), e.RecordID, e.DueDate.Format("2006-01-02"), e.ReminderOffset),
+		CreatedAt: e.Timestamp,
+	})
+}
+
+// HandleVaccinationOverdue tells the owner that a vaccination of their pet is overdue
+func (s *NotificationService) HandleVaccinationOverdue(ctx context.Context, e *event.VaccinationOverdue) error {
+	return s.notify(ctx, &projection.NotificationReadModel{
+		RecipientType: projection.NotificationRecipientUser,
+		RecipientID:   e.UserID,
+		Type:          e.EventType(),
+		Title:         fmt.Sprintf("%s vaccination overdue", e.VaccineName),
+		Message: fmt.Sprintf("%s's %s vaccination was due on %s.",
+			e.PetName, e.VaccineName, e.DueDate.Format("2006-01-02")),
+		PetID:     e.PetID,
+		SourceKey: fmt.Sprintf("%s:%s:%s", e.EventType(), e.RecordID, e.DueDate.Format("2006-01-02")),
+		CreatedAt: e.Timestamp,
+	})
+}
+
+// HandleMedicationDoseDue tells the owner that a dose of their pet's medication is coming due
+func (s *NotificationService) HandleMedicationDoseDue(ctx context.Context, e *event.MedicationDoseDue) error {
+	return s.notify(ctx, &projection.NotificationReadModel{
+		RecipientType: projection.NotificationRecipientUser,
+		RecipientID:   e.UserID,
+		Type:          e.EventType(),
+		Title:         fmt.Sprintf("%s dose due", e.DrugName),
+		Message: fmt.Sprintf("%s is due %s of %s at %s.",
+			e.PetName, e.Dose, e.DrugName, e.DueAt.UTC().Format("2006-01-02 15:04 UTC")),
+		PetID:     e.PetID,
+		SourceKey: fmt.Sprintf("%s:%s:%d", e.EventType(), e.PlanID, e.DueAt.Unix()),
+		CreatedAt: e.Timestamp,
+	})
+}
+
+// HandleWaitlistSlotOffered tells the customer that a slot they are waiting for is offered to them
+func (s *NotificationService) HandleWaitlistSlotOffered(ctx context.Context, e *event.WaitlistSlotOffered) error {
+	const layout = "2006-01-02 15:04 UTC"
+	return s.notify(ctx, &projection.NotificationReadModel{
+		RecipientType: projection.NotificationRecipientUser,
+		RecipientID:   e.UserID,
+		Type:          e.EventType(),
+		Title:         "A slot you are waiting for is free",
+		Message: fmt.Sprintf("The slot from %s to %s is held for you. Claim it before %s.",
+			e.Offer.StartTime.UTC().Format(layout), e.Offer.EndTime.UTC().Format(layout), e.Offer.ExpiresAt.UTC().Format(layout)),
+		WaitlistEntryID: e.EntryID,
+		SourceKey:       fmt.Sprintf("%s:%s:%d", e.EventType(), e.EntryID, e.Offer.OfferedAt.Unix()),
+		CreatedAt:       e.Timestamp,
+	})
+}
+
+// HandleScheduleReminderDue reminds the customer of an upcoming booking
+func (s *NotificationService) HandleScheduleReminderDue(ctx context.Context, e *event.ScheduleReminderDue) error {
+	return s.notify(ctx, &projection.NotificationReadModel{
+		RecipientType: projection.NotificationRecipientUser,
+		RecipientID:   e.UserID,
+		Type:          e.EventType(),
+		Title:         fmt.Sprintf("Upcoming booking at %s", e.ShopName),
+		Message: fmt.Sprintf("%s's booking at %s starts at %s.",
+			e.PetName, e.ShopName, e.StartTime.UTC().Format("2006-01-02 15:04 UTC")),
+		ScheduleID: e.ScheduleID,
+		SourceKey:  fmt.Sprintf("%s:%s:%d:%s", e.EventType(), e.ScheduleID, e.StartTime.Unix(), e.LeadTime),
+		CreatedAt:  e.Timestamp,
+	})
+}
+
+// HandleScheduleConfirmationOverdue asks the shop to confirm a booking it has left pending
+func (s *NotificationService) HandleScheduleConfirmationOverdue(ctx context.Context, e *event.ScheduleConfirmationOverdue) error {
+	message := fmt.Sprintf("A booking starting at %s has been waiting for confirmation since %s.",
+		e.StartTime.UTC().Format("2006-01-02 15:04 UTC"), e.PendingSince.UTC().Format("2006-01-02 15:04 UTC"))
+	if !e.CancelAt.IsZero() {
+		message += fmt.Sprintf(" It is cancelled and refunded at %s unless it is confirmed.", e.CancelAt.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+
+	return s.notify(ctx, &projection.NotificationReadModel{
+		RecipientType: projection.NotificationRecipientVendor,
+		RecipientID:   e.ShopID,
+		Type:          e.EventType(),
+		Title:         "Booking waiting for confirmation",
+		Message:       message,
+		ScheduleID:    e.ScheduleID,
+		SourceKey:     fmt.Sprintf("%s:%s", e.EventType(), e.ScheduleID),
+		CreatedAt:     e.Timestamp,
+	})
+}
+
+// ListUserNotifications returns a page of the requester's notification feed, newest first
+func (s *NotificationService) ListUserNotifications(ctx context.Context, userID string, unreadOnly bool, offset, limit int) (*NotificationFeed, error) {
+	if userID == "" {
+		return nil, errors.NewUnauthorizedError("user not authenticated")
+	}
+	return s.feed(ctx, projection.NotificationRecipientUser, userID, unreadOnly, offset, limit)
+}
+
+// MarkUserNotificationRead marks a notification of the requester as read
+func (s *NotificationService) MarkUserNotificationRead(ctx context.Context, userID, notificationID string) error {
+	if userID == "" {
+		return errors.NewUnauthorizedError("user not authenticated")
+	}
+	return s.markRead(ctx, projection.NotificationRecipientUser, userID, notificationID)
+}
+
+// ListVendorNotifications returns a page of a shop's notification feed, newest first. Available to active
+// staff of the shop and admins.
+func (s *NotificationService) ListVendorNotifications(ctx context.Context, vendorID, requesterID string, isAdmin bool, unreadOnly bool, offset, limit int) (*NotificationFeed, error) {
+	if err := s.checkShopStaff(ctx, vendorID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+	return s.feed(ctx, projection.NotificationRecipientVendor, vendorID, unreadOnly, offset, limit)
+}
+
+// MarkVendorNotificationRead marks a notification of a shop as read
+func (s *NotificationService) MarkVendorNotificationRead(ctx context.Context, vendorID, requesterID string, isAdmin bool, notificationID string) error {
+	if err := s.checkShopStaff(ctx, vendorID, requesterID, isAdmin); err != nil {
+		return err
+	}
+	return s.markRead(ctx, projection.NotificationRecipientVendor, vendorID, notificationID)
+}
+
+// checkShopStaff allows admins and active staff of the shop
+func (s *NotificationService) checkShopStaff(ctx context.Context, vendorID, requesterID string, isAdmin bool) error {
+	if vendorID == "" {
+		return errors.NewValidationError("vendor ID is required")
+	}
+	if isAdmin {
+		return nil
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, requesterID+"-"+vendorID)
+	if requesterID == "" || err != nil || staff == nil || !staff.IsActive() {
+		return errors.NewForbiddenError("only staff of the shop can see its notifications")
+	}
+	return nil
+}
+
+// notify adds a notification to its recipient's feed
+func (s *NotificationService) notify(ctx context.Context, notification *projection.NotificationReadModel) error {
+	if notification.RecipientID == "" {
+		fmt.Printf("⚠️  %s has no recipient - skipping notification\n", notification.Type)
+		return nil
+	}
+
+	notification.ID = uuid.New().String()
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	added, err := s.notifications.Add(ctx, notification)
+	if err != nil {
+		return err
+	}
+	if added {
+		fmt.Printf("🔔 Notified %s %s: %s\n", notification.RecipientType, notification.RecipientID, notification.Title)
+	}
+	return nil
+}
+
+// feed returns a page of a recipient's notification feed
+func (s *NotificationService) feed(ctx context.Context, recipientType, recipientID string, unreadOnly bool, offset, limit int) (*NotificationFeed, error) {
+	notifications, err := s.notifications.ListByRecipient(ctx, recipientType, recipientID, unreadOnly, offset, limit)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list notifications")
+	}
+	unread, err := s.notifications.CountUnread(ctx, recipientType, recipientID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to count unread notifications")
+	}
+
+	return &NotificationFeed{
+		Notifications: notifications,
+		Unread:        unread,
+		Offset:        offset,
+		Limit:         limit,
+	}, nil
+}
+
+// markRead marks a notification of a recipient as read
+func (s *NotificationService) markRead(ctx context.Context, recipientType, recipientID, notificationID string) error {
+	if notificationID == "" {
+		return errors.NewValidationError("notification ID is required")
+	}
+
+	found, err := s.notifications.MarkRead(ctx, notificationID, recipientType, recipientID, time.Now())
+	if err != nil {
+		return errors.NewInternalError("failed to mark notification as read")
+	}
+	if !found {
+		return errors.NewNotFoundError("notification")
+	}
+	return nil
+}
@@ -5,30 +5,27 @@ import (
 	"fmt"
 	"time"
 
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/event"
 	"whisko-petcare/internal/domain/repository"
 	"whisko-petcare/internal/infrastructure/bus"
-	"whisko-petcare/internal/infrastructure/payos"
+	"whisko-petcare/internal/infrastructure/gateway"
 )
 
-// PayOSService interface for cancelling payment links
-type PayOSService interface {
-	CancelPaymentLink(ctx context.Context, orderCode int64, cancelReason string) error
-}
-
 // PaymentExpiryService handles automatic expiration of pending payments
 type PaymentExpiryService struct {
 	uowFactory   repository.UnitOfWorkFactory
 	eventBus     bus.EventBus
-	payOSService PayOSService
+	gateways     *gateway.Registry
 	stopChan     chan struct{}
 }
 
 // NewPaymentExpiryService creates a new payment expiry service
-func NewPaymentExpiryService(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus, payOSService *payos.Service) *PaymentExpiryService {
+func NewPaymentExpiryService(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus, gateways *gateway.Registry) *PaymentExpiryService {
 	return &PaymentExpiryService{
 		uowFactory:   uowFactory,
 		eventBus:     eventBus,
-		payOSService: payOSService,
+		gateways:     gateways,
 		stopChan:     make(chan struct{}),
 	}
 }
@@ -80,15 +77,19 @@ func (s *PaymentExpiryService) expireOldPayments(ctx context.Context) error {
 	}
 
 	expiredCount := 0
+	var events []event.DomainEvent
 	for _, payment := range payments {
 		if payment.IsExpired() {
-			// First, cancel the payment link on PayOS platform
+			// First, cancel the payment with its gateway
 			cancelReason := "Payment expired automatically"
-			if err := s.payOSService.CancelPaymentLink(ctx, payment.OrderCode(), cancelReason); err != nil {
-				fmt.Printf("⚠️  Failed to cancel payment link on PayOS for payment %s (orderCode: %d): %v\n", payment.ID(), payment.OrderCode(), err)
-				// Continue anyway to mark as expired locally even if PayOS cancellation fails
+			paymentGateway, err := s.gateways.Get(payment.Method())
+			if err != nil {
+				fmt.Printf("⚠️  No gateway for payment %s: %v\n", payment.ID(), err)
+			} else if err := paymentGateway.CancelPayment(ctx, payment.OrderCode(), cancelReason); err != nil {
+				fmt.Printf("⚠️  Failed to cancel %s payment %s (orderCode: %d): %v\n", payment.Method(), payment.ID(), payment.OrderCode(), err)
+				// Continue anyway to mark as expired locally even if gateway cancellation fails
 			} else {
-				fmt.Printf("✅ Cancelled payment link on PayOS for payment %s (orderCode: %d)\n", payment.ID(), payment.OrderCode())
+				fmt.Printf("✅ Cancelled %s payment %s (orderCode: %d)\n", payment.Method(), payment.ID(), payment.OrderCode())
 			}
 
 			// Then mark as expired locally
@@ -97,16 +98,21 @@ func (s *PaymentExpiryService) expireOldPayments(ctx context.Context) error {
 				continue
 			}
 
+			// Get events BEFORE saving (Save will clear them)
+			paymentEvents := payment.GetUncommittedEvents()
+
 			if err := paymentRepo.Save(ctx, payment); err != nil {
 				fmt.Printf("⚠️  Failed to save expired payment %s: %v\n", payment.ID(), err)
 				continue
 			}
 
-			// Publish events
-			events := payment.GetUncommittedEvents()
-			if err := s.eventBus.PublishBatch(ctx, events); err != nil {
-				fmt.Printf("⚠️  Failed to publish events for payment %s: %v\n", payment.ID(), err)
+			// The time held during checkout is given back and recurring bookings waiting for this payment are cancelled
+			releaseEvents, err := command.ReleaseUnpaidPayment(ctx, uow, payment, cancelReason)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to release booking of expired payment %s: %v\n", payment.ID(), err)
 			}
+			events = append(events, paymentEvents...)
+			events = append(events, releaseEvents...)
 
 			expiredCount++
 		}
@@ -116,6 +122,11 @@ func (s *PaymentExpiryService) expireOldPayments(ctx context.Context) error {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	// Publish only once committed; the ledger and invoices act on these events
+	if err := s.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("⚠️  Failed to publish events for expired payments: %v\n", err)
+	}
+
 	if expiredCount > 0 {
 		fmt.Printf("✅ Expired %d pending payment(s)\n", expiredCount)
 	}
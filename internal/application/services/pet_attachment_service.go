@@ -0,0 +1,224 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/infrastructure/cloudinary"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+)
+
+// MaxAttachmentSize is the largest document that can be attached to a pet (10 MB)
+const MaxAttachmentSize = 10 << 20
+
+// allowedAttachmentTypes maps the accepted content types to their file extensions
+var allowedAttachmentTypes = map[string][]string{
+	"application/pdf": {".pdf"},
+	"image/jpeg":      {".jpg", ".jpeg"},
+	"image/png":       {".png"},
+	"image/webp":      {".webp"},
+}
+
+// StoredFile describes a file kept by an attachment storage backend
+type StoredFile struct {
+	Key string // Identifies the file for later removal
+	URL string
+}
+
+// AttachmentStorage keeps the files of pet attachments
+type AttachmentStorage interface {
+	Store(ctx context.Context, file io.Reader, fileName, petID string) (*StoredFile, error)
+	Remove(ctx context.Context, key string) error
+}
+
+// cloudinaryAttachmentStorage stores attachments through the Cloudinary media service
+type cloudinaryAttachmentStorage struct {
+	cloudinary *cloudinary.Service
+}
+
+// NewCloudinaryAttachmentStorage creates an attachment storage backed by Cloudinary
+func NewCloudinaryAttachmentStorage(service *cloudinary.Service) AttachmentStorage {
+	return &cloudinaryAttachmentStorage{cloudinary: service}
+}
+
+func (s *cloudinaryAttachmentStorage) Store(ctx context.Context, file io.Reader, fileName, petID string) (*StoredFile, error) {
+	result, err := s.cloudinary.UploadPetDocument(ctx, file, fileName, petID)
+	if err != nil {
+		return nil, err
+	}
+	return &StoredFile{Key: result.PublicID, URL: result.SecureURL}, nil
+}
+
+func (s *cloudinaryAttachmentStorage) Remove(ctx context.Context, key string) error {
+	return s.cloudinary.DeleteFile(ctx, key)
+}
+
+// UploadAttachmentRequest describes a document uploaded for a pet
+type UploadAttachmentRequest struct {
+	PetID      string
+	Category   string
+	Title      string
+	RecordType string // vaccination or medical_record when the document belongs to a health record
+	RecordID   string
+	FileName   string
+	Size       int64
+	UploadedBy string
+}
+
+// PetAttachmentService keeps documents such as lab results, certificates and x-rays for pets. Files are
+// checked and stored in the storage backend before the attachment is recorded on the pet, and deleted
+// from it once the attachment is removed.
+type PetAttachmentService struct {
+	petProjection projection.PetProjection
+	storage       AttachmentStorage
+
+	addAttachmentHandler    *command.AddPetAttachmentWithUoWHandler
+	removeAttachmentHandler *command.RemovePetAttachmentWithUoWHandler
+}
+
+// NewPetAttachmentService creates a new pet attachment service; storage may be nil when no backend is configured
+func NewPetAttachmentService(
+	petProjection projection.PetProjection,
+	storage AttachmentStorage,
+	addAttachmentHandler *command.AddPetAttachmentWithUoWHandler,
+	removeAttachmentHandler *command.RemovePetAttachmentWithUoWHandler,
+) *PetAttachmentService {
+	return &PetAttachmentService{
+		petProjection:           petProjection,
+		storage:                 storage,
+		addAttachmentHandler:    addAttachmentHandler,
+		removeAttachmentHandler: removeAttachmentHandler,
+	}
+}
+
+// Upload validates and stores a document, then attaches it to the pet
+func (s *PetAttachmentService) Upload(ctx context.Context, req UploadAttachmentRequest, file io.Reader) (*projection.PetAttachmentView, error) {
+	if s.storage == nil {
+		return nil, errors.NewInternalError("attachment storage is not configured")
+	}
+	if req.Size > MaxAttachmentSize {
+		return nil, errors.NewValidationError(fmt.Sprintf("attachments cannot be larger than %d MB", MaxAttachmentSize>>20))
+	}
+
+	pet, err := s.petProjection.GetByID(ctx, req.PetID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	if pet.GuardianRole(req.UploadedBy) != event.GuardianRoleOwner {
+		return nil, errors.NewForbiddenError("only owners can add attachments")
+	}
+
+	// Check the actual content, not only the name the client sent
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errors.NewValidationError("failed to read the uploaded file")
+	}
+	head = head[:n]
+	contentType, err := attachmentContentType(req.FileName, head)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storage.Store(ctx, io.MultiReader(bytes.NewReader(head), file), req.FileName, req.PetID)
+	if err != nil {
+		return nil, errors.NewInternalError(fmt.Sprintf("failed to store attachment: %v", err))
+	}
+
+	cmd := command.AddPetAttachment{
+		PetID:       req.PetID,
+		Category:    req.Category,
+		Title:       req.Title,
+		FileName:    req.FileName,
+		ContentType: contentType,
+		Size:        req.Size,
+		URL:         stored.URL,
+		StorageKey:  stored.Key,
+		RecordType:  req.RecordType,
+		RecordID:    req.RecordID,
+		UploadedBy:  req.UploadedBy,
+	}
+	if err := s.addAttachmentHandler.Handle(ctx, &cmd); err != nil {
+		// The file is not referenced by any pet, so do not keep it
+		if removeErr := s.storage.Remove(ctx, stored.Key); removeErr != nil {
+			fmt.Printf("Warning: failed to remove orphaned attachment %s: %v\n", stored.Key, removeErr)
+		}
+		return nil, err
+	}
+
+	pet, err = s.petProjection.GetByID(ctx, req.PetID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to load the pet attachments")
+	}
+	for i := range pet.Attachments {
+		if pet.Attachments[i].StorageKey == stored.Key {
+			return &pet.Attachments[i], nil
+		}
+	}
+	return nil, errors.NewInternalError("attachment was saved but is not listed yet")
+}
+
+// Remove removes an attachment from a pet; the stored file is deleted when the removal is published
+func (s *PetAttachmentService) Remove(ctx context.Context, cmd command.RemovePetAttachment) error {
+	return s.removeAttachmentHandler.Handle(ctx, &cmd)
+}
+
+// List lists the attachments of a pet, optionally only those of one health record. Available to guardians
+// of the pet and admins.
+func (s *PetAttachmentService) List(ctx context.Context, petID, recordID, requesterID string, isAdmin bool) ([]projection.PetAttachmentView, error) {
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) == "" {
+		return nil, errors.NewForbiddenError("only guardians of this pet can see its attachments")
+	}
+
+	attachments := []projection.PetAttachmentView{}
+	for _, attachment := range pet.Attachments {
+		if recordID == "" || attachment.RecordID == recordID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+// HandlePetAttachmentRemoved deletes the stored file of a removed attachment
+func (s *PetAttachmentService) HandlePetAttachmentRemoved(ctx context.Context, e *event.PetAttachmentRemoved) error {
+	if s.storage == nil || e.StorageKey == "" {
+		return nil
+	}
+	if err := s.storage.Remove(ctx, e.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete attachment %s of pet %s: %w", e.AttachmentID, e.PetID, err)
+	}
+	return nil
+}
+
+// attachmentContentType detects the content type of an uploaded file and checks that it is an accepted
+// type matching the file extension
+func attachmentContentType(fileName string, head []byte) (string, error) {
+	contentType := http.DetectContentType(head)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	extensions, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		return "", errors.NewValidationError("attachments must be PDF, JPEG, PNG or WebP files")
+	}
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, allowed := range extensions {
+		if ext == allowed {
+			return contentType, nil
+		}
+	}
+	return "", errors.NewValidationError(fmt.Sprintf("file extension %q does not match its %s content", ext, contentType))
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// CareBriefAccess describes who opens a care brief
+type CareBriefAccess struct {
+	UserID    string
+	IsAdmin   bool
+	IPAddress string
+	UserAgent string
+}
+
+// CareBriefAllergy is an allergy of the pet as shown to the vendor
+type CareBriefAllergy struct {
+	Allergen string `json:"allergen"`
+	Severity string `json:"severity"`
+	Symptoms string `json:"symptoms,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+// CareBriefMedication is a medication plan that runs during the booking
+type CareBriefMedication struct {
+	DrugName      string    `json:"drug_name"`
+	Dose          string    `json:"dose"`
+	IntervalHours int       `json:"interval_hours"`
+	Instructions  string    `json:"instructions,omitempty"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date,omitempty"` // Zero for an open-ended plan
+}
+
+// CareBrief is what the staff of a booked shop need to know to handle a pet safely
+type CareBrief struct {
+	ScheduleID          string                `json:"schedule_id"`
+	PetID               string                `json:"pet_id"`
+	PetName             string                `json:"pet_name"`
+	Species             string                `json:"species"`
+	Breed               string                `json:"breed"`
+	Age                 int                   `json:"age"`
+	Weight              float64               `json:"weight"`
+	Allergies           []CareBriefAllergy    `json:"allergies"`
+	Medications         []CareBriefMedication `json:"medications"`
+	Temperament         string                `json:"temperament,omitempty"`
+	SpecialInstructions string                `json:"special_instructions,omitempty"`
+	Shared              bool                  `json:"shared"` // Whether the owner shares the brief with the shop
+	SharedAt            *time.Time            `json:"shared_at,omitempty"`
+	AvailableFrom       time.Time             `json:"available_from"`  // Start of the booking
+	AvailableUntil      time.Time             `json:"available_until"` // End of the booking
+	GeneratedAt         time.Time             `json:"generated_at"`
+}
+
+// PetCareBriefService lets owners share a care brief of their pet (allergies, medication, temperament and
+// special instructions) with the shop they book. Staff of the shop can read the brief only while the owner
+// shares it and only during the booking; every attempt to open a brief is recorded.
+type PetCareBriefService struct {
+	uowFactory    repository.UnitOfWorkFactory
+	petProjection projection.PetProjection
+	accessLog     projection.CareBriefAccessProjection
+
+	updateCareNotesHandler *command.UpdatePetCareNotesWithUoWHandler
+	shareHandler           *command.ShareScheduleCareBriefWithUoWHandler
+	revokeHandler          *command.RevokeScheduleCareBriefWithUoWHandler
+}
+
+// NewPetCareBriefService creates a new pet care brief service
+func NewPetCareBriefService(
+	uowFactory repository.UnitOfWorkFactory,
+	petProjection projection.PetProjection,
+	accessLog projection.CareBriefAccessProjection,
+	updateCareNotesHandler *command.UpdatePetCareNotesWithUoWHandler,
+	shareHandler *command.ShareScheduleCareBriefWithUoWHandler,
+	revokeHandler *command.RevokeScheduleCareBriefWithUoWHandler,
+) *PetCareBriefService {
+	return &PetCareBriefService{
+		uowFactory:             uowFactory,
+		petProjection:          petProjection,
+		accessLog:              accessLog,
+		updateCareNotesHandler: updateCareNotesHandler,
+		shareHandler:           shareHandler,
+		revokeHandler:          revokeHandler,
+	}
+}
+
+// Command operations
+
+// UpdateCareNotes sets the temperament and special instructions of a pet
+func (s *PetCareBriefService) UpdateCareNotes(ctx context.Context, cmd command.UpdatePetCareNotes) error {
+	return s.updateCareNotesHandler.Handle(ctx, &cmd)
+}
+
+// ShareCareBrief shares the pet's care brief with the shop of a booking
+func (s *PetCareBriefService) ShareCareBrief(ctx context.Context, cmd command.ShareScheduleCareBrief) error {
+	return s.shareHandler.Handle(ctx, &cmd)
+}
+
+// RevokeCareBrief stops sharing the pet's care brief with the shop of a booking
+func (s *PetCareBriefService) RevokeCareBrief(ctx context.Context, cmd command.RevokeScheduleCareBrief) error {
+	return s.revokeHandler.Handle(ctx, &cmd)
+}
+
+// Query operations
+
+// GetCareBrief builds the care brief of a pet booked on a booking; an empty petID means the booking's
+// primary pet. Guardians of the pet, the booking user and admins can always read it; staff of the booked
+// shop only while it is shared and the booking is under way. The attempt is recorded before the brief is
+// served, including denied attempts.
+func (s *PetCareBriefService) GetCareBrief(ctx context.Context, scheduleID, petID string, access CareBriefAccess) (*CareBrief, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	schedule, err := uow.ScheduleRepository().GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	if petID == "" {
+		petID = schedule.AssignedPet().PetID
+	}
+	if !schedule.HasBookedPet(petID) {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+
+	now := time.Now()
+	role, denial := s.careBriefAccess(ctx, uow, schedule, pet, access, now)
+
+	entry := &projection.CareBriefAccessView{
+		ID:         uuid.New().String(),
+		ScheduleID: schedule.ID(),
+		PetID:      pet.ID,
+		ShopID:     schedule.BookedShop().ShopID,
+		AccessedBy: access.UserID,
+		Role:       role,
+		Outcome:    projection.CareBriefAccessGranted,
+		Reason:     denial,
+		IPAddress:  access.IPAddress,
+		UserAgent:  access.UserAgent,
+		AccessedAt: now,
+	}
+	if denial != "" {
+		entry.Outcome = projection.CareBriefAccessDenied
+	}
+	if err := s.accessLog.Record(ctx, entry); err != nil {
+		// Without an audit entry the brief is not served
+		return nil, errors.NewInternalError("failed to record care brief access")
+	}
+
+	if denial != "" {
+		return nil, errors.NewForbiddenError(denial)
+	}
+	return newCareBrief(schedule, pet, now), nil
+}
+
+// ListAccesses lists who opened the care briefs of a booking. Available to owners of a booked pet, the
+// booking user and admins.
+func (s *PetCareBriefService) ListAccesses(ctx context.Context, scheduleID, requesterID string, isAdmin bool) ([]*projection.CareBriefAccessView, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	schedule, err := uow.ScheduleRepository().GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	if !isAdmin && requesterID != schedule.BookingUser().UserID {
+		owner := false
+		for _, petID := range schedule.BookedPetIDs() {
+			pet, err := s.petProjection.GetByID(ctx, petID)
+			if err == nil && pet.GuardianRole(requesterID) == event.GuardianRoleOwner {
+				owner = true
+				break
+			}
+		}
+		if !owner {
+			return nil, errors.NewForbiddenError("only owners can see who opened the care brief")
+		}
+	}
+
+	accesses, err := s.accessLog.ListBySchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list care brief accesses")
+	}
+	return accesses, nil
+}
+
+// careBriefAccess returns the role under which the requester opens the care brief, and why access is
+// denied, if it is
+func (s *PetCareBriefService) careBriefAccess(ctx context.Context, uow repository.UnitOfWork, schedule *aggregate.Schedule,
+	pet *projection.PetReadModel, access CareBriefAccess, now time.Time) (string, string) {
+	if access.IsAdmin {
+		return projection.CareBriefRoleAdmin, ""
+	}
+	if access.UserID != "" && (access.UserID == schedule.BookingUser().UserID || pet.GuardianRole(access.UserID) != "") {
+		return projection.CareBriefRoleGuardian, ""
+	}
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, access.UserID+"-"+schedule.BookedShop().ShopID)
+	if access.UserID == "" || err != nil || staff == nil || !staff.IsActive() {
+		return projection.CareBriefRoleNone, "you do not have access to the care brief of this booking"
+	}
+	if schedule.CareBriefConsentFor(pet.ID) == nil {
+		return projection.CareBriefRoleVendorStaff, "the owner has not shared the care brief of this pet"
+	}
+	if !schedule.CareBriefReadableAt(pet.ID, now) {
+		return projection.CareBriefRoleVendorStaff, "the care brief can only be read during the booking"
+	}
+	return projection.CareBriefRoleVendorStaff, ""
+}
+
+// newCareBrief builds the care brief of a booked pet; medication plans are included when they run
+// during the booking
+func newCareBrief(schedule *aggregate.Schedule, pet *projection.PetReadModel, now time.Time) *CareBrief {
+	brief := &CareBrief{
+		ScheduleID:          schedule.ID(),
+		PetID:               pet.ID,
+		PetName:             pet.Name,
+		Species:             pet.Species,
+		Breed:               pet.Breed,
+		Age:                 pet.Age,
+		Weight:              pet.Weight,
+		Allergies:           []CareBriefAllergy{},
+		Medications:         []CareBriefMedication{},
+		Temperament:         pet.Temperament,
+		SpecialInstructions: pet.SpecialInstructions,
+		AvailableFrom:       schedule.StartTime(),
+		AvailableUntil:      schedule.EndTime(),
+		GeneratedAt:         now,
+	}
+	if consent := schedule.CareBriefConsentFor(pet.ID); consent != nil {
+		sharedAt := consent.SharedAt
+		brief.Shared = true
+		brief.SharedAt = &sharedAt
+	}
+
+	for _, allergy := range pet.Allergies {
+		brief.Allergies = append(brief.Allergies, CareBriefAllergy{
+			Allergen: allergy.Allergen,
+			Severity: allergy.Severity,
+			Symptoms: allergy.Symptoms,
+			Notes:    allergy.Notes,
+		})
+	}
+
+	for _, plan := range pet.MedicationPlans {
+		end := plan.End()
+		if plan.StartDate.After(schedule.EndTime()) || (!end.IsZero() && !end.After(schedule.StartTime())) {
+			continue
+		}
+		brief.Medications = append(brief.Medications, CareBriefMedication{
+			DrugName:      plan.DrugName,
+			Dose:          plan.Dose,
+			IntervalHours: plan.IntervalHours,
+			Instructions:  plan.Instructions,
+			StartDate:     plan.StartDate,
+			EndDate:       end,
+		})
+	}
+
+	return brief
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+)
+
+// PetGuardianship lists who shares a pet and what is pending for it
+type PetGuardianship struct {
+	PetID           string                              `json:"pet_id"`
+	PetName         string                              `json:"pet_name"`
+	OwnerID         string                              `json:"owner_id"` // The primary owner
+	Guardians       []projection.PetGuardianView        `json:"guardians"`
+	Invitations     []projection.GuardianInvitationView `json:"invitations"`
+	PendingTransfer *projection.OwnershipTransferView   `json:"pending_transfer,omitempty"`
+}
+
+// PendingGuardianInvitation is a guardian invitation waiting for the user to respond
+type PendingGuardianInvitation struct {
+	PetID      string                            `json:"pet_id"`
+	PetName    string                            `json:"pet_name"`
+	OwnerID    string                            `json:"owner_id"`
+	Invitation projection.GuardianInvitationView `json:"invitation"`
+}
+
+// PendingOwnershipTransfer is an ownership transfer waiting for the user to respond
+type PendingOwnershipTransfer struct {
+	PetID    string                           `json:"pet_id"`
+	PetName  string                           `json:"pet_name"`
+	Transfer projection.OwnershipTransferView `json:"transfer"`
+}
+
+// PendingGuardianships groups the invitations and transfers waiting for a user
+type PendingGuardianships struct {
+	UserID      string                      `json:"user_id"`
+	Invitations []PendingGuardianInvitation `json:"invitations"`
+	Transfers   []PendingOwnershipTransfer  `json:"transfers"`
+}
+
+// PetGuardianService manages who shares a pet: co-owners and caretakers invited by an owner, and
+// transfers of the pet to a new primary owner
+type PetGuardianService struct {
+	petProjection projection.PetProjection
+
+	inviteGuardianHandler    *command.InvitePetGuardianWithUoWHandler
+	acceptInvitationHandler  *command.AcceptPetGuardianInvitationWithUoWHandler
+	declineInvitationHandler *command.DeclinePetGuardianInvitationWithUoWHandler
+	removeGuardianHandler    *command.RemovePetGuardianWithUoWHandler
+	requestTransferHandler   *command.RequestPetOwnershipTransferWithUoWHandler
+	acceptTransferHandler    *command.AcceptPetOwnershipTransferWithUoWHandler
+	declineTransferHandler   *command.DeclinePetOwnershipTransferWithUoWHandler
+}
+
+// NewPetGuardianService creates a new pet guardian service
+func NewPetGuardianService(
+	petProjection projection.PetProjection,
+	inviteGuardianHandler *command.InvitePetGuardianWithUoWHandler,
+	acceptInvitationHandler *command.AcceptPetGuardianInvitationWithUoWHandler,
+	declineInvitationHandler *command.DeclinePetGuardianInvitationWithUoWHandler,
+	removeGuardianHandler *command.RemovePetGuardianWithUoWHandler,
+	requestTransferHandler *command.RequestPetOwnershipTransferWithUoWHandler,
+	acceptTransferHandler *command.AcceptPetOwnershipTransferWithUoWHandler,
+	declineTransferHandler *command.DeclinePetOwnershipTransferWithUoWHandler,
+) *PetGuardianService {
+	return &PetGuardianService{
+		petProjection:            petProjection,
+		inviteGuardianHandler:    inviteGuardianHandler,
+		acceptInvitationHandler:  acceptInvitationHandler,
+		declineInvitationHandler: declineInvitationHandler,
+		removeGuardianHandler:    removeGuardianHandler,
+		requestTransferHandler:   requestTransferHandler,
+		acceptTransferHandler:    acceptTransferHandler,
+		declineTransferHandler:   declineTransferHandler,
+	}
+}
+
+// Command operations
+
+// InviteGuardian invites a user to share a pet
+func (s *PetGuardianService) InviteGuardian(ctx context.Context, cmd command.InvitePetGuardian) error {
+	return s.inviteGuardianHandler.Handle(ctx, &cmd)
+}
+
+// AcceptInvitation accepts a guardian invitation
+func (s *PetGuardianService) AcceptInvitation(ctx context.Context, cmd command.AcceptPetGuardianInvitation) error {
+	return s.acceptInvitationHandler.Handle(ctx, &cmd)
+}
+
+// DeclineInvitation declines a guardian invitation, or cancels it when called by an owner
+func (s *PetGuardianService) DeclineInvitation(ctx context.Context, cmd command.DeclinePetGuardianInvitation) error {
+	return s.declineInvitationHandler.Handle(ctx, &cmd)
+}
+
+// RemoveGuardian removes a guardian from a pet
+func (s *PetGuardianService) RemoveGuardian(ctx context.Context, cmd command.RemovePetGuardian) error {
+	return s.removeGuardianHandler.Handle(ctx, &cmd)
+}
+
+// RequestTransfer offers a pet to a new primary owner
+func (s *PetGuardianService) RequestTransfer(ctx context.Context, cmd command.RequestPetOwnershipTransfer) error {
+	return s.requestTransferHandler.Handle(ctx, &cmd)
+}
+
+// AcceptTransfer accepts a pending ownership transfer
+func (s *PetGuardianService) AcceptTransfer(ctx context.Context, cmd command.AcceptPetOwnershipTransfer) error {
+	return s.acceptTransferHandler.Handle(ctx, &cmd)
+}
+
+// DeclineTransfer declines a pending ownership transfer, or cancels it when called by the owner
+func (s *PetGuardianService) DeclineTransfer(ctx context.Context, cmd command.DeclinePetOwnershipTransfer) error {
+	return s.declineTransferHandler.Handle(ctx, &cmd)
+}
+
+// Query operations
+
+// GetGuardianship lists the guardians of a pet; available to its guardians and admins
+func (s *PetGuardianService) GetGuardianship(ctx context.Context, petID, requesterID string, isAdmin bool) (*PetGuardianship, error) {
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) == "" {
+		return nil, errors.NewForbiddenError("only guardians of this pet can see who shares it")
+	}
+
+	guardianship := &PetGuardianship{
+		PetID:           pet.ID,
+		PetName:         pet.Name,
+		OwnerID:         pet.UserID,
+		Guardians:       pet.Guardians,
+		Invitations:     pet.GuardianInvitations,
+		PendingTransfer: pet.PendingTransfer,
+	}
+	if guardianship.Guardians == nil {
+		guardianship.Guardians = []projection.PetGuardianView{}
+	}
+	if guardianship.Invitations == nil {
+		guardianship.Invitations = []projection.GuardianInvitationView{}
+	}
+	return guardianship, nil
+}
+
+// PendingForUser lists the unexpired guardian invitations and ownership transfers waiting for the user
+func (s *PetGuardianService) PendingForUser(ctx context.Context, userID string) (*PendingGuardianships, error) {
+	pets, err := s.petProjection.ListWithPendingInvitationsFor(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list pending invitations")
+	}
+
+	now := time.Now()
+	pending := &PendingGuardianships{
+		UserID:      userID,
+		Invitations: []PendingGuardianInvitation{},
+		Transfers:   []PendingOwnershipTransfer{},
+	}
+	for _, pet := range pets {
+		for _, invitation := range pet.GuardianInvitations {
+			if invitation.UserID == userID && now.Before(invitation.ExpiresAt) {
+				pending.Invitations = append(pending.Invitations, PendingGuardianInvitation{
+					PetID:      pet.ID,
+					PetName:    pet.Name,
+					OwnerID:    pet.UserID,
+					Invitation: invitation,
+				})
+			}
+		}
+		if transfer := pet.PendingTransfer; transfer != nil && transfer.ToUserID == userID && now.Before(transfer.ExpiresAt) {
+			pending.Transfers = append(pending.Transfers, PendingOwnershipTransfer{
+				PetID:    pet.ID,
+				PetName:  pet.Name,
+				Transfer: *transfer,
+			})
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/infrastructure/document"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// Share link lifetimes
+const (
+	DefaultShareLinkTTL = 72 * time.Hour
+	MaxShareLinkTTL     = 30 * 24 * time.Hour
+)
+
+// CreateShareLinkRequest describes a new health record share link
+type CreateShareLinkRequest struct {
+	PetID     string
+	CreatedBy string
+	TTL       time.Duration // DefaultShareLinkTTL when zero
+	Recipient string
+	Note      string
+}
+
+// HealthShareLink is a share link together with its signed token and URL
+type HealthShareLink struct {
+	*projection.HealthShareLinkReadModel
+	Token  string `json:"token"`
+	URL    string `json:"url"`
+	Status string `json:"status"` // ACTIVE, EXPIRED or REVOKED
+}
+
+// ShareLinkAccess describes who opened a share link
+type ShareLinkAccess struct {
+	Format    string
+	IPAddress string
+	UserAgent string
+}
+
+// PetHealthShareService exports pet health records and manages the signed, expiring links that let
+// a vet or groomer view a record without an account. Tokens are the link ID followed by an HMAC of
+// the link ID, pet and expiry, so a token cannot be forged or have its expiry extended.
+type PetHealthShareService struct {
+	petProjection  projection.PetProjection
+	linkProjection projection.HealthShareLinkProjection
+	secret         []byte
+	baseURL        string
+}
+
+// NewPetHealthShareService creates a new pet health share service
+func NewPetHealthShareService(petProjection projection.PetProjection, linkProjection projection.HealthShareLinkProjection, secret, baseURL string) *PetHealthShareService {
+	return &PetHealthShareService{
+		petProjection:  petProjection,
+		linkProjection: linkProjection,
+		secret:         []byte(secret),
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// ExportHealthRecord builds the health record of a pet owned by the requester
+func (s *PetHealthShareService) ExportHealthRecord(ctx context.Context, petID, requesterID string, isAdmin bool) (*document.PetHealthRecord, error) {
+	pet, err := s.ownedPet(ctx, petID, requesterID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return document.NewPetHealthRecord(pet, time.Now()), nil
+}
+
+// CreateShareLink creates a share link to the health record of a pet owned by the requester
+func (s *PetHealthShareService) CreateShareLink(ctx context.Context, req CreateShareLinkRequest, isAdmin bool) (*HealthShareLink, error) {
+	ttl := req.TTL
+	if ttl == 0 {
+		ttl = DefaultShareLinkTTL
+	}
+	if ttl < time.Hour || ttl > MaxShareLinkTTL {
+		return nil, errors.NewValidationError(fmt.Sprintf("share link lifetime must be between 1 hour and %d days", int(MaxShareLinkTTL.Hours()/24)))
+	}
+
+	pet, err := s.ownedPet(ctx, req.PetID, req.CreatedBy, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	link := &projection.HealthShareLinkReadModel{
+		ID:        uuid.New().String(),
+		PetID:     pet.ID,
+		OwnerID:   pet.UserID,
+		CreatedBy: req.CreatedBy,
+		Recipient: strings.TrimSpace(req.Recipient),
+		Note:      strings.TrimSpace(req.Note),
+		CreatedAt: now,
+		// Mongo stores milliseconds; truncate so the signed expiry matches the stored one
+		ExpiresAt: now.Add(ttl).Truncate(time.Millisecond),
+	}
+	if err := s.linkProjection.Create(ctx, link); err != nil {
+		return nil, errors.NewInternalError("failed to create share link")
+	}
+
+	return s.withToken(link, now), nil
+}
+
+// ListShareLinks lists the share links of a pet owned by the requester, including their access log
+func (s *PetHealthShareService) ListShareLinks(ctx context.Context, petID, requesterID string, isAdmin bool) ([]*HealthShareLink, error) {
+	if _, err := s.ownedPet(ctx, petID, requesterID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkProjection.ListByPet(ctx, petID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list share links")
+	}
+
+	now := time.Now()
+	result := make([]*HealthShareLink, 0, len(links))
+	for _, link := range links {
+		result = append(result, s.withToken(link, now))
+	}
+	return result, nil
+}
+
+// RevokeShareLink revokes a share link of a pet owned by the requester
+func (s *PetHealthShareService) RevokeShareLink(ctx context.Context, petID, linkID, requesterID string, isAdmin bool) error {
+	if _, err := s.ownedPet(ctx, petID, requesterID, isAdmin); err != nil {
+		return err
+	}
+
+	link, err := s.linkProjection.GetByID(ctx, linkID)
+	if err != nil || link.PetID != petID {
+		return errors.NewNotFoundError("share link")
+	}
+
+	if err := s.linkProjection.Revoke(ctx, linkID, requesterID, time.Now()); err != nil {
+		return errors.NewInternalError("failed to revoke share link")
+	}
+	return nil
+}
+
+// OpenSharedRecord verifies a share link token and returns the shared health record. Every use of an
+// existing link is recorded, including attempts after it expired or was revoked.
+func (s *PetHealthShareService) OpenSharedRecord(ctx context.Context, token string, access ShareLinkAccess) (*document.PetHealthRecord, error) {
+	linkID, signature, ok := strings.Cut(token, ".")
+	if !ok || linkID == "" || signature == "" {
+		return nil, errors.NewNotFoundError("share link")
+	}
+
+	link, err := s.linkProjection.GetByID(ctx, linkID)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(link))) {
+		return nil, errors.NewNotFoundError("share link")
+	}
+
+	now := time.Now()
+	outcome := projection.ShareLinkAccessGranted
+	if link.IsRevoked() {
+		outcome = projection.ShareLinkAccessRevoked
+	} else if link.IsExpired(now) {
+		outcome = projection.ShareLinkAccessExpired
+	}
+
+	entry := projection.HealthShareLinkAccessView{
+		AccessedAt: now,
+		Outcome:    outcome,
+		Format:     access.Format,
+		IPAddress:  access.IPAddress,
+		UserAgent:  access.UserAgent,
+	}
+	if err := s.linkProjection.RecordAccess(ctx, link.ID, entry); err != nil {
+		// Without an audit entry the record is not served
+		return nil, errors.NewInternalError("failed to record share link access")
+	}
+
+	switch outcome {
+	case projection.ShareLinkAccessRevoked:
+		return nil, errors.NewForbiddenError("This share link has been revoked")
+	case projection.ShareLinkAccessExpired:
+		return nil, errors.NewForbiddenError("This share link has expired")
+	}
+
+	pet, err := s.petProjection.GetByID(ctx, link.PetID)
+	if err != nil || !pet.IsActive {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	return document.NewPetHealthRecord(pet, now), nil
+}
+
+// HandlePetOwnershipTransferred revokes the links shared by the previous household when a pet is rehomed
+func (s *PetHealthShareService) HandlePetOwnershipTransferred(ctx context.Context, e *event.PetOwnershipTransferred) error {
+	if err := s.linkProjection.RevokeAllForPet(ctx, e.PetID, e.FromUserID, e.Timestamp); err != nil {
+		return fmt.Errorf("failed to revoke share links of transferred pet %s: %w", e.PetID, err)
+	}
+	return nil
+}
+
+// ownedPet loads a pet and checks that the requester owns or co-owns it, or is an admin
+func (s *PetHealthShareService) ownedPet(ctx context.Context, petID, requesterID string, isAdmin bool) (*projection.PetReadModel, error) {
+	if petID == "" {
+		return nil, errors.NewValidationError("pet ID is required")
+	}
+
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) != event.GuardianRoleOwner {
+		return nil, errors.NewForbiddenError("you can only share the health record of your own pets")
+	}
+	return pet, nil
+}
+
+// withToken adds the signed token, URL and status to a link
+func (s *PetHealthShareService) withToken(link *projection.HealthShareLinkReadModel, now time.Time) *HealthShareLink {
+	token := link.ID + "." + s.sign(link)
+
+	status := "ACTIVE"
+	if link.IsRevoked() {
+		status = "REVOKED"
+	} else if link.IsExpired(now) {
+		status = "EXPIRED"
+	}
+
+	return &HealthShareLink{
+		HealthShareLinkReadModel: link,
+		Token:                    token,
+		URL:                      s.baseURL + "/shared/health/" + token,
+		Status:                   status,
+	}
+}
+
+// sign computes the token signature of a link
+func (s *PetHealthShareService) sign(link *projection.HealthShareLinkReadModel) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s|%s|%d", link.ID, link.PetID, link.ExpiresAt.UnixMilli())
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+)
+
+// DoseStatusPending marks a scheduled dose that has not been logged yet
+const DoseStatusPending = "PENDING"
+
+// MedicationPlanStatus is a medication plan together with its next dose
+type MedicationPlanStatus struct {
+	projection.MedicationPlanView
+	Active     bool       `json:"active"`
+	NextDoseAt *time.Time `json:"next_dose_at,omitempty"`
+}
+
+// PetMedications lists the medication plans of a pet
+type PetMedications struct {
+	PetID   string                 `json:"pet_id"`
+	PetName string                 `json:"pet_name"`
+	Plans   []MedicationPlanStatus `json:"plans"`
+}
+
+// ScheduledDose is a dose that falls due during a booking
+type ScheduledDose struct {
+	PlanID       string    `json:"plan_id"`
+	DrugName     string    `json:"drug_name"`
+	Dose         string    `json:"dose"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	Status       string    `json:"status"` // GIVEN, MISSED or PENDING
+	RecordedBy   string    `json:"recorded_by,omitempty"`
+}
+
+// ScheduleMedications lists the medication a pet needs during a booking
+type ScheduleMedications struct {
+	ScheduleID string                 `json:"schedule_id"`
+	PetID      string                 `json:"pet_id"`
+	PetName    string                 `json:"pet_name"`
+	StartTime  time.Time              `json:"start_time"`
+	EndTime    time.Time              `json:"end_time"`
+	Plans      []MedicationPlanStatus `json:"plans"`
+	Doses      []ScheduledDose        `json:"doses"`
+}
+
+// PetMedicationService manages pet medication plans and their dose logs, and reminds owners of doses
+// shortly before they fall due. Staff of a vendor caring for a pet see its medication through the booking.
+type PetMedicationService struct {
+	uowFactory    repository.UnitOfWorkFactory
+	petProjection projection.PetProjection
+	eventBus      bus.EventBus
+	reminderLog   ReminderLog
+	leadTime      time.Duration // How long before a dose the reminder is sent
+	stopChan      chan struct{}
+
+	addPlanHandler  *command.AddPetMedicationPlanWithUoWHandler
+	stopPlanHandler *command.StopPetMedicationPlanWithUoWHandler
+	logDoseHandler  *command.LogPetMedicationDoseWithUoWHandler
+}
+
+// NewPetMedicationService creates a new pet medication service
+func NewPetMedicationService(
+	uowFactory repository.UnitOfWorkFactory,
+	petProjection projection.PetProjection,
+	eventBus bus.EventBus,
+	reminderLog ReminderLog,
+	leadTime time.Duration,
+	addPlanHandler *command.AddPetMedicationPlanWithUoWHandler,
+	stopPlanHandler *command.StopPetMedicationPlanWithUoWHandler,
+	logDoseHandler *command.LogPetMedicationDoseWithUoWHandler,
+) *PetMedicationService {
+	return &PetMedicationService{
+		uowFactory:      uowFactory,
+		petProjection:   petProjection,
+		eventBus:        eventBus,
+		reminderLog:     reminderLog,
+		leadTime:        leadTime,
+		stopChan:        make(chan struct{}),
+		addPlanHandler:  addPlanHandler,
+		stopPlanHandler: stopPlanHandler,
+		logDoseHandler:  logDoseHandler,
+	}
+}
+
+// Command operations
+
+// AddPlan starts a medication plan for a pet
+func (s *PetMedicationService) AddPlan(ctx context.Context, cmd command.AddPetMedicationPlan) error {
+	return s.addPlanHandler.Handle(ctx, &cmd)
+}
+
+// StopPlan stops a medication plan
+func (s *PetMedicationService) StopPlan(ctx context.Context, cmd command.StopPetMedicationPlan) error {
+	return s.stopPlanHandler.Handle(ctx, &cmd)
+}
+
+// LogDose records a scheduled dose as given or missed
+func (s *PetMedicationService) LogDose(ctx context.Context, cmd command.LogPetMedicationDose) error {
+	return s.logDoseHandler.Handle(ctx, &cmd)
+}
+
+// Query operations
+
+// ListPlans lists the medication plans of a pet; finished and stopped plans are only included on request.
+// Available to guardians of the pet and admins.
+func (s *PetMedicationService) ListPlans(ctx context.Context, petID, requesterID string, isAdmin, includeInactive bool) (*PetMedications, error) {
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) == "" {
+		return nil, errors.NewForbiddenError("only guardians of this pet can see its medication")
+	}
+
+	now := time.Now()
+	medications := &PetMedications{
+		PetID:   pet.ID,
+		PetName: pet.Name,
+		Plans:   []MedicationPlanStatus{},
+	}
+	for _, plan := range pet.MedicationPlans {
+		status := newMedicationPlanStatus(plan, now)
+		if status.Active || includeInactive || plan.StartDate.After(now) {
+			medications.Plans = append(medications.Plans, status)
+		}
+	}
+	return medications, nil
+}
+
+// PlansForSchedule lists the medication plans of a pet booked by a schedule that run during the booking,
+// with every dose that falls due during it; an empty petID means the booking's primary pet. Available to
+// the booking user, guardians of the pet, admins and staff of the booked vendor while the booking is open.
+func (s *PetMedicationService) PlansForSchedule(ctx context.Context, scheduleID, petID, requesterID string, isAdmin bool) (*ScheduleMedications, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	schedule, err := uow.ScheduleRepository().GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("schedule")
+	}
+
+	if petID == "" {
+		petID = schedule.AssignedPet().PetID
+	}
+	if !schedule.HasBookedPet(petID) {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+
+	allowed := isAdmin || requesterID == schedule.BookingUser().UserID || pet.GuardianRole(requesterID) != ""
+	if !allowed && !schedule.Status().IsFinal() {
+		staff, err := uow.VendorStaffRepository().GetByID(ctx, requesterID+"-"+schedule.BookedShop().ShopID)
+		allowed = err == nil && staff != nil && staff.IsActive()
+	}
+	if !allowed {
+		return nil, errors.NewForbiddenError("you do not have access to the medication of this booking")
+	}
+
+	now := time.Now()
+	medications := &ScheduleMedications{
+		ScheduleID: schedule.ID(),
+		PetID:      pet.ID,
+		PetName:    pet.Name,
+		StartTime:  schedule.StartTime(),
+		EndTime:    schedule.EndTime(),
+		Plans:      []MedicationPlanStatus{},
+		Doses:      []ScheduledDose{},
+	}
+	for _, plan := range pet.MedicationPlans {
+		end := plan.End()
+		if plan.StartDate.After(schedule.EndTime()) || (!end.IsZero() && !end.After(schedule.StartTime())) {
+			continue
+		}
+		medications.Plans = append(medications.Plans, newMedicationPlanStatus(plan, now))
+
+		for _, doseTime := range plan.DoseTimes(schedule.StartTime(), schedule.EndTime()) {
+			dose := ScheduledDose{
+				PlanID:       plan.ID,
+				DrugName:     plan.DrugName,
+				Dose:         plan.Dose,
+				ScheduledFor: doseTime,
+				Status:       DoseStatusPending,
+			}
+			if logged := plan.LoggedDose(doseTime); logged != nil {
+				dose.Status = logged.Status
+				dose.RecordedBy = logged.RecordedBy
+			}
+			medications.Doses = append(medications.Doses, dose)
+		}
+	}
+
+	sort.SliceStable(medications.Doses, func(i, j int) bool {
+		return medications.Doses[i].ScheduledFor.Before(medications.Doses[j].ScheduledFor)
+	})
+	return medications, nil
+}
+
+// Reminders
+
+// Start begins the background job that sends due-dose reminders
+func (s *PetMedicationService) Start(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Minute) // Check every 5 minutes
+	defer ticker.Stop()
+
+	fmt.Printf("✅ Medication reminder service started (checking every 5 minutes, %v before each dose)\n", s.leadTime)
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.SendDueReminders(ctx); err != nil {
+				fmt.Printf("❌ Error sending medication reminders: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Medication reminder service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Medication reminder service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *PetMedicationService) Stop() {
+	close(s.stopChan)
+}
+
+// SendDueReminders raises a MedicationDoseDue event for every dose falling due within the lead time that
+// has not been logged or reminded yet, returning how many were sent
+func (s *PetMedicationService) SendDueReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+	until := now.Add(s.leadTime)
+	pets, err := s.petProjection.ListWithMedicationPlansBetween(ctx, now, until)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, pet := range pets {
+		for _, plan := range pet.MedicationPlans {
+			for _, doseTime := range plan.DoseTimes(now, until) {
+				if plan.LoggedDose(doseTime) != nil {
+					continue
+				}
+
+				key := fmt.Sprintf("medication:%s:%s:%d", pet.ID, plan.ID, doseTime.Unix())
+				first, err := s.reminderLog.MarkSent(ctx, key, now)
+				if err != nil {
+					fmt.Printf("⚠️  Failed to record reminder %s: %v\n", key, err)
+					continue
+				}
+				if !first {
+					continue
+				}
+
+				reminder := &event.MedicationDoseDue{
+					PetID:     pet.ID,
+					UserID:    pet.UserID,
+					PetName:   pet.Name,
+					PlanID:    plan.ID,
+					DrugName:  plan.DrugName,
+					Dose:      plan.Dose,
+					DueAt:     doseTime,
+					Timestamp: now,
+				}
+				if err := s.eventBus.Publish(ctx, reminder); err != nil {
+					fmt.Printf("Warning: failed to publish %s for pet %s: %v\n", reminder.EventType(), pet.ID, err)
+					// Forget the reminder so the next run sends it
+					if err := s.reminderLog.Unmark(ctx, key); err != nil {
+						fmt.Printf("⚠️  Failed to forget reminder %s: %v\n", key, err)
+					}
+					continue
+				}
+				sent++
+			}
+		}
+	}
+
+	if sent > 0 {
+		fmt.Printf("✅ Sent %d medication reminder(s)\n", sent)
+	}
+
+	return sent, nil
+}
+
+// newMedicationPlanStatus works out whether a plan is active and when its next dose is due
+func newMedicationPlanStatus(plan projection.MedicationPlanView, now time.Time) MedicationPlanStatus {
+	status := MedicationPlanStatus{
+		MedicationPlanView: plan,
+		Active:             plan.IsActiveAt(now),
+	}
+	if status.Doses == nil {
+		status.Doses = []projection.DoseLogView{}
+	}
+
+	// The next dose is the first one from now on, within one interval
+	window := time.Duration(plan.IntervalHours) * time.Hour
+	if plan.StartDate.After(now) {
+		window = plan.StartDate.Sub(now) + time.Minute
+	}
+	if next := plan.DoseTimes(now, now.Add(window)); len(next) > 0 {
+		status.NextDoseAt = &next[0]
+	}
+	return status
+}
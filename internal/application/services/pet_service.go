@@ -2,27 +2,37 @@ package services
 
 import (
 	"context"
+	"time"
+
 	"whisko-petcare/internal/application/command"
 	"whisko-petcare/internal/application/query"
 	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
 )
 
 // PetService orchestrates pet operations
 type PetService struct {
 	// Command handlers (using Unit of Work)
-	createPetHandler             *command.CreatePetWithUoWHandler
-	updatePetHandler             *command.UpdatePetWithUoWHandler
-	deletePetHandler             *command.DeletePetWithUoWHandler
-	updatePetImageHandler        *command.UpdatePetImageWithUoWHandler
-	addPetVaccinationHandler     *command.AddPetVaccinationWithUoWHandler
-	addPetMedicalRecordHandler   *command.AddPetMedicalRecordWithUoWHandler
-	addPetAllergyHandler         *command.AddPetAllergyWithUoWHandler
-	removePetAllergyHandler      *command.RemovePetAllergyWithUoWHandler
+	createPetHandler              *command.CreatePetWithUoWHandler
+	updatePetHandler              *command.UpdatePetWithUoWHandler
+	deletePetHandler              *command.DeletePetWithUoWHandler
+	updatePetImageHandler         *command.UpdatePetImageWithUoWHandler
+	addPetVaccinationHandler      *command.AddPetVaccinationWithUoWHandler
+	addPetMedicalRecordHandler    *command.AddPetMedicalRecordWithUoWHandler
+	addPetAllergyHandler          *command.AddPetAllergyWithUoWHandler
+	removePetAllergyHandler       *command.RemovePetAllergyWithUoWHandler
+	updatePetVaccinationHandler   *command.UpdatePetVaccinationWithUoWHandler
+	voidPetVaccinationHandler     *command.VoidPetVaccinationWithUoWHandler
+	updatePetMedicalRecordHandler *command.UpdatePetMedicalRecordWithUoWHandler
+	voidPetMedicalRecordHandler   *command.VoidPetMedicalRecordWithUoWHandler
+	updatePetAllergyHandler       *command.UpdatePetAllergyWithUoWHandler
+	recordPetWeightHandler        *command.RecordPetWeightWithUoWHandler
 
 	// Query handlers (using Projections)
-	getPetHandler       *query.GetPetHandler
-	listUserPetsHandler *query.ListUserPetsHandler
-	listPetsHandler     *query.ListPetsHandler
+	getPetHandler        *query.GetPetHandler
+	listUserPetsHandler  *query.ListUserPetsHandler
+	listPetsHandler      *query.ListPetsHandler
+	getPetWeightsHandler *query.GetPetWeightHistoryHandler
 }
 
 // NewPetService creates a new pet service
@@ -35,22 +45,36 @@ func NewPetService(
 	addPetMedicalRecordHandler *command.AddPetMedicalRecordWithUoWHandler,
 	addPetAllergyHandler *command.AddPetAllergyWithUoWHandler,
 	removePetAllergyHandler *command.RemovePetAllergyWithUoWHandler,
+	updatePetVaccinationHandler *command.UpdatePetVaccinationWithUoWHandler,
+	voidPetVaccinationHandler *command.VoidPetVaccinationWithUoWHandler,
+	updatePetMedicalRecordHandler *command.UpdatePetMedicalRecordWithUoWHandler,
+	voidPetMedicalRecordHandler *command.VoidPetMedicalRecordWithUoWHandler,
+	updatePetAllergyHandler *command.UpdatePetAllergyWithUoWHandler,
+	recordPetWeightHandler *command.RecordPetWeightWithUoWHandler,
 	getPetHandler *query.GetPetHandler,
 	listUserPetsHandler *query.ListUserPetsHandler,
 	listPetsHandler *query.ListPetsHandler,
+	getPetWeightsHandler *query.GetPetWeightHistoryHandler,
 ) *PetService {
 	return &PetService{
-		createPetHandler:           createPetHandler,
-		updatePetHandler:           updatePetHandler,
-		deletePetHandler:           deletePetHandler,
-		updatePetImageHandler:      updatePetImageHandler,
-		addPetVaccinationHandler:   addPetVaccinationHandler,
-		addPetMedicalRecordHandler: addPetMedicalRecordHandler,
-		addPetAllergyHandler:       addPetAllergyHandler,
-		removePetAllergyHandler:    removePetAllergyHandler,
-		getPetHandler:              getPetHandler,
-		listUserPetsHandler:        listUserPetsHandler,
-		listPetsHandler:            listPetsHandler,
+		createPetHandler:              createPetHandler,
+		updatePetHandler:              updatePetHandler,
+		deletePetHandler:              deletePetHandler,
+		updatePetImageHandler:         updatePetImageHandler,
+		addPetVaccinationHandler:      addPetVaccinationHandler,
+		addPetMedicalRecordHandler:    addPetMedicalRecordHandler,
+		addPetAllergyHandler:          addPetAllergyHandler,
+		removePetAllergyHandler:       removePetAllergyHandler,
+		updatePetVaccinationHandler:   updatePetVaccinationHandler,
+		voidPetVaccinationHandler:     voidPetVaccinationHandler,
+		updatePetMedicalRecordHandler: updatePetMedicalRecordHandler,
+		voidPetMedicalRecordHandler:   voidPetMedicalRecordHandler,
+		updatePetAllergyHandler:       updatePetAllergyHandler,
+		recordPetWeightHandler:        recordPetWeightHandler,
+		getPetHandler:                 getPetHandler,
+		listUserPetsHandler:           listUserPetsHandler,
+		listPetsHandler:               listPetsHandler,
+		getPetWeightsHandler:          getPetWeightsHandler,
 	}
 }
 
@@ -95,6 +119,36 @@ func (s *PetService) ListPets(ctx context.Context, offset, limit int) ([]*projec
 	})
 }
 
+// GetPetHealthHistory retrieves a pet with its full health record, including voided records and the log of
+// corrections. Available to guardians of the pet and admins.
+func (s *PetService) GetPetHealthHistory(ctx context.Context, petID, requesterID string, isAdmin bool) (*projection.PetReadModel, error) {
+	pet, err := s.GetPet(ctx, petID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) == "" {
+		return nil, errors.NewForbiddenError("only guardians of this pet can see its health history")
+	}
+	return pet, nil
+}
+
+// GetPetWeights retrieves a pet's weight history with trend statistics. Available to guardians of the pet
+// and admins.
+func (s *PetService) GetPetWeights(ctx context.Context, petID, requesterID string, isAdmin bool, since time.Time) (*query.PetWeightHistory, error) {
+	pet, err := s.GetPet(ctx, petID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) == "" {
+		return nil, errors.NewForbiddenError("only guardians of this pet can see its weight history")
+	}
+
+	return s.getPetWeightsHandler.Handle(ctx, &query.GetPetWeightHistory{
+		PetID: petID,
+		Since: since,
+	})
+}
+
 // Health operations
 
 // AddPetVaccination adds a vaccination record to a pet
@@ -117,6 +171,36 @@ func (s *PetService) RemovePetAllergy(ctx context.Context, cmd command.RemovePet
 	return s.removePetAllergyHandler.Handle(ctx, &cmd)
 }
 
+// UpdatePetVaccination corrects a vaccination record of a pet
+func (s *PetService) UpdatePetVaccination(ctx context.Context, cmd command.UpdatePetVaccination) error {
+	return s.updatePetVaccinationHandler.Handle(ctx, &cmd)
+}
+
+// VoidPetVaccination voids a vaccination record of a pet
+func (s *PetService) VoidPetVaccination(ctx context.Context, cmd command.VoidPetVaccination) error {
+	return s.voidPetVaccinationHandler.Handle(ctx, &cmd)
+}
+
+// UpdatePetMedicalRecord corrects a medical record of a pet
+func (s *PetService) UpdatePetMedicalRecord(ctx context.Context, cmd command.UpdatePetMedicalRecord) error {
+	return s.updatePetMedicalRecordHandler.Handle(ctx, &cmd)
+}
+
+// VoidPetMedicalRecord voids a medical record of a pet
+func (s *PetService) VoidPetMedicalRecord(ctx context.Context, cmd command.VoidPetMedicalRecord) error {
+	return s.voidPetMedicalRecordHandler.Handle(ctx, &cmd)
+}
+
+// UpdatePetAllergy corrects an allergy of a pet
+func (s *PetService) UpdatePetAllergy(ctx context.Context, cmd command.UpdatePetAllergy) error {
+	return s.updatePetAllergyHandler.Handle(ctx, &cmd)
+}
+
+// RecordPetWeight adds a weight measurement to a pet's weight history
+func (s *PetService) RecordPetWeight(ctx context.Context, cmd command.RecordPetWeight) error {
+	return s.recordPetWeightHandler.Handle(ctx, &cmd)
+}
+
 // UpdatePetImage updates a pet's image URL
 func (s *PetService) UpdatePetImage(ctx context.Context, cmd command.UpdatePetImage) error {
 	return s.updatePetImageHandler.Handle(ctx, &cmd)
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+
+	"github.com/google/uuid"
+)
+
+// scheduleLifecycleJob names the lease replicas compete for before running the lifecycle jobs
+const scheduleLifecycleJob = "schedule-lifecycle"
+
+// scheduleLifecycleLeaseTTL is how long a replica keeps the lease without renewing it. It outlasts a few
+// missed ticks, so the jobs only move to another replica once the holder is gone.
+const scheduleLifecycleLeaseTTL = 5 * time.Minute
+
+// JobLease lets one API replica at a time run a background job
+type JobLease interface {
+	Acquire(ctx context.Context, job, holder string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, job, holder string) error
+}
+
+// ScheduleLifecycleConfig sets when the lifecycle jobs act on a booking
+type ScheduleLifecycleConfig struct {
+	ReminderLeads        []time.Duration // Customers are reminded this long before a confirmed booking starts; none disables reminders
+	AutoCompleteAfter    time.Duration   // Bookings are completed this long after they end, unless the shop records a no-show
+	EscalatePendingAfter time.Duration   // The shop is asked to confirm a booking still pending this long after it was made; zero disables it
+	CancelUnconfirmed    bool            // Cancel and refund bookings the shop has not confirmed CancelPendingBefore they start
+	CancelPendingBefore  time.Duration
+}
+
+// ScheduleLifecycleService moves bookings along on their own: it reminds customers of upcoming bookings,
+// completes bookings once they have ended and chases or cancels bookings the shop has not confirmed. Every
+// API replica runs the service, but only the replica holding the lease acts on each tick. Reminders are
+// recorded so each one fires once, and forgotten again when publishing them fails so the next tick retries
+// them. Status changes re-check the booking in their own transaction, so a lease that changes hands mid-run
+// does not act twice.
+type ScheduleLifecycleService struct {
+	uowFactory  repository.UnitOfWorkFactory
+	eventBus    bus.EventBus
+	reminderLog ReminderLog
+	lease       JobLease
+	holder      string
+	config      ScheduleLifecycleConfig
+	stopChan    chan struct{}
+
+	autoCompleteHandler      *command.AutoCompleteScheduleWithUoWHandler
+	cancelUnconfirmedHandler *command.CancelUnconfirmedScheduleWithUoWHandler
+}
+
+// NewScheduleLifecycleService creates a new schedule lifecycle service
+func NewScheduleLifecycleService(
+	uowFactory repository.UnitOfWorkFactory,
+	eventBus bus.EventBus,
+	reminderLog ReminderLog,
+	lease JobLease,
+	config ScheduleLifecycleConfig,
+	autoCompleteHandler *command.AutoCompleteScheduleWithUoWHandler,
+	cancelUnconfirmedHandler *command.CancelUnconfirmedScheduleWithUoWHandler,
+) *ScheduleLifecycleService {
+	config.ReminderLeads = append([]time.Duration(nil), config.ReminderLeads...)
+	sort.Sort(sort.Reverse(durationSlice(config.ReminderLeads)))
+
+	hostname, _ := os.Hostname()
+	return &ScheduleLifecycleService{
+		uowFactory:               uowFactory,
+		eventBus:                 eventBus,
+		reminderLog:              reminderLog,
+		lease:                    lease,
+		holder:                   fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.New().String()[:8]),
+		config:                   config,
+		stopChan:                 make(chan struct{}),
+		autoCompleteHandler:      autoCompleteHandler,
+		cancelUnconfirmedHandler: cancelUnconfirmedHandler,
+	}
+}
+
+// Start begins the background job that runs the lifecycle jobs every minute while this replica holds the lease
+func (s *ScheduleLifecycleService) Start(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute) // Check every minute
+	defer ticker.Stop()
+
+	fmt.Printf("✅ Schedule lifecycle service started as %s (checking every 1 minute, reminders %v before, auto-complete %s after)\n",
+		s.holder, s.config.ReminderLeads, s.config.AutoCompleteAfter)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runDueJobs(ctx)
+		case <-s.stopChan:
+			fmt.Println("⏹️  Schedule lifecycle service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Schedule lifecycle service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job and hands the lease to the other replicas
+func (s *ScheduleLifecycleService) Stop() {
+	close(s.stopChan)
+	if err := s.lease.Release(context.Background(), scheduleLifecycleJob, s.holder); err != nil {
+		fmt.Printf("⚠️  Failed to release schedule lifecycle lease: %v\n", err)
+	}
+}
+
+// runDueJobs runs the lifecycle jobs if this replica holds the lease
+func (s *ScheduleLifecycleService) runDueJobs(ctx context.Context) {
+	held, err := s.lease.Acquire(ctx, scheduleLifecycleJob, s.holder, scheduleLifecycleLeaseTTL)
+	if err != nil {
+		fmt.Printf("❌ Error acquiring schedule lifecycle lease: %v\n", err)
+		return
+	}
+	if !held {
+		return
+	}
+
+	now := time.Now()
+	if s.config.CancelUnconfirmed {
+		if err := s.cancelUnconfirmed(ctx, now); err != nil {
+			fmt.Printf("❌ Error cancelling unconfirmed schedules: %v\n", err)
+		}
+	}
+	if s.config.EscalatePendingAfter > 0 {
+		if err := s.escalatePending(ctx, now); err != nil {
+			fmt.Printf("❌ Error escalating pending schedules: %v\n", err)
+		}
+	}
+	if err := s.completeEnded(ctx, now); err != nil {
+		fmt.Printf("❌ Error auto-completing schedules: %v\n", err)
+	}
+	if len(s.config.ReminderLeads) > 0 {
+		if err := s.sendReminders(ctx, now); err != nil {
+			fmt.Printf("❌ Error sending schedule reminders: %v\n", err)
+		}
+	}
+}
+
+// cancelUnconfirmed cancels the pending bookings that start within the cancellation lead time, including
+// those whose start has passed
+func (s *ScheduleLifecycleService) cancelUnconfirmed(ctx context.Context, now time.Time) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	pending, err := uow.ScheduleRepository().GetStartingBetween(ctx,
+		[]aggregate.ScheduleStatus{aggregate.ScheduleStatusPending}, time.Time{}, now.Add(s.config.CancelPendingBefore))
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get unconfirmed schedules: %w", err)
+	}
+
+	cancelledCount := 0
+	for _, schedule := range pending {
+		cancelled, err := s.cancelUnconfirmedHandler.Handle(ctx, schedule.ID(), "The shop did not confirm the booking in time")
+		if err != nil {
+			fmt.Printf("⚠️  Failed to cancel unconfirmed schedule %s: %v\n", schedule.ID(), err)
+			continue
+		}
+		if cancelled {
+			cancelledCount++
+		}
+	}
+
+	if cancelledCount > 0 {
+		fmt.Printf("⏰ Cancelled %d unconfirmed schedule(s)\n", cancelledCount)
+	}
+	return nil
+}
+
+// escalatePending asks the shop once to confirm each booking that has been pending for too long
+func (s *ScheduleLifecycleService) escalatePending(ctx context.Context, now time.Time) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	pending, err := uow.ScheduleRepository().GetCreatedBefore(ctx,
+		[]aggregate.ScheduleStatus{aggregate.ScheduleStatusPending}, now.Add(-s.config.EscalatePendingAfter))
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get pending schedules: %w", err)
+	}
+
+	for _, schedule := range pending {
+		if !schedule.StartTime().After(now) {
+			continue
+		}
+
+		key := fmt.Sprintf("schedule-confirmation:%s", schedule.ID())
+		first, err := s.reminderLog.MarkSent(ctx, key, now)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to record reminder %s: %v\n", key, err)
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		overdue := &event.ScheduleConfirmationOverdue{
+			ScheduleID:   schedule.ID(),
+			ShopID:       schedule.BookedShop().ShopID,
+			UserID:       schedule.BookingUser().UserID,
+			StartTime:    schedule.StartTime(),
+			PendingSince: schedule.CreatedAt(),
+			Timestamp:    now,
+		}
+		if s.config.CancelUnconfirmed {
+			overdue.CancelAt = schedule.StartTime().Add(-s.config.CancelPendingBefore)
+		}
+		if err := s.eventBus.Publish(ctx, overdue); err != nil {
+			fmt.Printf("Warning: failed to publish %s for schedule %s: %v\n", overdue.EventType(), schedule.ID(), err)
+			s.unmarkReminder(ctx, key)
+		}
+	}
+	return nil
+}
+
+// completeEnded completes the bookings that ended longer ago than the auto-complete delay. Bookings the
+// shop recorded as a no-show are final and not listed.
+func (s *ScheduleLifecycleService) completeEnded(ctx context.Context, now time.Time) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	ended, err := uow.ScheduleRepository().GetEndedBefore(ctx,
+		[]aggregate.ScheduleStatus{aggregate.ScheduleStatusConfirmed, aggregate.ScheduleStatusInProgress},
+		now.Add(-s.config.AutoCompleteAfter))
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get ended schedules: %w", err)
+	}
+
+	completedCount := 0
+	for _, schedule := range ended {
+		completed, err := s.autoCompleteHandler.Handle(ctx, schedule.ID())
+		if err != nil {
+			fmt.Printf("⚠️  Failed to auto-complete schedule %s: %v\n", schedule.ID(), err)
+			continue
+		}
+		if completed {
+			completedCount++
+		}
+	}
+
+	if completedCount > 0 {
+		fmt.Printf("✅ Auto-completed %d schedule(s)\n", completedCount)
+	}
+	return nil
+}
+
+// sendReminders reminds customers of the confirmed bookings that have come within a reminder lead time
+func (s *ScheduleLifecycleService) sendReminders(ctx context.Context, now time.Time) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	upcoming, err := uow.ScheduleRepository().GetStartingBetween(ctx,
+		[]aggregate.ScheduleStatus{aggregate.ScheduleStatusConfirmed}, now, now.Add(s.config.ReminderLeads[0]))
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get upcoming schedules: %w", err)
+	}
+
+	sent := 0
+	for _, schedule := range upcoming {
+		lead, ok := s.reminderLead(schedule.StartTime().Sub(now))
+		if !ok {
+			continue
+		}
+
+		// The start is part of the key so a rescheduled booking gets its own reminders
+		key := fmt.Sprintf("schedule:%s:%s:%s", schedule.ID(), schedule.StartTime().UTC().Format(time.RFC3339), lead)
+		first, err := s.reminderLog.MarkSent(ctx, key, now)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to record reminder %s: %v\n", key, err)
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		reminder := &event.ScheduleReminderDue{
+			ScheduleID: schedule.ID(),
+			UserID:     schedule.BookingUser().UserID,
+			ShopID:     schedule.BookedShop().ShopID,
+			ShopName:   schedule.BookedShop().Name,
+			PetName:    schedule.AssignedPet().Name,
+			StartTime:  schedule.StartTime(),
+			LeadTime:   lead.String(),
+			Timestamp:  now,
+		}
+		if err := s.eventBus.Publish(ctx, reminder); err != nil {
+			fmt.Printf("Warning: failed to publish %s for schedule %s: %v\n", reminder.EventType(), schedule.ID(), err)
+			s.unmarkReminder(ctx, key)
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		fmt.Printf("✅ Sent %d schedule reminder(s)\n", sent)
+	}
+	return nil
+}
+
+// unmarkReminder forgets a reminder that was recorded but could not be published, so the next run sends it
+func (s *ScheduleLifecycleService) unmarkReminder(ctx context.Context, key string) {
+	if err := s.reminderLog.Unmark(ctx, key); err != nil {
+		fmt.Printf("⚠️  Failed to forget reminder %s: %v\n", key, err)
+	}
+}
+
+// reminderLead returns the closest reminder lead time a booking starting in the given time has reached.
+// Only the closest one fires, so a booking made an hour before it starts does not also get the 24-hour reminder.
+func (s *ScheduleLifecycleService) reminderLead(untilStart time.Duration) (time.Duration, bool) {
+	for i := len(s.config.ReminderLeads) - 1; i >= 0; i-- {
+		if untilStart <= s.config.ReminderLeads[i] {
+			return s.config.ReminderLeads[i], true
+		}
+	}
+	return 0, false
+}
+
+// durationSlice sorts durations
+type durationSlice []time.Duration
+
+func (d durationSlice) Len() int           { return len(d) }
+func (d durationSlice) Less(i, j int) bool { return d[i] < d[j] }
+func (d durationSlice) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"whisko-petcare/internal/application/command"
 	"whisko-petcare/internal/application/query"
@@ -13,9 +14,17 @@ type ScheduleService struct {
 	changeScheduleStatusHandler  *command.ChangeScheduleStatusWithUoWHandler
 	completeScheduleHandler      *command.CompleteScheduleWithUoWHandler
 	cancelScheduleHandler        *command.CancelScheduleWithUoWHandler
+	cancelSchedulePetHandler     *command.CancelSchedulePetWithUoWHandler
+	submitVisitReportHandler     *command.SubmitVisitReportWithUoWHandler
+	acceptVisitReportHandler     *command.AcceptVisitReportWithUoWHandler
+	rescheduleHandler            *command.RescheduleScheduleWithUoWHandler
+	proposeRescheduleHandler     *command.ProposeScheduleRescheduleWithUoWHandler
+	respondRescheduleHandler     *command.RespondRescheduleProposalWithUoWHandler
+	assignStaffHandler           *command.AssignScheduleStaffWithUoWHandler
 	getScheduleHandler           *query.GetScheduleHandler
 	listUserSchedulesHandler     *query.ListUserSchedulesHandler
 	listShopSchedulesHandler     *query.ListShopSchedulesHandler
+	listStaffSchedulesHandler    *query.ListStaffSchedulesHandler
 	listSchedulesHandler         *query.ListSchedulesHandler
 }
 
@@ -25,9 +34,17 @@ func NewScheduleService(
 	changeScheduleStatusHandler *command.ChangeScheduleStatusWithUoWHandler,
 	completeScheduleHandler *command.CompleteScheduleWithUoWHandler,
 	cancelScheduleHandler *command.CancelScheduleWithUoWHandler,
+	cancelSchedulePetHandler *command.CancelSchedulePetWithUoWHandler,
+	submitVisitReportHandler *command.SubmitVisitReportWithUoWHandler,
+	acceptVisitReportHandler *command.AcceptVisitReportWithUoWHandler,
+	rescheduleHandler *command.RescheduleScheduleWithUoWHandler,
+	proposeRescheduleHandler *command.ProposeScheduleRescheduleWithUoWHandler,
+	respondRescheduleHandler *command.RespondRescheduleProposalWithUoWHandler,
+	assignStaffHandler *command.AssignScheduleStaffWithUoWHandler,
 	getScheduleHandler *query.GetScheduleHandler,
 	listUserSchedulesHandler *query.ListUserSchedulesHandler,
 	listShopSchedulesHandler *query.ListShopSchedulesHandler,
+	listStaffSchedulesHandler *query.ListStaffSchedulesHandler,
 	listSchedulesHandler *query.ListSchedulesHandler,
 ) *ScheduleService {
 	return &ScheduleService{
@@ -35,9 +52,17 @@ func NewScheduleService(
 		changeScheduleStatusHandler: changeScheduleStatusHandler,
 		completeScheduleHandler:     completeScheduleHandler,
 		cancelScheduleHandler:       cancelScheduleHandler,
+		cancelSchedulePetHandler:    cancelSchedulePetHandler,
+		submitVisitReportHandler:    submitVisitReportHandler,
+		acceptVisitReportHandler:    acceptVisitReportHandler,
+		rescheduleHandler:           rescheduleHandler,
+		proposeRescheduleHandler:    proposeRescheduleHandler,
+		respondRescheduleHandler:    respondRescheduleHandler,
+		assignStaffHandler:          assignStaffHandler,
 		getScheduleHandler:          getScheduleHandler,
 		listUserSchedulesHandler:    listUserSchedulesHandler,
 		listShopSchedulesHandler:    listShopSchedulesHandler,
+		listStaffSchedulesHandler:   listStaffSchedulesHandler,
 		listSchedulesHandler:        listSchedulesHandler,
 	}
 }
@@ -62,22 +87,62 @@ func (s *ScheduleService) CancelSchedule(ctx context.Context, cmd *command.Cance
 	return s.cancelScheduleHandler.Handle(ctx, cmd)
 }
 
+// SubmitVisitReport records the visit report of shop staff and completes the schedule
+func (s *ScheduleService) SubmitVisitReport(ctx context.Context, cmd *command.SubmitVisitReport) error {
+	return s.submitVisitReportHandler.Handle(ctx, cmd)
+}
+
+// CancelSchedulePet cancels one pet of a booking covering several pets and refunds its share of the price
+func (s *ScheduleService) CancelSchedulePet(ctx context.Context, cmd *command.CancelSchedulePet) (*command.CancelSchedulePetResponse, error) {
+	return s.cancelSchedulePetHandler.Handle(ctx, cmd)
+}
+
+// AcceptVisitReport adds the visit report of a schedule to the pet's medical history
+func (s *ScheduleService) AcceptVisitReport(ctx context.Context, cmd *command.AcceptVisitReport) error {
+	return s.acceptVisitReportHandler.Handle(ctx, cmd)
+}
+
+// RescheduleSchedule moves a schedule to a new time and settles any change in price
+func (s *ScheduleService) RescheduleSchedule(ctx context.Context, cmd *command.RescheduleSchedule) (*command.RescheduleScheduleResponse, error) {
+	return s.rescheduleHandler.Handle(ctx, cmd)
+}
+
+// ProposeReschedule records a new time proposed by shop staff for the customer to accept or decline
+func (s *ScheduleService) ProposeReschedule(ctx context.Context, cmd *command.ProposeScheduleReschedule) error {
+	return s.proposeRescheduleHandler.Handle(ctx, cmd)
+}
+
+// RespondRescheduleProposal accepts or declines the time proposed by shop staff
+func (s *ScheduleService) RespondRescheduleProposal(ctx context.Context, cmd *command.RespondRescheduleProposal) (*command.RescheduleScheduleResponse, error) {
+	return s.respondRescheduleHandler.Handle(ctx, cmd)
+}
+
+// AssignStaff assigns a schedule to a staff member of the booked shop, picking one by load if none is given
+func (s *ScheduleService) AssignStaff(ctx context.Context, cmd *command.AssignScheduleStaff) (*command.AssignScheduleStaffResponse, error) {
+	return s.assignStaffHandler.Handle(ctx, cmd)
+}
+
 // GetSchedule retrieves a schedule by ID
 func (s *ScheduleService) GetSchedule(ctx context.Context, scheduleID string) (interface{}, error) {
 	return s.getScheduleHandler.Handle(ctx, scheduleID)
 }
 
-// ListUserSchedules retrieves all schedules for a user with pagination
-func (s *ScheduleService) ListUserSchedules(ctx context.Context, userID string, offset, limit int) ([]interface{}, error) {
-	return s.listUserSchedulesHandler.Handle(ctx, userID, offset, limit)
+// ListUserSchedules retrieves a page of the schedules of a user
+func (s *ScheduleService) ListUserSchedules(ctx context.Context, q *query.ListUserSchedulesQuery) (*query.SchedulePage, error) {
+	return s.listUserSchedulesHandler.Handle(ctx, q)
+}
+
+// ListShopSchedules retrieves a page of the schedules of a shop
+func (s *ScheduleService) ListShopSchedules(ctx context.Context, q *query.ListShopSchedulesQuery) (*query.SchedulePage, error) {
+	return s.listShopSchedulesHandler.Handle(ctx, q)
 }
 
-// ListShopSchedules retrieves all schedules for a shop with pagination
-func (s *ScheduleService) ListShopSchedules(ctx context.Context, shopID string, offset, limit int) ([]interface{}, error) {
-	return s.listShopSchedulesHandler.Handle(ctx, shopID, offset, limit)
+// ListStaffSchedules retrieves the schedules assigned to a staff member within a time range
+func (s *ScheduleService) ListStaffSchedules(ctx context.Context, staffID, requesterID string, isAdmin bool, from, to time.Time) ([]interface{}, error) {
+	return s.listStaffSchedulesHandler.Handle(ctx, staffID, requesterID, isAdmin, from, to)
 }
 
-// ListSchedules retrieves all schedules with pagination
-func (s *ScheduleService) ListSchedules(ctx context.Context, offset, limit int) ([]interface{}, error) {
-	return s.listSchedulesHandler.Handle(ctx, offset, limit)
+// ListSchedules retrieves a page of the schedules of every shop
+func (s *ScheduleService) ListSchedules(ctx context.Context, q *query.ListSchedulesQuery) (*query.SchedulePage, error) {
+	return s.listSchedulesHandler.Handle(ctx, q)
 }
@@ -13,6 +13,7 @@ type ServiceService struct {
 	updateServiceHandler       *command.UpdateServiceWithUoWHandler
 	deleteServiceHandler       *command.DeleteServiceWithUoWHandler
 	updateServiceImageHandler  *command.UpdateServiceImageWithUoWHandler
+	updateEligibilityHandler   *command.UpdateServiceEligibilityWithUoWHandler
 	getServiceHandler          *query.GetServiceHandler
 	listVendorServicesHandler  *query.ListVendorServicesHandler
 	listServicesHandler        *query.ListServicesHandler
@@ -24,6 +25,7 @@ func NewServiceService(
 	updateServiceHandler *command.UpdateServiceWithUoWHandler,
 	deleteServiceHandler *command.DeleteServiceWithUoWHandler,
 	updateServiceImageHandler *command.UpdateServiceImageWithUoWHandler,
+	updateEligibilityHandler *command.UpdateServiceEligibilityWithUoWHandler,
 	getServiceHandler *query.GetServiceHandler,
 	listVendorServicesHandler *query.ListVendorServicesHandler,
 	listServicesHandler *query.ListServicesHandler,
@@ -33,6 +35,7 @@ func NewServiceService(
 		updateServiceHandler:      updateServiceHandler,
 		deleteServiceHandler:      deleteServiceHandler,
 		updateServiceImageHandler: updateServiceImageHandler,
+		updateEligibilityHandler:  updateEligibilityHandler,
 		getServiceHandler:         getServiceHandler,
 		listVendorServicesHandler: listVendorServicesHandler,
 		listServicesHandler:       listServicesHandler,
@@ -73,3 +76,8 @@ func (s *ServiceService) ListServices(ctx context.Context, offset, limit int) ([
 func (s *ServiceService) UpdateServiceImage(ctx context.Context, cmd command.UpdateServiceImage) error {
 	return s.updateServiceImageHandler.Handle(ctx, &cmd)
 }
+
+// UpdateServiceEligibility sets which species, sizes and weights a service accepts
+func (s *ServiceService) UpdateServiceEligibility(ctx context.Context, cmd command.UpdateServiceEligibility) error {
+	return s.updateEligibilityHandler.Handle(ctx, &cmd)
+}
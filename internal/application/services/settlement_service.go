@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/payos"
+
+	"github.com/google/uuid"
+)
+
+// SettlementResult summarizes a settlement run
+type SettlementResult struct {
+	PaidOut     int `json:"paid_out"`
+	CarriedOver int `json:"carried_over"`
+	Closed      int `json:"closed"` // Closed with a zero balance
+	Failed      int `json:"failed"`
+}
+
+// SettlementService closes vendor settlement periods and pays out their balances in one transfer per settlement
+type SettlementService struct {
+	uowFactory        repository.UnitOfWorkFactory
+	eventBus          bus.EventBus
+	payoutService     *payos.PayoutService
+	commissionPercent int // Platform commission withheld from each settlement payout
+	stopChan          chan struct{}
+}
+
+// NewSettlementService creates a new settlement service
+func NewSettlementService(uowFactory repository.UnitOfWorkFactory, eventBus bus.EventBus, payoutService *payos.PayoutService, commissionPercent int) *SettlementService {
+	return &SettlementService{
+		uowFactory:        uowFactory,
+		eventBus:          eventBus,
+		payoutService:     payoutService,
+		commissionPercent: commissionPercent,
+		stopChan:          make(chan struct{}),
+	}
+}
+
+// Start begins the background job that settles vendor balances whose period has ended
+func (s *SettlementService) Start(ctx context.Context) {
+	ticker := time.NewTicker(15 * time.Minute) // Check every 15 minutes
+	defer ticker.Stop()
+
+	fmt.Println("✅ Settlement service started (checking every 15 minutes)")
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.SettleDue(ctx); err != nil {
+				fmt.Printf("❌ Error settling vendor balances: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Settlement service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Settlement service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *SettlementService) Stop() {
+	close(s.stopChan)
+}
+
+// SettleDue closes every open settlement whose period has ended. Each settlement is either paid out
+// as a single payout or, when below the vendor's minimum payout amount, carried over to the next period.
+// Settlements with a zero balance are closed without either.
+func (s *SettlementService) SettleDue(ctx context.Context) (*SettlementResult, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	due, err := uow.SettlementRepository().GetDue(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due settlements: %w", err)
+	}
+
+	result := &SettlementResult{}
+	for _, settlement := range due {
+		status, payoutID, err := s.settle(ctx, settlement.ID())
+		if err != nil {
+			fmt.Printf("⚠️  Failed to settle %s for vendor %s: %v\n", settlement.ID(), settlement.VendorID(), err)
+			result.Failed++
+			continue
+		}
+
+		switch status {
+		case aggregate.SettlementStatusPaidOut:
+			result.PaidOut++
+			s.transfer(ctx, payoutID)
+		case aggregate.SettlementStatusClosed:
+			result.Closed++
+		default:
+			result.CarriedOver++
+		}
+	}
+
+	if len(due) > 0 {
+		fmt.Printf("✅ Settlement run: %d paid out, %d carried over, %d closed, %d failed\n",
+			result.PaidOut, result.CarriedOver, result.Closed, result.Failed)
+	}
+
+	return result, nil
+}
+
+// settle closes a single settlement and returns the status it was closed with and the created payout ID
+// (empty unless paid out)
+func (s *SettlementService) settle(ctx context.Context, settlementID string) (aggregate.SettlementStatus, string, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		return "", "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	settlementRepo := uow.SettlementRepository()
+	settlement, err := settlementRepo.GetByID(ctx, settlementID)
+	if err != nil {
+		uow.Rollback(ctx)
+		return "", "", err
+	}
+	if !settlement.IsDue(time.Now()) {
+		uow.Rollback(ctx)
+		return "", "", fmt.Errorf("settlement is not due")
+	}
+
+	vendor, err := uow.VendorRepository().GetByID(ctx, settlement.VendorID())
+	if err != nil {
+		uow.Rollback(ctx)
+		return "", "", fmt.Errorf("failed to get vendor: %w", err)
+	}
+
+	var events []event.DomainEvent
+	var payoutID string
+	total := settlement.TotalAmount()
+	commission := aggregate.SettlementCommission(total, s.commissionPercent)
+	payoutAmount := total - commission
+
+	if payoutAmount > 0 && payoutAmount >= vendor.MinPayoutAmount() && vendor.HasBankAccount() {
+		vendorBankAccount := vendor.GetBankAccount()
+		payoutID = fmt.Sprintf("PAYOUT-%d", time.Now().UnixNano())
+
+		payout, err := aggregate.NewSettlementPayout(
+			payoutID,
+			vendor.ID(),
+			settlement.ID(),
+			settlement.LineItems(),
+			payoutAmount,
+			aggregate.BankAccount{
+				BankName:      vendorBankAccount.BankName,
+				AccountNumber: vendorBankAccount.AccountNumber,
+				AccountName:   vendorBankAccount.AccountName,
+				BankBranch:    vendorBankAccount.BankBranch,
+			},
+			"Whisko settlement", // Max 25 chars for PayOS
+		)
+		if err != nil {
+			uow.Rollback(ctx)
+			return "", "", fmt.Errorf("failed to create payout: %w", err)
+		}
+
+		if err := settlement.MarkAsPaidOut(payoutID, commission); err != nil {
+			uow.Rollback(ctx)
+			return "", "", err
+		}
+
+		events = append(events, payout.GetUncommittedEvents()...)
+		if err := uow.PayoutRepository().Save(ctx, payout); err != nil {
+			uow.Rollback(ctx)
+			return "", "", fmt.Errorf("failed to save payout: %w", err)
+		}
+
+		fmt.Printf("💰 Settlement %s paid out: %d VND (commission %d VND) in %d line item(s) -> payout %s\n",
+			settlement.ID(), payoutAmount, commission, len(settlement.LineItems()), payoutID)
+	} else if total == 0 {
+		// Nothing to pay out or carry over: carrying a zero balance would only open another empty settlement
+		if err := settlement.Close("no balance"); err != nil {
+			uow.Rollback(ctx)
+			return "", "", err
+		}
+
+		fmt.Printf("✅ Settlement %s closed with no balance\n", settlement.ID())
+	} else {
+		reason := "below minimum payout amount"
+		if !vendor.HasBankAccount() {
+			reason = "vendor has no bank account"
+		}
+
+		// Carry the balance into the vendor's settlement for the current period
+		now := time.Now()
+		next, err := settlementRepo.GetOpenForVendorAt(ctx, vendor.ID(), now)
+		if err != nil {
+			uow.Rollback(ctx)
+			return "", "", err
+		}
+		if next == nil {
+			periodStart, periodEnd := aggregate.SettlementPeriodBounds(vendor.SettlementPeriod(), now)
+			next, err = aggregate.NewSettlement(uuid.New().String(), vendor.ID(), vendor.SettlementPeriod(), periodStart, periodEnd)
+			if err != nil {
+				uow.Rollback(ctx)
+				return "", "", err
+			}
+		}
+
+		if err := next.AddCarryOver(settlement.ID(), total); err != nil {
+			uow.Rollback(ctx)
+			return "", "", err
+		}
+		if err := settlement.CarryOver(next.ID(), reason); err != nil {
+			uow.Rollback(ctx)
+			return "", "", err
+		}
+
+		events = append(events, next.GetUncommittedEvents()...)
+		if err := settlementRepo.Save(ctx, next); err != nil {
+			uow.Rollback(ctx)
+			return "", "", fmt.Errorf("failed to save next settlement: %w", err)
+		}
+
+		fmt.Printf("↪️  Settlement %s carried over to %s: %d VND (%s)\n", settlement.ID(), next.ID(), total, reason)
+	}
+
+	events = append(events, settlement.GetUncommittedEvents()...)
+	if err := settlementRepo.Save(ctx, settlement); err != nil {
+		uow.Rollback(ctx)
+		return "", "", fmt.Errorf("failed to save settlement: %w", err)
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return "", "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := s.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish settlement events: %v\n", err)
+	}
+
+	return settlement.Status(), payoutID, nil
+}
+
+// transfer sends the bank transfer for a settlement payout and records the result.
+// Failed payouts can be retried through POST /payouts/{id}/process.
+func (s *SettlementService) transfer(ctx context.Context, payoutID string) {
+	if s.payoutService == nil {
+		fmt.Printf("⚠️  Payout service not configured - payout %s left pending\n", payoutID)
+		return
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	if err := uow.Begin(ctx); err != nil {
+		fmt.Printf("❌ Failed to begin payout transaction: %v\n", err)
+		return
+	}
+
+	payoutRepo := uow.PayoutRepository()
+	payout, err := payoutRepo.GetByID(ctx, payoutID)
+	if err != nil {
+		fmt.Printf("❌ Failed to get payout %s: %v\n", payoutID, err)
+		uow.Rollback(ctx)
+		return
+	}
+
+	bankAccount := payout.BankAccount()
+	fmt.Printf("🏦 Processing bank transfer to %s - %s\n", bankAccount.BankName, bankAccount.AccountNumber)
+
+	transferInfo, transferErr := s.payoutService.ProcessPayout(
+		ctx,
+		payout.ID(),
+		bankAccount.BankName,
+		bankAccount.AccountNumber,
+		bankAccount.AccountName,
+		payout.Amount(),
+		payout.Notes(),
+	)
+
+	if transferErr != nil {
+		fmt.Printf("❌ Bank transfer failed: %v\n", transferErr)
+		_ = payout.MarkAsFailed(transferErr.Error())
+	} else if transferInfo.Status == "SUCCEEDED" {
+		fmt.Printf("✅ Bank transfer SUCCEEDED! Transfer ID: %s\n", transferInfo.TransferID)
+		_ = payout.MarkAsProcessing(transferInfo.TransferID)
+		_ = payout.MarkAsCompleted()
+	} else if transferInfo.Status == "FAILED" {
+		errorMsg := "Transfer failed"
+		if transferInfo.ErrorMessage != "" {
+			errorMsg = transferInfo.ErrorMessage
+		}
+		fmt.Printf("❌ Transfer failed: %s\n", errorMsg)
+		_ = payout.MarkAsFailed(errorMsg)
+	} else {
+		fmt.Printf("⏳ Transfer is PROCESSING (status: %s) - marking as processing\n", transferInfo.Status)
+		if transferInfo.TransferID != "" {
+			_ = payout.MarkAsProcessing(transferInfo.TransferID)
+		} else {
+			_ = payout.MarkAsProcessing("PROCESSING")
+		}
+	}
+
+	events := payout.GetUncommittedEvents()
+	if err := payoutRepo.Save(ctx, payout); err != nil {
+		fmt.Printf("❌ Failed to save payout %s: %v\n", payoutID, err)
+		uow.Rollback(ctx)
+		return
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		fmt.Printf("❌ Failed to commit payout %s: %v\n", payoutID, err)
+		return
+	}
+
+	if err := s.eventBus.PublishBatch(ctx, events); err != nil {
+		fmt.Printf("Warning: failed to publish payout events: %v\n", err)
+	}
+
+	fmt.Printf("✅ Payout final status: %s\n", payout.Status())
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/pkg/errors"
+)
+
+// Catalogue languages
+const (
+	CatalogLanguageEnglish    = "en"
+	CatalogLanguageVietnamese = "vi"
+)
+
+// CatalogBreed is a breed of the species catalogue, named in the requested language
+type CatalogBreed struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"` // In the requested language
+	NameEn  string   `json:"name_en"`
+	NameVi  string   `json:"name_vi"`
+	Aliases []string `json:"aliases"`
+	Size    string   `json:"size,omitempty"`
+}
+
+// CatalogSpecies is a species of the catalogue, named in the requested language
+type CatalogSpecies struct {
+	Code      string         `json:"code"`
+	Name      string         `json:"name"` // In the requested language
+	NameEn    string         `json:"name_en"`
+	NameVi    string         `json:"name_vi"`
+	Aliases   []string       `json:"aliases"`
+	Breeds    []CatalogBreed `json:"breeds"`
+	IsActive  bool           `json:"is_active"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// SpeciesCatalogService manages the species and breed catalogue pets are validated against
+type SpeciesCatalogService struct {
+	uowFactory repository.UnitOfWorkFactory
+
+	createSpeciesHandler     *command.CreateSpeciesWithUoWHandler
+	updateSpeciesHandler     *command.UpdateSpeciesWithUoWHandler
+	deactivateSpeciesHandler *command.DeactivateSpeciesWithUoWHandler
+	addBreedHandler          *command.AddSpeciesBreedWithUoWHandler
+	updateBreedHandler       *command.UpdateSpeciesBreedWithUoWHandler
+	removeBreedHandler       *command.RemoveSpeciesBreedWithUoWHandler
+}
+
+// NewSpeciesCatalogService creates a new species catalogue service
+func NewSpeciesCatalogService(
+	uowFactory repository.UnitOfWorkFactory,
+	createSpeciesHandler *command.CreateSpeciesWithUoWHandler,
+	updateSpeciesHandler *command.UpdateSpeciesWithUoWHandler,
+	deactivateSpeciesHandler *command.DeactivateSpeciesWithUoWHandler,
+	addBreedHandler *command.AddSpeciesBreedWithUoWHandler,
+	updateBreedHandler *command.UpdateSpeciesBreedWithUoWHandler,
+	removeBreedHandler *command.RemoveSpeciesBreedWithUoWHandler,
+) *SpeciesCatalogService {
+	return &SpeciesCatalogService{
+		uowFactory:               uowFactory,
+		createSpeciesHandler:     createSpeciesHandler,
+		updateSpeciesHandler:     updateSpeciesHandler,
+		deactivateSpeciesHandler: deactivateSpeciesHandler,
+		addBreedHandler:          addBreedHandler,
+		updateBreedHandler:       updateBreedHandler,
+		removeBreedHandler:       removeBreedHandler,
+	}
+}
+
+// Command operations
+
+// CreateSpecies adds a species to the catalogue
+func (s *SpeciesCatalogService) CreateSpecies(ctx context.Context, cmd command.CreateSpecies) error {
+	return s.createSpeciesHandler.Handle(ctx, &cmd)
+}
+
+// UpdateSpecies changes the names and aliases of a species
+func (s *SpeciesCatalogService) UpdateSpecies(ctx context.Context, cmd command.UpdateSpecies) error {
+	return s.updateSpeciesHandler.Handle(ctx, &cmd)
+}
+
+// DeactivateSpecies stops a species from being chosen for new pets
+func (s *SpeciesCatalogService) DeactivateSpecies(ctx context.Context, cmd command.DeactivateSpecies) error {
+	return s.deactivateSpeciesHandler.Handle(ctx, &cmd)
+}
+
+// AddBreed adds a breed to a species
+func (s *SpeciesCatalogService) AddBreed(ctx context.Context, cmd command.AddSpeciesBreed) error {
+	return s.addBreedHandler.Handle(ctx, &cmd)
+}
+
+// UpdateBreed changes a breed of a species
+func (s *SpeciesCatalogService) UpdateBreed(ctx context.Context, cmd command.UpdateSpeciesBreed) error {
+	return s.updateBreedHandler.Handle(ctx, &cmd)
+}
+
+// RemoveBreed removes a breed from a species
+func (s *SpeciesCatalogService) RemoveBreed(ctx context.Context, cmd command.RemoveSpeciesBreed) error {
+	return s.removeBreedHandler.Handle(ctx, &cmd)
+}
+
+// Query operations
+
+// ListSpecies lists the catalogue with names in the requested language
+func (s *SpeciesCatalogService) ListSpecies(ctx context.Context, includeInactive bool, lang string) ([]CatalogSpecies, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	species, err := uow.SpeciesRepository().List(ctx, includeInactive)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list species")
+	}
+
+	result := make([]CatalogSpecies, 0, len(species))
+	for _, entry := range species {
+		result = append(result, toCatalogSpecies(entry, lang))
+	}
+	return result, nil
+}
+
+// GetSpecies looks a species up by its code, name or alias
+func (s *SpeciesCatalogService) GetSpecies(ctx context.Context, term, lang string) (*CatalogSpecies, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	species, err := uow.SpeciesRepository().GetByTerm(ctx, term)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to get species")
+	}
+	if species == nil {
+		return nil, errors.NewNotFoundError("species")
+	}
+
+	result := toCatalogSpecies(species, lang)
+	return &result, nil
+}
+
+// SeedDefaults fills an empty catalogue with the species most pets on the platform belong to
+func (s *SpeciesCatalogService) SeedDefaults(ctx context.Context) error {
+	existing, err := s.ListSpecies(ctx, true, CatalogLanguageEnglish)
+	if err != nil {
+		return fmt.Errorf("failed to check species catalogue: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	for _, species := range defaultSpeciesCatalogue {
+		if err := s.createSpeciesHandler.Handle(ctx, &species); err != nil {
+			return fmt.Errorf("failed to seed species %s: %w", species.Code, err)
+		}
+	}
+	return nil
+}
+
+// toCatalogSpecies converts a species aggregate to its catalogue view
+func toCatalogSpecies(species *aggregate.Species, lang string) CatalogSpecies {
+	breeds := make([]CatalogBreed, 0, len(species.Breeds()))
+	for _, breed := range species.Breeds() {
+		breeds = append(breeds, toCatalogBreed(breed, lang))
+	}
+
+	return CatalogSpecies{
+		Code:      species.ID(),
+		Name:      localizedName(species.NameEn(), species.NameVi(), lang),
+		NameEn:    species.NameEn(),
+		NameVi:    species.NameVi(),
+		Aliases:   species.Aliases(),
+		Breeds:    breeds,
+		IsActive:  species.IsActive(),
+		UpdatedAt: species.UpdatedAt(),
+	}
+}
+
+func toCatalogBreed(breed event.SpeciesBreed, lang string) CatalogBreed {
+	aliases := breed.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return CatalogBreed{
+		Code:    breed.Code,
+		Name:    localizedName(breed.NameEn, breed.NameVi, lang),
+		NameEn:  breed.NameEn,
+		NameVi:  breed.NameVi,
+		Aliases: aliases,
+		Size:    breed.Size,
+	}
+}
+
+// localizedName picks the Vietnamese name for "vi" and the English name otherwise
+func localizedName(nameEn, nameVi, lang string) string {
+	if lang == CatalogLanguageVietnamese {
+		return nameVi
+	}
+	return nameEn
+}
+
+// defaultSpeciesCatalogue is seeded into an empty catalogue on startup
+var defaultSpeciesCatalogue = []command.CreateSpecies{
+	{
+		Code:    "dog",
+		NameEn:  "Dog",
+		NameVi:  "Chó",
+		Aliases: []string{"dogs", "puppy", "cún", "cẩu"},
+		Breeds: []command.SpeciesBreedData{
+			{Code: "mixed", NameEn: "Mixed breed", NameVi: "Chó lai", Aliases: []string{"mix", "lai"}},
+			{Code: "vietnamese_native", NameEn: "Vietnamese native dog", NameVi: "Chó ta", Aliases: []string{"chó cỏ", "native"}, Size: aggregate.PetSizeMedium},
+			{Code: "phu_quoc_ridgeback", NameEn: "Phu Quoc Ridgeback", NameVi: "Chó Phú Quốc", Aliases: []string{"phú quốc"}, Size: aggregate.PetSizeMedium},
+			{Code: "poodle", NameEn: "Poodle", NameVi: "Chó Poodle", Aliases: []string{"toy poodle"}, Size: aggregate.PetSizeSmall},
+			{Code: "pomeranian", NameEn: "Pomeranian", NameVi: "Chó Phốc sóc", Aliases: []string{"pom", "phốc sóc"}, Size: aggregate.PetSizeSmall},
+			{Code: "chihuahua", NameEn: "Chihuahua", NameVi: "Chó Chihuahua", Size: aggregate.PetSizeSmall},
+			{Code: "corgi", NameEn: "Corgi", NameVi: "Chó Corgi", Aliases: []string{"pembroke welsh corgi"}, Size: aggregate.PetSizeMedium},
+			{Code: "shiba_inu", NameEn: "Shiba Inu", NameVi: "Chó Shiba", Aliases: []string{"shiba"}, Size: aggregate.PetSizeMedium},
+			{Code: "siberian_husky", NameEn: "Siberian Husky", NameVi: "Chó Husky", Aliases: []string{"husky"}, Size: aggregate.PetSizeMedium},
+			{Code: "golden_retriever", NameEn: "Golden Retriever", NameVi: "Chó Golden", Aliases: []string{"golden"}, Size: aggregate.PetSizeLarge},
+			{Code: "labrador_retriever", NameEn: "Labrador Retriever", NameVi: "Chó Labrador", Aliases: []string{"labrador", "lab"}, Size: aggregate.PetSizeLarge},
+			{Code: "alaskan_malamute", NameEn: "Alaskan Malamute", NameVi: "Chó Alaska", Aliases: []string{"alaska", "malamute"}, Size: aggregate.PetSizeLarge},
+		},
+	},
+	{
+		Code:    "cat",
+		NameEn:  "Cat",
+		NameVi:  "Mèo",
+		Aliases: []string{"cats", "kitten"},
+		Breeds: []command.SpeciesBreedData{
+			{Code: "mixed", NameEn: "Mixed breed", NameVi: "Mèo lai", Aliases: []string{"mix", "lai"}},
+			{Code: "vietnamese_native", NameEn: "Vietnamese native cat", NameVi: "Mèo ta", Aliases: []string{"native"}, Size: aggregate.PetSizeSmall},
+			{Code: "british_shorthair", NameEn: "British Shorthair", NameVi: "Mèo Anh lông ngắn", Aliases: []string{"british", "aln"}, Size: aggregate.PetSizeSmall},
+			{Code: "persian", NameEn: "Persian", NameVi: "Mèo Ba Tư", Size: aggregate.PetSizeSmall},
+			{Code: "scottish_fold", NameEn: "Scottish Fold", NameVi: "Mèo tai cụp", Size: aggregate.PetSizeSmall},
+			{Code: "siamese", NameEn: "Siamese", NameVi: "Mèo Xiêm", Size: aggregate.PetSizeSmall},
+			{Code: "maine_coon", NameEn: "Maine Coon", NameVi: "Mèo Maine Coon", Size: aggregate.PetSizeMedium},
+		},
+	},
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/infrastructure/bus"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/errors"
+)
+
+// Care item statuses
+const (
+	CareStatusOverdue  = "OVERDUE"
+	CareStatusDueSoon  = "DUE_SOON"
+	CareStatusUpcoming = "UPCOMING"
+)
+
+// ReminderLog records sent reminders so that each reminder fires only once
+type ReminderLog interface {
+	MarkSent(ctx context.Context, key string, sentAt time.Time) (bool, error)
+	Unmark(ctx context.Context, key string) error
+}
+
+// UpcomingCareItem is a vaccination that is due or coming up for a pet
+type UpcomingCareItem struct {
+	PetID        string    `json:"pet_id"`
+	PetName      string    `json:"pet_name"`
+	RecordID     string    `json:"record_id"`
+	VaccineName  string    `json:"vaccine_name"`
+	LastGiven    time.Time `json:"last_given"`
+	DueDate      time.Time `json:"due_date"`
+	DaysUntilDue int       `json:"days_until_due"` // Negative when overdue
+	Status       string    `json:"status"`
+}
+
+// CareDigest groups the upcoming care of all of a user's pets
+type CareDigest struct {
+	UserID      string             `json:"user_id"`
+	Overdue     []UpcomingCareItem `json:"overdue"`
+	DueSoon     []UpcomingCareItem `json:"due_soon"`
+	Upcoming    []UpcomingCareItem `json:"upcoming"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// VaccinationReminderService reminds owners of vaccinations that fall due. A VaccinationDueSoon event is
+// raised when a vaccination comes within one of the reminder offsets (e.g. 14 and 3 days before it is due)
+// and a VaccinationOverdue event once its due date has passed.
+type VaccinationReminderService struct {
+	petProjection projection.PetProjection
+	eventBus      bus.EventBus
+	reminderLog   ReminderLog
+	offsets       []int // Days before the due date, largest first
+	stopChan      chan struct{}
+}
+
+// NewVaccinationReminderService creates a new vaccination reminder service
+func NewVaccinationReminderService(petProjection projection.PetProjection, eventBus bus.EventBus, reminderLog ReminderLog, offsets []int) *VaccinationReminderService {
+	sorted := append([]int(nil), offsets...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	return &VaccinationReminderService{
+		petProjection: petProjection,
+		eventBus:      eventBus,
+		reminderLog:   reminderLog,
+		offsets:       sorted,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins the background job that sends vaccination reminders
+func (s *VaccinationReminderService) Start(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Hour) // Check every hour
+	defer ticker.Stop()
+
+	fmt.Printf("✅ Vaccination reminder service started (checking every 1 hour, offsets %v days)\n", s.offsets)
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.SendDueReminders(ctx); err != nil {
+				fmt.Printf("❌ Error sending vaccination reminders: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Vaccination reminder service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Vaccination reminder service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *VaccinationReminderService) Stop() {
+	close(s.stopChan)
+}
+
+// SendDueReminders raises the reminders that are due and have not been sent yet, returning how many were sent
+func (s *VaccinationReminderService) SendDueReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+	pets, err := s.petProjection.ListWithVaccinationsDueBefore(ctx, now.AddDate(0, 0, s.maxOffset()+1))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, pet := range pets {
+		for _, record := range currentVaccinations(pet) {
+			days := daysUntil(now, record.NextDueDate)
+
+			var stage string
+			var reminder event.DomainEvent
+			if days < 0 {
+				stage = "overdue"
+				reminder = &event.VaccinationOverdue{
+					PetID:       pet.ID,
+					UserID:      pet.UserID,
+					PetName:     pet.Name,
+					RecordID:    record.ID,
+					VaccineName: record.VaccineName,
+					DueDate:     record.NextDueDate,
+					DaysOverdue: -days,
+					Timestamp:   now,
+				}
+			} else {
+				offset, ok := s.reminderOffset(days)
+				if !ok {
+					continue
+				}
+				stage = fmt.Sprintf("due-%dd", offset)
+				reminder = &event.VaccinationDueSoon{
+					PetID:          pet.ID,
+					UserID:         pet.UserID,
+					PetName:        pet.Name,
+					RecordID:       record.ID,
+					VaccineName:    record.VaccineName,
+					DueDate:        record.NextDueDate,
+					DaysUntilDue:   days,
+					ReminderOffset: offset,
+					Timestamp:      now,
+				}
+			}
+
+			// The due date is part of the key so a changed due date gets its own reminders
+			key := fmt.Sprintf("vaccination:%s:%s:%s:%s", pet.ID, record.ID, record.NextDueDate.Format("2006-01-02"), stage)
+			first, err := s.reminderLog.MarkSent(ctx, key, now)
+			if err != nil {
+				fmt.Printf("⚠️  Failed to record reminder %s: %v\n", key, err)
+				continue
+			}
+			if !first {
+				continue
+			}
+
+			if err := s.eventBus.Publish(ctx, reminder); err != nil {
+				fmt.Printf("Warning: failed to publish %s for pet %s: %v\n", reminder.EventType(), pet.ID, err)
+				// Forget the reminder so the next run sends it
+				if err := s.reminderLog.Unmark(ctx, key); err != nil {
+					fmt.Printf("⚠️  Failed to forget reminder %s: %v\n", key, err)
+				}
+				continue
+			}
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		fmt.Printf("✅ Sent %d vaccination reminder(s)\n", sent)
+	}
+
+	return sent, nil
+}
+
+// UpcomingForPet lists the pet's overdue vaccinations and those due within the given number of days.
+// Available to guardians of the pet and admins.
+func (s *VaccinationReminderService) UpcomingForPet(ctx context.Context, petID, requesterID string, isAdmin bool, withinDays int) ([]UpcomingCareItem, error) {
+	pet, err := s.petProjection.GetByID(ctx, petID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("pet")
+	}
+	if !isAdmin && pet.GuardianRole(requesterID) == "" {
+		return nil, errors.NewForbiddenError("only guardians of this pet can see its upcoming care")
+	}
+
+	return s.upcomingCare(pet, time.Now(), withinDays), nil
+}
+
+// DigestForUser groups the upcoming care of all the user's active pets. Available to the user and admins.
+func (s *VaccinationReminderService) DigestForUser(ctx context.Context, userID, requesterID string, isAdmin bool, withinDays int) (*CareDigest, error) {
+	if !isAdmin && requesterID != userID {
+		return nil, errors.NewForbiddenError("you can only see the upcoming care of your own pets")
+	}
+
+	pets, err := s.petProjection.GetByUserID(ctx, userID, 0, 1000)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list pets")
+	}
+
+	now := time.Now()
+	digest := &CareDigest{
+		UserID:      userID,
+		Overdue:     []UpcomingCareItem{},
+		DueSoon:     []UpcomingCareItem{},
+		Upcoming:    []UpcomingCareItem{},
+		GeneratedAt: now,
+	}
+
+	var items []UpcomingCareItem
+	for _, pet := range pets {
+		items = append(items, s.upcomingCare(pet, now, withinDays)...)
+	}
+	sortCareItems(items)
+
+	for _, item := range items {
+		switch item.Status {
+		case CareStatusOverdue:
+			digest.Overdue = append(digest.Overdue, item)
+		case CareStatusDueSoon:
+			digest.DueSoon = append(digest.DueSoon, item)
+		default:
+			digest.Upcoming = append(digest.Upcoming, item)
+		}
+	}
+
+	return digest, nil
+}
+
+// upcomingCare builds the care items of a pet, soonest first
+func (s *VaccinationReminderService) upcomingCare(pet *projection.PetReadModel, now time.Time, withinDays int) []UpcomingCareItem {
+	items := []UpcomingCareItem{}
+	for _, record := range currentVaccinations(pet) {
+		days := daysUntil(now, record.NextDueDate)
+		if days > withinDays {
+			continue
+		}
+
+		status := CareStatusUpcoming
+		if days < 0 {
+			status = CareStatusOverdue
+		} else if days <= s.maxOffset() {
+			status = CareStatusDueSoon
+		}
+
+		items = append(items, UpcomingCareItem{
+			PetID:        pet.ID,
+			PetName:      pet.Name,
+			RecordID:     record.ID,
+			VaccineName:  record.VaccineName,
+			LastGiven:    record.Date,
+			DueDate:      record.NextDueDate,
+			DaysUntilDue: days,
+			Status:       status,
+		})
+	}
+
+	sortCareItems(items)
+	return items
+}
+
+// reminderOffset returns the closest reminder offset that a vaccination due in the given number of days has reached.
+// Only the closest one fires, so a vaccination recorded 2 days before it is due does not also get the 14-day reminder.
+func (s *VaccinationReminderService) reminderOffset(days int) (int, bool) {
+	for i := len(s.offsets) - 1; i >= 0; i-- {
+		if days <= s.offsets[i] {
+			return s.offsets[i], true
+		}
+	}
+	return 0, false
+}
+
+func (s *VaccinationReminderService) maxOffset() int {
+	if len(s.offsets) == 0 {
+		return 0
+	}
+	return s.offsets[0]
+}
+
+// currentVaccinations returns the latest record of each vaccine that has a next due date. Older records of the
+// same vaccine are superseded by the later shot and are no longer due; voided records are ignored.
+func currentVaccinations(pet *projection.PetReadModel) []projection.VaccinationRecordView {
+	latest := make(map[string]projection.VaccinationRecordView)
+	var order []string
+	for _, record := range pet.VaccinationRecords {
+		if record.Voided {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(record.VaccineName))
+		current, seen := latest[name]
+		if !seen {
+			order = append(order, name)
+		}
+		if !seen || !record.Date.Before(current.Date) {
+			latest[name] = record
+		}
+	}
+
+	var records []projection.VaccinationRecordView
+	for _, name := range order {
+		if record := latest[name]; !record.NextDueDate.IsZero() {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// daysUntil counts calendar days from now until the due date; negative when the date has passed
+func daysUntil(now, due time.Time) int {
+	due = due.In(now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
+	return int(math.Round(dueDay.Sub(today).Hours() / 24))
+}
+
+func sortCareItems(items []UpcomingCareItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DueDate.Before(items[j].DueDate)
+	})
+}
@@ -14,6 +14,9 @@ type VendorService struct {
 	deleteVendorHandler        *command.DeleteVendorWithUoWHandler
 	updateVendorImageHandler   *command.UpdateVendorImageWithUoWHandler
 	updateVendorBankHandler    *command.UpdateVendorBankAccountWithUoWHandler
+	updateSettlementHandler    *command.UpdateVendorSettlementSettingsWithUoWHandler
+	updatePaymentMethodsHandler *command.UpdateVendorPaymentMethodsWithUoWHandler
+	updateReschedulePolicyHandler *command.UpdateVendorReschedulePolicyWithUoWHandler
 	getVendorHandler           *query.GetVendorHandler
 	listVendorsHandler         *query.ListVendorsHandler
 }
@@ -25,6 +28,9 @@ func NewVendorService(
 	deleteVendorHandler *command.DeleteVendorWithUoWHandler,
 	updateVendorImageHandler *command.UpdateVendorImageWithUoWHandler,
 	updateVendorBankHandler *command.UpdateVendorBankAccountWithUoWHandler,
+	updateSettlementHandler *command.UpdateVendorSettlementSettingsWithUoWHandler,
+	updatePaymentMethodsHandler *command.UpdateVendorPaymentMethodsWithUoWHandler,
+	updateReschedulePolicyHandler *command.UpdateVendorReschedulePolicyWithUoWHandler,
 	getVendorHandler *query.GetVendorHandler,
 	listVendorsHandler *query.ListVendorsHandler,
 ) *VendorService {
@@ -34,6 +40,9 @@ func NewVendorService(
 		deleteVendorHandler:      deleteVendorHandler,
 		updateVendorImageHandler: updateVendorImageHandler,
 		updateVendorBankHandler:  updateVendorBankHandler,
+		updateSettlementHandler:  updateSettlementHandler,
+		updatePaymentMethodsHandler: updatePaymentMethodsHandler,
+		updateReschedulePolicyHandler: updateReschedulePolicyHandler,
 		getVendorHandler:         getVendorHandler,
 		listVendorsHandler:       listVendorsHandler,
 	}
@@ -73,3 +82,18 @@ func (s *VendorService) UpdateVendorImage(ctx context.Context, cmd command.Updat
 func (s *VendorService) UpdateVendorBankAccount(ctx context.Context, cmd command.UpdateVendorBankAccount) error {
 	return s.updateVendorBankHandler.Handle(ctx, &cmd)
 }
+
+// UpdateVendorSettlementSettings updates a vendor's settlement period and minimum payout amount
+func (s *VendorService) UpdateVendorSettlementSettings(ctx context.Context, cmd command.UpdateVendorSettlementSettings) error {
+	return s.updateSettlementHandler.Handle(ctx, &cmd)
+}
+
+// UpdateVendorPaymentMethods updates which payment methods customers can use to book a vendor
+func (s *VendorService) UpdateVendorPaymentMethods(ctx context.Context, cmd command.UpdateVendorPaymentMethods) error {
+	return s.updatePaymentMethodsHandler.Handle(ctx, &cmd)
+}
+
+// UpdateVendorReschedulePolicy updates how late and how often customers may reschedule bookings with a vendor
+func (s *VendorService) UpdateVendorReschedulePolicy(ctx context.Context, cmd command.UpdateVendorReschedulePolicy) error {
+	return s.updateReschedulePolicyHandler.Handle(ctx, &cmd)
+}
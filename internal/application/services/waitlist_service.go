@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+	"whisko-petcare/pkg/errors"
+)
+
+// WaitlistEntryView is a waitlist entry as shown to the customer
+type WaitlistEntryView struct {
+	ID          string               `json:"id"`
+	UserID      string               `json:"user_id"`
+	PetID       string               `json:"pet_id"`
+	VendorID    string               `json:"vendor_id"`
+	ServiceID   string               `json:"service_id"`
+	WindowStart time.Time            `json:"window_start"`
+	WindowEnd   time.Time            `json:"window_end"`
+	Status      string               `json:"status"`
+	Offer       *event.WaitlistOffer `json:"offer,omitempty"` // Slot waiting to be claimed while OFFERED
+	PaymentID   string               `json:"payment_id,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// WaitlistService keeps customers waiting for fully booked slots and offers them the slots that free up
+// when bookings are cancelled or moved
+type WaitlistService struct {
+	uowFactory repository.UnitOfWorkFactory
+	stopChan   chan struct{}
+
+	joinHandler    *command.JoinWaitlistWithUoWHandler
+	offerHandler   *command.OfferWaitlistSlotWithUoWHandler
+	declineHandler *command.DeclineWaitlistOfferWithUoWHandler
+	leaveHandler   *command.LeaveWaitlistWithUoWHandler
+	claimHandler   *command.ClaimWaitlistOfferWithUoWHandler
+}
+
+// NewWaitlistService creates a new waitlist service
+func NewWaitlistService(
+	uowFactory repository.UnitOfWorkFactory,
+	joinHandler *command.JoinWaitlistWithUoWHandler,
+	offerHandler *command.OfferWaitlistSlotWithUoWHandler,
+	declineHandler *command.DeclineWaitlistOfferWithUoWHandler,
+	leaveHandler *command.LeaveWaitlistWithUoWHandler,
+	claimHandler *command.ClaimWaitlistOfferWithUoWHandler,
+) *WaitlistService {
+	return &WaitlistService{
+		uowFactory:     uowFactory,
+		stopChan:       make(chan struct{}),
+		joinHandler:    joinHandler,
+		offerHandler:   offerHandler,
+		declineHandler: declineHandler,
+		leaveHandler:   leaveHandler,
+		claimHandler:   claimHandler,
+	}
+}
+
+// Start begins the background job that passes on offers not claimed in time and closes entries whose
+// window has passed
+func (s *WaitlistService) Start(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute) // Check every minute
+	defer ticker.Stop()
+
+	fmt.Printf("✅ Waitlist service started (checking every 1 minute, offers last %s)\n", aggregate.WaitlistClaimWindow)
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.passLapsedOffers(ctx); err != nil {
+				fmt.Printf("❌ Error passing on lapsed waitlist offers: %v\n", err)
+			}
+			if err := s.expirePassedWindows(ctx); err != nil {
+				fmt.Printf("❌ Error expiring waitlist entries: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("⏹️  Waitlist service stopped")
+			return
+		case <-ctx.Done():
+			fmt.Println("⏹️  Waitlist service stopped (context done)")
+			return
+		}
+	}
+}
+
+// Stop stops the background job
+func (s *WaitlistService) Stop() {
+	close(s.stopChan)
+}
+
+// passLapsedOffers offers the slots that were not claimed in time to the next customers in line
+func (s *WaitlistService) passLapsedOffers(ctx context.Context) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	lapsed, err := uow.WaitlistRepository().GetLapsedOffers(ctx, time.Now())
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get lapsed waitlist offers: %w", err)
+	}
+
+	for _, entry := range lapsed {
+		if err := s.declineHandler.Lapse(ctx, entry.ID()); err != nil {
+			fmt.Printf("⚠️  Failed to pass on lapsed offer of waitlist entry %s: %v\n", entry.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// expirePassedWindows closes the waiting entries whose time window has passed
+func (s *WaitlistService) expirePassedWindows(ctx context.Context) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	passed, err := uow.WaitlistRepository().GetPassedWindows(ctx, time.Now())
+	uow.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get passed waitlist windows: %w", err)
+	}
+
+	expiredCount := 0
+	for _, entry := range passed {
+		if err := s.leaveHandler.Expire(ctx, entry.ID()); err != nil {
+			fmt.Printf("⚠️  Failed to expire waitlist entry %s: %v\n", entry.ID(), err)
+			continue
+		}
+		expiredCount++
+	}
+
+	if expiredCount > 0 {
+		fmt.Printf("⏰ Expired %d waitlist entrie(s)\n", expiredCount)
+	}
+
+	return nil
+}
+
+// HandleScheduleCancelled offers the time of a cancelled booking to the waitlist. Errors are logged and
+// never fail the cancellation.
+func (s *WaitlistService) HandleScheduleCancelled(ctx context.Context, e *event.ScheduleCancelled) error {
+	uow := s.uowFactory.CreateUnitOfWork()
+	schedule, err := uow.ScheduleRepository().GetByID(ctx, e.ScheduleID)
+	uow.Close()
+	if err != nil {
+		fmt.Printf("⚠️  Failed to get cancelled schedule %s for the waitlist: %v\n", e.ScheduleID, err)
+		return nil
+	}
+
+	s.offerFreedSlot(ctx, schedule, schedule.StartTime(), schedule.EndTime())
+	return nil
+}
+
+// HandleScheduleRescheduled offers the time a booking moved away from to the waitlist. Errors are logged
+// and never fail the reschedule.
+func (s *WaitlistService) HandleScheduleRescheduled(ctx context.Context, e *event.ScheduleRescheduled) error {
+	// The booking still takes part of its old time
+	if e.NewStartTime.Before(e.OldEndTime) && e.NewEndTime.After(e.OldStartTime) {
+		return nil
+	}
+
+	uow := s.uowFactory.CreateUnitOfWork()
+	schedule, err := uow.ScheduleRepository().GetByID(ctx, e.ScheduleID)
+	uow.Close()
+	if err != nil {
+		fmt.Printf("⚠️  Failed to get rescheduled schedule %s for the waitlist: %v\n", e.ScheduleID, err)
+		return nil
+	}
+
+	s.offerFreedSlot(ctx, schedule, e.OldStartTime, e.OldEndTime)
+	return nil
+}
+
+// offerFreedSlot offers the freed time of a booking to the first customer waiting for one of its services
+func (s *WaitlistService) offerFreedSlot(ctx context.Context, schedule *aggregate.Schedule, startTime, endTime time.Time) {
+	shop := schedule.BookedShop()
+	serviceIDs := make([]string, 0, len(shop.BookedServices))
+	for _, service := range shop.BookedServices {
+		serviceIDs = append(serviceIDs, service.ServiceID)
+	}
+	if len(serviceIDs) == 0 {
+		return
+	}
+
+	entryID, err := s.offerHandler.Handle(ctx, &command.OfferWaitlistSlot{
+		VendorID:        shop.ShopID,
+		ServiceIDs:      serviceIDs,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		FreedScheduleID: schedule.ID(),
+	})
+	if err != nil {
+		fmt.Printf("❌ Failed to offer freed slot of schedule %s to the waitlist: %v\n", schedule.ID(), err)
+		return
+	}
+	if entryID != "" {
+		fmt.Printf("📨 Freed slot of schedule %s offered to waitlist entry %s\n", schedule.ID(), entryID)
+	}
+}
+
+// Command operations
+
+// Join puts the customer on the waitlist and returns the entry
+func (s *WaitlistService) Join(ctx context.Context, cmd command.JoinWaitlist) (*WaitlistEntryView, error) {
+	entryID, err := s.joinHandler.Handle(ctx, &cmd)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEntry(ctx, entryID, cmd.UserID, false)
+}
+
+// Leave takes the customer off the waitlist
+func (s *WaitlistService) Leave(ctx context.Context, cmd command.LeaveWaitlist) error {
+	return s.leaveHandler.Handle(ctx, &cmd)
+}
+
+// Decline turns down the offered slot; the customer keeps waiting for later slots
+func (s *WaitlistService) Decline(ctx context.Context, cmd command.DeclineWaitlistOffer) error {
+	return s.declineHandler.Handle(ctx, &cmd)
+}
+
+// Claim checks out the offered slot. The slot stays held for the customer until the payment expires.
+func (s *WaitlistService) Claim(ctx context.Context, cmd command.ClaimWaitlistOffer) (*command.CreatePaymentResponse, error) {
+	return s.claimHandler.Handle(ctx, &cmd)
+}
+
+// Query operations
+
+// GetEntry gets a waitlist entry. Only the customer on the waitlist and admins can see it.
+func (s *WaitlistService) GetEntry(ctx context.Context, entryID, userID string, isAdmin bool) (*WaitlistEntryView, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	entry, err := uow.WaitlistRepository().GetByID(ctx, entryID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("waitlist entry")
+	}
+	if !isAdmin && entry.UserID() != userID {
+		return nil, errors.NewForbiddenError("you cannot view this waitlist entry")
+	}
+
+	view := toWaitlistEntryView(entry)
+	return &view, nil
+}
+
+// ListUserEntries lists a customer's waitlist entries, newest first
+func (s *WaitlistService) ListUserEntries(ctx context.Context, userID string, offset, limit int) ([]WaitlistEntryView, error) {
+	uow := s.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	entries, err := uow.WaitlistRepository().GetByUserID(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to list waitlist entries")
+	}
+
+	result := make([]WaitlistEntryView, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, toWaitlistEntryView(entry))
+	}
+	return result, nil
+}
+
+// toWaitlistEntryView converts a waitlist entry aggregate to its view
+func toWaitlistEntryView(entry *aggregate.WaitlistEntry) WaitlistEntryView {
+	view := WaitlistEntryView{
+		ID:          entry.ID(),
+		UserID:      entry.UserID(),
+		PetID:       entry.PetID(),
+		VendorID:    entry.VendorID(),
+		ServiceID:   entry.ServiceID(),
+		WindowStart: entry.WindowStart(),
+		WindowEnd:   entry.WindowEnd(),
+		Status:      string(entry.Status()),
+		PaymentID:   entry.PaymentID(),
+		CreatedAt:   entry.CreatedAt(),
+		UpdatedAt:   entry.UpdatedAt(),
+	}
+	if entry.Status() == aggregate.WaitlistStatusOffered {
+		view.Offer = entry.Offer()
+	}
+	return view
+}
@@ -0,0 +1,707 @@
+package aggregate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+
+	"github.com/google/uuid"
+)
+
+// Recurrence frequencies of booking series
+const (
+	RecurrenceDaily   = "DAILY"
+	RecurrenceWeekly  = "WEEKLY"
+	RecurrenceMonthly = "MONTHLY"
+)
+
+// Limits of booking series
+const (
+	MaxUpfrontSeriesOccurrences = 52                  // Occurrences one upfront payment can cover
+	SeriesBookingHorizon        = 14 * 24 * time.Hour // How far ahead occurrences are booked
+)
+
+// SeriesPaymentMode is how the customer pays for a booking series
+type SeriesPaymentMode string
+
+const (
+	SeriesPaymentUpfront       SeriesPaymentMode = "UPFRONT"        // One payment for every occurrence when the series is set up
+	SeriesPaymentPerOccurrence SeriesPaymentMode = "PER_OCCURRENCE" // A payment for each occurrence when it is booked
+)
+
+// IsValid checks if the payment mode is supported
+func (m SeriesPaymentMode) IsValid() bool {
+	return m == SeriesPaymentUpfront || m == SeriesPaymentPerOccurrence
+}
+
+// BookingSeriesStatus is the status of a booking series
+type BookingSeriesStatus string
+
+const (
+	BookingSeriesStatusPendingPayment BookingSeriesStatus = "PENDING_PAYMENT" // Waiting for the upfront payment
+	BookingSeriesStatusActive         BookingSeriesStatus = "ACTIVE"
+	BookingSeriesStatusCancelled      BookingSeriesStatus = "CANCELLED"
+)
+
+// ParseRecurrenceRule parses the subset of an RFC 5545 RRULE that booking series support, such as
+// "FREQ=WEEKLY;INTERVAL=2;COUNT=10". FREQ is DAILY, WEEKLY or MONTHLY; INTERVAL defaults to 1; the series
+// ends after COUNT occurrences or at UNTIL (a date or UTC date-time), and runs until cancelled without either.
+func ParseRecurrenceRule(rule string) (event.SeriesRecurrence, error) {
+	recurrence := event.SeriesRecurrence{Interval: 1}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return recurrence, fmt.Errorf("recurrence rule cannot be empty")
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return recurrence, fmt.Errorf("invalid recurrence rule part: %s", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			recurrence.Frequency = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return recurrence, fmt.Errorf("invalid INTERVAL: %s", value)
+			}
+			recurrence.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return recurrence, fmt.Errorf("invalid COUNT: %s", value)
+			}
+			recurrence.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(value)
+			if err != nil {
+				return recurrence, err
+			}
+			recurrence.Until = until
+		default:
+			return recurrence, fmt.Errorf("recurrence rule part %s is not supported", key)
+		}
+	}
+
+	return recurrence, validateRecurrence(recurrence)
+}
+
+// FormatRecurrenceRule writes a recurrence as an RRULE
+func FormatRecurrenceRule(recurrence event.SeriesRecurrence) string {
+	parts := []string{"FREQ=" + recurrence.Frequency}
+	if recurrence.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(recurrence.Interval))
+	}
+	if recurrence.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(recurrence.Count))
+	}
+	if !recurrence.Until.IsZero() {
+		parts = append(parts, "UNTIL="+recurrence.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.Parse("20060102", value); err == nil {
+		// A date includes the whole day
+		return until.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL: %s", value)
+}
+
+func validateRecurrence(recurrence event.SeriesRecurrence) error {
+	switch recurrence.Frequency {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+	case "":
+		return fmt.Errorf("recurrence FREQ is required")
+	default:
+		return fmt.Errorf("recurrence FREQ %s is not supported", recurrence.Frequency)
+	}
+	if recurrence.Interval < 1 {
+		return fmt.Errorf("recurrence INTERVAL must be at least 1")
+	}
+	if recurrence.Count < 0 {
+		return fmt.Errorf("recurrence COUNT cannot be negative")
+	}
+	if recurrence.Count > 0 && !recurrence.Until.IsZero() {
+		return fmt.Errorf("recurrence cannot have both COUNT and UNTIL")
+	}
+	return nil
+}
+
+// SeriesOccurrencePlan is an occurrence of a series that is due to be booked, at its possibly modified time
+type SeriesOccurrencePlan struct {
+	OccurrenceStart time.Time
+	StartTime       time.Time
+	EndTime         time.Time
+}
+
+// BookingSeries is a recurring booking of the same services for a pet. Occurrences are booked as
+// individual schedules some time ahead; exceptions skip or move single occurrences.
+type BookingSeries struct {
+	id              string
+	userID          string
+	vendorID        string
+	petID           string
+	serviceIDs      []string
+	recurrence      event.SeriesRecurrence
+	firstStartTime  time.Time
+	firstEndTime    time.Time
+	paymentMode     SeriesPaymentMode
+	method          PaymentMethod
+	occurrencePrice int
+	paymentID       string // Upfront payment, once paid
+	status          BookingSeriesStatus
+	exceptions      []event.SeriesException
+	occurrences     []event.SeriesOccurrence
+	nextOccurrence  time.Time // First occurrence not booked yet; zero once every occurrence is booked
+	cancelledFrom   time.Time // Occurrences from this time are cancelled
+	version         int
+	createdAt       time.Time
+	updatedAt       time.Time
+
+	uncommittedEvents []event.DomainEvent
+}
+
+// NewBookingSeries sets up a recurring booking. Upfront series must end, and are paid for before any
+// occurrence is booked; per occurrence series are active straight away.
+func NewBookingSeries(userID, vendorID, petID string, serviceIDs []string, firstStartTime, firstEndTime time.Time,
+	recurrence event.SeriesRecurrence, paymentMode SeriesPaymentMode, method PaymentMethod, occurrencePrice int) (*BookingSeries, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+	if vendorID == "" {
+		return nil, fmt.Errorf("vendorID cannot be empty")
+	}
+	if petID == "" {
+		return nil, fmt.Errorf("petID cannot be empty")
+	}
+	if len(serviceIDs) == 0 {
+		return nil, fmt.Errorf("serviceIDs cannot be empty")
+	}
+	if !firstStartTime.After(time.Now()) {
+		return nil, fmt.Errorf("the first occurrence must be in the future")
+	}
+	if !firstEndTime.After(firstStartTime) {
+		return nil, fmt.Errorf("endTime must be after startTime")
+	}
+	if err := validateRecurrence(recurrence); err != nil {
+		return nil, err
+	}
+	if !recurrence.Until.IsZero() && recurrence.Until.Before(firstStartTime) {
+		return nil, fmt.Errorf("recurrence UNTIL must not be before the first occurrence")
+	}
+	if !paymentMode.IsValid() {
+		return nil, fmt.Errorf("invalid payment mode: %s", paymentMode)
+	}
+	if !method.IsValid() {
+		return nil, fmt.Errorf("unsupported payment method: %s", method)
+	}
+	if occurrencePrice <= 0 {
+		return nil, fmt.Errorf("occurrence price must be greater than 0")
+	}
+
+	status := BookingSeriesStatusActive
+	if paymentMode == SeriesPaymentUpfront {
+		if recurrence.Count == 0 && recurrence.Until.IsZero() {
+			return nil, fmt.Errorf("a series paid upfront must have COUNT or UNTIL")
+		}
+		if method.IsCollectedByVendor() {
+			return nil, fmt.Errorf("a series paid upfront needs an online payment method")
+		}
+		status = BookingSeriesStatusPendingPayment
+	}
+
+	now := time.Now()
+	series := &BookingSeries{}
+	series.raiseEvent(&event.BookingSeriesCreated{
+		SeriesID:        uuid.New().String(),
+		UserID:          userID,
+		VendorID:        vendorID,
+		PetID:           petID,
+		ServiceIDs:      serviceIDs,
+		Recurrence:      recurrence,
+		FirstStartTime:  firstStartTime,
+		FirstEndTime:    firstEndTime,
+		PaymentMode:     string(paymentMode),
+		Method:          string(method),
+		OccurrencePrice: occurrencePrice,
+		Status:          string(status),
+		Timestamp:       now,
+	})
+
+	if paymentMode == SeriesPaymentUpfront && series.OccurrenceCount() > MaxUpfrontSeriesOccurrences {
+		return nil, fmt.Errorf("a series paid upfront can have at most %d occurrences", MaxUpfrontSeriesOccurrences)
+	}
+
+	return series, nil
+}
+
+// ReconstructBookingSeries rebuilds a booking series from stored state without raising events
+func ReconstructBookingSeries(id, userID, vendorID, petID string, serviceIDs []string, recurrence event.SeriesRecurrence,
+	firstStartTime, firstEndTime time.Time, paymentMode SeriesPaymentMode, method PaymentMethod, occurrencePrice int,
+	paymentID string, status BookingSeriesStatus, exceptions []event.SeriesException, occurrences []event.SeriesOccurrence,
+	nextOccurrence, cancelledFrom time.Time, version int, createdAt, updatedAt time.Time) *BookingSeries {
+	return &BookingSeries{
+		id:              id,
+		userID:          userID,
+		vendorID:        vendorID,
+		petID:           petID,
+		serviceIDs:      serviceIDs,
+		recurrence:      recurrence,
+		firstStartTime:  firstStartTime,
+		firstEndTime:    firstEndTime,
+		paymentMode:     paymentMode,
+		method:          method,
+		occurrencePrice: occurrencePrice,
+		paymentID:       paymentID,
+		status:          status,
+		exceptions:      exceptions,
+		occurrences:     occurrences,
+		nextOccurrence:  nextOccurrence,
+		cancelledFrom:   cancelledFrom,
+		version:         version,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+// Activate starts booking occurrences once the upfront payment is paid
+func (s *BookingSeries) Activate(paymentID string) error {
+	if s.status != BookingSeriesStatusPendingPayment {
+		return fmt.Errorf("cannot activate a %s series", s.status)
+	}
+	if paymentID == "" {
+		return fmt.Errorf("paymentID cannot be empty")
+	}
+
+	s.raiseEvent(&event.BookingSeriesActivated{
+		SeriesID:     s.id,
+		PaymentID:    paymentID,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// DueOccurrences returns the occurrences starting before the given time that are not booked yet,
+// leaving out skipped ones and moving modified ones to their new time
+func (s *BookingSeries) DueOccurrences(until time.Time) []SeriesOccurrencePlan {
+	if s.status != BookingSeriesStatusActive || s.nextOccurrence.IsZero() {
+		return nil
+	}
+
+	var plans []SeriesOccurrencePlan
+	s.forEachOccurrence(func(start time.Time) bool {
+		if !start.Before(until) {
+			return false
+		}
+		if start.Before(s.nextOccurrence) {
+			return true
+		}
+
+		plan := SeriesOccurrencePlan{
+			OccurrenceStart: start,
+			StartTime:       start,
+			EndTime:         start.Add(s.OccurrenceDuration()),
+		}
+		if exception, ok := s.Exception(start); ok {
+			if exception.Kind == event.SeriesExceptionSkip {
+				return true
+			}
+			plan.StartTime = exception.StartTime
+			plan.EndTime = exception.EndTime
+		}
+		plans = append(plans, plan)
+		return true
+	})
+
+	return plans
+}
+
+// RecordBookedOccurrences records the occurrences booked up to the given time. Nothing is recorded if
+// nothing changed.
+func (s *BookingSeries) RecordBookedOccurrences(booked []event.SeriesOccurrence, until time.Time) error {
+	if s.status != BookingSeriesStatusActive {
+		return fmt.Errorf("cannot book occurrences of a %s series", s.status)
+	}
+
+	var next time.Time
+	s.forEachOccurrence(func(start time.Time) bool {
+		if !start.Before(until) {
+			next = start
+			return false
+		}
+		return true
+	})
+	if len(booked) == 0 && next.Equal(s.nextOccurrence) {
+		return nil
+	}
+
+	s.raiseEvent(&event.BookingSeriesOccurrencesBooked{
+		SeriesID:       s.id,
+		Occurrences:    booked,
+		NextOccurrence: next,
+		EventVersion:   s.version + 1,
+		Timestamp:      time.Now(),
+	})
+
+	return nil
+}
+
+// SkipOccurrence skips one occurrence. The caller cancels its booking when the occurrence is already booked.
+func (s *BookingSeries) SkipOccurrence(occurrenceStart time.Time, skippedBy, reason string) error {
+	if err := s.checkException(occurrenceStart, skippedBy); err != nil {
+		return err
+	}
+
+	exception := event.SeriesException{
+		OccurrenceStart: occurrenceStart,
+		Kind:            event.SeriesExceptionSkip,
+		Reason:          reason,
+		CreatedBy:       skippedBy,
+	}
+	if booked, ok := s.BookedOccurrence(occurrenceStart); ok {
+		exception.ScheduleID = booked.ScheduleID
+	} else if existing, ok := s.Exception(occurrenceStart); ok && existing.Kind == event.SeriesExceptionModify {
+		if !existing.StartTime.After(time.Now()) {
+			return fmt.Errorf("occurrence has already started")
+		}
+	} else if !occurrenceStart.After(time.Now()) {
+		return fmt.Errorf("occurrence has already started")
+	}
+
+	s.addException(exception)
+	return nil
+}
+
+// ModifyOccurrence moves one occurrence that is not booked yet; booked occurrences are rescheduled
+// like any other booking
+func (s *BookingSeries) ModifyOccurrence(occurrenceStart, startTime, endTime time.Time, modifiedBy, reason string) error {
+	if err := s.checkException(occurrenceStart, modifiedBy); err != nil {
+		return err
+	}
+	if _, ok := s.BookedOccurrence(occurrenceStart); ok {
+		return fmt.Errorf("occurrence is already booked; reschedule its booking instead")
+	}
+	if !startTime.After(time.Now()) {
+		return fmt.Errorf("an occurrence can only be moved to a time in the future")
+	}
+	if !endTime.After(startTime) {
+		return fmt.Errorf("endTime must be after startTime")
+	}
+
+	s.addException(event.SeriesException{
+		OccurrenceStart: occurrenceStart,
+		Kind:            event.SeriesExceptionModify,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		Reason:          reason,
+		CreatedBy:       modifiedBy,
+	})
+	return nil
+}
+
+// CancelRemaining cancels the occurrences starting from the given time, or from now if it is earlier.
+// The caller cancels the bookings of occurrences already booked.
+func (s *BookingSeries) CancelRemaining(from time.Time, cancelledBy, reason string) error {
+	if s.status == BookingSeriesStatusCancelled {
+		return fmt.Errorf("series is already cancelled")
+	}
+	if cancelledBy == "" {
+		return fmt.Errorf("cancelledBy cannot be empty")
+	}
+
+	now := time.Now()
+	if from.Before(now) {
+		from = now
+	}
+	if !s.cancelledFrom.IsZero() && !from.Before(s.cancelledFrom) {
+		return fmt.Errorf("series is already cancelled from %s", s.cancelledFrom.Format(time.RFC3339))
+	}
+
+	s.raiseEvent(&event.BookingSeriesCancelled{
+		SeriesID:     s.id,
+		From:         from,
+		CancelledBy:  cancelledBy,
+		Reason:       reason,
+		EventVersion: s.version + 1,
+		Timestamp:    now,
+	})
+
+	return nil
+}
+
+// OccurrenceCount returns how many occurrences the series has, skipped ones included, or -1 when it
+// runs until cancelled
+func (s *BookingSeries) OccurrenceCount() int {
+	if s.IsOpenEnded() {
+		return -1
+	}
+
+	count := 0
+	s.forEachOccurrence(func(time.Time) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// UpfrontAmount returns what the customer pays upfront for every occurrence of the series
+func (s *BookingSeries) UpfrontAmount() int {
+	if s.paymentMode != SeriesPaymentUpfront {
+		return 0
+	}
+	return s.occurrencePrice * s.OccurrenceCount()
+}
+
+// RemainingOccurrences returns how many occurrences starting from the given time are not skipped, or -1
+// when the series runs until cancelled. With an upfront payment they are what is left to refund.
+func (s *BookingSeries) RemainingOccurrences(from time.Time) int {
+	if s.IsOpenEnded() {
+		return -1
+	}
+
+	count := 0
+	s.forEachOccurrence(func(start time.Time) bool {
+		if start.Before(from) {
+			return true
+		}
+		if exception, ok := s.Exception(start); !ok || exception.Kind != event.SeriesExceptionSkip {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// IsOpenEnded reports whether the series runs until it is cancelled
+func (s *BookingSeries) IsOpenEnded() bool {
+	return s.recurrence.Count == 0 && s.recurrence.Until.IsZero() && s.cancelledFrom.IsZero()
+}
+
+// BookedOccurrence finds the booking of an occurrence
+func (s *BookingSeries) BookedOccurrence(occurrenceStart time.Time) (event.SeriesOccurrence, bool) {
+	for _, occurrence := range s.occurrences {
+		if occurrence.OccurrenceStart.Equal(occurrenceStart) {
+			return occurrence, true
+		}
+	}
+	return event.SeriesOccurrence{}, false
+}
+
+// Exception finds the exception of an occurrence
+func (s *BookingSeries) Exception(occurrenceStart time.Time) (event.SeriesException, bool) {
+	for _, exception := range s.exceptions {
+		if exception.OccurrenceStart.Equal(occurrenceStart) {
+			return exception, true
+		}
+	}
+	return event.SeriesException{}, false
+}
+
+// IsOccurrence reports whether the recurrence gives an occurrence at the given start time
+func (s *BookingSeries) IsOccurrence(occurrenceStart time.Time) bool {
+	found := false
+	s.forEachOccurrence(func(start time.Time) bool {
+		if start.Equal(occurrenceStart) {
+			found = true
+		}
+		return start.Before(occurrenceStart)
+	})
+	return found
+}
+
+// OccurrenceDuration returns how long each occurrence takes
+func (s *BookingSeries) OccurrenceDuration() time.Duration {
+	return s.firstEndTime.Sub(s.firstStartTime)
+}
+
+// checkException checks that an exception can be added for the occurrence
+func (s *BookingSeries) checkException(occurrenceStart time.Time, createdBy string) error {
+	if s.status != BookingSeriesStatusActive {
+		return fmt.Errorf("cannot change occurrences of a %s series", s.status)
+	}
+	if createdBy == "" {
+		return fmt.Errorf("createdBy cannot be empty")
+	}
+	if !s.IsOccurrence(occurrenceStart) {
+		return fmt.Errorf("series has no occurrence at %s", occurrenceStart.Format(time.RFC3339))
+	}
+	if exception, ok := s.Exception(occurrenceStart); ok && exception.Kind == event.SeriesExceptionSkip {
+		return fmt.Errorf("occurrence is already skipped")
+	}
+	return nil
+}
+
+func (s *BookingSeries) addException(exception event.SeriesException) {
+	now := time.Now()
+	exception.CreatedAt = now
+
+	s.raiseEvent(&event.BookingSeriesExceptionAdded{
+		SeriesID:     s.id,
+		Exception:    exception,
+		EventVersion: s.version + 1,
+		Timestamp:    now,
+	})
+}
+
+// forEachOccurrence calls fn with the start time the recurrence gives each occurrence, in order, until fn
+// returns false or the series ends. Monthly occurrences on days a month does not have are left out.
+func (s *BookingSeries) forEachOccurrence(fn func(start time.Time) bool) {
+	count := 0
+	for i := 0; ; i++ {
+		var start time.Time
+		switch s.recurrence.Frequency {
+		case RecurrenceDaily:
+			start = s.firstStartTime.AddDate(0, 0, i*s.recurrence.Interval)
+		case RecurrenceWeekly:
+			start = s.firstStartTime.AddDate(0, 0, 7*i*s.recurrence.Interval)
+		case RecurrenceMonthly:
+			start = s.firstStartTime.AddDate(0, i*s.recurrence.Interval, 0)
+			if start.Day() != s.firstStartTime.Day() {
+				continue
+			}
+		default:
+			return
+		}
+
+		if s.recurrence.Count > 0 && count >= s.recurrence.Count {
+			return
+		}
+		if !s.recurrence.Until.IsZero() && start.After(s.recurrence.Until) {
+			return
+		}
+		if !s.cancelledFrom.IsZero() && !start.Before(s.cancelledFrom) {
+			return
+		}
+
+		count++
+		if !fn(start) {
+			return
+		}
+	}
+}
+
+func (s *BookingSeries) GetUncommittedEvents() []event.DomainEvent {
+	return s.uncommittedEvents
+}
+
+func (s *BookingSeries) raiseEvent(ev event.DomainEvent) {
+	s.uncommittedEvents = append(s.uncommittedEvents, ev)
+	s.applyEvent(ev)
+}
+
+func (s *BookingSeries) applyEvent(ev event.DomainEvent) error {
+	switch e := ev.(type) {
+	case *event.BookingSeriesCreated:
+		s.id = e.SeriesID
+		s.userID = e.UserID
+		s.vendorID = e.VendorID
+		s.petID = e.PetID
+		s.serviceIDs = e.ServiceIDs
+		s.recurrence = e.Recurrence
+		s.firstStartTime = e.FirstStartTime
+		s.firstEndTime = e.FirstEndTime
+		s.paymentMode = SeriesPaymentMode(e.PaymentMode)
+		s.method = PaymentMethod(e.Method)
+		s.occurrencePrice = e.OccurrencePrice
+		s.status = BookingSeriesStatus(e.Status)
+		s.nextOccurrence = e.FirstStartTime
+		s.version = 1
+		s.createdAt = e.Timestamp
+		s.updatedAt = e.Timestamp
+
+	case *event.BookingSeriesActivated:
+		s.paymentID = e.PaymentID
+		s.status = BookingSeriesStatusActive
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.BookingSeriesOccurrencesBooked:
+		s.occurrences = append(s.occurrences, e.Occurrences...)
+		s.nextOccurrence = e.NextOccurrence
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.BookingSeriesExceptionAdded:
+		exceptions := make([]event.SeriesException, 0, len(s.exceptions)+1)
+		for _, exception := range s.exceptions {
+			if !exception.OccurrenceStart.Equal(e.Exception.OccurrenceStart) {
+				exceptions = append(exceptions, exception)
+			}
+		}
+		s.exceptions = append(exceptions, e.Exception)
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.BookingSeriesCancelled:
+		// Occurrences before the cancellation are still booked as usual
+		s.cancelledFrom = e.From
+		if s.nextOccurrence.IsZero() || !s.nextOccurrence.Before(e.From) {
+			s.status = BookingSeriesStatusCancelled
+			s.nextOccurrence = time.Time{}
+		}
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	default:
+		return fmt.Errorf("unknown event type: %T", ev)
+	}
+
+	return nil
+}
+
+// Getters
+func (s *BookingSeries) ID() string                                  { return s.id }
+func (s *BookingSeries) UserID() string                              { return s.userID }
+func (s *BookingSeries) VendorID() string                            { return s.vendorID }
+func (s *BookingSeries) PetID() string                               { return s.petID }
+func (s *BookingSeries) ServiceIDs() []string                        { return s.serviceIDs }
+func (s *BookingSeries) Recurrence() event.SeriesRecurrence          { return s.recurrence }
+func (s *BookingSeries) FirstStartTime() time.Time                   { return s.firstStartTime }
+func (s *BookingSeries) FirstEndTime() time.Time                     { return s.firstEndTime }
+func (s *BookingSeries) PaymentMode() SeriesPaymentMode              { return s.paymentMode }
+func (s *BookingSeries) Method() PaymentMethod                       { return s.method }
+func (s *BookingSeries) OccurrencePrice() int                        { return s.occurrencePrice }
+func (s *BookingSeries) PaymentID() string                           { return s.paymentID }
+func (s *BookingSeries) Status() BookingSeriesStatus                 { return s.status }
+func (s *BookingSeries) Exceptions() []event.SeriesException         { return s.exceptions }
+func (s *BookingSeries) BookedOccurrences() []event.SeriesOccurrence { return s.occurrences }
+func (s *BookingSeries) NextOccurrence() time.Time                   { return s.nextOccurrence }
+func (s *BookingSeries) CancelledFrom() time.Time                    { return s.cancelledFrom }
+func (s *BookingSeries) Version() int                                { return s.version }
+func (s *BookingSeries) CreatedAt() time.Time                        { return s.createdAt }
+func (s *BookingSeries) UpdatedAt() time.Time                        { return s.updatedAt }
+
+// Entity interface implementation
+func (s *BookingSeries) GetID() string    { return s.id }
+func (s *BookingSeries) GetVersion() int  { return s.version }
+func (s *BookingSeries) SetVersion(v int) { s.version = v }
+
+// AggregateRoot interface implementation
+func (s *BookingSeries) MarkEventsAsCommitted() {
+	s.uncommittedEvents = nil
+}
+
+func (s *BookingSeries) LoadFromHistory(events []event.DomainEvent) error {
+	for _, e := range events {
+		if err := s.applyEvent(e); err != nil {
+			return fmt.Errorf("failed to apply event %s: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
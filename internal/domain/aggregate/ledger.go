@@ -0,0 +1,242 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+)
+
+// LedgerAccount represents an account in the platform's double-entry ledger
+type LedgerAccount string
+
+const (
+	LedgerAccountCustomerReceivables LedgerAccount = "CUSTOMER_RECEIVABLES" // Amounts charged to customers for bookings
+	LedgerAccountPlatformRevenue     LedgerAccount = "PLATFORM_REVENUE"     // Commission earned by the platform
+	LedgerAccountVendorPayables      LedgerAccount = "VENDOR_PAYABLES"      // Amounts owed to vendors (per vendor sub-account)
+	LedgerAccountPayOSClearing       LedgerAccount = "PAYOS_CLEARING"       // Funds held at PayOS between collection and payout
+)
+
+// IsValid checks if the ledger account is known
+func (a LedgerAccount) IsValid() bool {
+	switch a {
+	case LedgerAccountCustomerReceivables, LedgerAccountPlatformRevenue, LedgerAccountVendorPayables, LedgerAccountPayOSClearing:
+		return true
+	}
+	return false
+}
+
+// IsDebitNormal reports whether the account balance increases with debits (assets)
+func (a LedgerAccount) IsDebitNormal() bool {
+	return a == LedgerAccountCustomerReceivables || a == LedgerAccountPayOSClearing
+}
+
+// LedgerEntryType represents the business event that produced a ledger entry
+type LedgerEntryType string
+
+const (
+	LedgerEntryPaymentCaptured LedgerEntryType = "PAYMENT_CAPTURED" // Customer paid for a booking
+	LedgerEntryCommission      LedgerEntryType = "COMMISSION"       // Platform commission withheld from a vendor settlement
+	LedgerEntryPayoutCompleted LedgerEntryType = "PAYOUT_COMPLETED" // Vendor received a bank transfer
+	LedgerEntryRefund          LedgerEntryType = "REFUND"           // Customer was refunded for a paid booking
+)
+
+// LedgerPosting is a single debit or credit line of a ledger entry
+type LedgerPosting struct {
+	Account  LedgerAccount `json:"account" bson:"account"`
+	VendorID string        `json:"vendor_id,omitempty" bson:"vendor_id,omitempty"` // Set for vendor payable sub-accounts
+	Debit    int           `json:"debit" bson:"debit"`
+	Credit   int           `json:"credit" bson:"credit"`
+}
+
+// DebitPosting creates a debit line
+func DebitPosting(account LedgerAccount, vendorID string, amount int) LedgerPosting {
+	return LedgerPosting{Account: account, VendorID: vendorID, Debit: amount}
+}
+
+// CreditPosting creates a credit line
+func CreditPosting(account LedgerAccount, vendorID string, amount int) LedgerPosting {
+	return LedgerPosting{Account: account, VendorID: vendorID, Credit: amount}
+}
+
+// LedgerEntry is an immutable, balanced journal entry. Entries are only ever appended;
+// corrections are made with new entries, never by editing existing ones.
+type LedgerEntry struct {
+	id          string
+	entryType   LedgerEntryType
+	reference   string // Unique source reference, makes posting idempotent
+	description string
+	postings    []LedgerPosting
+	occurredAt  time.Time
+	createdAt   time.Time
+}
+
+// NewLedgerEntry creates a new ledger entry, rejecting entries whose debits and credits do not balance
+func NewLedgerEntry(id string, entryType LedgerEntryType, reference, description string, occurredAt time.Time, postings []LedgerPosting) (*LedgerEntry, error) {
+	if id == "" {
+		return nil, fmt.Errorf("ledger entry ID cannot be empty")
+	}
+	if reference == "" {
+		return nil, fmt.Errorf("ledger entry reference cannot be empty")
+	}
+	if len(postings) < 2 {
+		return nil, fmt.Errorf("ledger entry must have at least two postings")
+	}
+
+	for _, posting := range postings {
+		if !posting.Account.IsValid() {
+			return nil, fmt.Errorf("invalid ledger account: %s", posting.Account)
+		}
+		if posting.Debit < 0 || posting.Credit < 0 {
+			return nil, fmt.Errorf("posting amounts cannot be negative")
+		}
+		if (posting.Debit == 0) == (posting.Credit == 0) {
+			return nil, fmt.Errorf("posting must have either a debit or a credit")
+		}
+		if posting.Account == LedgerAccountVendorPayables && posting.VendorID == "" {
+			return nil, fmt.Errorf("vendor payable posting requires a vendor ID")
+		}
+	}
+
+	entry := &LedgerEntry{
+		id:          id,
+		entryType:   entryType,
+		reference:   reference,
+		description: description,
+		postings:    postings,
+		occurredAt:  occurredAt,
+		createdAt:   time.Now(),
+	}
+
+	if !entry.IsBalanced() {
+		return nil, fmt.Errorf("ledger entry is not balanced: debits %d, credits %d", entry.TotalDebit(), entry.TotalCredit())
+	}
+
+	return entry, nil
+}
+
+// ReconstructLedgerEntry rebuilds a ledger entry from database state
+func ReconstructLedgerEntry(id string, entryType LedgerEntryType, reference, description string, postings []LedgerPosting, occurredAt, createdAt time.Time) *LedgerEntry {
+	return &LedgerEntry{
+		id:          id,
+		entryType:   entryType,
+		reference:   reference,
+		description: description,
+		postings:    postings,
+		occurredAt:  occurredAt,
+		createdAt:   createdAt,
+	}
+}
+
+// NewPaymentCapturedEntry records a paid booking: the booking is charged to the customer and becomes
+// payable to the vendor, and the charge is settled by the payment collected into the PayOS clearing
+// account. A platform-funded discount is paid to the vendor out of platform revenue.
+func NewPaymentCapturedEntry(id, paymentID, vendorID string, amount, platformDiscount int, occurredAt time.Time) (*LedgerEntry, error) {
+	postings := []LedgerPosting{
+		DebitPosting(LedgerAccountCustomerReceivables, "", amount),
+		CreditPosting(LedgerAccountVendorPayables, vendorID, amount),
+		DebitPosting(LedgerAccountPayOSClearing, "", amount),
+		CreditPosting(LedgerAccountCustomerReceivables, "", amount),
+	}
+	if platformDiscount > 0 {
+		postings = append(postings,
+			DebitPosting(LedgerAccountPlatformRevenue, "", platformDiscount),
+			CreditPosting(LedgerAccountVendorPayables, vendorID, platformDiscount),
+		)
+	}
+
+	return NewLedgerEntry(id, LedgerEntryPaymentCaptured, "payment:"+paymentID+":captured",
+		fmt.Sprintf("Payment %s captured", paymentID), occurredAt, postings)
+}
+
+// NewCommissionEntry moves the platform commission of a settlement from the vendor's payable to revenue
+func NewCommissionEntry(id, settlementID, vendorID string, amount int, occurredAt time.Time) (*LedgerEntry, error) {
+	return NewLedgerEntry(id, LedgerEntryCommission, "settlement:"+settlementID+":commission",
+		fmt.Sprintf("Commission on settlement %s", settlementID), occurredAt,
+		[]LedgerPosting{
+			DebitPosting(LedgerAccountVendorPayables, vendorID, amount),
+			CreditPosting(LedgerAccountPlatformRevenue, "", amount),
+		})
+}
+
+// NewPayoutCompletedEntry settles the vendor's payable with funds leaving the PayOS clearing account
+func NewPayoutCompletedEntry(id, payoutID, vendorID string, amount int, occurredAt time.Time) (*LedgerEntry, error) {
+	return NewLedgerEntry(id, LedgerEntryPayoutCompleted, "payout:"+payoutID+":completed",
+		fmt.Sprintf("Payout %s completed", payoutID), occurredAt,
+		[]LedgerPosting{
+			DebitPosting(LedgerAccountVendorPayables, vendorID, amount),
+			CreditPosting(LedgerAccountPayOSClearing, "", amount),
+		})
+}
+
+// NewRefundEntry reverses a refunded amount out of the vendor's payable, returning the funds from clearing to the customer.
+// A reversed platform-funded discount moves back from the vendor's payable to platform revenue.
+func NewRefundEntry(id, refundID, paymentID, vendorID string, amount, discountReversed int, occurredAt time.Time) (*LedgerEntry, error) {
+	postings := []LedgerPosting{
+		DebitPosting(LedgerAccountVendorPayables, vendorID, amount),
+		CreditPosting(LedgerAccountPayOSClearing, "", amount),
+	}
+	if discountReversed > 0 {
+		postings = append(postings,
+			DebitPosting(LedgerAccountVendorPayables, vendorID, discountReversed),
+			CreditPosting(LedgerAccountPlatformRevenue, "", discountReversed),
+		)
+	}
+
+	return NewLedgerEntry(id, LedgerEntryRefund, "refund:"+refundID,
+		fmt.Sprintf("Refund %s of payment %s", refundID, paymentID), occurredAt, postings)
+}
+
+// TotalDebit returns the sum of all debit postings
+func (e *LedgerEntry) TotalDebit() int {
+	total := 0
+	for _, posting := range e.postings {
+		total += posting.Debit
+	}
+	return total
+}
+
+// TotalCredit returns the sum of all credit postings
+func (e *LedgerEntry) TotalCredit() int {
+	total := 0
+	for _, posting := range e.postings {
+		total += posting.Credit
+	}
+	return total
+}
+
+// IsBalanced checks that debits equal credits
+func (e *LedgerEntry) IsBalanced() bool {
+	return e.TotalDebit() == e.TotalCredit()
+}
+
+// Getters
+func (e *LedgerEntry) ID() string                 { return e.id }
+func (e *LedgerEntry) EntryType() LedgerEntryType { return e.entryType }
+func (e *LedgerEntry) Reference() string          { return e.reference }
+func (e *LedgerEntry) Description() string        { return e.description }
+func (e *LedgerEntry) Postings() []LedgerPosting  { return e.postings }
+func (e *LedgerEntry) OccurredAt() time.Time      { return e.occurredAt }
+func (e *LedgerEntry) CreatedAt() time.Time       { return e.createdAt }
+
+// LedgerBalance is the aggregated balance of an account (or a vendor's payable sub-account)
+type LedgerBalance struct {
+	Account  LedgerAccount `json:"account"`
+	VendorID string        `json:"vendor_id,omitempty"`
+	Debit    int           `json:"debit"`
+	Credit   int           `json:"credit"`
+	Balance  int           `json:"balance"` // Signed by the account's normal side
+}
+
+// NewLedgerBalance computes the normal-side balance from debit and credit totals
+func NewLedgerBalance(account LedgerAccount, vendorID string, debit, credit int) LedgerBalance {
+	balance := credit - debit
+	if account.IsDebitNormal() {
+		balance = debit - credit
+	}
+	return LedgerBalance{
+		Account:  account,
+		VendorID: vendorID,
+		Debit:    debit,
+		Credit:   credit,
+		Balance:  balance,
+	}
+}
@@ -17,18 +17,40 @@ const (
 	PaymentStatusCancelled PaymentStatus = "CANCELLED"
 	PaymentStatusExpired   PaymentStatus = "EXPIRED"
 	PaymentStatusFailed    PaymentStatus = "FAILED"
+	PaymentStatusRefunded  PaymentStatus = "REFUNDED" // Fully refunded after being paid
 )
 
 // PaymentMethod represents the payment method
 type PaymentMethod string
 
 const (
-	PaymentMethodPayOS PaymentMethod = "PAYOS"
+	PaymentMethodPayOS     PaymentMethod = "PAYOS"       // Online payment through a PayOS checkout link
+	PaymentMethodPayAtShop PaymentMethod = "PAY_AT_SHOP" // Offline payment collected by the vendor at the appointment
 )
 
+// IsValid checks if the payment method is supported
+func (m PaymentMethod) IsValid() bool {
+	return m == PaymentMethodPayOS || m == PaymentMethodPayAtShop
+}
+
+// IsCollectedByVendor reports whether the vendor collects the money directly instead of the platform
+func (m PaymentMethod) IsCollectedByVendor() bool {
+	return m == PaymentMethodPayAtShop
+}
+
 // PaymentItem represents an item in the payment
 type PaymentItem = event.PaymentItem
 
+// PaymentPetLine is one pet of a booking covering several pets
+type PaymentPetLine = event.PaymentPetLine
+
+// PendingRefund is a refund reserved on a payment that the payment gateway has not executed yet
+type PendingRefund struct {
+	RefundID string `json:"refund_id" bson:"refund_id"`
+	Amount   int    `json:"amount" bson:"amount"`
+	Reason   string `json:"reason" bson:"reason"`
+}
+
 // Payment represents a payment aggregate root
 type Payment struct {
 	id                 string
@@ -43,6 +65,13 @@ type Payment struct {
 	checkoutURL        string
 	qrCode             string
 	expiredAt          time.Time
+	refundedAmount     int
+	pendingRefund      *PendingRefund // Refund reserved on the payment that the gateway has not executed yet
+	collectedBy        string // Vendor staff or vendor that collected an offline payment
+	promotionID        string
+	promotionCode      string
+	discountAmount     int              // Promotion discount already deducted from amount
+	discountFundedBy   PromotionFunding // Who bears the discount
 	version            int
 	createdAt          time.Time
 	updatedAt          time.Time
@@ -53,12 +82,73 @@ type Payment struct {
 	serviceIDs         []string
 	startTime          time.Time
 	endTime            time.Time
+	scheduleID         string // Booking a top-up or occurrence payment is for; empty for payments that create a booking
+	seriesID           string // Recurring booking the payment is for
+	petLines           []PaymentPetLine // Set on bookings covering several pets; petID is then the first pet
 	
 	uncommittedEvents  []event.DomainEvent
 }
 
 // NewPayment creates a new payment aggregate with schedule information
-func NewPayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petID string, serviceIDs []string, startTime, endTime time.Time) (*Payment, error) {
+func NewPayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petID string, serviceIDs []string, startTime, endTime time.Time, method PaymentMethod) (*Payment, error) {
+	return newPayment(userID, amount, description, items, vendorID, petID, serviceIDs, startTime, endTime, method, "", "", nil)
+}
+
+// NewTopUpPayment creates a payment for the extra cost of an existing booking, such as a reschedule to a
+// more expensive slot. Paying it does not create a new booking.
+func NewTopUpPayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petID string, serviceIDs []string, startTime, endTime time.Time, method PaymentMethod, scheduleID string) (*Payment, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("scheduleID cannot be empty")
+	}
+	return newPayment(userID, amount, description, items, vendorID, petID, serviceIDs, startTime, endTime, method, scheduleID, "", nil)
+}
+
+// NewSeriesPayment creates the upfront payment for every occurrence of a recurring booking. The start and
+// end time are those of the first occurrence. Paying it activates the series instead of creating a booking.
+func NewSeriesPayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petID string, serviceIDs []string, startTime, endTime time.Time, method PaymentMethod, seriesID string) (*Payment, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("seriesID cannot be empty")
+	}
+	return newPayment(userID, amount, description, items, vendorID, petID, serviceIDs, startTime, endTime, method, "", seriesID, nil)
+}
+
+// NewOccurrencePayment creates the payment for one booked occurrence of a recurring booking paid per
+// occurrence. Online occurrence payments can be paid until the occurrence starts.
+func NewOccurrencePayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petID string, serviceIDs []string, startTime, endTime time.Time, method PaymentMethod, scheduleID, seriesID string) (*Payment, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("scheduleID cannot be empty")
+	}
+	if seriesID == "" {
+		return nil, fmt.Errorf("seriesID cannot be empty")
+	}
+	return newPayment(userID, amount, description, items, vendorID, petID, serviceIDs, startTime, endTime, method, scheduleID, seriesID, nil)
+}
+
+// NewMultiPetPayment creates the payment of a booking covering several pets, each with its own services.
+// The first pet becomes the booking's primary pet.
+func NewMultiPetPayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petLines []PaymentPetLine, startTime, endTime time.Time, method PaymentMethod) (*Payment, error) {
+	if len(petLines) == 0 {
+		return nil, fmt.Errorf("petLines cannot be empty")
+	}
+
+	seen := map[string]bool{}
+	var serviceIDs []string
+	for _, line := range petLines {
+		if len(line.ServiceIDs) == 0 {
+			return nil, fmt.Errorf("pet %s has no services", line.PetID)
+		}
+		for _, serviceID := range line.ServiceIDs {
+			if !seen[serviceID] {
+				seen[serviceID] = true
+				serviceIDs = append(serviceIDs, serviceID)
+			}
+		}
+	}
+
+	return newPayment(userID, amount, description, items, vendorID, petLines[0].PetID, serviceIDs, startTime, endTime, method, "", "", petLines)
+}
+
+func newPayment(userID string, amount int, description string, items []PaymentItem, vendorID string, petID string, serviceIDs []string, startTime, endTime time.Time, method PaymentMethod, scheduleID, seriesID string, petLines []PaymentPetLine) (*Payment, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("userID cannot be empty")
 	}
@@ -86,6 +176,18 @@ func NewPayment(userID string, amount int, description string, items []PaymentIt
 	if endTime.IsZero() {
 		return nil, fmt.Errorf("endTime cannot be empty")
 	}
+	if !method.IsValid() {
+		return nil, fmt.Errorf("unsupported payment method: %s", method)
+	}
+
+	// Online payments must be completed within 15 minutes; offline payments stay pending
+	// until the vendor collects them at the appointment
+	expiredAt := time.Now().Add(15 * time.Minute)
+	if method.IsCollectedByVendor() {
+		expiredAt = endTime
+	} else if scheduleID != "" && seriesID != "" {
+		expiredAt = startTime
+	}
 
 	// Generate unique order code (timestamp + random)
 	orderCode := time.Now().Unix()*1000 + int64(time.Now().Nanosecond()/1000000)
@@ -98,13 +200,16 @@ func NewPayment(userID string, amount int, description string, items []PaymentIt
 		description: description,
 		items:       items,
 		status:      PaymentStatusPending,
-		method:      PaymentMethodPayOS,
-		expiredAt:   time.Now().Add(15 * time.Minute), // Default 15 minutes expiration
+		method:      method,
+		expiredAt:   expiredAt,
 		vendorID:    vendorID,
 		petID:       petID,
 		serviceIDs:  serviceIDs,
 		startTime:   startTime,
 		endTime:     endTime,
+		scheduleID:  scheduleID,
+		seriesID:    seriesID,
+		petLines:    petLines,
 		version:     1,
 		createdAt:   time.Now(),
 		updatedAt:   time.Now(),
@@ -118,13 +223,16 @@ func NewPayment(userID string, amount int, description string, items []PaymentIt
 		Description: description,
 Items:       items,
 		Status:      string(PaymentStatusPending),
-		Method:      string(PaymentMethodPayOS),
+		Method:      string(method),
 		ExpiredAt:   payment.expiredAt,
 		VendorID:    vendorID,
 		PetID:       petID,
 		ServiceIDs:  serviceIDs,
 		StartTime:   startTime,
 		EndTime:     endTime,
+		ScheduleID:  scheduleID,
+		SeriesID:    seriesID,
+		PetLines:    petLines,
 		Timestamp:   payment.createdAt,
 	})
 
@@ -222,9 +330,298 @@ func (p *Payment) MarkAsPaid() error {
 	return nil
 }
 
+// MarkAsCollected marks an offline payment as paid once the vendor has collected it
+func (p *Payment) MarkAsCollected(collectedBy string) error {
+	if !p.method.IsCollectedByVendor() {
+		return fmt.Errorf("payment method %s is not collected by the vendor", p.method)
+	}
+	if p.status != PaymentStatusPending {
+		return fmt.Errorf("cannot collect payment with current status: %s", p.status)
+	}
+	if collectedBy == "" {
+		return fmt.Errorf("collectedBy cannot be empty")
+	}
+
+	p.status = PaymentStatusPaid
+	p.collectedBy = collectedBy
+	p.version++
+	p.updatedAt = time.Now()
+
+	p.raiseEvent(&event.PaymentCollected{
+		PaymentID:   p.id,
+		VendorID:    p.vendorID,
+		Amount:      p.amount,
+		CollectedBy: collectedBy,
+		Timestamp:   p.updatedAt,
+	})
+
+	p.raiseEvent(&event.PaymentStatusChanged{
+		PaymentID: p.id,
+		OldStatus: string(PaymentStatusPending),
+		NewStatus: string(PaymentStatusPaid),
+		Timestamp: p.updatedAt,
+	})
+
+	return nil
+}
+
+// RequestRefund reserves a refund of part or all of a paid payment before the payment gateway is asked
+// to execute it, so the refund is on record even when the gateway call fails or the process stops
+// halfway. The refund ID is sent to the gateway as idempotency key; only one refund can be in progress.
+func (p *Payment) RequestRefund(refundID string, amount int, reason string) error {
+	if p.status != PaymentStatusPaid {
+		return fmt.Errorf("cannot refund payment with current status: %s", p.status)
+	}
+	if p.pendingRefund != nil {
+		return fmt.Errorf("refund %s of payment is still in progress", p.pendingRefund.RefundID)
+	}
+	if refundID == "" {
+		return fmt.Errorf("refundID cannot be empty")
+	}
+	if amount <= 0 {
+		return fmt.Errorf("refund amount must be greater than 0")
+	}
+	if amount > p.RefundableAmount() {
+		return fmt.Errorf("refund amount (%d) exceeds refundable amount (%d)", amount, p.RefundableAmount())
+	}
+
+	p.pendingRefund = &PendingRefund{RefundID: refundID, Amount: amount, Reason: reason}
+	p.version++
+	p.updatedAt = time.Now()
+
+	p.raiseEvent(&event.PaymentRefundRequested{
+		PaymentID: p.id,
+		RefundID:  refundID,
+		Amount:    amount,
+		Reason:    reason,
+		Timestamp: p.updatedAt,
+	})
+
+	return nil
+}
+
+// Refund returns part or all of a paid payment to the customer. A refund reserved with RequestRefund is
+// completed by passing its ID and amount. The payment becomes REFUNDED once the full amount has been
+// refunded.
+func (p *Payment) Refund(refundID string, amount int, reason string) error {
+	if p.status != PaymentStatusPaid {
+		return fmt.Errorf("cannot refund payment with current status: %s", p.status)
+	}
+	if refundID == "" {
+		return fmt.Errorf("refundID cannot be empty")
+	}
+	if amount <= 0 {
+		return fmt.Errorf("refund amount must be greater than 0")
+	}
+	if p.pendingRefund != nil {
+		if p.pendingRefund.RefundID != refundID || p.pendingRefund.Amount != amount {
+			return fmt.Errorf("refund %s of payment is still in progress", p.pendingRefund.RefundID)
+		}
+		p.pendingRefund = nil
+	}
+	if amount > p.RefundableAmount() {
+		return fmt.Errorf("refund amount (%d) exceeds refundable amount (%d)", amount, p.RefundableAmount())
+	}
+
+	p.refundedAmount += amount
+	p.version++
+	p.updatedAt = time.Now()
+
+	// A full refund also takes back the platform-funded discount credited to the vendor
+	discountReversed := 0
+	if p.refundedAmount == p.amount {
+		discountReversed = p.PlatformFundedDiscount()
+	}
+
+	p.raiseEvent(&event.PaymentRefunded{
+		PaymentID:        p.id,
+		VendorID:         p.vendorID,
+		RefundID:         refundID,
+		Method:           string(p.method),
+		Amount:           amount,
+		RefundedAmount:   p.refundedAmount,
+		DiscountReversed: discountReversed,
+		Reason:           reason,
+		Timestamp:        p.updatedAt,
+	})
+
+	if p.refundedAmount == p.amount {
+		p.status = PaymentStatusRefunded
+
+		p.raiseEvent(&event.PaymentStatusChanged{
+			PaymentID: p.id,
+			OldStatus: string(PaymentStatusPaid),
+			NewStatus: string(PaymentStatusRefunded),
+			Timestamp: p.updatedAt,
+		})
+	}
+
+	return nil
+}
+
+// ApplyDiscount deducts a promotion discount from a pending payment. Must be applied before the
+// payment is sent to a gateway.
+func (p *Payment) ApplyDiscount(promotionID, promotionCode string, discount int, fundedBy PromotionFunding) error {
+	if p.status != PaymentStatusPending {
+		return fmt.Errorf("cannot apply discount to payment with status: %s", p.status)
+	}
+	if p.promotionID != "" {
+		return fmt.Errorf("payment already has a promotion applied")
+	}
+	if p.checkoutURL != "" {
+		return fmt.Errorf("cannot apply discount after checkout has started")
+	}
+	if promotionID == "" {
+		return fmt.Errorf("promotionID cannot be empty")
+	}
+	if !fundedBy.IsValid() {
+		return fmt.Errorf("invalid funding source: %s", fundedBy)
+	}
+	if discount <= 0 {
+		return fmt.Errorf("discount must be greater than 0")
+	}
+	if discount >= p.amount {
+		return fmt.Errorf("discount (%d) must be less than the payment amount (%d)", discount, p.amount)
+	}
+
+	p.amount -= discount
+	p.promotionID = promotionID
+	p.promotionCode = promotionCode
+	p.discountAmount = discount
+	p.discountFundedBy = fundedBy
+	p.version++
+	p.updatedAt = time.Now()
+
+	p.raiseEvent(&event.PaymentDiscountApplied{
+		PaymentID:      p.id,
+		PromotionID:    promotionID,
+		PromotionCode:  promotionCode,
+		DiscountAmount: discount,
+		FundedBy:       string(fundedBy),
+		Amount:         p.amount,
+		Timestamp:      p.updatedAt,
+	})
+
+	return nil
+}
+
+// AdjustPendingAmount changes the amount still due on an unpaid pay at shop payment after the booking
+// changed price, so the vendor collects the new price. A line for the difference is added to the items so
+// they keep adding up to the amount. Online payments are fixed once sent to the gateway.
+func (p *Payment) AdjustPendingAmount(difference int, description string) error {
+	if p.status != PaymentStatusPending {
+		return fmt.Errorf("cannot adjust payment with status: %s", p.status)
+	}
+	if !p.method.IsCollectedByVendor() {
+		return fmt.Errorf("cannot adjust %s payment; only payments collected by the vendor can be adjusted", p.method)
+	}
+	if difference == 0 {
+		return fmt.Errorf("difference cannot be 0")
+	}
+	if p.amount+difference < 0 {
+		return fmt.Errorf("difference (%d) exceeds the payment amount (%d)", difference, p.amount)
+	}
+	if description == "" {
+		return fmt.Errorf("description cannot be empty")
+	}
+
+	item := PaymentItem{Name: description, Quantity: 1, Price: difference}
+	p.amount += difference
+	p.items = append(p.items, item)
+	p.version++
+	p.updatedAt = time.Now()
+
+	p.raiseEvent(&event.PaymentAmountAdjusted{
+		PaymentID:  p.id,
+		Difference: difference,
+		Amount:     p.amount,
+		Item:       item,
+		Timestamp:  p.updatedAt,
+	})
+
+	return nil
+}
+
+// PlatformFundedDiscount returns the part of the discount the platform pays to the vendor
+func (p *Payment) PlatformFundedDiscount() int {
+	if p.discountFundedBy == PromotionFundedByPlatform {
+		return p.discountAmount
+	}
+	return 0
+}
+
+// VendorEarning returns what the vendor earns for the booking: the amount paid plus any
+// platform-funded discount
+func (p *Payment) VendorEarning() int {
+	return p.amount + p.PlatformFundedDiscount()
+}
+
+// SetDiscount sets the applied promotion (used when loading from database)
+func (p *Payment) SetDiscount(promotionID, promotionCode string, discount int, fundedBy PromotionFunding) {
+	p.promotionID = promotionID
+	p.promotionCode = promotionCode
+	p.discountAmount = discount
+	p.discountFundedBy = fundedBy
+}
+
+// RefundableAmount returns the part of the payment that has not been refunded yet
+func (p *Payment) RefundableAmount() int {
+	refundable := p.amount - p.refundedAmount
+	if p.pendingRefund != nil {
+		refundable -= p.pendingRefund.Amount
+	}
+	return refundable
+}
+
+// PendingRefund returns the refund in progress, or nil
+func (p *Payment) PendingRefund() *PendingRefund {
+	return p.pendingRefund
+}
+
+// SetPendingRefund sets the refund in progress (used when loading from database)
+func (p *Payment) SetPendingRefund(pendingRefund *PendingRefund) {
+	p.pendingRefund = pendingRefund
+}
+
+// SetRefundedAmount sets the refunded amount (used when loading from database)
+func (p *Payment) SetRefundedAmount(refundedAmount int) {
+	p.refundedAmount = refundedAmount
+}
+
+// SetScheduleID sets the booking a top-up or occurrence payment is for (used by repository during reconstruction)
+func (p *Payment) SetScheduleID(scheduleID string) {
+	p.scheduleID = scheduleID
+}
+
+// SetSeriesID sets the recurring booking the payment is for (used by repository during reconstruction)
+func (p *Payment) SetSeriesID(seriesID string) {
+	p.seriesID = seriesID
+}
+
+// SetPetLines sets the pets of a booking covering several pets (used by repository during reconstruction)
+func (p *Payment) SetPetLines(petLines []PaymentPetLine) {
+	p.petLines = petLines
+}
+
+// IsForExistingBooking checks if the payment is for a booking that already exists, such as a top-up or
+// an occurrence of a recurring booking, instead of creating one
+func (p *Payment) IsForExistingBooking() bool {
+	return p.scheduleID != ""
+}
+
+// IsSeriesUpfront checks if the payment is the upfront payment of a recurring booking
+func (p *Payment) IsSeriesUpfront() bool {
+	return p.seriesID != "" && p.scheduleID == ""
+}
+
+// SetCollectedBy sets who collected an offline payment (used when loading from database)
+func (p *Payment) SetCollectedBy(collectedBy string) {
+	p.collectedBy = collectedBy
+}
+
 // MarkAsCancelled marks the payment as cancelled
 func (p *Payment) MarkAsCancelled() error {
-	if p.status == PaymentStatusPaid {
+	if p.status == PaymentStatusPaid || p.status == PaymentStatusRefunded {
 		return fmt.Errorf("cannot cancel a paid payment")
 	}
 
@@ -245,7 +642,7 @@ func (p *Payment) MarkAsCancelled() error {
 
 // MarkAsExpired marks the payment as expired
 func (p *Payment) MarkAsExpired() error {
-	if p.status == PaymentStatusPaid {
+	if p.status == PaymentStatusPaid || p.status == PaymentStatusRefunded {
 		return fmt.Errorf("cannot expire a paid payment")
 	}
 
@@ -266,7 +663,7 @@ func (p *Payment) MarkAsExpired() error {
 
 // MarkAsFailed marks the payment as failed
 func (p *Payment) MarkAsFailed() error {
-	if p.status == PaymentStatusPaid {
+	if p.status == PaymentStatusPaid || p.status == PaymentStatusRefunded {
 		return fmt.Errorf("cannot fail a paid payment")
 	}
 
@@ -285,8 +682,12 @@ func (p *Payment) MarkAsFailed() error {
 	return nil
 }
 
-// IsExpired checks if the payment has expired
+// IsExpired checks if the payment has expired. Offline payments never expire on their own;
+// the vendor either collects them or cancels the booking.
 func (p *Payment) IsExpired() bool {
+	if p.method.IsCollectedByVendor() {
+		return false
+	}
 	return time.Now().After(p.expiredAt) && p.status == PaymentStatusPending
 }
 
@@ -313,6 +714,9 @@ func (p *Payment) applyEvent(ev event.DomainEvent) error {
 		p.serviceIDs = e.ServiceIDs
 		p.startTime = e.StartTime
 		p.endTime = e.EndTime
+		p.scheduleID = e.ScheduleID
+		p.seriesID = e.SeriesID
+		p.petLines = e.PetLines
 		p.createdAt = e.Timestamp
 		p.updatedAt = e.Timestamp
 
@@ -326,6 +730,32 @@ func (p *Payment) applyEvent(ev event.DomainEvent) error {
 		p.status = PaymentStatus(e.NewStatus)
 		p.updatedAt = e.Timestamp
 
+	case *event.PaymentCollected:
+		p.collectedBy = e.CollectedBy
+		p.updatedAt = e.Timestamp
+
+	case *event.PaymentRefundRequested:
+		p.pendingRefund = &PendingRefund{RefundID: e.RefundID, Amount: e.Amount, Reason: e.Reason}
+		p.updatedAt = e.Timestamp
+
+	case *event.PaymentRefunded:
+		p.refundedAmount = e.RefundedAmount
+		p.pendingRefund = nil
+		p.updatedAt = e.Timestamp
+
+	case *event.PaymentAmountAdjusted:
+		p.amount = e.Amount
+		p.items = append(p.items, e.Item)
+		p.updatedAt = e.Timestamp
+
+	case *event.PaymentDiscountApplied:
+		p.amount = e.Amount
+		p.promotionID = e.PromotionID
+		p.promotionCode = e.PromotionCode
+		p.discountAmount = e.DiscountAmount
+		p.discountFundedBy = PromotionFunding(e.FundedBy)
+		p.updatedAt = e.Timestamp
+
 	default:
 		return fmt.Errorf("unknown event type: %T", ev)
 	}
@@ -345,26 +775,35 @@ func (p *Payment) LoadFromHistory(events []event.DomainEvent) error {
 }
 
 // Getters
-func (p *Payment) ID() string                 { return p.id }
-func (p *Payment) OrderCode() int64           { return p.orderCode }
-func (p *Payment) UserID() string             { return p.userID }
-func (p *Payment) Amount() int                { return p.amount }
-func (p *Payment) Description() string        { return p.description }
-func (p *Payment) Items() []PaymentItem       { return p.items }
-func (p *Payment) Status() PaymentStatus      { return p.status }
-func (p *Payment) Method() PaymentMethod      { return p.method }
-func (p *Payment) PayOSTransactionID() string { return p.payOSTransactionID }
-func (p *Payment) CheckoutURL() string        { return p.checkoutURL }
-func (p *Payment) QRCode() string             { return p.qrCode }
-func (p *Payment) ExpiredAt() time.Time       { return p.expiredAt }
-func (p *Payment) VendorID() string           { return p.vendorID }
-func (p *Payment) PetID() string              { return p.petID }
-func (p *Payment) ServiceIDs() []string       { return p.serviceIDs }
-func (p *Payment) StartTime() time.Time       { return p.startTime }
-func (p *Payment) EndTime() time.Time         { return p.endTime }
-func (p *Payment) Version() int               { return p.version }
-func (p *Payment) CreatedAt() time.Time       { return p.createdAt }
-func (p *Payment) UpdatedAt() time.Time       { return p.updatedAt }
+func (p *Payment) ID() string                         { return p.id }
+func (p *Payment) OrderCode() int64                   { return p.orderCode }
+func (p *Payment) UserID() string                     { return p.userID }
+func (p *Payment) Amount() int                        { return p.amount }
+func (p *Payment) Description() string                { return p.description }
+func (p *Payment) Items() []PaymentItem               { return p.items }
+func (p *Payment) Status() PaymentStatus              { return p.status }
+func (p *Payment) Method() PaymentMethod              { return p.method }
+func (p *Payment) PayOSTransactionID() string         { return p.payOSTransactionID }
+func (p *Payment) CheckoutURL() string                { return p.checkoutURL }
+func (p *Payment) QRCode() string                     { return p.qrCode }
+func (p *Payment) ExpiredAt() time.Time               { return p.expiredAt }
+func (p *Payment) RefundedAmount() int                { return p.refundedAmount }
+func (p *Payment) CollectedBy() string                { return p.collectedBy }
+func (p *Payment) PromotionID() string                { return p.promotionID }
+func (p *Payment) PromotionCode() string              { return p.promotionCode }
+func (p *Payment) DiscountAmount() int                { return p.discountAmount }
+func (p *Payment) DiscountFundedBy() PromotionFunding { return p.discountFundedBy }
+func (p *Payment) VendorID() string                   { return p.vendorID }
+func (p *Payment) PetID() string                      { return p.petID }
+func (p *Payment) ServiceIDs() []string               { return p.serviceIDs }
+func (p *Payment) StartTime() time.Time               { return p.startTime }
+func (p *Payment) EndTime() time.Time                 { return p.endTime }
+func (p *Payment) ScheduleID() string                 { return p.scheduleID }
+func (p *Payment) SeriesID() string                   { return p.seriesID }
+func (p *Payment) PetLines() []PaymentPetLine         { return p.petLines }
+func (p *Payment) Version() int                       { return p.version }
+func (p *Payment) CreatedAt() time.Time               { return p.createdAt }
+func (p *Payment) UpdatedAt() time.Time               { return p.updatedAt }
 
 // Entity interface implementation
 func (p *Payment) GetID() string    { return p.id }
@@ -30,6 +30,8 @@ type Payout struct {
 	vendorID        string
 	paymentID       string // Link to the payment that triggered this payout
 	scheduleID      string // Link to the schedule that was created
+	settlementID    string // Link to the settlement batch (settlement payouts only)
+	lineItems       []event.SettlementLineItem
 	amount          int
 	status          PayoutStatus
 	requestedAt     time.Time
@@ -103,6 +105,60 @@ func NewPayout(payoutID, vendorID, paymentID, scheduleID string, amount int, ban
 	return payout, nil
 }
 
+// NewSettlementPayout creates a payout for a vendor settlement covering multiple paid bookings
+func NewSettlementPayout(payoutID, vendorID, settlementID string, lineItems []event.SettlementLineItem, amount int, bankAccount BankAccount, notes string) (*Payout, error) {
+	if payoutID == "" {
+		return nil, fmt.Errorf("payout ID cannot be empty")
+	}
+	if vendorID == "" {
+		return nil, fmt.Errorf("vendor ID cannot be empty")
+	}
+	if settlementID == "" {
+		return nil, fmt.Errorf("settlement ID cannot be empty")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("payout amount must be greater than 0")
+	}
+	if amount > 500000000 {
+		return nil, fmt.Errorf("maximum payout amount is 500,000,000 VND per transaction")
+	}
+	if bankAccount.BankName == "" || bankAccount.AccountNumber == "" || bankAccount.AccountName == "" {
+		return nil, fmt.Errorf("complete bank account information is required")
+	}
+
+	now := time.Now()
+	payout := &Payout{
+		id:           payoutID,
+		vendorID:     vendorID,
+		settlementID: settlementID,
+		lineItems:    lineItems,
+		amount:       amount,
+		status:       PayoutStatusPending,
+		requestedAt:  now,
+		bankAccount:  bankAccount,
+		notes:        notes,
+		version:      1,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+
+	payout.raiseEvent(&event.PayoutRequested{
+		PayoutID:      payoutID,
+		VendorID:      vendorID,
+		SettlementID:  settlementID,
+		LineItems:     lineItems,
+		Amount:        amount,
+		BankName:      bankAccount.BankName,
+		AccountNumber: bankAccount.AccountNumber,
+		AccountName:   bankAccount.AccountName,
+		BankBranch:    bankAccount.BankBranch,
+		Notes:         notes,
+		Timestamp:     now,
+	})
+
+	return payout, nil
+}
+
 // ReconstructPayout reconstructs a payout from database state (for MongoDB repository)
 func ReconstructPayout(
 	id, vendorID, paymentID, scheduleID string,
@@ -128,6 +184,12 @@ func ReconstructPayout(
 	}
 }
 
+// SetSettlementDetails sets settlement link and line items (used by repository during reconstruction)
+func (p *Payout) SetSettlementDetails(settlementID string, lineItems []event.SettlementLineItem) {
+	p.settlementID = settlementID
+	p.lineItems = lineItems
+}
+
 // MarkAsProcessing marks payout as being processed by PayOS (automatic)
 func (p *Payout) MarkAsProcessing(payosTransferID string) error {
 	// Allow processing for PENDING or FAILED (retry) payouts
@@ -223,6 +285,8 @@ func (p *Payout) applyEvent(ev event.DomainEvent) error {
 		p.vendorID = e.VendorID
 		p.paymentID = e.PaymentID
 		p.scheduleID = e.ScheduleID
+		p.settlementID = e.SettlementID
+		p.lineItems = e.LineItems
 		p.amount = e.Amount
 		p.status = PayoutStatusPending
 		p.requestedAt = e.Timestamp
@@ -268,6 +332,8 @@ func (p *Payout) ID() string               { return p.id }
 func (p *Payout) VendorID() string         { return p.vendorID }
 func (p *Payout) PaymentID() string        { return p.paymentID }
 func (p *Payout) ScheduleID() string       { return p.scheduleID }
+func (p *Payout) SettlementID() string     { return p.settlementID }
+func (p *Payout) LineItems() []event.SettlementLineItem { return p.lineItems }
 func (p *Payout) Amount() int              { return p.amount }
 func (p *Payout) Status() PayoutStatus     { return p.status }
 func (p *Payout) RequestedAt() time.Time   { return p.requestedAt }
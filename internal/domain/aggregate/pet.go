@@ -23,6 +23,26 @@ type Pet struct {
 	updatedAt        time.Time
 	isActive         bool
 
+	// Birth date (exact or estimated) from which the age is derived, and the weight history
+	birthDate          time.Time
+	birthDateEstimated bool
+	weightHistory      []event.WeightMeasurement
+
+	// Users sharing the pet with its primary owner (userID), pending guardian invitations and a pending ownership transfer
+	guardians           []event.PetGuardian
+	guardianInvitations []event.GuardianInvitation
+	pendingTransfer     *event.OwnershipTransfer
+
+	// Medication plans with their dose logs
+	medicationPlans []event.MedicationPlan
+
+	// Documents such as lab results, certificates and x-rays
+	attachments []event.PetAttachment
+
+	// Behaviour and handling notes shared with vendors in the care brief of a booking
+	temperament         string
+	specialInstructions string
+
 	// Health data
 	vaccinationRecords []event.VaccinationRecord
 	medicalHistory     []event.MedicalRecord
@@ -161,6 +181,105 @@ func (p *Pet) Delete() error {
 	return nil
 }
 
+// UpdateBirthDate sets the pet's birth date. Estimated birth dates are used when only the approximate age is known.
+func (p *Pet) UpdateBirthDate(birthDate time.Time, estimated bool) error {
+	if birthDate.IsZero() {
+		return fmt.Errorf("birth date cannot be empty")
+	}
+	if birthDate.After(time.Now()) {
+		return fmt.Errorf("birth date cannot be in the future")
+	}
+	if birthDate.Before(time.Now().AddDate(-50, 0, 0)) {
+		return fmt.Errorf("birth date is more than 50 years ago")
+	}
+	if birthDate.Equal(p.birthDate) && estimated == p.birthDateEstimated {
+		return nil
+	}
+
+	p.raiseEvent(&event.PetBirthDateSet{
+		PetID:        p.id,
+		BirthDate:    birthDate,
+		Estimated:    estimated,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// EstimateBirthDateFromAge derives an estimated birth date from an age in years, unless the
+// current birth date already gives that age
+func (p *Pet) EstimateBirthDateFromAge(age int) error {
+	if age < 0 {
+		return fmt.Errorf("invalid age: %d", age)
+	}
+	if !p.birthDate.IsZero() && p.Age() == age {
+		return nil
+	}
+	return p.UpdateBirthDate(time.Now().AddDate(-age, 0, 0), true)
+}
+
+// RecordWeight adds a measurement to the pet's weight history. The current weight is the most recent measurement.
+func (p *Pet) RecordWeight(weight float64, measuredAt time.Time, source, recordedBy, notes string) error {
+	if weight <= 0 {
+		return fmt.Errorf("invalid weight: %f", weight)
+	}
+	if source != event.WeightSourceOwner && source != event.WeightSourceVendor && source != event.WeightSourceVet {
+		return fmt.Errorf("invalid weight source: must be '%s', '%s' or '%s'", event.WeightSourceOwner, event.WeightSourceVendor, event.WeightSourceVet)
+	}
+	if measuredAt.IsZero() {
+		measuredAt = time.Now()
+	}
+	if measuredAt.After(time.Now()) {
+		return fmt.Errorf("measurement date cannot be in the future")
+	}
+
+	measurement := event.WeightMeasurement{
+		ID:         uuid.New().String(),
+		Weight:     weight,
+		MeasuredAt: measuredAt,
+		Source:     source,
+		RecordedBy: recordedBy,
+		Notes:      notes,
+	}
+
+	currentWeight := weight
+	for _, m := range p.weightHistory {
+		if m.MeasuredAt.After(measuredAt) {
+			currentWeight = p.weight
+			break
+		}
+	}
+
+	p.raiseEvent(&event.PetWeightRecorded{
+		PetID:         p.id,
+		Measurement:   measurement,
+		CurrentWeight: currentWeight,
+		EventVersion:  p.version + 1,
+		Timestamp:     time.Now(),
+	})
+	return nil
+}
+
+// AgeAt returns the pet's age in whole years at the given time
+func (p *Pet) AgeAt(t time.Time) int {
+	if p.birthDate.IsZero() {
+		return p.age
+	}
+	return AgeInYears(p.birthDate, t)
+}
+
+// AgeInYears returns the number of whole years between a birth date and the given time
+func AgeInYears(birthDate, t time.Time) int {
+	years := t.Year() - birthDate.Year()
+	if t.Month() < birthDate.Month() || (t.Month() == birthDate.Month() && t.Day() < birthDate.Day()) {
+		years--
+	}
+	if years < 0 {
+		return 0
+	}
+	return years
+}
+
 // Health management methods
 
 func (p *Pet) AddVaccinationRecord(vaccineName string, date, nextDueDate time.Time, veterinarian, notes string) error {
@@ -216,6 +335,38 @@ func (p *Pet) AddMedicalRecord(date time.Time, description, treatment, veterinar
 	return nil
 }
 
+// AddVendorMedicalRecord adds a medical record reported by a vendor, e.g. from an accepted visit report,
+// and returns its ID
+func (p *Pet) AddVendorMedicalRecord(date time.Time, description, treatment, notes string, provenance event.RecordProvenance) (string, error) {
+	if date.IsZero() {
+		return "", fmt.Errorf("medical record date cannot be empty")
+	}
+	if description == "" {
+		return "", fmt.Errorf("description cannot be empty")
+	}
+	if provenance.VendorID == "" {
+		return "", fmt.Errorf("vendorID cannot be empty")
+	}
+	provenance.Source = event.RecordSourceVendor
+
+	record := event.MedicalRecord{
+		ID:          uuid.New().String(),
+		Date:        date,
+		Description: description,
+		Treatment:   treatment,
+		Notes:       notes,
+		Provenance:  &provenance,
+	}
+
+	p.raiseEvent(&event.PetMedicalRecordAdded{
+		PetID:        p.id,
+		Record:       record,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return record.ID, nil
+}
+
 func (p *Pet) AddAllergy(allergen, severity, symptoms string, diagnosedDate time.Time, notes string) error {
 	if allergen == "" {
 		return fmt.Errorf("allergen cannot be empty")
@@ -272,6 +423,790 @@ func (p *Pet) RemoveAllergy(allergyID string) error {
 	return nil
 }
 
+// Health record corrections. Every correction keeps who made it and why; voided records stay in the
+// history but no longer count as part of the pet's current health record.
+
+func (p *Pet) UpdateVaccinationRecord(recordID, vaccineName string, date, nextDueDate time.Time, veterinarian, notes, changedBy, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("a reason is required to correct a health record")
+	}
+	if vaccineName == "" {
+		return fmt.Errorf("vaccine name cannot be empty")
+	}
+	if date.IsZero() {
+		return fmt.Errorf("vaccination date cannot be empty")
+	}
+
+	current, err := p.findVaccinationRecord(recordID)
+	if err != nil {
+		return err
+	}
+
+	updated := current
+	updated.VaccineName = vaccineName
+	updated.Date = date
+	updated.NextDueDate = nextDueDate
+	updated.Veterinarian = veterinarian
+	updated.Notes = notes
+
+	var changes fieldChanges
+	changes.add("vaccine_name", current.VaccineName, updated.VaccineName)
+	changes.addTime("date", current.Date, updated.Date)
+	changes.addTime("next_due_date", current.NextDueDate, updated.NextDueDate)
+	changes.add("veterinarian", current.Veterinarian, updated.Veterinarian)
+	changes.add("notes", current.Notes, updated.Notes)
+	if len(changes) == 0 {
+		return fmt.Errorf("no changes to vaccination record: %s", recordID)
+	}
+
+	p.raiseEvent(&event.PetVaccinationUpdated{
+		PetID:        p.id,
+		Record:       updated,
+		Changes:      changes,
+		ChangedBy:    changedBy,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+func (p *Pet) VoidVaccinationRecord(recordID, voidedBy, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("a reason is required to void a health record")
+	}
+	if _, err := p.findVaccinationRecord(recordID); err != nil {
+		return err
+	}
+
+	p.raiseEvent(&event.PetVaccinationVoided{
+		PetID:        p.id,
+		RecordID:     recordID,
+		VoidedBy:     voidedBy,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+func (p *Pet) UpdateMedicalRecord(recordID string, date time.Time, description, treatment, veterinarian, diagnosis, notes, changedBy, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("a reason is required to correct a health record")
+	}
+	if date.IsZero() {
+		return fmt.Errorf("medical record date cannot be empty")
+	}
+	if description == "" {
+		return fmt.Errorf("description cannot be empty")
+	}
+
+	current, err := p.findMedicalRecord(recordID)
+	if err != nil {
+		return err
+	}
+
+	updated := current
+	updated.Date = date
+	updated.Description = description
+	updated.Treatment = treatment
+	updated.Veterinarian = veterinarian
+	updated.Diagnosis = diagnosis
+	updated.Notes = notes
+
+	var changes fieldChanges
+	changes.addTime("date", current.Date, updated.Date)
+	changes.add("description", current.Description, updated.Description)
+	changes.add("treatment", current.Treatment, updated.Treatment)
+	changes.add("veterinarian", current.Veterinarian, updated.Veterinarian)
+	changes.add("diagnosis", current.Diagnosis, updated.Diagnosis)
+	changes.add("notes", current.Notes, updated.Notes)
+	if len(changes) == 0 {
+		return fmt.Errorf("no changes to medical record: %s", recordID)
+	}
+
+	p.raiseEvent(&event.PetMedicalRecordUpdated{
+		PetID:        p.id,
+		Record:       updated,
+		Changes:      changes,
+		ChangedBy:    changedBy,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+func (p *Pet) VoidMedicalRecord(recordID, voidedBy, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("a reason is required to void a health record")
+	}
+	if _, err := p.findMedicalRecord(recordID); err != nil {
+		return err
+	}
+
+	p.raiseEvent(&event.PetMedicalRecordVoided{
+		PetID:        p.id,
+		RecordID:     recordID,
+		VoidedBy:     voidedBy,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+func (p *Pet) UpdateAllergy(allergyID, allergen, severity, symptoms string, diagnosedDate time.Time, notes, changedBy, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("a reason is required to correct a health record")
+	}
+	if allergen == "" {
+		return fmt.Errorf("allergen cannot be empty")
+	}
+	if severity != "mild" && severity != "moderate" && severity != "severe" {
+		return fmt.Errorf("invalid severity: must be 'mild', 'moderate', or 'severe'")
+	}
+
+	var current *event.Allergy
+	for i := range p.allergies {
+		if p.allergies[i].ID == allergyID {
+			current = &p.allergies[i]
+			break
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("allergy not found: %s", allergyID)
+	}
+
+	updated := *current
+	updated.Allergen = allergen
+	updated.Severity = severity
+	updated.Symptoms = symptoms
+	updated.DiagnosedDate = diagnosedDate
+	updated.Notes = notes
+
+	var changes fieldChanges
+	changes.add("allergen", current.Allergen, updated.Allergen)
+	changes.add("severity", current.Severity, updated.Severity)
+	changes.add("symptoms", current.Symptoms, updated.Symptoms)
+	changes.addTime("diagnosed_date", current.DiagnosedDate, updated.DiagnosedDate)
+	changes.add("notes", current.Notes, updated.Notes)
+	if len(changes) == 0 {
+		return fmt.Errorf("no changes to allergy: %s", allergyID)
+	}
+
+	p.raiseEvent(&event.PetAllergyUpdated{
+		PetID:        p.id,
+		Allergy:      updated,
+		Changes:      changes,
+		ChangedBy:    changedBy,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// findVaccinationRecord returns a vaccination record that can still be changed
+func (p *Pet) findVaccinationRecord(recordID string) (event.VaccinationRecord, error) {
+	for _, r := range p.vaccinationRecords {
+		if r.ID == recordID {
+			if r.Voided {
+				return r, fmt.Errorf("vaccination record is voided: %s", recordID)
+			}
+			return r, nil
+		}
+	}
+	return event.VaccinationRecord{}, fmt.Errorf("vaccination record not found: %s", recordID)
+}
+
+// findMedicalRecord returns a medical record that can still be changed
+func (p *Pet) findMedicalRecord(recordID string) (event.MedicalRecord, error) {
+	for _, r := range p.medicalHistory {
+		if r.ID == recordID {
+			if r.Voided {
+				return r, fmt.Errorf("medical record is voided: %s", recordID)
+			}
+			return r, nil
+		}
+	}
+	return event.MedicalRecord{}, fmt.Errorf("medical record not found: %s", recordID)
+}
+
+// fieldChanges collects the fields that differ between the old and new version of a record
+type fieldChanges []event.FieldChange
+
+func (c *fieldChanges) add(field, from, to string) {
+	if from != to {
+		*c = append(*c, event.FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func (c *fieldChanges) addTime(field string, from, to time.Time) {
+	if !from.Equal(to) {
+		c.add(field, formatChangeTime(from), formatChangeTime(to))
+	}
+}
+
+func formatChangeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Guardianship. The primary owner is always an owner of the pet; guardians share it with them as
+// co-owners or caretakers. Only the primary owner can hand the pet over to someone else.
+
+const (
+	guardianInvitationTTL = 7 * 24 * time.Hour
+	ownershipTransferTTL  = 7 * 24 * time.Hour
+)
+
+// GuardianRole returns the user's role for the pet, or an empty string when the user is not a guardian
+func (p *Pet) GuardianRole(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	if userID == p.userID {
+		return event.GuardianRoleOwner
+	}
+	for _, g := range p.guardians {
+		if g.UserID == userID {
+			return g.Role
+		}
+	}
+	return ""
+}
+
+// IsOwner reports whether the user is the primary owner or a co-owner of the pet
+func (p *Pet) IsOwner(userID string) bool {
+	return p.GuardianRole(userID) == event.GuardianRoleOwner
+}
+
+// CanBeBookedBy reports whether the user may book care for the pet, which owners and caretakers can
+func (p *Pet) CanBeBookedBy(userID string) bool {
+	return p.GuardianRole(userID) != ""
+}
+
+// InviteGuardian invites a user to share the pet as a co-owner or caretaker
+func (p *Pet) InviteGuardian(userID, role, invitedBy string) error {
+	if !p.isActive {
+		return fmt.Errorf("cannot invite guardians for a deleted pet")
+	}
+	if role != event.GuardianRoleOwner && role != event.GuardianRoleCaretaker {
+		return fmt.Errorf("invalid guardian role: must be '%s' or '%s'", event.GuardianRoleOwner, event.GuardianRoleCaretaker)
+	}
+	if !p.IsOwner(invitedBy) {
+		return fmt.Errorf("only owners can invite guardians")
+	}
+	if userID == "" {
+		return fmt.Errorf("invited user cannot be empty")
+	}
+	if p.GuardianRole(userID) != "" {
+		return fmt.Errorf("user is already a guardian of this pet")
+	}
+
+	now := time.Now()
+	for _, inv := range p.guardianInvitations {
+		if inv.UserID == userID && now.Before(inv.ExpiresAt) {
+			return fmt.Errorf("user already has a pending invitation: %s", inv.ID)
+		}
+	}
+
+	p.raiseEvent(&event.PetGuardianInvited{
+		PetID: p.id,
+		Invitation: event.GuardianInvitation{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			Role:      role,
+			InvitedBy: invitedBy,
+			InvitedAt: now,
+			ExpiresAt: now.Add(guardianInvitationTTL),
+		},
+		EventVersion: p.version + 1,
+		Timestamp:    now,
+	})
+	return nil
+}
+
+// AcceptGuardianInvitation makes the invited user a guardian of the pet
+func (p *Pet) AcceptGuardianInvitation(invitationID, userID string) error {
+	inv, err := p.findGuardianInvitation(invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.UserID != userID {
+		return fmt.Errorf("invitation was sent to another user")
+	}
+	if !p.isActive {
+		return fmt.Errorf("pet has been deleted")
+	}
+
+	now := time.Now()
+	if !now.Before(inv.ExpiresAt) {
+		return fmt.Errorf("invitation has expired")
+	}
+
+	p.raiseEvent(&event.PetGuardianInvitationAccepted{
+		PetID:        p.id,
+		InvitationID: inv.ID,
+		Guardian: event.PetGuardian{
+			UserID:  userID,
+			Role:    inv.Role,
+			AddedAt: now,
+			AddedBy: inv.InvitedBy,
+		},
+		EventVersion: p.version + 1,
+		Timestamp:    now,
+	})
+	return nil
+}
+
+// DeclineGuardianInvitation closes a pending invitation: the invited user declines it, an owner cancels it
+func (p *Pet) DeclineGuardianInvitation(invitationID, userID string) error {
+	inv, err := p.findGuardianInvitation(invitationID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case inv.UserID == userID:
+		p.raiseEvent(&event.PetGuardianInvitationDeclined{
+			PetID:        p.id,
+			InvitationID: inv.ID,
+			UserID:       userID,
+			EventVersion: p.version + 1,
+			Timestamp:    time.Now(),
+		})
+	case p.IsOwner(userID):
+		p.raiseEvent(&event.PetGuardianInvitationCancelled{
+			PetID:        p.id,
+			InvitationID: inv.ID,
+			CancelledBy:  userID,
+			EventVersion: p.version + 1,
+			Timestamp:    time.Now(),
+		})
+	default:
+		return fmt.Errorf("only the invited user or an owner can close an invitation")
+	}
+	return nil
+}
+
+// RemoveGuardian removes a guardian; owners can remove anyone but the primary owner and guardians can step down
+func (p *Pet) RemoveGuardian(guardianUserID, removedBy string) error {
+	if guardianUserID == p.userID {
+		return fmt.Errorf("the primary owner cannot be removed; transfer ownership instead")
+	}
+	if p.GuardianRole(guardianUserID) == "" {
+		return fmt.Errorf("user is not a guardian of this pet")
+	}
+	if removedBy != guardianUserID && !p.IsOwner(removedBy) {
+		return fmt.Errorf("only owners can remove other guardians")
+	}
+
+	p.raiseEvent(&event.PetGuardianRemoved{
+		PetID:        p.id,
+		UserID:       guardianUserID,
+		RemovedBy:    removedBy,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// RequestOwnershipTransfer offers the pet to a new primary owner, who has to accept the transfer
+func (p *Pet) RequestOwnershipTransfer(toUserID, requestedBy, note string) error {
+	if !p.isActive {
+		return fmt.Errorf("cannot transfer a deleted pet")
+	}
+	if requestedBy != p.userID {
+		return fmt.Errorf("only the primary owner can transfer ownership")
+	}
+	if toUserID == "" {
+		return fmt.Errorf("new owner cannot be empty")
+	}
+	if toUserID == p.userID {
+		return fmt.Errorf("user already owns this pet")
+	}
+
+	now := time.Now()
+	if p.pendingTransfer != nil && now.Before(p.pendingTransfer.ExpiresAt) {
+		return fmt.Errorf("an ownership transfer is already pending: %s", p.pendingTransfer.ID)
+	}
+
+	p.raiseEvent(&event.PetOwnershipTransferRequested{
+		PetID: p.id,
+		Transfer: event.OwnershipTransfer{
+			ID:          uuid.New().String(),
+			FromUserID:  p.userID,
+			ToUserID:    toUserID,
+			Note:        note,
+			RequestedAt: now,
+			ExpiresAt:   now.Add(ownershipTransferTTL),
+		},
+		EventVersion: p.version + 1,
+		Timestamp:    now,
+	})
+	return nil
+}
+
+// AcceptOwnershipTransfer makes the user the pet's primary owner. The previous household's guardians
+// and pending invitations are removed.
+func (p *Pet) AcceptOwnershipTransfer(userID string) error {
+	transfer := p.pendingTransfer
+	if transfer == nil {
+		return fmt.Errorf("no ownership transfer is pending")
+	}
+	if transfer.ToUserID != userID {
+		return fmt.Errorf("ownership transfer was offered to another user")
+	}
+	if !p.isActive {
+		return fmt.Errorf("pet has been deleted")
+	}
+
+	now := time.Now()
+	if !now.Before(transfer.ExpiresAt) {
+		return fmt.Errorf("ownership transfer has expired")
+	}
+
+	var removed []string
+	for _, g := range p.guardians {
+		removed = append(removed, g.UserID)
+	}
+
+	p.raiseEvent(&event.PetOwnershipTransferred{
+		PetID:            p.id,
+		TransferID:       transfer.ID,
+		FromUserID:       p.userID,
+		ToUserID:         userID,
+		RemovedGuardians: removed,
+		EventVersion:     p.version + 1,
+		Timestamp:        now,
+	})
+	return nil
+}
+
+// DeclineOwnershipTransfer closes the pending transfer: the proposed owner declines it, the primary owner cancels it
+func (p *Pet) DeclineOwnershipTransfer(userID string) error {
+	transfer := p.pendingTransfer
+	if transfer == nil {
+		return fmt.Errorf("no ownership transfer is pending")
+	}
+
+	switch userID {
+	case transfer.ToUserID:
+		p.raiseEvent(&event.PetOwnershipTransferDeclined{
+			PetID:        p.id,
+			TransferID:   transfer.ID,
+			UserID:       userID,
+			EventVersion: p.version + 1,
+			Timestamp:    time.Now(),
+		})
+	case p.userID:
+		p.raiseEvent(&event.PetOwnershipTransferCancelled{
+			PetID:        p.id,
+			TransferID:   transfer.ID,
+			CancelledBy:  userID,
+			EventVersion: p.version + 1,
+			Timestamp:    time.Now(),
+		})
+	default:
+		return fmt.Errorf("only the primary owner or the proposed owner can close a transfer")
+	}
+	return nil
+}
+
+// findGuardianInvitation returns a pending guardian invitation
+func (p *Pet) findGuardianInvitation(invitationID string) (event.GuardianInvitation, error) {
+	for _, inv := range p.guardianInvitations {
+		if inv.ID == invitationID {
+			return inv, nil
+		}
+	}
+	return event.GuardianInvitation{}, fmt.Errorf("guardian invitation not found: %s", invitationID)
+}
+
+// removeGuardianInvitation drops a pending invitation from the pet state
+func (p *Pet) removeGuardianInvitation(invitationID string) {
+	for i, inv := range p.guardianInvitations {
+		if inv.ID == invitationID {
+			p.guardianInvitations = append(p.guardianInvitations[:i], p.guardianInvitations[i+1:]...)
+			return
+		}
+	}
+}
+
+// maxMedicationIntervalHours bounds the time between two doses of a plan (90 days)
+const maxMedicationIntervalHours = 24 * 90
+
+// AddMedicationPlan starts a course of medication given every intervalHours from startDate until endDate.
+// A zero start date starts the plan now and a zero end date leaves it open until it is stopped.
+func (p *Pet) AddMedicationPlan(drugName, dose string, intervalHours int, startDate, endDate time.Time, prescribedBy, instructions, medicalRecordID, createdBy string) error {
+	if drugName == "" {
+		return fmt.Errorf("drug name is required")
+	}
+	if dose == "" {
+		return fmt.Errorf("dose is required")
+	}
+	if intervalHours < 1 || intervalHours > maxMedicationIntervalHours {
+		return fmt.Errorf("dose interval must be between 1 and %d hours", maxMedicationIntervalHours)
+	}
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+	// Dose times are derived from the start date, so keep them on whole minutes
+	startDate = startDate.Truncate(time.Minute)
+	if !endDate.IsZero() && !endDate.After(startDate) {
+		return fmt.Errorf("end date must be after the start date")
+	}
+	if medicalRecordID != "" {
+		if _, err := p.findMedicalRecord(medicalRecordID); err != nil {
+			return err
+		}
+	}
+
+	plan := event.MedicationPlan{
+		ID:              uuid.New().String(),
+		DrugName:        drugName,
+		Dose:            dose,
+		IntervalHours:   intervalHours,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		PrescribedBy:    prescribedBy,
+		Instructions:    instructions,
+		MedicalRecordID: medicalRecordID,
+		CreatedBy:       createdBy,
+	}
+
+	p.raiseEvent(&event.PetMedicationPlanAdded{
+		PetID:        p.id,
+		Plan:         plan,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// StopMedicationPlan ends a medication plan; no further doses are scheduled after it is stopped
+func (p *Pet) StopMedicationPlan(planID, stoppedBy, reason string) error {
+	plan, err := p.findMedicationPlan(planID)
+	if err != nil {
+		return err
+	}
+	if !plan.StoppedAt.IsZero() {
+		return fmt.Errorf("medication plan is already stopped")
+	}
+
+	p.raiseEvent(&event.PetMedicationPlanStopped{
+		PetID:        p.id,
+		PlanID:       planID,
+		StoppedBy:    stoppedBy,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// LogMedicationDose records whether the dose of a plan scheduled at scheduledFor was given or missed.
+// A dose can be logged once, up to an hour before it is due; it can only be missed once it is due.
+func (p *Pet) LogMedicationDose(planID string, scheduledFor time.Time, status, recordedBy, notes string) error {
+	if status != event.DoseStatusGiven && status != event.DoseStatusMissed {
+		return fmt.Errorf("invalid dose status: must be '%s' or '%s'", event.DoseStatusGiven, event.DoseStatusMissed)
+	}
+	plan, err := p.findMedicationPlan(planID)
+	if err != nil {
+		return err
+	}
+
+	if len(MedicationDoseTimes(plan.StartDate, MedicationPlanEnd(plan), plan.IntervalHours, scheduledFor, scheduledFor)) == 0 {
+		return fmt.Errorf("no dose of this plan is scheduled at %s", scheduledFor.Format(time.RFC3339))
+	}
+	now := time.Now()
+	if scheduledFor.After(now.Add(time.Hour)) {
+		return fmt.Errorf("a dose cannot be logged more than an hour before it is due")
+	}
+	if status == event.DoseStatusMissed && scheduledFor.After(now) {
+		return fmt.Errorf("a dose cannot be missed before it is due")
+	}
+	for _, d := range plan.Doses {
+		if d.ScheduledFor.Equal(scheduledFor) {
+			return fmt.Errorf("this dose has already been logged as %s", d.Status)
+		}
+	}
+
+	p.raiseEvent(&event.PetMedicationDoseLogged{
+		PetID:  p.id,
+		PlanID: planID,
+		Dose: event.DoseLog{
+			ID:           uuid.New().String(),
+			ScheduledFor: scheduledFor,
+			Status:       status,
+			RecordedAt:   now,
+			RecordedBy:   recordedBy,
+			Notes:        notes,
+		},
+		EventVersion: p.version + 1,
+		Timestamp:    now,
+	})
+	return nil
+}
+
+// ActiveMedicationPlans returns the plans that have started and are neither stopped nor finished at the given time
+func (p *Pet) ActiveMedicationPlans(at time.Time) []event.MedicationPlan {
+	var active []event.MedicationPlan
+	for _, plan := range p.medicationPlans {
+		end := MedicationPlanEnd(plan)
+		if !plan.StartDate.After(at) && (end.IsZero() || at.Before(end)) {
+			active = append(active, plan)
+		}
+	}
+	return active
+}
+
+// MedicationPlanEnd returns when a plan stops scheduling doses: its end date or the time it was
+// stopped, whichever comes first. Zero means the plan is open-ended.
+func MedicationPlanEnd(plan event.MedicationPlan) time.Time {
+	end := plan.EndDate
+	if !plan.StoppedAt.IsZero() && (end.IsZero() || plan.StoppedAt.Before(end)) {
+		end = plan.StoppedAt
+	}
+	return end
+}
+
+// MedicationDoseTimes returns the dose times between from and to (inclusive) of a plan starting at
+// start and repeating every intervalHours until end; a zero end means the plan is open-ended
+func MedicationDoseTimes(start, end time.Time, intervalHours int, from, to time.Time) []time.Time {
+	if intervalHours < 1 || to.Before(start) || to.Before(from) {
+		return nil
+	}
+	interval := time.Duration(intervalHours) * time.Hour
+
+	first := start
+	if from.After(start) {
+		n := from.Sub(start) / interval
+		first = start.Add(n * interval)
+		if first.Before(from) {
+			first = first.Add(interval)
+		}
+	}
+
+	var times []time.Time
+	for t := first; !t.After(to); t = t.Add(interval) {
+		if !end.IsZero() && !t.Before(end) {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// AddAttachment records a document stored for the pet. When recordType is set the attachment belongs to
+// that vaccination or medical record, which must exist.
+func (p *Pet) AddAttachment(category, title, fileName, contentType string, size int64, url, storageKey, recordType, recordID, uploadedBy string) error {
+	switch category {
+	case event.AttachmentCategoryLabResult, event.AttachmentCategoryCertificate, event.AttachmentCategoryXRay,
+		event.AttachmentCategoryPrescription, event.AttachmentCategoryOther:
+	default:
+		return fmt.Errorf("invalid attachment category: %s", category)
+	}
+	if url == "" || storageKey == "" {
+		return fmt.Errorf("attachment file is required")
+	}
+	if title == "" {
+		title = fileName
+	}
+
+	switch recordType {
+	case "":
+		recordID = ""
+	case event.AttachmentRecordVaccination:
+		if _, err := p.findVaccinationRecord(recordID); err != nil {
+			return err
+		}
+	case event.AttachmentRecordMedicalRecord:
+		if _, err := p.findMedicalRecord(recordID); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid record type: must be '%s' or '%s'", event.AttachmentRecordVaccination, event.AttachmentRecordMedicalRecord)
+	}
+
+	now := time.Now()
+	p.raiseEvent(&event.PetAttachmentAdded{
+		PetID: p.id,
+		Attachment: event.PetAttachment{
+			ID:          uuid.New().String(),
+			Category:    category,
+			Title:       title,
+			FileName:    fileName,
+			ContentType: contentType,
+			Size:        size,
+			URL:         url,
+			StorageKey:  storageKey,
+			RecordType:  recordType,
+			RecordID:    recordID,
+			UploadedBy:  uploadedBy,
+			UploadedAt:  now,
+		},
+		EventVersion: p.version + 1,
+		Timestamp:    now,
+	})
+	return nil
+}
+
+// RemoveAttachment removes a document from the pet
+func (p *Pet) RemoveAttachment(attachmentID, removedBy string) error {
+	for _, attachment := range p.attachments {
+		if attachment.ID == attachmentID {
+			p.raiseEvent(&event.PetAttachmentRemoved{
+				PetID:        p.id,
+				AttachmentID: attachmentID,
+				StorageKey:   attachment.StorageKey,
+				RemovedBy:    removedBy,
+				EventVersion: p.version + 1,
+				Timestamp:    time.Now(),
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("attachment not found: %s", attachmentID)
+}
+
+// UpdateCareNotes sets the temperament and special instructions vendors see in the pet's care brief
+func (p *Pet) UpdateCareNotes(temperament, specialInstructions, updatedBy string) error {
+	if len(temperament) > MaxCareNoteLength || len(specialInstructions) > MaxCareNoteLength {
+		return fmt.Errorf("care notes cannot be longer than %d characters", MaxCareNoteLength)
+	}
+	if temperament == p.temperament && specialInstructions == p.specialInstructions {
+		return fmt.Errorf("no changes to care notes")
+	}
+
+	p.raiseEvent(&event.PetCareNotesUpdated{
+		PetID:               p.id,
+		Temperament:         temperament,
+		SpecialInstructions: specialInstructions,
+		UpdatedBy:           updatedBy,
+		EventVersion:        p.version + 1,
+		Timestamp:           time.Now(),
+	})
+	return nil
+}
+
+// MaxCareNoteLength limits the temperament and special instructions of a pet
+const MaxCareNoteLength = 2000
+
+// findMedicationPlan returns a medication plan of the pet
+func (p *Pet) findMedicationPlan(planID string) (event.MedicationPlan, error) {
+	for _, plan := range p.medicationPlans {
+		if plan.ID == planID {
+			return plan, nil
+		}
+	}
+	return event.MedicationPlan{}, fmt.Errorf("medication plan not found: %s", planID)
+}
+
 func (p *Pet) GetUncommittedEvents() []event.DomainEvent {
 	return p.uncommittedEvents
 }
@@ -345,6 +1280,69 @@ func (p *Pet) applyEvent(ev event.DomainEvent) error {
 		p.version = e.EventVersion
 		p.updatedAt = e.Timestamp
 	
+	case *event.PetBirthDateSet:
+		p.birthDate = e.BirthDate
+		p.birthDateEstimated = e.Estimated
+		p.age = AgeInYears(e.BirthDate, e.Timestamp)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetWeightRecorded:
+		p.weightHistory = append(p.weightHistory, e.Measurement)
+		p.weight = e.CurrentWeight
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetVaccinationUpdated:
+		for i, r := range p.vaccinationRecords {
+			if r.ID == e.Record.ID {
+				p.vaccinationRecords[i] = e.Record
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetVaccinationVoided:
+		for i, r := range p.vaccinationRecords {
+			if r.ID == e.RecordID {
+				p.vaccinationRecords[i].Voided = true
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetMedicalRecordUpdated:
+		for i, r := range p.medicalHistory {
+			if r.ID == e.Record.ID {
+				p.medicalHistory[i] = e.Record
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetMedicalRecordVoided:
+		for i, r := range p.medicalHistory {
+			if r.ID == e.RecordID {
+				p.medicalHistory[i].Voided = true
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetAllergyUpdated:
+		for i, a := range p.allergies {
+			if a.ID == e.Allergy.ID {
+				p.allergies[i] = e.Allergy
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
 	case *event.PetAllergyRemoved:
 		// Remove allergy from slice
 		for i, a := range p.allergies {
@@ -355,7 +1353,108 @@ func (p *Pet) applyEvent(ev event.DomainEvent) error {
 		}
 		p.version = e.EventVersion
 		p.updatedAt = e.Timestamp
-		
+
+	case *event.PetGuardianInvited:
+		p.guardianInvitations = append(p.guardianInvitations, e.Invitation)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetGuardianInvitationAccepted:
+		p.removeGuardianInvitation(e.InvitationID)
+		p.guardians = append(p.guardians, e.Guardian)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetGuardianInvitationDeclined:
+		p.removeGuardianInvitation(e.InvitationID)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetGuardianInvitationCancelled:
+		p.removeGuardianInvitation(e.InvitationID)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetGuardianRemoved:
+		for i, g := range p.guardians {
+			if g.UserID == e.UserID {
+				p.guardians = append(p.guardians[:i], p.guardians[i+1:]...)
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetOwnershipTransferRequested:
+		transfer := e.Transfer
+		p.pendingTransfer = &transfer
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetOwnershipTransferred:
+		p.userID = e.ToUserID
+		p.guardians = nil
+		p.guardianInvitations = nil
+		p.pendingTransfer = nil
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetOwnershipTransferDeclined:
+		p.pendingTransfer = nil
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetOwnershipTransferCancelled:
+		p.pendingTransfer = nil
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetAttachmentAdded:
+		p.attachments = append(p.attachments, e.Attachment)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetAttachmentRemoved:
+		for i, attachment := range p.attachments {
+			if attachment.ID == e.AttachmentID {
+				p.attachments = append(p.attachments[:i], p.attachments[i+1:]...)
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetMedicationPlanAdded:
+		p.medicationPlans = append(p.medicationPlans, e.Plan)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetMedicationPlanStopped:
+		for i, plan := range p.medicationPlans {
+			if plan.ID == e.PlanID {
+				p.medicationPlans[i].StoppedAt = e.Timestamp
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetMedicationDoseLogged:
+		for i, plan := range p.medicationPlans {
+			if plan.ID == e.PlanID {
+				p.medicationPlans[i].Doses = append(p.medicationPlans[i].Doses, e.Dose)
+				break
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PetCareNotesUpdated:
+		p.temperament = e.Temperament
+		p.specialInstructions = e.SpecialInstructions
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
 	default:
 		return fmt.Errorf("unknown event type: %T", ev)
 	}
@@ -369,7 +1468,7 @@ func (p *Pet) UserID() string    { return p.userID }
 func (p *Pet) Name() string      { return p.name }
 func (p *Pet) Species() string   { return p.species }
 func (p *Pet) Breed() string     { return p.breed }
-func (p *Pet) Age() int          { return p.age }
+func (p *Pet) Age() int          { return p.AgeAt(time.Now()) }
 func (p *Pet) Weight() float64   { return p.weight }
 func (p *Pet) ImageUrl() string  { return p.imageUrl }
 func (p *Pet) Version() int      { return p.version }
@@ -377,6 +1476,24 @@ func (p *Pet) CreatedAt() time.Time { return p.createdAt }
 func (p *Pet) UpdatedAt() time.Time { return p.updatedAt }
 func (p *Pet) IsActive() bool       { return p.isActive }
 
+// Birth date and weight history getters
+func (p *Pet) BirthDate() time.Time                     { return p.birthDate }
+func (p *Pet) BirthDateEstimated() bool                 { return p.birthDateEstimated }
+func (p *Pet) WeightHistory() []event.WeightMeasurement { return p.weightHistory }
+
+// Guardianship getters
+func (p *Pet) Guardians() []event.PetGuardian                  { return p.guardians }
+func (p *Pet) GuardianInvitations() []event.GuardianInvitation { return p.guardianInvitations }
+func (p *Pet) PendingTransfer() *event.OwnershipTransfer       { return p.pendingTransfer }
+
+// Medication and attachment getters
+func (p *Pet) MedicationPlans() []event.MedicationPlan { return p.medicationPlans }
+func (p *Pet) Attachments() []event.PetAttachment      { return p.attachments }
+
+// Care note getters
+func (p *Pet) Temperament() string         { return p.temperament }
+func (p *Pet) SpecialInstructions() string { return p.specialInstructions }
+
 // Health data getters
 func (p *Pet) VaccinationRecords() []event.VaccinationRecord { return p.vaccinationRecords }
 func (p *Pet) MedicalHistory() []event.MedicalRecord         { return p.medicalHistory }
@@ -392,6 +1509,20 @@ func (p *Pet) MarkInactive() 	{ p.isActive = false }
 func (p *Pet) SetVaccinationRecords(records []event.VaccinationRecord) { p.vaccinationRecords = records }
 func (p *Pet) SetMedicalHistory(records []event.MedicalRecord)         { p.medicalHistory = records }
 func (p *Pet) SetAllergies(allergies []event.Allergy)                  { p.allergies = allergies }
+func (p *Pet) SetBirthDate(birthDate time.Time, estimated bool) {
+	p.birthDate = birthDate
+	p.birthDateEstimated = estimated
+}
+func (p *Pet) SetWeightHistory(measurements []event.WeightMeasurement) { p.weightHistory = measurements }
+func (p *Pet) SetGuardians(guardians []event.PetGuardian)              { p.guardians = guardians }
+func (p *Pet) SetGuardianInvitations(invitations []event.GuardianInvitation) { p.guardianInvitations = invitations }
+func (p *Pet) SetPendingTransfer(transfer *event.OwnershipTransfer)   { p.pendingTransfer = transfer }
+func (p *Pet) SetMedicationPlans(plans []event.MedicationPlan)        { p.medicationPlans = plans }
+func (p *Pet) SetAttachments(attachments []event.PetAttachment)       { p.attachments = attachments }
+func (p *Pet) SetCareNotes(temperament, specialInstructions string) {
+	p.temperament = temperament
+	p.specialInstructions = specialInstructions
+}
 
 func (p *Pet) MarkEventsAsCommitted(){
 	p.uncommittedEvents = nil
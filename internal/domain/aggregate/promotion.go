@@ -0,0 +1,428 @@
+package aggregate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"whisko-petcare/internal/domain/event"
+)
+
+// PromotionDiscountType represents how a promotion discount is calculated
+type PromotionDiscountType string
+
+const (
+	PromotionDiscountPercentage PromotionDiscountType = "PERCENTAGE" // Percentage of the order amount, optionally capped
+	PromotionDiscountFixed      PromotionDiscountType = "FIXED"      // Fixed amount in VND
+)
+
+// IsValid checks if the discount type is supported
+func (t PromotionDiscountType) IsValid() bool {
+	return t == PromotionDiscountPercentage || t == PromotionDiscountFixed
+}
+
+// PromotionFunding represents who bears the cost of a promotion discount
+type PromotionFunding string
+
+const (
+	PromotionFundedByPlatform PromotionFunding = "PLATFORM" // Platform pays the discount; the vendor still earns the full price
+	PromotionFundedByVendor   PromotionFunding = "VENDOR"   // Vendor earns the discounted price
+)
+
+// IsValid checks if the funding source is supported
+func (f PromotionFunding) IsValid() bool {
+	return f == PromotionFundedByPlatform || f == PromotionFundedByVendor
+}
+
+// PromotionStatus represents the status of a promotion
+type PromotionStatus string
+
+const (
+	PromotionStatusActive      PromotionStatus = "ACTIVE"
+	PromotionStatusDeactivated PromotionStatus = "DEACTIVATED"
+)
+
+// PromotionTerms holds the rules of a promotion
+type PromotionTerms struct {
+	Code             string
+	Name             string
+	Description      string
+	DiscountType     PromotionDiscountType
+	DiscountValue    int // Percentage (1-100) or fixed amount in VND
+	MaxDiscount      int // Cap for percentage discounts, 0 = no cap
+	MinOrderAmount   int // Minimum order amount in VND, 0 = no minimum
+	FundedBy         PromotionFunding
+	VendorID         string   // Restricts the promotion to one vendor, empty = all vendors
+	ServiceIDs       []string // Restricts the promotion to bookings including one of these services, empty = all services
+	FirstBookingOnly bool     // Only customers without a previous paid booking can redeem
+	StartsAt         time.Time
+	EndsAt           time.Time
+	UsageLimit       int // Total redemptions allowed, 0 = unlimited
+	PerUserLimit     int // Redemptions allowed per customer, 0 = unlimited
+}
+
+// Promotion is a coupon code or offer that discounts bookings. Redemptions are kept on the
+// aggregate so usage limits are enforced in the same write as the redemption.
+type Promotion struct {
+	id          string
+	terms       PromotionTerms
+	status      PromotionStatus
+	redemptions []event.PromotionRedemption
+	createdBy   string
+	version     int
+	createdAt   time.Time
+	updatedAt   time.Time
+
+	uncommittedEvents []event.DomainEvent
+}
+
+// NormalizePromotionCode returns the canonical form of a coupon code
+func NormalizePromotionCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// NewPromotion creates a new promotion
+func NewPromotion(promotionID string, terms PromotionTerms, createdBy string) (*Promotion, error) {
+	if promotionID == "" {
+		return nil, fmt.Errorf("promotion ID cannot be empty")
+	}
+
+	terms.Code = NormalizePromotionCode(terms.Code)
+	if terms.Code == "" {
+		return nil, fmt.Errorf("promotion code cannot be empty")
+	}
+	if strings.ContainsAny(terms.Code, " \t") {
+		return nil, fmt.Errorf("promotion code cannot contain spaces")
+	}
+	if terms.Name == "" {
+		return nil, fmt.Errorf("promotion name cannot be empty")
+	}
+	if !terms.DiscountType.IsValid() {
+		return nil, fmt.Errorf("invalid discount type: %s", terms.DiscountType)
+	}
+	if terms.DiscountValue <= 0 {
+		return nil, fmt.Errorf("discount value must be greater than 0")
+	}
+	if terms.DiscountType == PromotionDiscountPercentage && terms.DiscountValue > 100 {
+		return nil, fmt.Errorf("percentage discount cannot exceed 100")
+	}
+	if terms.MaxDiscount < 0 || terms.MinOrderAmount < 0 || terms.UsageLimit < 0 || terms.PerUserLimit < 0 {
+		return nil, fmt.Errorf("limits cannot be negative")
+	}
+	if !terms.FundedBy.IsValid() {
+		return nil, fmt.Errorf("invalid funding source: %s", terms.FundedBy)
+	}
+	if terms.FundedBy == PromotionFundedByVendor && terms.VendorID == "" {
+		return nil, fmt.Errorf("vendor-funded promotions must be scoped to a vendor")
+	}
+	if terms.StartsAt.IsZero() || terms.EndsAt.IsZero() {
+		return nil, fmt.Errorf("validity window is required")
+	}
+	if !terms.EndsAt.After(terms.StartsAt) {
+		return nil, fmt.Errorf("promotion must end after it starts")
+	}
+	if terms.ServiceIDs == nil {
+		terms.ServiceIDs = []string{}
+	}
+
+	promotion := &Promotion{}
+	promotion.raiseEvent(&event.PromotionCreated{
+		PromotionID:      promotionID,
+		Code:             terms.Code,
+		Name:             terms.Name,
+		Description:      terms.Description,
+		DiscountType:     string(terms.DiscountType),
+		DiscountValue:    terms.DiscountValue,
+		MaxDiscount:      terms.MaxDiscount,
+		MinOrderAmount:   terms.MinOrderAmount,
+		FundedBy:         string(terms.FundedBy),
+		VendorID:         terms.VendorID,
+		ServiceIDs:       terms.ServiceIDs,
+		FirstBookingOnly: terms.FirstBookingOnly,
+		StartsAt:         terms.StartsAt,
+		EndsAt:           terms.EndsAt,
+		UsageLimit:       terms.UsageLimit,
+		PerUserLimit:     terms.PerUserLimit,
+		CreatedBy:        createdBy,
+		Timestamp:        time.Now(),
+	})
+
+	return promotion, nil
+}
+
+// ReconstructPromotion rebuilds a Promotion aggregate from database state WITHOUT raising events
+func ReconstructPromotion(
+	id string,
+	terms PromotionTerms,
+	status PromotionStatus,
+	redemptions []event.PromotionRedemption,
+	createdBy string,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Promotion {
+	return &Promotion{
+		id:          id,
+		terms:       terms,
+		status:      status,
+		redemptions: redemptions,
+		createdBy:   createdBy,
+		version:     version,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// CalculateDiscount returns the discount for an order amount. A 100% coupon or a fixed discount at or above
+// the amount still leaves 1 VND to pay, since a payment cannot be for nothing.
+func (p *Promotion) CalculateDiscount(amount int) int {
+	discount := p.terms.DiscountValue
+	if p.terms.DiscountType == PromotionDiscountPercentage {
+		discount = amount * p.terms.DiscountValue / 100
+		if p.terms.MaxDiscount > 0 && discount > p.terms.MaxDiscount {
+			discount = p.terms.MaxDiscount
+		}
+	}
+	if discount >= amount {
+		discount = amount - 1
+	}
+	return discount
+}
+
+// CheckEligibility verifies that a booking can use the promotion and returns its discount
+func (p *Promotion) CheckEligibility(userID, vendorID string, serviceIDs []string, amount int, at time.Time, firstBooking bool) (int, error) {
+	if p.status != PromotionStatusActive {
+		return 0, fmt.Errorf("promotion is no longer active")
+	}
+	if at.Before(p.terms.StartsAt) {
+		return 0, fmt.Errorf("promotion has not started yet")
+	}
+	if !at.Before(p.terms.EndsAt) {
+		return 0, fmt.Errorf("promotion has ended")
+	}
+	if p.terms.VendorID != "" && p.terms.VendorID != vendorID {
+		return 0, fmt.Errorf("promotion is not valid for this vendor")
+	}
+	if len(p.terms.ServiceIDs) > 0 && !p.coversAnyService(serviceIDs) {
+		return 0, fmt.Errorf("promotion is not valid for the selected services")
+	}
+	if amount < p.terms.MinOrderAmount {
+		return 0, fmt.Errorf("order amount must be at least %d VND to use this promotion", p.terms.MinOrderAmount)
+	}
+	if p.terms.FirstBookingOnly && !firstBooking {
+		return 0, fmt.Errorf("promotion is only valid for a first booking")
+	}
+	if p.terms.UsageLimit > 0 && p.ActiveRedemptionCount() >= p.terms.UsageLimit {
+		return 0, fmt.Errorf("promotion has been fully redeemed")
+	}
+	if p.terms.PerUserLimit > 0 && p.UserRedemptionCount(userID) >= p.terms.PerUserLimit {
+		return 0, fmt.Errorf("promotion usage limit reached for this customer")
+	}
+
+	discount := p.CalculateDiscount(amount)
+	if discount <= 0 {
+		return 0, fmt.Errorf("promotion gives no discount for this order")
+	}
+	return discount, nil
+}
+
+// Redeem records the use of the promotion by a payment and returns the discount granted
+func (p *Promotion) Redeem(paymentID, userID, vendorID string, serviceIDs []string, amount int, at time.Time, firstBooking bool) (int, error) {
+	if paymentID == "" || userID == "" {
+		return 0, fmt.Errorf("payment ID and user ID cannot be empty")
+	}
+	if p.HasRedemption(paymentID) {
+		return 0, fmt.Errorf("payment %s has already redeemed this promotion", paymentID)
+	}
+
+	discount, err := p.CheckEligibility(userID, vendorID, serviceIDs, amount, at, firstBooking)
+	if err != nil {
+		return 0, err
+	}
+
+	p.raiseEvent(&event.PromotionRedeemed{
+		PromotionID: p.id,
+		Code:        p.terms.Code,
+		Redemption: event.PromotionRedemption{
+			PaymentID:      paymentID,
+			UserID:         userID,
+			VendorID:       vendorID,
+			DiscountAmount: discount,
+			RedeemedAt:     at,
+		},
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return discount, nil
+}
+
+// Release gives back the redemption of a payment that did not complete. Releasing a payment
+// without an active redemption is a no-op so the operation can be retried safely.
+func (p *Promotion) Release(paymentID, reason string) bool {
+	if !p.HasRedemption(paymentID) {
+		return false
+	}
+
+	p.raiseEvent(&event.PromotionRedemptionReleased{
+		PromotionID:  p.id,
+		PaymentID:    paymentID,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return true
+}
+
+// Deactivate switches the promotion off; existing redemptions are kept
+func (p *Promotion) Deactivate(reason string) error {
+	if p.status == PromotionStatusDeactivated {
+		return fmt.Errorf("promotion is already deactivated")
+	}
+
+	p.raiseEvent(&event.PromotionDeactivated{
+		PromotionID:  p.id,
+		Reason:       reason,
+		EventVersion: p.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// HasRedemption checks if a payment holds an active redemption of the promotion
+func (p *Promotion) HasRedemption(paymentID string) bool {
+	for _, redemption := range p.redemptions {
+		if redemption.PaymentID == paymentID && !redemption.Released {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveRedemptionCount returns the number of redemptions that have not been released
+func (p *Promotion) ActiveRedemptionCount() int {
+	count := 0
+	for _, redemption := range p.redemptions {
+		if !redemption.Released {
+			count++
+		}
+	}
+	return count
+}
+
+// UserRedemptionCount returns the number of active redemptions by a customer
+func (p *Promotion) UserRedemptionCount(userID string) int {
+	count := 0
+	for _, redemption := range p.redemptions {
+		if redemption.UserID == userID && !redemption.Released {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *Promotion) coversAnyService(serviceIDs []string) bool {
+	for _, scoped := range p.terms.ServiceIDs {
+		for _, serviceID := range serviceIDs {
+			if scoped == serviceID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *Promotion) raiseEvent(ev event.DomainEvent) {
+	p.uncommittedEvents = append(p.uncommittedEvents, ev)
+	p.applyEvent(ev)
+}
+
+func (p *Promotion) applyEvent(ev event.DomainEvent) error {
+	switch e := ev.(type) {
+	case *event.PromotionCreated:
+		p.id = e.PromotionID
+		p.terms = PromotionTerms{
+			Code:             e.Code,
+			Name:             e.Name,
+			Description:      e.Description,
+			DiscountType:     PromotionDiscountType(e.DiscountType),
+			DiscountValue:    e.DiscountValue,
+			MaxDiscount:      e.MaxDiscount,
+			MinOrderAmount:   e.MinOrderAmount,
+			FundedBy:         PromotionFunding(e.FundedBy),
+			VendorID:         e.VendorID,
+			ServiceIDs:       e.ServiceIDs,
+			FirstBookingOnly: e.FirstBookingOnly,
+			StartsAt:         e.StartsAt,
+			EndsAt:           e.EndsAt,
+			UsageLimit:       e.UsageLimit,
+			PerUserLimit:     e.PerUserLimit,
+		}
+		p.status = PromotionStatusActive
+		p.redemptions = []event.PromotionRedemption{}
+		p.createdBy = e.CreatedBy
+		p.version = 1
+		p.createdAt = e.Timestamp
+		p.updatedAt = e.Timestamp
+
+	case *event.PromotionRedeemed:
+		p.redemptions = append(p.redemptions, e.Redemption)
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PromotionRedemptionReleased:
+		for i := range p.redemptions {
+			if p.redemptions[i].PaymentID == e.PaymentID && !p.redemptions[i].Released {
+				p.redemptions[i].Released = true
+				p.redemptions[i].ReleasedAt = e.Timestamp
+			}
+		}
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	case *event.PromotionDeactivated:
+		p.status = PromotionStatusDeactivated
+		p.version = e.EventVersion
+		p.updatedAt = e.Timestamp
+
+	default:
+		return fmt.Errorf("unknown event type: %T", ev)
+	}
+
+	return nil
+}
+
+// Getters
+func (p *Promotion) ID() string                               { return p.id }
+func (p *Promotion) Code() string                             { return p.terms.Code }
+func (p *Promotion) Terms() PromotionTerms                    { return p.terms }
+func (p *Promotion) FundedBy() PromotionFunding               { return p.terms.FundedBy }
+func (p *Promotion) VendorID() string                         { return p.terms.VendorID }
+func (p *Promotion) FirstBookingOnly() bool                   { return p.terms.FirstBookingOnly }
+func (p *Promotion) Status() PromotionStatus                  { return p.status }
+func (p *Promotion) Redemptions() []event.PromotionRedemption { return p.redemptions }
+func (p *Promotion) CreatedBy() string                        { return p.createdBy }
+func (p *Promotion) Version() int                             { return p.version }
+func (p *Promotion) CreatedAt() time.Time                     { return p.createdAt }
+func (p *Promotion) UpdatedAt() time.Time                     { return p.updatedAt }
+
+// Entity interface implementation
+func (p *Promotion) GetID() string      { return p.id }
+func (p *Promotion) GetVersion() int    { return p.version }
+func (p *Promotion) SetVersion(ver int) { p.version = ver }
+
+// AggregateRoot interface implementation
+func (p *Promotion) GetUncommittedEvents() []event.DomainEvent {
+	return p.uncommittedEvents
+}
+
+func (p *Promotion) MarkEventsAsCommitted() {
+	p.uncommittedEvents = nil
+}
+
+func (p *Promotion) LoadFromHistory(events []event.DomainEvent) error {
+	for _, e := range events {
+		if err := p.applyEvent(e); err != nil {
+			return fmt.Errorf("failed to apply event %s: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package aggregate
 
 import (
 	"fmt"
+	"strings"
 	"time"
 	"whisko-petcare/internal/domain/event"
 
@@ -38,15 +39,154 @@ type BookedServices struct {
 	Name      string `json:"name" bson:"name"`
 }
 
+// CareBriefConsent records an owner's consent to share one booked pet's care brief with the booked shop
+type CareBriefConsent struct {
+	PetID    string    `json:"pet_id" bson:"pet_id"`
+	SharedBy string    `json:"shared_by" bson:"shared_by"`
+	SharedAt time.Time `json:"shared_at" bson:"shared_at"`
+}
+
+// AssignedStaff is the staff member of the booked shop who does the work
+type AssignedStaff struct {
+	UserID       string    `json:"user_id" bson:"user_id"`
+	AssignedBy   string    `json:"assigned_by" bson:"assigned_by"`
+	AutoAssigned bool      `json:"auto_assigned" bson:"auto_assigned"`
+	AssignedAt   time.Time `json:"assigned_at" bson:"assigned_at"`
+}
+
 type ScheduleStatus string
 
 const (
-	ScheduleStatusPending   ScheduleStatus = "pending"
+	ScheduleStatusPending    ScheduleStatus = "pending"
 	ScheduleStatusConfirmed  ScheduleStatus = "confirmed"
+	ScheduleStatusInProgress ScheduleStatus = "in_progress"
 	ScheduleStatusCompleted  ScheduleStatus = "completed"
 	ScheduleStatusCancelled  ScheduleStatus = "cancelled"
+	ScheduleStatusNoShow     ScheduleStatus = "no_show"
+)
+
+// IsValid reports whether the status is a known schedule status
+func (s ScheduleStatus) IsValid() bool {
+	switch s {
+	case ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleStatusInProgress,
+		ScheduleStatusCompleted, ScheduleStatusCancelled, ScheduleStatusNoShow:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether the booking is over: no transition leaves a final status
+func (s ScheduleStatus) IsFinal() bool {
+	return s == ScheduleStatusCompleted || s == ScheduleStatusCancelled || s == ScheduleStatusNoShow
+}
+
+// ScheduleActor is who moves a schedule from one status to another
+type ScheduleActor string
+
+const (
+	ScheduleActorCustomer    ScheduleActor = "customer"     // The booking user or an owner of the pet
+	ScheduleActorVendorStaff ScheduleActor = "vendor_staff" // Active staff of the booked shop
+	ScheduleActorSystem      ScheduleActor = "system"       // Background jobs and payment callbacks
+	ScheduleActorAdmin       ScheduleActor = "admin"
 )
 
+// Timing rules of schedule transitions
+const (
+	ScheduleCheckInWindow     = 30 * time.Minute // Staff may start a visit this long before the booking starts
+	ScheduleNoShowGracePeriod = 15 * time.Minute // A pet that has not arrived this long after the start is a no-show
+)
+
+// ScheduleTransitionErrorKind tells why a status change was refused
+type ScheduleTransitionErrorKind string
+
+const (
+	ScheduleTransitionUndefined ScheduleTransitionErrorKind = "UNDEFINED"   // The state machine has no such transition
+	ScheduleTransitionForbidden ScheduleTransitionErrorKind = "FORBIDDEN"   // The actor may not make the transition
+	ScheduleTransitionOutOfTime ScheduleTransitionErrorKind = "OUT_OF_TIME" // The transition is not allowed at this time
+)
+
+// ScheduleTransitionError is returned when a schedule cannot move to the requested status
+type ScheduleTransitionError struct {
+	Kind   ScheduleTransitionErrorKind
+	From   ScheduleStatus
+	To     ScheduleStatus
+	Actor  ScheduleActor
+	Reason string
+}
+
+func (e *ScheduleTransitionError) Error() string {
+	return fmt.Sprintf("cannot move schedule from %s to %s: %s", e.From, e.To, e.Reason)
+}
+
+// scheduleTransition is a status change the state machine allows for the listed actors. The timing
+// rule, when set, returns why the change is not allowed at the given time.
+type scheduleTransition struct {
+	from   ScheduleStatus
+	to     ScheduleStatus
+	actors []ScheduleActor
+	timing func(s *Schedule, at time.Time) string
+}
+
+// scheduleTransitions is the state machine of a booking. A status pair appears once per set of actors
+// that share a timing rule.
+var scheduleTransitions = []scheduleTransition{
+	{ScheduleStatusPending, ScheduleStatusConfirmed, []ScheduleActor{ScheduleActorVendorStaff, ScheduleActorSystem, ScheduleActorAdmin}, beforeScheduleEnd},
+	{ScheduleStatusPending, ScheduleStatusCancelled, []ScheduleActor{ScheduleActorCustomer, ScheduleActorVendorStaff, ScheduleActorSystem, ScheduleActorAdmin}, nil},
+
+	{ScheduleStatusConfirmed, ScheduleStatusInProgress, []ScheduleActor{ScheduleActorVendorStaff, ScheduleActorAdmin}, withinScheduleCheckIn},
+	{ScheduleStatusConfirmed, ScheduleStatusCompleted, []ScheduleActor{ScheduleActorVendorStaff, ScheduleActorAdmin}, afterScheduleStart},
+	{ScheduleStatusConfirmed, ScheduleStatusCompleted, []ScheduleActor{ScheduleActorSystem}, afterScheduleEnd},
+	{ScheduleStatusConfirmed, ScheduleStatusCancelled, []ScheduleActor{ScheduleActorCustomer, ScheduleActorVendorStaff}, beforeScheduleStart},
+	{ScheduleStatusConfirmed, ScheduleStatusCancelled, []ScheduleActor{ScheduleActorSystem, ScheduleActorAdmin}, nil},
+	{ScheduleStatusConfirmed, ScheduleStatusNoShow, []ScheduleActor{ScheduleActorVendorStaff, ScheduleActorAdmin}, afterScheduleNoShowGrace},
+
+	{ScheduleStatusInProgress, ScheduleStatusCompleted, []ScheduleActor{ScheduleActorVendorStaff, ScheduleActorAdmin}, nil},
+	{ScheduleStatusInProgress, ScheduleStatusCompleted, []ScheduleActor{ScheduleActorSystem}, afterScheduleEnd},
+	{ScheduleStatusInProgress, ScheduleStatusCancelled, []ScheduleActor{ScheduleActorAdmin}, nil},
+}
+
+func beforeScheduleStart(s *Schedule, at time.Time) string {
+	if !at.Before(s.startTime) {
+		return "the booking has already started"
+	}
+	return ""
+}
+
+func beforeScheduleEnd(s *Schedule, at time.Time) string {
+	if at.After(s.endTime) {
+		return "the booking has already ended"
+	}
+	return ""
+}
+
+func afterScheduleStart(s *Schedule, at time.Time) string {
+	if at.Before(s.startTime) {
+		return "the booking has not started yet"
+	}
+	return ""
+}
+
+func afterScheduleEnd(s *Schedule, at time.Time) string {
+	if at.Before(s.endTime) {
+		return "the booking has not ended yet"
+	}
+	return ""
+}
+
+func withinScheduleCheckIn(s *Schedule, at time.Time) string {
+	if at.Before(s.startTime.Add(-ScheduleCheckInWindow)) {
+		return fmt.Sprintf("the visit can start at most %s before the booking", ScheduleCheckInWindow)
+	}
+	return beforeScheduleEnd(s, at)
+}
+
+func afterScheduleNoShowGrace(s *Schedule, at time.Time) string {
+	if at.Before(s.startTime.Add(ScheduleNoShowGracePeriod)) {
+		return fmt.Sprintf("a no-show can be recorded %s after the booking starts", ScheduleNoShowGracePeriod)
+	}
+	return ""
+}
+
 type Schedule struct {
 	id               string
 	bookingUser      BookingUser
@@ -60,10 +200,86 @@ type Schedule struct {
 	version          int
 	isActive         bool
 
+	// One per booked pet whose owner shares its care brief with the booked shop
+	careBriefConsents []CareBriefConsent
+
+	// Submitted by vendor staff when the visit is completed
+	visitReport *event.VisitReport
+
+	// Payment that created the booking and the price the customer agreed to; empty for bookings made
+	// without a payment
+	paymentID  string
+	totalPrice int
+
+	// Recurring booking the schedule is an occurrence of, if any
+	seriesID string
+
+	rescheduleCount    int
+	rescheduleProposal *event.RescheduleProposal // Set while a vendor proposal waits for the customer
+	priceAdjustments   []event.SchedulePriceAdjustment
+
+	// Set once the booking is assigned to a staff member of the shop
+	assignedStaff *AssignedStaff
+
+	// One line per pet on bookings covering several pets; assignedPet is then the first pet still booked
+	// and bookedShop lists the services of the pets still booked
+	petLines []event.SchedulePetLine
+
 	uncommittedEvents []event.DomainEvent
 }
 
-func NewSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet PetAssigned, startTime, endTime time.Time) (*Schedule, error) {
+func NewSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet PetAssigned, startTime, endTime time.Time, paymentID string, totalPrice int) (*Schedule, error) {
+	return newSchedule(bookingUser, bookedShop, assignedPet, startTime, endTime, paymentID, totalPrice, "", nil)
+}
+
+// NewMultiPetSchedule creates a booking covering several pets, each with its own services. The pets are
+// seen one after another, so the booked time must cover the combined duration of every pet's services.
+func NewMultiPetSchedule(bookingUser BookingUser, bookedShop BookedVendor, petLines []event.SchedulePetLine, startTime, endTime time.Time, paymentID string, totalPrice int) (*Schedule, error) {
+	if len(petLines) == 0 {
+		return nil, fmt.Errorf("petLines cannot be empty")
+	}
+
+	seen := map[string]bool{}
+	combinedMinutes := 0
+	lines := make([]event.SchedulePetLine, 0, len(petLines))
+	for _, line := range petLines {
+		if line.PetID == "" {
+			return nil, fmt.Errorf("petID cannot be empty")
+		}
+		if seen[line.PetID] {
+			return nil, fmt.Errorf("pet %s is booked twice", line.PetID)
+		}
+		seen[line.PetID] = true
+		if len(line.Services) == 0 {
+			return nil, fmt.Errorf("pet %s has no services", line.PetID)
+		}
+		if line.DurationMinutes < 0 || line.Price < 0 {
+			return nil, fmt.Errorf("duration and price of pet %s cannot be negative", line.PetID)
+		}
+		combinedMinutes += line.DurationMinutes
+
+		line.Status = event.SchedulePetLineBooked
+		line.CancelledAt = time.Time{}
+		lines = append(lines, line)
+	}
+
+	if endTime.Sub(startTime) < time.Duration(combinedMinutes)*time.Minute {
+		return nil, fmt.Errorf("booked time is shorter than the %d minutes the pets' services take", combinedMinutes)
+	}
+
+	bookedShop.BookedServices = petLineServices(lines)
+	return newSchedule(bookingUser, bookedShop, petLinePet(lines[0]), startTime, endTime, paymentID, totalPrice, "", lines)
+}
+
+// NewSeriesSchedule creates the booking of one occurrence of a recurring booking series
+func NewSeriesSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet PetAssigned, startTime, endTime time.Time, paymentID string, totalPrice int, seriesID string) (*Schedule, error) {
+	if seriesID == "" {
+		return nil, fmt.Errorf("seriesID cannot be empty")
+	}
+	return newSchedule(bookingUser, bookedShop, assignedPet, startTime, endTime, paymentID, totalPrice, seriesID, nil)
+}
+
+func newSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet PetAssigned, startTime, endTime time.Time, paymentID string, totalPrice int, seriesID string, petLines []event.SchedulePetLine) (*Schedule, error) {
 	if bookingUser.UserID == "" {
 		return nil, fmt.Errorf("userID cannot be empty")
 	}
@@ -82,6 +298,9 @@ func NewSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet P
 	if endTime.Before(startTime) {
 		return nil, fmt.Errorf("endTime must be after startTime")
 	}
+	if totalPrice < 0 {
+		return nil, fmt.Errorf("totalPrice cannot be negative")
+	}
 
 	schedule := &Schedule{
 		id:          uuid.New().String(),
@@ -93,6 +312,10 @@ func NewSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet P
 		status:      ScheduleStatusPending,
 		createdAt:   time.Now(),
 		updatedAt:   time.Now(),
+		paymentID:   paymentID,
+		totalPrice:  totalPrice,
+		seriesID:    seriesID,
+		petLines:    petLines,
 		version:     1,
 		isActive:    true,
 	}
@@ -130,15 +353,68 @@ func NewSchedule(bookingUser BookingUser, bookedShop BookedVendor, assignedPet P
 			Age:     assignedPet.Age,
 			Weight:  assignedPet.Weight,
 		},
-		StartTime: startTime,
-		EndTime:   endTime,
-		Status:    string(schedule.status),
-		Timestamp: schedule.createdAt,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Status:     string(schedule.status),
+		PaymentID:  paymentID,
+		TotalPrice: totalPrice,
+		SeriesID:   seriesID,
+		PetLines:   petLines,
+		Timestamp:  schedule.createdAt,
 	})
 
 	return schedule, nil
 }
 
+// ReconstructSchedule rebuilds a schedule from stored state without raising events
+func ReconstructSchedule(id string, bookingUser BookingUser, bookedShop BookedVendor, assignedPet PetAssigned,
+	startTime, endTime time.Time, status ScheduleStatus, careBriefConsents []CareBriefConsent, visitReport *event.VisitReport,
+	version int, createdAt, updatedAt time.Time, isActive bool) *Schedule {
+	return &Schedule{
+		id:                id,
+		bookingUser:       bookingUser,
+		bookedShop:        bookedShop,
+		assignedPet:       assignedPet,
+		startTime:         startTime,
+		endTime:           endTime,
+		status:            status,
+		careBriefConsents: careBriefConsents,
+		visitReport:       visitReport,
+		version:           version,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+		isActive:          isActive,
+	}
+}
+
+// SetBilling sets the payment and price of the booking (used by repository during reconstruction)
+func (s *Schedule) SetBilling(paymentID string, totalPrice int) {
+	s.paymentID = paymentID
+	s.totalPrice = totalPrice
+}
+
+// SetSeriesID sets the recurring booking the schedule belongs to (used by repository during reconstruction)
+func (s *Schedule) SetSeriesID(seriesID string) {
+	s.seriesID = seriesID
+}
+
+// SetReschedules sets the reschedule history of the booking (used by repository during reconstruction)
+func (s *Schedule) SetReschedules(count int, proposal *event.RescheduleProposal, adjustments []event.SchedulePriceAdjustment) {
+	s.rescheduleCount = count
+	s.rescheduleProposal = proposal
+	s.priceAdjustments = adjustments
+}
+
+// SetPetLines sets the pets of a booking covering several pets (used by repository during reconstruction)
+func (s *Schedule) SetPetLines(petLines []event.SchedulePetLine) {
+	s.petLines = petLines
+}
+
+// SetAssignedStaff sets the staff member assigned to the booking (used by repository during reconstruction)
+func (s *Schedule) SetAssignedStaff(staff *AssignedStaff) {
+	s.assignedStaff = staff
+}
+
 func NewScheduleFromHistory(events []event.DomainEvent) (*Schedule, error) {
 	if len(events) == 0 {
 		return nil, fmt.Errorf("no events provided")
@@ -154,16 +430,79 @@ func NewScheduleFromHistory(events []event.DomainEvent) (*Schedule, error) {
 	return schedule, nil
 }
 
-// ChangeStatus changes the status of the schedule
-func (s *Schedule) ChangeStatus(newStatus ScheduleStatus) error {
-	if s.status == newStatus {
-		return fmt.Errorf("schedule is already in status %s", newStatus)
+// CanTransitionTo checks the state machine for a move from the current status to the given one by the
+// actor at the given time. It returns a *ScheduleTransitionError when the move is not allowed.
+func (s *Schedule) CanTransitionTo(to ScheduleStatus, actor ScheduleActor, at time.Time) error {
+	refuse := func(kind ScheduleTransitionErrorKind, reason string) error {
+		return &ScheduleTransitionError{Kind: kind, From: s.status, To: to, Actor: actor, Reason: reason}
 	}
-	
+
+	if s.status == to {
+		return refuse(ScheduleTransitionUndefined, fmt.Sprintf("schedule is already %s", to))
+	}
+
+	defined := false
+	for _, transition := range scheduleTransitions {
+		if transition.from != s.status || transition.to != to {
+			continue
+		}
+		defined = true
+		if !containsScheduleActor(transition.actors, actor) {
+			continue
+		}
+		if transition.timing != nil {
+			if reason := transition.timing(s, at); reason != "" {
+				return refuse(ScheduleTransitionOutOfTime, reason)
+			}
+		}
+		return nil
+	}
+
+	if !defined {
+		return refuse(ScheduleTransitionUndefined, fmt.Sprintf("a %s booking cannot become %s", s.status, to))
+	}
+	return refuse(ScheduleTransitionForbidden, fmt.Sprintf("%s cannot make this change", actor))
+}
+
+// AllowedTransitions lists the statuses the actor can move the schedule to at the given time
+func (s *Schedule) AllowedTransitions(actor ScheduleActor, at time.Time) []ScheduleStatus {
+	allowed := []ScheduleStatus{}
+	seen := map[ScheduleStatus]bool{}
+	for _, transition := range scheduleTransitions {
+		if transition.from != s.status || seen[transition.to] {
+			continue
+		}
+		seen[transition.to] = true
+		if s.CanTransitionTo(transition.to, actor, at) == nil {
+			allowed = append(allowed, transition.to)
+		}
+	}
+	return allowed
+}
+
+// ChangeStatus moves the schedule to a new status. Completing and cancelling go through Complete and
+// Cancel; the reason is only used when cancelling.
+func (s *Schedule) ChangeStatus(newStatus ScheduleStatus, actor ScheduleActor, reason string) error {
+	if !newStatus.IsValid() {
+		return fmt.Errorf("invalid status: %s", newStatus)
+	}
+
+	switch newStatus {
+	case ScheduleStatusCompleted:
+		return s.Complete(actor)
+	case ScheduleStatusCancelled:
+		return s.Cancel(reason, actor)
+	}
+
+	if err := s.CanTransitionTo(newStatus, actor, time.Now()); err != nil {
+		return err
+	}
+
 	s.raiseEvent(&event.ScheduleStatusChanged{
 		ScheduleID:   s.id,
 		OldStatus:    string(s.status),
 		NewStatus:    string(newStatus),
+		Actor:        string(actor),
 		EventVersion: s.version + 1,
 		Timestamp:    time.Now(),
 	})
@@ -172,36 +511,635 @@ func (s *Schedule) ChangeStatus(newStatus ScheduleStatus) error {
 }
 
 // Complete marks the schedule as completed
-func (s *Schedule) Complete() error {
-	if s.status == ScheduleStatusCompleted {
-		return fmt.Errorf("schedule is already completed")
+func (s *Schedule) Complete(actor ScheduleActor) error {
+	if err := s.CanTransitionTo(ScheduleStatusCompleted, actor, time.Now()); err != nil {
+		return err
 	}
-	
+
 	s.raiseEvent(&event.ScheduleCompleted{
 		ScheduleID:   s.id,
+		Actor:        string(actor),
 		EventVersion: s.version + 1,
 		Timestamp:    time.Now(),
 	})
-	
+
 	return nil
 }
 
 // Cancel cancels the schedule
-func (s *Schedule) Cancel(reason string) error {
-	if s.status == ScheduleStatusCancelled {
-		return fmt.Errorf("schedule is already cancelled")
+func (s *Schedule) Cancel(reason string, actor ScheduleActor) error {
+	if err := s.CanTransitionTo(ScheduleStatusCancelled, actor, time.Now()); err != nil {
+		return err
 	}
-	
+
 	s.raiseEvent(&event.ScheduleCancelled{
 		ScheduleID:   s.id,
 		Reason:       reason,
+		Actor:        string(actor),
 		EventVersion: s.version + 1,
 		Timestamp:    time.Now(),
 	})
-	
+
+	return nil
+}
+
+// CancelPet cancels one pet of a booking covering several pets. The actor needs the same rights as for
+// cancelling the whole booking, and the last pet still booked only goes with the booking. The price drops
+// by the pet's share of the list price of the pets still booked, so discounts carry over.
+func (s *Schedule) CancelPet(petID, reason string, actor ScheduleActor) error {
+	if len(s.petLines) == 0 {
+		return fmt.Errorf("booking covers a single pet, cancel the booking instead")
+	}
+	if err := s.CanTransitionTo(ScheduleStatusCancelled, actor, time.Now()); err != nil {
+		return err
+	}
+
+	cancelled := -1
+	bookedPrice := 0
+	var remaining []event.SchedulePetLine
+	for i, line := range s.petLines {
+		if line.Status != event.SchedulePetLineBooked {
+			continue
+		}
+		bookedPrice += line.Price
+		if line.PetID == petID {
+			cancelled = i
+			continue
+		}
+		remaining = append(remaining, line)
+	}
+	if cancelled < 0 {
+		return fmt.Errorf("pet %s is not booked", petID)
+	}
+	if len(remaining) == 0 {
+		return fmt.Errorf("pet %s is the last pet of the booking, cancel the booking instead", petID)
+	}
+
+	newPrice := s.totalPrice
+	if bookedPrice > 0 {
+		newPrice -= s.totalPrice * s.petLines[cancelled].Price / bookedPrice
+	}
+
+	primaryPet := petLinePet(remaining[0])
+	var bookedServices []event.BookedServicesData
+	for _, svc := range petLineServices(remaining) {
+		bookedServices = append(bookedServices, event.BookedServicesData{
+			ServiceID: svc.ServiceID,
+			Name:      svc.Name,
+		})
+	}
+
+	s.raiseEvent(&event.SchedulePetCancelled{
+		ScheduleID: s.id,
+		PetID:      petID,
+		Reason:     reason,
+		Actor:      string(actor),
+		OldPrice:   s.totalPrice,
+		NewPrice:   newPrice,
+		PrimaryPet: event.PetAssignedData{
+			PetID:   primaryPet.PetID,
+			Name:    primaryPet.Name,
+			Species: primaryPet.Species,
+			Breed:   primaryPet.Breed,
+			Age:     primaryPet.Age,
+			Weight:  primaryPet.Weight,
+		},
+		BookedServices: bookedServices,
+		EventVersion:   s.version + 1,
+		Timestamp:      time.Now(),
+	})
+
+	return nil
+}
+
+// BookedServiceIDs lists the services of the booking, once per pet they are booked for
+func (s *Schedule) BookedServiceIDs() []string {
+	var serviceIDs []string
+	if len(s.petLines) == 0 {
+		for _, svc := range s.bookedShop.BookedServices {
+			serviceIDs = append(serviceIDs, svc.ServiceID)
+		}
+		return serviceIDs
+	}
+
+	for _, line := range s.petLines {
+		if line.Status != event.SchedulePetLineBooked {
+			continue
+		}
+		for _, svc := range line.Services {
+			serviceIDs = append(serviceIDs, svc.ServiceID)
+		}
+	}
+	return serviceIDs
+}
+
+// BookedPetIDs lists the pets still booked, the primary pet first
+func (s *Schedule) BookedPetIDs() []string {
+	if len(s.petLines) == 0 {
+		return []string{s.assignedPet.PetID}
+	}
+
+	var petIDs []string
+	for _, line := range s.petLines {
+		if line.Status == event.SchedulePetLineBooked {
+			petIDs = append(petIDs, line.PetID)
+		}
+	}
+	return petIDs
+}
+
+// HasBookedPet reports whether the pet is still booked
+func (s *Schedule) HasBookedPet(petID string) bool {
+	for _, bookedPetID := range s.BookedPetIDs() {
+		if bookedPetID == petID {
+			return true
+		}
+	}
+	return false
+}
+
+// ShareCareBrief lets staff of the booked shop read a booked pet's care brief during the booking. An empty
+// petID means the primary pet.
+func (s *Schedule) ShareCareBrief(petID, sharedBy string) error {
+	if s.status.IsFinal() {
+		return fmt.Errorf("cannot share the care brief of a %s booking", s.status)
+	}
+	if petID == "" {
+		petID = s.assignedPet.PetID
+	}
+	if !s.HasBookedPet(petID) {
+		return fmt.Errorf("pet %s is not booked", petID)
+	}
+	if s.CareBriefConsentFor(petID) != nil {
+		return fmt.Errorf("care brief is already shared")
+	}
+
+	s.raiseEvent(&event.ScheduleCareBriefShared{
+		ScheduleID:   s.id,
+		PetID:        petID,
+		ShopID:       s.bookedShop.ShopID,
+		SharedBy:     sharedBy,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// RevokeCareBrief withdraws consent to share a booked pet's care brief with the booked shop. An empty
+// petID means the primary pet.
+func (s *Schedule) RevokeCareBrief(petID, revokedBy string) error {
+	if petID == "" {
+		petID = s.assignedPet.PetID
+	}
+	if s.CareBriefConsentFor(petID) == nil {
+		return fmt.Errorf("care brief is not shared")
+	}
+
+	s.raiseEvent(&event.ScheduleCareBriefRevoked{
+		ScheduleID:   s.id,
+		PetID:        petID,
+		RevokedBy:    revokedBy,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// CareBriefConsentFor returns the owner's consent to share the pet's care brief, or nil when it is not shared
+func (s *Schedule) CareBriefConsentFor(petID string) *CareBriefConsent {
+	for i := range s.careBriefConsents {
+		if s.careBriefConsents[i].PetID == petID {
+			return &s.careBriefConsents[i]
+		}
+	}
+	return nil
+}
+
+// CareBriefReadableAt reports whether staff of the booked shop may read the pet's care brief at the given
+// time: the owner has shared it, the booking is open and the time falls within the booking
+func (s *Schedule) CareBriefReadableAt(petID string, at time.Time) bool {
+	if s.CareBriefConsentFor(petID) == nil {
+		return false
+	}
+	if s.status.IsFinal() {
+		return false
+	}
+	return !at.Before(s.startTime) && !at.After(s.endTime)
+}
+
+// SubmitVisitReport records what the staff did during the visit and completes the booking if it is not
+// completed yet. Performed services must be among the booked services.
+func (s *Schedule) SubmitVisitReport(report event.VisitReport) error {
+	if s.status.IsFinal() && s.status != ScheduleStatusCompleted {
+		return fmt.Errorf("cannot report on a %s booking", s.status)
+	}
+	if s.visitReport != nil {
+		return fmt.Errorf("visit report is already submitted")
+	}
+	if report.SubmittedBy == "" {
+		return fmt.Errorf("submittedBy cannot be empty")
+	}
+	if len(report.ServicesPerformed) == 0 {
+		return fmt.Errorf("at least one performed service is required")
+	}
+	if strings.TrimSpace(report.Observations) == "" {
+		return fmt.Errorf("observations cannot be empty")
+	}
+	if report.Weight < 0 {
+		return fmt.Errorf("weight cannot be negative")
+	}
+	for _, photoURL := range report.PhotoURLs {
+		if !strings.HasPrefix(photoURL, "https://") && !strings.HasPrefix(photoURL, "http://") {
+			return fmt.Errorf("invalid photo URL: %s", photoURL)
+		}
+	}
+
+	services := make([]event.VisitReportService, 0, len(report.ServicesPerformed))
+	for _, performed := range report.ServicesPerformed {
+		booked, ok := s.bookedService(performed.ServiceID)
+		if !ok {
+			return fmt.Errorf("service %s was not booked", performed.ServiceID)
+		}
+		services = append(services, event.VisitReportService{
+			ServiceID: booked.ServiceID,
+			Name:      booked.Name,
+			Notes:     performed.Notes,
+		})
+	}
+
+	if s.status != ScheduleStatusCompleted {
+		if err := s.Complete(ScheduleActorVendorStaff); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	report.ID = uuid.New().String()
+	report.ServicesPerformed = services
+	report.SubmittedAt = now
+	report.AcceptedBy = ""
+	report.AcceptedAt = time.Time{}
+	report.MedicalRecordID = ""
+
+	s.raiseEvent(&event.ScheduleVisitReportSubmitted{
+		ScheduleID:   s.id,
+		PetID:        s.assignedPet.PetID,
+		ShopID:       s.bookedShop.ShopID,
+		Report:       report,
+		EventVersion: s.version + 1,
+		Timestamp:    now,
+	})
+
+	return nil
+}
+
+// AcceptVisitReport marks the visit report as accepted into a booked pet's medical history. Each pet of
+// the booking is accepted on its own, by one of its owners.
+func (s *Schedule) AcceptVisitReport(petID, acceptedBy, medicalRecordID string) error {
+	if s.visitReport == nil {
+		return fmt.Errorf("no visit report has been submitted")
+	}
+	if !s.HasBookedPet(petID) {
+		return fmt.Errorf("pet %s is not booked", petID)
+	}
+	if s.VisitReportAcceptedFor(petID) {
+		return fmt.Errorf("visit report is already accepted")
+	}
+	if medicalRecordID == "" {
+		return fmt.Errorf("medicalRecordID cannot be empty")
+	}
+
+	s.raiseEvent(&event.ScheduleVisitReportAccepted{
+		ScheduleID:      s.id,
+		PetID:           petID,
+		AcceptedBy:      acceptedBy,
+		MedicalRecordID: medicalRecordID,
+		EventVersion:    s.version + 1,
+		Timestamp:       time.Now(),
+	})
+
+	return nil
+}
+
+// VisitReportAcceptedFor reports whether the visit report is accepted into the pet's medical history
+func (s *Schedule) VisitReportAcceptedFor(petID string) bool {
+	if s.visitReport == nil {
+		return false
+	}
+	for _, record := range s.visitReport.MedicalRecords {
+		if record.PetID == petID {
+			return true
+		}
+	}
+	// Reports accepted before acceptance was kept per pet were accepted for the primary pet
+	return len(s.visitReport.MedicalRecords) == 0 && s.visitReport.AcceptedBy != "" && petID == s.assignedPet.PetID
+}
+
+// Reschedule moves the booking to a new time at the given price. Customers are bound by the vendor's
+// policy: no reschedules after the cut-off before the booking starts, and no more than the maximum
+// number of reschedules. Admins are not bound by the policy; vendor staff propose a new time instead.
+func (s *Schedule) Reschedule(startTime, endTime time.Time, price int, rescheduledBy string, actor ScheduleActor,
+	reason string, policy ReschedulePolicy) error {
+	switch actor {
+	case ScheduleActorCustomer:
+		if s.rescheduleCount >= policy.MaxReschedules {
+			return fmt.Errorf("this booking cannot be rescheduled more than %d times", policy.MaxReschedules)
+		}
+		cutoff := s.startTime.Add(-time.Duration(policy.CutoffHours) * time.Hour)
+		if !time.Now().Before(cutoff) {
+			return fmt.Errorf("bookings cannot be rescheduled later than %d hours before they start", policy.CutoffHours)
+		}
+	case ScheduleActorAdmin:
+	default:
+		return fmt.Errorf("%s cannot reschedule a booking", actor)
+	}
+
+	return s.reschedule(startTime, endTime, price, rescheduledBy, actor, reason, "")
+}
+
+// ProposeReschedule lets vendor staff propose a new time for the booking; the booking keeps its time
+// until the customer accepts
+func (s *Schedule) ProposeReschedule(startTime, endTime time.Time, proposedBy, reason string) error {
+	if err := s.checkReschedule(startTime, endTime); err != nil {
+		return err
+	}
+	if s.rescheduleProposal != nil {
+		return fmt.Errorf("a reschedule proposal is already waiting for the customer")
+	}
+	if proposedBy == "" {
+		return fmt.Errorf("proposedBy cannot be empty")
+	}
+
+	now := time.Now()
+	s.raiseEvent(&event.ScheduleRescheduleProposed{
+		ScheduleID: s.id,
+		ShopID:     s.bookedShop.ShopID,
+		Proposal: event.RescheduleProposal{
+			ID:         uuid.New().String(),
+			StartTime:  startTime,
+			EndTime:    endTime,
+			Reason:     reason,
+			ProposedBy: proposedBy,
+			ProposedAt: now,
+		},
+		EventVersion: s.version + 1,
+		Timestamp:    now,
+	})
+
+	return nil
+}
+
+// AcceptRescheduleProposal moves the booking to the time the vendor proposed. The cut-off does not apply
+// to vendor proposals, but the maximum number of reschedules does.
+func (s *Schedule) AcceptRescheduleProposal(acceptedBy string, price int, policy ReschedulePolicy) error {
+	if s.rescheduleProposal == nil {
+		return fmt.Errorf("no reschedule proposal is waiting")
+	}
+	if s.rescheduleCount >= policy.MaxReschedules {
+		return fmt.Errorf("this booking cannot be rescheduled more than %d times", policy.MaxReschedules)
+	}
+
+	proposal := s.rescheduleProposal
+	return s.reschedule(proposal.StartTime, proposal.EndTime, price, acceptedBy, ScheduleActorCustomer, proposal.Reason, proposal.ID)
+}
+
+// DeclineRescheduleProposal keeps the booking at its time and drops the vendor's proposal
+func (s *Schedule) DeclineRescheduleProposal(declinedBy, reason string) error {
+	if s.rescheduleProposal == nil {
+		return fmt.Errorf("no reschedule proposal is waiting")
+	}
+
+	s.raiseEvent(&event.ScheduleRescheduleDeclined{
+		ScheduleID:   s.id,
+		ProposalID:   s.rescheduleProposal.ID,
+		DeclinedBy:   declinedBy,
+		Reason:       reason,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// RecordPriceAdjustment records how a change in the price of the booking was paid or refunded
+func (s *Schedule) RecordPriceAdjustment(adjustment event.SchedulePriceAdjustment) error {
+	switch adjustment.Kind {
+	case event.PriceAdjustmentTopUp, event.PriceAdjustmentRefund, event.PriceAdjustmentAtShop:
+	default:
+		return fmt.Errorf("invalid price adjustment kind: %s", adjustment.Kind)
+	}
+	if adjustment.Amount <= 0 {
+		return fmt.Errorf("adjustment amount must be greater than 0")
+	}
+
+	now := time.Now()
+	adjustment.ID = uuid.New().String()
+	adjustment.CreatedAt = now
+
+	s.raiseEvent(&event.SchedulePriceAdjusted{
+		ScheduleID:   s.id,
+		Adjustment:   adjustment,
+		EventVersion: s.version + 1,
+		Timestamp:    now,
+	})
+
+	return nil
+}
+
+// RevertReschedule moves the booking back to the time and price it had before a reschedule whose top-up
+// payment lapsed. The reschedule no longer counts towards the vendor's limit.
+func (s *Schedule) RevertReschedule(topUpPaymentID string, startTime, endTime time.Time, reason string) error {
+	if s.status != ScheduleStatusPending && s.status != ScheduleStatusConfirmed {
+		return fmt.Errorf("cannot revert the reschedule of a %s booking", s.status)
+	}
+	if startTime.IsZero() || !endTime.After(startTime) {
+		return fmt.Errorf("invalid time to revert to")
+	}
+
+	var topUp *event.SchedulePriceAdjustment
+	for i := range s.priceAdjustments {
+		if s.priceAdjustments[i].Kind == event.PriceAdjustmentTopUp && s.priceAdjustments[i].PaymentID == topUpPaymentID {
+			topUp = &s.priceAdjustments[i]
+		}
+	}
+	if topUp == nil {
+		return fmt.Errorf("booking has no top-up payment %s", topUpPaymentID)
+	}
+
+	rescheduleCount := s.rescheduleCount - 1
+	if rescheduleCount < 0 {
+		rescheduleCount = 0
+	}
+
+	s.raiseEvent(&event.ScheduleRescheduleReverted{
+		ScheduleID:      s.id,
+		TopUpPaymentID:  topUpPaymentID,
+		OldStartTime:    s.startTime,
+		OldEndTime:      s.endTime,
+		NewStartTime:    startTime,
+		NewEndTime:      endTime,
+		NewPrice:        topUp.OldPrice,
+		Reason:          reason,
+		RescheduleCount: rescheduleCount,
+		EventVersion:    s.version + 1,
+		Timestamp:       time.Now(),
+	})
+
 	return nil
 }
 
+// AssignStaff assigns the booking to a staff member of the shop, replacing any earlier assignment.
+// Checking that the staff member works for the shop and is free at the time is up to the caller.
+func (s *Schedule) AssignStaff(staffUserID, assignedBy string, autoAssigned bool) error {
+	if s.status.IsFinal() {
+		return fmt.Errorf("cannot assign staff to a %s booking", s.status)
+	}
+	if staffUserID == "" {
+		return fmt.Errorf("staffUserID cannot be empty")
+	}
+	if assignedBy == "" {
+		return fmt.Errorf("assignedBy cannot be empty")
+	}
+
+	previousStaffID := ""
+	if s.assignedStaff != nil {
+		if s.assignedStaff.UserID == staffUserID {
+			return fmt.Errorf("booking is already assigned to this staff member")
+		}
+		previousStaffID = s.assignedStaff.UserID
+	}
+
+	s.raiseEvent(&event.ScheduleStaffAssigned{
+		ScheduleID:      s.id,
+		ShopID:          s.bookedShop.ShopID,
+		StaffID:         staffUserID,
+		PreviousStaffID: previousStaffID,
+		AssignedBy:      assignedBy,
+		AutoAssigned:    autoAssigned,
+		EventVersion:    s.version + 1,
+		Timestamp:       time.Now(),
+	})
+
+	return nil
+}
+
+// reschedule moves the booking to a new time; any waiting proposal is dropped
+func (s *Schedule) reschedule(startTime, endTime time.Time, price int, rescheduledBy string, actor ScheduleActor,
+	reason, proposalID string) error {
+	if err := s.checkReschedule(startTime, endTime); err != nil {
+		return err
+	}
+	if rescheduledBy == "" {
+		return fmt.Errorf("rescheduledBy cannot be empty")
+	}
+	if price < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+
+	s.raiseEvent(&event.ScheduleRescheduled{
+		ScheduleID:      s.id,
+		OldStartTime:    s.startTime,
+		OldEndTime:      s.endTime,
+		NewStartTime:    startTime,
+		NewEndTime:      endTime,
+		OldPrice:        s.totalPrice,
+		NewPrice:        price,
+		RescheduledBy:   rescheduledBy,
+		Actor:           string(actor),
+		Reason:          reason,
+		ProposalID:      proposalID,
+		RescheduleCount: s.rescheduleCount + 1,
+		EventVersion:    s.version + 1,
+		Timestamp:       time.Now(),
+	})
+
+	return nil
+}
+
+// checkReschedule checks that the booking can be moved to the given time
+func (s *Schedule) checkReschedule(startTime, endTime time.Time) error {
+	if s.status != ScheduleStatusPending && s.status != ScheduleStatusConfirmed {
+		return fmt.Errorf("cannot reschedule a %s booking", s.status)
+	}
+	if startTime.IsZero() || endTime.IsZero() {
+		return fmt.Errorf("startTime and endTime cannot be empty")
+	}
+	if !endTime.After(startTime) {
+		return fmt.Errorf("endTime must be after startTime")
+	}
+	if !startTime.After(time.Now()) {
+		return fmt.Errorf("a booking can only be moved to a time in the future")
+	}
+	if startTime.Equal(s.startTime) && endTime.Equal(s.endTime) {
+		return fmt.Errorf("booking is already at this time")
+	}
+	return nil
+}
+
+// removeCareBriefConsent drops the consent to share the pet's care brief, if given
+func (s *Schedule) removeCareBriefConsent(petID string) {
+	consents := s.careBriefConsents[:0]
+	for _, consent := range s.careBriefConsents {
+		if consent.PetID != petID {
+			consents = append(consents, consent)
+		}
+	}
+	s.careBriefConsents = consents
+}
+
+// containsScheduleActor reports whether the actor is among the given actors
+func containsScheduleActor(actors []ScheduleActor, actor ScheduleActor) bool {
+	for _, a := range actors {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
+
+// petLinePet is the pet of a pet line
+func petLinePet(line event.SchedulePetLine) PetAssigned {
+	return PetAssigned{
+		PetID:   line.PetID,
+		Name:    line.PetName,
+		Species: line.Species,
+		Breed:   line.Breed,
+		Age:     line.Age,
+		Weight:  line.Weight,
+	}
+}
+
+// petLineServices lists the services of the pet lines, each service once
+func petLineServices(lines []event.SchedulePetLine) []BookedServices {
+	seen := map[string]bool{}
+	var services []BookedServices
+	for _, line := range lines {
+		for _, svc := range line.Services {
+			if seen[svc.ServiceID] {
+				continue
+			}
+			seen[svc.ServiceID] = true
+			services = append(services, BookedServices{
+				ServiceID: svc.ServiceID,
+				Name:      svc.Name,
+			})
+		}
+	}
+	return services
+}
+
+// bookedService finds a service booked with the shop
+func (s *Schedule) bookedService(serviceID string) (BookedServices, bool) {
+	for _, svc := range s.bookedShop.BookedServices {
+		if svc.ServiceID == serviceID {
+			return svc, true
+		}
+	}
+	return BookedServices{}, false
+}
+
 func (s *Schedule) GetUncommittedEvents() []event.DomainEvent {
 	return s.uncommittedEvents
 }
@@ -259,6 +1197,10 @@ func (s *Schedule) applyEvent(ev event.DomainEvent) error {
 		s.startTime = e.StartTime
 		s.endTime = e.EndTime
 		s.status = ScheduleStatus(e.Status)
+		s.paymentID = e.PaymentID
+		s.totalPrice = e.TotalPrice
+		s.seriesID = e.SeriesID
+		s.petLines = append([]event.SchedulePetLine(nil), e.PetLines...)
 		s.createdAt = e.Timestamp
 		s.updatedAt = e.Timestamp
 		s.version = 1
@@ -279,6 +1221,118 @@ func (s *Schedule) applyEvent(ev event.DomainEvent) error {
 		s.version = e.EventVersion
 		s.updatedAt = e.Timestamp
 		s.isActive = false
+
+	case *event.ScheduleCareBriefShared:
+		s.careBriefConsents = append(s.careBriefConsents, CareBriefConsent{
+			PetID:    e.PetID,
+			SharedBy: e.SharedBy,
+			SharedAt: e.Timestamp,
+		})
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleCareBriefRevoked:
+		petID := e.PetID
+		if petID == "" {
+			petID = s.assignedPet.PetID // Revoked before consent was kept per pet
+		}
+		s.removeCareBriefConsent(petID)
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleVisitReportSubmitted:
+		report := e.Report
+		s.visitReport = &report
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleVisitReportAccepted:
+		if s.visitReport != nil {
+			if s.visitReport.AcceptedBy == "" {
+				s.visitReport.AcceptedBy = e.AcceptedBy
+				s.visitReport.AcceptedAt = e.Timestamp
+				s.visitReport.MedicalRecordID = e.MedicalRecordID
+			}
+			s.visitReport.MedicalRecords = append(s.visitReport.MedicalRecords, event.VisitReportRecord{
+				PetID:           e.PetID,
+				MedicalRecordID: e.MedicalRecordID,
+				AcceptedBy:      e.AcceptedBy,
+				AcceptedAt:      e.Timestamp,
+			})
+		}
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleRescheduled:
+		s.startTime = e.NewStartTime
+		s.endTime = e.NewEndTime
+		s.totalPrice = e.NewPrice
+		s.rescheduleCount = e.RescheduleCount
+		s.rescheduleProposal = nil
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleRescheduleProposed:
+		proposal := e.Proposal
+		s.rescheduleProposal = &proposal
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleRescheduleDeclined:
+		s.rescheduleProposal = nil
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleRescheduleReverted:
+		s.startTime = e.NewStartTime
+		s.endTime = e.NewEndTime
+		s.totalPrice = e.NewPrice
+		s.rescheduleCount = e.RescheduleCount
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SchedulePriceAdjusted:
+		s.priceAdjustments = append(s.priceAdjustments, e.Adjustment)
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SchedulePetCancelled:
+		for i := range s.petLines {
+			if s.petLines[i].PetID == e.PetID {
+				s.petLines[i].Status = event.SchedulePetLineCancelled
+				s.petLines[i].CancelledAt = e.Timestamp
+			}
+		}
+		s.removeCareBriefConsent(e.PetID)
+		s.assignedPet = PetAssigned{
+			PetID:   e.PrimaryPet.PetID,
+			Name:    e.PrimaryPet.Name,
+			Species: e.PrimaryPet.Species,
+			Breed:   e.PrimaryPet.Breed,
+			Age:     e.PrimaryPet.Age,
+			Weight:  e.PrimaryPet.Weight,
+		}
+		var bookedServices []BookedServices
+		for _, svcData := range e.BookedServices {
+			bookedServices = append(bookedServices, BookedServices{
+				ServiceID: svcData.ServiceID,
+				Name:      svcData.Name,
+			})
+		}
+		s.bookedShop.BookedServices = bookedServices
+		s.totalPrice = e.NewPrice
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.ScheduleStaffAssigned:
+		s.assignedStaff = &AssignedStaff{
+			UserID:       e.StaffID,
+			AssignedBy:   e.AssignedBy,
+			AutoAssigned: e.AutoAssigned,
+			AssignedAt:   e.Timestamp,
+		}
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
 		
 	default:
 		return fmt.Errorf("unknown event type: %T", ev)
@@ -299,6 +1353,16 @@ func (s *Schedule) CreatedAt() time.Time    { return s.createdAt }
 func (s *Schedule) UpdatedAt() time.Time    { return s.updatedAt }
 func (s *Schedule) Version() int            { return s.version }
 func (s *Schedule) IsActive() bool          { return s.isActive }
+func (s *Schedule) CareBriefConsents() []CareBriefConsent { return s.careBriefConsents }
+func (s *Schedule) VisitReport() *event.VisitReport     { return s.visitReport }
+func (s *Schedule) PaymentID() string                   { return s.paymentID }
+func (s *Schedule) TotalPrice() int                     { return s.totalPrice }
+func (s *Schedule) SeriesID() string                    { return s.seriesID }
+func (s *Schedule) RescheduleCount() int                { return s.rescheduleCount }
+func (s *Schedule) RescheduleProposal() *event.RescheduleProposal { return s.rescheduleProposal }
+func (s *Schedule) PriceAdjustments() []event.SchedulePriceAdjustment { return s.priceAdjustments }
+func (s *Schedule) AssignedStaff() *AssignedStaff                     { return s.assignedStaff }
+func (s *Schedule) PetLines() []event.SchedulePetLine                 { return s.petLines }
 
 // Entity interface implementation
 func (s *Schedule) GetID() string    { return s.id }
@@ -0,0 +1,279 @@
+package aggregate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+)
+
+var scheduleTestStart = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+const scheduleTestLength = time.Hour
+
+// newTestSchedule returns a one-hour booking starting at scheduleTestStart in the given status
+func newTestSchedule(status ScheduleStatus) *Schedule {
+	return ReconstructSchedule("schedule-1", BookingUser{}, BookedVendor{}, PetAssigned{},
+		scheduleTestStart, scheduleTestStart.Add(scheduleTestLength), status, nil, nil,
+		1, scheduleTestStart.Add(-24*time.Hour), scheduleTestStart.Add(-24*time.Hour), true)
+}
+
+// assertScheduleTransition checks the result of CanTransitionTo against the expected error kind, where an
+// empty kind means the transition is allowed
+func assertScheduleTransition(t *testing.T, err error, want ScheduleTransitionErrorKind) {
+	t.Helper()
+
+	if want == "" {
+		if err != nil {
+			t.Fatalf("expected transition to be allowed, got %v", err)
+		}
+		return
+	}
+
+	var transitionErr *ScheduleTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected *ScheduleTransitionError of kind %s, got %v", want, err)
+	}
+	if transitionErr.Kind != want {
+		t.Fatalf("expected error kind %s, got %s (%v)", want, transitionErr.Kind, err)
+	}
+}
+
+func TestScheduleCanTransitionTo(t *testing.T) {
+	const (
+		beforeStart = -time.Hour
+		duringVisit = 20 * time.Minute
+		afterEnd    = scheduleTestLength + time.Minute
+	)
+
+	tests := []struct {
+		from  ScheduleStatus
+		to    ScheduleStatus
+		actor ScheduleActor
+		at    time.Duration // Offset from the start of the booking
+		want  ScheduleTransitionErrorKind
+	}{
+		{ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleActorCustomer, beforeStart, ScheduleTransitionForbidden},
+		{ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleActorVendorStaff, beforeStart, ""},
+		{ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleActorSystem, beforeStart, ""},
+		{ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleActorAdmin, beforeStart, ""},
+
+		{ScheduleStatusPending, ScheduleStatusCancelled, ScheduleActorCustomer, duringVisit, ""},
+		{ScheduleStatusPending, ScheduleStatusCancelled, ScheduleActorVendorStaff, duringVisit, ""},
+		{ScheduleStatusPending, ScheduleStatusCancelled, ScheduleActorSystem, afterEnd, ""},
+		{ScheduleStatusPending, ScheduleStatusCancelled, ScheduleActorAdmin, afterEnd, ""},
+
+		{ScheduleStatusPending, ScheduleStatusInProgress, ScheduleActorVendorStaff, duringVisit, ScheduleTransitionUndefined},
+		{ScheduleStatusPending, ScheduleStatusCompleted, ScheduleActorSystem, afterEnd, ScheduleTransitionUndefined},
+		{ScheduleStatusPending, ScheduleStatusNoShow, ScheduleActorVendorStaff, duringVisit, ScheduleTransitionUndefined},
+		{ScheduleStatusPending, ScheduleStatusPending, ScheduleActorAdmin, beforeStart, ScheduleTransitionUndefined},
+
+		{ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorCustomer, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorVendorStaff, duringVisit, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorSystem, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorAdmin, duringVisit, ""},
+
+		{ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorCustomer, afterEnd, ScheduleTransitionForbidden},
+		{ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorVendorStaff, duringVisit, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorSystem, afterEnd, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorAdmin, duringVisit, ""},
+
+		{ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorCustomer, beforeStart, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorVendorStaff, beforeStart, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorSystem, duringVisit, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorAdmin, afterEnd, ""},
+
+		{ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorCustomer, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorVendorStaff, duringVisit, ""},
+		{ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorSystem, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorAdmin, duringVisit, ""},
+
+		{ScheduleStatusConfirmed, ScheduleStatusPending, ScheduleActorAdmin, beforeStart, ScheduleTransitionUndefined},
+		{ScheduleStatusConfirmed, ScheduleStatusConfirmed, ScheduleActorVendorStaff, beforeStart, ScheduleTransitionUndefined},
+
+		{ScheduleStatusInProgress, ScheduleStatusCompleted, ScheduleActorCustomer, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusInProgress, ScheduleStatusCompleted, ScheduleActorVendorStaff, duringVisit, ""},
+		{ScheduleStatusInProgress, ScheduleStatusCompleted, ScheduleActorSystem, afterEnd, ""},
+		{ScheduleStatusInProgress, ScheduleStatusCompleted, ScheduleActorAdmin, duringVisit, ""},
+
+		{ScheduleStatusInProgress, ScheduleStatusCancelled, ScheduleActorCustomer, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusInProgress, ScheduleStatusCancelled, ScheduleActorVendorStaff, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusInProgress, ScheduleStatusCancelled, ScheduleActorSystem, duringVisit, ScheduleTransitionForbidden},
+		{ScheduleStatusInProgress, ScheduleStatusCancelled, ScheduleActorAdmin, duringVisit, ""},
+
+		{ScheduleStatusInProgress, ScheduleStatusNoShow, ScheduleActorVendorStaff, duringVisit, ScheduleTransitionUndefined},
+		{ScheduleStatusInProgress, ScheduleStatusConfirmed, ScheduleActorAdmin, duringVisit, ScheduleTransitionUndefined},
+		{ScheduleStatusInProgress, ScheduleStatusPending, ScheduleActorAdmin, duringVisit, ScheduleTransitionUndefined},
+
+		// Completed, cancelled and no-show bookings are final, whoever asks
+		{ScheduleStatusCompleted, ScheduleStatusConfirmed, ScheduleActorAdmin, afterEnd, ScheduleTransitionUndefined},
+		{ScheduleStatusCompleted, ScheduleStatusCancelled, ScheduleActorAdmin, afterEnd, ScheduleTransitionUndefined},
+		{ScheduleStatusCompleted, ScheduleStatusInProgress, ScheduleActorVendorStaff, duringVisit, ScheduleTransitionUndefined},
+		{ScheduleStatusCompleted, ScheduleStatusCompleted, ScheduleActorSystem, afterEnd, ScheduleTransitionUndefined},
+		{ScheduleStatusCancelled, ScheduleStatusPending, ScheduleActorCustomer, beforeStart, ScheduleTransitionUndefined},
+		{ScheduleStatusCancelled, ScheduleStatusPending, ScheduleActorAdmin, beforeStart, ScheduleTransitionUndefined},
+		{ScheduleStatusCancelled, ScheduleStatusConfirmed, ScheduleActorVendorStaff, beforeStart, ScheduleTransitionUndefined},
+		{ScheduleStatusCancelled, ScheduleStatusCompleted, ScheduleActorSystem, afterEnd, ScheduleTransitionUndefined},
+		{ScheduleStatusCancelled, ScheduleStatusCancelled, ScheduleActorAdmin, beforeStart, ScheduleTransitionUndefined},
+		{ScheduleStatusNoShow, ScheduleStatusConfirmed, ScheduleActorVendorStaff, duringVisit, ScheduleTransitionUndefined},
+		{ScheduleStatusNoShow, ScheduleStatusCompleted, ScheduleActorAdmin, afterEnd, ScheduleTransitionUndefined},
+		{ScheduleStatusNoShow, ScheduleStatusCancelled, ScheduleActorAdmin, afterEnd, ScheduleTransitionUndefined},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to)+"/"+string(tt.actor), func(t *testing.T) {
+			schedule := newTestSchedule(tt.from)
+			err := schedule.CanTransitionTo(tt.to, tt.actor, scheduleTestStart.Add(tt.at))
+			assertScheduleTransition(t, err, tt.want)
+		})
+	}
+
+	// Every transition of the state machine must be exercised as allowed above
+	for _, transition := range scheduleTransitions {
+		for _, actor := range transition.actors {
+			covered := false
+			for _, tt := range tests {
+				if tt.from == transition.from && tt.to == transition.to && tt.actor == actor && tt.want == "" {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				t.Errorf("transition %s->%s by %s is not covered", transition.from, transition.to, actor)
+			}
+		}
+	}
+}
+
+func TestScheduleTransitionTiming(t *testing.T) {
+	end := scheduleTestLength
+
+	tests := []struct {
+		name  string
+		from  ScheduleStatus
+		to    ScheduleStatus
+		actor ScheduleActor
+		at    time.Duration // Offset from the start of the booking
+		want  ScheduleTransitionErrorKind
+	}{
+		{"check-in opens at the window", ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorVendorStaff, -ScheduleCheckInWindow, ""},
+		{"check-in before the window", ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorVendorStaff, -ScheduleCheckInWindow - time.Second, ScheduleTransitionOutOfTime},
+		{"check-in at the end", ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorVendorStaff, end, ""},
+		{"check-in after the end", ScheduleStatusConfirmed, ScheduleStatusInProgress, ScheduleActorAdmin, end + time.Second, ScheduleTransitionOutOfTime},
+
+		{"no-show at the end of the grace period", ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorVendorStaff, ScheduleNoShowGracePeriod, ""},
+		{"no-show within the grace period", ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorVendorStaff, ScheduleNoShowGracePeriod - time.Second, ScheduleTransitionOutOfTime},
+		{"no-show at the start", ScheduleStatusConfirmed, ScheduleStatusNoShow, ScheduleActorAdmin, 0, ScheduleTransitionOutOfTime},
+
+		{"customer cancels just before the start", ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorCustomer, -time.Second, ""},
+		{"customer cancels at the start", ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorCustomer, 0, ScheduleTransitionOutOfTime},
+		{"staff cancels after the start", ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorVendorStaff, time.Minute, ScheduleTransitionOutOfTime},
+		{"admin cancels after the start", ScheduleStatusConfirmed, ScheduleStatusCancelled, ScheduleActorAdmin, time.Minute, ""},
+
+		{"staff completes before the start", ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorVendorStaff, -time.Second, ScheduleTransitionOutOfTime},
+		{"staff completes at the start", ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorVendorStaff, 0, ""},
+		{"system completes before the end", ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorSystem, end - time.Second, ScheduleTransitionOutOfTime},
+		{"system completes at the end", ScheduleStatusConfirmed, ScheduleStatusCompleted, ScheduleActorSystem, end, ""},
+		{"system completes a visit before the end", ScheduleStatusInProgress, ScheduleStatusCompleted, ScheduleActorSystem, end - time.Second, ScheduleTransitionOutOfTime},
+		{"system completes a visit at the end", ScheduleStatusInProgress, ScheduleStatusCompleted, ScheduleActorSystem, end, ""},
+
+		{"confirm at the end", ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleActorVendorStaff, end, ""},
+		{"confirm after the end", ScheduleStatusPending, ScheduleStatusConfirmed, ScheduleActorSystem, end + time.Second, ScheduleTransitionOutOfTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := newTestSchedule(tt.from)
+			err := schedule.CanTransitionTo(tt.to, tt.actor, scheduleTestStart.Add(tt.at))
+			assertScheduleTransition(t, err, tt.want)
+		})
+	}
+}
+
+func TestScheduleTransitionErrorDetails(t *testing.T) {
+	schedule := newTestSchedule(ScheduleStatusCancelled)
+	err := schedule.CanTransitionTo(ScheduleStatusPending, ScheduleActorCustomer, scheduleTestStart)
+
+	var transitionErr *ScheduleTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected *ScheduleTransitionError, got %v", err)
+	}
+	if transitionErr.From != ScheduleStatusCancelled || transitionErr.To != ScheduleStatusPending || transitionErr.Actor != ScheduleActorCustomer {
+		t.Fatalf("unexpected error details: %+v", transitionErr)
+	}
+	if transitionErr.Error() == "" {
+		t.Fatal("expected an error message")
+	}
+}
+
+// newTestMultiPetSchedule returns a new booking for two pets of the same user
+func newTestMultiPetSchedule(t *testing.T) *Schedule {
+	t.Helper()
+
+	lines := []event.SchedulePetLine{
+		{PetID: "pet-1", Services: []event.ScheduleLineService{{ServiceID: "bath", Name: "Bath"}}, DurationMinutes: 30, Price: 100000},
+		{PetID: "pet-2", Services: []event.ScheduleLineService{{ServiceID: "bath", Name: "Bath"}}, DurationMinutes: 30, Price: 100000},
+	}
+	schedule, err := NewMultiPetSchedule(BookingUser{UserID: "user-1"}, BookedVendor{ShopID: "shop-1"}, lines,
+		scheduleTestStart, scheduleTestStart.Add(scheduleTestLength), "", 200000)
+	if err != nil {
+		t.Fatalf("failed to create schedule: %v", err)
+	}
+	return schedule
+}
+
+func TestScheduleCareBriefPerPet(t *testing.T) {
+	schedule := newTestMultiPetSchedule(t)
+	during := scheduleTestStart.Add(10 * time.Minute)
+
+	if err := schedule.ShareCareBrief("pet-2", "user-1"); err != nil {
+		t.Fatalf("failed to share care brief: %v", err)
+	}
+	if schedule.CareBriefReadableAt("pet-1", during) {
+		t.Fatal("expected the primary pet's care brief to stay private")
+	}
+	if !schedule.CareBriefReadableAt("pet-2", during) {
+		t.Fatal("expected the second pet's care brief to be readable during the booking")
+	}
+	if err := schedule.ShareCareBrief("pet-3", "user-1"); err == nil {
+		t.Fatal("expected sharing for a pet that is not booked to fail")
+	}
+
+	if err := schedule.RevokeCareBrief("pet-2", "user-1"); err != nil {
+		t.Fatalf("failed to revoke care brief: %v", err)
+	}
+	if schedule.CareBriefConsentFor("pet-2") != nil {
+		t.Fatal("expected the second pet's consent to be revoked")
+	}
+}
+
+func TestScheduleAcceptVisitReportPerPet(t *testing.T) {
+	schedule := newTestMultiPetSchedule(t)
+	schedule.status = ScheduleStatusInProgress
+
+	err := schedule.SubmitVisitReport(event.VisitReport{
+		SubmittedBy:       "staff-1",
+		ServicesPerformed: []event.VisitReportService{{ServiceID: "bath"}},
+		Observations:      "Both calm",
+	})
+	if err != nil {
+		t.Fatalf("failed to submit visit report: %v", err)
+	}
+
+	if err := schedule.AcceptVisitReport("pet-1", "user-1", "record-1"); err != nil {
+		t.Fatalf("failed to accept for the first pet: %v", err)
+	}
+	if schedule.VisitReportAcceptedFor("pet-2") {
+		t.Fatal("expected the second pet to be accepted on its own")
+	}
+	if err := schedule.AcceptVisitReport("pet-2", "user-1", "record-2"); err != nil {
+		t.Fatalf("failed to accept for the second pet: %v", err)
+	}
+	if err := schedule.AcceptVisitReport("pet-2", "user-1", "record-3"); err == nil {
+		t.Fatal("expected a second acceptance for the same pet to fail")
+	}
+	if report := schedule.VisitReport(); report.MedicalRecordID != "record-1" || len(report.MedicalRecords) != 2 {
+		t.Fatalf("unexpected accepted report: %+v", report)
+	}
+}
@@ -2,12 +2,63 @@ package aggregate
 
 import (
 	"fmt"
+	"strings"
 	"time"
 	"whisko-petcare/internal/domain/event"
 
 	"github.com/google/uuid"
 )
 
+// ServiceEligibility describes which pets a service accepts. Empty lists and zero weights mean no restriction.
+type ServiceEligibility struct {
+	Species   []string // Catalogue species codes
+	Sizes     []string // PetSizeSmall, PetSizeMedium, PetSizeLarge or PetSizeGiant
+	MinWeight float64  // Kilograms
+	MaxWeight float64  // Kilograms
+}
+
+// IsRestricted reports whether the service limits the pets it accepts
+func (e ServiceEligibility) IsRestricted() bool {
+	return len(e.Species) > 0 || len(e.Sizes) > 0 || e.MinWeight > 0 || e.MaxWeight > 0
+}
+
+// Accepts checks a pet against the eligibility rules. The size is the pet's size from its weight, or the
+// typical size of its breed when the weight is unknown; a weight of 0 means the weight is unknown.
+func (e ServiceEligibility) Accepts(species, size string, weight float64) error {
+	if len(e.Species) > 0 && !containsString(e.Species, species) {
+		return fmt.Errorf("only accepts %s", strings.Join(e.Species, ", "))
+	}
+	if len(e.Sizes) > 0 {
+		if size == "" {
+			return fmt.Errorf("needs the pet's weight or breed to check its size")
+		}
+		if !containsString(e.Sizes, size) {
+			return fmt.Errorf("only accepts %s pets", strings.ToLower(strings.Join(e.Sizes, ", ")))
+		}
+	}
+	if e.MinWeight > 0 || e.MaxWeight > 0 {
+		if weight <= 0 {
+			return fmt.Errorf("needs the pet's weight")
+		}
+		if e.MinWeight > 0 && weight < e.MinWeight {
+			return fmt.Errorf("only accepts pets of at least %g kg", e.MinWeight)
+		}
+		if e.MaxWeight > 0 && weight > e.MaxWeight {
+			return fmt.Errorf("only accepts pets of up to %g kg", e.MaxWeight)
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 type Service struct {
 	id          string
 	vendorId    string
@@ -21,6 +72,7 @@ type Service struct {
 	updatedAt   time.Time
 	version     int
 	isActive    bool
+	eligibility ServiceEligibility
 	
 	uncommittedEvents []event.DomainEvent
 }
@@ -153,6 +205,49 @@ func (s *Service) UpdateImageUrl(imageUrl string) error {
 	return nil
 }
 
+// UpdateEligibility changes which pets the service accepts. Species codes must already be normalized
+// catalogue codes; the caller checks that they exist.
+func (s *Service) UpdateEligibility(eligibility ServiceEligibility, updatedBy string) error {
+	species := []string{}
+	for _, code := range eligibility.Species {
+		code = NormalizeCatalogTerm(code)
+		if code == "" {
+			return fmt.Errorf("species code cannot be empty")
+		}
+		if !containsString(species, code) {
+			species = append(species, code)
+		}
+	}
+	sizes := []string{}
+	for _, size := range eligibility.Sizes {
+		size = strings.ToUpper(strings.TrimSpace(size))
+		if !IsValidPetSize(size) {
+			return fmt.Errorf("invalid pet size: %s", size)
+		}
+		if !containsString(sizes, size) {
+			sizes = append(sizes, size)
+		}
+	}
+	if eligibility.MinWeight < 0 || eligibility.MaxWeight < 0 {
+		return fmt.Errorf("weight limits cannot be negative")
+	}
+	if eligibility.MaxWeight > 0 && eligibility.MinWeight > eligibility.MaxWeight {
+		return fmt.Errorf("minimum weight cannot exceed maximum weight")
+	}
+
+	s.raiseEvent(&event.ServiceEligibilityUpdated{
+		ServiceID:    s.id,
+		Species:      species,
+		Sizes:        sizes,
+		MinWeight:    eligibility.MinWeight,
+		MaxWeight:    eligibility.MaxWeight,
+		UpdatedBy:    updatedBy,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
 func (s *Service) Delete() error {
 	s.raiseEvent(&event.ServiceDeleted{
 		ServiceID:    s.id,
@@ -209,6 +304,16 @@ func (s *Service) applyEvent(ev event.DomainEvent) error {
 		s.imageUrl = e.ImageUrl
 		s.version = e.EventVersion
 		s.updatedAt = e.Timestamp
+
+	case *event.ServiceEligibilityUpdated:
+		s.eligibility = ServiceEligibility{
+			Species:   e.Species,
+			Sizes:     e.Sizes,
+			MinWeight: e.MinWeight,
+			MaxWeight: e.MaxWeight,
+		}
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
 		
 	default:
 		return fmt.Errorf("unknown event type: %T", ev)
@@ -230,12 +335,16 @@ func (s *Service) CreatedAt() time.Time   { return s.createdAt }
 func (s *Service) UpdatedAt() time.Time   { return s.updatedAt }
 func (s *Service) Version() int           { return s.version }
 func (s *Service) IsActive() bool         { return s.isActive }
+func (s *Service) Eligibility() ServiceEligibility { return s.eligibility }
 
 // Entity interface implementation
 func (s *Service) GetID() string    { return s.id }
 func (s *Service) GetVersion() int  { return s.version }
 func (s *Service) SetVersion(v int) { s.version = v }
 
+// Repository helper methods - for database reconstruction only
+func (s *Service) SetEligibility(eligibility ServiceEligibility) { s.eligibility = eligibility }
+
 // AggregateRoot interface implementation
 func (s *Service) MarkEventsAsCommitted() {
 	s.uncommittedEvents = nil
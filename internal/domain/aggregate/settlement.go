@@ -0,0 +1,431 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+	"whisko-petcare/internal/domain/event"
+)
+
+// SettlementPeriod represents how often a vendor's earnings are settled
+type SettlementPeriod string
+
+const (
+	SettlementPeriodDaily  SettlementPeriod = "DAILY"
+	SettlementPeriodWeekly SettlementPeriod = "WEEKLY"
+)
+
+// IsValid checks if the settlement period is supported
+func (p SettlementPeriod) IsValid() bool {
+	return p == SettlementPeriodDaily || p == SettlementPeriodWeekly
+}
+
+// SettlementStatus represents the status of a settlement
+type SettlementStatus string
+
+const (
+	SettlementStatusOpen        SettlementStatus = "OPEN"         // Collecting earnings for the period
+	SettlementStatusPaidOut     SettlementStatus = "PAID_OUT"     // Closed with a payout to the vendor
+	SettlementStatusCarriedOver SettlementStatus = "CARRIED_OVER" // Closed, balance moved to the next settlement
+	SettlementStatusClosed      SettlementStatus = "CLOSED"       // Closed with a zero balance
+)
+
+// SettlementPeriodBounds returns the start and end of the settlement period containing t.
+// Weekly periods start on Monday.
+func SettlementPeriodBounds(period SettlementPeriod, t time.Time) (time.Time, time.Time) {
+	year, month, day := t.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+
+	if period == SettlementPeriodWeekly {
+		offset := (int(start.Weekday()) + 6) % 7 // Days since Monday
+		start = start.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	}
+
+	return start, start.AddDate(0, 0, 1)
+}
+
+// SettlementCommission returns the platform commission for an amount at the given percentage
+func SettlementCommission(amount, commissionPercent int) int {
+	if amount <= 0 || commissionPercent <= 0 {
+		return 0
+	}
+	return amount * commissionPercent / 100
+}
+
+// Settlement aggregates a vendor's earnings over a settlement period into a single payout
+type Settlement struct {
+	id              string
+	vendorID        string
+	period          SettlementPeriod
+	periodStart     time.Time
+	periodEnd       time.Time
+	lineItems       []event.SettlementLineItem
+	carriedInAmount int
+	carriedInFrom   []string
+	status          SettlementStatus
+	payoutID        string
+	commission      int
+	carriedOverTo   string
+	closedReason    string
+	version         int
+	createdAt       time.Time
+	updatedAt       time.Time
+
+	uncommittedEvents []event.DomainEvent
+}
+
+// NewSettlement opens a new settlement for a vendor's settlement period
+func NewSettlement(settlementID, vendorID string, period SettlementPeriod, periodStart, periodEnd time.Time) (*Settlement, error) {
+	if settlementID == "" {
+		return nil, fmt.Errorf("settlement ID cannot be empty")
+	}
+	if vendorID == "" {
+		return nil, fmt.Errorf("vendor ID cannot be empty")
+	}
+	if !period.IsValid() {
+		return nil, fmt.Errorf("invalid settlement period: %s", period)
+	}
+	if !periodEnd.After(periodStart) {
+		return nil, fmt.Errorf("period end must be after period start")
+	}
+
+	settlement := &Settlement{}
+	settlement.raiseEvent(&event.SettlementOpened{
+		SettlementID: settlementID,
+		VendorID:     vendorID,
+		Period:       string(period),
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		Timestamp:    time.Now(),
+	})
+
+	return settlement, nil
+}
+
+// ReconstructSettlement rebuilds a Settlement aggregate from database state WITHOUT raising events
+func ReconstructSettlement(
+	id, vendorID string,
+	period SettlementPeriod,
+	periodStart, periodEnd time.Time,
+	lineItems []event.SettlementLineItem,
+	carriedInAmount int,
+	carriedInFrom []string,
+	status SettlementStatus,
+	payoutID string,
+	commission int,
+	carriedOverTo, closedReason string,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Settlement {
+	return &Settlement{
+		id:              id,
+		vendorID:        vendorID,
+		period:          period,
+		periodStart:     periodStart,
+		periodEnd:       periodEnd,
+		lineItems:       lineItems,
+		carriedInAmount: carriedInAmount,
+		carriedInFrom:   carriedInFrom,
+		status:          status,
+		payoutID:        payoutID,
+		commission:      commission,
+		carriedOverTo:   carriedOverTo,
+		closedReason:    closedReason,
+		version:         version,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+// AddLineItem adds a paid booking to the settlement
+func (s *Settlement) AddLineItem(paymentID, scheduleID string, amount int, earnedAt time.Time) error {
+	if s.status != SettlementStatusOpen {
+		return fmt.Errorf("cannot add line item to %s settlement", s.status)
+	}
+	if paymentID == "" {
+		return fmt.Errorf("payment ID cannot be empty")
+	}
+	if amount <= 0 {
+		return fmt.Errorf("line item amount must be greater than 0")
+	}
+	if s.HasPayment(paymentID) {
+		return fmt.Errorf("payment %s is already included in this settlement", paymentID)
+	}
+
+	s.raiseEvent(&event.SettlementLineItemAdded{
+		SettlementID: s.id,
+		VendorID:     s.vendorID,
+		LineItem: event.SettlementLineItem{
+			PaymentID:  paymentID,
+			ScheduleID: scheduleID,
+			Amount:     amount,
+			EarnedAt:   earnedAt,
+		},
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// AddRefundAdjustment deducts a refunded booking from the vendor's earnings
+func (s *Settlement) AddRefundAdjustment(paymentID, refundID string, amount int, refundedAt time.Time) error {
+	if s.status != SettlementStatusOpen {
+		return fmt.Errorf("cannot add refund adjustment to %s settlement", s.status)
+	}
+	if paymentID == "" || refundID == "" {
+		return fmt.Errorf("payment ID and refund ID cannot be empty")
+	}
+	if amount <= 0 {
+		return fmt.Errorf("refund amount must be greater than 0")
+	}
+	if s.HasRefund(refundID) {
+		return fmt.Errorf("refund %s is already included in this settlement", refundID)
+	}
+
+	s.raiseEvent(&event.SettlementLineItemAdded{
+		SettlementID: s.id,
+		VendorID:     s.vendorID,
+		LineItem: event.SettlementLineItem{
+			PaymentID: paymentID,
+			RefundID:  refundID,
+			Amount:    -amount,
+			EarnedAt:  refundedAt,
+		},
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// AddCarryOver moves the balance of a closed settlement into this one. The balance is negative
+// when refunds exceeded the period's earnings.
+func (s *Settlement) AddCarryOver(fromSettlementID string, amount int) error {
+	if s.status != SettlementStatusOpen {
+		return fmt.Errorf("cannot carry balance into %s settlement", s.status)
+	}
+	if fromSettlementID == "" || fromSettlementID == s.id {
+		return fmt.Errorf("invalid source settlement")
+	}
+	s.raiseEvent(&event.SettlementBalanceCarriedIn{
+		SettlementID:     s.id,
+		VendorID:         s.vendorID,
+		FromSettlementID: fromSettlementID,
+		Amount:           amount,
+		EventVersion:     s.version + 1,
+		Timestamp:        time.Now(),
+	})
+
+	return nil
+}
+
+// CarryOver closes the settlement without a payout, moving its balance to the next settlement
+func (s *Settlement) CarryOver(toSettlementID, reason string) error {
+	if s.status != SettlementStatusOpen {
+		return fmt.Errorf("only open settlements can be carried over (current status: %s)", s.status)
+	}
+	if toSettlementID == "" || toSettlementID == s.id {
+		return fmt.Errorf("invalid target settlement")
+	}
+
+	s.raiseEvent(&event.SettlementCarriedOver{
+		SettlementID:   s.id,
+		VendorID:       s.vendorID,
+		ToSettlementID: toSettlementID,
+		Amount:         s.TotalAmount(),
+		Reason:         reason,
+		EventVersion:   s.version + 1,
+		Timestamp:      time.Now(),
+	})
+
+	return nil
+}
+
+// Close closes a settlement with a zero balance, which has nothing to pay out or carry over
+func (s *Settlement) Close(reason string) error {
+	if s.status != SettlementStatusOpen {
+		return fmt.Errorf("only open settlements can be closed (current status: %s)", s.status)
+	}
+	if total := s.TotalAmount(); total != 0 {
+		return fmt.Errorf("cannot close settlement with a balance of %d", total)
+	}
+
+	s.raiseEvent(&event.SettlementClosed{
+		SettlementID: s.id,
+		VendorID:     s.vendorID,
+		Reason:       reason,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// MarkAsPaidOut closes the settlement with the payout that transfers its balance less the platform commission
+func (s *Settlement) MarkAsPaidOut(payoutID string, commission int) error {
+	if s.status != SettlementStatusOpen {
+		return fmt.Errorf("only open settlements can be paid out (current status: %s)", s.status)
+	}
+	if payoutID == "" {
+		return fmt.Errorf("payout ID cannot be empty")
+	}
+	if commission < 0 || commission > s.TotalAmount() {
+		return fmt.Errorf("invalid commission amount: %d", commission)
+	}
+
+	s.raiseEvent(&event.SettlementPaidOut{
+		SettlementID: s.id,
+		VendorID:     s.vendorID,
+		PayoutID:     payoutID,
+		Amount:       s.TotalAmount(),
+		Commission:   commission,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// HasPayment checks if a payment is already included in the settlement
+func (s *Settlement) HasPayment(paymentID string) bool {
+	for _, item := range s.lineItems {
+		if item.PaymentID == paymentID && item.RefundID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRefund checks if a refund adjustment is already included in the settlement
+func (s *Settlement) HasRefund(refundID string) bool {
+	for _, item := range s.lineItems {
+		if item.RefundID == refundID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDue checks if the settlement period has ended
+func (s *Settlement) IsDue(now time.Time) bool {
+	return s.status == SettlementStatusOpen && !now.Before(s.periodEnd)
+}
+
+// EarningsAmount returns the sum of line items earned in this period
+func (s *Settlement) EarningsAmount() int {
+	total := 0
+	for _, item := range s.lineItems {
+		total += item.Amount
+	}
+	return total
+}
+
+// TotalAmount returns the earnings of this period plus balances carried in
+func (s *Settlement) TotalAmount() int {
+	return s.EarningsAmount() + s.carriedInAmount
+}
+
+func (s *Settlement) raiseEvent(ev event.DomainEvent) {
+	s.uncommittedEvents = append(s.uncommittedEvents, ev)
+	s.applyEvent(ev)
+}
+
+func (s *Settlement) applyEvent(ev event.DomainEvent) error {
+	switch e := ev.(type) {
+	case *event.SettlementOpened:
+		s.id = e.SettlementID
+		s.vendorID = e.VendorID
+		s.period = SettlementPeriod(e.Period)
+		s.periodStart = e.PeriodStart
+		s.periodEnd = e.PeriodEnd
+		s.lineItems = []event.SettlementLineItem{}
+		s.carriedInFrom = []string{}
+		s.status = SettlementStatusOpen
+		s.version = 1
+		s.createdAt = e.Timestamp
+		s.updatedAt = e.Timestamp
+
+	case *event.SettlementLineItemAdded:
+		s.lineItems = append(s.lineItems, e.LineItem)
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SettlementBalanceCarriedIn:
+		s.carriedInAmount += e.Amount
+		s.carriedInFrom = append(s.carriedInFrom, e.FromSettlementID)
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SettlementCarriedOver:
+		s.status = SettlementStatusCarriedOver
+		s.carriedOverTo = e.ToSettlementID
+		s.closedReason = e.Reason
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SettlementClosed:
+		s.status = SettlementStatusClosed
+		s.closedReason = e.Reason
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SettlementPaidOut:
+		s.status = SettlementStatusPaidOut
+		s.payoutID = e.PayoutID
+		s.commission = e.Commission
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	default:
+		return fmt.Errorf("unknown event type: %T", ev)
+	}
+
+	return nil
+}
+
+// PayoutAmount returns the amount transferred to the vendor after commission
+func (s *Settlement) PayoutAmount() int {
+	return s.TotalAmount() - s.commission
+}
+
+// Getters
+func (s *Settlement) ID() string                            { return s.id }
+func (s *Settlement) VendorID() string                      { return s.vendorID }
+func (s *Settlement) Period() SettlementPeriod              { return s.period }
+func (s *Settlement) PeriodStart() time.Time                { return s.periodStart }
+func (s *Settlement) PeriodEnd() time.Time                  { return s.periodEnd }
+func (s *Settlement) LineItems() []event.SettlementLineItem { return s.lineItems }
+func (s *Settlement) CarriedInAmount() int                  { return s.carriedInAmount }
+func (s *Settlement) CarriedInFrom() []string               { return s.carriedInFrom }
+func (s *Settlement) Status() SettlementStatus              { return s.status }
+func (s *Settlement) PayoutID() string                      { return s.payoutID }
+func (s *Settlement) Commission() int                       { return s.commission }
+func (s *Settlement) CarriedOverTo() string                 { return s.carriedOverTo }
+func (s *Settlement) ClosedReason() string                  { return s.closedReason }
+func (s *Settlement) Version() int                          { return s.version }
+func (s *Settlement) CreatedAt() time.Time                  { return s.createdAt }
+func (s *Settlement) UpdatedAt() time.Time                  { return s.updatedAt }
+
+// Entity interface implementation
+func (s *Settlement) GetID() string      { return s.id }
+func (s *Settlement) GetVersion() int    { return s.version }
+func (s *Settlement) SetVersion(ver int) { s.version = ver }
+
+// AggregateRoot interface implementation
+func (s *Settlement) GetUncommittedEvents() []event.DomainEvent {
+	return s.uncommittedEvents
+}
+
+func (s *Settlement) MarkEventsAsCommitted() {
+	s.uncommittedEvents = nil
+}
+
+func (s *Settlement) LoadFromHistory(events []event.DomainEvent) error {
+	for _, e := range events {
+		if err := s.applyEvent(e); err != nil {
+			return fmt.Errorf("failed to apply event %s: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+
+	"github.com/google/uuid"
+)
+
+// SlotHoldStatus is the status of a slot hold
+type SlotHoldStatus string
+
+const (
+	SlotHoldStatusHeld      SlotHoldStatus = "HELD"      // Waiting for the payment
+	SlotHoldStatusReleased  SlotHoldStatus = "RELEASED"  // The payment was cancelled or expired
+	SlotHoldStatusConverted SlotHoldStatus = "CONVERTED" // The payment went through and the schedule was created
+)
+
+// SlotHold reserves a shop's time for a customer between creating a booking payment and the payment
+// going through, so no one else can pay for the same time meanwhile. A hold lapses on its own at
+// ExpiresAt even if nothing releases it.
+type SlotHold struct {
+	id         string
+	paymentID  string
+	userID     string
+	shopID     string
+	startTime  time.Time
+	endTime    time.Time
+	expiresAt  time.Time
+	status     SlotHoldStatus
+	scheduleID string
+	reason     string
+	version    int
+	createdAt  time.Time
+	updatedAt  time.Time
+
+	uncommittedEvents []event.DomainEvent
+}
+
+// NewSlotHold holds a shop's time for the checkout of a booking payment until the payment expires
+func NewSlotHold(paymentID, userID, shopID string, startTime, endTime, expiresAt time.Time) (*SlotHold, error) {
+	if paymentID == "" {
+		return nil, fmt.Errorf("paymentID cannot be empty")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+	if shopID == "" {
+		return nil, fmt.Errorf("shopID cannot be empty")
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("end time must be after start time")
+	}
+	if expiresAt.IsZero() {
+		return nil, fmt.Errorf("expiresAt cannot be empty")
+	}
+
+	hold := &SlotHold{}
+	hold.raiseEvent(&event.SlotHoldPlaced{
+		HoldID:    uuid.New().String(),
+		PaymentID: paymentID,
+		UserID:    userID,
+		ShopID:    shopID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		ExpiresAt: expiresAt,
+		Timestamp: time.Now(),
+	})
+
+	return hold, nil
+}
+
+// ReconstructSlotHold rebuilds a slot hold from stored state without raising events
+func ReconstructSlotHold(id, paymentID, userID, shopID string, startTime, endTime, expiresAt time.Time,
+	status SlotHoldStatus, scheduleID, reason string, version int, createdAt, updatedAt time.Time) *SlotHold {
+	return &SlotHold{
+		id:         id,
+		paymentID:  paymentID,
+		userID:     userID,
+		shopID:     shopID,
+		startTime:  startTime,
+		endTime:    endTime,
+		expiresAt:  expiresAt,
+		status:     status,
+		scheduleID: scheduleID,
+		reason:     reason,
+		version:    version,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+// Release gives the time back once the payment is cancelled or expires
+func (h *SlotHold) Release(reason string) error {
+	if h.status != SlotHoldStatusHeld {
+		return fmt.Errorf("cannot release a %s hold", h.status)
+	}
+
+	h.raiseEvent(&event.SlotHoldReleased{
+		HoldID:       h.id,
+		PaymentID:    h.paymentID,
+		ShopID:       h.shopID,
+		StartTime:    h.startTime,
+		EndTime:      h.endTime,
+		Reason:       reason,
+		EventVersion: h.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// Convert turns the hold into the confirmed schedule. A hold that lapsed but was not released yet can
+// still be converted; the caller checks that nobody else took the time since.
+func (h *SlotHold) Convert(scheduleID string) error {
+	if h.status != SlotHoldStatusHeld {
+		return fmt.Errorf("cannot convert a %s hold", h.status)
+	}
+	if scheduleID == "" {
+		return fmt.Errorf("scheduleID cannot be empty")
+	}
+
+	h.raiseEvent(&event.SlotHoldConverted{
+		HoldID:       h.id,
+		PaymentID:    h.paymentID,
+		ScheduleID:   scheduleID,
+		EventVersion: h.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// IsActive checks if the hold still keeps the time from other customers
+func (h *SlotHold) IsActive(now time.Time) bool {
+	return h.status == SlotHoldStatusHeld && now.Before(h.expiresAt)
+}
+
+func (h *SlotHold) GetUncommittedEvents() []event.DomainEvent {
+	return h.uncommittedEvents
+}
+
+func (h *SlotHold) raiseEvent(ev event.DomainEvent) {
+	h.uncommittedEvents = append(h.uncommittedEvents, ev)
+	h.applyEvent(ev)
+}
+
+func (h *SlotHold) applyEvent(ev event.DomainEvent) error {
+	switch e := ev.(type) {
+	case *event.SlotHoldPlaced:
+		h.id = e.HoldID
+		h.paymentID = e.PaymentID
+		h.userID = e.UserID
+		h.shopID = e.ShopID
+		h.startTime = e.StartTime
+		h.endTime = e.EndTime
+		h.expiresAt = e.ExpiresAt
+		h.status = SlotHoldStatusHeld
+		h.version = 1
+		h.createdAt = e.Timestamp
+		h.updatedAt = e.Timestamp
+
+	case *event.SlotHoldReleased:
+		h.status = SlotHoldStatusReleased
+		h.reason = e.Reason
+		h.version = e.EventVersion
+		h.updatedAt = e.Timestamp
+
+	case *event.SlotHoldConverted:
+		h.status = SlotHoldStatusConverted
+		h.scheduleID = e.ScheduleID
+		h.version = e.EventVersion
+		h.updatedAt = e.Timestamp
+
+	default:
+		return fmt.Errorf("unknown event type: %T", ev)
+	}
+
+	return nil
+}
+
+// Getters
+func (h *SlotHold) ID() string             { return h.id }
+func (h *SlotHold) PaymentID() string      { return h.paymentID }
+func (h *SlotHold) UserID() string         { return h.userID }
+func (h *SlotHold) ShopID() string         { return h.shopID }
+func (h *SlotHold) StartTime() time.Time   { return h.startTime }
+func (h *SlotHold) EndTime() time.Time     { return h.endTime }
+func (h *SlotHold) ExpiresAt() time.Time   { return h.expiresAt }
+func (h *SlotHold) Status() SlotHoldStatus { return h.status }
+func (h *SlotHold) ScheduleID() string     { return h.scheduleID }
+func (h *SlotHold) Reason() string         { return h.reason }
+func (h *SlotHold) Version() int           { return h.version }
+func (h *SlotHold) CreatedAt() time.Time   { return h.createdAt }
+func (h *SlotHold) UpdatedAt() time.Time   { return h.updatedAt }
+
+// Entity interface implementation
+func (h *SlotHold) GetID() string    { return h.id }
+func (h *SlotHold) GetVersion() int  { return h.version }
+func (h *SlotHold) SetVersion(v int) { h.version = v }
+
+// AggregateRoot interface implementation
+func (h *SlotHold) MarkEventsAsCommitted() {
+	h.uncommittedEvents = nil
+}
+
+func (h *SlotHold) LoadFromHistory(events []event.DomainEvent) error {
+	for _, e := range events {
+		if err := h.applyEvent(e); err != nil {
+			return fmt.Errorf("failed to apply event %s: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+package aggregate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"whisko-petcare/internal/domain/event"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Pet sizes, used by services to say which pets they accept
+const (
+	PetSizeSmall  = "SMALL"  // Under 10 kg
+	PetSizeMedium = "MEDIUM" // 10 to 25 kg
+	PetSizeLarge  = "LARGE"  // 25 to 45 kg
+	PetSizeGiant  = "GIANT"  // 45 kg and over
+)
+
+// IsValidPetSize checks if a pet size is supported
+func IsValidPetSize(size string) bool {
+	switch size {
+	case PetSizeSmall, PetSizeMedium, PetSizeLarge, PetSizeGiant:
+		return true
+	}
+	return false
+}
+
+// PetSizeForWeight returns the size of a pet from its weight in kilograms, or "" when the weight is unknown
+func PetSizeForWeight(weight float64) string {
+	switch {
+	case weight <= 0:
+		return ""
+	case weight < 10:
+		return PetSizeSmall
+	case weight < 25:
+		return PetSizeMedium
+	case weight < 45:
+		return PetSizeLarge
+	default:
+		return PetSizeGiant
+	}
+}
+
+// NormalizeCatalogTerm returns the form species and breed names, codes and aliases are compared in:
+// lowercase, without Vietnamese diacritics, with runs of spaces and punctuation turned into "_".
+// "Chó", "cho" and "CHO" all normalize to "cho"; "Golden Retriever" to "golden_retriever".
+func NormalizeCatalogTerm(term string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), term)
+	if err != nil {
+		stripped = term
+	}
+
+	var b strings.Builder
+	separator := false
+	for _, r := range strings.ToLower(stripped) {
+		if r == 'đ' {
+			r = 'd'
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if separator && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			separator = false
+			b.WriteRune(r)
+		} else {
+			separator = true
+		}
+	}
+	return b.String()
+}
+
+// Species is an entry of the species catalogue, identified by a short code such as "dog". Pets store
+// the species and breed codes, so "Dog", "dog" and "chó" all end up as the same species.
+type Species struct {
+	id        string
+	nameEn    string
+	nameVi    string
+	aliases   []string
+	breeds    []event.SpeciesBreed
+	isActive  bool
+	createdBy string
+	version   int
+	createdAt time.Time
+	updatedAt time.Time
+
+	uncommittedEvents []event.DomainEvent
+}
+
+// NewSpecies adds a species to the catalogue
+func NewSpecies(code, nameEn, nameVi string, aliases []string, breeds []event.SpeciesBreed, createdBy string) (*Species, error) {
+	code = NormalizeCatalogTerm(code)
+	if code == "" {
+		return nil, fmt.Errorf("species code cannot be empty")
+	}
+	if strings.TrimSpace(nameEn) == "" || strings.TrimSpace(nameVi) == "" {
+		return nil, fmt.Errorf("species needs both an English and a Vietnamese name")
+	}
+
+	normalizedBreeds := []event.SpeciesBreed{}
+	for _, breed := range breeds {
+		normalized, err := normalizeBreed(breed)
+		if err != nil {
+			return nil, err
+		}
+		if conflict := breedTermConflict(normalizedBreeds, normalized, ""); conflict != "" {
+			return nil, fmt.Errorf("breed name or alias %q is used twice", conflict)
+		}
+		normalizedBreeds = append(normalizedBreeds, normalized)
+	}
+
+	species := &Species{}
+	species.raiseEvent(&event.SpeciesCreated{
+		SpeciesID: code,
+		NameEn:    strings.TrimSpace(nameEn),
+		NameVi:    strings.TrimSpace(nameVi),
+		Aliases:   normalizeAliases(aliases),
+		Breeds:    normalizedBreeds,
+		CreatedBy: createdBy,
+		Timestamp: time.Now(),
+	})
+	return species, nil
+}
+
+// ReconstructSpecies rebuilds a Species aggregate from database state WITHOUT raising events
+func ReconstructSpecies(
+	id, nameEn, nameVi string,
+	aliases []string,
+	breeds []event.SpeciesBreed,
+	isActive bool,
+	createdBy string,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Species {
+	return &Species{
+		id:        id,
+		nameEn:    nameEn,
+		nameVi:    nameVi,
+		aliases:   aliases,
+		breeds:    breeds,
+		isActive:  isActive,
+		createdBy: createdBy,
+		version:   version,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// Update changes the names and aliases of the species
+func (s *Species) Update(nameEn, nameVi string, aliases []string) error {
+	if strings.TrimSpace(nameEn) == "" || strings.TrimSpace(nameVi) == "" {
+		return fmt.Errorf("species needs both an English and a Vietnamese name")
+	}
+
+	s.raiseEvent(&event.SpeciesUpdated{
+		SpeciesID:    s.id,
+		NameEn:       strings.TrimSpace(nameEn),
+		NameVi:       strings.TrimSpace(nameVi),
+		Aliases:      normalizeAliases(aliases),
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// AddBreed adds a breed to the species
+func (s *Species) AddBreed(breed event.SpeciesBreed) error {
+	normalized, err := normalizeBreed(breed)
+	if err != nil {
+		return err
+	}
+	if _, exists := s.findBreedByCode(normalized.Code); exists {
+		return fmt.Errorf("breed %s already exists", normalized.Code)
+	}
+	if conflict := breedTermConflict(s.breeds, normalized, ""); conflict != "" {
+		return fmt.Errorf("breed name or alias %q is already used by another breed", conflict)
+	}
+
+	s.raiseEvent(&event.SpeciesBreedAdded{
+		SpeciesID:    s.id,
+		Breed:        normalized,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// UpdateBreed replaces the names, aliases and size of a breed; the breed code cannot change
+func (s *Species) UpdateBreed(breed event.SpeciesBreed) error {
+	normalized, err := normalizeBreed(breed)
+	if err != nil {
+		return err
+	}
+	if _, exists := s.findBreedByCode(normalized.Code); !exists {
+		return fmt.Errorf("breed %s not found", normalized.Code)
+	}
+	if conflict := breedTermConflict(s.breeds, normalized, normalized.Code); conflict != "" {
+		return fmt.Errorf("breed name or alias %q is already used by another breed", conflict)
+	}
+
+	s.raiseEvent(&event.SpeciesBreedUpdated{
+		SpeciesID:    s.id,
+		Breed:        normalized,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// RemoveBreed removes a breed; pets already recorded with it keep the breed code
+func (s *Species) RemoveBreed(code string) error {
+	code = NormalizeCatalogTerm(code)
+	if _, exists := s.findBreedByCode(code); !exists {
+		return fmt.Errorf("breed %s not found", code)
+	}
+
+	s.raiseEvent(&event.SpeciesBreedRemoved{
+		SpeciesID:    s.id,
+		BreedCode:    code,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// Deactivate stops the species from being chosen for new pets; existing pets keep it
+func (s *Species) Deactivate() error {
+	if !s.isActive {
+		return fmt.Errorf("species is already deactivated")
+	}
+
+	s.raiseEvent(&event.SpeciesDeactivated{
+		SpeciesID:    s.id,
+		EventVersion: s.version + 1,
+		Timestamp:    time.Now(),
+	})
+	return nil
+}
+
+// Terms returns the normalized code, names and aliases a species can be looked up by
+func (s *Species) Terms() []string {
+	return catalogTerms(s.id, s.nameEn, s.nameVi, s.aliases)
+}
+
+// Matches reports whether a species name, code or alias refers to this species
+func (s *Species) Matches(term string) bool {
+	normalized := NormalizeCatalogTerm(term)
+	if normalized == "" {
+		return false
+	}
+	for _, known := range s.Terms() {
+		if known == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// FindBreed looks a breed up by its code, English or Vietnamese name, or an alias
+func (s *Species) FindBreed(term string) (event.SpeciesBreed, bool) {
+	normalized := NormalizeCatalogTerm(term)
+	if normalized == "" {
+		return event.SpeciesBreed{}, false
+	}
+	for _, breed := range s.breeds {
+		for _, known := range catalogTerms(breed.Code, breed.NameEn, breed.NameVi, breed.Aliases) {
+			if known == normalized {
+				return breed, true
+			}
+		}
+	}
+	return event.SpeciesBreed{}, false
+}
+
+// HasBreeds reports whether the species lists its breeds; pets of a species without a breed list
+// may use any breed name
+func (s *Species) HasBreeds() bool {
+	return len(s.breeds) > 0
+}
+
+func (s *Species) findBreedByCode(code string) (event.SpeciesBreed, bool) {
+	for _, breed := range s.breeds {
+		if breed.Code == code {
+			return breed, true
+		}
+	}
+	return event.SpeciesBreed{}, false
+}
+
+// breedTermConflict returns a term of the breed that one of the other breeds (except ignoreCode) already uses
+func breedTermConflict(breeds []event.SpeciesBreed, breed event.SpeciesBreed, ignoreCode string) string {
+	for _, term := range catalogTerms(breed.Code, breed.NameEn, breed.NameVi, breed.Aliases) {
+		for _, other := range breeds {
+			if other.Code == ignoreCode {
+				continue
+			}
+			for _, known := range catalogTerms(other.Code, other.NameEn, other.NameVi, other.Aliases) {
+				if known == term {
+					return term
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeBreed validates a breed and normalizes its code and aliases
+func normalizeBreed(breed event.SpeciesBreed) (event.SpeciesBreed, error) {
+	breed.Code = NormalizeCatalogTerm(breed.Code)
+	breed.NameEn = strings.TrimSpace(breed.NameEn)
+	breed.NameVi = strings.TrimSpace(breed.NameVi)
+	breed.Size = strings.ToUpper(strings.TrimSpace(breed.Size))
+	breed.Aliases = normalizeAliases(breed.Aliases)
+
+	if breed.Code == "" {
+		return breed, fmt.Errorf("breed code cannot be empty")
+	}
+	if breed.NameEn == "" || breed.NameVi == "" {
+		return breed, fmt.Errorf("breed %s needs both an English and a Vietnamese name", breed.Code)
+	}
+	if breed.Size != "" && !IsValidPetSize(breed.Size) {
+		return breed, fmt.Errorf("invalid size for breed %s: %s", breed.Code, breed.Size)
+	}
+	return breed, nil
+}
+
+// normalizeAliases normalizes aliases, dropping empty and repeated ones
+func normalizeAliases(aliases []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, alias := range aliases {
+		term := NormalizeCatalogTerm(alias)
+		if term != "" && !seen[term] {
+			seen[term] = true
+			normalized = append(normalized, term)
+		}
+	}
+	return normalized
+}
+
+// catalogTerms returns the distinct normalized lookup terms of a catalogue entry
+func catalogTerms(code, nameEn, nameVi string, aliases []string) []string {
+	terms := []string{code}
+	for _, term := range append([]string{NormalizeCatalogTerm(nameEn), NormalizeCatalogTerm(nameVi)}, aliases...) {
+		duplicate := term == ""
+		for _, known := range terms {
+			if known == term {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func (s *Species) raiseEvent(ev event.DomainEvent) {
+	s.uncommittedEvents = append(s.uncommittedEvents, ev)
+	s.applyEvent(ev)
+}
+
+func (s *Species) applyEvent(ev event.DomainEvent) error {
+	switch e := ev.(type) {
+	case *event.SpeciesCreated:
+		s.id = e.SpeciesID
+		s.nameEn = e.NameEn
+		s.nameVi = e.NameVi
+		s.aliases = e.Aliases
+		s.breeds = e.Breeds
+		s.isActive = true
+		s.createdBy = e.CreatedBy
+		s.version = 1
+		s.createdAt = e.Timestamp
+		s.updatedAt = e.Timestamp
+
+	case *event.SpeciesUpdated:
+		s.nameEn = e.NameEn
+		s.nameVi = e.NameVi
+		s.aliases = e.Aliases
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SpeciesBreedAdded:
+		s.breeds = append(s.breeds, e.Breed)
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SpeciesBreedUpdated:
+		for i := range s.breeds {
+			if s.breeds[i].Code == e.Breed.Code {
+				s.breeds[i] = e.Breed
+			}
+		}
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SpeciesBreedRemoved:
+		breeds := []event.SpeciesBreed{}
+		for _, breed := range s.breeds {
+			if breed.Code != e.BreedCode {
+				breeds = append(breeds, breed)
+			}
+		}
+		s.breeds = breeds
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	case *event.SpeciesDeactivated:
+		s.isActive = false
+		s.version = e.EventVersion
+		s.updatedAt = e.Timestamp
+
+	default:
+		return fmt.Errorf("unknown event type: %T", ev)
+	}
+
+	return nil
+}
+
+// Getters
+func (s *Species) ID() string                   { return s.id }
+func (s *Species) Code() string                 { return s.id }
+func (s *Species) NameEn() string               { return s.nameEn }
+func (s *Species) NameVi() string               { return s.nameVi }
+func (s *Species) Aliases() []string            { return s.aliases }
+func (s *Species) Breeds() []event.SpeciesBreed { return s.breeds }
+func (s *Species) IsActive() bool               { return s.isActive }
+func (s *Species) CreatedBy() string            { return s.createdBy }
+func (s *Species) Version() int                 { return s.version }
+func (s *Species) CreatedAt() time.Time         { return s.createdAt }
+func (s *Species) UpdatedAt() time.Time         { return s.updatedAt }
+
+// Entity interface implementation
+func (s *Species) GetID() string      { return s.id }
+func (s *Species) GetVersion() int    { return s.version }
+func (s *Species) SetVersion(ver int) { s.version = ver }
+
+// AggregateRoot interface implementation
+func (s *Species) GetUncommittedEvents() []event.DomainEvent {
+	return s.uncommittedEvents
+}
+
+func (s *Species) MarkEventsAsCommitted() {
+	s.uncommittedEvents = nil
+}
+
+func (s *Species) LoadFromHistory(events []event.DomainEvent) error {
+	for _, e := range events {
+		if err := s.applyEvent(e); err != nil {
+			return fmt.Errorf("failed to apply event %s: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
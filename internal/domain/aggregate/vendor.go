@@ -14,6 +14,18 @@ type VendorBankAccount struct {
 	BankBranch    string
 }
 
+// Default reschedule policy for vendors that have not set one
+const (
+	DefaultRescheduleCutoffHours = 24
+	DefaultMaxReschedules        = 2
+)
+
+// ReschedulePolicy limits how customers may move their bookings with a vendor
+type ReschedulePolicy struct {
+	CutoffHours    int // Customers cannot reschedule later than this many hours before the booking starts
+	MaxReschedules int // How many times a booking may be moved; 0 disables customer reschedules
+}
+
 type Vendor struct {
 	id          string
 	name        string
@@ -22,6 +34,10 @@ type Vendor struct {
 	address     string
 	imageUrl    string
 	bankAccount *VendorBankAccount // Optional bank account for payouts
+	settlementPeriod SettlementPeriod // How often earnings are settled into a payout
+	minPayoutAmount  int              // Balances below this are carried over to the next period
+	paymentMethods   []PaymentMethod  // Payment methods customers can choose when booking this vendor
+	reschedulePolicy *ReschedulePolicy // Nil until the vendor sets one
 	version     int
 	createdAt   time.Time
 	updatedAt   time.Time
@@ -185,6 +201,127 @@ func (v *Vendor) GetBankAccount() *VendorBankAccount {
 	}
 }
 
+// UpdateSettlementSettings updates how often vendor earnings are paid out and the minimum payout amount
+func (v *Vendor) UpdateSettlementSettings(period SettlementPeriod, minPayoutAmount int) error {
+	if !period.IsValid() {
+		return fmt.Errorf("invalid settlement period: %s", period)
+	}
+	if minPayoutAmount < 0 {
+		return fmt.Errorf("minimum payout amount cannot be negative")
+	}
+
+	v.raiseEvent(&event.VendorSettlementSettingsUpdated{
+		VendorID:        v.id,
+		Period:          string(period),
+		MinPayoutAmount: minPayoutAmount,
+		EventVersion:    v.version + 1,
+		Timestamp:       time.Now(),
+	})
+	return nil
+}
+
+// SetSettlementSettings sets settlement settings (used by repository during reconstruction)
+func (v *Vendor) SetSettlementSettings(period SettlementPeriod, minPayoutAmount int) {
+	v.settlementPeriod = period
+	v.minPayoutAmount = minPayoutAmount
+}
+
+// UpdatePaymentMethods sets which payment methods customers can use when booking the vendor
+func (v *Vendor) UpdatePaymentMethods(methods []PaymentMethod) error {
+	if len(methods) == 0 {
+		return fmt.Errorf("at least one payment method is required")
+	}
+
+	seen := make(map[PaymentMethod]bool)
+	names := make([]string, 0, len(methods))
+	for _, method := range methods {
+		if !method.IsValid() {
+			return fmt.Errorf("invalid payment method: %s", method)
+		}
+		if seen[method] {
+			continue
+		}
+		seen[method] = true
+		names = append(names, string(method))
+	}
+
+	v.raiseEvent(&event.VendorPaymentMethodsUpdated{
+		VendorID:       v.id,
+		PaymentMethods: names,
+		EventVersion:   v.version + 1,
+		Timestamp:      time.Now(),
+	})
+	return nil
+}
+
+// SetPaymentMethods sets accepted payment methods (used by repository during reconstruction)
+func (v *Vendor) SetPaymentMethods(methods []PaymentMethod) {
+	v.paymentMethods = methods
+}
+
+// PaymentMethods returns the payment methods the vendor accepts (online PayOS by default)
+func (v *Vendor) PaymentMethods() []PaymentMethod {
+	if len(v.paymentMethods) == 0 {
+		return []PaymentMethod{PaymentMethodPayOS}
+	}
+	return v.paymentMethods
+}
+
+// AcceptsPaymentMethod checks if customers can pay the vendor with the given method
+func (v *Vendor) AcceptsPaymentMethod(method PaymentMethod) bool {
+	for _, accepted := range v.PaymentMethods() {
+		if accepted == method {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateReschedulePolicy sets how late and how often customers may reschedule bookings with the vendor
+func (v *Vendor) UpdateReschedulePolicy(cutoffHours, maxReschedules int) error {
+	if cutoffHours < 0 {
+		return fmt.Errorf("reschedule cut-off hours cannot be negative")
+	}
+	if maxReschedules < 0 {
+		return fmt.Errorf("maximum reschedules cannot be negative")
+	}
+
+	v.raiseEvent(&event.VendorReschedulePolicyUpdated{
+		VendorID:       v.id,
+		CutoffHours:    cutoffHours,
+		MaxReschedules: maxReschedules,
+		EventVersion:   v.version + 1,
+		Timestamp:      time.Now(),
+	})
+	return nil
+}
+
+// SetReschedulePolicy sets the reschedule policy (used by repository during reconstruction)
+func (v *Vendor) SetReschedulePolicy(policy *ReschedulePolicy) {
+	v.reschedulePolicy = policy
+}
+
+// ReschedulePolicy returns the vendor's reschedule policy (24 hours cut-off, two reschedules by default)
+func (v *Vendor) ReschedulePolicy() ReschedulePolicy {
+	if v.reschedulePolicy == nil {
+		return ReschedulePolicy{CutoffHours: DefaultRescheduleCutoffHours, MaxReschedules: DefaultMaxReschedules}
+	}
+	return *v.reschedulePolicy
+}
+
+// SettlementPeriod returns the vendor's settlement period (daily by default)
+func (v *Vendor) SettlementPeriod() SettlementPeriod {
+	if !v.settlementPeriod.IsValid() {
+		return SettlementPeriodDaily
+	}
+	return v.settlementPeriod
+}
+
+// MinPayoutAmount returns the minimum balance required before a payout is made
+func (v *Vendor) MinPayoutAmount() int {
+	return v.minPayoutAmount
+}
+
 func (v *Vendor) Delete() error {
 	v.raiseEvent(&event.VendorDeleted{
 		VendorID:     v.id,
@@ -249,6 +386,28 @@ func (v *Vendor) applyEvent(ev event.DomainEvent) error {
 		v.version = e.EventVersion
 		v.updatedAt = e.Timestamp
 		
+	case *event.VendorSettlementSettingsUpdated:
+		v.settlementPeriod = SettlementPeriod(e.Period)
+		v.minPayoutAmount = e.MinPayoutAmount
+		v.version = e.EventVersion
+		v.updatedAt = e.Timestamp
+
+	case *event.VendorPaymentMethodsUpdated:
+		v.paymentMethods = make([]PaymentMethod, 0, len(e.PaymentMethods))
+		for _, method := range e.PaymentMethods {
+			v.paymentMethods = append(v.paymentMethods, PaymentMethod(method))
+		}
+		v.version = e.EventVersion
+		v.updatedAt = e.Timestamp
+
+	case *event.VendorReschedulePolicyUpdated:
+		v.reschedulePolicy = &ReschedulePolicy{
+			CutoffHours:    e.CutoffHours,
+			MaxReschedules: e.MaxReschedules,
+		}
+		v.version = e.EventVersion
+		v.updatedAt = e.Timestamp
+		
 	default:
 		return fmt.Errorf("unknown event type: %T", ev)
 	}
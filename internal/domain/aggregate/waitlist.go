@@ -0,0 +1,323 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistClaimWindow is how long a waitlisted customer has to claim an offered slot
+const WaitlistClaimWindow = 15 * time.Minute
+
+// WaitlistStatus is the status of a waitlist entry
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting WaitlistStatus = "WAITING"
+	WaitlistStatusOffered WaitlistStatus = "OFFERED" // A freed slot is waiting to be claimed
+	WaitlistStatusClaimed WaitlistStatus = "CLAIMED" // The customer checked out the offered slot
+	WaitlistStatusLeft    WaitlistStatus = "LEFT"
+	WaitlistStatusExpired WaitlistStatus = "EXPIRED" // The time window passed without a slot
+)
+
+// Reasons an offered slot moves on to the next customer
+const (
+	WaitlistOfferDeclined = "DECLINED"
+	WaitlistOfferLapsed   = "LAPSED"
+)
+
+// WaitlistEntry is a customer waiting for a slot with a vendor's service within a time window. Freed
+// slots are offered to waiting customers one at a time, in the order they joined.
+type WaitlistEntry struct {
+	id          string
+	userID      string
+	petID       string
+	vendorID    string
+	serviceID   string
+	windowStart time.Time
+	windowEnd   time.Time
+	status      WaitlistStatus
+	offer       *event.WaitlistOffer
+	paymentID   string // Checkout of the claimed slot
+	version     int
+	createdAt   time.Time
+	updatedAt   time.Time
+
+	uncommittedEvents []event.DomainEvent
+}
+
+// NewWaitlistEntry puts a customer on the waitlist for a service within a time window
+func NewWaitlistEntry(userID, petID, vendorID, serviceID string, windowStart, windowEnd time.Time) (*WaitlistEntry, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty")
+	}
+	if petID == "" {
+		return nil, fmt.Errorf("petID cannot be empty")
+	}
+	if vendorID == "" {
+		return nil, fmt.Errorf("vendorID cannot be empty")
+	}
+	if serviceID == "" {
+		return nil, fmt.Errorf("serviceID cannot be empty")
+	}
+	if !windowEnd.After(windowStart) {
+		return nil, fmt.Errorf("window end must be after window start")
+	}
+	if !windowEnd.After(time.Now()) {
+		return nil, fmt.Errorf("window has already passed")
+	}
+
+	entry := &WaitlistEntry{}
+	entry.raiseEvent(&event.WaitlistJoined{
+		EntryID:     uuid.New().String(),
+		UserID:      userID,
+		PetID:       petID,
+		VendorID:    vendorID,
+		ServiceID:   serviceID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Timestamp:   time.Now(),
+	})
+
+	return entry, nil
+}
+
+// ReconstructWaitlistEntry rebuilds a waitlist entry from stored state without raising events
+func ReconstructWaitlistEntry(id, userID, petID, vendorID, serviceID string, windowStart, windowEnd time.Time,
+	status WaitlistStatus, offer *event.WaitlistOffer, paymentID string, version int, createdAt, updatedAt time.Time) *WaitlistEntry {
+	return &WaitlistEntry{
+		id:          id,
+		userID:      userID,
+		petID:       petID,
+		vendorID:    vendorID,
+		serviceID:   serviceID,
+		windowStart: windowStart,
+		windowEnd:   windowEnd,
+		status:      status,
+		offer:       offer,
+		paymentID:   paymentID,
+		version:     version,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// Fits checks if a slot lies within the entry's time window
+func (w *WaitlistEntry) Fits(startTime, endTime time.Time) bool {
+	return !startTime.Before(w.windowStart) && !endTime.After(w.windowEnd)
+}
+
+// OfferSlot offers a freed slot to the customer. The claim lasts WaitlistClaimWindow, but never past the
+// start of the slot. The services the slot was booked for are kept to pass the slot on if it is not claimed.
+func (w *WaitlistEntry) OfferSlot(startTime, endTime time.Time, serviceIDs []string, freedScheduleID string, now time.Time) error {
+	if w.status != WaitlistStatusWaiting {
+		return fmt.Errorf("cannot offer a slot to a %s entry", w.status)
+	}
+	if !w.Fits(startTime, endTime) {
+		return fmt.Errorf("slot is outside the waitlist window")
+	}
+	if !startTime.After(now) {
+		return fmt.Errorf("slot has already started")
+	}
+
+	expiresAt := now.Add(WaitlistClaimWindow)
+	if startTime.Before(expiresAt) {
+		expiresAt = startTime
+	}
+
+	w.raiseEvent(&event.WaitlistSlotOffered{
+		EntryID:   w.id,
+		UserID:    w.userID,
+		VendorID:  w.vendorID,
+		ServiceID: w.serviceID,
+		Offer: event.WaitlistOffer{
+			StartTime:       startTime,
+			EndTime:         endTime,
+			ExpiresAt:       expiresAt,
+			ServiceIDs:      serviceIDs,
+			FreedScheduleID: freedScheduleID,
+			OfferedAt:       now,
+		},
+		EventVersion: w.version + 1,
+		Timestamp:    now,
+	})
+
+	return nil
+}
+
+// PassOffer gives up the offered slot because the customer declined it or let the claim lapse. The customer
+// keeps their place on the waitlist for later slots.
+func (w *WaitlistEntry) PassOffer(reason string) error {
+	if w.status != WaitlistStatusOffered {
+		return fmt.Errorf("entry has no open offer")
+	}
+	if reason != WaitlistOfferDeclined && reason != WaitlistOfferLapsed {
+		return fmt.Errorf("invalid reason: %s", reason)
+	}
+
+	w.raiseEvent(&event.WaitlistOfferPassed{
+		EntryID:      w.id,
+		StartTime:    w.offer.StartTime,
+		EndTime:      w.offer.EndTime,
+		Reason:       reason,
+		EventVersion: w.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+// Claim takes the offered slot for checkout by the customer it was offered to
+func (w *WaitlistEntry) Claim(userID, paymentID string, startTime, endTime, now time.Time) error {
+	if w.status != WaitlistStatusOffered {
+		return fmt.Errorf("entry has no open offer")
+	}
+	if userID != w.userID {
+		return fmt.Errorf("slot was offered to another customer")
+	}
+	if !now.Before(w.offer.ExpiresAt) {
+		return fmt.Errorf("offer expired at %s", w.offer.ExpiresAt.Format(time.RFC3339))
+	}
+	if !startTime.Equal(w.offer.StartTime) || !endTime.Equal(w.offer.EndTime) {
+		return fmt.Errorf("checkout time does not match the offered slot")
+	}
+	if paymentID == "" {
+		return fmt.Errorf("paymentID cannot be empty")
+	}
+
+	w.raiseEvent(&event.WaitlistOfferClaimed{
+		EntryID:      w.id,
+		PaymentID:    paymentID,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		EventVersion: w.version + 1,
+		Timestamp:    now,
+	})
+
+	return nil
+}
+
+// Leave takes the customer off the waitlist; an open offer goes to the next customer
+func (w *WaitlistEntry) Leave() error {
+	return w.close(WaitlistStatusLeft)
+}
+
+// Expire closes the entry once its time window has passed
+func (w *WaitlistEntry) Expire(now time.Time) error {
+	if now.Before(w.windowEnd) {
+		return fmt.Errorf("window has not passed yet")
+	}
+	return w.close(WaitlistStatusExpired)
+}
+
+// HasLapsedOffer checks if the entry holds an offer that was not claimed in time
+func (w *WaitlistEntry) HasLapsedOffer(now time.Time) bool {
+	return w.status == WaitlistStatusOffered && !now.Before(w.offer.ExpiresAt)
+}
+
+func (w *WaitlistEntry) close(status WaitlistStatus) error {
+	if w.status != WaitlistStatusWaiting && w.status != WaitlistStatusOffered {
+		return fmt.Errorf("entry is already %s", w.status)
+	}
+
+	w.raiseEvent(&event.WaitlistEntryClosed{
+		EntryID:      w.id,
+		Status:       string(status),
+		EventVersion: w.version + 1,
+		Timestamp:    time.Now(),
+	})
+
+	return nil
+}
+
+func (w *WaitlistEntry) GetUncommittedEvents() []event.DomainEvent {
+	return w.uncommittedEvents
+}
+
+func (w *WaitlistEntry) raiseEvent(ev event.DomainEvent) {
+	w.uncommittedEvents = append(w.uncommittedEvents, ev)
+	w.applyEvent(ev)
+}
+
+func (w *WaitlistEntry) applyEvent(ev event.DomainEvent) error {
+	switch e := ev.(type) {
+	case *event.WaitlistJoined:
+		w.id = e.EntryID
+		w.userID = e.UserID
+		w.petID = e.PetID
+		w.vendorID = e.VendorID
+		w.serviceID = e.ServiceID
+		w.windowStart = e.WindowStart
+		w.windowEnd = e.WindowEnd
+		w.status = WaitlistStatusWaiting
+		w.version = 1
+		w.createdAt = e.Timestamp
+		w.updatedAt = e.Timestamp
+
+	case *event.WaitlistSlotOffered:
+		offer := e.Offer
+		w.offer = &offer
+		w.status = WaitlistStatusOffered
+		w.version = e.EventVersion
+		w.updatedAt = e.Timestamp
+
+	case *event.WaitlistOfferPassed:
+		w.offer = nil
+		w.status = WaitlistStatusWaiting
+		w.version = e.EventVersion
+		w.updatedAt = e.Timestamp
+
+	case *event.WaitlistOfferClaimed:
+		w.paymentID = e.PaymentID
+		w.status = WaitlistStatusClaimed
+		w.version = e.EventVersion
+		w.updatedAt = e.Timestamp
+
+	case *event.WaitlistEntryClosed:
+		w.status = WaitlistStatus(e.Status)
+		w.version = e.EventVersion
+		w.updatedAt = e.Timestamp
+
+	default:
+		return fmt.Errorf("unknown event type: %T", ev)
+	}
+
+	return nil
+}
+
+// Getters
+func (w *WaitlistEntry) ID() string                  { return w.id }
+func (w *WaitlistEntry) UserID() string              { return w.userID }
+func (w *WaitlistEntry) PetID() string               { return w.petID }
+func (w *WaitlistEntry) VendorID() string            { return w.vendorID }
+func (w *WaitlistEntry) ServiceID() string           { return w.serviceID }
+func (w *WaitlistEntry) WindowStart() time.Time      { return w.windowStart }
+func (w *WaitlistEntry) WindowEnd() time.Time        { return w.windowEnd }
+func (w *WaitlistEntry) Status() WaitlistStatus      { return w.status }
+func (w *WaitlistEntry) Offer() *event.WaitlistOffer { return w.offer }
+func (w *WaitlistEntry) PaymentID() string           { return w.paymentID }
+func (w *WaitlistEntry) Version() int                { return w.version }
+func (w *WaitlistEntry) CreatedAt() time.Time        { return w.createdAt }
+func (w *WaitlistEntry) UpdatedAt() time.Time        { return w.updatedAt }
+
+// Entity interface implementation
+func (w *WaitlistEntry) GetID() string    { return w.id }
+func (w *WaitlistEntry) GetVersion() int  { return w.version }
+func (w *WaitlistEntry) SetVersion(v int) { w.version = v }
+
+// AggregateRoot interface implementation
+func (w *WaitlistEntry) MarkEventsAsCommitted() {
+	w.uncommittedEvents = nil
+}
+
+func (w *WaitlistEntry) LoadFromHistory(events []event.DomainEvent) error {
+	for _, e := range events {
+		if err := w.applyEvent(e); err != nil {
+			return fmt.Errorf("failed to apply event %s: %w", e.EventType(), err)
+		}
+	}
+	return nil
+}
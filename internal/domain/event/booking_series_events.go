@@ -0,0 +1,115 @@
+package event
+
+import "time"
+
+// SeriesRecurrence is how often a booking series repeats, after RFC 5545 RRULE.
+// A series ends after Count occurrences or at Until, whichever is set; with neither it runs until cancelled.
+type SeriesRecurrence struct {
+	Frequency string    `json:"frequency" bson:"frequency"` // DAILY, WEEKLY or MONTHLY
+	Interval  int       `json:"interval" bson:"interval"`   // Every Interval days, weeks or months
+	Count     int       `json:"count,omitempty" bson:"count,omitempty"`
+	Until     time.Time `json:"until,omitempty" bson:"until,omitempty"`
+}
+
+// Kinds of booking series exceptions
+const (
+	SeriesExceptionSkip   = "SKIP"
+	SeriesExceptionModify = "MODIFY"
+)
+
+// SeriesException changes one occurrence of a booking series. Occurrences are identified by the start
+// time the recurrence gives them, which stays the same when the occurrence is moved.
+type SeriesException struct {
+	OccurrenceStart time.Time `json:"occurrence_start" bson:"occurrence_start"`
+	Kind            string    `json:"kind" bson:"kind"`
+	StartTime       time.Time `json:"start_time,omitempty" bson:"start_time,omitempty"` // New time of a modified occurrence
+	EndTime         time.Time `json:"end_time,omitempty" bson:"end_time,omitempty"`
+	ScheduleID      string    `json:"schedule_id,omitempty" bson:"schedule_id,omitempty"` // Booking cancelled by a skip
+	Reason          string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	CreatedBy       string    `json:"created_by" bson:"created_by"`
+	CreatedAt       time.Time `json:"created_at" bson:"created_at"`
+}
+
+// SeriesOccurrence is an occurrence of a booking series that has been booked
+type SeriesOccurrence struct {
+	OccurrenceStart time.Time `json:"occurrence_start" bson:"occurrence_start"`
+	ScheduleID      string    `json:"schedule_id" bson:"schedule_id"`
+	PaymentID       string    `json:"payment_id,omitempty" bson:"payment_id,omitempty"`
+}
+
+// BookingSeriesCreated event - fired when a customer sets up a recurring booking
+type BookingSeriesCreated struct {
+	SeriesID        string           `json:"series_id"`
+	UserID          string           `json:"user_id"`
+	VendorID        string           `json:"vendor_id"`
+	PetID           string           `json:"pet_id"`
+	ServiceIDs      []string         `json:"service_ids"`
+	Recurrence      SeriesRecurrence `json:"recurrence"`
+	FirstStartTime  time.Time        `json:"first_start_time"`
+	FirstEndTime    time.Time        `json:"first_end_time"`
+	PaymentMode     string           `json:"payment_mode"`
+	Method          string           `json:"method"`
+	OccurrencePrice int              `json:"occurrence_price"`
+	Status          string           `json:"status"`
+	Timestamp       time.Time        `json:"timestamp"`
+}
+
+func (e *BookingSeriesCreated) EventType() string     { return "BookingSeriesCreated" }
+func (e *BookingSeriesCreated) AggregateID() string   { return e.SeriesID }
+func (e *BookingSeriesCreated) OccurredAt() time.Time { return e.Timestamp }
+func (e *BookingSeriesCreated) Version() int          { return 1 }
+
+// BookingSeriesActivated event - fired when the upfront payment of a series is paid
+type BookingSeriesActivated struct {
+	SeriesID     string    `json:"series_id"`
+	PaymentID    string    `json:"payment_id"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *BookingSeriesActivated) EventType() string     { return "BookingSeriesActivated" }
+func (e *BookingSeriesActivated) AggregateID() string   { return e.SeriesID }
+func (e *BookingSeriesActivated) OccurredAt() time.Time { return e.Timestamp }
+func (e *BookingSeriesActivated) Version() int          { return e.EventVersion }
+
+// BookingSeriesOccurrencesBooked event - fired when upcoming occurrences of a series are booked ahead of time
+type BookingSeriesOccurrencesBooked struct {
+	SeriesID       string             `json:"series_id"`
+	Occurrences    []SeriesOccurrence `json:"occurrences"`
+	NextOccurrence time.Time          `json:"next_occurrence,omitempty"` // Zero once every occurrence is booked
+	EventVersion   int                `json:"version"`
+	Timestamp      time.Time          `json:"timestamp"`
+}
+
+func (e *BookingSeriesOccurrencesBooked) EventType() string     { return "BookingSeriesOccurrencesBooked" }
+func (e *BookingSeriesOccurrencesBooked) AggregateID() string   { return e.SeriesID }
+func (e *BookingSeriesOccurrencesBooked) OccurredAt() time.Time { return e.Timestamp }
+func (e *BookingSeriesOccurrencesBooked) Version() int          { return e.EventVersion }
+
+// BookingSeriesExceptionAdded event - fired when an occurrence of a series is skipped or moved
+type BookingSeriesExceptionAdded struct {
+	SeriesID     string          `json:"series_id"`
+	Exception    SeriesException `json:"exception"`
+	EventVersion int             `json:"version"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+func (e *BookingSeriesExceptionAdded) EventType() string     { return "BookingSeriesExceptionAdded" }
+func (e *BookingSeriesExceptionAdded) AggregateID() string   { return e.SeriesID }
+func (e *BookingSeriesExceptionAdded) OccurredAt() time.Time { return e.Timestamp }
+func (e *BookingSeriesExceptionAdded) Version() int          { return e.EventVersion }
+
+// BookingSeriesCancelled event - fired when the rest of a series is cancelled
+type BookingSeriesCancelled struct {
+	SeriesID     string    `json:"series_id"`
+	From         time.Time `json:"from"` // Occurrences starting from this time are cancelled
+	CancelledBy  string    `json:"cancelled_by"`
+	Reason       string    `json:"reason,omitempty"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *BookingSeriesCancelled) EventType() string     { return "BookingSeriesCancelled" }
+func (e *BookingSeriesCancelled) AggregateID() string   { return e.SeriesID }
+func (e *BookingSeriesCancelled) OccurredAt() time.Time { return e.Timestamp }
+func (e *BookingSeriesCancelled) Version() int          { return e.EventVersion }
@@ -9,6 +9,12 @@ type PaymentItem struct {
 	Price    int    `json:"price"` // Amount in VND cents
 }
 
+// PaymentPetLine is one pet of a booking covering several pets, with the services booked for it
+type PaymentPetLine struct {
+	PetID      string   `json:"pet_id" bson:"pet_id"`
+	ServiceIDs []string `json:"service_ids" bson:"service_ids"`
+}
+
 // PaymentCreated event
 type PaymentCreated struct {
 	PaymentID   string        `json:"payment_id"`
@@ -26,6 +32,9 @@ type PaymentCreated struct {
 	ServiceIDs  []string      `json:"service_ids"`
 	StartTime   time.Time     `json:"start_time"`
 	EndTime     time.Time     `json:"end_time"`
+	ScheduleID  string        `json:"schedule_id,omitempty"` // Set on top-ups and occurrence payments of an existing booking
+	SeriesID    string        `json:"series_id,omitempty"`   // Set on payments for a recurring booking
+	PetLines    []PaymentPetLine `json:"pet_lines,omitempty"` // Set on bookings covering several pets
 	Timestamp   time.Time     `json:"timestamp"`
 }
 
@@ -59,4 +68,82 @@ type PaymentStatusChanged struct {
 func (e *PaymentStatusChanged) EventType() string     { return "PaymentStatusChanged" }
 func (e *PaymentStatusChanged) AggregateID() string   { return e.PaymentID }
 func (e *PaymentStatusChanged) OccurredAt() time.Time { return e.Timestamp }
-func (e *PaymentStatusChanged) Version() int          { return 1 }
\ No newline at end of file
+func (e *PaymentStatusChanged) Version() int          { return 1 }
+
+// PaymentCollected event - fired when the vendor collects an offline (pay at shop) payment
+type PaymentCollected struct {
+	PaymentID   string    `json:"payment_id"`
+	VendorID    string    `json:"vendor_id"`
+	Amount      int       `json:"amount"`
+	CollectedBy string    `json:"collected_by"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (e *PaymentCollected) EventType() string     { return "PaymentCollected" }
+func (e *PaymentCollected) AggregateID() string   { return e.PaymentID }
+func (e *PaymentCollected) OccurredAt() time.Time { return e.Timestamp }
+func (e *PaymentCollected) Version() int          { return 1 }
+
+// PaymentRefundRequested event - fired when a refund is reserved on a paid payment, before the payment
+// gateway is asked to execute it. The refund ID is the idempotency key sent to the gateway.
+type PaymentRefundRequested struct {
+	PaymentID string    `json:"payment_id"`
+	RefundID  string    `json:"refund_id"`
+	Amount    int       `json:"amount"`
+	Reason    string    `json:"reason"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e *PaymentRefundRequested) EventType() string     { return "PaymentRefundRequested" }
+func (e *PaymentRefundRequested) AggregateID() string   { return e.PaymentID }
+func (e *PaymentRefundRequested) OccurredAt() time.Time { return e.Timestamp }
+func (e *PaymentRefundRequested) Version() int          { return 1 }
+
+// PaymentRefunded event - fired when part or all of a paid payment is refunded
+type PaymentRefunded struct {
+	PaymentID        string    `json:"payment_id"`
+	VendorID         string    `json:"vendor_id"`
+	RefundID         string    `json:"refund_id"`
+	Method           string    `json:"method"`
+	Amount           int       `json:"amount"`            // Amount refunded by this refund
+	RefundedAmount   int       `json:"refunded_amount"`   // Total refunded so far
+	DiscountReversed int       `json:"discount_reversed"` // Platform-funded discount taken back from the vendor on a full refund
+	Reason           string    `json:"reason"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (e *PaymentRefunded) EventType() string     { return "PaymentRefunded" }
+func (e *PaymentRefunded) AggregateID() string   { return e.PaymentID }
+func (e *PaymentRefunded) OccurredAt() time.Time { return e.Timestamp }
+func (e *PaymentRefunded) Version() int          { return 1 }
+
+// PaymentAmountAdjusted event - fired when the amount still due on an unpaid pay at shop payment changes
+// because the booking changed price
+type PaymentAmountAdjusted struct {
+	PaymentID  string      `json:"payment_id"`
+	Difference int         `json:"difference"` // Negative when the booking became cheaper
+	Amount     int         `json:"amount"`     // Amount due after the adjustment
+	Item       PaymentItem `json:"item"`       // Line added for the difference
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+func (e *PaymentAmountAdjusted) EventType() string     { return "PaymentAmountAdjusted" }
+func (e *PaymentAmountAdjusted) AggregateID() string   { return e.PaymentID }
+func (e *PaymentAmountAdjusted) OccurredAt() time.Time { return e.Timestamp }
+func (e *PaymentAmountAdjusted) Version() int          { return 1 }
+
+// PaymentDiscountApplied event - fired when a promotion discount is applied to a pending payment
+type PaymentDiscountApplied struct {
+	PaymentID      string    `json:"payment_id"`
+	PromotionID    string    `json:"promotion_id"`
+	PromotionCode  string    `json:"promotion_code"`
+	DiscountAmount int       `json:"discount_amount"`
+	FundedBy       string    `json:"funded_by"` // PLATFORM or VENDOR
+	Amount         int       `json:"amount"`    // Amount due after the discount
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (e *PaymentDiscountApplied) EventType() string     { return "PaymentDiscountApplied" }
+func (e *PaymentDiscountApplied) AggregateID() string   { return e.PaymentID }
+func (e *PaymentDiscountApplied) OccurredAt() time.Time { return e.Timestamp }
+func (e *PaymentDiscountApplied) Version() int          { return 1 }
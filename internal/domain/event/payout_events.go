@@ -4,17 +4,19 @@ import "time"
 
 // PayoutRequested event - fired when vendor requests a payout
 type PayoutRequested struct {
-	PayoutID      string    `json:"payout_id"`
-	VendorID      string    `json:"vendor_id"`
-	PaymentID     string    `json:"payment_id"`  // Link to the payment that triggered this
-	ScheduleID    string    `json:"schedule_id"` // Link to the schedule that was created
-	Amount        int       `json:"amount"`
-	BankName      string    `json:"bank_name"`
-	AccountNumber string    `json:"account_number"`
-	AccountName   string    `json:"account_name"`
-	BankBranch    string    `json:"bank_branch"`
-	Notes         string    `json:"notes"`
-	Timestamp     time.Time `json:"timestamp"`
+	PayoutID      string               `json:"payout_id"`
+	VendorID      string               `json:"vendor_id"`
+	PaymentID     string               `json:"payment_id"`              // Link to the payment that triggered this
+	ScheduleID    string               `json:"schedule_id"`             // Link to the schedule that was created
+	SettlementID  string               `json:"settlement_id,omitempty"` // Link to the settlement batch
+	LineItems     []SettlementLineItem `json:"line_items,omitempty"`    // Paid bookings covered by a settlement payout
+	Amount        int                  `json:"amount"`
+	BankName      string               `json:"bank_name"`
+	AccountNumber string               `json:"account_number"`
+	AccountName   string               `json:"account_name"`
+	BankBranch    string               `json:"bank_branch"`
+	Notes         string               `json:"notes"`
+	Timestamp     time.Time            `json:"timestamp"`
 }
 
 func (e *PayoutRequested) EventType() string     { return "PayoutRequested" }
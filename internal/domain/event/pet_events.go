@@ -12,16 +12,33 @@ type VaccinationRecord struct {
 	NextDueDate  time.Time `json:"next_due_date,omitempty"`
 	Veterinarian string    `json:"veterinarian,omitempty"`
 	Notes        string    `json:"notes,omitempty"`
+	Voided       bool      `json:"voided,omitempty"`
 }
 
 type MedicalRecord struct {
-	ID           string    `json:"id"`
-	Date         time.Time `json:"date"`
-	Description  string    `json:"description"`
-	Treatment    string    `json:"treatment,omitempty"`
-	Veterinarian string    `json:"veterinarian,omitempty"`
-	Diagnosis    string    `json:"diagnosis,omitempty"`
-	Notes        string    `json:"notes,omitempty"`
+	ID           string            `json:"id"`
+	Date         time.Time         `json:"date"`
+	Description  string            `json:"description"`
+	Treatment    string            `json:"treatment,omitempty"`
+	Veterinarian string            `json:"veterinarian,omitempty"`
+	Diagnosis    string            `json:"diagnosis,omitempty"`
+	Notes        string            `json:"notes,omitempty"`
+	Voided       bool              `json:"voided,omitempty"`
+	Provenance   *RecordProvenance `json:"provenance,omitempty"` // Set when the record was not entered by an owner
+}
+
+// Health record sources
+const (
+	RecordSourceVendor = "VENDOR"
+)
+
+// RecordProvenance tells where a health record entry came from, e.g. the visit report of a booked shop
+type RecordProvenance struct {
+	Source     string `json:"source"`
+	VendorID   string `json:"vendor_id,omitempty"`
+	VendorName string `json:"vendor_name,omitempty"`
+	ScheduleID string `json:"schedule_id,omitempty"`
+	RecordedBy string `json:"recorded_by,omitempty"`
 }
 
 type Allergy struct {
@@ -33,6 +50,127 @@ type Allergy struct {
 	Notes         string    `json:"notes,omitempty"`
 }
 
+// Weight measurement sources
+const (
+	WeightSourceOwner  = "OWNER"
+	WeightSourceVendor = "VENDOR"
+	WeightSourceVet    = "VET"
+)
+
+// WeightMeasurement is one entry of a pet's weight history
+type WeightMeasurement struct {
+	ID         string    `json:"id"`
+	Weight     float64   `json:"weight"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Source     string    `json:"source"`
+	RecordedBy string    `json:"recorded_by,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+}
+
+// Attachment categories
+const (
+	AttachmentCategoryLabResult    = "LAB_RESULT"
+	AttachmentCategoryCertificate  = "CERTIFICATE"
+	AttachmentCategoryXRay         = "XRAY"
+	AttachmentCategoryPrescription = "PRESCRIPTION"
+	AttachmentCategoryOther        = "OTHER"
+)
+
+// Health record types an attachment can belong to
+const (
+	AttachmentRecordVaccination   = "vaccination"
+	AttachmentRecordMedicalRecord = "medical_record"
+)
+
+// PetAttachment is a document (PDF or image) kept for a pet, optionally belonging to one of its health records
+type PetAttachment struct {
+	ID          string    `json:"id"`
+	Category    string    `json:"category"`
+	Title       string    `json:"title"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	StorageKey  string    `json:"storage_key"` // Identifies the file in the storage backend
+	RecordType  string    `json:"record_type,omitempty"`
+	RecordID    string    `json:"record_id,omitempty"`
+	UploadedBy  string    `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// Medication dose statuses
+const (
+	DoseStatusGiven  = "GIVEN"
+	DoseStatusMissed = "MISSED"
+)
+
+// MedicationPlan is a course of medication prescribed for a pet, given every IntervalHours from the start date
+type MedicationPlan struct {
+	ID              string    `json:"id"`
+	DrugName        string    `json:"drug_name"`
+	Dose            string    `json:"dose"`           // e.g. "5 mg" or "1 tablet"
+	IntervalHours   int       `json:"interval_hours"` // e.g. 12 for twice a day
+	StartDate       time.Time `json:"start_date"`     // Time of the first dose
+	EndDate         time.Time `json:"end_date,omitempty"`
+	PrescribedBy    string    `json:"prescribed_by,omitempty"` // The prescribing veterinarian
+	Instructions    string    `json:"instructions,omitempty"`
+	MedicalRecordID string    `json:"medical_record_id,omitempty"`
+	CreatedBy       string    `json:"created_by"`
+	StoppedAt       time.Time `json:"stopped_at,omitempty"`
+	Doses           []DoseLog `json:"doses,omitempty"`
+}
+
+// DoseLog records whether a scheduled dose of a medication plan was given or missed
+type DoseLog struct {
+	ID           string    `json:"id"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	Status       string    `json:"status"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	RecordedBy   string    `json:"recorded_by"`
+	Notes        string    `json:"notes,omitempty"`
+}
+
+// Pet guardian roles. Owners manage the pet and its guardians; caretakers can book care for it.
+const (
+	GuardianRoleOwner     = "OWNER"
+	GuardianRoleCaretaker = "CARETAKER"
+)
+
+// PetGuardian is a user who shares responsibility for a pet with its primary owner
+type PetGuardian struct {
+	UserID  string    `json:"user_id"`
+	Role    string    `json:"role"`
+	AddedAt time.Time `json:"added_at"`
+	AddedBy string    `json:"added_by"`
+}
+
+// GuardianInvitation is a pending invitation for a user to become a guardian of a pet
+type GuardianInvitation struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"` // The invited user
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	InvitedAt time.Time `json:"invited_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// OwnershipTransfer is a pending hand-over of a pet to a new primary owner, e.g. when it is rehomed
+type OwnershipTransfer struct {
+	ID          string    `json:"id"`
+	FromUserID  string    `json:"from_user_id"`
+	ToUserID    string    `json:"to_user_id"`
+	Note        string    `json:"note,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// FieldChange records the old and new value of one field of a corrected health record
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
 // PetCreated event
 type PetCreated struct {
 	PetID     string    `json:"pet_id"`
@@ -144,4 +282,375 @@ type PetAllergyRemoved struct {
 func (e *PetAllergyRemoved) EventType() string     { return "PetAllergyRemoved" }
 func (e *PetAllergyRemoved) AggregateID() string   { return e.PetID }
 func (e *PetAllergyRemoved) OccurredAt() time.Time { return e.Timestamp }
-func (e *PetAllergyRemoved) Version() int          { return e.EventVersion }
\ No newline at end of file
+func (e *PetAllergyRemoved) Version() int          { return e.EventVersion }
+
+// PetVaccinationUpdated event - fired when a vaccination record is corrected
+type PetVaccinationUpdated struct {
+	PetID        string            `json:"pet_id"`
+	Record       VaccinationRecord `json:"record"`
+	Changes      []FieldChange     `json:"changes"`
+	ChangedBy    string            `json:"changed_by"`
+	Reason       string            `json:"reason"`
+	EventVersion int               `json:"version"`
+	Timestamp    time.Time         `json:"timestamp"`
+}
+
+func (e *PetVaccinationUpdated) EventType() string     { return "PetVaccinationUpdated" }
+func (e *PetVaccinationUpdated) AggregateID() string   { return e.PetID }
+func (e *PetVaccinationUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetVaccinationUpdated) Version() int          { return e.EventVersion }
+
+// PetVaccinationVoided event - fired when a vaccination record is voided; it stays in the history
+type PetVaccinationVoided struct {
+	PetID        string    `json:"pet_id"`
+	RecordID     string    `json:"record_id"`
+	VoidedBy     string    `json:"voided_by"`
+	Reason       string    `json:"reason"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetVaccinationVoided) EventType() string     { return "PetVaccinationVoided" }
+func (e *PetVaccinationVoided) AggregateID() string   { return e.PetID }
+func (e *PetVaccinationVoided) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetVaccinationVoided) Version() int          { return e.EventVersion }
+
+// PetMedicalRecordUpdated event - fired when a medical record is corrected
+type PetMedicalRecordUpdated struct {
+	PetID        string        `json:"pet_id"`
+	Record       MedicalRecord `json:"record"`
+	Changes      []FieldChange `json:"changes"`
+	ChangedBy    string        `json:"changed_by"`
+	Reason       string        `json:"reason"`
+	EventVersion int           `json:"version"`
+	Timestamp    time.Time     `json:"timestamp"`
+}
+
+func (e *PetMedicalRecordUpdated) EventType() string     { return "PetMedicalRecordUpdated" }
+func (e *PetMedicalRecordUpdated) AggregateID() string   { return e.PetID }
+func (e *PetMedicalRecordUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetMedicalRecordUpdated) Version() int          { return e.EventVersion }
+
+// PetMedicalRecordVoided event - fired when a medical record is voided; it stays in the history
+type PetMedicalRecordVoided struct {
+	PetID        string    `json:"pet_id"`
+	RecordID     string    `json:"record_id"`
+	VoidedBy     string    `json:"voided_by"`
+	Reason       string    `json:"reason"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetMedicalRecordVoided) EventType() string     { return "PetMedicalRecordVoided" }
+func (e *PetMedicalRecordVoided) AggregateID() string   { return e.PetID }
+func (e *PetMedicalRecordVoided) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetMedicalRecordVoided) Version() int          { return e.EventVersion }
+
+// PetAllergyUpdated event - fired when an allergy is corrected
+type PetAllergyUpdated struct {
+	PetID        string        `json:"pet_id"`
+	Allergy      Allergy       `json:"allergy"`
+	Changes      []FieldChange `json:"changes"`
+	ChangedBy    string        `json:"changed_by"`
+	Reason       string        `json:"reason"`
+	EventVersion int           `json:"version"`
+	Timestamp    time.Time     `json:"timestamp"`
+}
+
+func (e *PetAllergyUpdated) EventType() string     { return "PetAllergyUpdated" }
+func (e *PetAllergyUpdated) AggregateID() string   { return e.PetID }
+func (e *PetAllergyUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetAllergyUpdated) Version() int          { return e.EventVersion }
+
+// PetBirthDateSet event - fired when a pet's birth date is set or corrected
+type PetBirthDateSet struct {
+	PetID        string    `json:"pet_id"`
+	BirthDate    time.Time `json:"birth_date"`
+	Estimated    bool      `json:"estimated"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetBirthDateSet) EventType() string     { return "PetBirthDateSet" }
+func (e *PetBirthDateSet) AggregateID() string   { return e.PetID }
+func (e *PetBirthDateSet) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetBirthDateSet) Version() int          { return e.EventVersion }
+
+// PetWeightRecorded event - fired when a weight measurement is added to a pet's weight history
+type PetWeightRecorded struct {
+	PetID         string            `json:"pet_id"`
+	Measurement   WeightMeasurement `json:"measurement"`
+	CurrentWeight float64           `json:"current_weight"` // Weight of the most recent measurement after this one
+	EventVersion  int               `json:"version"`
+	Timestamp     time.Time         `json:"timestamp"`
+}
+
+func (e *PetWeightRecorded) EventType() string     { return "PetWeightRecorded" }
+func (e *PetWeightRecorded) AggregateID() string   { return e.PetID }
+func (e *PetWeightRecorded) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetWeightRecorded) Version() int          { return e.EventVersion }
+
+// VaccinationDueSoon event - fired by the reminder service when a vaccination falls due within a reminder offset
+type VaccinationDueSoon struct {
+	PetID          string    `json:"pet_id"`
+	UserID         string    `json:"user_id"`
+	PetName        string    `json:"pet_name"`
+	RecordID       string    `json:"record_id"`
+	VaccineName    string    `json:"vaccine_name"`
+	DueDate        time.Time `json:"due_date"`
+	DaysUntilDue   int       `json:"days_until_due"`
+	ReminderOffset int       `json:"reminder_offset"` // The configured offset (in days) this reminder was sent for
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (e *VaccinationDueSoon) EventType() string     { return "VaccinationDueSoon" }
+func (e *VaccinationDueSoon) AggregateID() string   { return e.PetID }
+func (e *VaccinationDueSoon) OccurredAt() time.Time { return e.Timestamp }
+func (e *VaccinationDueSoon) Version() int          { return 1 }
+
+// VaccinationOverdue event - fired by the reminder service once a vaccination is past its due date
+type VaccinationOverdue struct {
+	PetID       string    `json:"pet_id"`
+	UserID      string    `json:"user_id"`
+	PetName     string    `json:"pet_name"`
+	RecordID    string    `json:"record_id"`
+	VaccineName string    `json:"vaccine_name"`
+	DueDate     time.Time `json:"due_date"`
+	DaysOverdue int       `json:"days_overdue"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (e *VaccinationOverdue) EventType() string     { return "VaccinationOverdue" }
+func (e *VaccinationOverdue) AggregateID() string   { return e.PetID }
+func (e *VaccinationOverdue) OccurredAt() time.Time { return e.Timestamp }
+func (e *VaccinationOverdue) Version() int          { return 1 }
+
+// PetGuardianInvited event
+type PetGuardianInvited struct {
+	PetID        string             `json:"pet_id"`
+	Invitation   GuardianInvitation `json:"invitation"`
+	EventVersion int                `json:"version"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+func (e *PetGuardianInvited) EventType() string     { return "PetGuardianInvited" }
+func (e *PetGuardianInvited) AggregateID() string   { return e.PetID }
+func (e *PetGuardianInvited) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetGuardianInvited) Version() int          { return e.EventVersion }
+
+// PetGuardianInvitationAccepted event - the invited user became a guardian
+type PetGuardianInvitationAccepted struct {
+	PetID        string      `json:"pet_id"`
+	InvitationID string      `json:"invitation_id"`
+	Guardian     PetGuardian `json:"guardian"`
+	EventVersion int         `json:"version"`
+	Timestamp    time.Time   `json:"timestamp"`
+}
+
+func (e *PetGuardianInvitationAccepted) EventType() string     { return "PetGuardianInvitationAccepted" }
+func (e *PetGuardianInvitationAccepted) AggregateID() string   { return e.PetID }
+func (e *PetGuardianInvitationAccepted) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetGuardianInvitationAccepted) Version() int          { return e.EventVersion }
+
+// PetGuardianInvitationDeclined event - the invited user turned the invitation down
+type PetGuardianInvitationDeclined struct {
+	PetID        string    `json:"pet_id"`
+	InvitationID string    `json:"invitation_id"`
+	UserID       string    `json:"user_id"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetGuardianInvitationDeclined) EventType() string     { return "PetGuardianInvitationDeclined" }
+func (e *PetGuardianInvitationDeclined) AggregateID() string   { return e.PetID }
+func (e *PetGuardianInvitationDeclined) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetGuardianInvitationDeclined) Version() int          { return e.EventVersion }
+
+// PetGuardianInvitationCancelled event - an owner withdrew the invitation
+type PetGuardianInvitationCancelled struct {
+	PetID        string    `json:"pet_id"`
+	InvitationID string    `json:"invitation_id"`
+	CancelledBy  string    `json:"cancelled_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetGuardianInvitationCancelled) EventType() string     { return "PetGuardianInvitationCancelled" }
+func (e *PetGuardianInvitationCancelled) AggregateID() string   { return e.PetID }
+func (e *PetGuardianInvitationCancelled) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetGuardianInvitationCancelled) Version() int          { return e.EventVersion }
+
+// PetGuardianRemoved event - a guardian was removed by an owner or stepped down
+type PetGuardianRemoved struct {
+	PetID        string    `json:"pet_id"`
+	UserID       string    `json:"user_id"`
+	RemovedBy    string    `json:"removed_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetGuardianRemoved) EventType() string     { return "PetGuardianRemoved" }
+func (e *PetGuardianRemoved) AggregateID() string   { return e.PetID }
+func (e *PetGuardianRemoved) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetGuardianRemoved) Version() int          { return e.EventVersion }
+
+// PetOwnershipTransferRequested event
+type PetOwnershipTransferRequested struct {
+	PetID        string            `json:"pet_id"`
+	Transfer     OwnershipTransfer `json:"transfer"`
+	EventVersion int               `json:"version"`
+	Timestamp    time.Time         `json:"timestamp"`
+}
+
+func (e *PetOwnershipTransferRequested) EventType() string     { return "PetOwnershipTransferRequested" }
+func (e *PetOwnershipTransferRequested) AggregateID() string   { return e.PetID }
+func (e *PetOwnershipTransferRequested) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetOwnershipTransferRequested) Version() int          { return e.EventVersion }
+
+// PetOwnershipTransferred event - the new owner accepted the transfer. The previous household's
+// guardians and pending invitations are removed.
+type PetOwnershipTransferred struct {
+	PetID            string    `json:"pet_id"`
+	TransferID       string    `json:"transfer_id"`
+	FromUserID       string    `json:"from_user_id"`
+	ToUserID         string    `json:"to_user_id"`
+	RemovedGuardians []string  `json:"removed_guardians,omitempty"`
+	EventVersion     int       `json:"version"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (e *PetOwnershipTransferred) EventType() string     { return "PetOwnershipTransferred" }
+func (e *PetOwnershipTransferred) AggregateID() string   { return e.PetID }
+func (e *PetOwnershipTransferred) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetOwnershipTransferred) Version() int          { return e.EventVersion }
+
+// PetOwnershipTransferDeclined event - the proposed new owner turned the transfer down
+type PetOwnershipTransferDeclined struct {
+	PetID        string    `json:"pet_id"`
+	TransferID   string    `json:"transfer_id"`
+	UserID       string    `json:"user_id"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetOwnershipTransferDeclined) EventType() string     { return "PetOwnershipTransferDeclined" }
+func (e *PetOwnershipTransferDeclined) AggregateID() string   { return e.PetID }
+func (e *PetOwnershipTransferDeclined) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetOwnershipTransferDeclined) Version() int          { return e.EventVersion }
+
+// PetOwnershipTransferCancelled event - the owner withdrew the transfer
+type PetOwnershipTransferCancelled struct {
+	PetID        string    `json:"pet_id"`
+	TransferID   string    `json:"transfer_id"`
+	CancelledBy  string    `json:"cancelled_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetOwnershipTransferCancelled) EventType() string     { return "PetOwnershipTransferCancelled" }
+func (e *PetOwnershipTransferCancelled) AggregateID() string   { return e.PetID }
+func (e *PetOwnershipTransferCancelled) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetOwnershipTransferCancelled) Version() int          { return e.EventVersion }
+
+// PetMedicationPlanAdded event
+type PetMedicationPlanAdded struct {
+	PetID        string         `json:"pet_id"`
+	Plan         MedicationPlan `json:"plan"`
+	EventVersion int            `json:"version"`
+	Timestamp    time.Time      `json:"timestamp"`
+}
+
+func (e *PetMedicationPlanAdded) EventType() string     { return "PetMedicationPlanAdded" }
+func (e *PetMedicationPlanAdded) AggregateID() string   { return e.PetID }
+func (e *PetMedicationPlanAdded) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetMedicationPlanAdded) Version() int          { return e.EventVersion }
+
+// PetMedicationPlanStopped event - the plan was ended before its end date
+type PetMedicationPlanStopped struct {
+	PetID        string    `json:"pet_id"`
+	PlanID       string    `json:"plan_id"`
+	StoppedBy    string    `json:"stopped_by"`
+	Reason       string    `json:"reason,omitempty"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetMedicationPlanStopped) EventType() string     { return "PetMedicationPlanStopped" }
+func (e *PetMedicationPlanStopped) AggregateID() string   { return e.PetID }
+func (e *PetMedicationPlanStopped) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetMedicationPlanStopped) Version() int          { return e.EventVersion }
+
+// PetMedicationDoseLogged event - a scheduled dose was given or missed
+type PetMedicationDoseLogged struct {
+	PetID        string    `json:"pet_id"`
+	PlanID       string    `json:"plan_id"`
+	Dose         DoseLog   `json:"dose"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetMedicationDoseLogged) EventType() string     { return "PetMedicationDoseLogged" }
+func (e *PetMedicationDoseLogged) AggregateID() string   { return e.PetID }
+func (e *PetMedicationDoseLogged) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetMedicationDoseLogged) Version() int          { return e.EventVersion }
+
+// MedicationDoseDue event - fired by the medication reminder service shortly before a dose is due
+type MedicationDoseDue struct {
+	PetID     string    `json:"pet_id"`
+	UserID    string    `json:"user_id"`
+	PetName   string    `json:"pet_name"`
+	PlanID    string    `json:"plan_id"`
+	DrugName  string    `json:"drug_name"`
+	Dose      string    `json:"dose"`
+	DueAt     time.Time `json:"due_at"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e *MedicationDoseDue) EventType() string     { return "MedicationDoseDue" }
+func (e *MedicationDoseDue) AggregateID() string   { return e.PetID }
+func (e *MedicationDoseDue) OccurredAt() time.Time { return e.Timestamp }
+func (e *MedicationDoseDue) Version() int          { return 1 }
+
+// PetAttachmentAdded event
+type PetAttachmentAdded struct {
+	PetID        string        `json:"pet_id"`
+	Attachment   PetAttachment `json:"attachment"`
+	EventVersion int           `json:"version"`
+	Timestamp    time.Time     `json:"timestamp"`
+}
+
+func (e *PetAttachmentAdded) EventType() string     { return "PetAttachmentAdded" }
+func (e *PetAttachmentAdded) AggregateID() string   { return e.PetID }
+func (e *PetAttachmentAdded) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetAttachmentAdded) Version() int          { return e.EventVersion }
+
+// PetAttachmentRemoved event - carries the storage key so the stored file can be deleted
+type PetAttachmentRemoved struct {
+	PetID        string    `json:"pet_id"`
+	AttachmentID string    `json:"attachment_id"`
+	StorageKey   string    `json:"storage_key"`
+	RemovedBy    string    `json:"removed_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PetAttachmentRemoved) EventType() string     { return "PetAttachmentRemoved" }
+func (e *PetAttachmentRemoved) AggregateID() string   { return e.PetID }
+func (e *PetAttachmentRemoved) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetAttachmentRemoved) Version() int          { return e.EventVersion }
+
+// PetCareNotesUpdated event - fired when an owner changes the temperament and special instructions
+// shared with vendors in the pet's care brief
+type PetCareNotesUpdated struct {
+	PetID               string    `json:"pet_id"`
+	Temperament         string    `json:"temperament"`
+	SpecialInstructions string    `json:"special_instructions"`
+	UpdatedBy           string    `json:"updated_by"`
+	EventVersion        int       `json:"version"`
+	Timestamp           time.Time `json:"timestamp"`
+}
+
+func (e *PetCareNotesUpdated) EventType() string     { return "PetCareNotesUpdated" }
+func (e *PetCareNotesUpdated) AggregateID() string   { return e.PetID }
+func (e *PetCareNotesUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *PetCareNotesUpdated) Version() int          { return e.EventVersion }
@@ -0,0 +1,82 @@
+package event
+
+import "time"
+
+// PromotionRedemption records one use of a promotion by a payment
+type PromotionRedemption struct {
+	PaymentID      string    `json:"payment_id" bson:"payment_id"`
+	UserID         string    `json:"user_id" bson:"user_id"`
+	VendorID       string    `json:"vendor_id" bson:"vendor_id"`
+	DiscountAmount int       `json:"discount_amount" bson:"discount_amount"`
+	RedeemedAt     time.Time `json:"redeemed_at" bson:"redeemed_at"`
+	Released       bool      `json:"released" bson:"released"` // Released when the payment is cancelled, expires or fails
+	ReleasedAt     time.Time `json:"released_at,omitempty" bson:"released_at,omitempty"`
+}
+
+// PromotionCreated event - fired when a new promotion is created
+type PromotionCreated struct {
+	PromotionID      string    `json:"promotion_id"`
+	Code             string    `json:"code"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	DiscountType     string    `json:"discount_type"`
+	DiscountValue    int       `json:"discount_value"`
+	MaxDiscount      int       `json:"max_discount"`
+	MinOrderAmount   int       `json:"min_order_amount"`
+	FundedBy         string    `json:"funded_by"`
+	VendorID         string    `json:"vendor_id"`
+	ServiceIDs       []string  `json:"service_ids"`
+	FirstBookingOnly bool      `json:"first_booking_only"`
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	UsageLimit       int       `json:"usage_limit"`
+	PerUserLimit     int       `json:"per_user_limit"`
+	CreatedBy        string    `json:"created_by"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (e *PromotionCreated) EventType() string     { return "PromotionCreated" }
+func (e *PromotionCreated) AggregateID() string   { return e.PromotionID }
+func (e *PromotionCreated) OccurredAt() time.Time { return e.Timestamp }
+func (e *PromotionCreated) Version() int          { return 1 }
+
+// PromotionRedeemed event - fired when a payment uses a promotion
+type PromotionRedeemed struct {
+	PromotionID  string              `json:"promotion_id"`
+	Code         string              `json:"code"`
+	Redemption   PromotionRedemption `json:"redemption"`
+	EventVersion int                 `json:"version"`
+	Timestamp    time.Time           `json:"timestamp"`
+}
+
+func (e *PromotionRedeemed) EventType() string     { return "PromotionRedeemed" }
+func (e *PromotionRedeemed) AggregateID() string   { return e.PromotionID }
+func (e *PromotionRedeemed) OccurredAt() time.Time { return e.Timestamp }
+func (e *PromotionRedeemed) Version() int          { return e.EventVersion }
+
+// PromotionRedemptionReleased event - fired when a redemption is given back because its payment did not complete
+type PromotionRedemptionReleased struct {
+	PromotionID  string    `json:"promotion_id"`
+	PaymentID    string    `json:"payment_id"`
+	Reason       string    `json:"reason"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PromotionRedemptionReleased) EventType() string     { return "PromotionRedemptionReleased" }
+func (e *PromotionRedemptionReleased) AggregateID() string   { return e.PromotionID }
+func (e *PromotionRedemptionReleased) OccurredAt() time.Time { return e.Timestamp }
+func (e *PromotionRedemptionReleased) Version() int          { return e.EventVersion }
+
+// PromotionDeactivated event - fired when a promotion is switched off before it ends
+type PromotionDeactivated struct {
+	PromotionID  string    `json:"promotion_id"`
+	Reason       string    `json:"reason"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *PromotionDeactivated) EventType() string     { return "PromotionDeactivated" }
+func (e *PromotionDeactivated) AggregateID() string   { return e.PromotionID }
+func (e *PromotionDeactivated) OccurredAt() time.Time { return e.Timestamp }
+func (e *PromotionDeactivated) Version() int          { return e.EventVersion }
@@ -35,14 +35,18 @@ type PetAssignedData struct {
 
 // ScheduleCreated event
 type ScheduleCreated struct {
-	ScheduleID   string           `json:"schedule_id"`
-	BookingUser  BookingUserData  `json:"booking_user"`
-	BookedVendor BookedVendorData `json:"booked_vendor"`
-	AssignedPet  PetAssignedData  `json:"assigned_pet"`
-	StartTime    time.Time        `json:"start_time"`
-	EndTime      time.Time        `json:"end_time"`
-	Status       string           `json:"status"`
-	Timestamp    time.Time        `json:"timestamp"`
+	ScheduleID   string            `json:"schedule_id"`
+	BookingUser  BookingUserData   `json:"booking_user"`
+	BookedVendor BookedVendorData  `json:"booked_vendor"`
+	AssignedPet  PetAssignedData   `json:"assigned_pet"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      time.Time         `json:"end_time"`
+	Status       string            `json:"status"`
+	PaymentID    string            `json:"payment_id,omitempty"`  // Payment that created the booking
+	TotalPrice   int               `json:"total_price,omitempty"` // Price the customer agreed to
+	SeriesID     string            `json:"series_id,omitempty"`   // Recurring booking the schedule is an occurrence of
+	PetLines     []SchedulePetLine `json:"pet_lines,omitempty"`   // Set on bookings covering several pets
+	Timestamp    time.Time         `json:"timestamp"`
 }
 
 func (e *ScheduleCreated) EventType() string     { return "ScheduleCreated" }
@@ -55,6 +59,7 @@ type ScheduleStatusChanged struct {
 	ScheduleID   string    `json:"schedule_id"`
 	OldStatus    string    `json:"old_status"`
 	NewStatus    string    `json:"new_status"`
+	Actor        string    `json:"actor,omitempty"` // customer, vendor_staff, system or admin
 	EventVersion int       `json:"version"`
 	Timestamp    time.Time `json:"timestamp"`
 }
@@ -68,6 +73,7 @@ func (e *ScheduleStatusChanged) Version() int          { return e.EventVersion }
 type ScheduleCancelled struct {
 	ScheduleID   string    `json:"schedule_id"`
 	Reason       string    `json:"reason"`
+	Actor        string    `json:"actor,omitempty"`
 	EventVersion int       `json:"version"`
 	Timestamp    time.Time `json:"timestamp"`
 }
@@ -80,6 +86,7 @@ func (e *ScheduleCancelled) Version() int          { return e.EventVersion }
 // ScheduleCompleted event
 type ScheduleCompleted struct {
 	ScheduleID   string    `json:"schedule_id"`
+	Actor        string    `json:"actor,omitempty"`
 	EventVersion int       `json:"version"`
 	Timestamp    time.Time `json:"timestamp"`
 }
@@ -88,3 +95,311 @@ func (e *ScheduleCompleted) EventType() string     { return "ScheduleCompleted"
 func (e *ScheduleCompleted) AggregateID() string   { return e.ScheduleID }
 func (e *ScheduleCompleted) OccurredAt() time.Time { return e.Timestamp }
 func (e *ScheduleCompleted) Version() int          { return e.EventVersion }
+
+// ScheduleCareBriefShared event - fired when an owner lets the booked shop read the pet's care brief
+type ScheduleCareBriefShared struct {
+	ScheduleID   string    `json:"schedule_id"`
+	PetID        string    `json:"pet_id"`
+	ShopID       string    `json:"shop_id"`
+	SharedBy     string    `json:"shared_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleCareBriefShared) EventType() string     { return "ScheduleCareBriefShared" }
+func (e *ScheduleCareBriefShared) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleCareBriefShared) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleCareBriefShared) Version() int          { return e.EventVersion }
+
+// ScheduleCareBriefRevoked event - fired when an owner withdraws consent to share the care brief
+type ScheduleCareBriefRevoked struct {
+	ScheduleID   string    `json:"schedule_id"`
+	PetID        string    `json:"pet_id"`
+	RevokedBy    string    `json:"revoked_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleCareBriefRevoked) EventType() string     { return "ScheduleCareBriefRevoked" }
+func (e *ScheduleCareBriefRevoked) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleCareBriefRevoked) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleCareBriefRevoked) Version() int          { return e.EventVersion }
+
+// VisitReportService is a booked service the staff performed during a visit
+type VisitReportService struct {
+	ServiceID string `json:"service_id" bson:"service_id"`
+	Name      string `json:"name" bson:"name"`
+	Notes     string `json:"notes,omitempty" bson:"notes,omitempty"`
+}
+
+// VisitReport is what vendor staff recorded about a visit when completing a booking
+type VisitReport struct {
+	ID                string               `json:"id" bson:"id"`
+	ServicesPerformed []VisitReportService `json:"services_performed" bson:"services_performed"`
+	Observations      string               `json:"observations" bson:"observations"`
+	Weight            float64              `json:"weight,omitempty" bson:"weight,omitempty"` // Kilograms, 0 when the pet was not weighed
+	PhotoURLs         []string             `json:"photo_urls,omitempty" bson:"photo_urls,omitempty"`
+	Recommendations   string               `json:"recommendations,omitempty" bson:"recommendations,omitempty"`
+	SubmittedBy       string               `json:"submitted_by" bson:"submitted_by"`
+	SubmittedAt       time.Time            `json:"submitted_at" bson:"submitted_at"`
+	AcceptedBy        string               `json:"accepted_by,omitempty" bson:"accepted_by,omitempty"`
+	AcceptedAt        time.Time            `json:"accepted_at,omitempty" bson:"accepted_at,omitempty"`
+	MedicalRecordID   string               `json:"medical_record_id,omitempty" bson:"medical_record_id,omitempty"` // Added to the pet's medical history on acceptance
+	MedicalRecords    []VisitReportRecord  `json:"medical_records,omitempty" bson:"medical_records,omitempty"`     // One per booked pet accepted so far
+}
+
+// VisitReportRecord is the medical record a visit report was accepted into for one pet of the booking
+type VisitReportRecord struct {
+	PetID           string    `json:"pet_id" bson:"pet_id"`
+	MedicalRecordID string    `json:"medical_record_id" bson:"medical_record_id"`
+	AcceptedBy      string    `json:"accepted_by" bson:"accepted_by"`
+	AcceptedAt      time.Time `json:"accepted_at" bson:"accepted_at"`
+}
+
+// ScheduleVisitReportSubmitted event - fired when vendor staff submit the report of a visit
+type ScheduleVisitReportSubmitted struct {
+	ScheduleID   string      `json:"schedule_id"`
+	PetID        string      `json:"pet_id"`
+	ShopID       string      `json:"shop_id"`
+	Report       VisitReport `json:"report"`
+	EventVersion int         `json:"version"`
+	Timestamp    time.Time   `json:"timestamp"`
+}
+
+func (e *ScheduleVisitReportSubmitted) EventType() string     { return "ScheduleVisitReportSubmitted" }
+func (e *ScheduleVisitReportSubmitted) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleVisitReportSubmitted) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleVisitReportSubmitted) Version() int          { return e.EventVersion }
+
+// ScheduleVisitReportAccepted event - fired when an owner accepts a visit report into the pet's medical history
+type ScheduleVisitReportAccepted struct {
+	ScheduleID      string    `json:"schedule_id"`
+	PetID           string    `json:"pet_id"`
+	AcceptedBy      string    `json:"accepted_by"`
+	MedicalRecordID string    `json:"medical_record_id"`
+	EventVersion    int       `json:"version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleVisitReportAccepted) EventType() string     { return "ScheduleVisitReportAccepted" }
+func (e *ScheduleVisitReportAccepted) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleVisitReportAccepted) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleVisitReportAccepted) Version() int          { return e.EventVersion }
+
+// RescheduleProposal is a new time for a booking proposed by the vendor, waiting for the customer
+type RescheduleProposal struct {
+	ID         string    `json:"id" bson:"id"`
+	StartTime  time.Time `json:"start_time" bson:"start_time"`
+	EndTime    time.Time `json:"end_time" bson:"end_time"`
+	Reason     string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	ProposedBy string    `json:"proposed_by" bson:"proposed_by"`
+	ProposedAt time.Time `json:"proposed_at" bson:"proposed_at"`
+}
+
+// Kinds of schedule price adjustments
+const (
+	PriceAdjustmentTopUp  = "TOP_UP"  // The customer pays the difference with a new payment
+	PriceAdjustmentRefund = "REFUND"  // Part of the original payment is refunded
+	PriceAdjustmentAtShop = "AT_SHOP" // The booking is not paid yet; the difference is settled at the shop
+)
+
+// SchedulePriceAdjustment records how a change in the price of a booking was settled
+type SchedulePriceAdjustment struct {
+	ID        string    `json:"id" bson:"id"`
+	Kind      string    `json:"kind" bson:"kind"`
+	OldPrice  int       `json:"old_price" bson:"old_price"`
+	NewPrice  int       `json:"new_price" bson:"new_price"`
+	Amount    int       `json:"amount" bson:"amount"`                             // Positive amount paid or refunded
+	PaymentID string    `json:"payment_id,omitempty" bson:"payment_id,omitempty"` // Top-up payment, or the refunded payment
+	RefundID  string    `json:"refund_id,omitempty" bson:"refund_id,omitempty"`
+	Reason    string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// ScheduleRescheduled event - fired when a booking is moved to a new time
+type ScheduleRescheduled struct {
+	ScheduleID      string    `json:"schedule_id"`
+	OldStartTime    time.Time `json:"old_start_time"`
+	OldEndTime      time.Time `json:"old_end_time"`
+	NewStartTime    time.Time `json:"new_start_time"`
+	NewEndTime      time.Time `json:"new_end_time"`
+	OldPrice        int       `json:"old_price"`
+	NewPrice        int       `json:"new_price"`
+	RescheduledBy   string    `json:"rescheduled_by"`
+	Actor           string    `json:"actor"`
+	Reason          string    `json:"reason,omitempty"`
+	ProposalID      string    `json:"proposal_id,omitempty"` // Set when the customer accepted a vendor proposal
+	RescheduleCount int       `json:"reschedule_count"`
+	EventVersion    int       `json:"version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleRescheduled) EventType() string     { return "ScheduleRescheduled" }
+func (e *ScheduleRescheduled) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleRescheduled) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleRescheduled) Version() int          { return e.EventVersion }
+
+// ScheduleRescheduleProposed event - fired when vendor staff propose a new time for a booking
+type ScheduleRescheduleProposed struct {
+	ScheduleID   string             `json:"schedule_id"`
+	ShopID       string             `json:"shop_id"`
+	Proposal     RescheduleProposal `json:"proposal"`
+	EventVersion int                `json:"version"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+func (e *ScheduleRescheduleProposed) EventType() string     { return "ScheduleRescheduleProposed" }
+func (e *ScheduleRescheduleProposed) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleRescheduleProposed) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleRescheduleProposed) Version() int          { return e.EventVersion }
+
+// ScheduleRescheduleDeclined event - fired when the customer declines a vendor's reschedule proposal
+type ScheduleRescheduleDeclined struct {
+	ScheduleID   string    `json:"schedule_id"`
+	ProposalID   string    `json:"proposal_id"`
+	DeclinedBy   string    `json:"declined_by"`
+	Reason       string    `json:"reason,omitempty"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleRescheduleDeclined) EventType() string     { return "ScheduleRescheduleDeclined" }
+func (e *ScheduleRescheduleDeclined) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleRescheduleDeclined) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleRescheduleDeclined) Version() int          { return e.EventVersion }
+
+// ScheduleRescheduleReverted event - fired when a booking moves back to its earlier time because the
+// top-up for its reschedule was never paid
+type ScheduleRescheduleReverted struct {
+	ScheduleID      string    `json:"schedule_id"`
+	TopUpPaymentID  string    `json:"top_up_payment_id"`
+	OldStartTime    time.Time `json:"old_start_time"`
+	OldEndTime      time.Time `json:"old_end_time"`
+	NewStartTime    time.Time `json:"new_start_time"`
+	NewEndTime      time.Time `json:"new_end_time"`
+	NewPrice        int       `json:"new_price"`
+	Reason          string    `json:"reason,omitempty"`
+	RescheduleCount int       `json:"reschedule_count"`
+	EventVersion    int       `json:"version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleRescheduleReverted) EventType() string     { return "ScheduleRescheduleReverted" }
+func (e *ScheduleRescheduleReverted) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleRescheduleReverted) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleRescheduleReverted) Version() int          { return e.EventVersion }
+
+// SchedulePriceAdjusted event - fired when a change in the price of a booking is paid or refunded
+type SchedulePriceAdjusted struct {
+	ScheduleID   string                  `json:"schedule_id"`
+	Adjustment   SchedulePriceAdjustment `json:"adjustment"`
+	EventVersion int                     `json:"version"`
+	Timestamp    time.Time               `json:"timestamp"`
+}
+
+func (e *SchedulePriceAdjusted) EventType() string     { return "SchedulePriceAdjusted" }
+func (e *SchedulePriceAdjusted) AggregateID() string   { return e.ScheduleID }
+func (e *SchedulePriceAdjusted) OccurredAt() time.Time { return e.Timestamp }
+func (e *SchedulePriceAdjusted) Version() int          { return e.EventVersion }
+
+// ScheduleStaffAssigned event - fired when a booking is assigned or reassigned to a staff member of the shop
+type ScheduleStaffAssigned struct {
+	ScheduleID      string    `json:"schedule_id"`
+	ShopID          string    `json:"shop_id"`
+	StaffID         string    `json:"staff_id"`
+	PreviousStaffID string    `json:"previous_staff_id,omitempty"`
+	AssignedBy      string    `json:"assigned_by"`
+	AutoAssigned    bool      `json:"auto_assigned"`
+	EventVersion    int       `json:"version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleStaffAssigned) EventType() string     { return "ScheduleStaffAssigned" }
+func (e *ScheduleStaffAssigned) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleStaffAssigned) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleStaffAssigned) Version() int          { return e.EventVersion }
+
+// Statuses of a pet line of a booking
+const (
+	SchedulePetLineBooked    = "BOOKED"
+	SchedulePetLineCancelled = "CANCELLED"
+)
+
+// SchedulePetLine is one pet of a booking with the services booked for it, how long they take and their
+// list price
+type SchedulePetLine struct {
+	PetID           string                `json:"pet_id" bson:"pet_id"`
+	PetName         string                `json:"pet_name" bson:"pet_name"`
+	Species         string                `json:"species" bson:"species"`
+	Breed           string                `json:"breed" bson:"breed"`
+	Age             int                   `json:"age" bson:"age"`
+	Weight          float64               `json:"weight" bson:"weight"`
+	Services        []ScheduleLineService `json:"services" bson:"services"`
+	DurationMinutes int                   `json:"duration_minutes" bson:"duration_minutes"`
+	Price           int                   `json:"price" bson:"price"`
+	Status          string                `json:"status" bson:"status"` // BOOKED or CANCELLED
+	CancelledAt     time.Time             `json:"cancelled_at,omitempty" bson:"cancelled_at,omitempty"`
+}
+
+// ScheduleLineService is a service booked for one pet of a booking
+type ScheduleLineService struct {
+	ServiceID string `json:"service_id" bson:"service_id"`
+	Name      string `json:"name" bson:"name"`
+}
+
+// SchedulePetCancelled event - fired when one pet of a booking covering several pets is cancelled. The
+// booking's price drops by the pet's share; the primary pet and booked services are those of the pets
+// still booked.
+type SchedulePetCancelled struct {
+	ScheduleID     string               `json:"schedule_id"`
+	PetID          string               `json:"pet_id"`
+	Reason         string               `json:"reason,omitempty"`
+	Actor          string               `json:"actor"`
+	OldPrice       int                  `json:"old_price"`
+	NewPrice       int                  `json:"new_price"`
+	PrimaryPet     PetAssignedData      `json:"primary_pet"`
+	BookedServices []BookedServicesData `json:"booked_services"`
+	EventVersion   int                  `json:"version"`
+	Timestamp      time.Time            `json:"timestamp"`
+}
+
+func (e *SchedulePetCancelled) EventType() string     { return "SchedulePetCancelled" }
+func (e *SchedulePetCancelled) AggregateID() string   { return e.ScheduleID }
+func (e *SchedulePetCancelled) OccurredAt() time.Time { return e.Timestamp }
+func (e *SchedulePetCancelled) Version() int          { return e.EventVersion }
+
+// ScheduleReminderDue event - fired by the lifecycle job when a booking comes within one of the reminder
+// lead times (e.g. 24 and 2 hours before it starts)
+type ScheduleReminderDue struct {
+	ScheduleID string    `json:"schedule_id"`
+	UserID     string    `json:"user_id"`
+	ShopID     string    `json:"shop_id"`
+	ShopName   string    `json:"shop_name"`
+	PetName    string    `json:"pet_name"`
+	StartTime  time.Time `json:"start_time"`
+	LeadTime   string    `json:"lead_time"` // The configured lead time this reminder was sent for, e.g. "24h0m0s"
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleReminderDue) EventType() string     { return "ScheduleReminderDue" }
+func (e *ScheduleReminderDue) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleReminderDue) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleReminderDue) Version() int          { return 1 }
+
+// ScheduleConfirmationOverdue event - fired by the lifecycle job once to ask the shop to confirm a booking
+// that has been pending for too long. The booking is cancelled if it is still pending shortly before it
+// starts.
+type ScheduleConfirmationOverdue struct {
+	ScheduleID   string    `json:"schedule_id"`
+	ShopID       string    `json:"shop_id"`
+	UserID       string    `json:"user_id"`
+	StartTime    time.Time `json:"start_time"`
+	PendingSince time.Time `json:"pending_since"`
+	CancelAt     time.Time `json:"cancel_at"` // When the booking is cancelled if the shop has not confirmed it
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *ScheduleConfirmationOverdue) EventType() string     { return "ScheduleConfirmationOverdue" }
+func (e *ScheduleConfirmationOverdue) AggregateID() string   { return e.ScheduleID }
+func (e *ScheduleConfirmationOverdue) OccurredAt() time.Time { return e.Timestamp }
+func (e *ScheduleConfirmationOverdue) Version() int          { return 1 }
@@ -61,3 +61,20 @@ func (e *ServiceImageUpdated) EventType() string     { return "ServiceImageUpdat
 func (e *ServiceImageUpdated) AggregateID() string   { return e.ServiceID }
 func (e *ServiceImageUpdated) OccurredAt() time.Time { return e.Timestamp }
 func (e *ServiceImageUpdated) Version() int          { return e.EventVersion }
+
+// ServiceEligibilityUpdated event - fired when a service changes which pets it accepts
+type ServiceEligibilityUpdated struct {
+	ServiceID    string    `json:"service_id"`
+	Species      []string  `json:"species"`    // Catalogue species codes, empty = all species
+	Sizes        []string  `json:"sizes"`      // Pet sizes, empty = all sizes
+	MinWeight    float64   `json:"min_weight"` // Kilograms, 0 = no minimum
+	MaxWeight    float64   `json:"max_weight"` // Kilograms, 0 = no maximum
+	UpdatedBy    string    `json:"updated_by"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *ServiceEligibilityUpdated) EventType() string     { return "ServiceEligibilityUpdated" }
+func (e *ServiceEligibilityUpdated) AggregateID() string   { return e.ServiceID }
+func (e *ServiceEligibilityUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *ServiceEligibilityUpdated) Version() int          { return e.EventVersion }
@@ -0,0 +1,119 @@
+package event
+
+import "time"
+
+// SettlementLineItem references a paid booking that contributes to a settlement
+type SettlementLineItem struct {
+	PaymentID  string    `json:"payment_id" bson:"payment_id"`
+	ScheduleID string    `json:"schedule_id" bson:"schedule_id"`
+	RefundID   string    `json:"refund_id,omitempty" bson:"refund_id,omitempty"` // Set on refund adjustments, which carry a negative amount
+	Amount     int       `json:"amount" bson:"amount"`
+	EarnedAt   time.Time `json:"earned_at" bson:"earned_at"`
+}
+
+// SettlementOpened event - fired when a new settlement period is opened for a vendor
+type SettlementOpened struct {
+	SettlementID string    `json:"settlement_id"`
+	VendorID     string    `json:"vendor_id"`
+	Period       string    `json:"period"`
+	PeriodStart  time.Time `json:"period_start"`
+	PeriodEnd    time.Time `json:"period_end"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SettlementOpened) EventType() string     { return "SettlementOpened" }
+func (e *SettlementOpened) AggregateID() string   { return e.SettlementID }
+func (e *SettlementOpened) OccurredAt() time.Time { return e.Timestamp }
+func (e *SettlementOpened) Version() int          { return 1 }
+
+// SettlementLineItemAdded event - fired when a paid booking is added to a settlement
+type SettlementLineItemAdded struct {
+	SettlementID string             `json:"settlement_id"`
+	VendorID     string             `json:"vendor_id"`
+	LineItem     SettlementLineItem `json:"line_item"`
+	EventVersion int                `json:"version"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+func (e *SettlementLineItemAdded) EventType() string     { return "SettlementLineItemAdded" }
+func (e *SettlementLineItemAdded) AggregateID() string   { return e.SettlementID }
+func (e *SettlementLineItemAdded) OccurredAt() time.Time { return e.Timestamp }
+func (e *SettlementLineItemAdded) Version() int          { return e.EventVersion }
+
+// SettlementBalanceCarriedIn event - fired when a previous settlement's balance is moved into this one
+type SettlementBalanceCarriedIn struct {
+	SettlementID     string    `json:"settlement_id"`
+	VendorID         string    `json:"vendor_id"`
+	FromSettlementID string    `json:"from_settlement_id"`
+	Amount           int       `json:"amount"`
+	EventVersion     int       `json:"version"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+func (e *SettlementBalanceCarriedIn) EventType() string     { return "SettlementBalanceCarriedIn" }
+func (e *SettlementBalanceCarriedIn) AggregateID() string   { return e.SettlementID }
+func (e *SettlementBalanceCarriedIn) OccurredAt() time.Time { return e.Timestamp }
+func (e *SettlementBalanceCarriedIn) Version() int          { return e.EventVersion }
+
+// SettlementCarriedOver event - fired when a closed period's balance is carried into the next settlement
+type SettlementCarriedOver struct {
+	SettlementID   string    `json:"settlement_id"`
+	VendorID       string    `json:"vendor_id"`
+	ToSettlementID string    `json:"to_settlement_id"`
+	Amount         int       `json:"amount"`
+	Reason         string    `json:"reason"`
+	EventVersion   int       `json:"version"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (e *SettlementCarriedOver) EventType() string     { return "SettlementCarriedOver" }
+func (e *SettlementCarriedOver) AggregateID() string   { return e.SettlementID }
+func (e *SettlementCarriedOver) OccurredAt() time.Time { return e.Timestamp }
+func (e *SettlementCarriedOver) Version() int          { return e.EventVersion }
+
+// SettlementClosed event - fired when a settlement is closed with a zero balance, so there is nothing to pay
+// out or carry over
+type SettlementClosed struct {
+	SettlementID string    `json:"settlement_id"`
+	VendorID     string    `json:"vendor_id"`
+	Reason       string    `json:"reason"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SettlementClosed) EventType() string     { return "SettlementClosed" }
+func (e *SettlementClosed) AggregateID() string   { return e.SettlementID }
+func (e *SettlementClosed) OccurredAt() time.Time { return e.Timestamp }
+func (e *SettlementClosed) Version() int          { return e.EventVersion }
+
+// SettlementPaidOut event - fired when a settlement is closed with a payout to the vendor
+type SettlementPaidOut struct {
+	SettlementID string    `json:"settlement_id"`
+	VendorID     string    `json:"vendor_id"`
+	PayoutID     string    `json:"payout_id"`
+	Amount       int       `json:"amount"`     // Settlement total before commission
+	Commission   int       `json:"commission"` // Platform commission withheld from the payout
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SettlementPaidOut) EventType() string     { return "SettlementPaidOut" }
+func (e *SettlementPaidOut) AggregateID() string   { return e.SettlementID }
+func (e *SettlementPaidOut) OccurredAt() time.Time { return e.Timestamp }
+func (e *SettlementPaidOut) Version() int          { return e.EventVersion }
+
+// VendorSettlementSettingsUpdated event - fired when vendor changes settlement period or payout threshold
+type VendorSettlementSettingsUpdated struct {
+	VendorID        string    `json:"vendor_id"`
+	Period          string    `json:"period"`
+	MinPayoutAmount int       `json:"min_payout_amount"`
+	EventVersion    int       `json:"version"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *VendorSettlementSettingsUpdated) EventType() string {
+	return "VendorSettlementSettingsUpdated"
+}
+func (e *VendorSettlementSettingsUpdated) AggregateID() string   { return e.VendorID }
+func (e *VendorSettlementSettingsUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *VendorSettlementSettingsUpdated) Version() int          { return e.EventVersion }
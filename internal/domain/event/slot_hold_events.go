@@ -0,0 +1,51 @@
+package event
+
+import "time"
+
+// SlotHoldPlaced event - fired when a booking payment is created and its time is held for the customer
+type SlotHoldPlaced struct {
+	HoldID    string    `json:"hold_id"`
+	PaymentID string    `json:"payment_id"`
+	UserID    string    `json:"user_id"`
+	ShopID    string    `json:"shop_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (e *SlotHoldPlaced) EventType() string     { return "SlotHoldPlaced" }
+func (e *SlotHoldPlaced) AggregateID() string   { return e.HoldID }
+func (e *SlotHoldPlaced) OccurredAt() time.Time { return e.Timestamp }
+func (e *SlotHoldPlaced) Version() int          { return 1 }
+
+// SlotHoldReleased event - fired when the payment behind a hold is cancelled or expires
+type SlotHoldReleased struct {
+	HoldID       string    `json:"hold_id"`
+	PaymentID    string    `json:"payment_id"`
+	ShopID       string    `json:"shop_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Reason       string    `json:"reason"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SlotHoldReleased) EventType() string     { return "SlotHoldReleased" }
+func (e *SlotHoldReleased) AggregateID() string   { return e.HoldID }
+func (e *SlotHoldReleased) OccurredAt() time.Time { return e.Timestamp }
+func (e *SlotHoldReleased) Version() int          { return e.EventVersion }
+
+// SlotHoldConverted event - fired when the held time becomes a confirmed schedule
+type SlotHoldConverted struct {
+	HoldID       string    `json:"hold_id"`
+	PaymentID    string    `json:"payment_id"`
+	ScheduleID   string    `json:"schedule_id"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SlotHoldConverted) EventType() string     { return "SlotHoldConverted" }
+func (e *SlotHoldConverted) AggregateID() string   { return e.HoldID }
+func (e *SlotHoldConverted) OccurredAt() time.Time { return e.Timestamp }
+func (e *SlotHoldConverted) Version() int          { return e.EventVersion }
@@ -0,0 +1,94 @@
+package event
+
+import "time"
+
+// SpeciesBreed is a breed in the species catalogue
+type SpeciesBreed struct {
+	Code    string   `json:"code" bson:"code"`
+	NameEn  string   `json:"name_en" bson:"name_en"`
+	NameVi  string   `json:"name_vi" bson:"name_vi"`
+	Aliases []string `json:"aliases" bson:"aliases"`
+	Size    string   `json:"size,omitempty" bson:"size,omitempty"` // Typical adult size, used when a pet has no weight
+}
+
+// SpeciesCreated event - fired when a species is added to the catalogue
+type SpeciesCreated struct {
+	SpeciesID string         `json:"species_id"`
+	NameEn    string         `json:"name_en"`
+	NameVi    string         `json:"name_vi"`
+	Aliases   []string       `json:"aliases"`
+	Breeds    []SpeciesBreed `json:"breeds"`
+	CreatedBy string         `json:"created_by"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+func (e *SpeciesCreated) EventType() string     { return "SpeciesCreated" }
+func (e *SpeciesCreated) AggregateID() string   { return e.SpeciesID }
+func (e *SpeciesCreated) OccurredAt() time.Time { return e.Timestamp }
+func (e *SpeciesCreated) Version() int          { return 1 }
+
+// SpeciesUpdated event - fired when the names or aliases of a species change
+type SpeciesUpdated struct {
+	SpeciesID    string    `json:"species_id"`
+	NameEn       string    `json:"name_en"`
+	NameVi       string    `json:"name_vi"`
+	Aliases      []string  `json:"aliases"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SpeciesUpdated) EventType() string     { return "SpeciesUpdated" }
+func (e *SpeciesUpdated) AggregateID() string   { return e.SpeciesID }
+func (e *SpeciesUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *SpeciesUpdated) Version() int          { return e.EventVersion }
+
+// SpeciesBreedAdded event - fired when a breed is added to a species
+type SpeciesBreedAdded struct {
+	SpeciesID    string       `json:"species_id"`
+	Breed        SpeciesBreed `json:"breed"`
+	EventVersion int          `json:"version"`
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+func (e *SpeciesBreedAdded) EventType() string     { return "SpeciesBreedAdded" }
+func (e *SpeciesBreedAdded) AggregateID() string   { return e.SpeciesID }
+func (e *SpeciesBreedAdded) OccurredAt() time.Time { return e.Timestamp }
+func (e *SpeciesBreedAdded) Version() int          { return e.EventVersion }
+
+// SpeciesBreedUpdated event - fired when the names, aliases or size of a breed change
+type SpeciesBreedUpdated struct {
+	SpeciesID    string       `json:"species_id"`
+	Breed        SpeciesBreed `json:"breed"`
+	EventVersion int          `json:"version"`
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+func (e *SpeciesBreedUpdated) EventType() string     { return "SpeciesBreedUpdated" }
+func (e *SpeciesBreedUpdated) AggregateID() string   { return e.SpeciesID }
+func (e *SpeciesBreedUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *SpeciesBreedUpdated) Version() int          { return e.EventVersion }
+
+// SpeciesBreedRemoved event - fired when a breed is removed from a species
+type SpeciesBreedRemoved struct {
+	SpeciesID    string    `json:"species_id"`
+	BreedCode    string    `json:"breed_code"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SpeciesBreedRemoved) EventType() string     { return "SpeciesBreedRemoved" }
+func (e *SpeciesBreedRemoved) AggregateID() string   { return e.SpeciesID }
+func (e *SpeciesBreedRemoved) OccurredAt() time.Time { return e.Timestamp }
+func (e *SpeciesBreedRemoved) Version() int          { return e.EventVersion }
+
+// SpeciesDeactivated event - fired when a species can no longer be chosen for new pets
+type SpeciesDeactivated struct {
+	SpeciesID    string    `json:"species_id"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *SpeciesDeactivated) EventType() string     { return "SpeciesDeactivated" }
+func (e *SpeciesDeactivated) AggregateID() string   { return e.SpeciesID }
+func (e *SpeciesDeactivated) OccurredAt() time.Time { return e.Timestamp }
+func (e *SpeciesDeactivated) Version() int          { return e.EventVersion }
@@ -58,3 +58,30 @@ func (e *VendorImageUpdated) EventType() string     { return "VendorImageUpdated
 func (e *VendorImageUpdated) AggregateID() string   { return e.VendorID }
 func (e *VendorImageUpdated) OccurredAt() time.Time { return e.Timestamp }
 func (e *VendorImageUpdated) Version() int          { return e.EventVersion }
+
+// VendorPaymentMethodsUpdated event - fired when a vendor changes the payment methods customers can use
+type VendorPaymentMethodsUpdated struct {
+	VendorID       string    `json:"vendor_id"`
+	PaymentMethods []string  `json:"payment_methods"`
+	EventVersion   int       `json:"version"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (e *VendorPaymentMethodsUpdated) EventType() string     { return "VendorPaymentMethodsUpdated" }
+func (e *VendorPaymentMethodsUpdated) AggregateID() string   { return e.VendorID }
+func (e *VendorPaymentMethodsUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *VendorPaymentMethodsUpdated) Version() int          { return e.EventVersion }
+
+// VendorReschedulePolicyUpdated event - fired when a vendor changes how customers may reschedule bookings
+type VendorReschedulePolicyUpdated struct {
+	VendorID       string    `json:"vendor_id"`
+	CutoffHours    int       `json:"cutoff_hours"`
+	MaxReschedules int       `json:"max_reschedules"`
+	EventVersion   int       `json:"version"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (e *VendorReschedulePolicyUpdated) EventType() string     { return "VendorReschedulePolicyUpdated" }
+func (e *VendorReschedulePolicyUpdated) AggregateID() string   { return e.VendorID }
+func (e *VendorReschedulePolicyUpdated) OccurredAt() time.Time { return e.Timestamp }
+func (e *VendorReschedulePolicyUpdated) Version() int          { return e.EventVersion }
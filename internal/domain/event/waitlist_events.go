@@ -0,0 +1,90 @@
+package event
+
+import "time"
+
+// WaitlistOffer is a freed slot offered to a waitlisted customer, who can claim it until ExpiresAt
+type WaitlistOffer struct {
+	StartTime       time.Time `json:"start_time" bson:"start_time"`
+	EndTime         time.Time `json:"end_time" bson:"end_time"`
+	ExpiresAt       time.Time `json:"expires_at" bson:"expires_at"`
+	ServiceIDs      []string  `json:"service_ids" bson:"service_ids"`                                 // Services the freed slot was booked for
+	FreedScheduleID string    `json:"freed_schedule_id,omitempty" bson:"freed_schedule_id,omitempty"` // Booking whose cancellation or move freed the slot
+	OfferedAt       time.Time `json:"offered_at" bson:"offered_at"`
+}
+
+// WaitlistJoined event - fired when a customer joins the waitlist for a service in a time window
+type WaitlistJoined struct {
+	EntryID     string    `json:"entry_id"`
+	UserID      string    `json:"user_id"`
+	PetID       string    `json:"pet_id"`
+	VendorID    string    `json:"vendor_id"`
+	ServiceID   string    `json:"service_id"`
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (e *WaitlistJoined) EventType() string     { return "WaitlistJoined" }
+func (e *WaitlistJoined) AggregateID() string   { return e.EntryID }
+func (e *WaitlistJoined) OccurredAt() time.Time { return e.Timestamp }
+func (e *WaitlistJoined) Version() int          { return 1 }
+
+// WaitlistSlotOffered event - fired when a freed slot is offered to a waitlisted customer
+type WaitlistSlotOffered struct {
+	EntryID      string        `json:"entry_id"`
+	UserID       string        `json:"user_id"`
+	VendorID     string        `json:"vendor_id"`
+	ServiceID    string        `json:"service_id"`
+	Offer        WaitlistOffer `json:"offer"`
+	EventVersion int           `json:"version"`
+	Timestamp    time.Time     `json:"timestamp"`
+}
+
+func (e *WaitlistSlotOffered) EventType() string     { return "WaitlistSlotOffered" }
+func (e *WaitlistSlotOffered) AggregateID() string   { return e.EntryID }
+func (e *WaitlistSlotOffered) OccurredAt() time.Time { return e.Timestamp }
+func (e *WaitlistSlotOffered) Version() int          { return e.EventVersion }
+
+// WaitlistOfferPassed event - fired when an offered slot was declined or not claimed in time and moves on
+// to the next customer. The customer stays on the waitlist.
+type WaitlistOfferPassed struct {
+	EntryID      string    `json:"entry_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Reason       string    `json:"reason"` // DECLINED or LAPSED
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *WaitlistOfferPassed) EventType() string     { return "WaitlistOfferPassed" }
+func (e *WaitlistOfferPassed) AggregateID() string   { return e.EntryID }
+func (e *WaitlistOfferPassed) OccurredAt() time.Time { return e.Timestamp }
+func (e *WaitlistOfferPassed) Version() int          { return e.EventVersion }
+
+// WaitlistOfferClaimed event - fired when a customer claims an offered slot and checks out
+type WaitlistOfferClaimed struct {
+	EntryID      string    `json:"entry_id"`
+	PaymentID    string    `json:"payment_id"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *WaitlistOfferClaimed) EventType() string     { return "WaitlistOfferClaimed" }
+func (e *WaitlistOfferClaimed) AggregateID() string   { return e.EntryID }
+func (e *WaitlistOfferClaimed) OccurredAt() time.Time { return e.Timestamp }
+func (e *WaitlistOfferClaimed) Version() int          { return e.EventVersion }
+
+// WaitlistEntryClosed event - fired when a customer leaves the waitlist or their time window passes
+type WaitlistEntryClosed struct {
+	EntryID      string    `json:"entry_id"`
+	Status       string    `json:"status"` // LEFT or EXPIRED
+	EventVersion int       `json:"version"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+func (e *WaitlistEntryClosed) EventType() string     { return "WaitlistEntryClosed" }
+func (e *WaitlistEntryClosed) AggregateID() string   { return e.EntryID }
+func (e *WaitlistEntryClosed) OccurredAt() time.Time { return e.Timestamp }
+func (e *WaitlistEntryClosed) Version() int          { return e.EventVersion }
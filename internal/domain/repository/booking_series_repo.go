@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+)
+
+// BookingSeriesRepository defines operations for recurring booking series
+type BookingSeriesRepository interface {
+	// Event store operations
+	SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error
+	GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error)
+
+	// Aggregate operations
+	Save(ctx context.Context, series *aggregate.BookingSeries) error
+	GetByID(ctx context.Context, id string) (*aggregate.BookingSeries, error)
+	GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*aggregate.BookingSeries, error)
+	GetDue(ctx context.Context, until time.Time) ([]*aggregate.BookingSeries, error) // Active series with an occurrence to book before the time
+
+	// Event stream operations
+	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+)
+
+// LedgerRepository defines the append-only storage of ledger entries
+type LedgerRepository interface {
+	// Append stores a new entry; returns false if an entry with the same reference already exists
+	Append(ctx context.Context, entry *aggregate.LedgerEntry) (bool, error)
+	GetByReference(ctx context.Context, reference string) (*aggregate.LedgerEntry, error) // Returns nil if not found
+	List(ctx context.Context, from, to time.Time, offset, limit int) ([]*aggregate.LedgerEntry, error)
+	ListAll(ctx context.Context, from, to time.Time) ([]*aggregate.LedgerEntry, error)
+
+	// Balances
+	GetAccountBalances(ctx context.Context) ([]aggregate.LedgerBalance, error)
+	GetVendorBalance(ctx context.Context, vendorID string) (aggregate.LedgerBalance, error)
+
+	// Invariant checks
+	GetTotals(ctx context.Context) (debit int, credit int, err error)
+	GetUnbalancedEntryIDs(ctx context.Context) ([]string, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/event"
 )
@@ -18,8 +19,10 @@ type PaymentRepository interface {
 	GetByOrderCode(ctx context.Context, orderCode int64) (*aggregate.Payment, error)
 	GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*aggregate.Payment, error)
 	GetByStatus(ctx context.Context, status string) ([]*aggregate.Payment, error)
+	HasPaidPayment(ctx context.Context, userID string) (bool, error) // True if the user has ever completed a payment
 
 	// Event stream operations
 	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
 	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+	GetEventsOfTypeSince(ctx context.Context, since time.Time, eventTypes ...string) ([]event.DomainEvent, error) // Committed events of the given types, oldest first
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/event"
 )
@@ -22,4 +23,5 @@ type PayoutRepository interface {
 	// Event stream operations
 	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
 	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+	GetEventsOfTypeSince(ctx context.Context, since time.Time, eventTypes ...string) ([]event.DomainEvent, error) // Committed events of the given types, oldest first
 }
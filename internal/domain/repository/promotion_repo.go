@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+)
+
+// PromotionRepository defines operations for promotion aggregates
+type PromotionRepository interface {
+	// Event store operations
+	SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error
+	GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error)
+
+	// Aggregate operations
+	Save(ctx context.Context, promotion *aggregate.Promotion) error
+	GetByID(ctx context.Context, id string) (*aggregate.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*aggregate.Promotion, error) // Returns nil if no promotion uses the code
+	List(ctx context.Context, offset, limit int) ([]*aggregate.Promotion, error)
+
+	// Event stream operations
+	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+}
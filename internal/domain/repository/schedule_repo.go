@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/event"
 )
@@ -16,6 +17,20 @@ type ScheduleRepository interface {
 	Save(ctx context.Context, schedule *aggregate.Schedule) error
 	GetByID(ctx context.Context, id string) (*aggregate.Schedule, error)
 
+	// HasOverlappingBooking checks if the shop has an open booking other than excludeID that overlaps the time range
+	HasOverlappingBooking(ctx context.Context, shopID string, startTime, endTime time.Time, excludeID string) (bool, error)
+
+	// HasOverlappingStaffBooking checks if the staff member is assigned an open booking other than excludeID that overlaps the time range
+	HasOverlappingStaffBooking(ctx context.Context, staffID string, startTime, endTime time.Time, excludeID string) (bool, error)
+
+	// CountStaffBookings counts the open bookings assigned to the staff member that start within the time range
+	CountStaffBookings(ctx context.Context, staffID string, from, to time.Time) (int, error)
+
+	// Lifecycle queries used by the background jobs that remind, complete and cancel bookings
+	GetStartingBetween(ctx context.Context, statuses []aggregate.ScheduleStatus, from, to time.Time) ([]*aggregate.Schedule, error)
+	GetEndedBefore(ctx context.Context, statuses []aggregate.ScheduleStatus, before time.Time) ([]*aggregate.Schedule, error)
+	GetCreatedBefore(ctx context.Context, statuses []aggregate.ScheduleStatus, before time.Time) ([]*aggregate.Schedule, error)
+
 	// Event stream operations
 	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
 	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+)
+
+// SettlementRepository defines operations for vendor settlement aggregates
+type SettlementRepository interface {
+	// Event store operations
+	SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error
+	GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error)
+
+	// Aggregate operations
+	Save(ctx context.Context, settlement *aggregate.Settlement) error
+	GetByID(ctx context.Context, id string) (*aggregate.Settlement, error)
+	GetByVendorID(ctx context.Context, vendorID string, offset, limit int) ([]*aggregate.Settlement, error)
+	GetOpenForVendorAt(ctx context.Context, vendorID string, at time.Time) (*aggregate.Settlement, error) // Returns nil if vendor has no open settlement covering the time
+	GetDue(ctx context.Context, now time.Time) ([]*aggregate.Settlement, error)                           // Open settlements whose period has ended
+	GetByPaymentID(ctx context.Context, paymentID string) (*aggregate.Settlement, error)                  // Returns nil if payment is not settled yet
+
+	// Event stream operations
+	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+	GetEventsOfTypeSince(ctx context.Context, since time.Time, eventTypes ...string) ([]event.DomainEvent, error) // Committed events of the given types, oldest first
+}
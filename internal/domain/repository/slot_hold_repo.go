@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+)
+
+// ErrShopSlotsBusy is returned by LockShopSlots when another transaction is booking time at the same shop
+var ErrShopSlotsBusy = errors.New("another booking at this shop is in progress")
+
+// SlotHoldRepository defines operations for holds on a shop's time during checkout
+type SlotHoldRepository interface {
+	// Event store operations
+	SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error
+	GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error)
+
+	// Aggregate operations
+	Save(ctx context.Context, hold *aggregate.SlotHold) error
+	GetByID(ctx context.Context, id string) (*aggregate.SlotHold, error)
+	GetByPaymentID(ctx context.Context, paymentID string) (*aggregate.SlotHold, error) // nil when the payment holds no time
+
+	// HasOverlappingHold checks if the shop has a hold other than the given payment's that is active at
+	// now and overlaps the time range
+	HasOverlappingHold(ctx context.Context, shopID string, startTime, endTime time.Time, excludePaymentID string, now time.Time) (bool, error)
+
+	// LockShopSlots makes transactions that book time at the same shop conflict with each other, so two
+	// customers checking the same free time cannot both take it. Fails with ErrShopSlotsBusy when lost.
+	LockShopSlots(ctx context.Context, shopID string) error
+
+	// Event stream operations
+	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+}
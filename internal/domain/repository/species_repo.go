@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+)
+
+// SpeciesRepository defines operations for the species catalogue
+type SpeciesRepository interface {
+	// Event store operations
+	SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error
+	GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error)
+
+	// Aggregate operations
+	Save(ctx context.Context, species *aggregate.Species) error
+	GetByID(ctx context.Context, id string) (*aggregate.Species, error)
+	GetByTerm(ctx context.Context, term string) (*aggregate.Species, error) // Looks up by code, name or alias; returns nil if no species matches
+	List(ctx context.Context, includeInactive bool) ([]*aggregate.Species, error)
+
+	// Event stream operations
+	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+}
@@ -20,6 +20,13 @@ type UnitOfWork interface {
 	ScheduleRepository() ScheduleRepository
 	VendorStaffRepository() VendorStaffRepository
 	PayoutRepository() PayoutRepository
+	SettlementRepository() SettlementRepository
+	LedgerRepository() LedgerRepository
+	PromotionRepository() PromotionRepository
+	SpeciesRepository() SpeciesRepository
+	BookingSeriesRepository() BookingSeriesRepository
+	SlotHoldRepository() SlotHoldRepository
+	WaitlistRepository() WaitlistRepository
 
 	// Generic repository factory
 	Repository(entityType string) interface{}
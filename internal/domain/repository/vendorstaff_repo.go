@@ -15,6 +15,7 @@ type VendorStaffRepository interface {
 	// Aggregate operations (built from events)
 	Save(ctx context.Context, staff *aggregate.VendorStaff) error
 	GetByID(ctx context.Context, id string) (*aggregate.VendorStaff, error)
+	GetActiveByVendorID(ctx context.Context, vendorID string) ([]*aggregate.VendorStaff, error)
 
 	// Event stream operations
 	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
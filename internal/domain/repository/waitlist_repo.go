@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+)
+
+// WaitlistRepository defines operations for customers waiting for a freed slot
+type WaitlistRepository interface {
+	// Event store operations
+	SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error
+	GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error)
+
+	// Aggregate operations
+	Save(ctx context.Context, entry *aggregate.WaitlistEntry) error
+	GetByID(ctx context.Context, id string) (*aggregate.WaitlistEntry, error)
+	GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*aggregate.WaitlistEntry, error)
+
+	// FindNextForSlot finds the first waiting entry, in joining order, that joined after the given time for
+	// one of the services at the vendor and whose window covers the slot. Returns nil when nobody is waiting.
+	FindNextForSlot(ctx context.Context, vendorID string, serviceIDs []string, startTime, endTime, joinedAfter time.Time) (*aggregate.WaitlistEntry, error)
+
+	// HasOverlappingOffer checks if the vendor has offered a slot overlapping the time range that can
+	// still be claimed at now
+	HasOverlappingOffer(ctx context.Context, vendorID string, startTime, endTime, now time.Time) (bool, error)
+
+	GetLapsedOffers(ctx context.Context, now time.Time) ([]*aggregate.WaitlistEntry, error)  // Offers not claimed in time
+	GetPassedWindows(ctx context.Context, now time.Time) ([]*aggregate.WaitlistEntry, error) // Waiting entries whose window ended
+
+	// Event stream operations
+	GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]event.DomainEvent, error)
+}
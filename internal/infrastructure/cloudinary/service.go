@@ -266,6 +266,22 @@ func (s *Service) UploadPetImage(ctx context.Context, file io.Reader, filename s
 	return s.UploadFile(ctx, file, filename, opts)
 }
 
+// UploadPetDocument uploads a pet document (lab result, certificate, x-ray...) as a PDF or image.
+// Documents are stored as-is, without the quality optimization applied to profile images.
+func (s *Service) UploadPetDocument(ctx context.Context, file io.Reader, filename string, petID string) (*UploadResult, error) {
+	opts := &UploadOptions{
+		Folder:         "pets/documents",
+		PublicID:       fmt.Sprintf("pet_%s_doc_%d", petID, time.Now().UnixNano()),
+		UniqueFilename: false,
+		Overwrite:      false,
+		Tags:           []string{"pet", "document", petID},
+		ResourceType:   "image", // Cloudinary stores PDFs as image resources
+		AllowedFormats: []string{"jpg", "jpeg", "png", "webp", "pdf"},
+	}
+
+	return s.UploadFile(ctx, file, filename, opts)
+}
+
 // UploadVendorImage uploads a vendor/service image with optimized settings
 func (s *Service) UploadVendorImage(ctx context.Context, file io.Reader, filename string, vendorID string) (*UploadResult, error) {
 	opts := &UploadOptions{
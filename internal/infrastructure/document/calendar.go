@@ -0,0 +1,252 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/infrastructure/projection"
+)
+
+// calendarProductID identifies the application that produced a calendar (RFC 5545 PRODID)
+const calendarProductID = "-//Whisko//Pet Care Bookings//EN"
+
+// calendarUIDDomain makes booking UIDs globally unique; a booking keeps its UID for life so calendars
+// update and cancel the copy they imported instead of adding a new one
+const calendarUIDDomain = "bookings.whisko"
+
+// calendarLineLimit is the longest content line allowed in octets, excluding the line break
+const calendarLineLimit = 75
+
+// CalendarAudience is who a booking calendar is made for; it decides how bookings are titled
+type CalendarAudience string
+
+const (
+	CalendarAudienceCustomer CalendarAudience = "customer"
+	CalendarAudienceShop     CalendarAudience = "shop"
+)
+
+// Calendar is a calendar of bookings, rendered as iCalendar (RFC 5545)
+type Calendar struct {
+	Name            string
+	TimeZone        string        // IANA time zone calendar applications display the calendar in, e.g. Asia/Ho_Chi_Minh
+	RefreshInterval time.Duration // How often subscribed calendar applications should fetch the calendar again; zero for a one-off download
+	Events          []CalendarEvent
+}
+
+// CalendarEvent is one booking in a calendar
+type CalendarEvent struct {
+	UID          string
+	Sequence     int
+	Status       string // CONFIRMED, TENTATIVE or CANCELLED
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	Created      time.Time
+	LastModified time.Time
+}
+
+// NewBookingCalendarEvent builds the calendar event of a booking. Customers see the shop and services
+// they booked, shops see the customer and pets they expect.
+func NewBookingCalendarEvent(schedule *projection.ScheduleReadModel, audience CalendarAudience) CalendarEvent {
+	services := bookedServiceNames(schedule)
+	pets := bookedPetNames(schedule)
+
+	var summary string
+	if audience == CalendarAudienceShop {
+		summary = joinNonEmpty(" - ", services, joinNonEmpty(" ", pets, parenthesize(schedule.BookingUser.Name)))
+	} else {
+		summary = joinNonEmpty(" - ", services, joinNonEmpty(" at ", pets, schedule.BookedShop.Name))
+	}
+	if summary == "" {
+		summary = "Pet care booking"
+	}
+
+	lines := []string{}
+	if audience == CalendarAudienceShop {
+		lines = append(lines, "Customer: "+joinNonEmpty(", ", schedule.BookingUser.Name, schedule.BookingUser.Phone))
+	} else {
+		lines = append(lines, "Shop: "+joinNonEmpty(", ", schedule.BookedShop.Name, schedule.BookedShop.Phone))
+	}
+	lines = append(lines, "Pets: "+pets, "Services: "+services, "Status: "+schedule.Status)
+	if schedule.TotalPrice > 0 {
+		lines = append(lines, "Price: "+FormatAmount(schedule.TotalPrice)+" VND")
+	}
+	lines = append(lines, "Booking: "+schedule.ID)
+
+	return CalendarEvent{
+		UID:          schedule.ID + "@" + calendarUIDDomain,
+		Sequence:     schedule.Sequence,
+		Status:       bookingCalendarStatus(schedule.Status),
+		Summary:      summary,
+		Description:  strings.Join(lines, "\n"),
+		Location:     schedule.BookedShop.Location,
+		Start:        schedule.StartTime,
+		End:          schedule.EndTime,
+		Created:      schedule.CreatedAt,
+		LastModified: schedule.UpdatedAt,
+	}
+}
+
+// RenderCalendar renders a calendar as iCalendar. Times are written in UTC, which RFC 5545 defines as
+// absolute, so every calendar application shows the booking at the right local time whatever zone its
+// user is in; the calendar's time zone is only a display hint.
+func RenderCalendar(calendar *Calendar, generatedAt time.Time) []byte {
+	w := &calendarWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", calendarProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if calendar.Name != "" {
+		w.line("X-WR-CALNAME", escapeCalendarText(calendar.Name))
+	}
+	if calendar.TimeZone != "" {
+		w.line("X-WR-TIMEZONE", calendar.TimeZone)
+	}
+	if calendar.RefreshInterval > 0 {
+		interval := calendarDuration(calendar.RefreshInterval)
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", interval)
+		w.line("X-PUBLISHED-TTL", interval)
+	}
+
+	for _, e := range calendar.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", e.UID)
+		w.line("DTSTAMP", calendarTime(generatedAt))
+		w.line("DTSTART", calendarTime(e.Start))
+		w.line("DTEND", calendarTime(e.End))
+		w.line("SEQUENCE", fmt.Sprint(e.Sequence))
+		w.line("STATUS", e.Status)
+		w.line("SUMMARY", escapeCalendarText(e.Summary))
+		if e.Location != "" {
+			w.line("LOCATION", escapeCalendarText(e.Location))
+		}
+		if e.Description != "" {
+			w.line("DESCRIPTION", escapeCalendarText(e.Description))
+		}
+		if !e.Created.IsZero() {
+			w.line("CREATED", calendarTime(e.Created))
+		}
+		if !e.LastModified.IsZero() {
+			w.line("LAST-MODIFIED", calendarTime(e.LastModified))
+		}
+		w.line("TRANSP", "OPAQUE")
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// bookingCalendarStatus maps the status of a booking to the status of its calendar event. Bookings the
+// shop has not confirmed yet are tentative; no-shows stay on the calendar as they did take the time.
+func bookingCalendarStatus(status string) string {
+	switch aggregate.ScheduleStatus(status) {
+	case aggregate.ScheduleStatusPending:
+		return "TENTATIVE"
+	case aggregate.ScheduleStatusCancelled:
+		return "CANCELLED"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+// bookedPetNames lists the pets still booked, or the booked pet of a single-pet booking
+func bookedPetNames(schedule *projection.ScheduleReadModel) string {
+	if len(schedule.PetLines) == 0 {
+		return schedule.AssignedPet.Name
+	}
+	names := []string{}
+	for _, line := range schedule.PetLines {
+		if line.Status != event.SchedulePetLineCancelled {
+			names = append(names, line.PetName)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// bookedServiceNames lists the booked services once each
+func bookedServiceNames(schedule *projection.ScheduleReadModel) string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, svc := range schedule.BookedShop.Services {
+		if svc.Name != "" && !seen[svc.Name] {
+			seen[svc.Name] = true
+			names = append(names, svc.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// parenthesize wraps a non-empty value in parentheses
+func parenthesize(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "(" + value + ")"
+}
+
+// calendarTime formats a time as an iCalendar UTC date-time, e.g. 20250301T023000Z
+func calendarTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// calendarDuration formats a duration as an iCalendar duration in whole minutes, e.g. PT1H30M
+func calendarDuration(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	result := "PT"
+	if minutes >= 60 {
+		result += fmt.Sprintf("%dH", minutes/60)
+	}
+	if minutes%60 != 0 {
+		result += fmt.Sprintf("%dM", minutes%60)
+	}
+	return result
+}
+
+// escapeCalendarText escapes an iCalendar TEXT value
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+// calendarWriter writes iCalendar content lines, ending them with CRLF and folding them at 75 octets
+// without splitting a UTF-8 character
+type calendarWriter struct {
+	buf bytes.Buffer
+}
+
+// line writes one property
+func (w *calendarWriter) line(name, value string) {
+	content := name + ":" + value
+	limit := calendarLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = calendarLineLimit - 1
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,300 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"time"
+
+	"whisko-petcare/internal/infrastructure/projection"
+)
+
+// healthRecordFormatVersion versions the JSON layout of exported health records so that
+// importing systems can tell which fields to expect
+const healthRecordFormatVersion = "1.0"
+
+// PetHealthRecord is the portable health record of a pet: its profile, vaccinations, medical
+// history, allergies and weight history. Voided entries are left out.
+type PetHealthRecord struct {
+	FormatVersion  string                             `json:"format_version"`
+	GeneratedAt    time.Time                          `json:"generated_at"`
+	Pet            HealthRecordPet                    `json:"pet"`
+	Vaccinations   []projection.VaccinationRecordView `json:"vaccinations"`
+	MedicalHistory []projection.MedicalRecordView     `json:"medical_history"`
+	Allergies      []projection.AllergyView           `json:"allergies"`
+	Weights        []projection.WeightMeasurementView `json:"weights"`
+}
+
+// HealthRecordPet holds the pet profile printed on a health record
+type HealthRecordPet struct {
+	ID                 string     `json:"id"`
+	Name               string     `json:"name"`
+	Species            string     `json:"species"`
+	Breed              string     `json:"breed"`
+	BirthDate          *time.Time `json:"birth_date,omitempty"`
+	BirthDateEstimated bool       `json:"birth_date_estimated,omitempty"`
+	Age                int        `json:"age"`
+	Weight             float64    `json:"weight"`
+}
+
+// NewPetHealthRecord builds the health record of a pet from its read model
+func NewPetHealthRecord(pet *projection.PetReadModel, generatedAt time.Time) *PetHealthRecord {
+	current := pet.WithoutVoidedRecords()
+
+	record := &PetHealthRecord{
+		FormatVersion: healthRecordFormatVersion,
+		GeneratedAt:   generatedAt,
+		Pet: HealthRecordPet{
+			ID:                 pet.ID,
+			Name:               pet.Name,
+			Species:            pet.Species,
+			Breed:              pet.Breed,
+			BirthDate:          pet.BirthDate,
+			BirthDateEstimated: pet.BirthDateEstimated,
+			Age:                pet.Age,
+			Weight:             pet.Weight,
+		},
+		Vaccinations:   current.VaccinationRecords,
+		MedicalHistory: current.MedicalHistory,
+		Allergies:      current.Allergies,
+		Weights:        current.Weights,
+	}
+	if record.Allergies == nil {
+		record.Allergies = []projection.AllergyView{}
+	}
+	if record.Weights == nil {
+		record.Weights = []projection.WeightMeasurementView{}
+	}
+	return record
+}
+
+// ParseHealthRecordFormat parses a health record format query value, defaulting to JSON
+func ParseHealthRecordFormat(value string) (Format, error) {
+	if value == "" || Format(value) == FormatJSON {
+		return FormatJSON, nil
+	}
+	return ParseFormat(value)
+}
+
+// RenderPetHealthRecord renders a pet health record in the requested format
+func RenderPetHealthRecord(record *PetHealthRecord, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		content, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to render health record: %w", err)
+		}
+		return content, nil
+	case FormatHTML:
+		return renderHTML(healthRecordTemplate, record)
+	default:
+		return renderHealthRecordPDF(record), nil
+	}
+}
+
+func renderHealthRecordPDF(record *PetHealthRecord) []byte {
+	w := newPDFWriter()
+
+	w.heading(18, "PET HEALTH RECORD")
+	w.keyValue("Name", record.Pet.Name)
+	w.keyValue("Species", joinNonEmpty(" - ", record.Pet.Species, record.Pet.Breed))
+	if record.Pet.BirthDate != nil {
+		birthDate := record.Pet.BirthDate.Format("02/01/2006")
+		if record.Pet.BirthDateEstimated {
+			birthDate += " (estimated)"
+		}
+		w.keyValue("Born", birthDate)
+	}
+	w.keyValue("Age", strconv.Itoa(record.Pet.Age)+" years")
+	if record.Pet.Weight > 0 {
+		w.keyValue("Weight", formatWeight(record.Pet.Weight))
+	}
+	w.keyValue("Generated", record.GeneratedAt.Format("02/01/2006 15:04"))
+	w.space(8)
+
+	right := pdfPageWidth - pdfMarginRight
+
+	w.heading(12, "Vaccinations")
+	if len(record.Vaccinations) == 0 {
+		w.line("No vaccinations recorded")
+	} else {
+		columns := []pdfColumn{
+			{Title: "Vaccine", X: pdfMarginLeft},
+			{Title: "Given", X: pdfMarginLeft + 170},
+			{Title: "Next due", X: pdfMarginLeft + 250},
+			{Title: "Veterinarian", X: pdfMarginLeft + 330},
+			{Title: "", X: right, Right: true},
+		}
+		w.tableHeader(columns)
+		for _, vaccination := range record.Vaccinations {
+			w.tableRow(columns, []string{
+				vaccination.VaccineName,
+				formatRecordDate(vaccination.Date),
+				formatRecordDate(vaccination.NextDueDate),
+				vaccination.Veterinarian,
+			}, false)
+		}
+	}
+	w.space(8)
+
+	w.heading(12, "Medical history")
+	if len(record.MedicalHistory) == 0 {
+		w.line("No medical records")
+	}
+	for _, medical := range record.MedicalHistory {
+		w.ensureSpace(60)
+		w.keyValue(formatRecordDate(medical.Date), medical.Description)
+		if medical.Diagnosis != "" {
+			w.keyValue("Diagnosis", medical.Diagnosis)
+		}
+		if medical.Treatment != "" {
+			w.keyValue("Treatment", medical.Treatment)
+		}
+		if medical.Veterinarian != "" {
+			w.keyValue("Veterinarian", medical.Veterinarian)
+		}
+		if medical.Provenance != nil && medical.Provenance.VendorName != "" {
+			w.keyValue("Recorded by", medical.Provenance.VendorName)
+		}
+		if medical.Notes != "" {
+			w.keyValue("Notes", medical.Notes)
+		}
+		w.space(6)
+	}
+	w.space(8)
+
+	w.heading(12, "Allergies")
+	if len(record.Allergies) == 0 {
+		w.line("No known allergies")
+	} else {
+		columns := []pdfColumn{
+			{Title: "Allergen", X: pdfMarginLeft},
+			{Title: "Severity", X: pdfMarginLeft + 130},
+			{Title: "Diagnosed", X: pdfMarginLeft + 210},
+			{Title: "Symptoms", X: pdfMarginLeft + 290},
+			{Title: "", X: right, Right: true},
+		}
+		w.tableHeader(columns)
+		for _, allergy := range record.Allergies {
+			w.tableRow(columns, []string{
+				allergy.Allergen,
+				allergy.Severity,
+				formatRecordDate(allergy.DiagnosedDate),
+				allergy.Symptoms,
+			}, false)
+		}
+	}
+
+	if len(record.Weights) > 0 {
+		w.space(8)
+		w.heading(12, "Weight history")
+		columns := []pdfColumn{
+			{Title: "Date", X: pdfMarginLeft},
+			{Title: "Source", X: pdfMarginLeft + 100},
+			{Title: "Weight", X: right, Right: true},
+		}
+		w.tableHeader(columns)
+		for _, weight := range record.Weights {
+			w.tableRow(columns, []string{
+				formatRecordDate(weight.MeasuredAt),
+				weight.Source,
+				formatWeight(weight.Weight),
+			}, false)
+		}
+	}
+
+	return w.Bytes()
+}
+
+// formatRecordDate formats a health record date, leaving unset dates blank
+func formatRecordDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02/01/2006")
+}
+
+// formatWeight formats a weight in kilograms
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64) + " kg"
+}
+
+var healthRecordTemplate = template.Must(template.New("health-record").Funcs(template.FuncMap{
+	"date":   formatRecordDate,
+	"weight": formatWeight,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Health record - {{.Pet.Name}}</title>` + documentStyle + `
+</head>
+<body>
+  <h1>Pet health record</h1>
+  <table class="meta">
+    <tr><td>Name</td><td><strong>{{.Pet.Name}}</strong></td></tr>
+    <tr><td>Species</td><td>{{.Pet.Species}}{{if .Pet.Breed}} - {{.Pet.Breed}}{{end}}</td></tr>
+    {{with .Pet.BirthDate}}<tr><td>Born</td><td>{{.Format "02/01/2006"}}{{if $.Pet.BirthDateEstimated}} (estimated){{end}}</td></tr>{{end}}
+    <tr><td>Age</td><td>{{.Pet.Age}} years</td></tr>
+    {{if gt .Pet.Weight 0.0}}<tr><td>Weight</td><td>{{weight .Pet.Weight}}</td></tr>{{end}}
+    <tr><td>Generated</td><td>{{.GeneratedAt.Format "02/01/2006 15:04"}}</td></tr>
+  </table>
+
+  <h2>Vaccinations</h2>
+  {{if .Vaccinations}}
+  <table>
+    <thead>
+      <tr><th>Vaccine</th><th>Given</th><th>Next due</th><th>Veterinarian</th><th>Notes</th></tr>
+    </thead>
+    <tbody>
+      {{range .Vaccinations}}
+      <tr><td>{{.VaccineName}}</td><td>{{date .Date}}</td><td>{{date .NextDueDate}}</td><td>{{.Veterinarian}}</td><td>{{.Notes}}</td></tr>
+      {{end}}
+    </tbody>
+  </table>
+  {{else}}<div class="muted">No vaccinations recorded</div>{{end}}
+
+  <h2>Medical history</h2>
+  {{if .MedicalHistory}}
+  <table>
+    <thead>
+      <tr><th>Date</th><th>Description</th><th>Diagnosis</th><th>Treatment</th><th>Veterinarian</th></tr>
+    </thead>
+    <tbody>
+      {{range .MedicalHistory}}
+      <tr><td>{{date .Date}}</td><td>{{.Description}}{{with .Notes}}<div class="muted">{{.}}</div>{{end}}</td><td>{{.Diagnosis}}</td><td>{{.Treatment}}</td><td>{{.Veterinarian}}{{with .Provenance}}<div class="muted">Recorded by {{.VendorName}}</div>{{end}}</td></tr>
+      {{end}}
+    </tbody>
+  </table>
+  {{else}}<div class="muted">No medical records</div>{{end}}
+
+  <h2>Allergies</h2>
+  {{if .Allergies}}
+  <table>
+    <thead>
+      <tr><th>Allergen</th><th>Severity</th><th>Diagnosed</th><th>Symptoms</th></tr>
+    </thead>
+    <tbody>
+      {{range .Allergies}}
+      <tr><td>{{.Allergen}}</td><td>{{.Severity}}</td><td>{{date .DiagnosedDate}}</td><td>{{.Symptoms}}</td></tr>
+      {{end}}
+    </tbody>
+  </table>
+  {{else}}<div class="muted">No known allergies</div>{{end}}
+
+  {{if .Weights}}
+  <h2>Weight history</h2>
+  <table>
+    <thead>
+      <tr><th>Date</th><th>Source</th><th class="num">Weight</th></tr>
+    </thead>
+    <tbody>
+      {{range .Weights}}
+      <tr><td>{{date .MeasuredAt}}</td><td>{{.Source}}</td><td class="num">{{weight .Weight}}</td></tr>
+      {{end}}
+    </tbody>
+  </table>
+  {{end}}
+</body>
+</html>
+`))
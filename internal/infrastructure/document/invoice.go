@@ -0,0 +1,238 @@
+// Package document renders customer invoices, vendor payout statements and pet health records
+// as HTML and PDF, and booking calendars as iCalendar.
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strconv"
+
+	"whisko-petcare/internal/infrastructure/projection"
+)
+
+// Format is the output format of a rendered document
+type Format string
+
+const (
+	FormatHTML Format = "html"
+	FormatPDF  Format = "pdf"
+	FormatJSON Format = "json" // Health records only
+	FormatICS  Format = "ics"  // Calendars only
+)
+
+// ContentType returns the HTTP content type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatPDF:
+		return "application/pdf"
+	case FormatJSON:
+		return "application/json"
+	case FormatICS:
+		return "text/calendar; charset=utf-8"
+	}
+	return "text/html; charset=utf-8"
+}
+
+// ParseFormat parses a format query value, defaulting to PDF
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case "", FormatPDF:
+		return FormatPDF, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("unsupported document format: %s", value)
+	}
+}
+
+// RenderInvoice renders an invoice in the requested format
+func RenderInvoice(invoice *projection.InvoiceReadModel, format Format) ([]byte, error) {
+	if format == FormatHTML {
+		return renderHTML(invoiceTemplate, invoice)
+	}
+	return renderInvoicePDF(invoice), nil
+}
+
+func renderInvoicePDF(invoice *projection.InvoiceReadModel) []byte {
+	w := newPDFWriter()
+
+	w.heading(18, "INVOICE")
+	w.keyValue("Invoice number", invoice.Number)
+	w.keyValue("Issued", invoice.IssuedAt.Format("02/01/2006 15:04"))
+	w.keyValue("Order code", strconv.FormatInt(invoice.OrderCode, 10))
+	w.keyValue("Payment method", invoice.PaymentMethod)
+	w.space(8)
+
+	w.heading(12, "Seller")
+	writeParty(w, invoice.Seller)
+	w.space(8)
+
+	w.heading(12, "Customer")
+	writeParty(w, invoice.Buyer)
+	w.space(8)
+
+	right := pdfPageWidth - pdfMarginRight
+	columns := []pdfColumn{
+		{Title: "Description", X: pdfMarginLeft},
+		{Title: "Qty", X: right - 200, Right: true},
+		{Title: "Unit price", X: right - 110, Right: true},
+		{Title: "Amount", X: right, Right: true},
+	}
+	w.tableHeader(columns)
+	for _, line := range invoice.Lines {
+		w.tableRow(columns, []string{
+			line.Description,
+			strconv.Itoa(line.Quantity),
+			FormatAmount(line.UnitPrice),
+			FormatAmount(line.Amount),
+		}, false)
+	}
+	w.rule()
+
+	w.totalRow("Subtotal", FormatAmount(invoice.Subtotal)+" "+invoice.Currency, false)
+	if invoice.DiscountAmount > 0 {
+		label := "Discount"
+		if invoice.PromotionCode != "" {
+			label = "Discount (" + invoice.PromotionCode + ")"
+		}
+		w.totalRow(label, "-"+FormatAmount(invoice.DiscountAmount)+" "+invoice.Currency, false)
+	}
+	w.totalRow("Total", FormatAmount(invoice.TotalAmount)+" "+invoice.Currency, true)
+	if invoice.TaxRate > 0 {
+		w.totalRow("Excl. VAT", FormatAmount(invoice.NetAmount)+" "+invoice.Currency, false)
+		w.totalRow(fmt.Sprintf("VAT %d%% incl.", invoice.TaxRate), FormatAmount(invoice.TaxAmount)+" "+invoice.Currency, false)
+	}
+
+	return w.Bytes()
+}
+
+// writeParty writes the name and contact details of an invoice party
+func writeParty(w *pdfWriter, party projection.InvoicePartyReadModel) {
+	w.line(party.Name)
+	if party.Address != "" {
+		w.line(party.Address)
+	}
+	if party.Email != "" || party.Phone != "" {
+		w.line(joinNonEmpty(" | ", party.Email, party.Phone))
+	}
+}
+
+// renderHTML executes an HTML template
+func renderHTML(tmpl *template.Template, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// FormatAmount formats a VND amount with dot thousand separators, e.g. 1250000 becomes "1.250.000"
+func FormatAmount(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, digits[i])
+	}
+	return sign + string(out)
+}
+
+// joinNonEmpty joins the non-empty values with a separator
+func joinNonEmpty(sep string, values ...string) string {
+	result := ""
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if result != "" {
+			result += sep
+		}
+		result += value
+	}
+	return result
+}
+
+var templateFuncs = template.FuncMap{
+	"amount": FormatAmount,
+}
+
+// documentStyle is shared by the HTML documents
+const documentStyle = `
+<style>
+  body { font-family: Helvetica, Arial, sans-serif; color: #222; max-width: 800px; margin: 40px auto; font-size: 14px; }
+  h1 { font-size: 26px; margin-bottom: 4px; }
+  h2 { font-size: 16px; margin: 24px 0 6px; }
+  table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+  th, td { padding: 6px 8px; border-bottom: 1px solid #ddd; text-align: left; }
+  .num { text-align: right; white-space: nowrap; }
+  .meta td { border: none; padding: 2px 8px 2px 0; }
+  .totals td { border: none; }
+  .total td { font-weight: bold; border-top: 2px solid #222; }
+  .parties { display: flex; gap: 48px; }
+  .muted { color: #666; }
+</style>`
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Number}}</title>` + documentStyle + `
+</head>
+<body>
+  <h1>Invoice</h1>
+  <table class="meta">
+    <tr><td>Invoice number</td><td><strong>{{.Number}}</strong></td></tr>
+    <tr><td>Issued</td><td>{{.IssuedAt.Format "02/01/2006 15:04"}}</td></tr>
+    <tr><td>Order code</td><td>{{.OrderCode}}</td></tr>
+    <tr><td>Payment method</td><td>{{.PaymentMethod}}</td></tr>
+  </table>
+
+  <div class="parties">
+    <div>
+      <h2>Seller</h2>
+      <div>{{.Seller.Name}}</div>
+      {{with .Seller.Address}}<div>{{.}}</div>{{end}}
+      <div class="muted">{{.Seller.Email}}{{if .Seller.Phone}} | {{.Seller.Phone}}{{end}}</div>
+    </div>
+    <div>
+      <h2>Customer</h2>
+      <div>{{.Buyer.Name}}</div>
+      {{with .Buyer.Address}}<div>{{.}}</div>{{end}}
+      <div class="muted">{{.Buyer.Email}}{{if .Buyer.Phone}} | {{.Buyer.Phone}}{{end}}</div>
+    </div>
+  </div>
+
+  <table>
+    <thead>
+      <tr><th>Description</th><th class="num">Qty</th><th class="num">Unit price</th><th class="num">Amount</th></tr>
+    </thead>
+    <tbody>
+      {{range .Lines}}
+      <tr><td>{{.Description}}</td><td class="num">{{.Quantity}}</td><td class="num">{{amount .UnitPrice}}</td><td class="num">{{amount .Amount}}</td></tr>
+      {{end}}
+    </tbody>
+  </table>
+
+  <table class="totals">
+    <tr><td></td><td class="num">Subtotal</td><td class="num">{{amount .Subtotal}} {{.Currency}}</td></tr>
+    {{if gt .DiscountAmount 0}}
+    <tr><td></td><td class="num">Discount{{with .PromotionCode}} ({{.}}){{end}}</td><td class="num">-{{amount .DiscountAmount}} {{.Currency}}</td></tr>
+    {{end}}
+    <tr class="total"><td></td><td class="num">Total</td><td class="num">{{amount .TotalAmount}} {{.Currency}}</td></tr>
+    {{if gt .TaxRate 0}}
+    <tr><td></td><td class="num muted">Excl. VAT</td><td class="num muted">{{amount .NetAmount}} {{.Currency}}</td></tr>
+    <tr><td></td><td class="num muted">VAT {{.TaxRate}}% incl.</td><td class="num muted">{{amount .TaxAmount}} {{.Currency}}</td></tr>
+    {{end}}
+  </table>
+</body>
+</html>
+`))
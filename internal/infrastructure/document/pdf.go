@@ -0,0 +1,257 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// A4 page size and margins in PDF points
+const (
+	pdfPageWidth    = 595.28
+	pdfPageHeight   = 841.89
+	pdfMarginLeft   = 50.0
+	pdfMarginRight  = 50.0
+	pdfMarginTop    = 60.0
+	pdfMarginBottom = 60.0
+)
+
+// pdfWriter lays out simple text documents (headings, key/value rows and tables) using the
+// standard Helvetica fonts, so no font files need to be embedded.
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64
+}
+
+// newPDFWriter creates a writer positioned at the top of its first page
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+	return w
+}
+
+func (w *pdfWriter) newPage() {
+	w.page = &bytes.Buffer{}
+	w.pages = append(w.pages, w.page)
+	w.y = pdfPageHeight - pdfMarginTop
+}
+
+// ensureSpace starts a new page when fewer than height points are left on the current one
+func (w *pdfWriter) ensureSpace(height float64) {
+	if w.y-height < pdfMarginBottom {
+		w.newPage()
+	}
+}
+
+// text draws a string with its baseline at (x, y)
+func (w *pdfWriter) text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(w.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// textRight draws a string that ends at x
+func (w *pdfWriter) textRight(x, y, size float64, bold bool, s string) {
+	w.text(x-textWidth(s, size), y, size, bold, s)
+}
+
+// rule draws a horizontal line across the content area
+func (w *pdfWriter) rule() {
+	w.ensureSpace(10)
+	fmt.Fprintf(w.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMarginLeft, w.y, pdfPageWidth-pdfMarginRight, w.y)
+	w.y -= 14
+}
+
+// heading writes a bold line of text
+func (w *pdfWriter) heading(size float64, s string) {
+	w.ensureSpace(size + 8)
+	w.text(pdfMarginLeft, w.y, size, true, s)
+	w.y -= size + 8
+}
+
+// line writes a regular line of text
+func (w *pdfWriter) line(s string) {
+	w.ensureSpace(14)
+	w.text(pdfMarginLeft, w.y, 10, false, s)
+	w.y -= 14
+}
+
+// keyValue writes a label and its value on one line
+func (w *pdfWriter) keyValue(key, value string) {
+	w.ensureSpace(14)
+	w.text(pdfMarginLeft, w.y, 10, true, key)
+	w.text(pdfMarginLeft+130, w.y, 10, false, value)
+	w.y -= 14
+}
+
+// pdfColumn describes a table column; right-aligned columns end at their X position
+type pdfColumn struct {
+	Title string
+	X     float64
+	Right bool
+}
+
+// tableHeader writes the column titles followed by a rule
+func (w *pdfWriter) tableHeader(columns []pdfColumn) {
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+	w.tableRow(columns, titles, true)
+	w.rule()
+}
+
+// tableRow writes one table row, truncating cells that would overlap the next column
+func (w *pdfWriter) tableRow(columns []pdfColumn, cells []string, bold bool) {
+	w.ensureSpace(14)
+	for i, column := range columns {
+		if i >= len(cells) {
+			break
+		}
+		cell := cells[i]
+		if !column.Right && i+1 < len(columns) {
+			next := columns[i+1]
+			maxWidth := next.X - column.X - 10
+			if next.Right {
+				maxWidth -= 70 // Room for the right-aligned value ending at next.X
+			}
+			cell = truncateToWidth(cell, 10, maxWidth)
+		}
+		if column.Right {
+			w.textRight(column.X, w.y, 10, bold, cell)
+		} else {
+			w.text(column.X, w.y, 10, bold, cell)
+		}
+	}
+	w.y -= 14
+}
+
+// totalRow writes a right-aligned label and amount, used below tables
+func (w *pdfWriter) totalRow(label, value string, bold bool) {
+	w.ensureSpace(14)
+	right := pdfPageWidth - pdfMarginRight
+	w.textRight(right-110, w.y, 10, bold, label)
+	w.textRight(right, w.y, 10, bold, value)
+	w.y -= 14
+}
+
+// space adds vertical whitespace
+func (w *pdfWriter) space(height float64) {
+	w.y -= height
+}
+
+// Bytes assembles the PDF file
+func (w *pdfWriter) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4: catalog, page tree and fonts; then a page and a content stream per page
+	pageCount := len(w.pages)
+	kids := make([]string, pageCount)
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pageCount))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range w.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+i*2))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return out.Bytes()
+}
+
+// pdfEscape converts a string to a WinAnsi PDF string literal body. Vietnamese diacritics are
+// removed because the standard fonts cannot render them.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range foldDiacritics(s) {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// foldDiacritics strips combining marks, e.g. "Thú cưng" becomes "Thu cung"
+func foldDiacritics(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ':
+			b.WriteRune('d')
+		case r == 'Đ':
+			b.WriteRune('D')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// helveticaWidths holds glyph widths (per 1000 units) for characters that differ from the default
+var helveticaWidths = map[rune]int{
+	' ': 278, '.': 278, ',': 278, ':': 278, '-': 333, '/': 278, '(': 333, ')': 333, '%': 889, '#': 556,
+	'i': 222, 'j': 222, 'l': 222, 'f': 278, 't': 278, 'r': 333, 'm': 833, 'w': 722,
+	'I': 278, 'M': 833, 'W': 944, 'N': 722, 'D': 722, 'V': 667, 'A': 667, 'O': 778, 'Q': 778,
+}
+
+// textWidth estimates the rendered width of a string in Helvetica
+func textWidth(s string, size float64) float64 {
+	total := 0
+	for _, r := range foldDiacritics(s) {
+		if width, ok := helveticaWidths[r]; ok {
+			total += width
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// truncateToWidth shortens a string with an ellipsis so it fits within maxWidth
+func truncateToWidth(s string, size, maxWidth float64) string {
+	if maxWidth <= 0 || textWidth(s, size) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,170 @@
+package document
+
+import (
+	"html/template"
+	"time"
+)
+
+// PayoutStatement summarises what a vendor was paid and why: the bookings settled into the
+// payout, refund deductions, balances carried in and the platform commission withheld.
+type PayoutStatement struct {
+	PayoutID        string
+	SettlementID    string
+	Status          string
+	GeneratedAt     time.Time
+	PeriodStart     time.Time
+	PeriodEnd       time.Time
+	Vendor          StatementVendor
+	BankName        string
+	AccountNumber   string // Masked except for the last digits
+	AccountName     string
+	Lines           []StatementLine
+	EarningsAmount  int
+	CarriedInAmount int
+	Commission      int
+	PayoutAmount    int
+	RequestedAt     time.Time
+	CompletedAt     *time.Time
+	Currency        string
+}
+
+// StatementVendor holds the vendor details printed on a payout statement
+type StatementVendor struct {
+	ID      string
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+}
+
+// StatementLine is one booking earning or refund deduction on a payout statement
+type StatementLine struct {
+	Date        time.Time
+	Description string
+	Reference   string
+	Amount      int
+}
+
+// HasPeriod reports whether the statement covers a settlement period
+func (s *PayoutStatement) HasPeriod() bool {
+	return !s.PeriodStart.IsZero()
+}
+
+// RenderPayoutStatement renders a payout statement in the requested format
+func RenderPayoutStatement(statement *PayoutStatement, format Format) ([]byte, error) {
+	if format == FormatHTML {
+		return renderHTML(statementTemplate, statement)
+	}
+	return renderStatementPDF(statement), nil
+}
+
+func renderStatementPDF(statement *PayoutStatement) []byte {
+	w := newPDFWriter()
+
+	w.heading(18, "PAYOUT STATEMENT")
+	w.keyValue("Payout", statement.PayoutID)
+	if statement.SettlementID != "" {
+		w.keyValue("Settlement", statement.SettlementID)
+	}
+	if statement.HasPeriod() {
+		w.keyValue("Period", statement.PeriodStart.Format("02/01/2006")+" - "+statement.PeriodEnd.Format("02/01/2006"))
+	}
+	w.keyValue("Status", statement.Status)
+	w.keyValue("Requested", statement.RequestedAt.Format("02/01/2006 15:04"))
+	if statement.CompletedAt != nil {
+		w.keyValue("Completed", statement.CompletedAt.Format("02/01/2006 15:04"))
+	}
+	w.keyValue("Generated", statement.GeneratedAt.Format("02/01/2006 15:04"))
+	w.space(8)
+
+	w.heading(12, "Vendor")
+	w.line(statement.Vendor.Name)
+	if statement.Vendor.Address != "" {
+		w.line(statement.Vendor.Address)
+	}
+	if statement.Vendor.Email != "" || statement.Vendor.Phone != "" {
+		w.line(joinNonEmpty(" | ", statement.Vendor.Email, statement.Vendor.Phone))
+	}
+	if statement.BankName != "" {
+		w.line(joinNonEmpty(" - ", statement.BankName, statement.AccountNumber, statement.AccountName))
+	}
+	w.space(8)
+
+	right := pdfPageWidth - pdfMarginRight
+	columns := []pdfColumn{
+		{Title: "Date", X: pdfMarginLeft},
+		{Title: "Description", X: pdfMarginLeft + 80},
+		{Title: "Reference", X: pdfMarginLeft + 220},
+		{Title: "Amount", X: right, Right: true},
+	}
+	w.tableHeader(columns)
+	for _, line := range statement.Lines {
+		w.tableRow(columns, []string{
+			line.Date.Format("02/01/2006"),
+			line.Description,
+			line.Reference,
+			FormatAmount(line.Amount),
+		}, false)
+	}
+	w.rule()
+
+	w.totalRow("Earnings", FormatAmount(statement.EarningsAmount)+" "+statement.Currency, false)
+	if statement.CarriedInAmount != 0 {
+		w.totalRow("Carried in", FormatAmount(statement.CarriedInAmount)+" "+statement.Currency, false)
+	}
+	if statement.Commission > 0 {
+		w.totalRow("Commission", "-"+FormatAmount(statement.Commission)+" "+statement.Currency, false)
+	}
+	w.totalRow("Paid out", FormatAmount(statement.PayoutAmount)+" "+statement.Currency, true)
+
+	return w.Bytes()
+}
+
+var statementTemplate = template.Must(template.New("statement").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Payout statement {{.PayoutID}}</title>` + documentStyle + `
+</head>
+<body>
+  <h1>Payout statement</h1>
+  <table class="meta">
+    <tr><td>Payout</td><td><strong>{{.PayoutID}}</strong></td></tr>
+    {{with .SettlementID}}<tr><td>Settlement</td><td>{{.}}</td></tr>{{end}}
+    {{if .HasPeriod}}<tr><td>Period</td><td>{{.PeriodStart.Format "02/01/2006"}} - {{.PeriodEnd.Format "02/01/2006"}}</td></tr>{{end}}
+    <tr><td>Status</td><td>{{.Status}}</td></tr>
+    <tr><td>Requested</td><td>{{.RequestedAt.Format "02/01/2006 15:04"}}</td></tr>
+    {{with .CompletedAt}}<tr><td>Completed</td><td>{{.Format "02/01/2006 15:04"}}</td></tr>{{end}}
+    <tr><td>Generated</td><td>{{.GeneratedAt.Format "02/01/2006 15:04"}}</td></tr>
+  </table>
+
+  <h2>Vendor</h2>
+  <div>{{.Vendor.Name}}</div>
+  {{with .Vendor.Address}}<div>{{.}}</div>{{end}}
+  <div class="muted">{{.Vendor.Email}}{{if .Vendor.Phone}} | {{.Vendor.Phone}}{{end}}</div>
+  {{if .BankName}}<div class="muted">{{.BankName}} - {{.AccountNumber}} - {{.AccountName}}</div>{{end}}
+
+  <table>
+    <thead>
+      <tr><th>Date</th><th>Description</th><th>Reference</th><th class="num">Amount</th></tr>
+    </thead>
+    <tbody>
+      {{range .Lines}}
+      <tr><td>{{.Date.Format "02/01/2006"}}</td><td>{{.Description}}</td><td>{{.Reference}}</td><td class="num">{{amount .Amount}}</td></tr>
+      {{end}}
+    </tbody>
+  </table>
+
+  <table class="totals">
+    <tr><td></td><td class="num">Earnings</td><td class="num">{{amount .EarningsAmount}} {{.Currency}}</td></tr>
+    {{if ne .CarriedInAmount 0}}
+    <tr><td></td><td class="num">Carried in</td><td class="num">{{amount .CarriedInAmount}} {{.Currency}}</td></tr>
+    {{end}}
+    {{if gt .Commission 0}}
+    <tr><td></td><td class="num">Commission</td><td class="num">-{{amount .Commission}} {{.Currency}}</td></tr>
+    {{end}}
+    <tr class="total"><td></td><td class="num">Paid out</td><td class="num">{{amount .PayoutAmount}} {{.Currency}}</td></tr>
+  </table>
+</body>
+</html>
+`))
@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"context"
+
+	"whisko-petcare/internal/domain/aggregate"
+
+	"github.com/google/uuid"
+)
+
+// CashGateway handles payments made at the shop. Nothing is charged online: the booking is
+// confirmed straight away and the payment stays pending until the vendor marks it collected.
+type CashGateway struct{}
+
+// NewCashGateway creates a new pay at shop gateway
+func NewCashGateway() *CashGateway {
+	return &CashGateway{}
+}
+
+// Method returns the pay at shop payment method
+func (g *CashGateway) Method() aggregate.PaymentMethod {
+	return aggregate.PaymentMethodPayAtShop
+}
+
+// CreatePayment has nothing to create with a provider
+func (g *CashGateway) CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResult, error) {
+	return &CreatePaymentResult{}, nil
+}
+
+// VerifyPayment always reports pending; only the vendor can confirm a cash payment
+func (g *CashGateway) VerifyPayment(ctx context.Context, orderCode int64) (aggregate.PaymentStatus, error) {
+	return aggregate.PaymentStatusPending, nil
+}
+
+// CancelPayment has nothing to cancel with a provider
+func (g *CashGateway) CancelPayment(ctx context.Context, orderCode int64, reason string) error {
+	return nil
+}
+
+// RefundPayment records a refund the vendor hands back in cash
+func (g *CashGateway) RefundPayment(ctx context.Context, req *RefundRequest) (*RefundResult, error) {
+	refundID := req.IdempotencyKey
+	if refundID == "" {
+		refundID = uuid.New().String()
+	}
+	return &RefundResult{
+		RefundID: refundID,
+		Manual:   true,
+	}, nil
+}
+
+// ParseWebhook is not supported; cash payments have no provider notifications
+func (g *CashGateway) ParseWebhook(payload map[string]interface{}, signature string) (*WebhookNotification, error) {
+	return nil, ErrNotSupported
+}
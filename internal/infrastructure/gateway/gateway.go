@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"whisko-petcare/internal/domain/aggregate"
+)
+
+// ErrNotSupported is returned when a gateway does not support an operation
+var ErrNotSupported = errors.New("operation not supported by payment gateway")
+
+// PaymentGateway is implemented by every payment provider customers can pay through.
+// Command handlers only depend on this interface, so adding a provider means implementing
+// it and registering it in the Registry.
+type PaymentGateway interface {
+	// Method returns the payment method handled by the gateway
+	Method() aggregate.PaymentMethod
+
+	// CreatePayment starts a payment with the provider
+	CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResult, error)
+
+	// VerifyPayment asks the provider for the current status of a payment
+	VerifyPayment(ctx context.Context, orderCode int64) (aggregate.PaymentStatus, error)
+
+	// CancelPayment cancels a pending payment with the provider
+	CancelPayment(ctx context.Context, orderCode int64, reason string) error
+
+	// RefundPayment returns money for a paid payment to the customer
+	RefundPayment(ctx context.Context, req *RefundRequest) (*RefundResult, error)
+
+	// ParseWebhook validates a provider notification and extracts the payment it refers to
+	ParseWebhook(payload map[string]interface{}, signature string) (*WebhookNotification, error)
+}
+
+// CreatePaymentRequest represents a provider-independent payment creation request
+type CreatePaymentRequest struct {
+	OrderCode   int64
+	Amount      int // Amount in VND
+	Description string
+	Items       []aggregate.PaymentItem
+}
+
+// CreatePaymentResult holds what the customer needs to complete a payment
+type CreatePaymentResult struct {
+	TransactionID string // Provider reference, empty for offline payments
+	CheckoutURL   string
+	QRCode        string
+}
+
+// RefundRequest represents a refund of part or all of a paid payment. Retrying a request with the same
+// idempotency key must not refund the customer twice.
+type RefundRequest struct {
+	PaymentID      string
+	OrderCode      int64
+	Amount         int // Amount in VND
+	Reason         string
+	IdempotencyKey string // ID of the refund reserved on the payment
+}
+
+// RefundResult describes how a refund was executed
+type RefundResult struct {
+	RefundID string
+	Manual   bool // True when the money must be returned outside the provider (bank transfer, cash)
+}
+
+// WebhookNotification is a parsed provider webhook
+type WebhookNotification struct {
+	OrderCode int64
+	Verified  bool // Whether the provider signature was present and valid
+}
+
+// Registry holds the payment gateways available to the platform, keyed by payment method
+type Registry struct {
+	gateways map[aggregate.PaymentMethod]PaymentGateway
+}
+
+// NewRegistry creates a registry with the given gateways
+func NewRegistry(gateways ...PaymentGateway) *Registry {
+	registry := &Registry{
+		gateways: make(map[aggregate.PaymentMethod]PaymentGateway),
+	}
+	for _, gateway := range gateways {
+		registry.Register(gateway)
+	}
+	return registry
+}
+
+// Register adds a gateway, replacing any gateway registered for the same method
+func (r *Registry) Register(gateway PaymentGateway) {
+	r.gateways[gateway.Method()] = gateway
+}
+
+// Get returns the gateway for a payment method
+func (r *Registry) Get(method aggregate.PaymentMethod) (PaymentGateway, error) {
+	gateway, ok := r.gateways[method]
+	if !ok {
+		return nil, fmt.Errorf("no payment gateway registered for method %s", method)
+	}
+	return gateway, nil
+}
+
+// Methods returns the payment methods with a registered gateway
+func (r *Registry) Methods() []aggregate.PaymentMethod {
+	methods := make([]aggregate.PaymentMethod, 0, len(r.gateways))
+	for method := range r.gateways {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i] < methods[j] })
+	return methods
+}
@@ -0,0 +1,153 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/infrastructure/payos"
+
+	"github.com/google/uuid"
+)
+
+// PayOSGateway takes online payments through PayOS checkout links
+type PayOSGateway struct {
+	service *payos.Service
+}
+
+// NewPayOSGateway creates a new PayOS gateway
+func NewPayOSGateway(service *payos.Service) *PayOSGateway {
+	return &PayOSGateway{
+		service: service,
+	}
+}
+
+// Method returns the PayOS payment method
+func (g *PayOSGateway) Method() aggregate.PaymentMethod {
+	return aggregate.PaymentMethodPayOS
+}
+
+// CreatePayment creates a PayOS payment link
+func (g *PayOSGateway) CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResult, error) {
+	items := make([]payos.PaymentItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = payos.PaymentItem{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		}
+	}
+
+	// PayOS requires description to be max 25 characters
+	description := req.Description
+	if len(description) > 25 {
+		description = description[:25]
+	}
+
+	resp, err := g.service.CreatePaymentLink(ctx, &payos.CreatePaymentRequest{
+		OrderCode:   req.OrderCode,
+		Amount:      req.Amount,
+		Description: description,
+		Items:       items,
+		ReturnURL:   g.service.GetReturnURL(),
+		CancelURL:   g.service.GetCancelURL(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("PayOS payment creation failed: %s", resp.Desc)
+	}
+
+	return &CreatePaymentResult{
+		TransactionID: resp.Data.PaymentLinkId,
+		CheckoutURL:   resp.Data.CheckoutUrl,
+		QRCode:        resp.Data.QrCode,
+	}, nil
+}
+
+// VerifyPayment gets the payment link status from PayOS
+func (g *PayOSGateway) VerifyPayment(ctx context.Context, orderCode int64) (aggregate.PaymentStatus, error) {
+	info, err := g.service.GetPaymentLinkInformation(ctx, orderCode)
+	if err != nil {
+		return "", err
+	}
+	if !info.Success {
+		return "", fmt.Errorf("PayOS payment info request failed: %s", info.Desc)
+	}
+
+	fmt.Printf("💰 PayOS Status: %s\n", info.Data.Status)
+	return payos.GetPaymentStatus(info.Data.Status), nil
+}
+
+// CancelPayment cancels the PayOS payment link
+func (g *PayOSGateway) CancelPayment(ctx context.Context, orderCode int64, reason string) error {
+	return g.service.CancelPaymentLink(ctx, orderCode, reason)
+}
+
+// RefundPayment records a refund of a PayOS payment. PayOS has no refund API, so the money
+// is returned to the customer by bank transfer outside the platform.
+func (g *PayOSGateway) RefundPayment(ctx context.Context, req *RefundRequest) (*RefundResult, error) {
+	refundID := req.IdempotencyKey
+	if refundID == "" {
+		refundID = uuid.New().String()
+	}
+	return &RefundResult{
+		RefundID: refundID,
+		Manual:   true,
+	}, nil
+}
+
+// ParseWebhook extracts the order code from a PayOS webhook, verifying the signature when present
+func (g *PayOSGateway) ParseWebhook(payload map[string]interface{}, signature string) (*WebhookNotification, error) {
+	notification := &WebhookNotification{}
+
+	if signature != "" {
+		webhookData, err := payos.CreateWebhookDataFromMap(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook data format: %w", err)
+		}
+
+		// Verification failures are tolerated since PayOS does not always sign webhooks consistently
+		verifiedData, err := g.service.VerifyPaymentWebhookData(*webhookData)
+		if err != nil {
+			fmt.Printf("⚠️ Webhook verification failed (continuing anyway): %v\n", err)
+		} else {
+			fmt.Printf("✅ Webhook verified! Order Code: %d\n", verifiedData.OrderCode)
+			notification.Verified = true
+		}
+	} else {
+		fmt.Printf("⚠️ Webhook received without signature - PayOS may not be sending signatures\n")
+	}
+
+	// PayOS sends orderCode inside the "data" object; fall back to top-level for backward compatibility
+	if dataObj, ok := payload["data"].(map[string]interface{}); ok {
+		notification.OrderCode = parseOrderCode(dataObj["orderCode"])
+	}
+	if notification.OrderCode == 0 {
+		notification.OrderCode = parseOrderCode(payload["orderCode"])
+	}
+	if notification.OrderCode == 0 {
+		return nil, fmt.Errorf("missing or invalid orderCode in payload")
+	}
+
+	return notification, nil
+}
+
+// parseOrderCode converts a JSON order code value to int64
+func parseOrderCode(value interface{}) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case string:
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPBookingSeriesController handles HTTP requests for recurring bookings
+type HTTPBookingSeriesController struct {
+	seriesService *services.BookingSeriesService
+}
+
+// NewHTTPBookingSeriesController creates a new HTTP booking series controller
+func NewHTTPBookingSeriesController(seriesService *services.BookingSeriesService) *HTTPBookingSeriesController {
+	return &HTTPBookingSeriesController{
+		seriesService: seriesService,
+	}
+}
+
+// CreateSeries handles POST /booking-series
+func (c *HTTPBookingSeriesController) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var cmd command.CreateBookingSeries
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	result, err := c.seriesService.CreateSeries(r.Context(), cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, result)
+}
+
+// ListMySeries handles GET /booking-series?offset=0&limit=10
+func (c *HTTPBookingSeriesController) ListMySeries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		middleware.HandleError(w, r, errors.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	offset := 0
+	limit := 10 // default limit
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	series, err := c.seriesService.ListUserSeries(r.Context(), userID, offset, limit)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"series": series,
+		"offset": offset,
+		"limit":  limit,
+		"count":  len(series),
+	})
+}
+
+// GetSeries handles GET /booking-series/{seriesID}
+func (c *HTTPBookingSeriesController) GetSeries(w http.ResponseWriter, r *http.Request) {
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+
+	series, err := c.seriesService.GetSeries(r.Context(), r.PathValue("seriesID"), userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, series)
+}
+
+// SkipOccurrence handles POST /booking-series/{seriesID}/skip
+func (c *HTTPBookingSeriesController) SkipOccurrence(w http.ResponseWriter, r *http.Request) {
+	var cmd command.SkipSeriesOccurrence
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.SeriesID = r.PathValue("seriesID")
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	result, err := c.seriesService.SkipOccurrence(r.Context(), cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// ModifyOccurrence handles POST /booking-series/{seriesID}/modify
+func (c *HTTPBookingSeriesController) ModifyOccurrence(w http.ResponseWriter, r *http.Request) {
+	var cmd command.ModifySeriesOccurrence
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.SeriesID = r.PathValue("seriesID")
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	result, err := c.seriesService.ModifyOccurrence(r.Context(), cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// CancelSeries handles POST /booking-series/{seriesID}/cancel; without a body every occurrence from now is cancelled
+func (c *HTTPBookingSeriesController) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	var cmd command.CancelBookingSeries
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+			return
+		}
+	}
+	cmd.SeriesID = r.PathValue("seriesID")
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	result, err := c.seriesService.CancelSeries(r.Context(), cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/internal/infrastructure/document"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPCalendarController handles HTTP requests for calendar feeds and booking calendar downloads
+type HTTPCalendarController struct {
+	calendarService *services.CalendarService
+}
+
+// NewHTTPCalendarController creates a new HTTP calendar controller
+func NewHTTPCalendarController(calendarService *services.CalendarService) *HTTPCalendarController {
+	return &HTTPCalendarController{
+		calendarService: calendarService,
+	}
+}
+
+// CreateMyFeed handles POST /calendar-feed - creates the caller's feed, revoking the previous URL
+func (c *HTTPCalendarController) CreateMyFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := c.calendarService.CreateUserFeed(r.Context(), middleware.GetUserID(r.Context()))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, feed)
+}
+
+// GetMyFeed handles GET /calendar-feed
+func (c *HTTPCalendarController) GetMyFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := c.calendarService.GetUserFeed(r.Context(), middleware.GetUserID(r.Context()))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, feed)
+}
+
+// RevokeMyFeed handles DELETE /calendar-feed
+func (c *HTTPCalendarController) RevokeMyFeed(w http.ResponseWriter, r *http.Request) {
+	if err := c.calendarService.RevokeUserFeed(r.Context(), middleware.GetUserID(r.Context())); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// CreateVendorFeed handles POST /vendors/{vendorID}/calendar-feed - creates the shop's feed, revoking
+// the previous URL
+func (c *HTTPCalendarController) CreateVendorFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := c.calendarService.CreateVendorFeed(r.Context(), r.PathValue("vendorID"), middleware.GetUserID(r.Context()), isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, feed)
+}
+
+// GetVendorFeed handles GET /vendors/{vendorID}/calendar-feed
+func (c *HTTPCalendarController) GetVendorFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := c.calendarService.GetVendorFeed(r.Context(), r.PathValue("vendorID"), middleware.GetUserID(r.Context()), isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, feed)
+}
+
+// RevokeVendorFeed handles DELETE /vendors/{vendorID}/calendar-feed
+func (c *HTTPCalendarController) RevokeVendorFeed(w http.ResponseWriter, r *http.Request) {
+	if err := c.calendarService.RevokeVendorFeed(r.Context(), r.PathValue("vendorID"), middleware.GetUserID(r.Context()), isAdmin(r)); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// GetFeed handles GET /calendar-feeds/{token}.ics - the iCalendar feed calendar applications subscribe
+// to, available without an account
+func (c *HTTPCalendarController) GetFeed(w http.ResponseWriter, r *http.Request) {
+	content, err := c.calendarService.OpenFeed(r.Context(), r.PathValue("token"))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	sendDocument(w, document.FormatICS, "", content)
+}
+
+// GetScheduleCalendar handles GET /schedules/{id}/calendar.ics - a single booking to add to a calendar
+func (c *HTTPCalendarController) GetScheduleCalendar(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+	if len(parts) == 0 || parts[0] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Schedule ID is required"))
+		return
+	}
+
+	content, err := c.calendarService.ScheduleCalendar(r.Context(), parts[0], middleware.GetUserID(r.Context()), isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "booking-"+parts[0]+".ics"))
+	w.Header().Set("Cache-Control", "no-store")
+	sendDocument(w, document.FormatICS, "", content)
+}
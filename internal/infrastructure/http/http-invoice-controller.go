@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/internal/infrastructure/document"
+	"whisko-petcare/internal/infrastructure/mongo"
+	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPInvoiceController serves invoice and payout statement downloads
+type HTTPInvoiceController struct {
+	uowFactory        *mongo.MongoUnitOfWorkFactory
+	paymentProjection projection.PaymentProjection
+	invoiceService    *services.InvoiceService
+}
+
+// NewHTTPInvoiceController creates a new HTTP invoice controller
+func NewHTTPInvoiceController(
+	uowFactory *mongo.MongoUnitOfWorkFactory,
+	paymentProjection projection.PaymentProjection,
+	invoiceService *services.InvoiceService,
+) *HTTPInvoiceController {
+	return &HTTPInvoiceController{
+		uowFactory:        uowFactory,
+		paymentProjection: paymentProjection,
+		invoiceService:    invoiceService,
+	}
+}
+
+// DownloadInvoice handles GET /payments/{id}/invoice?format=pdf|html
+// Available to the customer who paid, staff of the vendor and admins.
+func (c *HTTPInvoiceController) DownloadInvoice(w http.ResponseWriter, r *http.Request) {
+	paymentID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/payments/"), "/invoice")
+	if paymentID == "" {
+		response.SendBadRequest(w, r, "Payment ID is required")
+		return
+	}
+
+	format, err := document.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		response.SendBadRequest(w, r, err.Error())
+		return
+	}
+
+	payment, err := c.paymentProjection.GetByID(r.Context(), paymentID)
+	if err != nil {
+		response.SendNotFound(w, r, "Payment not found")
+		return
+	}
+	if payment.Invoice == nil {
+		response.SendNotFound(w, r, "Invoice has not been issued for this payment")
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	if userID != payment.UserID && !c.canAccessVendorDocuments(r.Context(), payment.Invoice.Seller.ID) {
+		response.SendForbidden(w, r, "You do not have access to this invoice")
+		return
+	}
+
+	content, err := document.RenderInvoice(payment.Invoice, format)
+	if err != nil {
+		response.SendInternalError(w, r, "Failed to render invoice: "+err.Error())
+		return
+	}
+
+	sendDocument(w, format, payment.Invoice.Number, content)
+}
+
+// DownloadPayoutStatement handles GET /payouts/{id}/statement?format=pdf|html
+// Available to staff of the vendor and admins.
+func (c *HTTPInvoiceController) DownloadPayoutStatement(w http.ResponseWriter, r *http.Request) {
+	payoutID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/payouts/"), "/statement")
+	if payoutID == "" {
+		response.SendBadRequest(w, r, "Payout ID is required")
+		return
+	}
+
+	format, err := document.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		response.SendBadRequest(w, r, err.Error())
+		return
+	}
+
+	statement, err := c.invoiceService.BuildPayoutStatement(r.Context(), payoutID)
+	if err != nil {
+		response.SendNotFound(w, r, "Payout not found")
+		return
+	}
+
+	if !c.canAccessVendorDocuments(r.Context(), statement.Vendor.ID) {
+		response.SendForbidden(w, r, "You do not have access to this payout statement")
+		return
+	}
+
+	content, err := document.RenderPayoutStatement(statement, format)
+	if err != nil {
+		response.SendInternalError(w, r, "Failed to render payout statement: "+err.Error())
+		return
+	}
+
+	sendDocument(w, format, "payout-"+statement.PayoutID, content)
+}
+
+// canAccessVendorDocuments reports whether the caller is an admin or active staff of the vendor
+func (c *HTTPInvoiceController) canAccessVendorDocuments(ctx context.Context, vendorID string) bool {
+	return isVendorStaffOrAdmin(ctx, c.uowFactory, vendorID)
+}
+
+// sendDocument writes a rendered document; PDFs are sent as attachments
+func sendDocument(w http.ResponseWriter, format document.Format, name string, content []byte) {
+	w.Header().Set("Content-Type", format.ContentType())
+	if format == document.FormatPDF {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".pdf"))
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
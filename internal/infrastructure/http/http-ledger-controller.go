@@ -0,0 +1,162 @@
+package http
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPLedgerController handles HTTP requests for the platform ledger (Admin only)
+type HTTPLedgerController struct {
+	ledgerService *services.LedgerService
+}
+
+// NewHTTPLedgerController creates a new HTTP ledger controller
+func NewHTTPLedgerController(ledgerService *services.LedgerService) *HTTPLedgerController {
+	return &HTTPLedgerController{
+		ledgerService: ledgerService,
+	}
+}
+
+// GetBalances handles GET /admin/ledger/balances
+func (c *HTTPLedgerController) GetBalances(w http.ResponseWriter, r *http.Request) {
+	balances, err := c.ledgerService.GetAccountBalances(r.Context())
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to get ledger balances: %v", err)))
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"balances": balances,
+	})
+}
+
+// GetVendorBalance handles GET /admin/ledger/vendors/{vendorID}/balance
+func (c *HTTPLedgerController) GetVendorBalance(w http.ResponseWriter, r *http.Request) {
+	vendorID := extractVendorIDFromPath(r.URL.Path, "/admin/ledger/vendors/", "/balance")
+	if vendorID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("vendor ID is required"))
+		return
+	}
+
+	balance, err := c.ledgerService.GetVendorBalance(r.Context(), vendorID)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to get vendor balance: %v", err)))
+		return
+	}
+
+	response.SendSuccess(w, r, balance)
+}
+
+// CheckBalance handles GET /admin/ledger/check - verifies that the ledger balances
+func (c *HTTPLedgerController) CheckBalance(w http.ResponseWriter, r *http.Request) {
+	result, err := c.ledgerService.CheckBalance(r.Context())
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to check ledger: %v", err)))
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// ListEntries handles GET /admin/ledger/entries?from_date=&to_date=&offset=&limit=
+func (c *HTTPLedgerController) ListEntries(w http.ResponseWriter, r *http.Request) {
+	fromDate, toDate, err := parseDateRange(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	offset := 0
+	limit := 50
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 500 {
+			limit = parsed
+		}
+	}
+
+	entries, err := c.ledgerService.ListEntries(r.Context(), fromDate, toDate, offset, limit)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to list ledger entries: %v", err)))
+		return
+	}
+
+	results := []map[string]interface{}{}
+	for _, entry := range entries {
+		results = append(results, ledgerEntryToResponse(entry))
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"entries": results,
+		"offset":  offset,
+		"limit":   limit,
+		"count":   len(results),
+	})
+}
+
+// ExportEntries handles GET /admin/ledger/export?from_date=&to_date= - one CSV row per posting
+func (c *HTTPLedgerController) ExportEntries(w http.ResponseWriter, r *http.Request) {
+	fromDate, toDate, err := parseDateRange(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	entries, err := c.ledgerService.ExportEntries(r.Context(), fromDate, toDate)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to export ledger: %v", err)))
+		return
+	}
+
+	filename := fmt.Sprintf("ledger_%s_%s.csv", fromDate.Format("20060102"), toDate.Format("20060102"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"entry_id", "occurred_at", "entry_type", "reference", "description", "account", "vendor_id", "debit", "credit"})
+	for _, entry := range entries {
+		for _, posting := range entry.Postings() {
+			writer.Write([]string{
+				entry.ID(),
+				entry.OccurredAt().Format(time.RFC3339),
+				string(entry.EntryType()),
+				entry.Reference(),
+				entry.Description(),
+				string(posting.Account),
+				posting.VendorID,
+				strconv.Itoa(posting.Debit),
+				strconv.Itoa(posting.Credit),
+			})
+		}
+	}
+	writer.Flush()
+}
+
+// ledgerEntryToResponse converts a ledger entry to its API representation
+func ledgerEntryToResponse(entry *aggregate.LedgerEntry) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          entry.ID(),
+		"entryType":   entry.EntryType(),
+		"reference":   entry.Reference(),
+		"description": entry.Description(),
+		"postings":    entry.Postings(),
+		"totalDebit":  entry.TotalDebit(),
+		"totalCredit": entry.TotalCredit(),
+		"occurredAt":  entry.OccurredAt(),
+		"createdAt":   entry.CreatedAt(),
+	}
+}
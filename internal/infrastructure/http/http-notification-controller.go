@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPNotificationController handles HTTP requests for notification feeds
+type HTTPNotificationController struct {
+	notificationService *services.NotificationService
+}
+
+// NewHTTPNotificationController creates a new HTTP notification controller
+func NewHTTPNotificationController(notificationService *services.NotificationService) *HTTPNotificationController {
+	return &HTTPNotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// ListMyNotifications handles GET /notifications?unread=true&offset=0&limit=20
+func (c *HTTPNotificationController) ListMyNotifications(w http.ResponseWriter, r *http.Request) {
+	unreadOnly, offset, limit := parseNotificationListOptions(r)
+
+	feed, err := c.notificationService.ListUserNotifications(r.Context(), middleware.GetUserID(r.Context()), unreadOnly, offset, limit)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, feed)
+}
+
+// MarkMyNotificationRead handles POST /notifications/{notificationID}/read
+func (c *HTTPNotificationController) MarkMyNotificationRead(w http.ResponseWriter, r *http.Request) {
+	err := c.notificationService.MarkUserNotificationRead(r.Context(), middleware.GetUserID(r.Context()), r.PathValue("notificationID"))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Notification marked as read",
+	})
+}
+
+// ListVendorNotifications handles GET /vendors/{vendorID}/notifications?unread=true&offset=0&limit=20
+func (c *HTTPNotificationController) ListVendorNotifications(w http.ResponseWriter, r *http.Request) {
+	unreadOnly, offset, limit := parseNotificationListOptions(r)
+
+	feed, err := c.notificationService.ListVendorNotifications(r.Context(), r.PathValue("vendorID"),
+		middleware.GetUserID(r.Context()), isAdmin(r), unreadOnly, offset, limit)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, feed)
+}
+
+// MarkVendorNotificationRead handles POST /vendors/{vendorID}/notifications/{notificationID}/read
+func (c *HTTPNotificationController) MarkVendorNotificationRead(w http.ResponseWriter, r *http.Request) {
+	err := c.notificationService.MarkVendorNotificationRead(r.Context(), r.PathValue("vendorID"),
+		middleware.GetUserID(r.Context()), isAdmin(r), r.PathValue("notificationID"))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Notification marked as read",
+	})
+}
+
+// parseNotificationListOptions reads the unread filter and page of a notification feed request
+func parseNotificationListOptions(r *http.Request) (bool, int, int) {
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	offset := 0
+	limit := 20 // default limit
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	return unreadOnly, offset, limit
+}
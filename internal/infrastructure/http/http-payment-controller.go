@@ -10,8 +10,10 @@ import (
 
 	"whisko-petcare/internal/application/command"
 	"whisko-petcare/internal/application/query"
-	"whisko-petcare/internal/infrastructure/payos"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/infrastructure/gateway"
 	"whisko-petcare/internal/infrastructure/projection"
+	"whisko-petcare/pkg/middleware"
 	"whisko-petcare/pkg/response"
 )
 
@@ -28,15 +30,25 @@ type ConfirmPaymentHandlerInterface interface {
 	Handle(ctx context.Context, cmd *command.ConfirmPaymentCommand) error
 }
 
+type MarkPaymentCollectedHandlerInterface interface {
+	Handle(ctx context.Context, cmd *command.MarkPaymentCollectedCommand) error
+}
+
+type RefundPaymentHandlerInterface interface {
+	Handle(ctx context.Context, cmd *command.RefundPaymentCommand) (*command.RefundPaymentResponse, error)
+}
+
 // HTTPPaymentController handles HTTP requests for payment operations
 type HTTPPaymentController struct {
 	createPaymentHandler         CreatePaymentHandlerInterface
 	cancelPaymentHandler         CancelPaymentHandlerInterface
 	confirmPaymentHandler        ConfirmPaymentHandlerInterface
+	collectPaymentHandler        MarkPaymentCollectedHandlerInterface
+	refundPaymentHandler         RefundPaymentHandlerInterface
 	getPaymentHandler            *query.GetPaymentHandler
 	getPaymentByOrderCodeHandler *query.GetPaymentByOrderCodeHandler
 	listUserPaymentsHandler      *query.ListUserPaymentsHandler
-	payOSService                 *payos.Service
+	gateways                     *gateway.Registry
 }
 
 // NewHTTPPaymentController creates a new HTTP payment controller
@@ -44,19 +56,23 @@ func NewHTTPPaymentController(
 	createPaymentHandler CreatePaymentHandlerInterface,
 	cancelPaymentHandler CancelPaymentHandlerInterface,
 	confirmPaymentHandler ConfirmPaymentHandlerInterface,
+	collectPaymentHandler MarkPaymentCollectedHandlerInterface,
+	refundPaymentHandler RefundPaymentHandlerInterface,
 	getPaymentHandler *query.GetPaymentHandler,
 	getPaymentByOrderCodeHandler *query.GetPaymentByOrderCodeHandler,
 	listUserPaymentsHandler *query.ListUserPaymentsHandler,
-	payOSService *payos.Service,
+	gateways *gateway.Registry,
 ) *HTTPPaymentController {
 	return &HTTPPaymentController{
 		createPaymentHandler:         createPaymentHandler,
 		cancelPaymentHandler:         cancelPaymentHandler,
 		confirmPaymentHandler:        confirmPaymentHandler,
+		collectPaymentHandler:        collectPaymentHandler,
+		refundPaymentHandler:         refundPaymentHandler,
 		getPaymentHandler:            getPaymentHandler,
 		getPaymentByOrderCodeHandler: getPaymentByOrderCodeHandler,
 		listUserPaymentsHandler:      listUserPaymentsHandler,
-		payOSService:                 payOSService,
+		gateways:                     gateways,
 	}
 }
 
@@ -286,79 +302,23 @@ func (c *HTTPPaymentController) WebhookHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Check for signature (optional for now since PayOS doesn't always send it)
-	signature := r.Header.Get("x-signature")
-	if signature != "" {
-		fmt.Printf("✅ Signature present: %s...\n", signature[:20])
-		
-		// Convert to PayOS webhook type for verification
-		webhookData, err := payos.CreateWebhookDataFromMap(webhookPayload)
-		if err != nil {
-			fmt.Printf("❌ Webhook rejected: Invalid data format - %v\n", err)
-			response.SendBadRequest(w, r, "Invalid webhook data format")
-			return
-		}
-
-		// Verify webhook data using PayOS SDK
-		verifiedData, err := c.payOSService.VerifyPaymentWebhookData(*webhookData)
-		if err != nil {
-			fmt.Printf("⚠️ Webhook verification failed (continuing anyway): %v\n", err)
-		} else {
-			fmt.Printf("✅ Webhook verified! Order Code: %d\n", verifiedData.OrderCode)
-		}
-	} else {
-		fmt.Printf("⚠️ Webhook received without signature - PayOS may not be sending signatures\n")
-		fmt.Printf("   Processing webhook anyway based on payload data...\n")
+	// Verify the signature and extract the order code through the PayOS gateway
+	payOSGateway, err := c.gateways.Get(aggregate.PaymentMethodPayOS)
+	if err != nil {
+		fmt.Printf("❌ Webhook rejected: %v\n", err)
+		response.SendInternalError(w, r, "PayOS gateway is not configured")
+		return
 	}
 
-	// Extract order code from payload
-	// PayOS sends orderCode inside the "data" object
-	var orderCode int64
-	
-	// First, try to get data object
-	if dataObj, ok := webhookPayload["data"].(map[string]interface{}); ok {
-		// orderCode is inside data
-		if orderCodeVal, exists := dataObj["orderCode"]; exists {
-			switch v := orderCodeVal.(type) {
-			case float64:
-				orderCode = int64(v)
-			case int64:
-				orderCode = v
-			case int:
-				orderCode = int64(v)
-			case string:
-				if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-					orderCode = parsed
-				}
-			}
-		}
-	}
-	
-	// Fallback: try top-level orderCode (for backward compatibility)
-	if orderCode == 0 {
-		if orderCodeVal, ok := webhookPayload["orderCode"]; ok {
-			switch v := orderCodeVal.(type) {
-			case float64:
-				orderCode = int64(v)
-			case int64:
-				orderCode = v
-			case int:
-				orderCode = int64(v)
-			case string:
-				if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-					orderCode = parsed
-				}
-			}
-		}
-	}
-	
-	if orderCode == 0 {
-		fmt.Printf("❌ Webhook rejected: Missing or invalid orderCode in payload\n")
+	notification, err := payOSGateway.ParseWebhook(webhookPayload, r.Header.Get("x-signature"))
+	if err != nil {
+		fmt.Printf("❌ Webhook rejected: %v\n", err)
 		fmt.Printf("   Payload structure: %+v\n", webhookPayload)
 		response.SendBadRequest(w, r, "Missing orderCode")
 		return
 	}
-	
+	orderCode := notification.OrderCode
+
 	fmt.Printf("📋 Processing webhook for Order Code: %d\n", orderCode)
 
 	// Process the webhook
@@ -367,7 +327,7 @@ func (c *HTTPPaymentController) WebhookHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	fmt.Printf("🔄 Processing webhook for order: %d\n", orderCode)
-	err := c.confirmPaymentHandler.Handle(r.Context(), cmd)
+	err = c.confirmPaymentHandler.Handle(r.Context(), cmd)
 	if err != nil {
 		fmt.Printf("❌ Webhook processing failed: %v\n", err)
 		fmt.Printf("========================================\n")
@@ -393,6 +353,66 @@ func (c *HTTPPaymentController) WebhookHandler(w http.ResponseWriter, r *http.Re
 	response.SendSuccess(w, r, nil)
 }
 
+// CollectPayment handles POST /payments/{id}/collect - vendor staff confirm a pay at shop payment was collected
+func (c *HTTPPaymentController) CollectPayment(w http.ResponseWriter, r *http.Request) {
+	paymentID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/payments/"), "/collect")
+	if paymentID == "" {
+		response.SendBadRequest(w, r, "Payment ID is required")
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		response.SendUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	cmd := &command.MarkPaymentCollectedCommand{
+		PaymentID:   paymentID,
+		CollectedBy: userID,
+	}
+
+	if err := c.collectPaymentHandler.Handle(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message":    "Payment marked as collected",
+		"payment_id": paymentID,
+	})
+}
+
+// RefundPayment handles POST /payments/{id}/refund - refund part or all of a paid payment (Admin only)
+func (c *HTTPPaymentController) RefundPayment(w http.ResponseWriter, r *http.Request) {
+	paymentID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/payments/"), "/refund")
+	if paymentID == "" {
+		response.SendBadRequest(w, r, "Payment ID is required")
+		return
+	}
+
+	var req struct {
+		Amount int    `json:"amount"` // Omit to refund the remaining amount
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	result, err := c.refundPaymentHandler.Handle(r.Context(), &command.RefundPaymentCommand{
+		PaymentID: paymentID,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
 // ReturnHandler handles PayOS return URL
 func (c *HTTPPaymentController) ReturnHandler(w http.ResponseWriter, r *http.Request) {
 	orderCode := r.URL.Query().Get("orderCode")
@@ -527,6 +547,10 @@ func (c *HTTPPaymentController) paymentToResponse(payment *projection.PaymentRea
 		"checkout_url":         payment.CheckoutURL,
 		"qr_code":              payment.QRCode,
 		"expired_at":           payment.ExpiredAt.Format("2006-01-02T15:04:05Z07:00"),
+		"refunded_amount":      payment.RefundedAmount,
+		"collected_by":         payment.CollectedBy,
+		"promotion_code":       payment.PromotionCode,
+		"discount_amount":      payment.DiscountAmount,
 		"created_at":           payment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		"updated_at":           payment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
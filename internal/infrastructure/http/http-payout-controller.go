@@ -203,6 +203,8 @@ func (c *HTTPPayoutController) GetPayoutByID(w http.ResponseWriter, r *http.Requ
 		"vendorName":      vendor.Name(),
 		"scheduleId":      payout.ScheduleID(),
 		"paymentId":       payout.PaymentID(),
+		"settlementId":    payout.SettlementID(),
+		"lineItems":       payout.LineItems(),
 		"amount":          payout.Amount(),
 		"status":          payout.Status(),
 		"notes":           payout.Notes(),
@@ -244,14 +246,15 @@ func (c *HTTPPayoutController) ListPayoutsByVendor(w http.ResponseWriter, r *htt
 	var results []map[string]interface{}
 	for _, payout := range payouts {
 		results = append(results, map[string]interface{}{
-			"id":         payout.ID(),
-			"vendorId":   payout.VendorID(),
-			"scheduleId": payout.ScheduleID(),
-			"paymentId":  payout.PaymentID(),
-			"amount":     payout.Amount(),
-			"status":     payout.Status(),
-			"notes":      payout.Notes(),
-			"createdAt":  payout.CreatedAt(),
+			"id":           payout.ID(),
+			"vendorId":     payout.VendorID(),
+			"scheduleId":   payout.ScheduleID(),
+			"paymentId":    payout.PaymentID(),
+			"settlementId": payout.SettlementID(),
+			"amount":       payout.Amount(),
+			"status":       payout.Status(),
+			"notes":        payout.Notes(),
+			"createdAt":    payout.CreatedAt(),
 		})
 	}
 
@@ -300,14 +303,15 @@ func (c *HTTPPayoutController) ListPayoutsByStatus(w http.ResponseWriter, r *htt
 	var results []map[string]interface{}
 	for _, payout := range payouts {
 		results = append(results, map[string]interface{}{
-			"id":         payout.ID(),
-			"vendorId":   payout.VendorID(),
-			"scheduleId": payout.ScheduleID(),
-			"paymentId":  payout.PaymentID(),
-			"amount":     payout.Amount(),
-			"status":     payout.Status(),
-			"notes":      payout.Notes(),
-			"createdAt":  payout.CreatedAt(),
+			"id":           payout.ID(),
+			"vendorId":     payout.VendorID(),
+			"scheduleId":   payout.ScheduleID(),
+			"paymentId":    payout.PaymentID(),
+			"settlementId": payout.SettlementID(),
+			"amount":       payout.Amount(),
+			"status":       payout.Status(),
+			"notes":        payout.Notes(),
+			"createdAt":    payout.CreatedAt(),
 		})
 	}
 
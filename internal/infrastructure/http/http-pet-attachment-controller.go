@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPPetAttachmentController handles HTTP requests for pet document attachments
+type HTTPPetAttachmentController struct {
+	attachmentService *services.PetAttachmentService
+}
+
+// NewHTTPPetAttachmentController creates a new HTTP pet attachment controller
+func NewHTTPPetAttachmentController(attachmentService *services.PetAttachmentService) *HTTPPetAttachmentController {
+	return &HTTPPetAttachmentController{
+		attachmentService: attachmentService,
+	}
+}
+
+// ListAttachments handles GET /pets/{id}/attachments?record_id=
+func (c *HTTPPetAttachmentController) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	attachments, err := c.attachmentService.List(r.Context(), parts[0], r.URL.Query().Get("record_id"), userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, attachments)
+}
+
+// UploadAttachment handles POST /pets/{id}/attachments as multipart/form-data with a "file" field and
+// optional "category", "title", "record_type" and "record_id" fields
+func (c *HTTPPetAttachmentController) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		middleware.HandleError(w, r, errors.NewValidationError("Attachments must be uploaded as multipart/form-data"))
+		return
+	}
+
+	// Leave room for the form fields around the file
+	r.Body = http.MaxBytesReader(w, r.Body, services.MaxAttachmentSize+(1<<20))
+	if err := r.ParseMultipartForm(services.MaxAttachmentSize); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Failed to parse form; attachments cannot be larger than 10 MB"))
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("File is required"))
+		return
+	}
+	defer file.Close()
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	req := services.UploadAttachmentRequest{
+		PetID:      parts[0],
+		Category:   r.FormValue("category"),
+		Title:      r.FormValue("title"),
+		RecordType: r.FormValue("record_type"),
+		RecordID:   r.FormValue("record_id"),
+		FileName:   fileHeader.Filename,
+		Size:       fileHeader.Size,
+		UploadedBy: userID,
+	}
+
+	attachment, err := c.attachmentService.Upload(r.Context(), req, file)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, attachment)
+}
+
+// RemoveAttachment handles DELETE /pets/{id}/attachments/{attachment_id}
+func (c *HTTPPetAttachmentController) RemoveAttachment(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+	if len(parts) < 3 || parts[2] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Attachment ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.RemovePetAttachment{
+		PetID:        parts[0],
+		AttachmentID: parts[2],
+		RemovedBy:    userID,
+	}
+	if err := c.attachmentService.Remove(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Attachment removed successfully",
+	})
+}
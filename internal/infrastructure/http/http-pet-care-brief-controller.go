@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPPetCareBriefController handles HTTP requests for pet care notes and the care briefs shared with booked shops
+type HTTPPetCareBriefController struct {
+	careBriefService *services.PetCareBriefService
+}
+
+// NewHTTPPetCareBriefController creates a new HTTP pet care brief controller
+func NewHTTPPetCareBriefController(careBriefService *services.PetCareBriefService) *HTTPPetCareBriefController {
+	return &HTTPPetCareBriefController{
+		careBriefService: careBriefService,
+	}
+}
+
+// UpdateCareNotes handles PUT /pets/{id}/care-notes
+func (c *HTTPPetCareBriefController) UpdateCareNotes(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	var cmd command.UpdatePetCareNotes
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.PetID = parts[0]
+	cmd.UpdatedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.careBriefService.UpdateCareNotes(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Care notes updated successfully",
+	})
+}
+
+// GetCareBrief handles GET /schedules/{id}/care-brief?pet_id={petId}
+func (c *HTTPPetCareBriefController) GetCareBrief(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	brief, err := c.careBriefService.GetCareBrief(r.Context(), parts[0], r.URL.Query().Get("pet_id"), services.CareBriefAccess{
+		UserID:    userID,
+		IsAdmin:   isAdmin(r),
+		IPAddress: middleware.GetClientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, brief)
+}
+
+// ShareCareBrief handles POST /schedules/{id}/care-brief/consent?pet_id={petId}
+func (c *HTTPPetCareBriefController) ShareCareBrief(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	cmd := command.ShareScheduleCareBrief{ScheduleID: parts[0], PetID: r.URL.Query().Get("pet_id")}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.careBriefService.ShareCareBrief(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Care brief shared with the shop for this booking",
+	})
+}
+
+// RevokeCareBrief handles DELETE /schedules/{id}/care-brief/consent?pet_id={petId}
+func (c *HTTPPetCareBriefController) RevokeCareBrief(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	cmd := command.RevokeScheduleCareBrief{ScheduleID: parts[0], PetID: r.URL.Query().Get("pet_id")}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.careBriefService.RevokeCareBrief(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Care brief is no longer shared with the shop",
+	})
+}
+
+// ListAccesses handles GET /schedules/{id}/care-brief/accesses
+func (c *HTTPPetCareBriefController) ListAccesses(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	accesses, err := c.careBriefService.ListAccesses(r.Context(), parts[0], userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, accesses)
+}
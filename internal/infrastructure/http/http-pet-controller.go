@@ -32,9 +32,10 @@ func NewHTTPPetController(petService *services.PetService, cloudinary *cloudinar
 
 // CreatePet handles POST /pets - supports both JSON and multipart/form-data with image
 func (c *HTTPPetController) CreatePet(w http.ResponseWriter, r *http.Request) {
-	var userID, name, species, breed, imageUrl string
+	var userID, name, species, breed, imageUrl, birthDateStr string
 	var age int
 	var weight float64
+	var birthDateEstimated bool
 	petID := fmt.Sprintf("pet_%d", time.Now().UnixNano())
 
 	// Check if multipart form (with image file)
@@ -56,6 +57,8 @@ func (c *HTTPPetController) CreatePet(w http.ResponseWriter, r *http.Request) {
 		if weightStr := r.FormValue("weight"); weightStr != "" {
 			weight, _ = strconv.ParseFloat(weightStr, 64)
 		}
+		birthDateStr = r.FormValue("birth_date")
+		birthDateEstimated, _ = strconv.ParseBool(r.FormValue("birth_date_estimated"))
 
 		// Check if image file is provided
 		file, fileHeader, err := r.FormFile("image")
@@ -85,6 +88,9 @@ func (c *HTTPPetController) CreatePet(w http.ResponseWriter, r *http.Request) {
 			Age      int     `json:"age,omitempty"`
 			Weight   float64 `json:"weight,omitempty"`
 			ImageUrl string  `json:"image_url,omitempty"`
+
+			BirthDate          string `json:"birth_date,omitempty"`
+			BirthDateEstimated bool   `json:"birth_date_estimated,omitempty"`
 		}
 
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -99,16 +105,26 @@ func (c *HTTPPetController) CreatePet(w http.ResponseWriter, r *http.Request) {
 		age = req.Age
 		weight = req.Weight
 		imageUrl = req.ImageUrl
+		birthDateStr = req.BirthDate
+		birthDateEstimated = req.BirthDateEstimated
+	}
+
+	birthDate, err := parseBirthDate(birthDateStr)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
 	}
 
 	cmd := command.CreatePet{
-		UserID:   userID,
-		Name:     name,
-		Species:  species,
-		Breed:    breed,
-		Age:      age,
-		Weight:   weight,
-		ImageUrl: imageUrl,
+		UserID:             userID,
+		Name:               name,
+		Species:            species,
+		Breed:              breed,
+		Age:                age,
+		BirthDate:          birthDate,
+		BirthDateEstimated: birthDateEstimated,
+		Weight:             weight,
+		ImageUrl:           imageUrl,
 	}
 
 	if err := c.petService.CreatePet(r.Context(), cmd); err != nil {
@@ -143,7 +159,8 @@ func (c *HTTPPetController) GetPet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.SendSuccess(w, r, pet)
+	// Voided health records are only listed by the health history endpoint
+	response.SendSuccess(w, r, pet.WithoutVoidedRecords())
 }
 
 // ListPets handles GET /pets
@@ -172,6 +189,9 @@ func (c *HTTPPetController) ListPets(w http.ResponseWriter, r *http.Request) {
 		middleware.HandleError(w, r, err)
 		return
 	}
+	for i, pet := range pets {
+		pets[i] = pet.WithoutVoidedRecords()
+	}
 
 	responseData := map[string]interface{}{
 		"pets":   pets,
@@ -218,6 +238,9 @@ func (c *HTTPPetController) ListUserPets(w http.ResponseWriter, r *http.Request)
 		middleware.HandleError(w, r, err)
 		return
 	}
+	for i, pet := range pets {
+		pets[i] = pet.WithoutVoidedRecords()
+	}
 
 	responseData := map[string]interface{}{
 		"pets":   pets,
@@ -246,6 +269,9 @@ func (c *HTTPPetController) UpdatePet(w http.ResponseWriter, r *http.Request) {
 		Breed   string  `json:"breed,omitempty"`
 		Age     int     `json:"age,omitempty"`
 		Weight  float64 `json:"weight,omitempty"`
+
+		BirthDate          string `json:"birth_date,omitempty"`
+		BirthDateEstimated bool   `json:"birth_date_estimated,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -253,13 +279,21 @@ func (c *HTTPPetController) UpdatePet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	birthDate, err := parseBirthDate(req.BirthDate)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
 	cmd := command.UpdatePet{
-		PetID:   petID,
-		Name:    req.Name,
-		Species: req.Species,
-		Breed:   req.Breed,
-		Age:     req.Age,
-		Weight:  req.Weight,
+		PetID:              petID,
+		Name:               req.Name,
+		Species:            req.Species,
+		Breed:              req.Breed,
+		Age:                req.Age,
+		BirthDate:          birthDate,
+		BirthDateEstimated: req.BirthDateEstimated,
+		Weight:             req.Weight,
 	}
 
 	if err := c.petService.UpdatePet(r.Context(), cmd); err != nil {
@@ -576,3 +610,370 @@ func (c *HTTPPetController) UpdatePetImage(w http.ResponseWriter, r *http.Reques
 		"image_url": imageUrl,
 	})
 }
+
+// UpdatePetVaccination handles PUT /pets/{id}/vaccinations/{record_id}
+func (c *HTTPPetController) UpdatePetVaccination(w http.ResponseWriter, r *http.Request) {
+	petID, recordID, ok := extractPetRecordIDs(r.URL.Path)
+	if !ok {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID and Record ID are required"))
+		return
+	}
+
+	var req struct {
+		VaccineName  string `json:"vaccine_name"`
+		Date         string `json:"date"`
+		NextDueDate  string `json:"next_due_date,omitempty"`
+		Veterinarian string `json:"veterinarian,omitempty"`
+		Notes        string `json:"notes,omitempty"`
+		Reason       string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	date, err := time.Parse(time.RFC3339, req.Date)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid date format, use RFC3339"))
+		return
+	}
+
+	var nextDueDate time.Time
+	if req.NextDueDate != "" {
+		nextDueDate, err = time.Parse(time.RFC3339, req.NextDueDate)
+		if err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid next_due_date format, use RFC3339"))
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.UpdatePetVaccination{
+		PetID:        petID,
+		RecordID:     recordID,
+		VaccineName:  req.VaccineName,
+		Date:         date,
+		NextDueDate:  nextDueDate,
+		Veterinarian: req.Veterinarian,
+		Notes:        req.Notes,
+		Reason:       req.Reason,
+		ChangedBy:    userID,
+	}
+
+	if err := c.petService.UpdatePetVaccination(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Vaccination record updated successfully",
+	})
+}
+
+// VoidPetVaccination handles POST /pets/{id}/vaccinations/{record_id}/void
+func (c *HTTPPetController) VoidPetVaccination(w http.ResponseWriter, r *http.Request) {
+	petID, recordID, ok := extractPetRecordIDs(r.URL.Path)
+	if !ok {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID and Record ID are required"))
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.VoidPetVaccination{
+		PetID:    petID,
+		RecordID: recordID,
+		Reason:   req.Reason,
+		VoidedBy: userID,
+	}
+
+	if err := c.petService.VoidPetVaccination(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Vaccination record voided successfully",
+	})
+}
+
+// UpdatePetMedicalRecord handles PUT /pets/{id}/medical-records/{record_id}
+func (c *HTTPPetController) UpdatePetMedicalRecord(w http.ResponseWriter, r *http.Request) {
+	petID, recordID, ok := extractPetRecordIDs(r.URL.Path)
+	if !ok {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID and Record ID are required"))
+		return
+	}
+
+	var req struct {
+		Date         string `json:"date"`
+		Description  string `json:"description"`
+		Treatment    string `json:"treatment,omitempty"`
+		Veterinarian string `json:"veterinarian,omitempty"`
+		Diagnosis    string `json:"diagnosis,omitempty"`
+		Notes        string `json:"notes,omitempty"`
+		Reason       string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	date, err := time.Parse(time.RFC3339, req.Date)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid date format, use RFC3339"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.UpdatePetMedicalRecord{
+		PetID:        petID,
+		RecordID:     recordID,
+		Date:         date,
+		Description:  req.Description,
+		Treatment:    req.Treatment,
+		Veterinarian: req.Veterinarian,
+		Diagnosis:    req.Diagnosis,
+		Notes:        req.Notes,
+		Reason:       req.Reason,
+		ChangedBy:    userID,
+	}
+
+	if err := c.petService.UpdatePetMedicalRecord(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Medical record updated successfully",
+	})
+}
+
+// VoidPetMedicalRecord handles POST /pets/{id}/medical-records/{record_id}/void
+func (c *HTTPPetController) VoidPetMedicalRecord(w http.ResponseWriter, r *http.Request) {
+	petID, recordID, ok := extractPetRecordIDs(r.URL.Path)
+	if !ok {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID and Record ID are required"))
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.VoidPetMedicalRecord{
+		PetID:    petID,
+		RecordID: recordID,
+		Reason:   req.Reason,
+		VoidedBy: userID,
+	}
+
+	if err := c.petService.VoidPetMedicalRecord(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Medical record voided successfully",
+	})
+}
+
+// UpdatePetAllergy handles PUT /pets/{id}/allergies/{allergy_id}
+func (c *HTTPPetController) UpdatePetAllergy(w http.ResponseWriter, r *http.Request) {
+	petID, allergyID, ok := extractPetRecordIDs(r.URL.Path)
+	if !ok {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID and Allergy ID are required"))
+		return
+	}
+
+	var req struct {
+		Allergen      string `json:"allergen"`
+		Severity      string `json:"severity"`
+		Symptoms      string `json:"symptoms,omitempty"`
+		DiagnosedDate string `json:"diagnosed_date,omitempty"`
+		Notes         string `json:"notes,omitempty"`
+		Reason        string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	var diagnosedDate time.Time
+	if req.DiagnosedDate != "" {
+		var err error
+		diagnosedDate, err = time.Parse(time.RFC3339, req.DiagnosedDate)
+		if err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid diagnosed_date format, use RFC3339"))
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.UpdatePetAllergy{
+		PetID:         petID,
+		AllergyID:     allergyID,
+		Allergen:      req.Allergen,
+		Severity:      req.Severity,
+		Symptoms:      req.Symptoms,
+		DiagnosedDate: diagnosedDate,
+		Notes:         req.Notes,
+		Reason:        req.Reason,
+		ChangedBy:     userID,
+	}
+
+	if err := c.petService.UpdatePetAllergy(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Allergy updated successfully",
+	})
+}
+
+// GetPetHealthHistory handles GET /pets/{id}/health/history - the full health record including voided
+// entries and the log of corrections (guardians only)
+func (c *HTTPPetController) GetPetHealthHistory(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+
+	if petID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID is required"))
+		return
+	}
+
+	pet, err := c.petService.GetPetHealthHistory(r.Context(), petID, middleware.GetUserID(r.Context()), isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"pet_id":              pet.ID,
+		"vaccination_records": pet.VaccinationRecords,
+		"medical_history":     pet.MedicalHistory,
+		"allergies":           pet.Allergies,
+		"changes":             pet.HealthRecordChanges,
+	})
+}
+
+// extractPetRecordIDs extracts the pet ID and health record ID from /pets/{id}/{resource}/{record_id}[/...]
+func extractPetRecordIDs(urlPath string) (string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(urlPath, "/pets/"), "/")
+	if len(parts) < 3 || parts[0] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[2], true
+}
+
+// GetPetWeights handles GET /pets/{id}/weights?since=RFC3339 (guardians only)
+func (c *HTTPPetController) GetPetWeights(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+
+	if petID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID is required"))
+		return
+	}
+
+	var since time.Time
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid since format, use RFC3339"))
+			return
+		}
+	}
+
+	history, err := c.petService.GetPetWeights(r.Context(), petID, middleware.GetUserID(r.Context()), isAdmin(r), since)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, history)
+}
+
+// RecordPetWeight handles POST /pets/{id}/weights
+func (c *HTTPPetController) RecordPetWeight(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+
+	if petID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID is required"))
+		return
+	}
+
+	var req struct {
+		Weight     float64 `json:"weight"`
+		MeasuredAt string  `json:"measured_at,omitempty"`
+		Source     string  `json:"source,omitempty"`
+		Notes      string  `json:"notes,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	var measuredAt time.Time
+	if req.MeasuredAt != "" {
+		var err error
+		measuredAt, err = time.Parse(time.RFC3339, req.MeasuredAt)
+		if err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid measured_at format, use RFC3339"))
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.RecordPetWeight{
+		PetID:      petID,
+		Weight:     req.Weight,
+		MeasuredAt: measuredAt,
+		Source:     strings.ToUpper(req.Source),
+		Notes:      req.Notes,
+		RecordedBy: userID,
+	}
+
+	if err := c.petService.RecordPetWeight(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]interface{}{
+		"message": "Weight recorded successfully",
+	})
+}
+
+// parseBirthDate parses an optional birth date given as YYYY-MM-DD or RFC3339
+func parseBirthDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if birthDate, err := time.Parse("2006-01-02", value); err == nil {
+		return birthDate, nil
+	}
+	birthDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError("Invalid birth_date format, use YYYY-MM-DD or RFC3339")
+	}
+	return birthDate, nil
+}
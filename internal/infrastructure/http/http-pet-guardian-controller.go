@@ -0,0 +1,213 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPPetGuardianController handles HTTP requests for pet guardians and ownership transfers
+type HTTPPetGuardianController struct {
+	guardianService *services.PetGuardianService
+}
+
+// NewHTTPPetGuardianController creates a new HTTP pet guardian controller
+func NewHTTPPetGuardianController(guardianService *services.PetGuardianService) *HTTPPetGuardianController {
+	return &HTTPPetGuardianController{
+		guardianService: guardianService,
+	}
+}
+
+// GetGuardians handles GET /pets/{id}/guardians
+func (c *HTTPPetGuardianController) GetGuardians(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	guardianship, err := c.guardianService.GetGuardianship(r.Context(), parts[0], userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, guardianship)
+}
+
+// InviteGuardian handles POST /pets/{id}/guardians/invitations
+func (c *HTTPPetGuardianController) InviteGuardian(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	var cmd command.InvitePetGuardian
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.PetID = parts[0]
+	cmd.Role = strings.ToUpper(strings.TrimSpace(cmd.Role))
+	cmd.InvitedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.guardianService.InviteGuardian(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]string{
+		"message": "Guardian invited successfully",
+	})
+}
+
+// AcceptInvitation handles POST /pets/{id}/guardians/invitations/{invitation_id}/accept
+func (c *HTTPPetGuardianController) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+	if len(parts) < 4 || parts[3] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Invitation ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.AcceptPetGuardianInvitation{
+		PetID:        parts[0],
+		InvitationID: parts[3],
+		UserID:       userID,
+	}
+	if err := c.guardianService.AcceptInvitation(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Invitation accepted successfully",
+	})
+}
+
+// DeclineInvitation handles POST /pets/{id}/guardians/invitations/{invitation_id}/decline and, for owners
+// cancelling an invitation, DELETE /pets/{id}/guardians/invitations/{invitation_id}
+func (c *HTTPPetGuardianController) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+	if len(parts) < 4 || parts[3] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Invitation ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.DeclinePetGuardianInvitation{
+		PetID:        parts[0],
+		InvitationID: parts[3],
+		UserID:       userID,
+	}
+	if err := c.guardianService.DeclineInvitation(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Invitation closed successfully",
+	})
+}
+
+// RemoveGuardian handles DELETE /pets/{id}/guardians/{user_id}
+func (c *HTTPPetGuardianController) RemoveGuardian(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+	if len(parts) < 3 || parts[2] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Guardian user ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd := command.RemovePetGuardian{
+		PetID:          parts[0],
+		GuardianUserID: parts[2],
+		RemovedBy:      userID,
+	}
+	if err := c.guardianService.RemoveGuardian(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Guardian removed successfully",
+	})
+}
+
+// RequestTransfer handles POST /pets/{id}/transfer
+func (c *HTTPPetGuardianController) RequestTransfer(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	var cmd command.RequestPetOwnershipTransfer
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.PetID = parts[0]
+	cmd.RequestedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.guardianService.RequestTransfer(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]string{
+		"message": "Ownership transfer requested successfully",
+	})
+}
+
+// AcceptTransfer handles POST /pets/{id}/transfer/accept
+func (c *HTTPPetGuardianController) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	if err := c.guardianService.AcceptTransfer(r.Context(), command.AcceptPetOwnershipTransfer{PetID: parts[0], UserID: userID}); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Ownership transfer accepted successfully",
+	})
+}
+
+// DeclineTransfer handles POST /pets/{id}/transfer/decline and, for the owner cancelling the transfer,
+// DELETE /pets/{id}/transfer
+func (c *HTTPPetGuardianController) DeclineTransfer(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	if err := c.guardianService.DeclineTransfer(r.Context(), command.DeclinePetOwnershipTransfer{PetID: parts[0], UserID: userID}); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Ownership transfer closed successfully",
+	})
+}
+
+// ListPendingForUser handles GET /users/{id}/pet-invitations
+func (c *HTTPPetGuardianController) ListPendingForUser(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/users/")
+	userID := strings.Split(path, "/")[0]
+
+	requesterID, _ := middleware.GetUserIDFromContext(r.Context())
+	if requesterID != userID && !isAdmin(r) {
+		middleware.HandleError(w, r, errors.NewForbiddenError("You can only see your own invitations"))
+		return
+	}
+
+	pending, err := c.guardianService.PendingForUser(r.Context(), userID)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, pending)
+}
+
+// petPathParts splits /pets/{id}/... into its segments
+func petPathParts(r *http.Request) []string {
+	return strings.Split(strings.TrimPrefix(r.URL.Path, "/pets/"), "/")
+}
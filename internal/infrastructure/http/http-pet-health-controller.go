@@ -0,0 +1,239 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/infrastructure/document"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// defaultUpcomingCareDays is how far ahead upcoming care is listed when no days parameter is given
+const defaultUpcomingCareDays = 60
+
+// HTTPPetHealthController handles HTTP requests for pet health reminders, exports and share links
+type HTTPPetHealthController struct {
+	reminderService *services.VaccinationReminderService
+	shareService    *services.PetHealthShareService
+}
+
+// NewHTTPPetHealthController creates a new HTTP pet health controller
+func NewHTTPPetHealthController(reminderService *services.VaccinationReminderService, shareService *services.PetHealthShareService) *HTTPPetHealthController {
+	return &HTTPPetHealthController{
+		reminderService: reminderService,
+		shareService:    shareService,
+	}
+}
+
+// GetUpcomingCare handles GET /pets/{id}/health/upcoming?days=60
+func (c *HTTPPetHealthController) GetUpcomingCare(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+	if petID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID is required"))
+		return
+	}
+
+	days, err := parseUpcomingCareDays(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	items, err := c.reminderService.UpcomingForPet(r.Context(), petID, middleware.GetUserID(r.Context()), isAdmin(r), days)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"pet_id":      petID,
+		"within_days": days,
+		"items":       items,
+		"count":       len(items),
+	})
+}
+
+// GetUserCareDigest handles GET /users/{userID}/health/upcoming?days=60
+func (c *HTTPPetHealthController) GetUserCareDigest(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/users/")
+	userID := strings.Split(path, "/")[0]
+	if userID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("User ID is required"))
+		return
+	}
+
+	days, err := parseUpcomingCareDays(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	digest, err := c.reminderService.DigestForUser(r.Context(), userID, middleware.GetUserID(r.Context()), isAdmin(r), days)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, digest)
+}
+
+// ExportHealthRecord handles GET /pets/{id}/health/export?format=json|pdf|html
+func (c *HTTPPetHealthController) ExportHealthRecord(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+
+	format, err := document.ParseHealthRecordFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError(err.Error()))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	record, err := c.shareService.ExportHealthRecord(r.Context(), petID, userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	sendHealthRecord(w, r, record, format)
+}
+
+// CreateShareLink handles POST /pets/{id}/health/share-links
+func (c *HTTPPetHealthController) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+
+	var body struct {
+		ExpiresInHours int    `json:"expires_in_hours"`
+		Recipient      string `json:"recipient"`
+		Note           string `json:"note"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	link, err := c.shareService.CreateShareLink(r.Context(), services.CreateShareLinkRequest{
+		PetID:     petID,
+		CreatedBy: userID,
+		TTL:       time.Duration(body.ExpiresInHours) * time.Hour,
+		Recipient: body.Recipient,
+		Note:      body.Note,
+	}, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, link)
+}
+
+// ListShareLinks handles GET /pets/{id}/health/share-links
+func (c *HTTPPetHealthController) ListShareLinks(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/pets/")
+	petID := strings.Split(path, "/")[0]
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	links, err := c.shareService.ListShareLinks(r.Context(), petID, userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"pet_id": petID,
+		"links":  links,
+		"count":  len(links),
+	})
+}
+
+// RevokeShareLink handles DELETE /pets/{id}/health/share-links/{link_id}
+func (c *HTTPPetHealthController) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/pets/"), "/")
+	if len(parts) < 4 || parts[3] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Share link ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	if err := c.shareService.RevokeShareLink(r.Context(), parts[0], parts[3], userID, isAdmin(r)); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Share link revoked successfully",
+	})
+}
+
+// GetSharedHealthRecord handles GET /shared/health/{token}?format=json|pdf|html - the read-only view
+// of a health record behind a share link, available without an account
+func (c *HTTPPetHealthController) GetSharedHealthRecord(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.URL.Path, "/shared/health/")
+
+	format, err := document.ParseHealthRecordFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError(err.Error()))
+		return
+	}
+
+	record, err := c.shareService.OpenSharedRecord(r.Context(), token, services.ShareLinkAccess{
+		Format:    string(format),
+		IPAddress: middleware.GetClientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	sendHealthRecord(w, r, record, format)
+}
+
+// sendHealthRecord renders a health record; JSON and PDF exports are sent as attachments
+func sendHealthRecord(w http.ResponseWriter, r *http.Request, record *document.PetHealthRecord, format document.Format) {
+	content, err := document.RenderPetHealthRecord(record, format)
+	if err != nil {
+		response.SendInternalError(w, r, "Failed to render health record: "+err.Error())
+		return
+	}
+
+	name := "health-record-" + record.Pet.ID
+	if format == document.FormatJSON {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".json"))
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	sendDocument(w, format, name, content)
+}
+
+// isAdmin reports whether the caller is an admin
+func isAdmin(r *http.Request) bool {
+	role, ok := middleware.GetUserRole(r.Context())
+	return ok && role == aggregate.RoleAdmin
+}
+
+// parseUpcomingCareDays reads the days query parameter, capped at one year
+func parseUpcomingCareDays(r *http.Request) (int, error) {
+	daysStr := r.URL.Query().Get("days")
+	if daysStr == "" {
+		return defaultUpcomingCareDays, nil
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 0 || days > 365 {
+		return 0, errors.NewValidationError("days must be between 0 and 365")
+	}
+	return days, nil
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPPetMedicationController handles HTTP requests for pet medication plans and dose logs
+type HTTPPetMedicationController struct {
+	medicationService *services.PetMedicationService
+}
+
+// NewHTTPPetMedicationController creates a new HTTP pet medication controller
+func NewHTTPPetMedicationController(medicationService *services.PetMedicationService) *HTTPPetMedicationController {
+	return &HTTPPetMedicationController{
+		medicationService: medicationService,
+	}
+}
+
+// ListPlans handles GET /pets/{id}/medications?include_inactive=true
+func (c *HTTPPetMedicationController) ListPlans(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	includeInactive := r.URL.Query().Get("include_inactive") == "true"
+	medications, err := c.medicationService.ListPlans(r.Context(), parts[0], userID, isAdmin(r), includeInactive)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, medications)
+}
+
+// AddPlan handles POST /pets/{id}/medications
+func (c *HTTPPetMedicationController) AddPlan(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+
+	var cmd command.AddPetMedicationPlan
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.PetID = parts[0]
+	cmd.CreatedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.medicationService.AddPlan(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]string{
+		"message": "Medication plan added successfully",
+	})
+}
+
+// StopPlan handles POST /pets/{id}/medications/{plan_id}/stop
+func (c *HTTPPetMedicationController) StopPlan(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+	if len(parts) < 3 || parts[2] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Plan ID is required"))
+		return
+	}
+
+	var cmd command.StopPetMedicationPlan
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+			return
+		}
+	}
+	cmd.PetID = parts[0]
+	cmd.PlanID = parts[2]
+	cmd.StoppedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.medicationService.StopPlan(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Medication plan stopped successfully",
+	})
+}
+
+// LogDose handles POST /pets/{id}/medications/{plan_id}/doses
+func (c *HTTPPetMedicationController) LogDose(w http.ResponseWriter, r *http.Request) {
+	parts := petPathParts(r)
+	if len(parts) < 3 || parts[2] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Plan ID is required"))
+		return
+	}
+
+	var cmd command.LogPetMedicationDose
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.PetID = parts[0]
+	cmd.PlanID = parts[2]
+	cmd.RecordedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	c.logDose(w, r, cmd)
+}
+
+// GetScheduleMedications handles GET /schedules/{id}/pet-medications?pet_id={petId}
+func (c *HTTPPetMedicationController) GetScheduleMedications(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	medications, err := c.medicationService.PlansForSchedule(r.Context(), parts[0], r.URL.Query().Get("pet_id"), userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, medications)
+}
+
+// LogScheduleDose handles POST /schedules/{id}/pet-medications/{plan_id}/doses?pet_id={petId}, used by
+// vendor staff to log the doses they give during a booking
+func (c *HTTPPetMedicationController) LogScheduleDose(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+	if len(parts) < 3 || parts[2] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Plan ID is required"))
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	medications, err := c.medicationService.PlansForSchedule(r.Context(), parts[0], r.URL.Query().Get("pet_id"), userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	var cmd command.LogPetMedicationDose
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.PetID = medications.PetID
+	cmd.PlanID = parts[2]
+	cmd.RecordedBy = userID
+	cmd.ScheduleID = parts[0]
+
+	c.logDose(w, r, cmd)
+}
+
+// logDose logs a dose and sends the response
+func (c *HTTPPetMedicationController) logDose(w http.ResponseWriter, r *http.Request, cmd command.LogPetMedicationDose) {
+	if err := c.medicationService.LogDose(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]string{
+		"message": "Dose logged successfully",
+	})
+}
+
+// schedulePathParts splits /schedules/{id}/... into its segments
+func schedulePathParts(r *http.Request) []string {
+	return strings.Split(strings.TrimPrefix(r.URL.Path, "/schedules/"), "/")
+}
@@ -0,0 +1,276 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/infrastructure/mongo"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPPromotionController handles HTTP requests for promotions and coupon codes
+type HTTPPromotionController struct {
+	uowFactory                 *mongo.MongoUnitOfWorkFactory
+	createPromotionHandler     *command.CreatePromotionWithUoWHandler
+	deactivatePromotionHandler *command.DeactivatePromotionWithUoWHandler
+}
+
+// NewHTTPPromotionController creates a new HTTP promotion controller
+func NewHTTPPromotionController(
+	uowFactory *mongo.MongoUnitOfWorkFactory,
+	createPromotionHandler *command.CreatePromotionWithUoWHandler,
+	deactivatePromotionHandler *command.DeactivatePromotionWithUoWHandler,
+) *HTTPPromotionController {
+	return &HTTPPromotionController{
+		uowFactory:                 uowFactory,
+		createPromotionHandler:     createPromotionHandler,
+		deactivatePromotionHandler: deactivatePromotionHandler,
+	}
+}
+
+// CreatePromotion handles POST /admin/promotions
+func (c *HTTPPromotionController) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var cmd command.CreatePromotion
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		response.SendBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	cmd.CreatedBy = userID
+
+	result, err := c.createPromotionHandler.Handle(r.Context(), &cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, result)
+}
+
+// CreateVendorPromotion handles POST /vendors/{vendorID}/promotions - vendor staff create a discount they fund
+func (c *HTTPPromotionController) CreateVendorPromotion(w http.ResponseWriter, r *http.Request) {
+	vendorID := extractVendorIDFromPath(r.URL.Path, "/vendors/", "/promotions")
+	if vendorID == "" {
+		response.SendBadRequest(w, r, "Vendor ID is required")
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		response.SendUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var cmd command.CreatePromotion
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		response.SendBadRequest(w, r, "Invalid request body")
+		return
+	}
+	cmd.VendorID = vendorID
+	cmd.CreatedBy = userID
+	cmd.CreatedByVendor = true
+
+	result, err := c.createPromotionHandler.Handle(r.Context(), &cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, result)
+}
+
+// ListPromotions handles GET /admin/promotions?offset=&limit=
+func (c *HTTPPromotionController) ListPromotions(w http.ResponseWriter, r *http.Request) {
+	offset := 0
+	limit := 20
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	uow := c.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	promotions, err := uow.PromotionRepository().List(r.Context(), offset, limit)
+	if err != nil {
+		response.SendInternalError(w, r, "Failed to get promotions: "+err.Error())
+		return
+	}
+
+	results := []map[string]interface{}{}
+	for _, promotion := range promotions {
+		results = append(results, promotionToResponse(promotion))
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"promotions": results,
+		"offset":     offset,
+		"limit":      limit,
+		"count":      len(results),
+	})
+}
+
+// GetPromotion handles GET /admin/promotions/{id}
+func (c *HTTPPromotionController) GetPromotion(w http.ResponseWriter, r *http.Request) {
+	promotionID := strings.TrimPrefix(r.URL.Path, "/admin/promotions/")
+	if promotionID == "" {
+		response.SendBadRequest(w, r, "Promotion ID is required")
+		return
+	}
+
+	uow := c.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	promotion, err := uow.PromotionRepository().GetByID(r.Context(), promotionID)
+	if err != nil {
+		response.SendNotFound(w, r, "Promotion not found")
+		return
+	}
+
+	result := promotionToResponse(promotion)
+	result["redemptions"] = promotion.Redemptions()
+	response.SendSuccess(w, r, result)
+}
+
+// DeactivatePromotion handles PUT /admin/promotions/{id}/deactivate
+func (c *HTTPPromotionController) DeactivatePromotion(w http.ResponseWriter, r *http.Request) {
+	promotionID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/admin/promotions/"), "/deactivate")
+	if promotionID == "" {
+		response.SendBadRequest(w, r, "Promotion ID is required")
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// Reason is optional, so an empty body is fine
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	cmd := &command.DeactivatePromotion{
+		PromotionID: promotionID,
+		Reason:      req.Reason,
+	}
+	if err := c.deactivatePromotionHandler.Handle(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message":      "Promotion deactivated",
+		"promotion_id": promotionID,
+	})
+}
+
+// ValidatePromotion handles POST /promotions/validate - previews the discount a coupon gives a booking
+func (c *HTTPPromotionController) ValidatePromotion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok || userID == "" {
+		response.SendUnauthorized(w, r, "Authentication required")
+		return
+	}
+
+	var req struct {
+		Code       string   `json:"code"`
+		VendorID   string   `json:"vendor_id"`
+		ServiceIDs []string `json:"service_ids"`
+		Amount     int      `json:"amount"`
+		Method     string   `json:"method,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.SendBadRequest(w, r, "Invalid request body")
+		return
+	}
+	if req.Code == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("code is required"))
+		return
+	}
+	if req.Amount <= 0 {
+		middleware.HandleError(w, r, errors.NewValidationError("amount must be greater than 0"))
+		return
+	}
+
+	uow := c.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	promotion, err := uow.PromotionRepository().GetByCode(r.Context(), req.Code)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to get promotion: %v", err)))
+		return
+	}
+	if promotion == nil {
+		middleware.HandleError(w, r, errors.NewValidationError(fmt.Sprintf("invalid coupon code: %s", req.Code)))
+		return
+	}
+
+	if promotion.FundedBy() == aggregate.PromotionFundedByPlatform && aggregate.PaymentMethod(strings.ToUpper(req.Method)).IsCollectedByVendor() {
+		middleware.HandleError(w, r, errors.NewValidationError(fmt.Sprintf("coupon %s cannot be used with payment method %s", promotion.Code(), req.Method)))
+		return
+	}
+
+	firstBooking := true
+	if promotion.FirstBookingOnly() {
+		hasPaid, err := uow.PaymentRepository().HasPaidPayment(r.Context(), userID)
+		if err != nil {
+			middleware.HandleError(w, r, errors.NewInternalError(fmt.Sprintf("failed to check booking history: %v", err)))
+			return
+		}
+		firstBooking = !hasPaid
+	}
+
+	discount, err := promotion.CheckEligibility(userID, req.VendorID, req.ServiceIDs, req.Amount, time.Now(), firstBooking)
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError(fmt.Sprintf("coupon %s cannot be applied: %v", promotion.Code(), err)))
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"code":            promotion.Code(),
+		"name":            promotion.Terms().Name,
+		"discount_amount": discount,
+		"final_amount":    req.Amount - discount,
+		"funded_by":       promotion.FundedBy(),
+	})
+}
+
+// promotionToResponse converts a promotion aggregate to its API representation
+func promotionToResponse(promotion *aggregate.Promotion) map[string]interface{} {
+	terms := promotion.Terms()
+	return map[string]interface{}{
+		"id":               promotion.ID(),
+		"code":             terms.Code,
+		"name":             terms.Name,
+		"description":      terms.Description,
+		"discountType":     terms.DiscountType,
+		"discountValue":    terms.DiscountValue,
+		"maxDiscount":      terms.MaxDiscount,
+		"minOrderAmount":   terms.MinOrderAmount,
+		"fundedBy":         terms.FundedBy,
+		"vendorId":         terms.VendorID,
+		"serviceIds":       terms.ServiceIDs,
+		"firstBookingOnly": terms.FirstBookingOnly,
+		"startsAt":         terms.StartsAt,
+		"endsAt":           terms.EndsAt,
+		"usageLimit":       terms.UsageLimit,
+		"perUserLimit":     terms.PerUserLimit,
+		"usageCount":       promotion.ActiveRedemptionCount(),
+		"status":           promotion.Status(),
+		"createdBy":        promotion.CreatedBy(),
+		"createdAt":        promotion.CreatedAt(),
+		"updatedAt":        promotion.UpdatedAt(),
+	}
+}
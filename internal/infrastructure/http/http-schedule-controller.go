@@ -2,11 +2,14 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/query"
 	"whisko-petcare/internal/application/services"
 	"whisko-petcare/pkg/errors"
 	"whisko-petcare/pkg/middleware"
@@ -63,22 +66,24 @@ func (c *ScheduleController) GetSchedule(w http.ResponseWriter, r *http.Request)
 	response.SendSuccess(w, r, schedule)
 }
 
-// ListSchedules handles GET /schedules
+// ListSchedules handles GET /schedules with the filters of parseScheduleListOptions
 func (c *ScheduleController) ListSchedules(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	opts, err := parseScheduleListOptions(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
 
-	schedules, err := c.service.ListSchedules(r.Context(), offset, limit)
+	page, err := c.service.ListSchedules(r.Context(), &query.ListSchedulesQuery{ScheduleListOptions: opts})
 	if err != nil {
 		middleware.HandleError(w, r, err)
 		return
 	}
 
-	response.SendSuccess(w, r, schedules)
+	sendSchedulePage(w, r, page)
 }
 
-// ListUserSchedules handles GET /users/{userID}/schedules
+// ListUserSchedules handles GET /users/{userID}/schedules with the filters of parseScheduleListOptions
 func (c *ScheduleController) ListUserSchedules(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from URL path
 	path := strings.TrimPrefix(r.URL.Path, "/users/")
@@ -89,25 +94,22 @@ func (c *ScheduleController) ListUserSchedules(w http.ResponseWriter, r *http.Re
 	}
 	userID := parts[0]
 
-	// Parse query parameters
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-
-	// Debug log
-	println("📥 ListUserSchedules - Received request for userID:", userID, "offset:", offset, "limit:", limit)
+	opts, err := parseScheduleListOptions(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
 
-	schedules, err := c.service.ListUserSchedules(r.Context(), userID, offset, limit)
+	page, err := c.service.ListUserSchedules(r.Context(), &query.ListUserSchedulesQuery{UserID: userID, ScheduleListOptions: opts})
 	if err != nil {
-		println("❌ ListUserSchedules - Error:", err.Error())
 		middleware.HandleError(w, r, err)
 		return
 	}
 
-	println("📤 ListUserSchedules - Returning schedules, count:", len(schedules))
-	response.SendSuccess(w, r, schedules)
+	sendSchedulePage(w, r, page)
 }
 
-// ListShopSchedules handles GET /vendors/{shopID}/schedules
+// ListShopSchedules handles GET /vendors/{shopID}/schedules with the filters of parseScheduleListOptions
 func (c *ScheduleController) ListShopSchedules(w http.ResponseWriter, r *http.Request) {
 	// Extract shop ID from URL path
 	path := strings.TrimPrefix(r.URL.Path, "/vendors/")
@@ -118,23 +120,25 @@ func (c *ScheduleController) ListShopSchedules(w http.ResponseWriter, r *http.Re
 	}
 	shopID := parts[0]
 
-	// Parse query parameters
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	opts, err := parseScheduleListOptions(r)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
 
-	schedules, err := c.service.ListShopSchedules(r.Context(), shopID, offset, limit)
+	page, err := c.service.ListShopSchedules(r.Context(), &query.ListShopSchedulesQuery{ShopID: shopID, ScheduleListOptions: opts})
 	if err != nil {
 		middleware.HandleError(w, r, err)
 		return
 	}
 
-	response.SendSuccess(w, r, schedules)
+	sendSchedulePage(w, r, page)
 }
 
 // ChangeScheduleStatus handles PUT /schedules/{id}/status
 func (c *ScheduleController) ChangeScheduleStatus(w http.ResponseWriter, r *http.Request) {
 	// Extract schedule ID from path
-	scheduleID := r.PathValue("id")
+	scheduleID := schedulePathParts(r)[0]
 	if scheduleID == "" {
 		middleware.HandleError(w, r, errors.NewValidationError("Schedule ID is required"))
 		return
@@ -142,6 +146,7 @@ func (c *ScheduleController) ChangeScheduleStatus(w http.ResponseWriter, r *http
 
 	var req struct {
 		Status string `json:"status"`
+		Reason string `json:"reason,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -152,7 +157,10 @@ func (c *ScheduleController) ChangeScheduleStatus(w http.ResponseWriter, r *http
 	cmd := command.ChangeScheduleStatus{
 		ScheduleID: scheduleID,
 		Status:     req.Status,
+		Reason:     req.Reason,
+		IsAdmin:    isAdmin(r),
 	}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.service.ChangeScheduleStatus(r.Context(), &cmd); err != nil {
 		middleware.HandleError(w, r, err)
@@ -168,7 +176,7 @@ func (c *ScheduleController) ChangeScheduleStatus(w http.ResponseWriter, r *http
 // CompleteSchedule handles POST /schedules/{id}/complete
 func (c *ScheduleController) CompleteSchedule(w http.ResponseWriter, r *http.Request) {
 	// Extract schedule ID from path
-	scheduleID := r.PathValue("id")
+	scheduleID := schedulePathParts(r)[0]
 	if scheduleID == "" {
 		middleware.HandleError(w, r, errors.NewValidationError("Schedule ID is required"))
 		return
@@ -176,7 +184,9 @@ func (c *ScheduleController) CompleteSchedule(w http.ResponseWriter, r *http.Req
 
 	cmd := &command.CompleteSchedule{
 		ScheduleID: scheduleID,
+		IsAdmin:    isAdmin(r),
 	}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.service.CompleteSchedule(r.Context(), cmd); err != nil {
 		middleware.HandleError(w, r, err)
@@ -192,7 +202,7 @@ func (c *ScheduleController) CompleteSchedule(w http.ResponseWriter, r *http.Req
 // CancelSchedule handles POST /schedules/{id}/cancel
 func (c *ScheduleController) CancelSchedule(w http.ResponseWriter, r *http.Request) {
 	// Extract schedule ID from path
-	scheduleID := r.PathValue("id")
+	scheduleID := schedulePathParts(r)[0]
 	if scheduleID == "" {
 		middleware.HandleError(w, r, errors.NewValidationError("Schedule ID is required"))
 		return
@@ -210,7 +220,9 @@ func (c *ScheduleController) CancelSchedule(w http.ResponseWriter, r *http.Reque
 	cmd := command.CancelSchedule{
 		ScheduleID: scheduleID,
 		Reason:     req.Reason,
+		IsAdmin:    isAdmin(r),
 	}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
 
 	if err := c.service.CancelSchedule(r.Context(), &cmd); err != nil {
 		middleware.HandleError(w, r, err)
@@ -222,3 +234,271 @@ func (c *ScheduleController) CancelSchedule(w http.ResponseWriter, r *http.Reque
 	}
 	response.SendSuccess(w, r, responseData)
 }
+
+// CancelSchedulePet handles POST /schedules/{id}/pets/{petID}/cancel
+func (c *ScheduleController) CancelSchedulePet(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+	if len(parts) < 3 || parts[2] == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Pet ID is required"))
+		return
+	}
+
+	var cmd command.CancelSchedulePet
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.ScheduleID = parts[0]
+	cmd.PetID = parts[2]
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	result, err := c.service.CancelSchedulePet(r.Context(), &cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// SubmitVisitReport handles POST /schedules/{id}/visit-report
+func (c *ScheduleController) SubmitVisitReport(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	var cmd command.SubmitVisitReport
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.ScheduleID = parts[0]
+	cmd.SubmittedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.service.SubmitVisitReport(r.Context(), &cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Visit report submitted and schedule completed",
+	})
+}
+
+// AcceptVisitReport handles POST /schedules/{id}/visit-report/accept
+func (c *ScheduleController) AcceptVisitReport(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	cmd := command.AcceptVisitReport{ScheduleID: parts[0]}
+	cmd.AcceptedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.service.AcceptVisitReport(r.Context(), &cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Visit report added to the pet's medical history",
+	})
+}
+
+// RescheduleSchedule handles POST /schedules/{id}/reschedule
+func (c *ScheduleController) RescheduleSchedule(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	var cmd command.RescheduleSchedule
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.ScheduleID = parts[0]
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	result, err := c.service.RescheduleSchedule(r.Context(), &cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// ProposeReschedule handles POST /schedules/{id}/reschedule-proposal
+func (c *ScheduleController) ProposeReschedule(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	var cmd command.ProposeScheduleReschedule
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.ScheduleID = parts[0]
+	cmd.ProposedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.service.ProposeReschedule(r.Context(), &cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "New time proposed to the customer",
+	})
+}
+
+// RespondRescheduleProposal handles POST /schedules/{id}/reschedule-proposal/respond
+func (c *ScheduleController) RespondRescheduleProposal(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	var cmd command.RespondRescheduleProposal
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.ScheduleID = parts[0]
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	result, err := c.service.RespondRescheduleProposal(r.Context(), &cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// AssignStaff handles POST /schedules/{id}/assign-staff
+func (c *ScheduleController) AssignStaff(w http.ResponseWriter, r *http.Request) {
+	parts := schedulePathParts(r)
+
+	// An empty body assigns the free staff member with the fewest bookings
+	var cmd command.AssignScheduleStaff
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+			return
+		}
+	}
+	cmd.ScheduleID = parts[0]
+	cmd.AssignedBy, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	result, err := c.service.AssignStaff(r.Context(), &cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// ListStaffSchedules handles GET /vendor-staffs/{userID}/schedules?from=&to=
+func (c *ScheduleController) ListStaffSchedules(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/vendor-staffs/")
+	staffID := strings.Split(path, "/")[0]
+	if staffID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Staff ID is required"))
+		return
+	}
+
+	c.listStaffSchedules(w, r, staffID)
+}
+
+// ListMySchedules handles GET /vendor-staff/schedules?from=&to=, the bookings assigned to the current user
+func (c *ScheduleController) ListMySchedules(w http.ResponseWriter, r *http.Request) {
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+	c.listStaffSchedules(w, r, userID)
+}
+
+func (c *ScheduleController) listStaffSchedules(w http.ResponseWriter, r *http.Request, staffID string) {
+	from, err := parseCalendarTime(r.URL.Query().Get("from"))
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid from format, use RFC3339 or YYYY-MM-DD"))
+		return
+	}
+	to, err := parseCalendarTime(r.URL.Query().Get("to"))
+	if err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid to format, use RFC3339 or YYYY-MM-DD"))
+		return
+	}
+
+	requesterID, _ := middleware.GetUserIDFromContext(r.Context())
+	schedules, err := c.service.ListStaffSchedules(r.Context(), staffID, requesterID, isAdmin(r), from, to)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, schedules)
+}
+
+// parseScheduleListOptions reads the filters, order and page of a schedule list:
+// ?from=&to=&status=confirmed,pending&pet_id=&service_id=&staff_id=&q=&sort=-start_time&cursor=&limit=10
+// Older clients page with offset, which is still accepted as long as no cursor is passed with it. A missing
+// limit or limit=0 gets the default page size.
+func parseScheduleListOptions(r *http.Request) (query.ScheduleListOptions, error) {
+	values := r.URL.Query()
+	if values.Has("offset") && values.Get("cursor") != "" {
+		return query.ScheduleListOptions{}, errors.NewValidationError(
+			"pass either offset or cursor, not both; follow next_cursor to get the next page")
+	}
+
+	opts := query.ScheduleListOptions{
+		PetID:     values.Get("pet_id"),
+		ServiceID: values.Get("service_id"),
+		StaffID:   values.Get("staff_id"),
+		Search:    values.Get("q"),
+		Sort:      values.Get("sort"),
+		Cursor:    values.Get("cursor"),
+	}
+
+	var err error
+	if opts.From, err = parseCalendarTime(values.Get("from")); err != nil {
+		return opts, errors.NewValidationError("Invalid from format, use RFC3339 or YYYY-MM-DD")
+	}
+	if opts.To, err = parseCalendarTime(values.Get("to")); err != nil {
+		return opts, errors.NewValidationError("Invalid to format, use RFC3339 or YYYY-MM-DD")
+	}
+
+	for _, value := range values["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				opts.Statuses = append(opts.Statuses, status)
+			}
+		}
+	}
+
+	if offsetStr := values.Get("offset"); offsetStr != "" {
+		if opts.Offset, err = strconv.Atoi(offsetStr); err != nil || opts.Offset < 0 {
+			return opts, errors.NewValidationError("offset must be a non-negative number")
+		}
+	}
+	if limitStr := values.Get("limit"); limitStr != "" {
+		if opts.Limit, err = strconv.Atoi(limitStr); err != nil || opts.Limit < 0 {
+			return opts, errors.NewValidationError(fmt.Sprintf(
+				"limit must be a number up to %d, follow next_cursor to get the remaining pages", query.MaxSchedulePageSize))
+		}
+	}
+	return opts, nil
+}
+
+// sendSchedulePage sends a page of schedules with its cursor pagination in the response meta. Cursor pages
+// have no number, so the meta carries neither page nor total_pages.
+func sendSchedulePage(w http.ResponseWriter, r *http.Request, page *query.SchedulePage) {
+	response.SendSuccessWithMeta(w, r, page.Schedules, &response.Meta{
+		Limit:      page.Limit,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+	})
+}
+
+// parseCalendarTime parses an RFC3339 time or a date; an empty value is the zero time
+func parseCalendarTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
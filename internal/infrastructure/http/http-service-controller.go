@@ -371,3 +371,32 @@ func (c *HTTPServiceController) UpdateServiceImage(w http.ResponseWriter, r *htt
 		"image_url": imageUrl,
 	})
 }
+
+// UpdateServiceEligibility handles PUT /services/{id}/eligibility
+func (c *HTTPServiceController) UpdateServiceEligibility(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/services/")
+	serviceID := strings.Split(path, "/")[0]
+
+	if serviceID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Service ID is required"))
+		return
+	}
+
+	var cmd command.UpdateServiceEligibility
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.ServiceID = serviceID
+	cmd.UpdatedBy, _ = middleware.GetUserIDFromContext(r.Context())
+	cmd.IsAdmin = isAdmin(r)
+
+	if err := c.service.UpdateServiceEligibility(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message": "Service eligibility updated successfully",
+	})
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/infrastructure/mongo"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPSettlementController handles HTTP requests for vendor settlement operations
+type HTTPSettlementController struct {
+	uowFactory        *mongo.MongoUnitOfWorkFactory
+	settlementService *services.SettlementService
+}
+
+// NewHTTPSettlementController creates a new HTTP settlement controller
+func NewHTTPSettlementController(
+	uowFactory *mongo.MongoUnitOfWorkFactory,
+	settlementService *services.SettlementService,
+) *HTTPSettlementController {
+	return &HTTPSettlementController{
+		uowFactory:        uowFactory,
+		settlementService: settlementService,
+	}
+}
+
+// GetSettlementByID handles GET /settlements/{id}
+// Available to staff of the settlement's vendor and admins.
+func (c *HTTPSettlementController) GetSettlementByID(w http.ResponseWriter, r *http.Request) {
+	settlementID := strings.TrimPrefix(r.URL.Path, "/settlements/")
+	if settlementID == "" {
+		response.SendBadRequest(w, r, "Settlement ID is required")
+		return
+	}
+
+	uow := c.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	settlement, err := uow.SettlementRepository().GetByID(r.Context(), settlementID)
+	if err != nil {
+		response.SendNotFound(w, r, "Settlement not found")
+		return
+	}
+
+	if !isVendorStaffOrAdmin(r.Context(), c.uowFactory, settlement.VendorID()) {
+		response.SendForbidden(w, r, "You do not have access to this settlement")
+		return
+	}
+
+	response.SendSuccess(w, r, settlementToResponse(settlement))
+}
+
+// ListSettlementsByVendor handles GET /settlements/vendor/{vendorId}?offset=&limit=
+// Available to staff of the vendor and admins.
+func (c *HTTPSettlementController) ListSettlementsByVendor(w http.ResponseWriter, r *http.Request) {
+	vendorID := strings.TrimPrefix(r.URL.Path, "/settlements/vendor/")
+	if vendorID == "" {
+		response.SendBadRequest(w, r, "Vendor ID is required")
+		return
+	}
+
+	if !isVendorStaffOrAdmin(r.Context(), c.uowFactory, vendorID) {
+		response.SendForbidden(w, r, "You do not have access to this vendor's settlements")
+		return
+	}
+
+	offset := 0
+	limit := 20
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	uow := c.uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	settlements, err := uow.SettlementRepository().GetByVendorID(r.Context(), vendorID, offset, limit)
+	if err != nil {
+		response.SendInternalError(w, r, "Failed to get settlements: "+err.Error())
+		return
+	}
+
+	results := []map[string]interface{}{}
+	for _, settlement := range settlements {
+		results = append(results, settlementToResponse(settlement))
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"settlements": results,
+		"offset":      offset,
+		"limit":       limit,
+		"count":       len(results),
+	})
+}
+
+// RunSettlements handles POST /admin/settlements/run - settles all due periods immediately
+func (c *HTTPSettlementController) RunSettlements(w http.ResponseWriter, r *http.Request) {
+	result, err := c.settlementService.SettleDue(r.Context())
+	if err != nil {
+		response.SendInternalError(w, r, "Failed to run settlements: "+err.Error())
+		return
+	}
+
+	response.SendSuccess(w, r, result)
+}
+
+// isVendorStaffOrAdmin reports whether the caller is an admin or active staff of the vendor
+func isVendorStaffOrAdmin(ctx context.Context, uowFactory *mongo.MongoUnitOfWorkFactory, vendorID string) bool {
+	if role, ok := middleware.GetUserRole(ctx); ok && role == aggregate.RoleAdmin {
+		return true
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok || userID == "" || vendorID == "" {
+		return false
+	}
+
+	uow := uowFactory.CreateUnitOfWork()
+	defer uow.Close()
+
+	staff, err := uow.VendorStaffRepository().GetByID(ctx, userID+"-"+vendorID)
+	return err == nil && staff != nil && staff.IsActive()
+}
+
+// settlementToResponse converts a settlement aggregate to its API representation
+func settlementToResponse(settlement *aggregate.Settlement) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              settlement.ID(),
+		"vendorId":        settlement.VendorID(),
+		"period":          settlement.Period(),
+		"periodStart":     settlement.PeriodStart(),
+		"periodEnd":       settlement.PeriodEnd(),
+		"status":          settlement.Status(),
+		"lineItems":       settlement.LineItems(),
+		"earningsAmount":  settlement.EarningsAmount(),
+		"carriedInAmount": settlement.CarriedInAmount(),
+		"carriedInFrom":   settlement.CarriedInFrom(),
+		"totalAmount":     settlement.TotalAmount(),
+		"payoutId":        settlement.PayoutID(),
+		"commission":      settlement.Commission(),
+		"payoutAmount":    settlement.PayoutAmount(),
+		"carriedOverTo":   settlement.CarriedOverTo(),
+		"closedReason":    settlement.ClosedReason(),
+		"createdAt":       settlement.CreatedAt(),
+		"updatedAt":       settlement.UpdatedAt(),
+	}
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPSpeciesController handles HTTP requests for the species and breed catalogue
+type HTTPSpeciesController struct {
+	catalogService *services.SpeciesCatalogService
+}
+
+// NewHTTPSpeciesController creates a new HTTP species controller
+func NewHTTPSpeciesController(catalogService *services.SpeciesCatalogService) *HTTPSpeciesController {
+	return &HTTPSpeciesController{
+		catalogService: catalogService,
+	}
+}
+
+// ListSpecies handles GET /species?lang=vi and, including deactivated species, GET /admin/species
+func (c *HTTPSpeciesController) ListSpecies(w http.ResponseWriter, r *http.Request) {
+	includeInactive := r.URL.Path == "/admin/species"
+
+	species, err := c.catalogService.ListSpecies(r.Context(), includeInactive, r.URL.Query().Get("lang"))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, species)
+}
+
+// GetSpecies handles GET /species/{speciesID}?lang=vi; the species may be given by code, name or alias
+func (c *HTTPSpeciesController) GetSpecies(w http.ResponseWriter, r *http.Request) {
+	species, err := c.catalogService.GetSpecies(r.Context(), r.PathValue("speciesID"), r.URL.Query().Get("lang"))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, species)
+}
+
+// CreateSpecies handles POST /admin/species
+func (c *HTTPSpeciesController) CreateSpecies(w http.ResponseWriter, r *http.Request) {
+	var cmd command.CreateSpecies
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.CreatedBy, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.catalogService.CreateSpecies(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]string{
+		"message": "Species created successfully",
+	})
+}
+
+// UpdateSpecies handles PUT /admin/species/{speciesID}
+func (c *HTTPSpeciesController) UpdateSpecies(w http.ResponseWriter, r *http.Request) {
+	var cmd command.UpdateSpecies
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.SpeciesID = r.PathValue("speciesID")
+
+	if err := c.catalogService.UpdateSpecies(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Species updated successfully",
+	})
+}
+
+// DeactivateSpecies handles DELETE /admin/species/{speciesID}; pets of the species keep it
+func (c *HTTPSpeciesController) DeactivateSpecies(w http.ResponseWriter, r *http.Request) {
+	cmd := command.DeactivateSpecies{SpeciesID: r.PathValue("speciesID")}
+	if err := c.catalogService.DeactivateSpecies(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Species deactivated successfully",
+	})
+}
+
+// AddBreed handles POST /admin/species/{speciesID}/breeds
+func (c *HTTPSpeciesController) AddBreed(w http.ResponseWriter, r *http.Request) {
+	var cmd command.AddSpeciesBreed
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.SpeciesID = r.PathValue("speciesID")
+
+	if err := c.catalogService.AddBreed(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, map[string]string{
+		"message": "Breed added successfully",
+	})
+}
+
+// UpdateBreed handles PUT /admin/species/{speciesID}/breeds/{breedCode}
+func (c *HTTPSpeciesController) UpdateBreed(w http.ResponseWriter, r *http.Request) {
+	var cmd command.UpdateSpeciesBreed
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.SpeciesID = r.PathValue("speciesID")
+	cmd.Code = r.PathValue("breedCode")
+
+	if err := c.catalogService.UpdateBreed(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Breed updated successfully",
+	})
+}
+
+// RemoveBreed handles DELETE /admin/species/{speciesID}/breeds/{breedCode}
+func (c *HTTPSpeciesController) RemoveBreed(w http.ResponseWriter, r *http.Request) {
+	cmd := command.RemoveSpeciesBreed{
+		SpeciesID: r.PathValue("speciesID"),
+		BreedCode: r.PathValue("breedCode"),
+	}
+	if err := c.catalogService.RemoveBreed(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{
+		"message": "Breed removed successfully",
+	})
+}
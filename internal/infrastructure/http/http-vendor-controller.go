@@ -424,3 +424,123 @@ func (c *VendorController) UpdateBankAccount(w http.ResponseWriter, r *http.Requ
 		},
 	})
 }
+
+// UpdateSettlementSettings handles PUT /vendors/{id}/settlement-settings - Update how vendor earnings are paid out
+func (c *VendorController) UpdateSettlementSettings(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/vendors/")
+	vendorID := strings.Split(path, "/")[0]
+
+	if vendorID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Vendor ID is required"))
+		return
+	}
+
+	var req struct {
+		Period          string `json:"period"`
+		MinPayoutAmount int    `json:"min_payout_amount"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	cmd := command.UpdateVendorSettlementSettings{
+		VendorID:        vendorID,
+		Period:          req.Period,
+		MinPayoutAmount: req.MinPayoutAmount,
+		UpdatedBy:       middleware.GetUserID(r.Context()),
+		IsAdmin:         isAdmin(r),
+	}
+
+	if err := c.service.UpdateVendorSettlementSettings(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message":           "Settlement settings updated successfully",
+		"vendor_id":         vendorID,
+		"period":            strings.ToUpper(req.Period),
+		"min_payout_amount": req.MinPayoutAmount,
+	})
+}
+
+// UpdatePaymentMethods handles PUT /vendors/{id}/payment-methods - Update how customers can pay the vendor
+func (c *VendorController) UpdatePaymentMethods(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/vendors/")
+	vendorID := strings.Split(path, "/")[0]
+
+	if vendorID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Vendor ID is required"))
+		return
+	}
+
+	var req struct {
+		PaymentMethods []string `json:"payment_methods"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	cmd := command.UpdateVendorPaymentMethods{
+		VendorID:       vendorID,
+		PaymentMethods: req.PaymentMethods,
+		UpdatedBy:      middleware.GetUserID(r.Context()),
+		IsAdmin:        isAdmin(r),
+	}
+
+	if err := c.service.UpdateVendorPaymentMethods(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message":         "Payment methods updated successfully",
+		"vendor_id":       vendorID,
+		"payment_methods": req.PaymentMethods,
+	})
+}
+
+// UpdateReschedulePolicy handles PUT /vendors/{id}/reschedule-policy - Update how customers may reschedule bookings
+func (c *VendorController) UpdateReschedulePolicy(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/vendors/")
+	vendorID := strings.Split(path, "/")[0]
+
+	if vendorID == "" {
+		middleware.HandleError(w, r, errors.NewValidationError("Vendor ID is required"))
+		return
+	}
+
+	var req struct {
+		CutoffHours    int `json:"cutoff_hours"`
+		MaxReschedules int `json:"max_reschedules"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+
+	cmd := command.UpdateVendorReschedulePolicy{
+		VendorID:       vendorID,
+		CutoffHours:    req.CutoffHours,
+		MaxReschedules: req.MaxReschedules,
+		UpdatedBy:      middleware.GetUserID(r.Context()),
+		IsAdmin:        isAdmin(r),
+	}
+
+	if err := c.service.UpdateVendorReschedulePolicy(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"message":         "Reschedule policy updated successfully",
+		"vendor_id":       vendorID,
+		"cutoff_hours":    req.CutoffHours,
+		"max_reschedules": req.MaxReschedules,
+	})
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"whisko-petcare/internal/application/command"
+	"whisko-petcare/internal/application/services"
+	"whisko-petcare/pkg/errors"
+	"whisko-petcare/pkg/middleware"
+	"whisko-petcare/pkg/response"
+)
+
+// HTTPWaitlistController handles HTTP requests for the waitlist of fully booked slots
+type HTTPWaitlistController struct {
+	waitlistService *services.WaitlistService
+}
+
+// NewHTTPWaitlistController creates a new HTTP waitlist controller
+func NewHTTPWaitlistController(waitlistService *services.WaitlistService) *HTTPWaitlistController {
+	return &HTTPWaitlistController{
+		waitlistService: waitlistService,
+	}
+}
+
+// JoinWaitlist handles POST /waitlist
+func (c *HTTPWaitlistController) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	var cmd command.JoinWaitlist
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+		return
+	}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	entry, err := c.waitlistService.Join(r.Context(), cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, entry)
+}
+
+// ListMyEntries handles GET /waitlist?offset=0&limit=10
+func (c *HTTPWaitlistController) ListMyEntries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		middleware.HandleError(w, r, errors.NewUnauthorizedError("user not authenticated"))
+		return
+	}
+
+	offset := 0
+	limit := 10 // default limit
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	entries, err := c.waitlistService.ListUserEntries(r.Context(), userID, offset, limit)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]interface{}{
+		"entries": entries,
+		"offset":  offset,
+		"limit":   limit,
+		"count":   len(entries),
+	})
+}
+
+// GetEntry handles GET /waitlist/{entryID}
+func (c *HTTPWaitlistController) GetEntry(w http.ResponseWriter, r *http.Request) {
+	userID, _ := middleware.GetUserIDFromContext(r.Context())
+
+	entry, err := c.waitlistService.GetEntry(r.Context(), r.PathValue("entryID"), userID, isAdmin(r))
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, entry)
+}
+
+// LeaveWaitlist handles DELETE /waitlist/{entryID}
+func (c *HTTPWaitlistController) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	cmd := command.LeaveWaitlist{
+		EntryID: r.PathValue("entryID"),
+		IsAdmin: isAdmin(r),
+	}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.waitlistService.Leave(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{"message": "Left the waitlist"})
+}
+
+// DeclineOffer handles POST /waitlist/{entryID}/decline
+func (c *HTTPWaitlistController) DeclineOffer(w http.ResponseWriter, r *http.Request) {
+	cmd := command.DeclineWaitlistOffer{EntryID: r.PathValue("entryID")}
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	if err := c.waitlistService.Decline(r.Context(), cmd); err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendSuccess(w, r, map[string]string{"message": "Offer declined, you stay on the waitlist"})
+}
+
+// ClaimOffer handles POST /waitlist/{entryID}/claim
+func (c *HTTPWaitlistController) ClaimOffer(w http.ResponseWriter, r *http.Request) {
+	var cmd command.ClaimWaitlistOffer
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+			middleware.HandleError(w, r, errors.NewValidationError("Invalid JSON format"))
+			return
+		}
+	}
+	cmd.EntryID = r.PathValue("entryID")
+	cmd.UserID, _ = middleware.GetUserIDFromContext(r.Context())
+
+	result, err := c.waitlistService.Claim(r.Context(), cmd)
+	if err != nil {
+		middleware.HandleError(w, r, err)
+		return
+	}
+
+	response.SendCreated(w, r, result)
+}
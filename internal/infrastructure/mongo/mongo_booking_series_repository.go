@@ -0,0 +1,305 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoBookingSeriesRepository implements BookingSeriesRepository with MongoDB persistence
+type MongoBookingSeriesRepository struct {
+	database         *mongo.Database
+	entityCollection *mongo.Collection
+	eventCollection  *mongo.Collection
+	session          mongo.Session
+}
+
+// NewMongoBookingSeriesRepository creates a new MongoDB booking series repository
+func NewMongoBookingSeriesRepository(database *mongo.Database) repository.BookingSeriesRepository {
+	return &MongoBookingSeriesRepository{
+		database:         database,
+		entityCollection: database.Collection("booking_series"),
+		eventCollection:  database.Collection("booking_series_events"),
+	}
+}
+
+// EnsureBookingSeriesIndexes creates the indexes the booking series collection relies on.
+// The status and next occurrence index keeps the search for series with occurrences to book cheap.
+func EnsureBookingSeriesIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_occurrence", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}
+
+	if _, err := database.Collection("booking_series").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create booking series indexes: %w", err)
+	}
+	return nil
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoBookingSeriesRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoBookingSeriesRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoBookingSeriesRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoBookingSeriesRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Save stores a booking series aggregate to MongoDB
+func (r *MongoBookingSeriesRepository) Save(ctx context.Context, series *aggregate.BookingSeries) error {
+	ctx = r.getContext(ctx)
+
+	// First, save the events
+	events := series.GetUncommittedEvents()
+	if len(events) > 0 {
+		if err := r.SaveEvents(ctx, series.ID(), events, series.Version()-len(events)); err != nil {
+			return fmt.Errorf("failed to save events: %w", err)
+		}
+	}
+
+	seriesDoc := bson.M{
+		"_id":              series.ID(),
+		"user_id":          series.UserID(),
+		"vendor_id":        series.VendorID(),
+		"pet_id":           series.PetID(),
+		"service_ids":      series.ServiceIDs(),
+		"recurrence":       series.Recurrence(),
+		"first_start_time": series.FirstStartTime(),
+		"first_end_time":   series.FirstEndTime(),
+		"payment_mode":     string(series.PaymentMode()),
+		"method":           string(series.Method()),
+		"occurrence_price": series.OccurrencePrice(),
+		"payment_id":       series.PaymentID(),
+		"status":           string(series.Status()),
+		"exceptions":       series.Exceptions(),
+		"occurrences":      series.BookedOccurrences(),
+		"next_occurrence":  optionalTime(series.NextOccurrence()),
+		"cancelled_from":   optionalTime(series.CancelledFrom()),
+		"version":          series.Version(),
+		"created_at":       series.CreatedAt(),
+		"updated_at":       series.UpdatedAt(),
+	}
+
+	// Use upsert to insert or update
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.entityCollection.ReplaceOne(ctx, bson.M{"_id": series.ID()}, seriesDoc, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save booking series: %w", err)
+	}
+
+	if len(events) > 0 {
+		series.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a booking series by its ID
+func (r *MongoBookingSeriesRepository) GetByID(ctx context.Context, id string) (*aggregate.BookingSeries, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("booking series not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get booking series: %w", err)
+	}
+
+	return documentToBookingSeries(result), nil
+}
+
+// GetByUserID retrieves a customer's booking series, newest first
+func (r *MongoBookingSeriesRepository) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*aggregate.BookingSeries, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	return r.find(ctx, bson.M{"user_id": userID}, opts)
+}
+
+// GetDue retrieves the active series with an occurrence to book before the given time.
+// Series with every occurrence booked store no next occurrence and never match.
+func (r *MongoBookingSeriesRepository) GetDue(ctx context.Context, until time.Time) ([]*aggregate.BookingSeries, error) {
+	filter := bson.M{
+		"status":          string(aggregate.BookingSeriesStatusActive),
+		"next_occurrence": bson.M{"$lt": until},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_occurrence", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// find runs a query and converts the matching documents to booking series
+func (r *MongoBookingSeriesRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*aggregate.BookingSeries, error) {
+	ctx = r.getContext(ctx)
+
+	cursor, err := r.entityCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find booking series: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	seriesList := []*aggregate.BookingSeries{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode booking series: %w", err)
+		}
+		seriesList = append(seriesList, documentToBookingSeries(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return seriesList, nil
+}
+
+// SaveEvents saves domain events for a booking series
+func (r *MongoBookingSeriesRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
+	ctx = r.getContext(ctx)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var eventDocs []interface{}
+	for i, e := range events {
+		eventDoc := bson.M{
+			"aggregate_id":  aggregateID,
+			"event_type":    e.EventType(),
+			"event_version": expectedVersion + i + 1,
+			"occurred_at":   e.OccurredAt(),
+			"event_data":    e,
+		}
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	_, err := r.eventCollection.InsertMany(ctx, eventDocs)
+	if err != nil {
+		return fmt.Errorf("failed to save booking series events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves all events for a booking series
+func (r *MongoBookingSeriesRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	// Booking series are loaded from entity state; event replay is not needed
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsSince retrieves events after a specific version
+func (r *MongoBookingSeriesRepository) GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error) {
+	return r.GetEvents(ctx, aggregateID)
+}
+
+// GetAllEvents retrieves all events
+func (r *MongoBookingSeriesRepository) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return []event.DomainEvent{}, nil
+}
+
+// optionalTime stores a zero time as null so it never matches a time range
+func optionalTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// documentToBookingSeries converts a MongoDB document to a BookingSeries aggregate
+func documentToBookingSeries(doc bson.M) *aggregate.BookingSeries {
+	recurrence := event.SeriesRecurrence{}
+	if recurrenceDoc, ok := doc["recurrence"].(bson.M); ok {
+		recurrence = event.SeriesRecurrence{
+			Frequency: getString(recurrenceDoc, "frequency"),
+			Interval:  getIntValue(recurrenceDoc, "interval"),
+			Count:     getIntValue(recurrenceDoc, "count"),
+			Until:     getTime(recurrenceDoc, "until"),
+		}
+	}
+
+	exceptions := []event.SeriesException{}
+	if items, ok := doc["exceptions"].(bson.A); ok {
+		for _, item := range items {
+			if itemDoc, ok := item.(bson.M); ok {
+				exceptions = append(exceptions, event.SeriesException{
+					OccurrenceStart: getTime(itemDoc, "occurrence_start"),
+					Kind:            getString(itemDoc, "kind"),
+					StartTime:       getTime(itemDoc, "start_time"),
+					EndTime:         getTime(itemDoc, "end_time"),
+					ScheduleID:      getString(itemDoc, "schedule_id"),
+					Reason:          getString(itemDoc, "reason"),
+					CreatedBy:       getString(itemDoc, "created_by"),
+					CreatedAt:       getTime(itemDoc, "created_at"),
+				})
+			}
+		}
+	}
+
+	occurrences := []event.SeriesOccurrence{}
+	if items, ok := doc["occurrences"].(bson.A); ok {
+		for _, item := range items {
+			if itemDoc, ok := item.(bson.M); ok {
+				occurrences = append(occurrences, event.SeriesOccurrence{
+					OccurrenceStart: getTime(itemDoc, "occurrence_start"),
+					ScheduleID:      getString(itemDoc, "schedule_id"),
+					PaymentID:       getString(itemDoc, "payment_id"),
+				})
+			}
+		}
+	}
+
+	return aggregate.ReconstructBookingSeries(
+		getString(doc, "_id"),
+		getString(doc, "user_id"),
+		getString(doc, "vendor_id"),
+		getString(doc, "pet_id"),
+		getStringArray(doc, "service_ids"),
+		recurrence,
+		getTime(doc, "first_start_time"),
+		getTime(doc, "first_end_time"),
+		aggregate.SeriesPaymentMode(getString(doc, "payment_mode")),
+		aggregate.PaymentMethod(getString(doc, "method")),
+		getIntValue(doc, "occurrence_price"),
+		getString(doc, "payment_id"),
+		aggregate.BookingSeriesStatus(getString(doc, "status")),
+		exceptions,
+		occurrences,
+		getTime(doc, "next_occurrence"),
+		getTime(doc, "cancelled_from"),
+		getIntValue(doc, "version"),
+		getTime(doc, "created_at"),
+		getTime(doc, "updated_at"),
+	)
+}
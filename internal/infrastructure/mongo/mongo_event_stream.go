@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/event"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// storedEvent is an event document as written by the repositories' SaveEvents
+type storedEvent struct {
+	EventType string   `bson:"event_type"`
+	EventData bson.Raw `bson:"event_data"`
+}
+
+// findEventsOfTypeSince reads the events of the given types that occurred at or after since from an event
+// collection, oldest first. newEvent returns an empty event to decode a stored event into, or nil for an
+// event type the collection does not hold.
+func findEventsOfTypeSince(ctx context.Context, collection *mongo.Collection, since time.Time, eventTypes []string,
+	newEvent func(eventType string) event.DomainEvent) ([]event.DomainEvent, error) {
+	filter := bson.M{
+		"event_type":  bson.M{"$in": eventTypes},
+		"occurred_at": bson.M{"$gte": since},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []event.DomainEvent{}
+	for cursor.Next(ctx) {
+		var doc storedEvent
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+
+		evt := newEvent(doc.EventType)
+		if evt == nil {
+			return nil, fmt.Errorf("unknown event type: %s", doc.EventType)
+		}
+		if err := bson.Unmarshal(doc.EventData, evt); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event: %w", doc.EventType, err)
+		}
+		events = append(events, evt)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+	return events, nil
+}
+
+// EnsureEventStreamIndexes creates the indexes used to read events by type and time
+func EnsureEventStreamIndexes(ctx context.Context, database *mongo.Database) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "event_type", Value: 1},
+			{Key: "occurred_at", Value: 1},
+		},
+	}
+
+	for _, name := range []string{"payment_events", "settlement_events", "payout_events"} {
+		if _, err := database.Collection(name).Indexes().CreateOne(ctx, index); err != nil {
+			return fmt.Errorf("failed to create %s indexes: %w", name, err)
+		}
+	}
+	return nil
+}
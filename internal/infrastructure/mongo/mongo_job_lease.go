@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoJobLease lets one API replica at a time run a background job. A lease is one document per job
+// naming its holder until it expires; a replica that stops renewing it hands the job to the others.
+type MongoJobLease struct {
+	collection *mongo.Collection
+}
+
+// NewMongoJobLease creates a new MongoDB job lease
+func NewMongoJobLease(database *mongo.Database) *MongoJobLease {
+	return &MongoJobLease{
+		collection: database.Collection("job_leases"),
+	}
+}
+
+// Acquire takes the lease on a job, or renews it when the holder already has it, and reports whether the
+// holder has the lease. Another holder's lease is only taken once it has expired.
+func (l *MongoJobLease) Acquire(ctx context.Context, job, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": job,
+		"$or": []bson.M{
+			{"holder": holder},
+			{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"holder":     holder,
+			"expires_at": now.Add(ttl),
+			"renewed_at": now,
+		},
+	}
+
+	// When another holder has a live lease the filter matches nothing and the upsert collides with its
+	// document, so exactly one replica gets the lease
+	_, err := l.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to acquire lease on %s: %w", job, err)
+	}
+	return true, nil
+}
+
+// Release gives up the holder's lease on a job so another replica can take it over right away
+func (l *MongoJobLease) Release(ctx context.Context, job, holder string) error {
+	_, err := l.collection.DeleteOne(ctx, bson.M{"_id": job, "holder": holder})
+	if err != nil {
+		return fmt.Errorf("failed to release lease on %s: %w", job, err)
+	}
+	return nil
+}
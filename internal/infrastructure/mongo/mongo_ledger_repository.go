@@ -0,0 +1,360 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoLedgerRepository implements LedgerRepository with an append-only MongoDB collection
+type MongoLedgerRepository struct {
+	database        *mongo.Database
+	entryCollection *mongo.Collection
+	session         mongo.Session
+}
+
+// NewMongoLedgerRepository creates a new MongoDB ledger repository
+func NewMongoLedgerRepository(database *mongo.Database) repository.LedgerRepository {
+	return &MongoLedgerRepository{
+		database:        database,
+		entryCollection: database.Collection("ledger_entries"),
+	}
+}
+
+// EnsureLedgerIndexes creates the ledger indexes. The unique reference index makes each
+// source event post at most once.
+func EnsureLedgerIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "reference", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "occurred_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{
+				{Key: "postings.account", Value: 1},
+				{Key: "postings.vendor_id", Value: 1},
+			},
+		},
+	}
+
+	if _, err := database.Collection("ledger_entries").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create ledger indexes: %w", err)
+	}
+	return nil
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoLedgerRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoLedgerRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoLedgerRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoLedgerRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Append inserts a ledger entry. Entries are never updated or deleted.
+func (r *MongoLedgerRepository) Append(ctx context.Context, entry *aggregate.LedgerEntry) (bool, error) {
+	ctx = r.getContext(ctx)
+
+	entryDoc := bson.M{
+		"_id":          entry.ID(),
+		"entry_type":   string(entry.EntryType()),
+		"reference":    entry.Reference(),
+		"description":  entry.Description(),
+		"postings":     entry.Postings(),
+		"total_debit":  entry.TotalDebit(),
+		"total_credit": entry.TotalCredit(),
+		"occurred_at":  entry.OccurredAt(),
+		"created_at":   entry.CreatedAt(),
+	}
+
+	_, err := r.entryCollection.InsertOne(ctx, entryDoc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil // Already posted
+		}
+		return false, fmt.Errorf("failed to append ledger entry: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetByReference retrieves the entry posted for a source reference
+func (r *MongoLedgerRepository) GetByReference(ctx context.Context, reference string) (*aggregate.LedgerEntry, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entryCollection.FindOne(ctx, bson.M{"reference": reference}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ledger entry: %w", err)
+	}
+
+	return documentToLedgerEntry(result), nil
+}
+
+// List retrieves entries that occurred within [from, to) with pagination (oldest first)
+func (r *MongoLedgerRepository) List(ctx context.Context, from, to time.Time, offset, limit int) ([]*aggregate.LedgerEntry, error) {
+	ctx = r.getContext(ctx)
+
+	opts := options.Find().
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	return r.find(ctx, occurredBetween(from, to), opts)
+}
+
+// ListAll retrieves every entry that occurred within [from, to) (oldest first)
+func (r *MongoLedgerRepository) ListAll(ctx context.Context, from, to time.Time) ([]*aggregate.LedgerEntry, error) {
+	ctx = r.getContext(ctx)
+
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	return r.find(ctx, occurredBetween(from, to), opts)
+}
+
+// GetAccountBalances aggregates debit and credit totals per account and vendor sub-account
+func (r *MongoLedgerRepository) GetAccountBalances(ctx context.Context) ([]aggregate.LedgerBalance, error) {
+	ctx = r.getContext(ctx)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$postings"}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"account":   "$postings.account",
+				"vendor_id": "$postings.vendor_id",
+			},
+			"debit":  bson.M{"$sum": "$postings.debit"},
+			"credit": bson.M{"$sum": "$postings.credit"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.account", Value: 1}, {Key: "_id.vendor_id", Value: 1}}}},
+	}
+
+	cursor, err := r.entryCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate ledger balances: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	balances := []aggregate.LedgerBalance{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode ledger balance: %w", err)
+		}
+		key, _ := result["_id"].(bson.M)
+		balances = append(balances, aggregate.NewLedgerBalance(
+			aggregate.LedgerAccount(getString(key, "account")),
+			getString(key, "vendor_id"),
+			getIntValue(result, "debit"),
+			getIntValue(result, "credit"),
+		))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return balances, nil
+}
+
+// GetVendorBalance aggregates the vendor's payable sub-account
+func (r *MongoLedgerRepository) GetVendorBalance(ctx context.Context, vendorID string) (aggregate.LedgerBalance, error) {
+	ctx = r.getContext(ctx)
+
+	posting := bson.M{
+		"postings.account":   string(aggregate.LedgerAccountVendorPayables),
+		"postings.vendor_id": vendorID,
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: posting}},
+		{{Key: "$unwind", Value: "$postings"}},
+		{{Key: "$match", Value: posting}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    nil,
+			"debit":  bson.M{"$sum": "$postings.debit"},
+			"credit": bson.M{"$sum": "$postings.credit"},
+		}}},
+	}
+
+	debit, credit, err := r.sumDebitCredit(ctx, pipeline)
+	if err != nil {
+		return aggregate.LedgerBalance{}, fmt.Errorf("failed to aggregate vendor balance: %w", err)
+	}
+
+	return aggregate.NewLedgerBalance(aggregate.LedgerAccountVendorPayables, vendorID, debit, credit), nil
+}
+
+// GetTotals sums every debit and credit in the ledger
+func (r *MongoLedgerRepository) GetTotals(ctx context.Context) (int, int, error) {
+	ctx = r.getContext(ctx)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$postings"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    nil,
+			"debit":  bson.M{"$sum": "$postings.debit"},
+			"credit": bson.M{"$sum": "$postings.credit"},
+		}}},
+	}
+
+	debit, credit, err := r.sumDebitCredit(ctx, pipeline)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to aggregate ledger totals: %w", err)
+	}
+
+	return debit, credit, nil
+}
+
+// GetUnbalancedEntryIDs returns the IDs of entries whose postings do not balance
+func (r *MongoLedgerRepository) GetUnbalancedEntryIDs(ctx context.Context) ([]string, error) {
+	ctx = r.getContext(ctx)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$project", Value: bson.M{
+			"debit":  bson.M{"$sum": "$postings.debit"},
+			"credit": bson.M{"$sum": "$postings.credit"},
+		}}},
+		{{Key: "$match", Value: bson.M{
+			"$expr": bson.M{"$ne": bson.A{"$debit", "$credit"}},
+		}}},
+	}
+
+	cursor, err := r.entryCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check ledger entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	ids := []string{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode ledger entry: %w", err)
+		}
+		ids = append(ids, getString(result, "_id"))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// sumDebitCredit runs a pipeline that groups into a single debit/credit document
+func (r *MongoLedgerRepository) sumDebitCredit(ctx context.Context, pipeline mongo.Pipeline) (int, int, error) {
+	cursor, err := r.entryCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		return 0, 0, cursor.Err() // Empty ledger
+	}
+
+	var result bson.M
+	if err := cursor.Decode(&result); err != nil {
+		return 0, 0, err
+	}
+
+	return getIntValue(result, "debit"), getIntValue(result, "credit"), nil
+}
+
+// find runs a query and converts all matching documents to ledger entries
+func (r *MongoLedgerRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*aggregate.LedgerEntry, error) {
+	cursor, err := r.entryCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ledger entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*aggregate.LedgerEntry
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode ledger entry: %w", err)
+		}
+		entries = append(entries, documentToLedgerEntry(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// occurredBetween builds a filter on occurred_at; zero times leave that side open
+func occurredBetween(from, to time.Time) bson.M {
+	occurredAt := bson.M{}
+	if !from.IsZero() {
+		occurredAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		occurredAt["$lt"] = to
+	}
+	if len(occurredAt) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"occurred_at": occurredAt}
+}
+
+// documentToLedgerEntry converts a MongoDB document to a LedgerEntry
+func documentToLedgerEntry(doc bson.M) *aggregate.LedgerEntry {
+	postings := []aggregate.LedgerPosting{}
+	if items, ok := doc["postings"].(bson.A); ok {
+		for _, item := range items {
+			if postingDoc, ok := item.(bson.M); ok {
+				postings = append(postings, aggregate.LedgerPosting{
+					Account:  aggregate.LedgerAccount(getString(postingDoc, "account")),
+					VendorID: getString(postingDoc, "vendor_id"),
+					Debit:    getIntValue(postingDoc, "debit"),
+					Credit:   getIntValue(postingDoc, "credit"),
+				})
+			}
+		}
+	}
+
+	return aggregate.ReconstructLedgerEntry(
+		getString(doc, "_id"),
+		aggregate.LedgerEntryType(getString(doc, "entry_type")),
+		getString(doc, "reference"),
+		getString(doc, "description"),
+		postings,
+		getTime(doc, "occurred_at"),
+		getTime(doc, "created_at"),
+	)
+}
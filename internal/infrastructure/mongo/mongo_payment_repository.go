@@ -87,6 +87,16 @@ func (r *MongoPaymentRepository) Save(ctx context.Context, payment *aggregate.Pa
 		"service_ids":          payment.ServiceIDs(),
 		"start_time":           payment.StartTime(),
 		"end_time":             payment.EndTime(),
+		"schedule_id":          payment.ScheduleID(),
+		"series_id":            payment.SeriesID(),
+		"pet_lines":            payment.PetLines(),
+		"refunded_amount":      payment.RefundedAmount(),
+		"pending_refund":       payment.PendingRefund(),
+		"collected_by":         payment.CollectedBy(),
+		"promotion_id":         payment.PromotionID(),
+		"promotion_code":       payment.PromotionCode(),
+		"discount_amount":      payment.DiscountAmount(),
+		"discount_funded_by":   string(payment.DiscountFundedBy()),
 		"version":              payment.Version(),
 		"created_at":           payment.CreatedAt(),
 		"updated_at":           payment.UpdatedAt(),
@@ -204,6 +214,26 @@ func (r *MongoPaymentRepository) GetByStatus(ctx context.Context, status string)
 	return payments, nil
 }
 
+// HasPaidPayment reports whether the user has ever completed a payment (refunded payments included)
+func (r *MongoPaymentRepository) HasPaidPayment(ctx context.Context, userID string) (bool, error) {
+	ctx = r.getContext(ctx)
+
+	filter := bson.M{
+		"user_id": userID,
+		"status": bson.M{"$in": []string{
+			string(aggregate.PaymentStatusPaid),
+			string(aggregate.PaymentStatusRefunded),
+		}},
+	}
+
+	count, err := r.entityCollection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check paid payments: %w", err)
+	}
+
+	return count > 0, nil
+}
+
 // SaveEvents saves events for a payment aggregate
 func (r *MongoPaymentRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
 	ctx = r.getContext(ctx)
@@ -251,6 +281,34 @@ func (r *MongoPaymentRepository) GetAllEvents(ctx context.Context) ([]event.Doma
 	return []event.DomainEvent{}, nil
 }
 
+// GetEventsOfTypeSince retrieves the stored payment events of the given types that occurred at or after since
+func (r *MongoPaymentRepository) GetEventsOfTypeSince(ctx context.Context, since time.Time, eventTypes ...string) ([]event.DomainEvent, error) {
+	return findEventsOfTypeSince(r.getContext(ctx), r.eventCollection, since, eventTypes, newPaymentEvent)
+}
+
+// newPaymentEvent returns an empty payment event of the given type to decode a stored event into
+func newPaymentEvent(eventType string) event.DomainEvent {
+	switch eventType {
+	case "PaymentCreated":
+		return &event.PaymentCreated{}
+	case "PaymentUpdated":
+		return &event.PaymentUpdated{}
+	case "PaymentStatusChanged":
+		return &event.PaymentStatusChanged{}
+	case "PaymentCollected":
+		return &event.PaymentCollected{}
+	case "PaymentRefundRequested":
+		return &event.PaymentRefundRequested{}
+	case "PaymentRefunded":
+		return &event.PaymentRefunded{}
+	case "PaymentAmountAdjusted":
+		return &event.PaymentAmountAdjusted{}
+	case "PaymentDiscountApplied":
+		return &event.PaymentDiscountApplied{}
+	}
+	return nil
+}
+
 // documentToPayment converts a MongoDB document to a Payment aggregate
 func (r *MongoPaymentRepository) documentToPayment(doc bson.M) (*aggregate.Payment, error) {
 	// Extract items
@@ -312,10 +370,54 @@ func (r *MongoPaymentRepository) documentToPayment(doc bson.M) (*aggregate.Payme
 		getTime(doc, "created_at"),
 		getTime(doc, "updated_at"),
 	)
+	payment.SetRefundedAmount(getIntValue(doc, "refunded_amount"))
+	payment.SetPendingRefund(getPendingRefund(doc))
+	payment.SetCollectedBy(getString(doc, "collected_by"))
+	payment.SetScheduleID(getString(doc, "schedule_id"))
+	payment.SetSeriesID(getString(doc, "series_id"))
+	payment.SetPetLines(getPaymentPetLines(doc))
+	payment.SetDiscount(
+		getString(doc, "promotion_id"),
+		getString(doc, "promotion_code"),
+		getIntValue(doc, "discount_amount"),
+		aggregate.PromotionFunding(getString(doc, "discount_funded_by")),
+	)
 
 	return payment, nil
 }
 
+// getPendingRefund extracts the refund in progress on a payment, if any
+func getPendingRefund(doc bson.M) *aggregate.PendingRefund {
+	refundDoc, ok := doc["pending_refund"].(bson.M)
+	if !ok {
+		return nil
+	}
+	return &aggregate.PendingRefund{
+		RefundID: getString(refundDoc, "refund_id"),
+		Amount:   getIntValue(refundDoc, "amount"),
+		Reason:   getString(refundDoc, "reason"),
+	}
+}
+
+// getPaymentPetLines extracts the pets of a payment for a booking covering several pets
+func getPaymentPetLines(doc bson.M) []aggregate.PaymentPetLine {
+	linesData, ok := doc["pet_lines"].(bson.A)
+	if !ok {
+		return nil
+	}
+
+	var lines []aggregate.PaymentPetLine
+	for _, lineData := range linesData {
+		if lineDoc, ok := lineData.(bson.M); ok {
+			lines = append(lines, aggregate.PaymentPetLine{
+				PetID:      getString(lineDoc, "pet_id"),
+				ServiceIDs: getStringArray(lineDoc, "service_ids"),
+			})
+		}
+	}
+	return lines
+}
+
 // Helper functions specific to payment repository
 func getIntValue(doc bson.M, key string) int {
 	if val, ok := doc[key].(int32); ok {
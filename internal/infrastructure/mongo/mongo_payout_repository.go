@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/event"
@@ -79,6 +80,8 @@ func (r *MongoPayoutRepository) Save(ctx context.Context, payout *aggregate.Payo
 		"payment_id":  payout.PaymentID(),
 		"schedule_id": payout.ScheduleID(),
 		"amount":      payout.Amount(),
+		"settlement_id": payout.SettlementID(),
+		"line_items":    payout.LineItems(),
 		"bank_account": bson.M{
 			"bank_name":      bankAccount.BankName,
 			"account_number": bankAccount.AccountNumber,
@@ -121,32 +124,7 @@ func (r *MongoPayoutRepository) GetByID(ctx context.Context, id string) (*aggreg
 		return nil, fmt.Errorf("failed to get payout: %w", err)
 	}
 
-	// Extract bank account
-	var bankAccount aggregate.BankAccount
-	if bankAccountDoc, ok := result["bank_account"].(bson.M); ok {
-		bankAccount = aggregate.BankAccount{
-			BankName:      getString(bankAccountDoc, "bank_name"),
-			AccountNumber: getString(bankAccountDoc, "account_number"),
-			AccountName:   getString(bankAccountDoc, "account_name"),
-			BankBranch:    getString(bankAccountDoc, "bank_branch"),
-		}
-	}
-
-	// Reconstruct payout from database state
-	payout := aggregate.ReconstructPayout(
-		getString(result, "_id"),
-		getString(result, "vendor_id"),
-		getString(result, "payment_id"),
-		getString(result, "schedule_id"),
-		getInt(result, "amount"),
-		bankAccount,
-		getString(result, "status"),
-		getString(result, "notes"),
-		getString(result, "failure_reason"),
-		getInt(result, "version"),
-		getTime(result, "created_at"),
-		getTime(result, "updated_at"),
-	)
+	payout := documentToPayout(result)
 
 	fmt.Printf("✅ Payout found: %s (Status: %s)\n", id, payout.Status())
 	return payout, nil
@@ -170,30 +148,7 @@ func (r *MongoPayoutRepository) GetByVendorID(ctx context.Context, vendorID stri
 			return nil, fmt.Errorf("failed to decode payout: %w", err)
 		}
 
-		var bankAccount aggregate.BankAccount
-		if bankAccountDoc, ok := result["bank_account"].(bson.M); ok {
-			bankAccount = aggregate.BankAccount{
-				BankName:      getString(bankAccountDoc, "bank_name"),
-				AccountNumber: getString(bankAccountDoc, "account_number"),
-				AccountName:   getString(bankAccountDoc, "account_name"),
-				BankBranch:    getString(bankAccountDoc, "bank_branch"),
-			}
-		}
-
-		payout := aggregate.ReconstructPayout(
-			getString(result, "_id"),
-			getString(result, "vendor_id"),
-			getString(result, "payment_id"),
-			getString(result, "schedule_id"),
-			getInt(result, "amount"),
-			bankAccount,
-			getString(result, "status"),
-			getString(result, "notes"),
-			getString(result, "failure_reason"),
-			getInt(result, "version"),
-			getTime(result, "created_at"),
-			getTime(result, "updated_at"),
-		)
+		payout := documentToPayout(result)
 		payouts = append(payouts, payout)
 	}
 
@@ -213,30 +168,7 @@ func (r *MongoPayoutRepository) GetByPaymentID(ctx context.Context, paymentID st
 		return nil, fmt.Errorf("failed to get payout: %w", err)
 	}
 
-	var bankAccount aggregate.BankAccount
-	if bankAccountDoc, ok := result["bank_account"].(bson.M); ok {
-		bankAccount = aggregate.BankAccount{
-			BankName:      getString(bankAccountDoc, "bank_name"),
-			AccountNumber: getString(bankAccountDoc, "account_number"),
-			AccountName:   getString(bankAccountDoc, "account_name"),
-			BankBranch:    getString(bankAccountDoc, "bank_branch"),
-		}
-	}
-
-	payout := aggregate.ReconstructPayout(
-		getString(result, "_id"),
-		getString(result, "vendor_id"),
-		getString(result, "payment_id"),
-		getString(result, "schedule_id"),
-		getInt(result, "amount"),
-		bankAccount,
-		getString(result, "status"),
-		getString(result, "notes"),
-		getString(result, "failure_reason"),
-		getInt(result, "version"),
-		getTime(result, "created_at"),
-		getTime(result, "updated_at"),
-	)
+	payout := documentToPayout(result)
 
 	return payout, nil
 }
@@ -254,30 +186,7 @@ func (r *MongoPayoutRepository) GetByScheduleID(ctx context.Context, scheduleID
 		return nil, fmt.Errorf("failed to get payout: %w", err)
 	}
 
-	var bankAccount aggregate.BankAccount
-	if bankAccountDoc, ok := result["bank_account"].(bson.M); ok {
-		bankAccount = aggregate.BankAccount{
-			BankName:      getString(bankAccountDoc, "bank_name"),
-			AccountNumber: getString(bankAccountDoc, "account_number"),
-			AccountName:   getString(bankAccountDoc, "account_name"),
-			BankBranch:    getString(bankAccountDoc, "bank_branch"),
-		}
-	}
-
-	payout := aggregate.ReconstructPayout(
-		getString(result, "_id"),
-		getString(result, "vendor_id"),
-		getString(result, "payment_id"),
-		getString(result, "schedule_id"),
-		getInt(result, "amount"),
-		bankAccount,
-		getString(result, "status"),
-		getString(result, "notes"),
-		getString(result, "failure_reason"),
-		getInt(result, "version"),
-		getTime(result, "created_at"),
-		getTime(result, "updated_at"),
-	)
+	payout := documentToPayout(result)
 
 	return payout, nil
 }
@@ -300,30 +209,7 @@ func (r *MongoPayoutRepository) GetByStatus(ctx context.Context, status aggregat
 			return nil, fmt.Errorf("failed to decode payout: %w", err)
 		}
 
-		var bankAccount aggregate.BankAccount
-		if bankAccountDoc, ok := result["bank_account"].(bson.M); ok {
-			bankAccount = aggregate.BankAccount{
-				BankName:      getString(bankAccountDoc, "bank_name"),
-				AccountNumber: getString(bankAccountDoc, "account_number"),
-				AccountName:   getString(bankAccountDoc, "account_name"),
-				BankBranch:    getString(bankAccountDoc, "bank_branch"),
-			}
-		}
-
-		payout := aggregate.ReconstructPayout(
-			getString(result, "_id"),
-			getString(result, "vendor_id"),
-			getString(result, "payment_id"),
-			getString(result, "schedule_id"),
-			getInt(result, "amount"),
-			bankAccount,
-			getString(result, "status"),
-			getString(result, "notes"),
-			getString(result, "failure_reason"),
-			getInt(result, "version"),
-			getTime(result, "created_at"),
-			getTime(result, "updated_at"),
-		)
+		payout := documentToPayout(result)
 		payouts = append(payouts, payout)
 	}
 
@@ -386,6 +272,30 @@ func (r *MongoPayoutRepository) GetAllEvents(ctx context.Context) ([]event.Domai
 	return events, nil
 }
 
+// GetEventsOfTypeSince retrieves the stored payout events of the given types that occurred at or after since
+func (r *MongoPayoutRepository) GetEventsOfTypeSince(ctx context.Context, since time.Time, eventTypes ...string) ([]event.DomainEvent, error) {
+	return findEventsOfTypeSince(r.getContext(ctx), r.eventCollection, since, eventTypes, newPayoutEvent)
+}
+
+// newPayoutEvent returns an empty payout event of the given type to decode a stored event into
+func newPayoutEvent(eventType string) event.DomainEvent {
+	switch eventType {
+	case "PayoutRequested":
+		return &event.PayoutRequested{}
+	case "PayoutApproved":
+		return &event.PayoutApproved{}
+	case "PayoutRejected":
+		return &event.PayoutRejected{}
+	case "PayoutProcessing":
+		return &event.PayoutProcessing{}
+	case "PayoutCompleted":
+		return &event.PayoutCompleted{}
+	case "PayoutFailed":
+		return &event.PayoutFailed{}
+	}
+	return nil
+}
+
 // GetEvents retrieves all events for a specific payout
 func (r *MongoPayoutRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
 	ctx = r.getContext(ctx)
@@ -464,6 +374,13 @@ func (r *MongoPayoutRepository) GetPendingPayoutForVendor(ctx context.Context, v
 		return nil, fmt.Errorf("failed to get pending payout: %w", err)
 	}
 
+	payout := documentToPayout(result)
+
+	return payout, nil
+}
+
+// documentToPayout converts a MongoDB document to a Payout aggregate
+func documentToPayout(result bson.M) *aggregate.Payout {
 	var bankAccount aggregate.BankAccount
 	if bankAccountDoc, ok := result["bank_account"].(bson.M); ok {
 		bankAccount = aggregate.BankAccount{
@@ -489,5 +406,21 @@ func (r *MongoPayoutRepository) GetPendingPayoutForVendor(ctx context.Context, v
 		getTime(result, "updated_at"),
 	)
 
-	return payout, nil
+	// Settlement payouts cover multiple paid bookings
+	var lineItems []event.SettlementLineItem
+	if items, ok := result["line_items"].(bson.A); ok {
+		for _, item := range items {
+			if itemDoc, ok := item.(bson.M); ok {
+				lineItems = append(lineItems, event.SettlementLineItem{
+					PaymentID:  getString(itemDoc, "payment_id"),
+					ScheduleID: getString(itemDoc, "schedule_id"),
+					Amount:     getIntValue(itemDoc, "amount"),
+					EarnedAt:   getTime(itemDoc, "earned_at"),
+				})
+			}
+		}
+	}
+	payout.SetSettlementDetails(getString(result, "settlement_id"), lineItems)
+
+	return payout
 }
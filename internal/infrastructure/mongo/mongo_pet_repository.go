@@ -10,6 +10,7 @@ import (
 	// "whisko-petcare/internal/domain/repository"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	// "whisko-petcare/internal/infrastructure/mongo/collections"
@@ -69,6 +70,12 @@ func (r *MongoPetRepository) Save(ctx context.Context, pet *aggregate.Pet) error
 		"created_at":  pet.CreatedAt(),
 		"updated_at":  pet.UpdatedAt(),
 	}
+	if !pet.BirthDate().IsZero() {
+		entityDoc["birth_date"] = pet.BirthDate()
+		entityDoc["birth_date_estimated"] = pet.BirthDateEstimated()
+	}
+	entityDoc["temperament"] = pet.Temperament()
+	entityDoc["special_instructions"] = pet.SpecialInstructions()
 	
 	// Upsert the entity document in the database
 	opts := options.Update().SetUpsert(true)
@@ -141,6 +148,18 @@ func (r *MongoPetRepository) GetByID(ctx context.Context, id string) (*aggregate
 	pet.SetVaccinationRecords(getPetVaccinationRecords(petDoc))
 	pet.SetMedicalHistory(getPetMedicalHistory(petDoc))
 	pet.SetAllergies(getPetAllergies(petDoc))
+	pet.SetBirthDate(getPetTime(petDoc, "birth_date"), getPetBool(petDoc, "birth_date_estimated"))
+	pet.SetWeightHistory(getPetWeightHistory(petDoc))
+
+	// Reconstruct guardianship from database
+	pet.SetGuardians(getPetGuardians(petDoc))
+	pet.SetGuardianInvitations(getPetGuardianInvitations(petDoc))
+	pet.SetPendingTransfer(getPetPendingTransfer(petDoc))
+
+	// Reconstruct medication plans from database
+	pet.SetMedicationPlans(getPetMedicationPlans(petDoc))
+	pet.SetAttachments(getPetAttachments(petDoc))
+	pet.SetCareNotes(getPetString(petDoc, "temperament"), getPetString(petDoc, "special_instructions"))
 
 	return pet, nil
 }
@@ -246,6 +265,7 @@ func getPetVaccinationRecords(doc bson.M) []event.VaccinationRecord {
 					NextDueDate:   getPetTime(recordMap, "next_due_date"),
 					Veterinarian:  getPetString(recordMap, "veterinarian"),
 					Notes:         getPetString(recordMap, "notes"),
+					Voided:        getPetBool(recordMap, "voided"),
 				}
 				records = append(records, record)
 			}
@@ -267,6 +287,16 @@ func getPetMedicalHistory(doc bson.M) []event.MedicalRecord {
 					Veterinarian:  getPetString(recordMap, "veterinarian"),
 					Diagnosis:     getPetString(recordMap, "diagnosis"),
 					Notes:         getPetString(recordMap, "notes"),
+					Voided:        getPetBool(recordMap, "voided"),
+				}
+				if provenanceMap, ok := recordMap["provenance"].(bson.M); ok {
+					record.Provenance = &event.RecordProvenance{
+						Source:     getPetString(provenanceMap, "source"),
+						VendorID:   getPetString(provenanceMap, "vendor_id"),
+						VendorName: getPetString(provenanceMap, "vendor_name"),
+						ScheduleID: getPetString(provenanceMap, "schedule_id"),
+						RecordedBy: getPetString(provenanceMap, "recorded_by"),
+					}
 				}
 				records = append(records, record)
 			}
@@ -295,7 +325,150 @@ func getPetAllergies(doc bson.M) []event.Allergy {
 	return allergies
 }
 
+func getPetWeightHistory(doc bson.M) []event.WeightMeasurement {
+	measurements := []event.WeightMeasurement{}
+	if val, ok := doc["weights"].(bson.A); ok {
+		for _, item := range val {
+			if measurementMap, ok := item.(bson.M); ok {
+				measurements = append(measurements, event.WeightMeasurement{
+					ID:         getPetString(measurementMap, "id"),
+					Weight:     getPetFloat64(measurementMap, "weight"),
+					MeasuredAt: getPetTime(measurementMap, "measured_at"),
+					Source:     getPetString(measurementMap, "source"),
+					RecordedBy: getPetString(measurementMap, "recorded_by"),
+					Notes:      getPetString(measurementMap, "notes"),
+				})
+			}
+		}
+	}
+	return measurements
+}
+
+func getPetGuardians(doc bson.M) []event.PetGuardian {
+	guardians := []event.PetGuardian{}
+	if val, ok := doc["guardians"].(bson.A); ok {
+		for _, item := range val {
+			if guardianMap, ok := item.(bson.M); ok {
+				guardians = append(guardians, event.PetGuardian{
+					UserID:  getPetString(guardianMap, "user_id"),
+					Role:    getPetString(guardianMap, "role"),
+					AddedAt: getPetTime(guardianMap, "added_at"),
+					AddedBy: getPetString(guardianMap, "added_by"),
+				})
+			}
+		}
+	}
+	return guardians
+}
+
+func getPetGuardianInvitations(doc bson.M) []event.GuardianInvitation {
+	invitations := []event.GuardianInvitation{}
+	if val, ok := doc["guardian_invitations"].(bson.A); ok {
+		for _, item := range val {
+			if invitationMap, ok := item.(bson.M); ok {
+				invitations = append(invitations, event.GuardianInvitation{
+					ID:        getPetString(invitationMap, "id"),
+					UserID:    getPetString(invitationMap, "user_id"),
+					Role:      getPetString(invitationMap, "role"),
+					InvitedBy: getPetString(invitationMap, "invited_by"),
+					InvitedAt: getPetTime(invitationMap, "invited_at"),
+					ExpiresAt: getPetTime(invitationMap, "expires_at"),
+				})
+			}
+		}
+	}
+	return invitations
+}
+
+func getPetPendingTransfer(doc bson.M) *event.OwnershipTransfer {
+	transferMap, ok := doc["pending_transfer"].(bson.M)
+	if !ok {
+		return nil
+	}
+	return &event.OwnershipTransfer{
+		ID:          getPetString(transferMap, "id"),
+		FromUserID:  getPetString(transferMap, "from_user_id"),
+		ToUserID:    getPetString(transferMap, "to_user_id"),
+		Note:        getPetString(transferMap, "note"),
+		RequestedAt: getPetTime(transferMap, "requested_at"),
+		ExpiresAt:   getPetTime(transferMap, "expires_at"),
+	}
+}
+
+func getPetMedicationPlans(doc bson.M) []event.MedicationPlan {
+	plans := []event.MedicationPlan{}
+	if val, ok := doc["medication_plans"].(bson.A); ok {
+		for _, item := range val {
+			if planMap, ok := item.(bson.M); ok {
+				plans = append(plans, event.MedicationPlan{
+					ID:              getPetString(planMap, "id"),
+					DrugName:        getPetString(planMap, "drug_name"),
+					Dose:            getPetString(planMap, "dose"),
+					IntervalHours:   getPetInt(planMap, "interval_hours"),
+					StartDate:       getPetTime(planMap, "start_date"),
+					EndDate:         getPetTime(planMap, "end_date"),
+					PrescribedBy:    getPetString(planMap, "prescribed_by"),
+					Instructions:    getPetString(planMap, "instructions"),
+					MedicalRecordID: getPetString(planMap, "medical_record_id"),
+					CreatedBy:       getPetString(planMap, "created_by"),
+					StoppedAt:       getPetTime(planMap, "stopped_at"),
+					Doses:           getPetDoseLogs(planMap),
+				})
+			}
+		}
+	}
+	return plans
+}
+
+func getPetDoseLogs(doc bson.M) []event.DoseLog {
+	doses := []event.DoseLog{}
+	if val, ok := doc["doses"].(bson.A); ok {
+		for _, item := range val {
+			if doseMap, ok := item.(bson.M); ok {
+				doses = append(doses, event.DoseLog{
+					ID:           getPetString(doseMap, "id"),
+					ScheduledFor: getPetTime(doseMap, "scheduled_for"),
+					Status:       getPetString(doseMap, "status"),
+					RecordedAt:   getPetTime(doseMap, "recorded_at"),
+					RecordedBy:   getPetString(doseMap, "recorded_by"),
+					Notes:        getPetString(doseMap, "notes"),
+				})
+			}
+		}
+	}
+	return doses
+}
+
+func getPetAttachments(doc bson.M) []event.PetAttachment {
+	attachments := []event.PetAttachment{}
+	if val, ok := doc["attachments"].(bson.A); ok {
+		for _, item := range val {
+			if attachmentMap, ok := item.(bson.M); ok {
+				attachments = append(attachments, event.PetAttachment{
+					ID:          getPetString(attachmentMap, "id"),
+					Category:    getPetString(attachmentMap, "category"),
+					Title:       getPetString(attachmentMap, "title"),
+					FileName:    getPetString(attachmentMap, "file_name"),
+					ContentType: getPetString(attachmentMap, "content_type"),
+					Size:        int64(getPetInt(attachmentMap, "size")),
+					URL:         getPetString(attachmentMap, "url"),
+					StorageKey:  getPetString(attachmentMap, "storage_key"),
+					RecordType:  getPetString(attachmentMap, "record_type"),
+					RecordID:    getPetString(attachmentMap, "record_id"),
+					UploadedBy:  getPetString(attachmentMap, "uploaded_by"),
+					UploadedAt:  getPetTime(attachmentMap, "uploaded_at"),
+				})
+			}
+		}
+	}
+	return attachments
+}
+
 func getPetTime(doc bson.M, key string) time.Time {
+	// Dates decode as primitive.DateTime when reading into bson.M
+	if val, ok := doc[key].(primitive.DateTime); ok {
+		return val.Time()
+	}
 	if val, ok := doc[key].(time.Time); ok {
 		return val
 	}
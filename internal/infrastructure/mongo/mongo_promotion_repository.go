@@ -0,0 +1,297 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoPromotionRepository implements PromotionRepository with MongoDB persistence
+type MongoPromotionRepository struct {
+	database         *mongo.Database
+	entityCollection *mongo.Collection
+	eventCollection  *mongo.Collection
+	session          mongo.Session
+}
+
+// NewMongoPromotionRepository creates a new MongoDB promotion repository
+func NewMongoPromotionRepository(database *mongo.Database) repository.PromotionRepository {
+	return &MongoPromotionRepository{
+		database:         database,
+		entityCollection: database.Collection("promotions"),
+		eventCollection:  database.Collection("promotion_events"),
+	}
+}
+
+// EnsurePromotionIndexes creates the indexes the promotion collection relies on.
+// The unique code index stops two promotions from sharing a coupon code.
+func EnsurePromotionIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	}
+
+	if _, err := database.Collection("promotions").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create promotion indexes: %w", err)
+	}
+	return nil
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoPromotionRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoPromotionRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoPromotionRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoPromotionRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Save stores a promotion aggregate to MongoDB
+func (r *MongoPromotionRepository) Save(ctx context.Context, promotion *aggregate.Promotion) error {
+	ctx = r.getContext(ctx)
+
+	// First, save the events
+	events := promotion.GetUncommittedEvents()
+	if len(events) > 0 {
+		if err := r.SaveEvents(ctx, promotion.ID(), events, promotion.Version()-len(events)); err != nil {
+			return fmt.Errorf("failed to save events: %w", err)
+		}
+	}
+
+	terms := promotion.Terms()
+	promotionDoc := bson.M{
+		"_id":                promotion.ID(),
+		"code":               terms.Code,
+		"name":               terms.Name,
+		"description":        terms.Description,
+		"discount_type":      string(terms.DiscountType),
+		"discount_value":     terms.DiscountValue,
+		"max_discount":       terms.MaxDiscount,
+		"min_order_amount":   terms.MinOrderAmount,
+		"funded_by":          string(terms.FundedBy),
+		"vendor_id":          terms.VendorID,
+		"service_ids":        terms.ServiceIDs,
+		"first_booking_only": terms.FirstBookingOnly,
+		"starts_at":          terms.StartsAt,
+		"ends_at":            terms.EndsAt,
+		"usage_limit":        terms.UsageLimit,
+		"per_user_limit":     terms.PerUserLimit,
+		"status":             string(promotion.Status()),
+		"redemptions":        promotion.Redemptions(),
+		"created_by":         promotion.CreatedBy(),
+		"version":            promotion.Version(),
+		"created_at":         promotion.CreatedAt(),
+		"updated_at":         promotion.UpdatedAt(),
+	}
+
+	// Use upsert to insert or update
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.entityCollection.ReplaceOne(ctx, bson.M{"_id": promotion.ID()}, promotionDoc, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("promotion code already exists: %s", terms.Code)
+		}
+		return fmt.Errorf("failed to save promotion: %w", err)
+	}
+
+	if len(events) > 0 {
+		promotion.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a promotion by ID
+func (r *MongoPromotionRepository) GetByID(ctx context.Context, id string) (*aggregate.Promotion, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("promotion not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return documentToPromotion(result), nil
+}
+
+// GetByCode retrieves a promotion by its coupon code
+func (r *MongoPromotionRepository) GetByCode(ctx context.Context, code string) (*aggregate.Promotion, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"code": aggregate.NormalizePromotionCode(code)}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // No promotion uses this code
+		}
+		return nil, fmt.Errorf("failed to get promotion by code: %w", err)
+	}
+
+	return documentToPromotion(result), nil
+}
+
+// List retrieves promotions with pagination (newest first)
+func (r *MongoPromotionRepository) List(ctx context.Context, offset, limit int) ([]*aggregate.Promotion, error) {
+	ctx = r.getContext(ctx)
+
+	opts := options.Find().
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.entityCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promotions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var promotions []*aggregate.Promotion
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode promotion: %w", err)
+		}
+		promotions = append(promotions, documentToPromotion(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return promotions, nil
+}
+
+// SaveEvents saves domain events for a promotion
+func (r *MongoPromotionRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
+	ctx = r.getContext(ctx)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var eventDocs []interface{}
+	for i, e := range events {
+		eventDoc := bson.M{
+			"aggregate_id":  aggregateID,
+			"event_type":    e.EventType(),
+			"event_version": expectedVersion + i + 1,
+			"occurred_at":   e.OccurredAt(),
+			"event_data":    e,
+		}
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	_, err := r.eventCollection.InsertMany(ctx, eventDocs)
+	if err != nil {
+		return fmt.Errorf("failed to save promotion events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves all events for a promotion
+func (r *MongoPromotionRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	// Promotions are loaded from entity state; event replay is not needed
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsSince retrieves events after a specific version
+func (r *MongoPromotionRepository) GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error) {
+	return r.GetEvents(ctx, aggregateID)
+}
+
+// GetAllEvents retrieves all events
+func (r *MongoPromotionRepository) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return []event.DomainEvent{}, nil
+}
+
+// documentToPromotion converts a MongoDB document to a Promotion aggregate
+func documentToPromotion(doc bson.M) *aggregate.Promotion {
+	serviceIDs := []string{}
+	if ids, ok := doc["service_ids"].(bson.A); ok {
+		for _, id := range ids {
+			if idStr, ok := id.(string); ok {
+				serviceIDs = append(serviceIDs, idStr)
+			}
+		}
+	}
+
+	redemptions := []event.PromotionRedemption{}
+	if items, ok := doc["redemptions"].(bson.A); ok {
+		for _, item := range items {
+			if itemDoc, ok := item.(bson.M); ok {
+				redemptions = append(redemptions, event.PromotionRedemption{
+					PaymentID:      getString(itemDoc, "payment_id"),
+					UserID:         getString(itemDoc, "user_id"),
+					VendorID:       getString(itemDoc, "vendor_id"),
+					DiscountAmount: getIntValue(itemDoc, "discount_amount"),
+					RedeemedAt:     getTime(itemDoc, "redeemed_at"),
+					Released:       getBool(itemDoc, "released"),
+					ReleasedAt:     getTime(itemDoc, "released_at"),
+				})
+			}
+		}
+	}
+
+	terms := aggregate.PromotionTerms{
+		Code:             getString(doc, "code"),
+		Name:             getString(doc, "name"),
+		Description:      getString(doc, "description"),
+		DiscountType:     aggregate.PromotionDiscountType(getString(doc, "discount_type")),
+		DiscountValue:    getIntValue(doc, "discount_value"),
+		MaxDiscount:      getIntValue(doc, "max_discount"),
+		MinOrderAmount:   getIntValue(doc, "min_order_amount"),
+		FundedBy:         aggregate.PromotionFunding(getString(doc, "funded_by")),
+		VendorID:         getString(doc, "vendor_id"),
+		ServiceIDs:       serviceIDs,
+		FirstBookingOnly: getBool(doc, "first_booking_only"),
+		StartsAt:         getTime(doc, "starts_at"),
+		EndsAt:           getTime(doc, "ends_at"),
+		UsageLimit:       getIntValue(doc, "usage_limit"),
+		PerUserLimit:     getIntValue(doc, "per_user_limit"),
+	}
+
+	return aggregate.ReconstructPromotion(
+		getString(doc, "_id"),
+		terms,
+		aggregate.PromotionStatus(getString(doc, "status")),
+		redemptions,
+		getString(doc, "created_by"),
+		getIntValue(doc, "version"),
+		getTime(doc, "created_at"),
+		getTime(doc, "updated_at"),
+	)
+}
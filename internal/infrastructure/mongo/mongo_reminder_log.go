@@ -0,0 +1,42 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoReminderLog records which reminders have been sent so each one fires only once
+type MongoReminderLog struct {
+	collection *mongo.Collection
+}
+
+// NewMongoReminderLog creates a new MongoDB reminder log
+func NewMongoReminderLog(database *mongo.Database) *MongoReminderLog {
+	return &MongoReminderLog{
+		collection: database.Collection("sent_reminders"),
+	}
+}
+
+// MarkSent records the reminder key and reports whether it was recorded for the first time
+func (l *MongoReminderLog) MarkSent(ctx context.Context, key string, sentAt time.Time) (bool, error) {
+	_, err := l.collection.InsertOne(ctx, bson.M{"_id": key, "sent_at": sentAt})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to record reminder %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Unmark forgets a reminder that could not be sent, so it is sent on a later run
+func (l *MongoReminderLog) Unmark(ctx context.Context, key string) error {
+	if _, err := l.collection.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		return fmt.Errorf("failed to forget reminder %s: %w", key, err)
+	}
+	return nil
+}
@@ -29,6 +29,21 @@ func NewMongoScheduleRepository(database *mongo.Database) *MongoScheduleReposito
 	}
 }
 
+// EnsureScheduleIndexes creates the indexes the lifecycle jobs rely on to find bookings by status and
+// by when they start, end or were made
+func EnsureScheduleIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "start_time", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "end_time", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+	}
+
+	if _, err := database.Collection("schedules").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create schedule indexes: %w", err)
+	}
+	return nil
+}
+
 // SetTransaction implements TransactionalRepository
 func (r *MongoScheduleRepository) SetTransaction(tx interface{}) {
 	if session, ok := tx.(mongo.Session); ok {
@@ -67,6 +82,19 @@ func (r *MongoScheduleRepository) Save(ctx context.Context, schedule *aggregate.
 		"is_active":    schedule.IsActive(),
 		"created_at":   schedule.CreatedAt(),
 		"updated_at":   schedule.UpdatedAt(),
+
+		"care_brief_consents": schedule.CareBriefConsents(),
+		"visit_report":        schedule.VisitReport(),
+
+		"payment_id":          schedule.PaymentID(),
+		"total_price":         schedule.TotalPrice(),
+		"series_id":           schedule.SeriesID(),
+		"reschedule_count":    schedule.RescheduleCount(),
+		"reschedule_proposal": schedule.RescheduleProposal(),
+		"price_adjustments":   schedule.PriceAdjustments(),
+
+		"assigned_staff": schedule.AssignedStaff(),
+		"pet_lines":      schedule.PetLines(),
 	}
 
 	// Upsert entity document to MongoDB
@@ -121,6 +149,66 @@ func (r *MongoScheduleRepository) GetByID(ctx context.Context, id string) (*aggr
 		return nil, fmt.Errorf("failed to get schedule from MongoDB: %w", err)
 	}
 
+	return documentToSchedule(result), nil
+}
+
+// GetStartingBetween retrieves the bookings in one of the statuses that start within the time range, soonest first
+func (r *MongoScheduleRepository) GetStartingBetween(ctx context.Context, statuses []aggregate.ScheduleStatus, from, to time.Time) ([]*aggregate.Schedule, error) {
+	filter := bson.M{
+		"status":     bson.M{"$in": statuses},
+		"start_time": bson.M{"$gte": from, "$lt": to},
+	}
+	return r.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}}))
+}
+
+// GetEndedBefore retrieves the bookings in one of the statuses that ended before the given time
+func (r *MongoScheduleRepository) GetEndedBefore(ctx context.Context, statuses []aggregate.ScheduleStatus, before time.Time) ([]*aggregate.Schedule, error) {
+	filter := bson.M{
+		"status":   bson.M{"$in": statuses},
+		"end_time": bson.M{"$lte": before},
+	}
+	return r.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "end_time", Value: 1}}))
+}
+
+// GetCreatedBefore retrieves the bookings in one of the statuses that were created before the given time
+func (r *MongoScheduleRepository) GetCreatedBefore(ctx context.Context, statuses []aggregate.ScheduleStatus, before time.Time) ([]*aggregate.Schedule, error) {
+	filter := bson.M{
+		"status":     bson.M{"$in": statuses},
+		"created_at": bson.M{"$lte": before},
+	}
+	return r.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+}
+
+// find runs a query and converts the matching documents to schedules
+func (r *MongoScheduleRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*aggregate.Schedule, error) {
+	var ctxToUse context.Context = ctx
+	if r.session != nil {
+		ctxToUse = mongo.NewSessionContext(ctx, r.session)
+	}
+
+	cursor, err := r.entityCollection.Find(ctxToUse, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find schedules: %w", err)
+	}
+	defer cursor.Close(ctxToUse)
+
+	schedules := []*aggregate.Schedule{}
+	for cursor.Next(ctxToUse) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode schedule: %w", err)
+		}
+		schedules = append(schedules, documentToSchedule(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+	return schedules, nil
+}
+
+// documentToSchedule reconstructs a schedule from its entity document
+func documentToSchedule(result bson.M) *aggregate.Schedule {
 	// Extract nested objects
 	var bookingUser aggregate.BookingUser
 	if bu, ok := result["booking_user"].(bson.M); ok {
@@ -166,20 +254,271 @@ func (r *MongoScheduleRepository) GetByID(ctx context.Context, id string) (*aggr
 		}
 	}
 
-	// Extract times
-	startTime, _ := result["start_time"].(time.Time)
-	endTime, _ := result["end_time"].(time.Time)
+	var careBriefConsents []aggregate.CareBriefConsent
+	if consents, ok := result["care_brief_consents"].(bson.A); ok {
+		for _, c := range consents {
+			if consent, ok := c.(bson.M); ok {
+				careBriefConsents = append(careBriefConsents, aggregate.CareBriefConsent{
+					PetID:    getScheduleString(consent, "pet_id"),
+					SharedBy: getScheduleString(consent, "shared_by"),
+					SharedAt: getTime(consent, "shared_at"),
+				})
+			}
+		}
+	} else if consent, ok := result["care_brief_consent"].(bson.M); ok {
+		// Stored before consent was kept per pet; it covers the primary pet
+		careBriefConsents = append(careBriefConsents, aggregate.CareBriefConsent{
+			PetID:    assignedPet.PetID,
+			SharedBy: getScheduleString(consent, "shared_by"),
+			SharedAt: getTime(consent, "shared_at"),
+		})
+	}
+
+	// Reconstruct schedule from document WITHOUT raising events
+	schedule := aggregate.ReconstructSchedule(
+		getScheduleString(result, "_id"),
+		bookingUser,
+		bookedShop,
+		assignedPet,
+		getTime(result, "start_time"),
+		getTime(result, "end_time"),
+		aggregate.ScheduleStatus(getScheduleString(result, "status")),
+		careBriefConsents,
+		getScheduleVisitReport(result),
+		getScheduleInt(result, "version"),
+		getTime(result, "created_at"),
+		getTime(result, "updated_at"),
+		getBool(result, "is_active"),
+	)
+	schedule.SetBilling(getScheduleString(result, "payment_id"), getScheduleInt(result, "total_price"))
+	schedule.SetSeriesID(getScheduleString(result, "series_id"))
+	schedule.SetReschedules(
+		getScheduleInt(result, "reschedule_count"),
+		getScheduleRescheduleProposal(result),
+		getSchedulePriceAdjustments(result),
+	)
+	schedule.SetAssignedStaff(getScheduleAssignedStaff(result))
+	schedule.SetPetLines(getSchedulePetLines(result))
+
+	return schedule
+}
+
+// HasOverlappingBooking checks if the shop has an open booking other than the given one that overlaps
+// the time range
+func (r *MongoScheduleRepository) HasOverlappingBooking(ctx context.Context, shopID string, startTime, endTime time.Time, excludeID string) (bool, error) {
+	var ctxToUse context.Context = ctx
+	if r.session != nil {
+		ctxToUse = mongo.NewSessionContext(ctx, r.session)
+	}
+
+	filter := bson.M{
+		"_id":                 bson.M{"$ne": excludeID},
+		"booked_shop.shop_id": shopID,
+		"status":              bson.M{"$in": openScheduleStatuses()},
+		"start_time":          bson.M{"$lt": endTime},
+		"end_time":            bson.M{"$gt": startTime},
+	}
+
+	count, err := r.entityCollection.CountDocuments(ctxToUse, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check overlapping bookings: %w", err)
+	}
+	return count > 0, nil
+}
+
+// HasOverlappingStaffBooking checks if the staff member is assigned an open booking other than the given one that overlaps
+func (r *MongoScheduleRepository) HasOverlappingStaffBooking(ctx context.Context, staffID string, startTime, endTime time.Time, excludeID string) (bool, error) {
+	var ctxToUse context.Context = ctx
+	if r.session != nil {
+		ctxToUse = mongo.NewSessionContext(ctx, r.session)
+	}
+
+	filter := bson.M{
+		"_id":                    bson.M{"$ne": excludeID},
+		"assigned_staff.user_id": staffID,
+		"status":                 bson.M{"$in": openScheduleStatuses()},
+		"start_time":             bson.M{"$lt": endTime},
+		"end_time":               bson.M{"$gt": startTime},
+	}
+
+	count, err := r.entityCollection.CountDocuments(ctxToUse, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check overlapping staff bookings: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CountStaffBookings counts the open bookings assigned to the staff member that start within the time range
+func (r *MongoScheduleRepository) CountStaffBookings(ctx context.Context, staffID string, from, to time.Time) (int, error) {
+	var ctxToUse context.Context = ctx
+	if r.session != nil {
+		ctxToUse = mongo.NewSessionContext(ctx, r.session)
+	}
+
+	filter := bson.M{
+		"assigned_staff.user_id": staffID,
+		"status":                 bson.M{"$in": openScheduleStatuses()},
+		"start_time":             bson.M{"$gte": from, "$lt": to},
+	}
 
-	// Reconstruct schedule from document
-	schedule, err := aggregate.NewSchedule(bookingUser, bookedShop, assignedPet, startTime, endTime)
+	count, err := r.entityCollection.CountDocuments(ctxToUse, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reconstruct schedule: %w", err)
+		return 0, fmt.Errorf("failed to count staff bookings: %w", err)
+	}
+	return int(count), nil
+}
+
+// openScheduleStatuses are the statuses of bookings that still take up time
+func openScheduleStatuses() []aggregate.ScheduleStatus {
+	return []aggregate.ScheduleStatus{
+		aggregate.ScheduleStatusPending,
+		aggregate.ScheduleStatusConfirmed,
+		aggregate.ScheduleStatusInProgress,
+	}
+}
+
+// getScheduleAssignedStaff extracts the staff member assigned to a schedule document, if any
+func getScheduleAssignedStaff(doc bson.M) *aggregate.AssignedStaff {
+	staffMap, ok := doc["assigned_staff"].(bson.M)
+	if !ok {
+		return nil
+	}
+
+	return &aggregate.AssignedStaff{
+		UserID:       getScheduleString(staffMap, "user_id"),
+		AssignedBy:   getScheduleString(staffMap, "assigned_by"),
+		AutoAssigned: getBool(staffMap, "auto_assigned"),
+		AssignedAt:   getTime(staffMap, "assigned_at"),
+	}
+}
+
+// getScheduleRescheduleProposal extracts the reschedule proposal waiting for the customer, if any
+func getScheduleRescheduleProposal(doc bson.M) *event.RescheduleProposal {
+	proposalMap, ok := doc["reschedule_proposal"].(bson.M)
+	if !ok {
+		return nil
+	}
+
+	return &event.RescheduleProposal{
+		ID:         getScheduleString(proposalMap, "id"),
+		StartTime:  getTime(proposalMap, "start_time"),
+		EndTime:    getTime(proposalMap, "end_time"),
+		Reason:     getScheduleString(proposalMap, "reason"),
+		ProposedBy: getScheduleString(proposalMap, "proposed_by"),
+		ProposedAt: getTime(proposalMap, "proposed_at"),
+	}
+}
+
+// getSchedulePriceAdjustments extracts the price adjustments of a schedule document
+func getSchedulePriceAdjustments(doc bson.M) []event.SchedulePriceAdjustment {
+	adjustments := []event.SchedulePriceAdjustment{}
+	items, ok := doc["price_adjustments"].(bson.A)
+	if !ok {
+		return adjustments
+	}
+
+	for _, item := range items {
+		if adjustmentDoc, ok := item.(bson.M); ok {
+			adjustments = append(adjustments, event.SchedulePriceAdjustment{
+				ID:        getScheduleString(adjustmentDoc, "id"),
+				Kind:      getScheduleString(adjustmentDoc, "kind"),
+				OldPrice:  getScheduleInt(adjustmentDoc, "old_price"),
+				NewPrice:  getScheduleInt(adjustmentDoc, "new_price"),
+				Amount:    getScheduleInt(adjustmentDoc, "amount"),
+				PaymentID: getScheduleString(adjustmentDoc, "payment_id"),
+				RefundID:  getScheduleString(adjustmentDoc, "refund_id"),
+				Reason:    getScheduleString(adjustmentDoc, "reason"),
+				CreatedAt: getTime(adjustmentDoc, "created_at"),
+			})
+		}
+	}
+	return adjustments
+}
+
+// getSchedulePetLines extracts the pet lines of a booking covering several pets
+func getSchedulePetLines(doc bson.M) []event.SchedulePetLine {
+	items, ok := doc["pet_lines"].(bson.A)
+	if !ok {
+		return nil
+	}
+
+	var lines []event.SchedulePetLine
+	for _, item := range items {
+		lineDoc, ok := item.(bson.M)
+		if !ok {
+			continue
+		}
+		line := event.SchedulePetLine{
+			PetID:           getScheduleString(lineDoc, "pet_id"),
+			PetName:         getScheduleString(lineDoc, "pet_name"),
+			Species:         getScheduleString(lineDoc, "species"),
+			Breed:           getScheduleString(lineDoc, "breed"),
+			Age:             getScheduleInt(lineDoc, "age"),
+			Weight:          getScheduleFloat64(lineDoc, "weight"),
+			DurationMinutes: getScheduleInt(lineDoc, "duration_minutes"),
+			Price:           getScheduleInt(lineDoc, "price"),
+			Status:          getScheduleString(lineDoc, "status"),
+			CancelledAt:     getTime(lineDoc, "cancelled_at"),
+		}
+		if services, ok := lineDoc["services"].(bson.A); ok {
+			for _, svc := range services {
+				if svcDoc, ok := svc.(bson.M); ok {
+					line.Services = append(line.Services, event.ScheduleLineService{
+						ServiceID: getScheduleString(svcDoc, "service_id"),
+						Name:      getScheduleString(svcDoc, "name"),
+					})
+				}
+			}
+		}
+		lines = append(lines, line)
 	}
+	return lines
+}
 
-	// Set version from database
-	schedule.SetVersion(getScheduleInt(result, "version"))
+// getScheduleVisitReport extracts the visit report of a schedule document, if one was submitted
+func getScheduleVisitReport(doc bson.M) *event.VisitReport {
+	reportMap, ok := doc["visit_report"].(bson.M)
+	if !ok {
+		return nil
+	}
 
-	return schedule, nil
+	report := &event.VisitReport{
+		ID:                getScheduleString(reportMap, "id"),
+		ServicesPerformed: []event.VisitReportService{},
+		Observations:      getScheduleString(reportMap, "observations"),
+		Weight:            getScheduleFloat64(reportMap, "weight"),
+		PhotoURLs:         getStringArray(reportMap, "photo_urls"),
+		Recommendations:   getScheduleString(reportMap, "recommendations"),
+		SubmittedBy:       getScheduleString(reportMap, "submitted_by"),
+		SubmittedAt:       getTime(reportMap, "submitted_at"),
+		AcceptedBy:        getScheduleString(reportMap, "accepted_by"),
+		AcceptedAt:        getTime(reportMap, "accepted_at"),
+		MedicalRecordID:   getScheduleString(reportMap, "medical_record_id"),
+	}
+	if records, ok := reportMap["medical_records"].(bson.A); ok {
+		for _, rec := range records {
+			if recDoc, ok := rec.(bson.M); ok {
+				report.MedicalRecords = append(report.MedicalRecords, event.VisitReportRecord{
+					PetID:           getScheduleString(recDoc, "pet_id"),
+					MedicalRecordID: getScheduleString(recDoc, "medical_record_id"),
+					AcceptedBy:      getScheduleString(recDoc, "accepted_by"),
+					AcceptedAt:      getTime(recDoc, "accepted_at"),
+				})
+			}
+		}
+	}
+	if services, ok := reportMap["services_performed"].(bson.A); ok {
+		for _, svc := range services {
+			if svcDoc, ok := svc.(bson.M); ok {
+				report.ServicesPerformed = append(report.ServicesPerformed, event.VisitReportService{
+					ServiceID: getScheduleString(svcDoc, "service_id"),
+					Name:      getScheduleString(svcDoc, "name"),
+					Notes:     getScheduleString(svcDoc, "notes"),
+				})
+			}
+		}
+	}
+	return report
 }
 
 // getScheduleString safely extracts a string from a bson.M document
@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSequence hands out sequential numbers (e.g. invoice numbers) from a counters collection
+type MongoSequence struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSequence creates a new MongoDB sequence generator
+func NewMongoSequence(database *mongo.Database) *MongoSequence {
+	return &MongoSequence{
+		collection: database.Collection("counters"),
+	}
+}
+
+// Next atomically increments the named counter and returns its new value, starting at 1
+func (s *MongoSequence) Next(ctx context.Context, name string) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var result struct {
+		Value int64 `bson:"value"`
+	}
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"_id": name}, bson.M{"$inc": bson.M{"value": 1}}, opts).Decode(&result)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment sequence %s: %w", name, err)
+	}
+
+	return result.Value, nil
+}
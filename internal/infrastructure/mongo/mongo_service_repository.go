@@ -65,8 +65,14 @@ func (r *MongoServiceRepository) Save(ctx context.Context, service *aggregate.Se
 		"duration":    service.Duration().Minutes(), // Store as minutes
 		"tags":        service.Tags(),
 		"is_active":   service.IsActive(),
-		"created_at":  service.CreatedAt(),
-		"updated_at":  service.UpdatedAt(),
+		"eligibility": bson.M{
+			"species":    service.Eligibility().Species,
+			"sizes":      service.Eligibility().Sizes,
+			"min_weight": service.Eligibility().MinWeight,
+			"max_weight": service.Eligibility().MaxWeight,
+		},
+		"created_at": service.CreatedAt(),
+		"updated_at": service.UpdatedAt(),
 	}
 
 	// Upsert entity document to MongoDB
@@ -143,10 +149,26 @@ func (r *MongoServiceRepository) GetByID(ctx context.Context, id string) (*aggre
 		getServiceTime(result, "updated_at"),
 		getServiceBool(result, "is_active"),
 	)
+	service.SetEligibility(getServiceEligibility(result))
 
 	return service, nil
 }
 
+// getServiceEligibility extracts which pets a service accepts; services saved before eligibility existed accept all pets
+func getServiceEligibility(doc bson.M) aggregate.ServiceEligibility {
+	eligibilityDoc, ok := doc["eligibility"].(bson.M)
+	if !ok {
+		return aggregate.ServiceEligibility{Species: []string{}, Sizes: []string{}}
+	}
+
+	return aggregate.ServiceEligibility{
+		Species:   getStringArray(eligibilityDoc, "species"),
+		Sizes:     getStringArray(eligibilityDoc, "sizes"),
+		MinWeight: getServiceFloat64(eligibilityDoc, "min_weight"),
+		MaxWeight: getServiceFloat64(eligibilityDoc, "max_weight"),
+	}
+}
+
 // getServiceString safely extracts a string from a bson.M document
 func getServiceString(doc bson.M, key string) string {
 	if val, ok := doc[key].(string); ok {
@@ -220,6 +242,19 @@ func getServiceTags(doc bson.M, key string) []string {
 	return []string{}
 }
 
+// getStringArray safely extracts a string array from a bson.M document
+func getStringArray(doc bson.M, key string) []string {
+	values := []string{}
+	if items, ok := doc[key].(bson.A); ok {
+		for _, item := range items {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+	}
+	return values
+}
+
 // SaveEvents saves events for a service aggregate
 func (r *MongoServiceRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
 	return nil // Stub implementation
@@ -0,0 +1,323 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSettlementRepository implements SettlementRepository with MongoDB persistence
+type MongoSettlementRepository struct {
+	database         *mongo.Database
+	entityCollection *mongo.Collection
+	eventCollection  *mongo.Collection
+	session          mongo.Session
+}
+
+// NewMongoSettlementRepository creates a new MongoDB settlement repository
+func NewMongoSettlementRepository(database *mongo.Database) repository.SettlementRepository {
+	return &MongoSettlementRepository{
+		database:         database,
+		entityCollection: database.Collection("settlements"),
+		eventCollection:  database.Collection("settlement_events"),
+	}
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoSettlementRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoSettlementRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoSettlementRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoSettlementRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Save stores a settlement aggregate to MongoDB
+func (r *MongoSettlementRepository) Save(ctx context.Context, settlement *aggregate.Settlement) error {
+	ctx = r.getContext(ctx)
+
+	// First, save the events
+	events := settlement.GetUncommittedEvents()
+	if len(events) > 0 {
+		if err := r.SaveEvents(ctx, settlement.ID(), events, settlement.Version()-len(events)); err != nil {
+			return fmt.Errorf("failed to save events: %w", err)
+		}
+	}
+
+	settlementDoc := bson.M{
+		"_id":               settlement.ID(),
+		"vendor_id":         settlement.VendorID(),
+		"period":            string(settlement.Period()),
+		"period_start":      settlement.PeriodStart(),
+		"period_end":        settlement.PeriodEnd(),
+		"line_items":        settlement.LineItems(),
+		"carried_in_amount": settlement.CarriedInAmount(),
+		"carried_in_from":   settlement.CarriedInFrom(),
+		"total_amount":      settlement.TotalAmount(),
+		"status":            string(settlement.Status()),
+		"payout_id":         settlement.PayoutID(),
+		"commission":        settlement.Commission(),
+		"carried_over_to":   settlement.CarriedOverTo(),
+		"closed_reason":     settlement.ClosedReason(),
+		"version":           settlement.Version(),
+		"created_at":        settlement.CreatedAt(),
+		"updated_at":        settlement.UpdatedAt(),
+	}
+
+	// Use upsert to insert or update
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.entityCollection.ReplaceOne(ctx, bson.M{"_id": settlement.ID()}, settlementDoc, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save settlement: %w", err)
+	}
+
+	if len(events) > 0 {
+		settlement.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a settlement by ID
+func (r *MongoSettlementRepository) GetByID(ctx context.Context, id string) (*aggregate.Settlement, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("settlement not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get settlement: %w", err)
+	}
+
+	return documentToSettlement(result), nil
+}
+
+// GetByVendorID retrieves settlements for a vendor with pagination (newest period first)
+func (r *MongoSettlementRepository) GetByVendorID(ctx context.Context, vendorID string, offset, limit int) ([]*aggregate.Settlement, error) {
+	ctx = r.getContext(ctx)
+
+	opts := options.Find().
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "period_start", Value: -1}})
+
+	return r.find(ctx, bson.M{"vendor_id": vendorID}, opts)
+}
+
+// GetOpenForVendorAt retrieves the vendor's open settlement whose period covers the given time
+func (r *MongoSettlementRepository) GetOpenForVendorAt(ctx context.Context, vendorID string, at time.Time) (*aggregate.Settlement, error) {
+	ctx = r.getContext(ctx)
+
+	filter := bson.M{
+		"vendor_id":    vendorID,
+		"status":       string(aggregate.SettlementStatusOpen),
+		"period_start": bson.M{"$lte": at},
+		"period_end":   bson.M{"$gt": at},
+	}
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // No open settlement for this period yet
+		}
+		return nil, fmt.Errorf("failed to get open settlement: %w", err)
+	}
+
+	return documentToSettlement(result), nil
+}
+
+// GetDue retrieves all open settlements whose period has ended
+func (r *MongoSettlementRepository) GetDue(ctx context.Context, now time.Time) ([]*aggregate.Settlement, error) {
+	ctx = r.getContext(ctx)
+
+	filter := bson.M{
+		"status":     string(aggregate.SettlementStatusOpen),
+		"period_end": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "period_end", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// GetByPaymentID retrieves the settlement that includes a payment
+func (r *MongoSettlementRepository) GetByPaymentID(ctx context.Context, paymentID string) (*aggregate.Settlement, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"line_items": bson.M{"$elemMatch": bson.M{"payment_id": paymentID, "refund_id": bson.M{"$exists": false}}}}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Payment not settled yet
+		}
+		return nil, fmt.Errorf("failed to get settlement by payment: %w", err)
+	}
+
+	return documentToSettlement(result), nil
+}
+
+// find runs a query and converts all matching documents to settlements
+func (r *MongoSettlementRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*aggregate.Settlement, error) {
+	cursor, err := r.entityCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find settlements: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var settlements []*aggregate.Settlement
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode settlement: %w", err)
+		}
+		settlements = append(settlements, documentToSettlement(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return settlements, nil
+}
+
+// SaveEvents saves domain events for a settlement
+func (r *MongoSettlementRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
+	ctx = r.getContext(ctx)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var eventDocs []interface{}
+	for i, e := range events {
+		eventDoc := bson.M{
+			"aggregate_id":  aggregateID,
+			"event_type":    e.EventType(),
+			"event_version": expectedVersion + i + 1,
+			"occurred_at":   e.OccurredAt(),
+			"event_data":    e,
+		}
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	_, err := r.eventCollection.InsertMany(ctx, eventDocs)
+	if err != nil {
+		return fmt.Errorf("failed to save settlement events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves all events for a settlement
+func (r *MongoSettlementRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	// Settlements are loaded from entity state; event replay is not needed
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsSince retrieves events after a specific version
+func (r *MongoSettlementRepository) GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error) {
+	return r.GetEvents(ctx, aggregateID)
+}
+
+// GetAllEvents retrieves all events
+func (r *MongoSettlementRepository) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsOfTypeSince retrieves the stored settlement events of the given types that occurred at or after since
+func (r *MongoSettlementRepository) GetEventsOfTypeSince(ctx context.Context, since time.Time, eventTypes ...string) ([]event.DomainEvent, error) {
+	return findEventsOfTypeSince(r.getContext(ctx), r.eventCollection, since, eventTypes, newSettlementEvent)
+}
+
+// newSettlementEvent returns an empty settlement event of the given type to decode a stored event into
+func newSettlementEvent(eventType string) event.DomainEvent {
+	switch eventType {
+	case "SettlementOpened":
+		return &event.SettlementOpened{}
+	case "SettlementLineItemAdded":
+		return &event.SettlementLineItemAdded{}
+	case "SettlementBalanceCarriedIn":
+		return &event.SettlementBalanceCarriedIn{}
+	case "SettlementCarriedOver":
+		return &event.SettlementCarriedOver{}
+	case "SettlementClosed":
+		return &event.SettlementClosed{}
+	case "SettlementPaidOut":
+		return &event.SettlementPaidOut{}
+	}
+	return nil
+}
+
+// documentToSettlement converts a MongoDB document to a Settlement aggregate
+func documentToSettlement(doc bson.M) *aggregate.Settlement {
+	lineItems := []event.SettlementLineItem{}
+	if items, ok := doc["line_items"].(bson.A); ok {
+		for _, item := range items {
+			if itemDoc, ok := item.(bson.M); ok {
+				lineItems = append(lineItems, event.SettlementLineItem{
+					PaymentID:  getString(itemDoc, "payment_id"),
+					ScheduleID: getString(itemDoc, "schedule_id"),
+					RefundID:   getString(itemDoc, "refund_id"),
+					Amount:     getIntValue(itemDoc, "amount"),
+					EarnedAt:   getTime(itemDoc, "earned_at"),
+				})
+			}
+		}
+	}
+
+	carriedInFrom := []string{}
+	if ids, ok := doc["carried_in_from"].(bson.A); ok {
+		for _, id := range ids {
+			if idStr, ok := id.(string); ok {
+				carriedInFrom = append(carriedInFrom, idStr)
+			}
+		}
+	}
+
+	return aggregate.ReconstructSettlement(
+		getString(doc, "_id"),
+		getString(doc, "vendor_id"),
+		aggregate.SettlementPeriod(getString(doc, "period")),
+		getTime(doc, "period_start"),
+		getTime(doc, "period_end"),
+		lineItems,
+		getIntValue(doc, "carried_in_amount"),
+		carriedInFrom,
+		aggregate.SettlementStatus(getString(doc, "status")),
+		getString(doc, "payout_id"),
+		getIntValue(doc, "commission"),
+		getString(doc, "carried_over_to"),
+		getString(doc, "closed_reason"),
+		getIntValue(doc, "version"),
+		getTime(doc, "created_at"),
+		getTime(doc, "updated_at"),
+	)
+}
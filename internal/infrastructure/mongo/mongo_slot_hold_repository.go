@@ -0,0 +1,259 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSlotHoldRepository implements SlotHoldRepository with MongoDB persistence
+type MongoSlotHoldRepository struct {
+	database         *mongo.Database
+	entityCollection *mongo.Collection
+	eventCollection  *mongo.Collection
+	lockCollection   *mongo.Collection
+	session          mongo.Session
+}
+
+// NewMongoSlotHoldRepository creates a new MongoDB slot hold repository
+func NewMongoSlotHoldRepository(database *mongo.Database) repository.SlotHoldRepository {
+	return &MongoSlotHoldRepository{
+		database:         database,
+		entityCollection: database.Collection("slot_holds"),
+		eventCollection:  database.Collection("slot_hold_events"),
+		lockCollection:   database.Collection("slot_hold_locks"),
+	}
+}
+
+// EnsureSlotHoldIndexes creates the indexes the slot hold collection relies on. A payment holds at most
+// one time, and the shop, status and time index keeps the overlap check on every checkout cheap.
+func EnsureSlotHoldIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "payment_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "shop_id", Value: 1}, {Key: "status", Value: 1}, {Key: "start_time", Value: 1}}},
+	}
+
+	if _, err := database.Collection("slot_holds").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create slot hold indexes: %w", err)
+	}
+	return nil
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoSlotHoldRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoSlotHoldRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoSlotHoldRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoSlotHoldRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Save stores a slot hold aggregate to MongoDB
+func (r *MongoSlotHoldRepository) Save(ctx context.Context, hold *aggregate.SlotHold) error {
+	ctx = r.getContext(ctx)
+
+	// First, save the events
+	events := hold.GetUncommittedEvents()
+	if len(events) > 0 {
+		if err := r.SaveEvents(ctx, hold.ID(), events, hold.Version()-len(events)); err != nil {
+			return fmt.Errorf("failed to save events: %w", err)
+		}
+	}
+
+	holdDoc := bson.M{
+		"_id":         hold.ID(),
+		"payment_id":  hold.PaymentID(),
+		"user_id":     hold.UserID(),
+		"shop_id":     hold.ShopID(),
+		"start_time":  hold.StartTime(),
+		"end_time":    hold.EndTime(),
+		"expires_at":  hold.ExpiresAt(),
+		"status":      string(hold.Status()),
+		"schedule_id": hold.ScheduleID(),
+		"reason":      hold.Reason(),
+		"version":     hold.Version(),
+		"created_at":  hold.CreatedAt(),
+		"updated_at":  hold.UpdatedAt(),
+	}
+
+	// Use upsert to insert or update
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.entityCollection.ReplaceOne(ctx, bson.M{"_id": hold.ID()}, holdDoc, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("payment already holds a time: %s", hold.PaymentID())
+		}
+		return fmt.Errorf("failed to save slot hold: %w", err)
+	}
+
+	if len(events) > 0 {
+		hold.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a slot hold by its ID
+func (r *MongoSlotHoldRepository) GetByID(ctx context.Context, id string) (*aggregate.SlotHold, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("slot hold not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get slot hold: %w", err)
+	}
+
+	return documentToSlotHold(result), nil
+}
+
+// GetByPaymentID retrieves the hold placed for a payment, or nil if the payment holds no time
+func (r *MongoSlotHoldRepository) GetByPaymentID(ctx context.Context, paymentID string) (*aggregate.SlotHold, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"payment_id": paymentID}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get slot hold: %w", err)
+	}
+
+	return documentToSlotHold(result), nil
+}
+
+// HasOverlappingHold checks if the shop has a hold other than the given payment's that is active at now
+// and overlaps the time range. Lapsed holds the expiry service has not released yet are ignored.
+func (r *MongoSlotHoldRepository) HasOverlappingHold(ctx context.Context, shopID string, startTime, endTime time.Time, excludePaymentID string, now time.Time) (bool, error) {
+	ctx = r.getContext(ctx)
+
+	filter := bson.M{
+		"payment_id": bson.M{"$ne": excludePaymentID},
+		"shop_id":    shopID,
+		"status":     string(aggregate.SlotHoldStatusHeld),
+		"expires_at": bson.M{"$gt": now},
+		"start_time": bson.M{"$lt": endTime},
+		"end_time":   bson.M{"$gt": startTime},
+	}
+
+	count, err := r.entityCollection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check overlapping holds: %w", err)
+	}
+	return count > 0, nil
+}
+
+// LockShopSlots writes the shop's lock document inside the caller's transaction. MongoDB aborts one of
+// two transactions writing the same document, so concurrent checkouts at a shop cannot both pass the
+// overlap checks.
+func (r *MongoSlotHoldRepository) LockShopSlots(ctx context.Context, shopID string) error {
+	ctx = r.getContext(ctx)
+
+	update := bson.M{
+		"$inc": bson.M{"version": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	opts := options.Update().SetUpsert(true)
+	_, err := r.lockCollection.UpdateOne(ctx, bson.M{"_id": shopID}, update, opts)
+	if err != nil {
+		var serverErr mongo.ServerError
+		if mongo.IsDuplicateKeyError(err) || (errors.As(err, &serverErr) && serverErr.HasErrorLabel("TransientTransactionError")) {
+			return repository.ErrShopSlotsBusy
+		}
+		return fmt.Errorf("failed to lock shop slots: %w", err)
+	}
+	return nil
+}
+
+// SaveEvents saves domain events for a slot hold
+func (r *MongoSlotHoldRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
+	ctx = r.getContext(ctx)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var eventDocs []interface{}
+	for i, e := range events {
+		eventDoc := bson.M{
+			"aggregate_id":  aggregateID,
+			"event_type":    e.EventType(),
+			"event_version": expectedVersion + i + 1,
+			"occurred_at":   e.OccurredAt(),
+			"event_data":    e,
+		}
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	_, err := r.eventCollection.InsertMany(ctx, eventDocs)
+	if err != nil {
+		return fmt.Errorf("failed to save slot hold events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves all events for a slot hold
+func (r *MongoSlotHoldRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	// Slot holds are loaded from entity state; event replay is not needed
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsSince retrieves events after a specific version
+func (r *MongoSlotHoldRepository) GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error) {
+	return r.GetEvents(ctx, aggregateID)
+}
+
+// GetAllEvents retrieves all events
+func (r *MongoSlotHoldRepository) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return []event.DomainEvent{}, nil
+}
+
+// documentToSlotHold converts a MongoDB document to a SlotHold aggregate
+func documentToSlotHold(doc bson.M) *aggregate.SlotHold {
+	return aggregate.ReconstructSlotHold(
+		getString(doc, "_id"),
+		getString(doc, "payment_id"),
+		getString(doc, "user_id"),
+		getString(doc, "shop_id"),
+		getTime(doc, "start_time"),
+		getTime(doc, "end_time"),
+		getTime(doc, "expires_at"),
+		aggregate.SlotHoldStatus(getString(doc, "status")),
+		getString(doc, "schedule_id"),
+		getString(doc, "reason"),
+		getIntValue(doc, "version"),
+		getTime(doc, "created_at"),
+		getTime(doc, "updated_at"),
+	)
+}
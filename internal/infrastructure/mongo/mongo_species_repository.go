@@ -0,0 +1,261 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSpeciesRepository implements SpeciesRepository with MongoDB persistence
+type MongoSpeciesRepository struct {
+	database         *mongo.Database
+	entityCollection *mongo.Collection
+	eventCollection  *mongo.Collection
+	session          mongo.Session
+}
+
+// NewMongoSpeciesRepository creates a new MongoDB species repository
+func NewMongoSpeciesRepository(database *mongo.Database) repository.SpeciesRepository {
+	return &MongoSpeciesRepository{
+		database:         database,
+		entityCollection: database.Collection("species"),
+		eventCollection:  database.Collection("species_events"),
+	}
+}
+
+// EnsureSpeciesIndexes creates the indexes the species collection relies on.
+// The unique terms index stops two species from sharing a name or alias.
+func EnsureSpeciesIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "terms", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	if _, err := database.Collection("species").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create species indexes: %w", err)
+	}
+	return nil
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoSpeciesRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoSpeciesRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoSpeciesRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoSpeciesRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Save stores a species aggregate to MongoDB
+func (r *MongoSpeciesRepository) Save(ctx context.Context, species *aggregate.Species) error {
+	ctx = r.getContext(ctx)
+
+	// First, save the events
+	events := species.GetUncommittedEvents()
+	if len(events) > 0 {
+		if err := r.SaveEvents(ctx, species.ID(), events, species.Version()-len(events)); err != nil {
+			return fmt.Errorf("failed to save events: %w", err)
+		}
+	}
+
+	speciesDoc := bson.M{
+		"_id":        species.ID(),
+		"name_en":    species.NameEn(),
+		"name_vi":    species.NameVi(),
+		"aliases":    species.Aliases(),
+		"breeds":     species.Breeds(),
+		"terms":      species.Terms(),
+		"is_active":  species.IsActive(),
+		"created_by": species.CreatedBy(),
+		"version":    species.Version(),
+		"created_at": species.CreatedAt(),
+		"updated_at": species.UpdatedAt(),
+	}
+
+	// Use upsert to insert or update
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.entityCollection.ReplaceOne(ctx, bson.M{"_id": species.ID()}, speciesDoc, opts)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("species name or alias is already used by another species")
+		}
+		return fmt.Errorf("failed to save species: %w", err)
+	}
+
+	if len(events) > 0 {
+		species.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a species by its code
+func (r *MongoSpeciesRepository) GetByID(ctx context.Context, id string) (*aggregate.Species, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("species not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get species: %w", err)
+	}
+
+	return documentToSpecies(result), nil
+}
+
+// GetByTerm retrieves the species a code, name or alias refers to
+func (r *MongoSpeciesRepository) GetByTerm(ctx context.Context, term string) (*aggregate.Species, error) {
+	ctx = r.getContext(ctx)
+
+	normalized := aggregate.NormalizeCatalogTerm(term)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"terms": normalized}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // No species uses this term
+		}
+		return nil, fmt.Errorf("failed to get species by term: %w", err)
+	}
+
+	return documentToSpecies(result), nil
+}
+
+// List retrieves the species catalogue ordered by code
+func (r *MongoSpeciesRepository) List(ctx context.Context, includeInactive bool) ([]*aggregate.Species, error) {
+	ctx = r.getContext(ctx)
+
+	filter := bson.M{}
+	if !includeInactive {
+		filter["is_active"] = true
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := r.entityCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find species: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	species := []*aggregate.Species{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode species: %w", err)
+		}
+		species = append(species, documentToSpecies(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return species, nil
+}
+
+// SaveEvents saves domain events for a species
+func (r *MongoSpeciesRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
+	ctx = r.getContext(ctx)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var eventDocs []interface{}
+	for i, e := range events {
+		eventDoc := bson.M{
+			"aggregate_id":  aggregateID,
+			"event_type":    e.EventType(),
+			"event_version": expectedVersion + i + 1,
+			"occurred_at":   e.OccurredAt(),
+			"event_data":    e,
+		}
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	_, err := r.eventCollection.InsertMany(ctx, eventDocs)
+	if err != nil {
+		return fmt.Errorf("failed to save species events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves all events for a species
+func (r *MongoSpeciesRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	// Species are loaded from entity state; event replay is not needed
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsSince retrieves events after a specific version
+func (r *MongoSpeciesRepository) GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error) {
+	return r.GetEvents(ctx, aggregateID)
+}
+
+// GetAllEvents retrieves all events
+func (r *MongoSpeciesRepository) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return []event.DomainEvent{}, nil
+}
+
+// documentToSpecies converts a MongoDB document to a Species aggregate
+func documentToSpecies(doc bson.M) *aggregate.Species {
+	breeds := []event.SpeciesBreed{}
+	if items, ok := doc["breeds"].(bson.A); ok {
+		for _, item := range items {
+			if itemDoc, ok := item.(bson.M); ok {
+				breeds = append(breeds, event.SpeciesBreed{
+					Code:    getString(itemDoc, "code"),
+					NameEn:  getString(itemDoc, "name_en"),
+					NameVi:  getString(itemDoc, "name_vi"),
+					Aliases: getStringArray(itemDoc, "aliases"),
+					Size:    getString(itemDoc, "size"),
+				})
+			}
+		}
+	}
+
+	return aggregate.ReconstructSpecies(
+		getString(doc, "_id"),
+		getString(doc, "name_en"),
+		getString(doc, "name_vi"),
+		getStringArray(doc, "aliases"),
+		breeds,
+		getBool(doc, "is_active"),
+		getString(doc, "created_by"),
+		getIntValue(doc, "version"),
+		getTime(doc, "created_at"),
+		getTime(doc, "updated_at"),
+	)
+}
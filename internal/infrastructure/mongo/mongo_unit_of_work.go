@@ -20,14 +20,21 @@ type MongoUnitOfWork struct {
 	inTransaction bool
 
 	// Repository instances
-	userRepo         repository.UserRepository
-	paymentRepo      repository.PaymentRepository
-	petRepo          repository.PetRepository
-	vendorRepo       repository.VendorRepository
-	serviceRepo      repository.ServiceRepository
-	scheduleRepo     repository.ScheduleRepository
-	vendorStaffRepo  repository.VendorStaffRepository
-	payoutRepo       repository.PayoutRepository
+	userRepo          repository.UserRepository
+	paymentRepo       repository.PaymentRepository
+	petRepo           repository.PetRepository
+	vendorRepo        repository.VendorRepository
+	serviceRepo       repository.ServiceRepository
+	scheduleRepo      repository.ScheduleRepository
+	vendorStaffRepo   repository.VendorStaffRepository
+	payoutRepo        repository.PayoutRepository
+	settlementRepo    repository.SettlementRepository
+	ledgerRepo        repository.LedgerRepository
+	promotionRepo     repository.PromotionRepository
+	speciesRepo       repository.SpeciesRepository
+	bookingSeriesRepo repository.BookingSeriesRepository
+	slotHoldRepo      repository.SlotHoldRepository
+	waitlistRepo      repository.WaitlistRepository
 }
 
 // NewMongoUnitOfWork creates a new MongoDB unit of work
@@ -242,6 +249,125 @@ func (uow *MongoUnitOfWork) PayoutRepository() repository.PayoutRepository {
 	return uow.payoutRepo
 }
 
+// SettlementRepository returns the settlement repository
+func (uow *MongoUnitOfWork) SettlementRepository() repository.SettlementRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.settlementRepo == nil {
+		uow.settlementRepo = NewMongoSettlementRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.settlementRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.settlementRepo
+}
+
+// LedgerRepository returns the ledger repository
+func (uow *MongoUnitOfWork) LedgerRepository() repository.LedgerRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.ledgerRepo == nil {
+		uow.ledgerRepo = NewMongoLedgerRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.ledgerRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.ledgerRepo
+}
+
+// PromotionRepository returns the promotion repository
+func (uow *MongoUnitOfWork) PromotionRepository() repository.PromotionRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.promotionRepo == nil {
+		uow.promotionRepo = NewMongoPromotionRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.promotionRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.promotionRepo
+}
+
+// SpeciesRepository returns the species catalogue repository
+func (uow *MongoUnitOfWork) SpeciesRepository() repository.SpeciesRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.speciesRepo == nil {
+		uow.speciesRepo = NewMongoSpeciesRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.speciesRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.speciesRepo
+}
+
+// BookingSeriesRepository returns the recurring booking series repository
+func (uow *MongoUnitOfWork) BookingSeriesRepository() repository.BookingSeriesRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.bookingSeriesRepo == nil {
+		uow.bookingSeriesRepo = NewMongoBookingSeriesRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.bookingSeriesRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.bookingSeriesRepo
+}
+
+// SlotHoldRepository returns the checkout slot hold repository
+func (uow *MongoUnitOfWork) SlotHoldRepository() repository.SlotHoldRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.slotHoldRepo == nil {
+		uow.slotHoldRepo = NewMongoSlotHoldRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.slotHoldRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.slotHoldRepo
+}
+
+// WaitlistRepository returns the waitlist repository
+func (uow *MongoUnitOfWork) WaitlistRepository() repository.WaitlistRepository {
+	uow.mutex.Lock()
+	defer uow.mutex.Unlock()
+
+	if uow.waitlistRepo == nil {
+		uow.waitlistRepo = NewMongoWaitlistRepository(uow.database)
+		if uow.inTransaction {
+			if transactionalRepo, ok := uow.waitlistRepo.(repository.TransactionalRepository); ok {
+				transactionalRepo.SetTransaction(uow.session)
+			}
+		}
+	}
+
+	return uow.waitlistRepo
+}
+
 // Repository returns a generic repository for the specified entity type
 func (uow *MongoUnitOfWork) Repository(entityType string) interface{} {
 	uow.mutex.RLock()
@@ -353,6 +479,46 @@ func (uow *MongoUnitOfWork) setTransactionForRepositories() {
 		}
 	}
 
+	if uow.settlementRepo != nil {
+		if transactionalRepo, ok := uow.settlementRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+
+	if uow.ledgerRepo != nil {
+		if transactionalRepo, ok := uow.ledgerRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+
+	if uow.promotionRepo != nil {
+		if transactionalRepo, ok := uow.promotionRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+
+	if uow.speciesRepo != nil {
+		if transactionalRepo, ok := uow.speciesRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+
+	if uow.bookingSeriesRepo != nil {
+		if transactionalRepo, ok := uow.bookingSeriesRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+	if uow.slotHoldRepo != nil {
+		if transactionalRepo, ok := uow.slotHoldRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+	if uow.waitlistRepo != nil {
+		if transactionalRepo, ok := uow.waitlistRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(uow.session)
+		}
+	}
+
 	// Set transaction for other repositories in the map
 	for _, repo := range uow.repositories {
 		if transactionalRepo, ok := repo.(repository.TransactionalRepository); ok {
@@ -411,6 +577,48 @@ func (uow *MongoUnitOfWork) clearTransactionFromRepositories() {
 		}
 	}
 
+	if uow.settlementRepo != nil {
+		if transactionalRepo, ok := uow.settlementRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
+	if uow.ledgerRepo != nil {
+		if transactionalRepo, ok := uow.ledgerRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
+	if uow.promotionRepo != nil {
+		if transactionalRepo, ok := uow.promotionRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
+	if uow.speciesRepo != nil {
+		if transactionalRepo, ok := uow.speciesRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
+	if uow.bookingSeriesRepo != nil {
+		if transactionalRepo, ok := uow.bookingSeriesRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
+	if uow.slotHoldRepo != nil {
+		if transactionalRepo, ok := uow.slotHoldRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
+	if uow.waitlistRepo != nil {
+		if transactionalRepo, ok := uow.waitlistRepo.(repository.TransactionalRepository); ok {
+			transactionalRepo.SetTransaction(nil)
+		}
+	}
+
 	// Clear transaction for other repositories in the map
 	for _, repo := range uow.repositories {
 		if transactionalRepo, ok := repo.(repository.TransactionalRepository); ok {
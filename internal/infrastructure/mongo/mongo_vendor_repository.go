@@ -66,6 +66,14 @@ func (r *MongoVendorRepository) Save(ctx context.Context, vendor *aggregate.Vend
 		"is_active":  vendor.IsActive(),
 		"created_at": vendor.CreatedAt(),
 		"updated_at": vendor.UpdatedAt(),
+
+		"settlement_period": string(vendor.SettlementPeriod()),
+		"min_payout_amount": vendor.MinPayoutAmount(),
+		"payment_methods":   vendor.PaymentMethods(),
+		"reschedule_policy": bson.M{
+			"cutoff_hours":    vendor.ReschedulePolicy().CutoffHours,
+			"max_reschedules": vendor.ReschedulePolicy().MaxReschedules,
+		},
 	}
 
 	// Add bank account if present
@@ -155,6 +163,25 @@ func (r *MongoVendorRepository) GetByID(ctx context.Context, id string) (*aggreg
 		getVendorBool(result, "is_active"),
 		bankAccount,
 	)
+	vendor.SetSettlementSettings(
+		aggregate.SettlementPeriod(getVendorString(result, "settlement_period")),
+		getVendorInt(result, "min_payout_amount"),
+	)
+	if methods, ok := result["payment_methods"].(bson.A); ok {
+		paymentMethods := make([]aggregate.PaymentMethod, 0, len(methods))
+		for _, method := range methods {
+			if methodStr, ok := method.(string); ok {
+				paymentMethods = append(paymentMethods, aggregate.PaymentMethod(methodStr))
+			}
+		}
+		vendor.SetPaymentMethods(paymentMethods)
+	}
+	if policyDoc, ok := result["reschedule_policy"].(bson.M); ok {
+		vendor.SetReschedulePolicy(&aggregate.ReschedulePolicy{
+			CutoffHours:    getVendorInt(policyDoc, "cutoff_hours"),
+			MaxReschedules: getVendorInt(policyDoc, "max_reschedules"),
+		})
+	}
 
 	return vendor, nil
 }
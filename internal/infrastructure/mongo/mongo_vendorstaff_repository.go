@@ -117,7 +117,44 @@ func (r *MongoVendorStaffRepository) GetByID(ctx context.Context, id string) (*a
 		return nil, fmt.Errorf("failed to get vendor staff from MongoDB: %w", err)
 	}
 
-	// Reconstruct vendor staff from document
+	return vendorStaffFromDocument(result)
+}
+
+// GetActiveByVendorID retrieves the active staff of a vendor
+func (r *MongoVendorStaffRepository) GetActiveByVendorID(ctx context.Context, vendorID string) ([]*aggregate.VendorStaff, error) {
+	// Use session context if in transaction
+	var ctxToUse context.Context = ctx
+	if r.session != nil {
+		ctxToUse = mongo.NewSessionContext(ctx, r.session)
+	}
+
+	cursor, err := r.entityCollection.Find(ctxToUse, bson.M{"vendor_id": vendorID, "is_active": true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vendor staff from MongoDB: %w", err)
+	}
+	defer cursor.Close(ctxToUse)
+
+	var staffs []*aggregate.VendorStaff
+	for cursor.Next(ctxToUse) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode vendor staff: %w", err)
+		}
+		vendorStaff, err := vendorStaffFromDocument(result)
+		if err != nil {
+			return nil, err
+		}
+		staffs = append(staffs, vendorStaff)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list vendor staff from MongoDB: %w", err)
+	}
+
+	return staffs, nil
+}
+
+// vendorStaffFromDocument reconstructs a vendor staff aggregate from a stored document
+func vendorStaffFromDocument(result bson.M) (*aggregate.VendorStaff, error) {
 	role := getVendorStaffString(result, "role")
 	if role == "" {
 		role = string(aggregate.VendorStaffRoleStaff) // Default if missing
@@ -0,0 +1,309 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"whisko-petcare/internal/domain/aggregate"
+	"whisko-petcare/internal/domain/event"
+	"whisko-petcare/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoWaitlistRepository implements WaitlistRepository with MongoDB persistence
+type MongoWaitlistRepository struct {
+	database         *mongo.Database
+	entityCollection *mongo.Collection
+	eventCollection  *mongo.Collection
+	session          mongo.Session
+}
+
+// NewMongoWaitlistRepository creates a new MongoDB waitlist repository
+func NewMongoWaitlistRepository(database *mongo.Database) repository.WaitlistRepository {
+	return &MongoWaitlistRepository{
+		database:         database,
+		entityCollection: database.Collection("waitlist_entries"),
+		eventCollection:  database.Collection("waitlist_events"),
+	}
+}
+
+// EnsureWaitlistIndexes creates the indexes the waitlist collection relies on. The vendor, service and
+// status index serves the search for the next customer in line whenever a slot frees up.
+func EnsureWaitlistIndexes(ctx context.Context, database *mongo.Database) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vendor_id", Value: 1}, {Key: "service_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "offer.expires_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}
+
+	if _, err := database.Collection("waitlist_entries").Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("failed to create waitlist indexes: %w", err)
+	}
+	return nil
+}
+
+// SetTransaction implements TransactionalRepository
+func (r *MongoWaitlistRepository) SetTransaction(tx interface{}) {
+	if session, ok := tx.(mongo.Session); ok {
+		r.session = session
+	} else {
+		r.session = nil
+	}
+}
+
+// GetTransaction implements TransactionalRepository
+func (r *MongoWaitlistRepository) GetTransaction() interface{} {
+	return r.session
+}
+
+// IsTransactional implements TransactionalRepository
+func (r *MongoWaitlistRepository) IsTransactional() bool {
+	return r.session != nil
+}
+
+// getContext returns the appropriate context for MongoDB operations
+func (r *MongoWaitlistRepository) getContext(ctx context.Context) context.Context {
+	if r.session != nil {
+		return mongo.NewSessionContext(ctx, r.session)
+	}
+	return ctx
+}
+
+// Save stores a waitlist entry aggregate to MongoDB
+func (r *MongoWaitlistRepository) Save(ctx context.Context, entry *aggregate.WaitlistEntry) error {
+	ctx = r.getContext(ctx)
+
+	// First, save the events
+	events := entry.GetUncommittedEvents()
+	if len(events) > 0 {
+		if err := r.SaveEvents(ctx, entry.ID(), events, entry.Version()-len(events)); err != nil {
+			return fmt.Errorf("failed to save events: %w", err)
+		}
+	}
+
+	entryDoc := bson.M{
+		"_id":          entry.ID(),
+		"user_id":      entry.UserID(),
+		"pet_id":       entry.PetID(),
+		"vendor_id":    entry.VendorID(),
+		"service_id":   entry.ServiceID(),
+		"window_start": entry.WindowStart(),
+		"window_end":   entry.WindowEnd(),
+		"status":       string(entry.Status()),
+		"offer":        entry.Offer(),
+		"payment_id":   entry.PaymentID(),
+		"version":      entry.Version(),
+		"created_at":   entry.CreatedAt(),
+		"updated_at":   entry.UpdatedAt(),
+	}
+
+	// Use upsert to insert or update
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.entityCollection.ReplaceOne(ctx, bson.M{"_id": entry.ID()}, entryDoc, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save waitlist entry: %w", err)
+	}
+
+	if len(events) > 0 {
+		entry.MarkEventsAsCommitted()
+	}
+
+	return nil
+}
+
+// GetByID retrieves a waitlist entry by its ID
+func (r *MongoWaitlistRepository) GetByID(ctx context.Context, id string) (*aggregate.WaitlistEntry, error) {
+	ctx = r.getContext(ctx)
+
+	var result bson.M
+	err := r.entityCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("waitlist entry not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+
+	return documentToWaitlistEntry(result), nil
+}
+
+// GetByUserID retrieves a customer's waitlist entries, newest first
+func (r *MongoWaitlistRepository) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*aggregate.WaitlistEntry, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	return r.find(ctx, bson.M{"user_id": userID}, opts)
+}
+
+// FindNextForSlot finds the first waiting entry, in joining order, that joined after the given time for one
+// of the services at the vendor and whose window covers the slot
+func (r *MongoWaitlistRepository) FindNextForSlot(ctx context.Context, vendorID string, serviceIDs []string, startTime, endTime, joinedAfter time.Time) (*aggregate.WaitlistEntry, error) {
+	filter := bson.M{
+		"vendor_id":    vendorID,
+		"service_id":   bson.M{"$in": serviceIDs},
+		"status":       string(aggregate.WaitlistStatusWaiting),
+		"window_start": bson.M{"$lte": startTime},
+		"window_end":   bson.M{"$gte": endTime},
+		"created_at":   bson.M{"$gt": joinedAfter},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(1)
+
+	entries, err := r.find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// HasOverlappingOffer checks if the vendor has offered a slot overlapping the time range that can still be
+// claimed at now
+func (r *MongoWaitlistRepository) HasOverlappingOffer(ctx context.Context, vendorID string, startTime, endTime, now time.Time) (bool, error) {
+	ctx = r.getContext(ctx)
+
+	filter := bson.M{
+		"vendor_id":        vendorID,
+		"status":           string(aggregate.WaitlistStatusOffered),
+		"offer.expires_at": bson.M{"$gt": now},
+		"offer.start_time": bson.M{"$lt": endTime},
+		"offer.end_time":   bson.M{"$gt": startTime},
+	}
+
+	count, err := r.entityCollection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check overlapping waitlist offers: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetLapsedOffers retrieves the entries holding an offer that was not claimed in time, oldest first
+func (r *MongoWaitlistRepository) GetLapsedOffers(ctx context.Context, now time.Time) ([]*aggregate.WaitlistEntry, error) {
+	filter := bson.M{
+		"status":           string(aggregate.WaitlistStatusOffered),
+		"offer.expires_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "offer.expires_at", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// GetPassedWindows retrieves the waiting entries whose time window has ended
+func (r *MongoWaitlistRepository) GetPassedWindows(ctx context.Context, now time.Time) ([]*aggregate.WaitlistEntry, error) {
+	filter := bson.M{
+		"status":     string(aggregate.WaitlistStatusWaiting),
+		"window_end": bson.M{"$lte": now},
+	}
+
+	return r.find(ctx, filter, options.Find())
+}
+
+// find runs a query and converts the matching documents to waitlist entries
+func (r *MongoWaitlistRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*aggregate.WaitlistEntry, error) {
+	ctx = r.getContext(ctx)
+
+	cursor, err := r.entityCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find waitlist entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*aggregate.WaitlistEntry{}
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode waitlist entry: %w", err)
+		}
+		entries = append(entries, documentToWaitlistEntry(result))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("cursor error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SaveEvents saves domain events for a waitlist entry
+func (r *MongoWaitlistRepository) SaveEvents(ctx context.Context, aggregateID string, events []event.DomainEvent, expectedVersion int) error {
+	ctx = r.getContext(ctx)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	var eventDocs []interface{}
+	for i, e := range events {
+		eventDoc := bson.M{
+			"aggregate_id":  aggregateID,
+			"event_type":    e.EventType(),
+			"event_version": expectedVersion + i + 1,
+			"occurred_at":   e.OccurredAt(),
+			"event_data":    e,
+		}
+		eventDocs = append(eventDocs, eventDoc)
+	}
+
+	_, err := r.eventCollection.InsertMany(ctx, eventDocs)
+	if err != nil {
+		return fmt.Errorf("failed to save waitlist events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves all events for a waitlist entry
+func (r *MongoWaitlistRepository) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	// Waitlist entries are loaded from entity state; event replay is not needed
+	return []event.DomainEvent{}, nil
+}
+
+// GetEventsSince retrieves events after a specific version
+func (r *MongoWaitlistRepository) GetEventsSince(ctx context.Context, aggregateID string, version int) ([]event.DomainEvent, error) {
+	return r.GetEvents(ctx, aggregateID)
+}
+
+// GetAllEvents retrieves all events
+func (r *MongoWaitlistRepository) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return []event.DomainEvent{}, nil
+}
+
+// documentToWaitlistEntry converts a MongoDB document to a WaitlistEntry aggregate
+func documentToWaitlistEntry(doc bson.M) *aggregate.WaitlistEntry {
+	var offer *event.WaitlistOffer
+	if offerDoc, ok := doc["offer"].(bson.M); ok {
+		offer = &event.WaitlistOffer{
+			StartTime:       getTime(offerDoc, "start_time"),
+			EndTime:         getTime(offerDoc, "end_time"),
+			ExpiresAt:       getTime(offerDoc, "expires_at"),
+			ServiceIDs:      getStringArray(offerDoc, "service_ids"),
+			FreedScheduleID: getString(offerDoc, "freed_schedule_id"),
+			OfferedAt:       getTime(offerDoc, "offered_at"),
+		}
+	}
+
+	return aggregate.ReconstructWaitlistEntry(
+		getString(doc, "_id"),
+		getString(doc, "user_id"),
+		getString(doc, "pet_id"),
+		getString(doc, "vendor_id"),
+		getString(doc, "service_id"),
+		getTime(doc, "window_start"),
+		getTime(doc, "window_end"),
+		aggregate.WaitlistStatus(getString(doc, "status")),
+		offer,
+		getString(doc, "payment_id"),
+		getIntValue(doc, "version"),
+		getTime(doc, "created_at"),
+		getTime(doc, "updated_at"),
+	)
+}
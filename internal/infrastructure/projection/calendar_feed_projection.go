@@ -0,0 +1,152 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Calendar feed owners
+const (
+	CalendarFeedOwnerUser   = "USER"
+	CalendarFeedOwnerVendor = "VENDOR"
+)
+
+// CalendarFeedReadModel is a secret calendar subscription URL listing the bookings of a customer or a shop
+type CalendarFeedReadModel struct {
+	ID            string     `bson:"_id" json:"id"`
+	OwnerType     string     `bson:"owner_type" json:"owner_type"` // USER or VENDOR
+	OwnerID       string     `bson:"owner_id" json:"owner_id"`     // User ID or vendor ID
+	Name          string     `bson:"name" json:"name"`             // Calendar name shown in calendar applications
+	CreatedBy     string     `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	RevokedAt     *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	RevokedBy     string     `bson:"revoked_by,omitempty" json:"revoked_by,omitempty"`
+	LastFetchedAt *time.Time `bson:"last_fetched_at,omitempty" json:"last_fetched_at,omitempty"`
+	FetchCount    int        `bson:"fetch_count" json:"fetch_count"`
+}
+
+// IsRevoked reports whether the feed was revoked or replaced
+func (f *CalendarFeedReadModel) IsRevoked() bool {
+	return f.RevokedAt != nil
+}
+
+// CalendarFeedProjection stores calendar feeds
+type CalendarFeedProjection interface {
+	Create(ctx context.Context, feed *CalendarFeedReadModel) error
+	GetByID(ctx context.Context, id string) (*CalendarFeedReadModel, error)
+	GetActiveByOwner(ctx context.Context, ownerType, ownerID string) (*CalendarFeedReadModel, error)
+	RevokeAllForOwner(ctx context.Context, ownerType, ownerID, revokedBy string, revokedAt time.Time) error
+	RecordFetch(ctx context.Context, id string, fetchedAt time.Time) error
+}
+
+// ScheduleCalendarProjection lists the bookings shown in calendar feeds
+type ScheduleCalendarProjection interface {
+	GetByID(ctx context.Context, id string) (interface{}, error)
+	GetCalendarByUserID(ctx context.Context, userID string, since time.Time) ([]ScheduleReadModel, error)
+	GetCalendarByShopID(ctx context.Context, shopID string, since time.Time) ([]ScheduleReadModel, error)
+}
+
+// MongoCalendarFeedProjection implements CalendarFeedProjection using MongoDB
+type MongoCalendarFeedProjection struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCalendarFeedProjection creates a new MongoDB calendar feed projection
+func NewMongoCalendarFeedProjection(db *mongo.Database) *MongoCalendarFeedProjection {
+	collection := db.Collection("calendar_feeds")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "owner_type", Value: 1}, {Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		fmt.Printf("Warning: failed to create calendar feed indexes: %v\n", err)
+	}
+
+	return &MongoCalendarFeedProjection{
+		collection: collection,
+	}
+}
+
+// Create stores a new calendar feed
+func (p *MongoCalendarFeedProjection) Create(ctx context.Context, feed *CalendarFeedReadModel) error {
+	if _, err := p.collection.InsertOne(ctx, feed); err != nil {
+		return fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a calendar feed by ID
+func (p *MongoCalendarFeedProjection) GetByID(ctx context.Context, id string) (*CalendarFeedReadModel, error) {
+	var feed CalendarFeedReadModel
+	err := p.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&feed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("calendar feed not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+	return &feed, nil
+}
+
+// GetActiveByOwner retrieves the newest feed of a user or vendor that is not revoked
+func (p *MongoCalendarFeedProjection) GetActiveByOwner(ctx context.Context, ownerType, ownerID string) (*CalendarFeedReadModel, error) {
+	filter := bson.M{
+		"owner_type": ownerType,
+		"owner_id":   ownerID,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var feed CalendarFeedReadModel
+	err := p.collection.FindOne(ctx, filter, opts).Decode(&feed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("calendar feed not found for %s %s", ownerType, ownerID)
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+	return &feed, nil
+}
+
+// RevokeAllForOwner revokes every feed of a user or vendor that is not revoked yet
+func (p *MongoCalendarFeedProjection) RevokeAllForOwner(ctx context.Context, ownerType, ownerID, revokedBy string, revokedAt time.Time) error {
+	filter := bson.M{
+		"owner_type": ownerType,
+		"owner_id":   ownerID,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": revokedAt,
+			"revoked_by": revokedBy,
+		},
+	}
+
+	if _, err := p.collection.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to revoke calendar feeds: %w", err)
+	}
+	return nil
+}
+
+// RecordFetch notes that a calendar application fetched the feed
+func (p *MongoCalendarFeedProjection) RecordFetch(ctx context.Context, id string, fetchedAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"last_fetched_at": fetchedAt},
+		"$inc": bson.M{"fetch_count": 1},
+	}
+
+	if _, err := p.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to record calendar feed fetch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Care brief access outcomes
+const (
+	CareBriefAccessGranted = "GRANTED"
+	CareBriefAccessDenied  = "DENIED"
+)
+
+// Roles under which a care brief is opened
+const (
+	CareBriefRoleGuardian    = "GUARDIAN"     // An owner or caretaker of the pet, or the booking user
+	CareBriefRoleVendorStaff = "VENDOR_STAFF" // Staff of the booked shop
+	CareBriefRoleAdmin       = "ADMIN"
+	CareBriefRoleNone        = "NONE" // Anyone else
+)
+
+// CareBriefAccessView records one attempt to open the care brief of a booking
+type CareBriefAccessView struct {
+	ID         string    `bson:"_id" json:"id"`
+	ScheduleID string    `bson:"schedule_id" json:"schedule_id"`
+	PetID      string    `bson:"pet_id" json:"pet_id"`
+	ShopID     string    `bson:"shop_id" json:"shop_id"`
+	AccessedBy string    `bson:"accessed_by" json:"accessed_by"`
+	Role       string    `bson:"role" json:"role"`
+	Outcome    string    `bson:"outcome" json:"outcome"`
+	Reason     string    `bson:"reason,omitempty" json:"reason,omitempty"` // Why access was denied
+	IPAddress  string    `bson:"ip_address" json:"ip_address"`
+	UserAgent  string    `bson:"user_agent" json:"user_agent"`
+	AccessedAt time.Time `bson:"accessed_at" json:"accessed_at"`
+}
+
+// CareBriefAccessProjection stores the access log of booking care briefs
+type CareBriefAccessProjection interface {
+	Record(ctx context.Context, access *CareBriefAccessView) error
+	ListBySchedule(ctx context.Context, scheduleID string) ([]*CareBriefAccessView, error)
+}
+
+// MongoCareBriefAccessProjection implements CareBriefAccessProjection using MongoDB
+type MongoCareBriefAccessProjection struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCareBriefAccessProjection creates a new MongoDB care brief access projection
+func NewMongoCareBriefAccessProjection(db *mongo.Database) *MongoCareBriefAccessProjection {
+	collection := db.Collection("care_brief_accesses")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "schedule_id", Value: 1}, {Key: "accessed_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "pet_id", Value: 1}, {Key: "accessed_at", Value: -1}},
+		},
+	}
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		fmt.Printf("Warning: failed to create care brief access indexes: %v\n", err)
+	}
+
+	return &MongoCareBriefAccessProjection{
+		collection: collection,
+	}
+}
+
+// Record appends an entry to the access log
+func (p *MongoCareBriefAccessProjection) Record(ctx context.Context, access *CareBriefAccessView) error {
+	if _, err := p.collection.InsertOne(ctx, access); err != nil {
+		return fmt.Errorf("failed to record care brief access: %w", err)
+	}
+	return nil
+}
+
+// ListBySchedule retrieves the access log of a booking's care brief, newest first
+func (p *MongoCareBriefAccessProjection) ListBySchedule(ctx context.Context, scheduleID string) ([]*CareBriefAccessView, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "accessed_at", Value: -1}})
+
+	cursor, err := p.collection.Find(ctx, bson.M{"schedule_id": scheduleID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find care brief accesses: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	accesses := []*CareBriefAccessView{}
+	if err := cursor.All(ctx, &accesses); err != nil {
+		return nil, fmt.Errorf("failed to decode care brief accesses: %w", err)
+	}
+	return accesses, nil
+}
@@ -0,0 +1,185 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Share link access outcomes
+const (
+	ShareLinkAccessGranted = "GRANTED"
+	ShareLinkAccessExpired = "EXPIRED"
+	ShareLinkAccessRevoked = "REVOKED"
+)
+
+// HealthShareLinkAccessView records one use of a health record share link
+type HealthShareLinkAccessView struct {
+	AccessedAt time.Time `bson:"accessed_at" json:"accessed_at"`
+	Outcome    string    `bson:"outcome" json:"outcome"`
+	Format     string    `bson:"format" json:"format"`
+	IPAddress  string    `bson:"ip_address" json:"ip_address"`
+	UserAgent  string    `bson:"user_agent" json:"user_agent"`
+}
+
+// HealthShareLinkReadModel is a time-limited, read-only link to a pet's health record
+type HealthShareLinkReadModel struct {
+	ID             string                      `bson:"_id" json:"id"`
+	PetID          string                      `bson:"pet_id" json:"pet_id"`
+	OwnerID        string                      `bson:"owner_id" json:"owner_id"`
+	CreatedBy      string                      `bson:"created_by" json:"created_by"`
+	Recipient      string                      `bson:"recipient,omitempty" json:"recipient,omitempty"` // e.g. the clinic or groomer the link was sent to
+	Note           string                      `bson:"note,omitempty" json:"note,omitempty"`
+	CreatedAt      time.Time                   `bson:"created_at" json:"created_at"`
+	ExpiresAt      time.Time                   `bson:"expires_at" json:"expires_at"`
+	RevokedAt      *time.Time                  `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+	RevokedBy      string                      `bson:"revoked_by,omitempty" json:"revoked_by,omitempty"`
+	AccessCount    int                         `bson:"access_count" json:"access_count"` // Granted accesses only
+	LastAccessedAt *time.Time                  `bson:"last_accessed_at,omitempty" json:"last_accessed_at,omitempty"`
+	Accesses       []HealthShareLinkAccessView `bson:"accesses" json:"accesses"`
+}
+
+// IsRevoked reports whether the owner revoked the link
+func (l *HealthShareLinkReadModel) IsRevoked() bool {
+	return l.RevokedAt != nil
+}
+
+// IsExpired reports whether the link has expired at the given time
+func (l *HealthShareLinkReadModel) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// HealthShareLinkProjection stores health record share links and their access log
+type HealthShareLinkProjection interface {
+	Create(ctx context.Context, link *HealthShareLinkReadModel) error
+	GetByID(ctx context.Context, id string) (*HealthShareLinkReadModel, error)
+	ListByPet(ctx context.Context, petID string) ([]*HealthShareLinkReadModel, error)
+	Revoke(ctx context.Context, id, revokedBy string, revokedAt time.Time) error
+	RecordAccess(ctx context.Context, id string, access HealthShareLinkAccessView) error
+	RevokeAllForPet(ctx context.Context, petID, revokedBy string, revokedAt time.Time) error
+}
+
+// MongoHealthShareLinkProjection implements HealthShareLinkProjection using MongoDB
+type MongoHealthShareLinkProjection struct {
+	collection *mongo.Collection
+}
+
+// NewMongoHealthShareLinkProjection creates a new MongoDB health share link projection
+func NewMongoHealthShareLinkProjection(db *mongo.Database) *MongoHealthShareLinkProjection {
+	collection := db.Collection("pet_health_share_links")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "pet_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		fmt.Printf("Warning: failed to create health share link indexes: %v\n", err)
+	}
+
+	return &MongoHealthShareLinkProjection{
+		collection: collection,
+	}
+}
+
+// Create stores a new share link
+func (p *MongoHealthShareLinkProjection) Create(ctx context.Context, link *HealthShareLinkReadModel) error {
+	if link.Accesses == nil {
+		link.Accesses = []HealthShareLinkAccessView{}
+	}
+	if _, err := p.collection.InsertOne(ctx, link); err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a share link by ID
+func (p *MongoHealthShareLinkProjection) GetByID(ctx context.Context, id string) (*HealthShareLinkReadModel, error) {
+	var link HealthShareLinkReadModel
+	err := p.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&link)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("share link not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	return &link, nil
+}
+
+// ListByPet retrieves the share links of a pet, newest first
+func (p *MongoHealthShareLinkProjection) ListByPet(ctx context.Context, petID string) ([]*HealthShareLinkReadModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := p.collection.Find(ctx, bson.M{"pet_id": petID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find share links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	links := []*HealthShareLinkReadModel{}
+	if err := cursor.All(ctx, &links); err != nil {
+		return nil, fmt.Errorf("failed to decode share links: %w", err)
+	}
+	return links, nil
+}
+
+// Revoke marks a share link as revoked; revoking an already revoked link keeps the first revocation
+func (p *MongoHealthShareLinkProjection) Revoke(ctx context.Context, id, revokedBy string, revokedAt time.Time) error {
+	filter := bson.M{
+		"_id":        id,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": revokedAt,
+			"revoked_by": revokedBy,
+		},
+	}
+
+	if _, err := p.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllForPet revokes every link of a pet that is not revoked yet
+func (p *MongoHealthShareLinkProjection) RevokeAllForPet(ctx context.Context, petID, revokedBy string, revokedAt time.Time) error {
+	filter := bson.M{
+		"pet_id":     petID,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": revokedAt,
+			"revoked_by": revokedBy,
+		},
+	}
+
+	if _, err := p.collection.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to revoke share links: %w", err)
+	}
+	return nil
+}
+
+// RecordAccess appends an entry to the link's access log
+func (p *MongoHealthShareLinkProjection) RecordAccess(ctx context.Context, id string, access HealthShareLinkAccessView) error {
+	update := bson.M{
+		"$push": bson.M{"accesses": access},
+	}
+	if access.Outcome == ShareLinkAccessGranted {
+		update["$inc"] = bson.M{"access_count": 1}
+		update["$set"] = bson.M{"last_accessed_at": access.AccessedAt}
+	}
+
+	if _, err := p.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		return fmt.Errorf("failed to record share link access: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Notification recipients
+const (
+	NotificationRecipientUser   = "USER"   // A customer
+	NotificationRecipientVendor = "VENDOR" // The staff of a shop
+)
+
+// NotificationReadModel is one entry of a customer's or shop's notification feed
+type NotificationReadModel struct {
+	ID              string     `bson:"_id" json:"id"`
+	RecipientType   string     `bson:"recipient_type" json:"recipient_type"`
+	RecipientID     string     `bson:"recipient_id" json:"recipient_id"`
+	Type            string     `bson:"type" json:"type"` // The event the notification was raised for, e.g. VaccinationDueSoon
+	Title           string     `bson:"title" json:"title"`
+	Message         string     `bson:"message" json:"message"`
+	PetID           string     `bson:"pet_id,omitempty" json:"pet_id,omitempty"`
+	ScheduleID      string     `bson:"schedule_id,omitempty" json:"schedule_id,omitempty"`
+	WaitlistEntryID string     `bson:"waitlist_entry_id,omitempty" json:"waitlist_entry_id,omitempty"`
+	SourceKey       string     `bson:"source_key" json:"-"` // Identifies the reminder, so redelivered events do not notify twice
+	ReadAt          *time.Time `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	CreatedAt       time.Time  `bson:"created_at" json:"created_at"`
+}
+
+// NotificationProjection stores the notification feeds of customers and shops
+type NotificationProjection interface {
+	// Add stores a notification; it reports false when one with the same source key is already stored
+	Add(ctx context.Context, notification *NotificationReadModel) (bool, error)
+	ListByRecipient(ctx context.Context, recipientType, recipientID string, unreadOnly bool, offset, limit int) ([]*NotificationReadModel, error)
+	CountUnread(ctx context.Context, recipientType, recipientID string) (int64, error)
+	// MarkRead marks a notification of the recipient as read; it reports false when the recipient has no such notification
+	MarkRead(ctx context.Context, id, recipientType, recipientID string, readAt time.Time) (bool, error)
+}
+
+// MongoNotificationProjection implements NotificationProjection using MongoDB
+type MongoNotificationProjection struct {
+	collection *mongo.Collection
+}
+
+// NewMongoNotificationProjection creates a new MongoDB notification projection
+func NewMongoNotificationProjection(db *mongo.Database) *MongoNotificationProjection {
+	collection := db.Collection("notifications")
+
+	ctx := context.Background()
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "source_key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "recipient_type", Value: 1}, {Key: "recipient_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+
+	_, err := collection.Indexes().CreateMany(ctx, indexes)
+	if err != nil {
+		fmt.Printf("Warning: failed to create notification indexes: %v\n", err)
+	}
+
+	return &MongoNotificationProjection{
+		collection: collection,
+	}
+}
+
+// Add stores a notification unless one with the same source key is already stored
+func (p *MongoNotificationProjection) Add(ctx context.Context, notification *NotificationReadModel) (bool, error) {
+	if _, err := p.collection.InsertOne(ctx, notification); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to add notification: %w", err)
+	}
+	return true, nil
+}
+
+// ListByRecipient retrieves the notification feed of a customer or shop, newest first
+func (p *MongoNotificationProjection) ListByRecipient(ctx context.Context, recipientType, recipientID string, unreadOnly bool, offset, limit int) ([]*NotificationReadModel, error) {
+	filter := bson.M{"recipient_type": recipientType, "recipient_id": recipientID}
+	if unreadOnly {
+		filter["read_at"] = bson.M{"$exists": false}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := p.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []*NotificationReadModel{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// CountUnread counts the notifications of a customer or shop not read yet
+func (p *MongoNotificationProjection) CountUnread(ctx context.Context, recipientType, recipientID string) (int64, error) {
+	filter := bson.M{
+		"recipient_type": recipientType,
+		"recipient_id":   recipientID,
+		"read_at":        bson.M{"$exists": false},
+	}
+
+	count, err := p.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks a notification of the recipient as read. Marking a read notification again keeps the
+// time it was first read.
+func (p *MongoNotificationProjection) MarkRead(ctx context.Context, id, recipientType, recipientID string, readAt time.Time) (bool, error) {
+	filter := bson.M{"_id": id, "recipient_type": recipientType, "recipient_id": recipientID}
+
+	result, err := p.collection.UpdateOne(ctx, filter, bson.A{
+		bson.M{"$set": bson.M{"read_at": bson.M{"$ifNull": bson.A{"$read_at", readAt}}}},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return result.MatchedCount == 1, nil
+}
@@ -33,11 +33,65 @@ type PaymentReadModel struct {
 	CheckoutURL        string                  `json:"checkout_url" bson:"checkout_url"`
 	QRCode             string                  `json:"qr_code" bson:"qr_code"`
 	ExpiredAt          time.Time               `json:"expired_at" bson:"expired_at"`
+	RefundedAmount     int                     `json:"refunded_amount" bson:"refunded_amount"`
+	PendingRefund      *PendingRefundReadModel `json:"pending_refund,omitempty" bson:"pending_refund,omitempty"` // Refund the gateway has not executed yet
+	CollectedBy        string                  `json:"collected_by,omitempty" bson:"collected_by,omitempty"`
+	PromotionCode      string                  `json:"promotion_code,omitempty" bson:"promotion_code,omitempty"`
+	DiscountAmount     int                     `json:"discount_amount" bson:"discount_amount"`
+	Invoice            *InvoiceReadModel       `json:"invoice,omitempty" bson:"invoice,omitempty"` // Issued once the payment is PAID
+	ScheduleID         string                  `json:"schedule_id,omitempty" bson:"schedule_id,omitempty"` // Booking a top-up payment adjusts
+	SeriesID           string                  `json:"series_id,omitempty" bson:"series_id,omitempty"`     // Recurring booking the payment is for
 	Version            int                     `json:"version" bson:"version"`
 	CreatedAt          time.Time               `json:"created_at" bson:"created_at"`
 	UpdatedAt          time.Time               `json:"updated_at" bson:"updated_at"`
 }
 
+// PendingRefundReadModel is a refund reserved on a payment that the payment gateway has not executed yet
+type PendingRefundReadModel struct {
+	RefundID  string    `json:"refund_id" bson:"refund_id"`
+	Amount    int       `json:"amount" bson:"amount"`
+	Reason    string    `json:"reason" bson:"reason"`
+	Requested time.Time `json:"requested_at" bson:"requested_at"`
+}
+
+// InvoicePartyReadModel represents the seller or buyer on an invoice
+type InvoicePartyReadModel struct {
+	ID      string `json:"id" bson:"id"`
+	Name    string `json:"name" bson:"name"`
+	Email   string `json:"email" bson:"email"`
+	Phone   string `json:"phone" bson:"phone"`
+	Address string `json:"address" bson:"address"`
+}
+
+// InvoiceLineReadModel represents a line item on an invoice
+type InvoiceLineReadModel struct {
+	Description string `json:"description" bson:"description"`
+	Quantity    int    `json:"quantity" bson:"quantity"`
+	UnitPrice   int    `json:"unit_price" bson:"unit_price"`
+	Amount      int    `json:"amount" bson:"amount"`
+}
+
+// InvoiceReadModel is the receipt issued for a paid booking. It is a snapshot: later changes to
+// the vendor or customer do not alter an invoice that has been issued.
+type InvoiceReadModel struct {
+	Number         string                 `json:"number" bson:"number"`
+	IssuedAt       time.Time              `json:"issued_at" bson:"issued_at"`
+	PaymentID      string                 `json:"payment_id" bson:"payment_id"`
+	OrderCode      int64                  `json:"order_code" bson:"order_code"`
+	PaymentMethod  string                 `json:"payment_method" bson:"payment_method"`
+	Seller         InvoicePartyReadModel  `json:"seller" bson:"seller"`
+	Buyer          InvoicePartyReadModel  `json:"buyer" bson:"buyer"`
+	Lines          []InvoiceLineReadModel `json:"lines" bson:"lines"`
+	Subtotal       int                    `json:"subtotal" bson:"subtotal"` // Sum of the line items
+	PromotionCode  string                 `json:"promotion_code,omitempty" bson:"promotion_code,omitempty"`
+	DiscountAmount int                    `json:"discount_amount" bson:"discount_amount"`
+	TaxRate        int                    `json:"tax_rate" bson:"tax_rate"`     // VAT percentage included in the total
+	TaxAmount      int                    `json:"tax_amount" bson:"tax_amount"` // VAT portion of the total
+	NetAmount      int                    `json:"net_amount" bson:"net_amount"` // Total excluding VAT
+	TotalAmount    int                    `json:"total_amount" bson:"total_amount"`
+	Currency       string                 `json:"currency" bson:"currency"`
+}
+
 // PaymentProjection defines operations for payment read model
 type PaymentProjection interface {
 	GetByID(ctx context.Context, id string) (*PaymentReadModel, error)
@@ -49,8 +103,25 @@ type PaymentProjection interface {
 	HandlePaymentCreated(ctx context.Context, event *event.PaymentCreated) error
 	HandlePaymentUpdated(ctx context.Context, event *event.PaymentUpdated) error
 	HandlePaymentStatusChanged(ctx context.Context, event *event.PaymentStatusChanged) error
+	HandlePaymentCollected(ctx context.Context, event *event.PaymentCollected) error
+	HandlePaymentRefundRequested(ctx context.Context, event *event.PaymentRefundRequested) error
+	HandlePaymentRefunded(ctx context.Context, event *event.PaymentRefunded) error
+	HandlePaymentDiscountApplied(ctx context.Context, event *event.PaymentDiscountApplied) error
+	HandlePaymentAmountAdjusted(ctx context.Context, event *event.PaymentAmountAdjusted) error
+
+	// ClaimInvoice reserves the invoice of a paid payment for one issuer before an invoice number is taken;
+	// it reports false when the payment already has an invoice or another issuer is preparing it
+	ClaimInvoice(ctx context.Context, paymentID, claimID string, claimedAt time.Time) (bool, error)
+	// AttachInvoice stores the invoice of a paid payment prepared under a claim
+	AttachInvoice(ctx context.Context, paymentID, claimID string, invoice *InvoiceReadModel) error
+	// ReleaseInvoiceClaim gives up a claim without issuing an invoice
+	ReleaseInvoiceClaim(ctx context.Context, paymentID, claimID string) error
 }
 
+// InvoiceClaimTimeout is how long an invoice claim holds; a claim whose issuer stopped before attaching
+// the invoice can be taken over after it
+const InvoiceClaimTimeout = 5 * time.Minute
+
 // MongoPaymentProjection implements PaymentProjection using MongoDB
 type MongoPaymentProjection struct {
 	collection *mongo.Collection
@@ -172,6 +243,8 @@ func (p *MongoPaymentProjection) HandlePaymentCreated(ctx context.Context, evt *
 		Status:      evt.Status,
 		Method:      evt.Method,
 		ExpiredAt:   evt.ExpiredAt,
+		ScheduleID:  evt.ScheduleID,
+		SeriesID:    evt.SeriesID,
 		Version:     1,
 		CreatedAt:   evt.Timestamp,
 		UpdatedAt:   evt.Timestamp,
@@ -241,3 +314,209 @@ func (p *MongoPaymentProjection) HandlePaymentStatusChanged(ctx context.Context,
 
 	return nil
 }
+
+func (p *MongoPaymentProjection) HandlePaymentCollected(ctx context.Context, evt *event.PaymentCollected) error {
+	update := bson.M{
+		"$set": bson.M{
+			"collected_by": evt.CollectedBy,
+			"updated_at":   evt.Timestamp,
+		},
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.PaymentID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update collected payment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("payment not found")
+	}
+
+	return nil
+}
+
+// HandlePaymentRefundRequested handles PaymentRefundRequested event
+func (p *MongoPaymentProjection) HandlePaymentRefundRequested(ctx context.Context, evt *event.PaymentRefundRequested) error {
+	update := bson.M{
+		"$set": bson.M{
+			"pending_refund": PendingRefundReadModel{
+				RefundID:  evt.RefundID,
+				Amount:    evt.Amount,
+				Reason:    evt.Reason,
+				Requested: evt.Timestamp,
+			},
+			"updated_at": evt.Timestamp,
+		},
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.PaymentID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update payment refund request: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("payment not found")
+	}
+
+	return nil
+}
+
+func (p *MongoPaymentProjection) HandlePaymentRefunded(ctx context.Context, evt *event.PaymentRefunded) error {
+	update := bson.M{
+		"$set": bson.M{
+			"refunded_amount": evt.RefundedAmount,
+			"updated_at":      evt.Timestamp,
+		},
+		"$unset": bson.M{
+			"pending_refund": "",
+		},
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.PaymentID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update refunded payment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("payment not found")
+	}
+
+	return nil
+}
+
+// HandlePaymentDiscountApplied handles PaymentDiscountApplied event
+func (p *MongoPaymentProjection) HandlePaymentDiscountApplied(ctx context.Context, evt *event.PaymentDiscountApplied) error {
+	update := bson.M{
+		"$set": bson.M{
+			"amount":          evt.Amount,
+			"promotion_code":  evt.PromotionCode,
+			"discount_amount": evt.DiscountAmount,
+			"updated_at":      evt.Timestamp,
+		},
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.PaymentID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update discounted payment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("payment not found")
+	}
+
+	return nil
+}
+
+// HandlePaymentAmountAdjusted handles PaymentAmountAdjusted event
+func (p *MongoPaymentProjection) HandlePaymentAmountAdjusted(ctx context.Context, evt *event.PaymentAmountAdjusted) error {
+	update := bson.M{
+		"$set": bson.M{
+			"amount":     evt.Amount,
+			"updated_at": evt.Timestamp,
+		},
+		"$push": bson.M{
+			"items": PaymentItemReadModel{
+				Name:     evt.Item.Name,
+				Quantity: evt.Item.Quantity,
+				Price:    evt.Item.Price,
+			},
+		},
+		"$inc": bson.M{
+			"version": 1,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.PaymentID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update adjusted payment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("payment not found")
+	}
+
+	return nil
+}
+
+// ClaimInvoice reserves the invoice of a payment that has none, unless another claim on it still holds
+func (p *MongoPaymentProjection) ClaimInvoice(ctx context.Context, paymentID, claimID string, claimedAt time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":     paymentID,
+		"invoice": bson.M{"$exists": false},
+		"$or": bson.A{
+			bson.M{"invoice_claim": bson.M{"$exists": false}},
+			bson.M{"invoice_claim.claimed_at": bson.M{"$lt": claimedAt.Add(-InvoiceClaimTimeout)}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"invoice_claim": bson.M{"id": claimID, "claimed_at": claimedAt},
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim invoice: %w", err)
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// AttachInvoice stores an invoice on the payment read model and ends the claim it was prepared under
+func (p *MongoPaymentProjection) AttachInvoice(ctx context.Context, paymentID, claimID string, invoice *InvoiceReadModel) error {
+	filter := bson.M{
+		"_id":              paymentID,
+		"invoice":          bson.M{"$exists": false},
+		"invoice_claim.id": claimID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"invoice": invoice,
+		},
+		"$unset": bson.M{
+			"invoice_claim": "",
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to attach invoice: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("invoice claim %s of payment %s no longer holds", claimID, paymentID)
+	}
+
+	return nil
+}
+
+// ReleaseInvoiceClaim removes a claim so the invoice can be issued again later
+func (p *MongoPaymentProjection) ReleaseInvoiceClaim(ctx context.Context, paymentID, claimID string) error {
+	filter := bson.M{
+		"_id":              paymentID,
+		"invoice_claim.id": claimID,
+	}
+	update := bson.M{
+		"$unset": bson.M{
+			"invoice_claim": "",
+		},
+	}
+
+	if _, err := p.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to release invoice claim: %w", err)
+	}
+
+	return nil
+}
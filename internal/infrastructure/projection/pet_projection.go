@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"whisko-petcare/internal/domain/aggregate"
 	"whisko-petcare/internal/domain/event"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,6 +21,10 @@ type VaccinationRecordView struct {
 	NextDueDate   time.Time `bson:"next_due_date" json:"next_due_date"`
 	Veterinarian  string    `bson:"veterinarian" json:"veterinarian"`
 	Notes         string    `bson:"notes" json:"notes"`
+	Voided        bool      `bson:"voided,omitempty" json:"voided,omitempty"`
+	VoidedAt      time.Time `bson:"voided_at,omitempty" json:"voided_at,omitempty"`
+	VoidedBy      string    `bson:"voided_by,omitempty" json:"voided_by,omitempty"`
+	VoidReason    string    `bson:"void_reason,omitempty" json:"void_reason,omitempty"`
 }
 
 // MedicalRecordView represents a medical record in the read model
@@ -31,6 +36,20 @@ type MedicalRecordView struct {
 	Veterinarian  string    `bson:"veterinarian" json:"veterinarian"`
 	Diagnosis     string    `bson:"diagnosis" json:"diagnosis"`
 	Notes         string    `bson:"notes" json:"notes"`
+	Voided        bool      `bson:"voided,omitempty" json:"voided,omitempty"`
+	VoidedAt      time.Time `bson:"voided_at,omitempty" json:"voided_at,omitempty"`
+	VoidedBy      string    `bson:"voided_by,omitempty" json:"voided_by,omitempty"`
+	VoidReason    string    `bson:"void_reason,omitempty" json:"void_reason,omitempty"`
+	Provenance    *RecordProvenanceView `bson:"provenance,omitempty" json:"provenance,omitempty"`
+}
+
+// RecordProvenanceView tells where a medical record came from when it was not entered by an owner
+type RecordProvenanceView struct {
+	Source     string `bson:"source" json:"source"`
+	VendorID   string `bson:"vendor_id,omitempty" json:"vendor_id,omitempty"`
+	VendorName string `bson:"vendor_name,omitempty" json:"vendor_name,omitempty"`
+	ScheduleID string `bson:"schedule_id,omitempty" json:"schedule_id,omitempty"`
+	RecordedBy string `bson:"recorded_by,omitempty" json:"recorded_by,omitempty"`
 }
 
 // AllergyView represents an allergy in the read model
@@ -43,6 +62,132 @@ type AllergyView struct {
 	Notes         string    `bson:"notes" json:"notes"`
 }
 
+// WeightMeasurementView represents one entry of a pet's weight history in the read model
+type WeightMeasurementView struct {
+	ID         string    `bson:"id" json:"id"`
+	Weight     float64   `bson:"weight" json:"weight"`
+	MeasuredAt time.Time `bson:"measured_at" json:"measured_at"`
+	Source     string    `bson:"source" json:"source"`
+	RecordedBy string    `bson:"recorded_by,omitempty" json:"recorded_by,omitempty"`
+	Notes      string    `bson:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// FieldChangeView represents one changed field of a corrected health record
+type FieldChangeView struct {
+	Field string `bson:"field" json:"field"`
+	From  string `bson:"from" json:"from"`
+	To    string `bson:"to" json:"to"`
+}
+
+// HealthRecordChangeView is an audit entry for a corrected or voided health record
+type HealthRecordChangeView struct {
+	RecordType string            `bson:"record_type" json:"record_type"` // vaccination, medical_record or allergy
+	RecordID   string            `bson:"record_id" json:"record_id"`
+	Action     string            `bson:"action" json:"action"` // UPDATED or VOIDED
+	Changes    []FieldChangeView `bson:"changes,omitempty" json:"changes,omitempty"`
+	ChangedBy  string            `bson:"changed_by" json:"changed_by"`
+	Reason     string            `bson:"reason" json:"reason"`
+	ChangedAt  time.Time         `bson:"changed_at" json:"changed_at"`
+}
+
+// PetGuardianView is a user who shares a pet with its primary owner
+type PetGuardianView struct {
+	UserID  string    `bson:"user_id" json:"user_id"`
+	Role    string    `bson:"role" json:"role"`
+	AddedAt time.Time `bson:"added_at" json:"added_at"`
+	AddedBy string    `bson:"added_by" json:"added_by"`
+}
+
+// GuardianInvitationView is a pending invitation to become a guardian of a pet
+type GuardianInvitationView struct {
+	ID        string    `bson:"id" json:"id"`
+	UserID    string    `bson:"user_id" json:"user_id"`
+	Role      string    `bson:"role" json:"role"`
+	InvitedBy string    `bson:"invited_by" json:"invited_by"`
+	InvitedAt time.Time `bson:"invited_at" json:"invited_at"`
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// OwnershipTransferView is a pending transfer of a pet to a new primary owner
+type OwnershipTransferView struct {
+	ID          string    `bson:"id" json:"id"`
+	FromUserID  string    `bson:"from_user_id" json:"from_user_id"`
+	ToUserID    string    `bson:"to_user_id" json:"to_user_id"`
+	Note        string    `bson:"note,omitempty" json:"note,omitempty"`
+	RequestedAt time.Time `bson:"requested_at" json:"requested_at"`
+	ExpiresAt   time.Time `bson:"expires_at" json:"expires_at"`
+}
+
+// PetAttachmentView is a document kept for a pet, optionally belonging to one of its health records
+type PetAttachmentView struct {
+	ID          string    `bson:"id" json:"id"`
+	Category    string    `bson:"category" json:"category"`
+	Title       string    `bson:"title" json:"title"`
+	FileName    string    `bson:"file_name" json:"file_name"`
+	ContentType string    `bson:"content_type" json:"content_type"`
+	Size        int64     `bson:"size" json:"size"`
+	URL         string    `bson:"url" json:"url"`
+	StorageKey  string    `bson:"storage_key" json:"-"`
+	RecordType  string    `bson:"record_type,omitempty" json:"record_type,omitempty"` // vaccination or medical_record
+	RecordID    string    `bson:"record_id,omitempty" json:"record_id,omitempty"`
+	UploadedBy  string    `bson:"uploaded_by" json:"uploaded_by"`
+	UploadedAt  time.Time `bson:"uploaded_at" json:"uploaded_at"`
+}
+
+// DoseLogView records whether a scheduled dose of a medication plan was given or missed
+type DoseLogView struct {
+	ID           string    `bson:"id" json:"id"`
+	ScheduledFor time.Time `bson:"scheduled_for" json:"scheduled_for"`
+	Status       string    `bson:"status" json:"status"` // GIVEN or MISSED
+	RecordedAt   time.Time `bson:"recorded_at" json:"recorded_at"`
+	RecordedBy   string    `bson:"recorded_by" json:"recorded_by"`
+	Notes        string    `bson:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// MedicationPlanView is a course of medication prescribed for a pet, with its dose log
+type MedicationPlanView struct {
+	ID              string        `bson:"id" json:"id"`
+	DrugName        string        `bson:"drug_name" json:"drug_name"`
+	Dose            string        `bson:"dose" json:"dose"`
+	IntervalHours   int           `bson:"interval_hours" json:"interval_hours"`
+	StartDate       time.Time     `bson:"start_date" json:"start_date"`
+	EndDate         time.Time     `bson:"end_date,omitempty" json:"end_date,omitempty"`
+	PrescribedBy    string        `bson:"prescribed_by,omitempty" json:"prescribed_by,omitempty"`
+	Instructions    string        `bson:"instructions,omitempty" json:"instructions,omitempty"`
+	MedicalRecordID string        `bson:"medical_record_id,omitempty" json:"medical_record_id,omitempty"`
+	CreatedBy       string        `bson:"created_by" json:"created_by"`
+	StoppedAt       time.Time     `bson:"stopped_at,omitempty" json:"stopped_at,omitempty"`
+	StoppedBy       string        `bson:"stopped_by,omitempty" json:"stopped_by,omitempty"`
+	StopReason      string        `bson:"stop_reason,omitempty" json:"stop_reason,omitempty"`
+	Doses           []DoseLogView `bson:"doses" json:"doses"`
+}
+
+// End returns when the plan stops scheduling doses; zero for an open-ended plan
+func (v *MedicationPlanView) End() time.Time {
+	return aggregate.MedicationPlanEnd(event.MedicationPlan{EndDate: v.EndDate, StoppedAt: v.StoppedAt})
+}
+
+// IsActiveAt reports whether the plan has started and is neither stopped nor finished at the given time
+func (v *MedicationPlanView) IsActiveAt(at time.Time) bool {
+	end := v.End()
+	return !v.StartDate.After(at) && (end.IsZero() || at.Before(end))
+}
+
+// DoseTimes returns the scheduled dose times of the plan between from and to (inclusive)
+func (v *MedicationPlanView) DoseTimes(from, to time.Time) []time.Time {
+	return aggregate.MedicationDoseTimes(v.StartDate, v.End(), v.IntervalHours, from, to)
+}
+
+// LoggedDose returns the log entry of the dose scheduled at the given time, if any
+func (v *MedicationPlanView) LoggedDose(scheduledFor time.Time) *DoseLogView {
+	for i := range v.Doses {
+		if v.Doses[i].ScheduledFor.Equal(scheduledFor) {
+			return &v.Doses[i]
+		}
+	}
+	return nil
+}
+
 // PetReadModel represents the read model for pet queries
 type PetReadModel struct {
 	ID                  string                   `bson:"_id" json:"id"`
@@ -50,8 +195,12 @@ type PetReadModel struct {
 	Name                string                   `bson:"name" json:"name"`
 	Species             string                   `bson:"species" json:"species"`
 	Breed               string                   `bson:"breed" json:"breed"`
-	Age                 int                      `bson:"age" json:"age"`
+	Age                 int                      `bson:"age" json:"age"` // Derived from the birth date on read
+	AgeMonths           int                      `bson:"-" json:"age_months,omitempty"`
+	BirthDate           *time.Time               `bson:"birth_date,omitempty" json:"birth_date,omitempty"`
+	BirthDateEstimated  bool                     `bson:"birth_date_estimated,omitempty" json:"birth_date_estimated,omitempty"`
 	Weight              float64                  `bson:"weight" json:"weight"`
+	Weights             []WeightMeasurementView  `bson:"weights,omitempty" json:"-"` // Served by GET /pets/{id}/weights
 	ImageUrl            string                   `bson:"image_url" json:"image_url,omitempty"`
 	IsActive            bool                     `bson:"is_active" json:"is_active"`
 	CreatedAt           time.Time                `bson:"created_at" json:"created_at"`
@@ -59,6 +208,66 @@ type PetReadModel struct {
 	VaccinationRecords  []VaccinationRecordView  `bson:"vaccination_records" json:"vaccination_records,omitempty"`
 	MedicalHistory      []MedicalRecordView      `bson:"medical_history" json:"medical_history,omitempty"`
 	Allergies           []AllergyView            `bson:"allergies" json:"allergies,omitempty"`
+	HealthRecordChanges []HealthRecordChangeView `bson:"health_record_changes,omitempty" json:"health_record_changes,omitempty"`
+	Guardians           []PetGuardianView        `bson:"guardians,omitempty" json:"guardians,omitempty"`
+	GuardianInvitations []GuardianInvitationView `bson:"guardian_invitations,omitempty" json:"-"` // Served by GET /pets/{id}/guardians
+	PendingTransfer     *OwnershipTransferView   `bson:"pending_transfer,omitempty" json:"-"`
+	MedicationPlans     []MedicationPlanView     `bson:"medication_plans,omitempty" json:"-"` // Served by GET /pets/{id}/medications
+	Attachments         []PetAttachmentView      `bson:"attachments,omitempty" json:"attachments,omitempty"`
+	Temperament         string                   `bson:"temperament,omitempty" json:"temperament,omitempty"`
+	SpecialInstructions string                   `bson:"special_instructions,omitempty" json:"special_instructions,omitempty"`
+}
+
+// GuardianRole returns the user's role for the pet, or an empty string when the user is not a guardian
+func (m *PetReadModel) GuardianRole(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	if userID == m.UserID {
+		return event.GuardianRoleOwner
+	}
+	for _, guardian := range m.Guardians {
+		if guardian.UserID == userID {
+			return guardian.Role
+		}
+	}
+	return ""
+}
+
+// deriveAge sets the age from the birth date so that it does not go stale
+func (m *PetReadModel) deriveAge(now time.Time) {
+	if m.BirthDate == nil || m.BirthDate.IsZero() {
+		return
+	}
+	m.Age = aggregate.AgeInYears(*m.BirthDate, now)
+	months := (now.Year()-m.BirthDate.Year())*12 + int(now.Month()) - int(m.BirthDate.Month())
+	if now.Day() < m.BirthDate.Day() {
+		months--
+	}
+	if months < 0 {
+		months = 0
+	}
+	m.AgeMonths = months
+}
+
+// WithoutVoidedRecords returns a copy of the pet holding only its current health record, without
+// voided entries and the correction history
+func (m *PetReadModel) WithoutVoidedRecords() *PetReadModel {
+	current := *m
+	current.VaccinationRecords = []VaccinationRecordView{}
+	for _, record := range m.VaccinationRecords {
+		if !record.Voided {
+			current.VaccinationRecords = append(current.VaccinationRecords, record)
+		}
+	}
+	current.MedicalHistory = []MedicalRecordView{}
+	for _, record := range m.MedicalHistory {
+		if !record.Voided {
+			current.MedicalHistory = append(current.MedicalHistory, record)
+		}
+	}
+	current.HealthRecordChanges = nil
+	return &current
 }
 
 // PetProjection handles pet read model operations
@@ -66,6 +275,9 @@ type PetProjection interface {
 	GetByID(ctx context.Context, id string) (*PetReadModel, error)
 	GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*PetReadModel, error)
 	ListAll(ctx context.Context, offset, limit int) ([]*PetReadModel, error)
+	ListWithVaccinationsDueBefore(ctx context.Context, before time.Time) ([]*PetReadModel, error)
+	ListWithPendingInvitationsFor(ctx context.Context, userID string) ([]*PetReadModel, error)
+	ListWithMedicationPlansBetween(ctx context.Context, from, to time.Time) ([]*PetReadModel, error)
 	HandlePetCreated(ctx context.Context, event *event.PetCreated) error
 	HandlePetUpdated(ctx context.Context, event *event.PetUpdated) error
 	HandlePetDeleted(ctx context.Context, event *event.PetDeleted) error
@@ -74,6 +286,28 @@ type PetProjection interface {
 	HandlePetMedicalRecordAdded(ctx context.Context, event *event.PetMedicalRecordAdded) error
 	HandlePetAllergyAdded(ctx context.Context, event *event.PetAllergyAdded) error
 	HandlePetAllergyRemoved(ctx context.Context, event *event.PetAllergyRemoved) error
+	HandlePetVaccinationUpdated(ctx context.Context, event *event.PetVaccinationUpdated) error
+	HandlePetVaccinationVoided(ctx context.Context, event *event.PetVaccinationVoided) error
+	HandlePetMedicalRecordUpdated(ctx context.Context, event *event.PetMedicalRecordUpdated) error
+	HandlePetMedicalRecordVoided(ctx context.Context, event *event.PetMedicalRecordVoided) error
+	HandlePetAllergyUpdated(ctx context.Context, event *event.PetAllergyUpdated) error
+	HandlePetBirthDateSet(ctx context.Context, event *event.PetBirthDateSet) error
+	HandlePetWeightRecorded(ctx context.Context, event *event.PetWeightRecorded) error
+	HandlePetGuardianInvited(ctx context.Context, event *event.PetGuardianInvited) error
+	HandlePetGuardianInvitationAccepted(ctx context.Context, event *event.PetGuardianInvitationAccepted) error
+	HandlePetGuardianInvitationDeclined(ctx context.Context, event *event.PetGuardianInvitationDeclined) error
+	HandlePetGuardianInvitationCancelled(ctx context.Context, event *event.PetGuardianInvitationCancelled) error
+	HandlePetGuardianRemoved(ctx context.Context, event *event.PetGuardianRemoved) error
+	HandlePetOwnershipTransferRequested(ctx context.Context, event *event.PetOwnershipTransferRequested) error
+	HandlePetOwnershipTransferred(ctx context.Context, event *event.PetOwnershipTransferred) error
+	HandlePetOwnershipTransferDeclined(ctx context.Context, event *event.PetOwnershipTransferDeclined) error
+	HandlePetOwnershipTransferCancelled(ctx context.Context, event *event.PetOwnershipTransferCancelled) error
+	HandlePetMedicationPlanAdded(ctx context.Context, event *event.PetMedicationPlanAdded) error
+	HandlePetMedicationPlanStopped(ctx context.Context, event *event.PetMedicationPlanStopped) error
+	HandlePetMedicationDoseLogged(ctx context.Context, event *event.PetMedicationDoseLogged) error
+	HandlePetAttachmentAdded(ctx context.Context, event *event.PetAttachmentAdded) error
+	HandlePetAttachmentRemoved(ctx context.Context, event *event.PetAttachmentRemoved) error
+	HandlePetCareNotesUpdated(ctx context.Context, event *event.PetCareNotesUpdated) error
 }
 
 // MongoPetProjection implements PetProjection using MongoDB
@@ -97,6 +331,21 @@ func NewMongoPetProjection(db *mongo.Database) *MongoPetProjection {
 		{
 			Keys: bson.D{{Key: "species", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "vaccination_records.next_due_date", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "guardians.user_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "guardian_invitations.user_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "pending_transfer.to_user_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "medication_plans.start_date", Value: 1}},
+		},
 	}
 	
 	_, err := collection.Indexes().CreateMany(ctx, indexes)
@@ -119,10 +368,11 @@ func (p *MongoPetProjection) GetByID(ctx context.Context, id string) (*PetReadMo
 		}
 		return nil, fmt.Errorf("failed to get pet: %w", err)
 	}
+	pet.deriveAge(time.Now())
 	return &pet, nil
 }
 
-// GetByUserID retrieves all pets for a user with pagination
+// GetByUserID retrieves all pets a user owns or is a guardian of, with pagination
 func (p *MongoPetProjection) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]*PetReadModel, error) {
 	opts := options.Find().
 		SetSkip(int64(offset)).
@@ -130,7 +380,10 @@ func (p *MongoPetProjection) GetByUserID(ctx context.Context, userID string, off
 		SetSort(bson.D{{Key: "created_at", Value: -1}})
 	
 	filter := bson.M{
-		"user_id":   userID,
+		"$or": []bson.M{
+			{"user_id": userID},
+			{"guardians.user_id": userID},
+		},
 		"is_active": true,
 	}
 	
@@ -144,6 +397,7 @@ func (p *MongoPetProjection) GetByUserID(ctx context.Context, userID string, off
 	if err := cursor.All(ctx, &pets); err != nil {
 		return nil, fmt.Errorf("failed to decode pets: %w", err)
 	}
+	derivePetAges(pets)
 	
 	return pets, nil
 }
@@ -167,10 +421,96 @@ func (p *MongoPetProjection) ListAll(ctx context.Context, offset, limit int) ([]
 	if err := cursor.All(ctx, &pets); err != nil {
 		return nil, fmt.Errorf("failed to decode pets: %w", err)
 	}
+	derivePetAges(pets)
 	
 	return pets, nil
 }
 
+// ListWithVaccinationsDueBefore retrieves active pets with at least one vaccination due on or before the given time
+func (p *MongoPetProjection) ListWithVaccinationsDueBefore(ctx context.Context, before time.Time) ([]*PetReadModel, error) {
+	filter := bson.M{
+		"is_active": true,
+		"vaccination_records": bson.M{
+			"$elemMatch": bson.M{
+				"next_due_date": bson.M{
+					"$gt":  time.Time{}, // Records without a next due date
+					"$lte": before,
+				},
+				"voided": bson.M{"$ne": true},
+			},
+		},
+	}
+
+	cursor, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pets with due vaccinations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var pets []*PetReadModel
+	if err := cursor.All(ctx, &pets); err != nil {
+		return nil, fmt.Errorf("failed to decode pets: %w", err)
+	}
+
+	return pets, nil
+}
+
+// ListWithPendingInvitationsFor retrieves active pets with a guardian invitation or ownership transfer waiting for the user
+func (p *MongoPetProjection) ListWithPendingInvitationsFor(ctx context.Context, userID string) ([]*PetReadModel, error) {
+	filter := bson.M{
+		"is_active": true,
+		"$or": []bson.M{
+			{"guardian_invitations.user_id": userID},
+			{"pending_transfer.to_user_id": userID},
+		},
+	}
+
+	cursor, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pets with pending invitations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var pets []*PetReadModel
+	if err := cursor.All(ctx, &pets); err != nil {
+		return nil, fmt.Errorf("failed to decode pets: %w", err)
+	}
+	derivePetAges(pets)
+
+	return pets, nil
+}
+
+// ListWithMedicationPlansBetween retrieves active pets with a medication plan that is not stopped and
+// schedules doses between from and to
+func (p *MongoPetProjection) ListWithMedicationPlansBetween(ctx context.Context, from, to time.Time) ([]*PetReadModel, error) {
+	filter := bson.M{
+		"is_active": true,
+		"medication_plans": bson.M{
+			"$elemMatch": bson.M{
+				"start_date": bson.M{"$lte": to},
+				"stopped_at": bson.M{"$exists": false},
+				"$or": []bson.M{
+					{"end_date": bson.M{"$exists": false}},
+					{"end_date": bson.M{"$gt": from}},
+				},
+			},
+		},
+	}
+
+	cursor, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pets with medication plans: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var pets []*PetReadModel
+	if err := cursor.All(ctx, &pets); err != nil {
+		return nil, fmt.Errorf("failed to decode pets: %w", err)
+	}
+
+	return pets, nil
+}
+
 // HandlePetCreated handles the PetCreated event
 func (p *MongoPetProjection) HandlePetCreated(ctx context.Context, event *event.PetCreated) error {
 	pet := &PetReadModel{
@@ -315,6 +655,15 @@ func (p *MongoPetProjection) HandlePetMedicalRecordAdded(ctx context.Context, ev
 		Diagnosis:    event.Record.Diagnosis,
 		Notes:        event.Record.Notes,
 	}
+	if provenance := event.Record.Provenance; provenance != nil {
+		medicalRecordView.Provenance = &RecordProvenanceView{
+			Source:     provenance.Source,
+			VendorID:   provenance.VendorID,
+			VendorName: provenance.VendorName,
+			ScheduleID: provenance.ScheduleID,
+			RecordedBy: provenance.RecordedBy,
+		}
+	}
 	
 	update := bson.M{
 		"$push": bson.M{
@@ -395,3 +744,554 @@ func (p *MongoPetProjection) HandlePetAllergyRemoved(ctx context.Context, event
 	
 	return nil
 }
+
+// HandlePetVaccinationUpdated handles the PetVaccinationUpdated event
+func (p *MongoPetProjection) HandlePetVaccinationUpdated(ctx context.Context, event *event.PetVaccinationUpdated) error {
+	filter := bson.M{"_id": event.PetID, "vaccination_records.id": event.Record.ID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"vaccination_records.$.vaccine_name":  event.Record.VaccineName,
+			"vaccination_records.$.date":          event.Record.Date,
+			"vaccination_records.$.next_due_date": event.Record.NextDueDate,
+			"vaccination_records.$.veterinarian":  event.Record.Veterinarian,
+			"vaccination_records.$.notes":         event.Record.Notes,
+			"updated_at":                          event.Timestamp,
+		},
+		"$push": bson.M{
+			"health_record_changes": newHealthRecordChange("vaccination", event.Record.ID, "UPDATED", event.Changes, event.ChangedBy, event.Reason, event.Timestamp),
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "vaccination record", event.Record.ID)
+}
+
+// HandlePetVaccinationVoided handles the PetVaccinationVoided event
+func (p *MongoPetProjection) HandlePetVaccinationVoided(ctx context.Context, event *event.PetVaccinationVoided) error {
+	filter := bson.M{"_id": event.PetID, "vaccination_records.id": event.RecordID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"vaccination_records.$.voided":      true,
+			"vaccination_records.$.voided_at":   event.Timestamp,
+			"vaccination_records.$.voided_by":   event.VoidedBy,
+			"vaccination_records.$.void_reason": event.Reason,
+			"updated_at":                        event.Timestamp,
+		},
+		"$push": bson.M{
+			"health_record_changes": newHealthRecordChange("vaccination", event.RecordID, "VOIDED", nil, event.VoidedBy, event.Reason, event.Timestamp),
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "vaccination record", event.RecordID)
+}
+
+// HandlePetMedicalRecordUpdated handles the PetMedicalRecordUpdated event
+func (p *MongoPetProjection) HandlePetMedicalRecordUpdated(ctx context.Context, event *event.PetMedicalRecordUpdated) error {
+	filter := bson.M{"_id": event.PetID, "medical_history.id": event.Record.ID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"medical_history.$.date":         event.Record.Date,
+			"medical_history.$.description":  event.Record.Description,
+			"medical_history.$.treatment":    event.Record.Treatment,
+			"medical_history.$.veterinarian": event.Record.Veterinarian,
+			"medical_history.$.diagnosis":    event.Record.Diagnosis,
+			"medical_history.$.notes":        event.Record.Notes,
+			"updated_at":                     event.Timestamp,
+		},
+		"$push": bson.M{
+			"health_record_changes": newHealthRecordChange("medical_record", event.Record.ID, "UPDATED", event.Changes, event.ChangedBy, event.Reason, event.Timestamp),
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "medical record", event.Record.ID)
+}
+
+// HandlePetMedicalRecordVoided handles the PetMedicalRecordVoided event
+func (p *MongoPetProjection) HandlePetMedicalRecordVoided(ctx context.Context, event *event.PetMedicalRecordVoided) error {
+	filter := bson.M{"_id": event.PetID, "medical_history.id": event.RecordID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"medical_history.$.voided":      true,
+			"medical_history.$.voided_at":   event.Timestamp,
+			"medical_history.$.voided_by":   event.VoidedBy,
+			"medical_history.$.void_reason": event.Reason,
+			"updated_at":                    event.Timestamp,
+		},
+		"$push": bson.M{
+			"health_record_changes": newHealthRecordChange("medical_record", event.RecordID, "VOIDED", nil, event.VoidedBy, event.Reason, event.Timestamp),
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "medical record", event.RecordID)
+}
+
+// HandlePetAllergyUpdated handles the PetAllergyUpdated event
+func (p *MongoPetProjection) HandlePetAllergyUpdated(ctx context.Context, event *event.PetAllergyUpdated) error {
+	filter := bson.M{"_id": event.PetID, "allergies.id": event.Allergy.ID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"allergies.$.allergen":       event.Allergy.Allergen,
+			"allergies.$.severity":       event.Allergy.Severity,
+			"allergies.$.symptoms":       event.Allergy.Symptoms,
+			"allergies.$.diagnosed_date": event.Allergy.DiagnosedDate,
+			"allergies.$.notes":          event.Allergy.Notes,
+			"updated_at":                 event.Timestamp,
+		},
+		"$push": bson.M{
+			"health_record_changes": newHealthRecordChange("allergy", event.Allergy.ID, "UPDATED", event.Changes, event.ChangedBy, event.Reason, event.Timestamp),
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "allergy", event.Allergy.ID)
+}
+
+// updateHealthRecord applies an update to one health record of a pet
+func (p *MongoPetProjection) updateHealthRecord(ctx context.Context, filter, update bson.M, recordType, recordID string) error {
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", recordType, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%s not found: %s", recordType, recordID)
+	}
+
+	return nil
+}
+
+// newHealthRecordChange builds the audit entry of a health record correction
+func newHealthRecordChange(recordType, recordID, action string, changes []event.FieldChange, changedBy, reason string, changedAt time.Time) HealthRecordChangeView {
+	change := HealthRecordChangeView{
+		RecordType: recordType,
+		RecordID:   recordID,
+		Action:     action,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		ChangedAt:  changedAt,
+	}
+	for _, c := range changes {
+		change.Changes = append(change.Changes, FieldChangeView{Field: c.Field, From: c.From, To: c.To})
+	}
+	return change
+}
+
+// HandlePetBirthDateSet handles the PetBirthDateSet event
+func (p *MongoPetProjection) HandlePetBirthDateSet(ctx context.Context, event *event.PetBirthDateSet) error {
+	filter := bson.M{"_id": event.PetID}
+	update := bson.M{
+		"$set": bson.M{
+			"birth_date":           event.BirthDate,
+			"birth_date_estimated": event.Estimated,
+			"age":                  aggregate.AgeInYears(event.BirthDate, event.Timestamp),
+			"updated_at":           event.Timestamp,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to set pet birth date: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", event.PetID)
+	}
+
+	return nil
+}
+
+// HandlePetWeightRecorded handles the PetWeightRecorded event
+func (p *MongoPetProjection) HandlePetWeightRecorded(ctx context.Context, event *event.PetWeightRecorded) error {
+	filter := bson.M{"_id": event.PetID}
+
+	measurementView := WeightMeasurementView{
+		ID:         event.Measurement.ID,
+		Weight:     event.Measurement.Weight,
+		MeasuredAt: event.Measurement.MeasuredAt,
+		Source:     event.Measurement.Source,
+		RecordedBy: event.Measurement.RecordedBy,
+		Notes:      event.Measurement.Notes,
+	}
+
+	update := bson.M{
+		"$push": bson.M{
+			"weights": measurementView,
+		},
+		"$set": bson.M{
+			"weight":     event.CurrentWeight,
+			"updated_at": event.Timestamp,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to record pet weight: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", event.PetID)
+	}
+
+	return nil
+}
+
+// HandlePetGuardianInvited handles the PetGuardianInvited event
+func (p *MongoPetProjection) HandlePetGuardianInvited(ctx context.Context, event *event.PetGuardianInvited) error {
+	invitationView := GuardianInvitationView{
+		ID:        event.Invitation.ID,
+		UserID:    event.Invitation.UserID,
+		Role:      event.Invitation.Role,
+		InvitedBy: event.Invitation.InvitedBy,
+		InvitedAt: event.Invitation.InvitedAt,
+		ExpiresAt: event.Invitation.ExpiresAt,
+	}
+
+	update := bson.M{
+		"$push": bson.M{"guardian_invitations": invitationView},
+		"$set":  bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to add guardian invitation")
+}
+
+// HandlePetGuardianInvitationAccepted handles the PetGuardianInvitationAccepted event
+func (p *MongoPetProjection) HandlePetGuardianInvitationAccepted(ctx context.Context, event *event.PetGuardianInvitationAccepted) error {
+	guardianView := PetGuardianView{
+		UserID:  event.Guardian.UserID,
+		Role:    event.Guardian.Role,
+		AddedAt: event.Guardian.AddedAt,
+		AddedBy: event.Guardian.AddedBy,
+	}
+
+	update := bson.M{
+		"$pull": bson.M{"guardian_invitations": bson.M{"id": event.InvitationID}},
+		"$push": bson.M{"guardians": guardianView},
+		"$set":  bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to add guardian")
+}
+
+// HandlePetGuardianInvitationDeclined handles the PetGuardianInvitationDeclined event
+func (p *MongoPetProjection) HandlePetGuardianInvitationDeclined(ctx context.Context, event *event.PetGuardianInvitationDeclined) error {
+	update := bson.M{
+		"$pull": bson.M{"guardian_invitations": bson.M{"id": event.InvitationID}},
+		"$set":  bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to remove guardian invitation")
+}
+
+// HandlePetGuardianInvitationCancelled handles the PetGuardianInvitationCancelled event
+func (p *MongoPetProjection) HandlePetGuardianInvitationCancelled(ctx context.Context, event *event.PetGuardianInvitationCancelled) error {
+	update := bson.M{
+		"$pull": bson.M{"guardian_invitations": bson.M{"id": event.InvitationID}},
+		"$set":  bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to remove guardian invitation")
+}
+
+// HandlePetGuardianRemoved handles the PetGuardianRemoved event
+func (p *MongoPetProjection) HandlePetGuardianRemoved(ctx context.Context, event *event.PetGuardianRemoved) error {
+	update := bson.M{
+		"$pull": bson.M{"guardians": bson.M{"user_id": event.UserID}},
+		"$set":  bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to remove guardian")
+}
+
+// HandlePetOwnershipTransferRequested handles the PetOwnershipTransferRequested event
+func (p *MongoPetProjection) HandlePetOwnershipTransferRequested(ctx context.Context, event *event.PetOwnershipTransferRequested) error {
+	transferView := OwnershipTransferView{
+		ID:          event.Transfer.ID,
+		FromUserID:  event.Transfer.FromUserID,
+		ToUserID:    event.Transfer.ToUserID,
+		Note:        event.Transfer.Note,
+		RequestedAt: event.Transfer.RequestedAt,
+		ExpiresAt:   event.Transfer.ExpiresAt,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"pending_transfer": transferView,
+			"updated_at":       event.Timestamp,
+		},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to set ownership transfer")
+}
+
+// HandlePetOwnershipTransferred handles the PetOwnershipTransferred event
+func (p *MongoPetProjection) HandlePetOwnershipTransferred(ctx context.Context, event *event.PetOwnershipTransferred) error {
+	update := bson.M{
+		"$set": bson.M{
+			"user_id":    event.ToUserID,
+			"updated_at": event.Timestamp,
+		},
+		"$unset": bson.M{
+			"guardians":            "",
+			"guardian_invitations": "",
+			"pending_transfer":     "",
+		},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to transfer pet ownership")
+}
+
+// HandlePetOwnershipTransferDeclined handles the PetOwnershipTransferDeclined event
+func (p *MongoPetProjection) HandlePetOwnershipTransferDeclined(ctx context.Context, event *event.PetOwnershipTransferDeclined) error {
+	update := bson.M{
+		"$unset": bson.M{"pending_transfer": ""},
+		"$set":   bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to clear ownership transfer")
+}
+
+// HandlePetOwnershipTransferCancelled handles the PetOwnershipTransferCancelled event
+func (p *MongoPetProjection) HandlePetOwnershipTransferCancelled(ctx context.Context, event *event.PetOwnershipTransferCancelled) error {
+	update := bson.M{
+		"$unset": bson.M{"pending_transfer": ""},
+		"$set":   bson.M{"updated_at": event.Timestamp},
+	}
+	return p.updateGuardianship(ctx, event.PetID, update, "failed to clear ownership transfer")
+}
+
+// HandlePetMedicationPlanAdded handles the PetMedicationPlanAdded event
+func (p *MongoPetProjection) HandlePetMedicationPlanAdded(ctx context.Context, event *event.PetMedicationPlanAdded) error {
+	filter := bson.M{"_id": event.PetID}
+
+	planView := MedicationPlanView{
+		ID:              event.Plan.ID,
+		DrugName:        event.Plan.DrugName,
+		Dose:            event.Plan.Dose,
+		IntervalHours:   event.Plan.IntervalHours,
+		StartDate:       event.Plan.StartDate,
+		EndDate:         event.Plan.EndDate,
+		PrescribedBy:    event.Plan.PrescribedBy,
+		Instructions:    event.Plan.Instructions,
+		MedicalRecordID: event.Plan.MedicalRecordID,
+		CreatedBy:       event.Plan.CreatedBy,
+		Doses:           []DoseLogView{},
+	}
+
+	update := bson.M{
+		"$push": bson.M{
+			"medication_plans": planView,
+		},
+		"$set": bson.M{
+			"updated_at": event.Timestamp,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to add medication plan: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", event.PetID)
+	}
+
+	return nil
+}
+
+// HandlePetMedicationPlanStopped handles the PetMedicationPlanStopped event
+func (p *MongoPetProjection) HandlePetMedicationPlanStopped(ctx context.Context, event *event.PetMedicationPlanStopped) error {
+	filter := bson.M{"_id": event.PetID, "medication_plans.id": event.PlanID}
+
+	update := bson.M{
+		"$set": bson.M{
+			"medication_plans.$.stopped_at":  event.Timestamp,
+			"medication_plans.$.stopped_by":  event.StoppedBy,
+			"medication_plans.$.stop_reason": event.Reason,
+			"updated_at":                     event.Timestamp,
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "medication plan", event.PlanID)
+}
+
+// HandlePetMedicationDoseLogged handles the PetMedicationDoseLogged event
+func (p *MongoPetProjection) HandlePetMedicationDoseLogged(ctx context.Context, event *event.PetMedicationDoseLogged) error {
+	filter := bson.M{"_id": event.PetID, "medication_plans.id": event.PlanID}
+
+	doseView := DoseLogView{
+		ID:           event.Dose.ID,
+		ScheduledFor: event.Dose.ScheduledFor,
+		Status:       event.Dose.Status,
+		RecordedAt:   event.Dose.RecordedAt,
+		RecordedBy:   event.Dose.RecordedBy,
+		Notes:        event.Dose.Notes,
+	}
+
+	update := bson.M{
+		"$push": bson.M{
+			"medication_plans.$.doses": doseView,
+		},
+		"$set": bson.M{
+			"updated_at": event.Timestamp,
+		},
+	}
+
+	return p.updateHealthRecord(ctx, filter, update, "medication plan", event.PlanID)
+}
+
+// HandlePetAttachmentAdded handles the PetAttachmentAdded event
+func (p *MongoPetProjection) HandlePetAttachmentAdded(ctx context.Context, event *event.PetAttachmentAdded) error {
+	filter := bson.M{"_id": event.PetID}
+
+	attachmentView := PetAttachmentView{
+		ID:          event.Attachment.ID,
+		Category:    event.Attachment.Category,
+		Title:       event.Attachment.Title,
+		FileName:    event.Attachment.FileName,
+		ContentType: event.Attachment.ContentType,
+		Size:        event.Attachment.Size,
+		URL:         event.Attachment.URL,
+		StorageKey:  event.Attachment.StorageKey,
+		RecordType:  event.Attachment.RecordType,
+		RecordID:    event.Attachment.RecordID,
+		UploadedBy:  event.Attachment.UploadedBy,
+		UploadedAt:  event.Attachment.UploadedAt,
+	}
+
+	update := bson.M{
+		"$push": bson.M{
+			"attachments": attachmentView,
+		},
+		"$set": bson.M{
+			"updated_at": event.Timestamp,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", event.PetID)
+	}
+
+	return nil
+}
+
+// HandlePetAttachmentRemoved handles the PetAttachmentRemoved event
+func (p *MongoPetProjection) HandlePetAttachmentRemoved(ctx context.Context, event *event.PetAttachmentRemoved) error {
+	filter := bson.M{"_id": event.PetID}
+
+	update := bson.M{
+		"$pull": bson.M{
+			"attachments": bson.M{"id": event.AttachmentID},
+		},
+		"$set": bson.M{
+			"updated_at": event.Timestamp,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to remove attachment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", event.PetID)
+	}
+
+	return nil
+}
+
+// HandlePetCareNotesUpdated handles the PetCareNotesUpdated event
+func (p *MongoPetProjection) HandlePetCareNotesUpdated(ctx context.Context, event *event.PetCareNotesUpdated) error {
+	filter := bson.M{"_id": event.PetID}
+	update := bson.M{
+		"$set": bson.M{
+			"temperament":          event.Temperament,
+			"special_instructions": event.SpecialInstructions,
+			"updated_at":           event.Timestamp,
+		},
+	}
+
+	result, err := p.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update pet care notes: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", event.PetID)
+	}
+
+	return nil
+}
+
+// updateGuardianship applies a guardianship update to a pet
+func (p *MongoPetProjection) updateGuardianship(ctx context.Context, petID string, update bson.M, failure string) error {
+	result, err := p.collection.UpdateOne(ctx, bson.M{"_id": petID}, update)
+	if err != nil {
+		return fmt.Errorf("%s: %w", failure, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pet not found: %s", petID)
+	}
+
+	return nil
+}
+
+// MigrateLegacyProfiles rebuilds the birth date and weight history of pets created before they
+// existed: the stored age becomes an estimated birth date counted back from when the pet was
+// created, and the stored weight becomes the first weight measurement. Pets that already have
+// them are left alone, so the migration can run on every start.
+func (p *MongoPetProjection) MigrateLegacyProfiles(ctx context.Context) (int, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"birth_date": bson.M{"$exists": false}, "age": bson.M{"$gt": 0}},
+			{"weights": bson.M{"$exists": false}, "weight": bson.M{"$gt": 0}},
+		},
+	}
+
+	cursor, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find legacy pets: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var pets []*PetReadModel
+	if err := cursor.All(ctx, &pets); err != nil {
+		return 0, fmt.Errorf("failed to decode legacy pets: %w", err)
+	}
+
+	migrated := 0
+	for _, pet := range pets {
+		set := bson.M{}
+		if pet.BirthDate == nil && pet.Age > 0 {
+			set["birth_date"] = pet.CreatedAt.AddDate(-pet.Age, 0, 0)
+			set["birth_date_estimated"] = true
+		}
+		if len(pet.Weights) == 0 && pet.Weight > 0 {
+			set["weights"] = []WeightMeasurementView{{
+				ID:         pet.ID + "-initial",
+				Weight:     pet.Weight,
+				MeasuredAt: pet.UpdatedAt,
+				Source:     event.WeightSourceOwner,
+				Notes:      "Migrated from pet profile",
+			}}
+		}
+		if len(set) == 0 {
+			continue
+		}
+
+		if _, err := p.collection.UpdateOne(ctx, bson.M{"_id": pet.ID}, bson.M{"$set": set}); err != nil {
+			return migrated, fmt.Errorf("failed to migrate pet %s: %w", pet.ID, err)
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// derivePetAges derives the age of each pet from its birth date
+func derivePetAges(pets []*PetReadModel) {
+	now := time.Now()
+	for _, pet := range pets {
+		pet.deriveAge(now)
+	}
+}
@@ -3,11 +3,13 @@ package projection
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"whisko-petcare/internal/domain/event"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -27,6 +29,105 @@ type ScheduleReadModel struct {
 	IsActive     bool                `bson:"is_active" json:"is_active"`
 	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
 	UpdatedAt    time.Time           `bson:"updated_at" json:"updated_at"`
+
+	CareBriefConsents []CareBriefConsentRead `bson:"care_brief_consents,omitempty" json:"care_brief_consents,omitempty"`
+	VisitReport       *VisitReportRead       `bson:"visit_report,omitempty" json:"visit_report,omitempty"`
+
+	PaymentID          string                        `bson:"payment_id,omitempty" json:"payment_id,omitempty"`
+	TotalPrice         int                           `bson:"total_price,omitempty" json:"total_price,omitempty"`
+	SeriesID           string                        `bson:"series_id,omitempty" json:"series_id,omitempty"`
+	RescheduleCount    int                           `bson:"reschedule_count,omitempty" json:"reschedule_count"`
+	RescheduleProposal *RescheduleProposalRead       `bson:"reschedule_proposal,omitempty" json:"reschedule_proposal,omitempty"`
+	PriceAdjustments   []SchedulePriceAdjustmentRead `bson:"price_adjustments,omitempty" json:"price_adjustments,omitempty"`
+
+	AssignedStaff *AssignedStaffRead `bson:"assigned_staff,omitempty" json:"assigned_staff,omitempty"`
+
+	PetLines []SchedulePetLineRead `bson:"pet_lines,omitempty" json:"pet_lines,omitempty"`
+
+	// Sequence counts the changes to the time, status or content of the booking, so calendars that
+	// imported it replace their copy
+	Sequence int `bson:"sequence" json:"sequence"`
+}
+
+// SchedulePetLineRead is one pet of a booking covering several pets, with its own services and price
+type SchedulePetLineRead struct {
+	PetID           string              `bson:"pet_id" json:"pet_id"`
+	PetName         string              `bson:"pet_name" json:"pet_name"`
+	Species         string              `bson:"species" json:"species"`
+	Breed           string              `bson:"breed" json:"breed"`
+	Services        []BookedServiceRead `bson:"services" json:"services"`
+	DurationMinutes int                 `bson:"duration_minutes" json:"duration_minutes"`
+	Price           int                 `bson:"price" json:"price"`
+	Status          string              `bson:"status" json:"status"`
+	CancelledAt     *time.Time          `bson:"cancelled_at,omitempty" json:"cancelled_at,omitempty"`
+}
+
+// AssignedStaffRead is the staff member of the shop who does the work
+type AssignedStaffRead struct {
+	UserID       string    `bson:"user_id" json:"user_id"`
+	AssignedBy   string    `bson:"assigned_by" json:"assigned_by"`
+	AutoAssigned bool      `bson:"auto_assigned" json:"auto_assigned"`
+	AssignedAt   time.Time `bson:"assigned_at" json:"assigned_at"`
+}
+
+// RescheduleProposalRead is a new time the vendor proposed, waiting for the customer to accept or decline
+type RescheduleProposalRead struct {
+	ID         string    `bson:"id" json:"id"`
+	StartTime  time.Time `bson:"start_time" json:"start_time"`
+	EndTime    time.Time `bson:"end_time" json:"end_time"`
+	Reason     string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	ProposedBy string    `bson:"proposed_by" json:"proposed_by"`
+	ProposedAt time.Time `bson:"proposed_at" json:"proposed_at"`
+}
+
+// SchedulePriceAdjustmentRead shows how a change in the price of the booking was paid or refunded
+type SchedulePriceAdjustmentRead struct {
+	ID        string    `bson:"id" json:"id"`
+	Kind      string    `bson:"kind" json:"kind"`
+	OldPrice  int       `bson:"old_price" json:"old_price"`
+	NewPrice  int       `bson:"new_price" json:"new_price"`
+	Amount    int       `bson:"amount" json:"amount"`
+	PaymentID string    `bson:"payment_id,omitempty" json:"payment_id,omitempty"`
+	RefundID  string    `bson:"refund_id,omitempty" json:"refund_id,omitempty"`
+	Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CareBriefConsentRead shows that the owner shares a booked pet's care brief with the booked shop
+type CareBriefConsentRead struct {
+	PetID    string    `bson:"pet_id" json:"pet_id"`
+	SharedBy string    `bson:"shared_by" json:"shared_by"`
+	SharedAt time.Time `bson:"shared_at" json:"shared_at"`
+}
+
+// VisitReportRead is the report vendor staff submitted on completing the booking
+type VisitReportRead struct {
+	ID                string                   `bson:"id" json:"id"`
+	ServicesPerformed []VisitReportServiceRead `bson:"services_performed" json:"services_performed"`
+	Observations      string                   `bson:"observations" json:"observations"`
+	Weight            float64                  `bson:"weight,omitempty" json:"weight,omitempty"`
+	PhotoURLs         []string                 `bson:"photo_urls,omitempty" json:"photo_urls,omitempty"`
+	Recommendations   string                   `bson:"recommendations,omitempty" json:"recommendations,omitempty"`
+	SubmittedBy       string                   `bson:"submitted_by" json:"submitted_by"`
+	SubmittedAt       time.Time                `bson:"submitted_at" json:"submitted_at"`
+	AcceptedBy        string                   `bson:"accepted_by,omitempty" json:"accepted_by,omitempty"`
+	AcceptedAt        time.Time                `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	MedicalRecordID   string                   `bson:"medical_record_id,omitempty" json:"medical_record_id,omitempty"`
+	MedicalRecords    []VisitReportRecordRead  `bson:"medical_records,omitempty" json:"medical_records,omitempty"`
+}
+
+// VisitReportRecordRead is the medical record the visit report was accepted into for one pet
+type VisitReportRecordRead struct {
+	PetID           string    `bson:"pet_id" json:"pet_id"`
+	MedicalRecordID string    `bson:"medical_record_id" json:"medical_record_id"`
+	AcceptedBy      string    `bson:"accepted_by" json:"accepted_by"`
+	AcceptedAt      time.Time `bson:"accepted_at" json:"accepted_at"`
+}
+
+type VisitReportServiceRead struct {
+	ServiceID string `bson:"service_id" json:"service_id"`
+	Name      string `bson:"name" json:"name"`
+	Notes     string `bson:"notes,omitempty" json:"notes,omitempty"`
 }
 
 type BookingUserRead struct {
@@ -71,42 +172,109 @@ func NewMongoScheduleProjection(db *mongo.Database) *MongoScheduleProjection {
 	// Create indexes
 	ctx := context.Background()
 	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "pet_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "is_active", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "start_time", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "assigned_staff.user_id", Value: 1},
+				{Key: "start_time", Value: 1},
+			},
+		},
+		// Schedule searches: equality filters first, then the sort field and the ID that breaks its ties
 		{
 			Keys: bson.D{
 				{Key: "user_id", Value: 1},
+				{Key: "start_time", Value: 1},
+				{Key: "_id", Value: 1},
 			},
 		},
 		{
 			Keys: bson.D{
-				{Key: "shop_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
 			},
 		},
 		{
 			Keys: bson.D{
-				{Key: "pet_id", Value: 1},
+				{Key: "shop_id", Value: 1},
+				{Key: "start_time", Value: 1},
+				{Key: "_id", Value: 1},
 			},
 		},
 		{
 			Keys: bson.D{
+				{Key: "shop_id", Value: 1},
 				{Key: "status", Value: 1},
+				{Key: "start_time", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "shop_id", Value: 1},
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
 			},
 		},
 		{
 			Keys: bson.D{
 				{Key: "is_active", Value: 1},
+				{Key: "start_time", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "start_time", Value: 1},
+				{Key: "_id", Value: 1},
 			},
 		},
 		{
 			Keys: bson.D{
+				{Key: "booked_shop.booked_services.service_id", Value: 1},
 				{Key: "start_time", Value: 1},
 			},
 		},
+		{
+			Keys: bson.D{
+				{Key: "pet_lines.pet_id", Value: 1},
+			},
+		},
 	}
 	
 	_, err := collection.Indexes().CreateMany(ctx, indexes)
 	if err != nil {
 		fmt.Printf("Warning: failed to create schedule indexes: %v\n", err)
 	}
+
+	// Early read models only kept the user and shop IDs nested; copy them to the top level the indexes cover
+	for field, nested := range map[string]string{"user_id": "$booking_user.user_id", "shop_id": "$booked_shop.shop_id"} {
+		backfill := mongo.Pipeline{{{Key: "$set", Value: bson.M{field: nested}}}}
+		_, err := collection.UpdateMany(ctx, bson.M{field: bson.M{"$in": []interface{}{nil, ""}}}, backfill)
+		if err != nil {
+			fmt.Printf("Warning: failed to backfill schedule %s: %v\n", field, err)
+		}
+	}
 	
 	return &MongoScheduleProjection{
 		collection: collection,
@@ -123,127 +291,230 @@ func (p *MongoScheduleProjection) GetByID(ctx context.Context, id string) (inter
 	return schedule, nil
 }
 
-// GetByUserID retrieves schedules by user ID with pagination
-func (p *MongoScheduleProjection) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]interface{}, error) {
-	opts := options.Find().
-		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "start_time", Value: -1}})
-	
-	// Only set limit if it's greater than 0, otherwise return all
-	if limit > 0 {
-		opts.SetLimit(int64(limit))
-	}
-	
-	// Query both top-level user_id and nested booking_user.user_id for backward compatibility
+// GetByStaffID retrieves the active schedules assigned to a staff member that start within the time range,
+// earliest first
+func (p *MongoScheduleProjection) GetByStaffID(ctx context.Context, staffID string, from, to time.Time) ([]interface{}, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}})
+
 	filter := bson.M{
-		"$or": []bson.M{
-			{"user_id": userID},
-			{"booking_user.user_id": userID},
-		},
-		"is_active": true,
+		"assigned_staff.user_id": staffID,
+		"is_active":              true,
+		"start_time":             bson.M{"$gte": from, "$lt": to},
 	}
-	fmt.Printf("🔍 GetByUserID - Querying schedules with filter: %+v\n", filter)
-	
+
 	cursor, err := p.collection.Find(ctx, filter, opts)
 	if err != nil {
-		fmt.Printf("❌ GetByUserID - Query error: %v\n", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
-	
+
 	var schedules []interface{}
 	for cursor.Next(ctx) {
 		var schedule ScheduleReadModel
 		if err := cursor.Decode(&schedule); err != nil {
-			fmt.Printf("❌ GetByUserID - Decode error: %v\n", err)
 			return nil, err
 		}
 		schedules = append(schedules, schedule)
 	}
-	
+
 	if err := cursor.Err(); err != nil {
-		fmt.Printf("❌ GetByUserID - Cursor error: %v\n", err)
 		return nil, err
 	}
-	
-	fmt.Printf("✅ GetByUserID - Found %d schedules for user_id: %s\n", len(schedules), userID)
+
 	return schedules, nil
 }
 
-// GetByShopID retrieves schedules by shop ID with pagination
-func (p *MongoScheduleProjection) GetByShopID(ctx context.Context, shopID string, offset, limit int) ([]interface{}, error) {
-	opts := options.Find().
-		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "start_time", Value: -1}})
-	
-	// Only set limit if it's greater than 0, otherwise return all
-	if limit > 0 {
-		opts.SetLimit(int64(limit))
+// GetCalendarByUserID retrieves the schedules of a user that start from the given time, earliest first.
+// Cancelled schedules are included so calendars can remove them.
+func (p *MongoScheduleProjection) GetCalendarByUserID(ctx context.Context, userID string, since time.Time) ([]ScheduleReadModel, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"user_id": userID},
+			{"booking_user.user_id": userID},
+		},
+		"start_time": bson.M{"$gte": since},
 	}
-	
-	// Query both top-level shop_id and nested booked_shop.shop_id for backward compatibility
+	return p.findCalendar(ctx, filter)
+}
+
+// GetCalendarByShopID retrieves the schedules of a shop that start from the given time, earliest first.
+// Cancelled schedules are included so calendars can remove them.
+func (p *MongoScheduleProjection) GetCalendarByShopID(ctx context.Context, shopID string, since time.Time) ([]ScheduleReadModel, error) {
 	filter := bson.M{
 		"$or": []bson.M{
 			{"shop_id": shopID},
 			{"booked_shop.shop_id": shopID},
 		},
-		"is_active": true,
+		"start_time": bson.M{"$gte": since},
 	}
-	fmt.Printf("🔍 GetByShopID - Querying schedules with filter: %+v\n", filter)
-	
+	return p.findCalendar(ctx, filter)
+}
+
+// findCalendar retrieves the schedules matching a calendar filter, earliest first
+func (p *MongoScheduleProjection) findCalendar(ctx context.Context, filter bson.M) ([]ScheduleReadModel, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}})
+
 	cursor, err := p.collection.Find(ctx, filter, opts)
 	if err != nil {
-		fmt.Printf("❌ GetByShopID - Query error: %v\n", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
-	
-	var schedules []interface{}
-	for cursor.Next(ctx) {
-		var schedule ScheduleReadModel
-		if err := cursor.Decode(&schedule); err != nil {
-			fmt.Printf("❌ GetByShopID - Decode error: %v\n", err)
-			return nil, err
-		}
-		schedules = append(schedules, schedule)
-	}
-	
-	if err := cursor.Err(); err != nil {
-		fmt.Printf("❌ GetByShopID - Cursor error: %v\n", err)
+
+	schedules := []ScheduleReadModel{}
+	if err := cursor.All(ctx, &schedules); err != nil {
 		return nil, err
 	}
-	
-	fmt.Printf("✅ GetByShopID - Found %d schedules for shop_id: %s\n", len(schedules), shopID)
 	return schedules, nil
 }
 
-// ListAll retrieves all schedules with pagination
-func (p *MongoScheduleProjection) ListAll(ctx context.Context, offset, limit int) ([]interface{}, error) {
+// Fields schedules can be sorted by
+const (
+	ScheduleSortStartTime = "start_time"
+	ScheduleSortCreatedAt = "created_at"
+)
+
+// ScheduleSearch filters, orders and pages schedules; zero fields do not filter
+type ScheduleSearch struct {
+	UserID     string
+	ShopID     string
+	From       time.Time // Starting at or after
+	To         time.Time // Starting before
+	Statuses   []string  // Active schedules of any status when empty
+	PetID      string
+	ServiceID  string
+	StaffID    string
+	Text       string // Matched against pet, customer, shop and service names, ignoring case
+	SortField  string // ScheduleSortStartTime or ScheduleSortCreatedAt
+	Descending bool
+	After      *ScheduleCursor // Page starts after this schedule; first page when nil
+	Offset     int             // Schedules skipped before the page
+	Limit      int
+}
+
+// ScheduleCursor is the position of a schedule in a sorted list: its sort value, with the ID breaking ties
+type ScheduleCursor struct {
+	Value time.Time
+	ID    string
+}
+
+// SchedulePage is one page of a schedule search
+type SchedulePage struct {
+	Schedules []*ScheduleReadModel
+	Total     int64           // Schedules matching the filters across all pages
+	Next      *ScheduleCursor // Nil on the last page
+}
+
+// Search retrieves a page of schedules. Pages are keyed on the sort value and ID of the last schedule
+// rather than skipped over, so they stay consistent while bookings are added and cost the same however
+// deep the page. An offset is still honoured for clients that page by skipping.
+func (p *MongoScheduleProjection) Search(ctx context.Context, search ScheduleSearch) (*SchedulePage, error) {
+	filter := scheduleSearchFilter(search)
+
+	total, err := p.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count schedules: %w", err)
+	}
+
+	direction, op := 1, "$gt"
+	if search.Descending {
+		direction, op = -1, "$lt"
+	}
+	if search.After != nil {
+		filter = bson.M{"$and": []bson.M{filter, {
+			"$or": []bson.M{
+				{search.SortField: bson.M{op: search.After.Value}},
+				{search.SortField: search.After.Value, "_id": bson.M{op: search.After.ID}},
+			},
+		}}}
+	}
+
+	// One schedule more than the page tells whether there is a next page
 	opts := options.Find().
-		SetSkip(int64(offset)).
-		SetLimit(int64(limit)).
-		SetSort(bson.D{{Key: "start_time", Value: -1}})
-	
-	cursor, err := p.collection.Find(ctx, bson.M{"is_active": true}, opts)
+		SetSort(bson.D{{Key: search.SortField, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(int64(search.Limit + 1))
+	if search.Offset > 0 {
+		opts.SetSkip(int64(search.Offset))
+	}
+
+	cursor, err := p.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to find schedules: %w", err)
 	}
 	defer cursor.Close(ctx)
-	
-	var schedules []interface{}
-	for cursor.Next(ctx) {
-		var schedule ScheduleReadModel
-		if err := cursor.Decode(&schedule); err != nil {
-			return nil, err
+
+	schedules := []*ScheduleReadModel{}
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to decode schedules: %w", err)
+	}
+
+	page := &SchedulePage{Schedules: schedules, Total: total}
+	if len(schedules) > search.Limit {
+		page.Schedules = schedules[:search.Limit]
+		last := page.Schedules[search.Limit-1]
+		page.Next = &ScheduleCursor{Value: last.StartTime, ID: last.ID}
+		if search.SortField == ScheduleSortCreatedAt {
+			page.Next.Value = last.CreatedAt
 		}
-		schedules = append(schedules, schedule)
 	}
-	
-	if err := cursor.Err(); err != nil {
-		return nil, err
+	return page, nil
+}
+
+// scheduleSearchFilter builds the filter of a schedule search, without its page position
+func scheduleSearchFilter(search ScheduleSearch) bson.M {
+	filter := bson.M{}
+	conditions := []bson.M{}
+
+	if search.UserID != "" {
+		filter["user_id"] = search.UserID
 	}
-	
-	return schedules, nil
+	if search.ShopID != "" {
+		filter["shop_id"] = search.ShopID
+	}
+	if len(search.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": search.Statuses}
+	} else {
+		filter["is_active"] = true
+	}
+
+	startTime := bson.M{}
+	if !search.From.IsZero() {
+		startTime["$gte"] = search.From
+	}
+	if !search.To.IsZero() {
+		startTime["$lt"] = search.To
+	}
+	if len(startTime) > 0 {
+		filter["start_time"] = startTime
+	}
+
+	if search.PetID != "" {
+		// Multi-pet bookings list every pet in their pet lines
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"pet_id": search.PetID},
+			{"pet_lines.pet_id": search.PetID},
+		}})
+	}
+	if search.ServiceID != "" {
+		filter["booked_shop.booked_services.service_id"] = search.ServiceID
+	}
+	if search.StaffID != "" {
+		filter["assigned_staff.user_id"] = search.StaffID
+	}
+	if search.Text != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(search.Text), Options: "i"}
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"assigned_pet.name": pattern},
+			{"pet_lines.pet_name": pattern},
+			{"booking_user.name": pattern},
+			{"booked_shop.name": pattern},
+			{"booked_shop.booked_services.name": pattern},
+		}})
+	}
+
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+	return filter
 }
 
 // HandleScheduleCreated handles ScheduleCreated event
@@ -281,6 +552,28 @@ func (p *MongoScheduleProjection) HandleScheduleCreated(ctx context.Context, evt
 		IsActive:  true,
 		CreatedAt: evt.Timestamp,
 		UpdatedAt: evt.Timestamp,
+
+		PaymentID:  evt.PaymentID,
+		TotalPrice: evt.TotalPrice,
+		SeriesID:   evt.SeriesID,
+	}
+	for _, line := range evt.PetLines {
+		lineRead := SchedulePetLineRead{
+			PetID:           line.PetID,
+			PetName:         line.PetName,
+			Species:         line.Species,
+			Breed:           line.Breed,
+			DurationMinutes: line.DurationMinutes,
+			Price:           line.Price,
+			Status:          line.Status,
+		}
+		for _, svc := range line.Services {
+			lineRead.Services = append(lineRead.Services, BookedServiceRead{
+				ServiceID: svc.ServiceID,
+				Name:      svc.Name,
+			})
+		}
+		schedule.PetLines = append(schedule.PetLines, lineRead)
 	}
 	
 	fmt.Printf("📝 HandleScheduleCreated - Creating schedule with UserID: %s, ShopID: %s, PetID: %s\n", 
@@ -313,6 +606,7 @@ func (p *MongoScheduleProjection) HandleScheduleStatusChanged(ctx context.Contex
 			"status":     evt.NewStatus,
 			"updated_at": evt.Timestamp,
 		},
+		"$inc": bson.M{"sequence": 1},
 	}
 	
 	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
@@ -330,6 +624,7 @@ func (p *MongoScheduleProjection) HandleScheduleCompleted(ctx context.Context, e
 			"status":     "completed",
 			"updated_at": evt.Timestamp,
 		},
+		"$inc": bson.M{"sequence": 1},
 	}
 	
 	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
@@ -348,6 +643,7 @@ func (p *MongoScheduleProjection) HandleScheduleCancelled(ctx context.Context, e
 			"is_active":  false,
 			"updated_at": evt.Timestamp,
 		},
+		"$inc": bson.M{"sequence": 1},
 	}
 	
 	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
@@ -358,3 +654,272 @@ func (p *MongoScheduleProjection) HandleScheduleCancelled(ctx context.Context, e
 	return nil
 	
 }
+
+// HandleScheduleCareBriefShared handles ScheduleCareBriefShared event
+func (p *MongoScheduleProjection) HandleScheduleCareBriefShared(ctx context.Context, evt event.ScheduleCareBriefShared) error {
+	update := bson.M{
+		"$push": bson.M{
+			"care_brief_consents": CareBriefConsentRead{
+				PetID:    evt.PetID,
+				SharedBy: evt.SharedBy,
+				SharedAt: evt.Timestamp,
+			},
+		},
+		"$set": bson.M{"updated_at": evt.Timestamp},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to share schedule care brief: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleCareBriefRevoked handles ScheduleCareBriefRevoked event
+func (p *MongoScheduleProjection) HandleScheduleCareBriefRevoked(ctx context.Context, evt event.ScheduleCareBriefRevoked) error {
+	update := bson.M{
+		"$pull":  bson.M{"care_brief_consents": bson.M{"pet_id": evt.PetID}},
+		"$unset": bson.M{"care_brief_consent": ""}, // Consent stored before it was kept per pet
+		"$set":   bson.M{"updated_at": evt.Timestamp},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to revoke schedule care brief: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleVisitReportSubmitted handles ScheduleVisitReportSubmitted event
+func (p *MongoScheduleProjection) HandleScheduleVisitReportSubmitted(ctx context.Context, evt event.ScheduleVisitReportSubmitted) error {
+	report := VisitReportRead{
+		ID:                evt.Report.ID,
+		ServicesPerformed: []VisitReportServiceRead{},
+		Observations:      evt.Report.Observations,
+		Weight:            evt.Report.Weight,
+		PhotoURLs:         evt.Report.PhotoURLs,
+		Recommendations:   evt.Report.Recommendations,
+		SubmittedBy:       evt.Report.SubmittedBy,
+		SubmittedAt:       evt.Report.SubmittedAt,
+	}
+	for _, svc := range evt.Report.ServicesPerformed {
+		report.ServicesPerformed = append(report.ServicesPerformed, VisitReportServiceRead{
+			ServiceID: svc.ServiceID,
+			Name:      svc.Name,
+			Notes:     svc.Notes,
+		})
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"visit_report": report,
+			"updated_at":   evt.Timestamp,
+		},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to add schedule visit report: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleVisitReportAccepted handles ScheduleVisitReportAccepted event
+func (p *MongoScheduleProjection) HandleScheduleVisitReportAccepted(ctx context.Context, evt event.ScheduleVisitReportAccepted) error {
+	update := bson.M{
+		"$push": bson.M{
+			"visit_report.medical_records": VisitReportRecordRead{
+				PetID:           evt.PetID,
+				MedicalRecordID: evt.MedicalRecordID,
+				AcceptedBy:      evt.AcceptedBy,
+				AcceptedAt:      evt.Timestamp,
+			},
+		},
+		"$set": bson.M{"updated_at": evt.Timestamp},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to accept schedule visit report: %w", err)
+	}
+
+	// The report counts as accepted from the first pet accepted into its medical history
+	firstAccepted := bson.M{
+		"$set": bson.M{
+			"visit_report.accepted_by":       evt.AcceptedBy,
+			"visit_report.accepted_at":       evt.Timestamp,
+			"visit_report.medical_record_id": evt.MedicalRecordID,
+		},
+	}
+	_, err = p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID, "visit_report.accepted_by": bson.M{"$exists": false}}, firstAccepted)
+	if err != nil {
+		return fmt.Errorf("failed to accept schedule visit report: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleRescheduled handles ScheduleRescheduled event
+func (p *MongoScheduleProjection) HandleScheduleRescheduled(ctx context.Context, evt event.ScheduleRescheduled) error {
+	update := bson.M{
+		"$set": bson.M{
+			"start_time":       evt.NewStartTime,
+			"end_time":         evt.NewEndTime,
+			"total_price":      evt.NewPrice,
+			"reschedule_count": evt.RescheduleCount,
+			"updated_at":       evt.Timestamp,
+		},
+		"$unset": bson.M{"reschedule_proposal": ""},
+		"$inc":   bson.M{"sequence": 1},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule schedule: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleRescheduleProposed handles ScheduleRescheduleProposed event
+func (p *MongoScheduleProjection) HandleScheduleRescheduleProposed(ctx context.Context, evt event.ScheduleRescheduleProposed) error {
+	update := bson.M{
+		"$set": bson.M{
+			"reschedule_proposal": RescheduleProposalRead{
+				ID:         evt.Proposal.ID,
+				StartTime:  evt.Proposal.StartTime,
+				EndTime:    evt.Proposal.EndTime,
+				Reason:     evt.Proposal.Reason,
+				ProposedBy: evt.Proposal.ProposedBy,
+				ProposedAt: evt.Proposal.ProposedAt,
+			},
+			"updated_at": evt.Timestamp,
+		},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to add schedule reschedule proposal: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleRescheduleDeclined handles ScheduleRescheduleDeclined event
+func (p *MongoScheduleProjection) HandleScheduleRescheduleDeclined(ctx context.Context, evt event.ScheduleRescheduleDeclined) error {
+	update := bson.M{
+		"$unset": bson.M{"reschedule_proposal": ""},
+		"$set":   bson.M{"updated_at": evt.Timestamp},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to decline schedule reschedule proposal: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleRescheduleReverted handles ScheduleRescheduleReverted event
+func (p *MongoScheduleProjection) HandleScheduleRescheduleReverted(ctx context.Context, evt event.ScheduleRescheduleReverted) error {
+	update := bson.M{
+		"$set": bson.M{
+			"start_time":       evt.NewStartTime,
+			"end_time":         evt.NewEndTime,
+			"total_price":      evt.NewPrice,
+			"reschedule_count": evt.RescheduleCount,
+			"updated_at":       evt.Timestamp,
+		},
+		"$inc": bson.M{"sequence": 1},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to revert schedule reschedule: %w", err)
+	}
+
+	return nil
+}
+
+// HandleSchedulePriceAdjusted handles SchedulePriceAdjusted event
+func (p *MongoScheduleProjection) HandleSchedulePriceAdjusted(ctx context.Context, evt event.SchedulePriceAdjusted) error {
+	update := bson.M{
+		"$push": bson.M{
+			"price_adjustments": SchedulePriceAdjustmentRead{
+				ID:        evt.Adjustment.ID,
+				Kind:      evt.Adjustment.Kind,
+				OldPrice:  evt.Adjustment.OldPrice,
+				NewPrice:  evt.Adjustment.NewPrice,
+				Amount:    evt.Adjustment.Amount,
+				PaymentID: evt.Adjustment.PaymentID,
+				RefundID:  evt.Adjustment.RefundID,
+				Reason:    evt.Adjustment.Reason,
+				CreatedAt: evt.Adjustment.CreatedAt,
+			},
+		},
+		"$set": bson.M{"updated_at": evt.Timestamp},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to add schedule price adjustment: %w", err)
+	}
+
+	return nil
+}
+
+// HandleSchedulePetCancelled handles SchedulePetCancelled event
+func (p *MongoScheduleProjection) HandleSchedulePetCancelled(ctx context.Context, evt event.SchedulePetCancelled) error {
+	update := bson.M{
+		"$set": bson.M{
+			"pet_lines.$.status":       event.SchedulePetLineCancelled,
+			"pet_lines.$.cancelled_at": evt.Timestamp,
+			"pet_id":                   evt.PrimaryPet.PetID,
+			"assigned_pet": AssignedPetRead{
+				PetID:   evt.PrimaryPet.PetID,
+				Name:    evt.PrimaryPet.Name,
+				Species: evt.PrimaryPet.Species,
+				Breed:   evt.PrimaryPet.Breed,
+				Age:     evt.PrimaryPet.Age,
+				Weight:  evt.PrimaryPet.Weight,
+			},
+			"booked_shop.booked_services": convertToBookedServiceRead(evt.BookedServices),
+			"total_price":                 evt.NewPrice,
+			"updated_at":                  evt.Timestamp,
+		},
+		"$pull": bson.M{"care_brief_consents": bson.M{"pet_id": evt.PetID}},
+		"$inc":  bson.M{"sequence": 1},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID, "pet_lines.pet_id": evt.PetID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to cancel schedule pet: %w", err)
+	}
+
+	return nil
+}
+
+// HandleScheduleStaffAssigned handles ScheduleStaffAssigned event
+func (p *MongoScheduleProjection) HandleScheduleStaffAssigned(ctx context.Context, evt event.ScheduleStaffAssigned) error {
+	update := bson.M{
+		"$set": bson.M{
+			"assigned_staff": AssignedStaffRead{
+				UserID:       evt.StaffID,
+				AssignedBy:   evt.AssignedBy,
+				AutoAssigned: evt.AutoAssigned,
+				AssignedAt:   evt.Timestamp,
+			},
+			"updated_at": evt.Timestamp,
+		},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ScheduleID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update schedule staff assignment: %w", err)
+	}
+
+	return nil
+}
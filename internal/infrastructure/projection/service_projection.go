@@ -25,6 +25,16 @@ type ServiceReadModel struct {
 	IsActive    bool      `bson:"is_active" json:"is_active"`
 	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+
+	Eligibility *ServiceEligibilityView `bson:"eligibility,omitempty" json:"eligibility,omitempty"` // Missing = accepts all pets
+}
+
+// ServiceEligibilityView describes which pets a service accepts; empty lists and zero weights mean no restriction
+type ServiceEligibilityView struct {
+	Species   []string `bson:"species" json:"species"` // Catalogue species codes
+	Sizes     []string `bson:"sizes" json:"sizes"`
+	MinWeight float64  `bson:"min_weight" json:"min_weight"` // Kilograms
+	MaxWeight float64  `bson:"max_weight" json:"max_weight"` // Kilograms
 }
 
 // MongoServiceProjection implements ServiceProjection using MongoDB
@@ -226,3 +236,25 @@ func (p *MongoServiceProjection) HandleServiceImageUpdated(ctx context.Context,
 	
 	return nil
 }
+
+// HandleServiceEligibilityUpdated handles ServiceEligibilityUpdated event
+func (p *MongoServiceProjection) HandleServiceEligibilityUpdated(ctx context.Context, evt *event.ServiceEligibilityUpdated) error {
+	update := bson.M{
+		"$set": bson.M{
+			"eligibility": ServiceEligibilityView{
+				Species:   evt.Species,
+				Sizes:     evt.Sizes,
+				MinWeight: evt.MinWeight,
+				MaxWeight: evt.MaxWeight,
+			},
+			"updated_at": evt.Timestamp,
+		},
+	}
+
+	_, err := p.collection.UpdateOne(ctx, bson.M{"_id": evt.ServiceID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update service eligibility: %w", err)
+	}
+
+	return nil
+}
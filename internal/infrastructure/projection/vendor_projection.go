@@ -21,10 +21,18 @@ type VendorReadModel struct {
 	Address   string    `bson:"address" json:"address"`
 	ImageUrl  string    `bson:"image_url" json:"image_url,omitempty"`
 	IsActive  bool      `bson:"is_active" json:"is_active"`
+	PaymentMethods []string `bson:"payment_methods,omitempty" json:"payment_methods,omitempty"` // Written by the vendor repository
+	ReschedulePolicy *ReschedulePolicyRead `bson:"reschedule_policy,omitempty" json:"reschedule_policy,omitempty"` // Written by the vendor repository
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
 }
 
+// ReschedulePolicyRead is how late and how often customers may reschedule bookings with a vendor
+type ReschedulePolicyRead struct {
+	CutoffHours    int `bson:"cutoff_hours" json:"cutoff_hours"`
+	MaxReschedules int `bson:"max_reschedules" json:"max_reschedules"`
+}
+
 // MongoVendorProjection implements VendorProjection using MongoDB
 type MongoVendorProjection struct {
 	collection *mongo.Collection
@@ -76,7 +76,7 @@ func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
 
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		clientIP := getClientIP(r)
+		clientIP := GetClientIP(r)
 		now := time.Now()
 
 		// Clean old requests
@@ -178,8 +178,8 @@ func generateRequestID() string {
 	return hex.EncodeToString(bytes)
 }
 
-// getClientIP extracts the client IP address from the request
-func getClientIP(r *http.Request) string {
+// GetClientIP extracts the client IP address from the request
+func GetClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header first
 	if xForwardedFor := r.Header.Get("X-Forwarded-For"); xForwardedFor != "" {
 		// X-Forwarded-For can contain multiple IPs, take the first one
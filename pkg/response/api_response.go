@@ -27,10 +27,12 @@ type ApiError struct {
 
 // Meta contains metadata about the response
 type Meta struct {
-	Page       int `json:"page,omitempty"`
-	Limit      int `json:"limit,omitempty"`
-	Total      int `json:"total,omitempty"`
-	TotalPages int `json:"total_pages,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Total      int    `json:"total,omitempty"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor of the next page for cursor-paginated lists
+	HasMore    bool   `json:"has_more,omitempty"`
 }
 
 // ValidationError represents validation error details